@@ -2,46 +2,94 @@ package app
 
 import (
 	"context"
+	"sync"
 
 	"github.com/TheFellow/go-modular-monolith/app/domains/audit"
 	"github.com/TheFellow/go-modular-monolith/app/domains/drinks"
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog"
 	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients"
 	"github.com/TheFellow/go-modular-monolith/app/domains/inventory"
 	"github.com/TheFellow/go-modular-monolith/app/domains/menus"
 	"github.com/TheFellow/go-modular-monolith/app/domains/orders"
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox"
 	"github.com/TheFellow/go-modular-monolith/app/domains/tagging"
+	"github.com/TheFellow/go-modular-monolith/pkg/changes"
 	"github.com/TheFellow/go-modular-monolith/pkg/dispatcher"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/idempotency"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/migrate"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
+	"github.com/TheFellow/go-modular-monolith/pkg/undo"
+	cedar "github.com/cedar-policy/cedar-go"
 )
 
 type App struct {
-	Store *store.Store
-	Tags  *tagging.Module
+	Store   *store.Store
+	Tags    *tagging.Module
+	Changes *changes.Feed
 
 	Audit       *audit.Module
 	Drinks      *drinks.Module
+	EventLog    *eventlog.Module
 	Ingredients *ingredients.Module
 	Inventory   *inventory.Module
 	Menus       *menus.Module
 	Orders      *orders.Module
+	Outbox      *outbox.Module
+
+	Migrations *migrate.Migrator
+
+	compensations *undo.Registry
+	targets       *tagging.Registry
+	defaults      Defaults
+
+	collectorMu   sync.Mutex
+	stopCollector func()
 }
 
 // New constructs the application around a required store. Domain modules
-// register their private persistence models before New returns.
-func New(ctx context.Context, config Config) *App {
+// register their private persistence models, and then pending data migrations
+// run, before New returns. New fails without changing anything when the
+// database was migrated by a newer binary.
+func New(ctx context.Context, config Config) (*App, error) {
 	s := config.Store
 	audit.RegisterSchema(ctx, s)
+	eventlog.RegisterSchema(ctx, s)
+	outbox.RegisterSchema(ctx, s)
 	tagging.RegisterSchema(ctx, s)
+	idempotency.Register(ctx, s)
+	migrate.Register(ctx, s)
 	tags := tagging.NewRepository(s)
 	targets := tagging.NewRegistry()
 	auditWriter := audit.NewWriter(s)
+	feed := changes.NewFeed(func(ctx store.Context, uid cedar.EntityUID) (changes.Resource, error) {
+		target, state, err := targets.Load(ctx, uid)
+		if errors.IsNotFound(err) {
+			target, state, err = targets.LoadDeleted(ctx, uid)
+		}
+		if err != nil {
+			return changes.Resource{}, err
+		}
+		return changes.Resource{Entity: state.Entity, GetAction: target.GetAction}, nil
+	})
+	redact := config.Redact
+	if redact == nil {
+		redact = RedactCosts
+	}
 	pipeline := middleware.NewPipeline(middleware.PipelineConfig{
 		Store:          s,
 		Dispatcher:     dispatcher.New(s, tags),
 		Metrics:        telemetry.FromContext(ctx),
 		RecordActivity: auditWriter.RecordActivity,
+		ActivityID:     audit.NewActivityID,
+		Events:         eventlog.NewRecorder(s),
+		Changes:        feed,
+		Publisher:      outbox.NewPublisher(s),
+		Idempotency:    idempotency.New(s),
+		Clock:          config.Clock,
+		Redact:         redact,
 	})
 
 	drinksModule := drinks.NewModule(ctx, s, tags, targets, pipeline)
@@ -49,19 +97,53 @@ func New(ctx context.Context, config Config) *App {
 	inventoryModule := inventory.NewModule(ctx, s, tags, targets, pipeline)
 	menusModule := menus.NewModule(ctx, s, tags, targets, pipeline)
 	ordersModule := orders.NewModule(ctx, s, tags, targets, pipeline)
+	tagsModule := tagging.NewModule(tags, targets, pipeline)
+
+	migrations, err := migrate.New(s,
+		audit.Migrations(),
+		drinks.Migrations(),
+		ingredients.Migrations(),
+		inventory.Migrations(),
+		menus.Migrations(),
+		orders.Migrations(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if config.DeferMigrations || s.ReadOnly() {
+		err = migrations.Check(ctx)
+	} else {
+		_, err = migrations.Up(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
 
 	return &App{
 		Store:       s,
-		Tags:        tagging.NewModule(tags, targets, pipeline),
+		Tags:        tagsModule,
+		Changes:     feed,
 		Audit:       audit.NewModule(s, pipeline),
 		Drinks:      drinksModule,
+		EventLog:    eventlog.NewModule(s, pipeline),
 		Ingredients: ingredientsModule,
 		Inventory:   inventoryModule,
 		Menus:       menusModule,
 		Orders:      ordersModule,
-	}
+		Outbox:      outbox.NewModule(s, pipeline),
+		Migrations:  migrations,
+		compensations: undo.NewRegistry(
+			inventoryModule.Compensations(),
+			menusModule.Compensations(),
+			tagsModule.Compensations(),
+		),
+		targets:  targets,
+		defaults: config.Defaults.orBuiltIn(),
+	}, nil
 }
 
+// Close stops the metrics collector, if one is running, and closes the store.
 func (a *App) Close() error {
+	a.stopMetricsCollector()
 	return a.Store.Close()
 }
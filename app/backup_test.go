@@ -0,0 +1,88 @@
+package app_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app"
+	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients"
+	ingredientsmodels "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/teststore"
+)
+
+func TestApp_BackupVerifiesSnapshotsAndAppliesRetention(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	dir := filepath.Join(t.TempDir(), "backups")
+	testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{
+		Name: "Backup Gin", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitMl,
+	})
+
+	first, err := f.App.Backup(f.OwnerContext(), app.BackupOptions{Dir: dir, Keep: 1})
+	testutil.Ok(t, err)
+	testutil.Equals(t, first.Verification.Records["IngredientRow"], 1)
+	testutil.IsTrue(t, first.Verification.AuditEntries >= 1)
+	testutil.IsTrue(t, first.Verification.AuditHead != "")
+	testutil.Equals(t, len(first.Pruned), 0)
+
+	second, err := f.App.Backup(f.OwnerContext(), app.BackupOptions{Dir: dir, Keep: 1})
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(second.Pruned), 1)
+	testutil.Equals(t, second.Pruned[0].Name, first.Backup.Name)
+	backups, err := store.ListBackups(dir)
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(backups), 1)
+	testutil.Equals(t, backups[0].Name, second.Backup.Name)
+}
+
+func TestApp_BackupDiscardsSnapshotThatFailsVerification(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	dir := filepath.Join(t.TempDir(), "backups")
+
+	// Only the owner may verify the audit chain, so a bartender's snapshot
+	// cannot be declared good and must not be kept.
+	_, err := f.App.Backup(f.ActorContext("bartender"), app.BackupOptions{Dir: dir})
+	testutil.ErrorIsPermission(t, err)
+	backups, err := store.ListBackups(dir)
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(backups), 0)
+
+	corrupt := teststore.Path(t, "corrupt")
+	testutil.Ok(t, os.WriteFile(corrupt, []byte("not a database"), 0o600))
+	_, err = f.App.VerifyBackup(f.OwnerContext(), corrupt)
+	testutil.IsTrue(t, errors.IsFailedPrecondition(err))
+}
+
+func TestApp_RestoreReplacesLiveDatabase(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	kept := testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{
+		Name: "Kept Rum", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitMl,
+	})
+	result, err := f.App.Backup(f.OwnerContext(), app.BackupOptions{Dir: filepath.Join(t.TempDir(), "backups")})
+	testutil.Ok(t, err)
+	testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{
+		Name: "Discarded Vodka", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitMl,
+	})
+
+	path := f.Store.Path()
+	_, err = f.App.Restore(f.OwnerContext(), result.Backup.Path)
+	testutil.Ok(t, err)
+	testutil.Ok(t, f.Close())
+
+	s, err := store.Open(f.OwnerContext(), path)
+	testutil.Ok(t, err)
+	restored, err := app.New(f.OwnerContext(), app.Config{Store: s})
+	testutil.Ok(t, err)
+	t.Cleanup(func() { _ = restored.Close() })
+	page, err := restored.Ingredients.List(f.OwnerContext(), ingredients.ListRequest{})
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(page.Items), 1)
+	testutil.Equals(t, page.Items[0].ID, kept.ID)
+}
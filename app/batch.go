@@ -0,0 +1,124 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/log"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// BatchStep is one domain command of a batch. Name describes the step in
+// errors and results. Alias, when set, names the entity the step produced so
+// later steps can refer to it through BatchResults.
+type BatchStep struct {
+	Name  string
+	Alias string
+	Run   func(ctx *middleware.Context, results BatchResults) (cedar.EntityUID, error)
+}
+
+// BatchResult is the entity a completed step produced. CorrelationID is the
+// same for every step of a batch and marks their audit entries, log records,
+// and recorded events, so the batch can be listed as one unit.
+type BatchResult struct {
+	Name          string
+	Alias         string
+	Entity        cedar.EntityUID
+	CorrelationID string
+}
+
+// BatchResults holds the entities produced so far, keyed by alias.
+type BatchResults map[string]cedar.EntityUID
+
+// Entity returns the entity an earlier step produced under alias.
+func (r BatchResults) Entity(alias string) (cedar.EntityUID, error) {
+	uid, ok := r[alias]
+	if !ok {
+		return cedar.EntityUID{}, errors.Invalidf("unknown batch alias %q", alias)
+	}
+	return uid, nil
+}
+
+// Batch runs every step in one transaction. Each step is an ordinary command,
+// so its event handlers and audit entry join the same transaction and the
+// audit log gains the whole batch at once. The steps share a correlation ID of
+// their own, which ties their audit entries together; a log line under the
+// caller's ID names it. The first failure rolls back every step, leaving
+// neither entities nor audit entries behind.
+func (a *App) Batch(ctx *middleware.Context, steps []BatchStep) ([]BatchResult, error) {
+	if len(steps) == 0 {
+		return nil, errors.Invalidf("batch has no steps")
+	}
+	aliases := make(map[string]bool, len(steps))
+	for i, step := range steps {
+		if step.Run == nil {
+			return nil, errors.Invalidf("batch step %d (%s) has nothing to run", i+1, step.Name)
+		}
+		if step.Alias == "" {
+			continue
+		}
+		if aliases[step.Alias] {
+			return nil, errors.Invalidf("batch alias %q is used by more than one step", step.Alias)
+		}
+		aliases[step.Alias] = true
+	}
+
+	batchCtx := ctx.WithNewCorrelationID()
+	log.FromContext(ctx).Info("running batch", slog.Int("steps", len(steps)), slog.String("batch_correlation_id", batchCtx.CorrelationID()))
+	ctx = batchCtx
+	run := func(txCtx *middleware.Context) ([]BatchResult, error) {
+		results := make([]BatchResult, 0, len(steps))
+		produced := make(BatchResults, len(aliases))
+		for i, step := range steps {
+			uid, err := step.Run(txCtx, produced)
+			if err != nil {
+				return nil, stepError(i+1, step.Name, err)
+			}
+			if step.Alias != "" {
+				produced[step.Alias] = uid
+			}
+			results = append(results, BatchResult{Name: step.Name, Alias: step.Alias, Entity: uid, CorrelationID: txCtx.CorrelationID()})
+		}
+		return results, nil
+	}
+
+	// Like RunTaggedMutation, a caller-owned transaction keeps commit and
+	// rollback ownership with the caller.
+	if tx, ok := ctx.Transaction(); ok && tx != nil {
+		return run(ctx)
+	}
+	var results []BatchResult
+	err := a.Store.Write(ctx, func(tx *store.Tx) error {
+		var err error
+		results, err = run(ctx.WithTransaction(tx))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// stepError names the failing step while keeping the error's kind, which
+// decides exit codes and how surfaces present it.
+func stepError(step int, name string, err error) error {
+	const format = "batch step %d (%s): %w"
+	switch {
+	case errors.IsInvalid(err):
+		return errors.Invalidf(format, step, name, err)
+	case errors.IsNotFound(err):
+		return errors.NotFoundf(format, step, name, err)
+	case errors.IsPermission(err):
+		return errors.Permissionf(format, step, name, err)
+	case errors.IsConflict(err):
+		return errors.Conflictf(format, step, name, err)
+	case errors.IsFailedPrecondition(err):
+		return errors.FailedPreconditionf(format, step, name, err)
+	case errors.IsInternal(err):
+		return errors.Internalf(format, step, name, err)
+	}
+	return fmt.Errorf(format, step, name, err)
+}
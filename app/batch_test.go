@@ -0,0 +1,117 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app"
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit"
+	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients"
+	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	menumodels "github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/paging"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+func createIngredientStep(f *testutil.Fixture, alias, name string, category models.Category) app.BatchStep {
+	return app.BatchStep{Name: "ingredients.create", Alias: alias, Run: func(ctx *middleware.Context, _ app.BatchResults) (cedar.EntityUID, error) {
+		created, err := f.App.Ingredients.Create(ctx, &models.Ingredient{Name: name, Category: category, Unit: "oz"})
+		if err != nil {
+			return cedar.EntityUID{}, err
+		}
+		return created.EntityUID(), nil
+	}}
+}
+
+func TestBatchPassesEarlierResultsToLaterSteps(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	var seen entity.IngredientID
+
+	results, err := f.App.Batch(f.OwnerContext(), []app.BatchStep{
+		createIngredientStep(f, "gin", "Batch Gin", models.CategorySpirit),
+		{Name: "ingredients.get", Run: func(ctx *middleware.Context, produced app.BatchResults) (cedar.EntityUID, error) {
+			uid, err := produced.Entity("gin")
+			if err != nil {
+				return cedar.EntityUID{}, err
+			}
+			// Reads inside the batch observe its uncommitted writes.
+			got, err := f.App.Ingredients.Get(ctx, entity.IngredientID(uid))
+			if err != nil {
+				return cedar.EntityUID{}, err
+			}
+			seen = got.ID
+			return uid, nil
+		}},
+	})
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(results), 2)
+	testutil.Equals(t, results[0].Alias, "gin")
+	testutil.Equals(t, seen, entity.IngredientID(results[0].Entity))
+}
+
+func TestBatchRollsBackEarlierStepsWhenOneFails(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+
+	_, err := f.App.Batch(f.OwnerContext(), []app.BatchStep{
+		createIngredientStep(f, "gin", "Kept Nowhere", models.CategorySpirit),
+		{Name: "menus.publish", Run: func(ctx *middleware.Context, _ app.BatchResults) (cedar.EntityUID, error) {
+			published, err := f.App.Menus.Publish(ctx, &menumodels.Menu{ID: entity.NewMenuID()})
+			if err != nil {
+				return cedar.EntityUID{}, err
+			}
+			return published.EntityUID(), nil
+		}},
+	})
+	testutil.ErrorIsNotFound(t, err)
+	testutil.StringContains(t, err.Error(), "batch step 2 (menus.publish)")
+
+	page, err := f.App.Ingredients.List(f.OwnerContext(), ingredients.ListRequest{Filter: `name == "Kept Nowhere"`, Limit: paging.DefaultLimit})
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(page.Items), 0)
+}
+
+func TestBatchRejectsDuplicateAliasesBeforeRunning(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+
+	_, err := f.App.Batch(f.OwnerContext(), []app.BatchStep{
+		createIngredientStep(f, "gin", "First Gin", models.CategorySpirit),
+		createIngredientStep(f, "gin", "Second Gin", models.CategorySpirit),
+	})
+	testutil.ErrorIsInvalid(t, err)
+	page, err := f.App.Ingredients.List(f.OwnerContext(), ingredients.ListRequest{Filter: `name == "First Gin"`, Limit: paging.DefaultLimit})
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(page.Items), 0)
+}
+
+func TestBatchStepsShareACorrelationIDTheAuditCanListBy(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	ctx := f.OwnerContext()
+	_, err := f.App.Ingredients.Create(ctx, &models.Ingredient{Name: "Outside Gin", Category: models.CategorySpirit, Unit: "oz"})
+	testutil.Ok(t, err)
+
+	results, err := f.App.Batch(ctx, []app.BatchStep{
+		createIngredientStep(f, "gin", "Batched Gin", models.CategorySpirit),
+		createIngredientStep(f, "rum", "Batched Rum", models.CategorySpirit),
+	})
+	testutil.Ok(t, err)
+	batch := results[0].CorrelationID
+	testutil.IsTrue(t, batch != "" && batch != ctx.CorrelationID())
+	testutil.Equals(t, results[1].CorrelationID, batch)
+
+	page, err := f.App.Audit.List(ctx, audit.ListRequest{Filter: `correlation_id == "` + batch + `"`, Limit: paging.DefaultLimit})
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(page.Items), 2)
+	listed := map[cedar.EntityUID]bool{}
+	for _, entry := range page.Items {
+		for _, uid := range entry.Touches {
+			listed[uid] = true
+		}
+	}
+	testutil.Equals(t, listed, map[cedar.EntityUID]bool{results[0].Entity: true, results[1].Entity: true})
+}
@@ -0,0 +1,155 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app"
+	drinksauthz "github.com/TheFellow/go-modular-monolith/app/domains/drinks/authz"
+	drinksmodels "github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
+	"github.com/TheFellow/go-modular-monolith/pkg/changes"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+func TestChangesReauthorizeEachEntityForTheSubscriber(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	drinkTypes := changes.Filter{Types: []cedar.EntityType{entity.TypeDrink}}
+	sommelier := f.App.Changes.Subscribe(f.ActorContext("sommelier"), drinkTypes)
+	bartender := f.App.Changes.Subscribe(f.ActorContext("bartender"), drinkTypes)
+	t.Cleanup(sommelier.Close)
+	t.Cleanup(bartender.Close)
+
+	cocktail := createDrink(t, f, "Negroni", drinksmodels.DrinkCategoryCocktail)
+	wine := createDrink(t, f, "Claret", drinksmodels.DrinkCategoryWine)
+
+	seen := receiveChanges(sommelier)
+	testutil.Equals(t, len(seen), 1)
+	testutil.Equals(t, seen[0].Entities, []cedar.EntityUID{wine.ID.EntityUID()})
+	seen = receiveChanges(bartender)
+	testutil.Equals(t, len(seen), 1)
+	testutil.Equals(t, seen[0].Entities, []cedar.EntityUID{cocktail.ID.EntityUID()})
+	testutil.IsTrue(t, seen[0].Touches(entity.TypeDrink))
+}
+
+func TestChangesAnnounceSoftDeletesToSubscribersThatCouldReadThem(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	drinkTypes := changes.Filter{Types: []cedar.EntityType{entity.TypeDrink}}
+	sommelier := f.App.Changes.Subscribe(f.ActorContext("sommelier"), drinkTypes)
+	bartender := f.App.Changes.Subscribe(f.ActorContext("bartender"), drinkTypes)
+	t.Cleanup(sommelier.Close)
+	t.Cleanup(bartender.Close)
+
+	cocktail := createDrink(t, f, "Sazerac", drinksmodels.DrinkCategoryCocktail)
+	wine := createDrink(t, f, "Rioja", drinksmodels.DrinkCategoryWine)
+	receiveChanges(sommelier)
+	receiveChanges(bartender)
+
+	_, err := f.App.Drinks.Delete(f.OwnerContext(), cocktail.ID)
+	testutil.Ok(t, err)
+	_, err = f.App.Drinks.Delete(f.OwnerContext(), wine.ID)
+	testutil.Ok(t, err)
+
+	seen := receiveChanges(sommelier)
+	testutil.Equals(t, len(seen), 1)
+	testutil.Equals(t, seen[0].Action, drinksauthz.ActionDelete)
+	testutil.Equals(t, seen[0].Entities, []cedar.EntityUID{wine.ID.EntityUID()})
+	seen = receiveChanges(bartender)
+	testutil.Equals(t, len(seen), 1)
+	testutil.Equals(t, seen[0].Entities, []cedar.EntityUID{cocktail.ID.EntityUID()})
+}
+
+func TestChangesFilterByEntityType(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	menus := f.App.Changes.Subscribe(f.ActorContext("manager"), changes.Filter{Types: []cedar.EntityType{entity.TypeMenu}})
+	all := f.App.Changes.Subscribe(f.ActorContext("manager"), changes.Filter{})
+	t.Cleanup(menus.Close)
+	t.Cleanup(all.Close)
+
+	createDrink(t, f, "Paloma", drinksmodels.DrinkCategoryHighball)
+
+	testutil.Equals(t, len(receiveChanges(menus)), 0)
+	seen := receiveChanges(all)
+	testutil.Equals(t, len(seen), 2)
+	testutil.IsTrue(t, seen[0].Touches(entity.TypeIngredient))
+	testutil.IsTrue(t, seen[1].Touches(entity.TypeDrink))
+}
+
+func TestChangesAreDeliveredOnlyAfterTheOwningTransactionCommits(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	sub := f.App.Changes.Subscribe(f.ActorContext("manager"), changes.Filter{})
+	t.Cleanup(sub.Close)
+	desired := tag.Tags{{Key: "region", Value: "west"}}
+
+	var staged int
+	result, err := app.RunTaggedMutation(f.App.App, f.OwnerContext(), &desired, func(ctx *middleware.Context) (*taggedIngredient, error) {
+		created, err := f.App.Ingredients.Create(ctx, &models.Ingredient{Name: "Feed", Category: models.CategorySpirit, Unit: "oz"})
+		if err != nil {
+			return nil, err
+		}
+		staged = len(receiveChanges(sub))
+		return &taggedIngredient{ID: created.ID}, nil
+	})
+	testutil.Ok(t, err)
+	testutil.Equals(t, staged, 0)
+	seen := receiveChanges(sub)
+	testutil.Equals(t, len(seen), 2)
+	testutil.Equals(t, seen[0].Entities, []cedar.EntityUID{result.ID.EntityUID()})
+
+	_, err = app.RunTaggedMutation(f.App.App, f.OwnerContext(), &desired, func(ctx *middleware.Context) (*taggedIngredient, error) {
+		_, err := f.App.Ingredients.Create(ctx, &models.Ingredient{Name: "Rolled Back", Category: models.CategorySpirit, Unit: "oz"})
+		return &taggedIngredient{ID: entity.NewIngredientID()}, err
+	})
+	testutil.ErrorIf(t, err == nil, "expected tag replacement failure")
+	testutil.Equals(t, len(receiveChanges(sub)), 0)
+}
+
+func TestChangesSubscriptionEndsWhenClosed(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	sub := f.App.Subscribe(changes.Filter{})
+	sub.Close()
+	sub.Close()
+
+	createDrink(t, f, "Gimlet", drinksmodels.DrinkCategorySour)
+
+	_, open := <-sub.Changes()
+	testutil.IsFalse(t, open)
+}
+
+func createDrink(t *testing.T, f *testutil.Fixture, name string, category drinksmodels.DrinkCategory) *drinksmodels.Drink {
+	t.Helper()
+	base := testutil.CreateIngredient(t, f, models.Ingredient{Name: name + " Base", Category: models.CategorySpirit, Unit: "oz"})
+	return testutil.CreateDrink(t, f, drinksmodels.Drink{
+		Name: name, Category: category, Glass: drinksmodels.GlassTypeRocks,
+		Recipe: drinksmodels.Recipe{
+			Ingredients: []drinksmodels.RecipeIngredient{{IngredientID: base.ID, Amount: measurement.MustAmount(2, measurement.UnitOz)}},
+			Steps:       []string{"Stir"},
+		},
+	})
+}
+
+// receiveChanges drains what has been delivered so far. Delivery happens on
+// the committing goroutine, so everything committed is already queued.
+func receiveChanges(sub *changes.Subscription) []changes.Change {
+	var out []changes.Change
+	for {
+		select {
+		case change, ok := <-sub.Changes():
+			if !ok {
+				return out
+			}
+			out = append(out, change)
+		default:
+			return out
+		}
+	}
+}
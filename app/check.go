@@ -0,0 +1,429 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	drinksmodels "github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	ingredientsmodels "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	inventorymodels "github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
+	menusmodels "github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	ordersmodels "github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/set"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// Severity ranks a violation. Errors mean data other code relies on is wrong;
+// warnings mean derived or auxiliary data has drifted.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Invariant names a cross-domain rule the checker validates.
+type Invariant string
+
+const (
+	// InvariantReservedTotal: stock's reserved total equals the sum of the
+	// reservations held against it.
+	InvariantReservedTotal Invariant = "reserved-total"
+	// InvariantReservationStock: a reservation of an active ingredient draws
+	// on existing stock.
+	InvariantReservationStock Invariant = "reservation-stock"
+	// InvariantOrderReservations: every open order holds a reservation for
+	// each ingredient it uses, of the amount it uses.
+	InvariantOrderReservations Invariant = "order-reservations"
+	// InvariantStaleReservation: only open orders hold reservations.
+	InvariantStaleReservation Invariant = "stale-reservation"
+	// InvariantTagTarget: tags belong to entities that exist, retired or not.
+	InvariantTagTarget Invariant = "tag-target"
+	// InvariantRecipeIngredient: recipes and their substitutes reference
+	// existing or retired ingredients.
+	InvariantRecipeIngredient Invariant = "recipe-ingredient"
+	// InvariantMenuAvailability: a published menu records the availability
+	// the calculator derives from current stock.
+	InvariantMenuAvailability Invariant = "menu-availability"
+)
+
+// reservationTolerance absorbs float rounding from unit conversion.
+const reservationTolerance = 1e-9
+
+// CheckOptions controls an integrity check.
+type CheckOptions struct {
+	// Repair fixes safe violations through audited commands.
+	Repair bool
+}
+
+// Violation is one broken invariant. Repairable violations have a safe fix;
+// Repaired reports whether this check applied it.
+type Violation struct {
+	Invariant  Invariant
+	Severity   Severity
+	Entity     cedar.EntityUID
+	Message    string
+	Repairable bool
+	Repaired   bool
+
+	repair func(*middleware.Context) error
+}
+
+// CheckReport lists violations, errors first.
+type CheckReport struct {
+	Violations []Violation
+}
+
+// Outstanding counts the violations of severity that remain unrepaired.
+func (r CheckReport) Outstanding(severity Severity) int {
+	count := 0
+	for _, violation := range r.Violations {
+		if violation.Severity == severity && !violation.Repaired {
+			count++
+		}
+	}
+	return count
+}
+
+// Repaired counts the violations this check fixed.
+func (r CheckReport) Repaired() int {
+	count := 0
+	for _, violation := range r.Violations {
+		if violation.Repaired {
+			count++
+		}
+	}
+	return count
+}
+
+// Check validates invariants that span bounded contexts. Each domain's data is
+// read through its public export queries in one transaction, so the caller
+// must be allowed to export everything. With Repair, safe violations are fixed
+// in that transaction through audited commands: stale reservations are
+// released, menu availability is refreshed and tags of vanished entities are
+// purged. Other violations need a person to decide the fix. Without Repair the
+// check only reads, so it runs in a read transaction and works on a read-only
+// store.
+func (a *App) Check(ctx *middleware.Context, opts CheckOptions) (CheckReport, error) {
+	if tx, ok := ctx.Transaction(); ok && tx != nil {
+		return a.check(ctx, opts)
+	}
+	run := a.Store.Read
+	if opts.Repair {
+		run = a.Store.Write
+	}
+	var report CheckReport
+	err := run(ctx, func(tx *store.Tx) error {
+		var err error
+		report, err = a.check(ctx.WithTransaction(tx), opts)
+		return err
+	})
+	if err != nil {
+		return CheckReport{}, err
+	}
+	return report, nil
+}
+
+func (a *App) check(ctx *middleware.Context, opts CheckOptions) (CheckReport, error) {
+	snapshot, err := a.checkSnapshot(ctx)
+	if err != nil {
+		return CheckReport{}, err
+	}
+	var violations []Violation
+	violations = append(violations, snapshot.checkReservations()...)
+	violations = append(violations, snapshot.checkRecipes()...)
+	drift, err := a.checkMenus(ctx, snapshot.menus)
+	if err != nil {
+		return CheckReport{}, err
+	}
+	violations = append(violations, drift...)
+	tags, err := a.checkTags(ctx, snapshot)
+	if err != nil {
+		return CheckReport{}, err
+	}
+	violations = append(violations, tags...)
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].Severity != violations[j].Severity {
+			return violations[i].Severity == SeverityError
+		}
+		if violations[i].Invariant != violations[j].Invariant {
+			return violations[i].Invariant < violations[j].Invariant
+		}
+		return violations[i].Entity.String() < violations[j].Entity.String()
+	})
+	if opts.Repair {
+		for i := range violations {
+			if !violations[i].Repairable {
+				continue
+			}
+			if err := violations[i].repair(ctx); err != nil {
+				return CheckReport{}, err
+			}
+			violations[i].Repaired = true
+		}
+	}
+	return CheckReport{Violations: violations}, nil
+}
+
+type checkSnapshot struct {
+	ingredients  []*ingredientsmodels.Ingredient
+	drinks       []*drinksmodels.Drink
+	stock        []*inventorymodels.Inventory
+	reservations []inventorymodels.Reservation
+	menus        []*menusmodels.Menu
+	orders       []*ordersmodels.Order
+
+	release func(*middleware.Context, inventorymodels.Reservation) (*inventorymodels.Inventory, error)
+}
+
+func (a *App) checkSnapshot(ctx *middleware.Context) (*checkSnapshot, error) {
+	s := &checkSnapshot{release: a.Inventory.ReleaseReservation}
+	var err error
+	if s.ingredients, err = a.Ingredients.ExportAll(ctx); err != nil {
+		return nil, err
+	}
+	if s.drinks, err = a.Drinks.ExportAll(ctx); err != nil {
+		return nil, err
+	}
+	if s.stock, s.reservations, err = a.Inventory.ExportAll(ctx); err != nil {
+		return nil, err
+	}
+	if s.menus, err = a.Menus.ExportAll(ctx); err != nil {
+		return nil, err
+	}
+	if s.orders, err = a.Orders.ExportAll(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *checkSnapshot) checkReservations() []Violation {
+	stockByIngredient := make(map[string]*inventorymodels.Inventory, len(s.stock))
+	for _, stock := range s.stock {
+		stockByIngredient[stock.IngredientID.String()] = stock
+	}
+	retired := make(map[string]bool, len(s.ingredients))
+	for _, ingredient := range s.ingredients {
+		retired[ingredient.ID.String()] = ingredient.DeletedAt.IsSome()
+	}
+	ordersByID := make(map[string]*ordersmodels.Order, len(s.orders))
+	for _, order := range s.orders {
+		ordersByID[order.ID.String()] = order
+	}
+	held := make(map[string]inventorymodels.Reservation, len(s.reservations))
+	totals := make(map[string]float64, len(s.stock))
+
+	var violations []Violation
+	for _, reservation := range s.reservations {
+		held[reservation.OrderID.String()+":"+reservation.IngredientID.String()] = reservation
+		stock := stockByIngredient[reservation.IngredientID.String()]
+		if stock != nil {
+			amount, err := reservation.Amount.Convert(stock.Amount.Unit())
+			if err != nil {
+				violations = append(violations, Violation{
+					Invariant: InvariantReservedTotal, Severity: SeverityError, Entity: stock.EntityUID(),
+					Message: fmt.Sprintf("order %s reserves %s, which cannot be counted in the stock's %s", reservation.OrderID.String(), reservation.Amount.String(), stock.Amount.Unit()),
+				})
+			} else {
+				totals[stock.IngredientID.String()] += amount.Value()
+			}
+		}
+
+		order := ordersByID[reservation.OrderID.String()]
+		if order == nil || !orderIsOpen(order) || !orderUses(order, reservation) {
+			violation := Violation{
+				Invariant: InvariantStaleReservation, Severity: SeverityWarning, Entity: reservation.OrderID.EntityUID(),
+				Message: staleReservationMessage(order, reservation),
+			}
+			if stock != nil {
+				violation.Repairable = true
+				violation.repair = func(ctx *middleware.Context) error {
+					_, err := s.release(ctx, reservation)
+					return err
+				}
+			} else {
+				violation.Message += "; its stock no longer exists, so it cannot be released"
+			}
+			violations = append(violations, violation)
+			continue
+		}
+		if stock == nil && !retired[reservation.IngredientID.String()] {
+			violations = append(violations, Violation{
+				Invariant: InvariantReservationStock, Severity: SeverityError, Entity: reservation.OrderID.EntityUID(),
+				Message: fmt.Sprintf("reserves %s of ingredient %s, which has no stock", reservation.Amount.String(), reservation.IngredientID.String()),
+			})
+		}
+	}
+
+	for _, stock := range s.stock {
+		recorded := 0.0
+		if reserved := stock.ReservedAmount(); reserved != nil {
+			if converted, err := reserved.Convert(stock.Amount.Unit()); err == nil {
+				recorded = converted.Value()
+			}
+		}
+		if summed := totals[stock.IngredientID.String()]; math.Abs(recorded-summed) > reservationTolerance {
+			violations = append(violations, Violation{
+				Invariant: InvariantReservedTotal, Severity: SeverityError, Entity: stock.EntityUID(),
+				Message: fmt.Sprintf("reserved total is %g %s but its reservations sum to %g %s", recorded, stock.Amount.Unit(), summed, stock.Amount.Unit()),
+			})
+		}
+	}
+
+	for _, order := range s.orders {
+		if !orderIsOpen(order) {
+			continue
+		}
+		for _, usage := range order.IngredientUsage {
+			reservation, ok := held[order.ID.String()+":"+usage.IngredientID.String()]
+			if !ok {
+				violations = append(violations, Violation{
+					Invariant: InvariantOrderReservations, Severity: SeverityError, Entity: order.ID.EntityUID(),
+					Message: fmt.Sprintf("uses %s of ingredient %s but holds no reservation for it", usage.Amount.String(), usage.IngredientID.String()),
+				})
+				continue
+			}
+			amount, err := reservation.Amount.Convert(usage.Amount.Unit())
+			if err != nil || math.Abs(amount.Value()-usage.Amount.Value()) > reservationTolerance {
+				violations = append(violations, Violation{
+					Invariant: InvariantOrderReservations, Severity: SeverityError, Entity: order.ID.EntityUID(),
+					Message: fmt.Sprintf("uses %s of ingredient %s but reserves %s", usage.Amount.String(), usage.IngredientID.String(), reservation.Amount.String()),
+				})
+			}
+		}
+	}
+	return violations
+}
+
+func orderIsOpen(order *ordersmodels.Order) bool {
+	if order.DeletedAt.IsSome() {
+		return false
+	}
+	return order.Status == ordersmodels.OrderStatusPending || order.Status == ordersmodels.OrderStatusBlocked
+}
+
+func orderUses(order *ordersmodels.Order, reservation inventorymodels.Reservation) bool {
+	for _, usage := range order.IngredientUsage {
+		if usage.IngredientID.String() == reservation.IngredientID.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func staleReservationMessage(order *ordersmodels.Order, reservation inventorymodels.Reservation) string {
+	held := fmt.Sprintf("holds a reservation of %s of ingredient %s", reservation.Amount.String(), reservation.IngredientID.String())
+	switch {
+	case order == nil:
+		return held + " but the order does not exist"
+	case order.DeletedAt.IsSome():
+		return held + " but the order is deleted"
+	case !orderIsOpen(order):
+		return held + " but the order is " + string(order.Status)
+	default:
+		return held + " that the order does not use"
+	}
+}
+
+func (s *checkSnapshot) checkRecipes() []Violation {
+	known := set.New[string]()
+	for _, ingredient := range s.ingredients {
+		known.Add(ingredient.ID.String())
+	}
+	var violations []Violation
+	for _, drink := range s.drinks {
+		var missing []string
+		for _, requirement := range drink.Recipe.Ingredients {
+			if !known.Contains(requirement.IngredientID.String()) {
+				missing = append(missing, requirement.IngredientID.String())
+			}
+			for _, substitute := range requirement.Substitutes {
+				if !known.Contains(substitute.String()) {
+					missing = append(missing, substitute.String()+" (substitute)")
+				}
+			}
+		}
+		if len(missing) > 0 {
+			violations = append(violations, Violation{
+				Invariant: InvariantRecipeIngredient, Severity: SeverityError, Entity: drink.ID.EntityUID(),
+				Message: "recipe references missing ingredients: " + strings.Join(missing, ", "),
+			})
+		}
+	}
+	return violations
+}
+
+func (a *App) checkMenus(ctx *middleware.Context, menus []*menusmodels.Menu) ([]Violation, error) {
+	var violations []Violation
+	for _, menu := range menus {
+		if menu.Status != menusmodels.MenuStatusPublished || menu.DeletedAt.IsSome() {
+			continue
+		}
+		drift, err := a.Menus.AvailabilityDrift(ctx, menu.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(drift) == 0 {
+			continue
+		}
+		details := make([]string, 0, len(drift))
+		for _, item := range drift {
+			details = append(details, fmt.Sprintf("drink %s records %s but is %s", item.DrinkID.String(), item.Recorded, item.Calculated))
+		}
+		id := menu.ID
+		violations = append(violations, Violation{
+			Invariant: InvariantMenuAvailability, Severity: SeverityWarning, Entity: id.EntityUID(),
+			Message:    strings.Join(details, "; "),
+			Repairable: true,
+			repair: func(ctx *middleware.Context) error {
+				_, err := a.Menus.RefreshAvailability(ctx, id)
+				return err
+			},
+		})
+	}
+	return violations, nil
+}
+
+func (a *App) checkTags(ctx *middleware.Context, s *checkSnapshot) ([]Violation, error) {
+	targets, err := a.Tags.TaggedTargets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exists := set.New[cedar.EntityUID]()
+	for _, ingredient := range s.ingredients {
+		exists.Add(ingredient.ID.EntityUID())
+	}
+	for _, drink := range s.drinks {
+		exists.Add(drink.ID.EntityUID())
+	}
+	for _, stock := range s.stock {
+		exists.Add(stock.EntityUID())
+	}
+	for _, menu := range s.menus {
+		exists.Add(menu.ID.EntityUID())
+	}
+	for _, order := range s.orders {
+		exists.Add(order.ID.EntityUID())
+	}
+	var violations []Violation
+	for _, target := range targets {
+		if exists.Contains(target) {
+			continue
+		}
+		violations = append(violations, Violation{
+			Invariant: InvariantTagTarget, Severity: SeverityWarning, Entity: target,
+			Message:    "carries tags but the entity does not exist",
+			Repairable: true,
+			repair: func(ctx *middleware.Context) error {
+				_, err := a.Tags.Purge(ctx, target)
+				return err
+			},
+		})
+	}
+	return violations, nil
+}
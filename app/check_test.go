@@ -0,0 +1,112 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app"
+	drinksmodels "github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	inventoryauthz "github.com/TheFellow/go-modular-monolith/app/domains/inventory/authz"
+	inventorymodels "github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
+	menusauthz "github.com/TheFellow/go-modular-monolith/app/domains/menus/authz"
+	menusmodels "github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	ordersmodels "github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	"github.com/TheFellow/go-modular-monolith/app/domains/tagging"
+	taggingauthz "github.com/TheFellow/go-modular-monolith/app/domains/tagging/authz"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func invariants(report app.CheckReport) map[app.Invariant]app.Severity {
+	got := make(map[app.Invariant]app.Severity, len(report.Violations))
+	for _, violation := range report.Violations {
+		got[violation.Invariant] = violation.Severity
+	}
+	return got
+}
+
+func TestApp_CheckPassesConsistentDatabase(t *testing.T) {
+	t.Parallel()
+	f := exportFixture(t)
+
+	report, err := f.App.Check(f.OwnerContext(), app.CheckOptions{})
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(report.Violations), 0)
+}
+
+// TestApp_CheckReportsAndRepairsViolations corrupts a consistent database
+// through the import commands, which trust their input, and a tag written
+// straight to the repository.
+func TestApp_CheckReportsAndRepairsViolations(t *testing.T) {
+	t.Parallel()
+	f := exportFixture(t)
+	ctx := f.OwnerContext()
+	menus, err := f.Menus.ExportAll(ctx)
+	testutil.Ok(t, err)
+	orders, err := f.Orders.ExportAll(ctx)
+	testutil.Ok(t, err)
+
+	stale := *menus[0]
+	stale.ID, stale.Name = entity.NewMenuID(), "Stale Menu"
+	stale.Items = []menusmodels.MenuItem{{DrinkID: menus[0].Items[0].DrinkID, Availability: menusmodels.AvailabilityUnavailable}}
+	_, err = f.Menus.Import(ctx, &stale)
+	testutil.Ok(t, err)
+
+	unreserved := *orders[0]
+	unreserved.ID = entity.NewOrderID()
+	_, err = f.Orders.Import(ctx, &unreserved)
+	testutil.Ok(t, err)
+
+	completed := *orders[0]
+	completed.ID, completed.Status = entity.NewOrderID(), ordersmodels.OrderStatusCompleted
+	_, err = f.Orders.Import(ctx, &completed)
+	testutil.Ok(t, err)
+	usage := completed.IngredientUsage[0]
+	_, err = f.Inventory.ImportReservation(ctx, inventorymodels.Reservation{OrderID: completed.ID, IngredientID: usage.IngredientID, Amount: usage.Amount})
+	testutil.Ok(t, err)
+
+	_, err = f.Drinks.Import(ctx, &drinksmodels.Drink{
+		ID: entity.NewDrinkID(), Name: "Phantom Sour", Category: drinksmodels.DrinkCategoryCocktail, Glass: drinksmodels.GlassTypeCoupe, Status: drinksmodels.StatusActive,
+		Recipe: drinksmodels.Recipe{
+			Ingredients: []drinksmodels.RecipeIngredient{{IngredientID: entity.NewIngredientID(), Amount: measurement.MustAmount(1, measurement.UnitOz)}},
+			Steps:       []string{"Shake"},
+		},
+	})
+	testutil.Ok(t, err)
+
+	vanished := entity.NewMenuID().EntityUID()
+	testutil.Ok(t, f.Store.Write(ctx, func(tx *store.Tx) error {
+		_, err := tagging.NewRepository(f.Store).Upsert(ctx.WithTransaction(tx), vanished, tag.Tag{Key: "season", Value: "winter"})
+		return err
+	}))
+
+	report, err := f.App.Check(ctx, app.CheckOptions{})
+	testutil.Ok(t, err)
+	testutil.Equals(t, invariants(report), map[app.Invariant]app.Severity{
+		app.InvariantOrderReservations: app.SeverityError,
+		app.InvariantRecipeIngredient:  app.SeverityError,
+		app.InvariantStaleReservation:  app.SeverityWarning,
+		app.InvariantMenuAvailability:  app.SeverityWarning,
+		app.InvariantTagTarget:         app.SeverityWarning,
+	})
+	testutil.Equals(t, report.Violations[0].Severity, app.SeverityError)
+	testutil.Equals(t, report.Repaired(), 0)
+
+	repaired, err := f.App.Check(ctx, app.CheckOptions{Repair: true})
+	testutil.Ok(t, err)
+	testutil.Equals(t, repaired.Repaired(), 3)
+	testutil.Equals(t, repaired.Outstanding(app.SeverityWarning), 0)
+	testutil.Equals(t, repaired.Outstanding(app.SeverityError), 2)
+	f.LatestAuditEntry(inventoryauthz.ActionRelease)
+	f.LatestAuditEntry(menusauthz.ActionRefresh)
+	f.LatestAuditEntry(taggingauthz.ActionPurge)
+
+	after, err := f.App.Check(ctx, app.CheckOptions{})
+	testutil.Ok(t, err)
+	testutil.Equals(t, invariants(after), map[app.Invariant]app.Severity{
+		app.InvariantOrderReservations: app.SeverityError,
+		app.InvariantRecipeIngredient:  app.SeverityError,
+	})
+}
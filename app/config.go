@@ -1,7 +1,33 @@
 package app
 
-import "github.com/TheFellow/go-modular-monolith/pkg/store"
+import (
+	"strings"
+	"time"
+
+	middlewareevents "github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
 
 type Config struct {
 	Store *store.Store
+	// Clock supplies the time recorded in authorization requests; nil uses
+	// time.Now.
+	Clock func() time.Time
+	// Redact hides sensitive fields in the changes recorded in audit history;
+	// nil uses RedactCosts.
+	Redact middlewareevents.Redactor
+	// DeferMigrations leaves pending data migrations for App.Migrations.Up.
+	// A read-only store always defers them. A database a newer binary has
+	// migrated is refused either way.
+	DeferMigrations bool
+	// Defaults are the starting values surfaces offer. Zero fields use the
+	// built-in defaults unless the Defaults came from DefaultsFrom, which
+	// has resolved them already.
+	Defaults Defaults
+}
+
+// RedactCosts is the default Redactor. It hides inventory cost prices; the
+// history still records that a cost changed.
+func RedactCosts(path string) bool {
+	return path == "CostPerUnit" || strings.HasSuffix(path, ".CostPerUnit")
 }
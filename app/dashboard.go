@@ -67,7 +67,7 @@ func (a *App) Dashboard(ctx *middleware.Context) (Dashboard, error) {
 	load(&data.IngredientCount, func() (int, error) { return a.Ingredients.Count(ctx, ingredients.ListRequest{}) })
 	load(&data.InventoryCount, func() (int, error) { return a.Inventory.Count(ctx, inventory.ListRequest{}) })
 	load(&data.LowStockCount, func() (int, error) {
-		return a.Inventory.Count(ctx, inventory.ListRequest{LowStock: optional.Some(inventory.DefaultLowStockThreshold)})
+		return a.Inventory.Count(ctx, inventory.ListRequest{LowStock: optional.Some(a.defaults.LowStockThreshold)})
 	})
 	load(&data.MenuCount, func() (int, error) { return a.Menus.Count(ctx, menus.ListRequest{}) })
 	load(&data.DraftMenus, func() (int, error) { return a.Menus.Count(ctx, menus.ListRequest{Status: menumodels.MenuStatusDraft}) })
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/inventory"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/currency"
+	"github.com/TheFellow/go-modular-monolith/pkg/runtimeconfig"
+)
+
+// Defaults are the configured starting values surfaces offer where a request
+// leaves the choice to the user: the low-stock filter, the target margin of a
+// menu analysis, and the currency of a price entered as a bare number.
+type Defaults struct {
+	LowStockThreshold float64
+	TargetMargin      float64
+	Currency          currency.Currency
+
+	// configured marks Defaults read from the runtime configuration, where a
+	// zero is a configured value rather than an unset one.
+	configured bool
+}
+
+// DefaultsFrom reads Defaults from the runtime configuration. A setting the
+// configuration leaves at its default takes the built-in value; one it sets,
+// even to zero, is kept.
+func DefaultsFrom(config runtimeconfig.Config) (Defaults, error) {
+	curr, err := currency.Parse(config.Currency)
+	if err != nil {
+		return Defaults{}, err
+	}
+	d := Defaults{
+		LowStockThreshold: config.LowStockThreshold,
+		TargetMargin:      config.TargetMargin,
+		Currency:          curr,
+		configured:        true,
+	}
+	if config.Source("inventory.low_stock_threshold") == runtimeconfig.SourceDefault {
+		d.LowStockThreshold = inventory.DefaultLowStockThreshold
+	}
+	if config.Source("menus.target_margin") == runtimeconfig.SourceDefault {
+		d.TargetMargin = runtimeconfig.DefaultTargetMargin
+	}
+	return d, nil
+}
+
+// Defaults returns the configured defaults, with built-in values for any left
+// unset in Config.
+func (a *App) Defaults() Defaults {
+	return a.defaults
+}
+
+func (d Defaults) orBuiltIn() Defaults {
+	if d.configured {
+		return d
+	}
+	if d.LowStockThreshold == 0 {
+		d.LowStockThreshold = inventory.DefaultLowStockThreshold
+	}
+	if d.TargetMargin == 0 {
+		d.TargetMargin = runtimeconfig.DefaultTargetMargin
+	}
+	if d.Currency.IsZero() {
+		d.Currency = currency.USD
+	}
+	return d
+}
@@ -0,0 +1,73 @@
+package app_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app"
+	"github.com/TheFellow/go-modular-monolith/app/domains/inventory"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/currency"
+	"github.com/TheFellow/go-modular-monolith/pkg/authn"
+	"github.com/TheFellow/go-modular-monolith/pkg/log"
+	"github.com/TheFellow/go-modular-monolith/pkg/runtimeconfig"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/teststore"
+)
+
+func TestDefaultsFromRuntimeConfig(t *testing.T) {
+	t.Parallel()
+
+	got, err := app.DefaultsFrom(runtimeconfig.Default())
+	testutil.Ok(t, err)
+	testutil.Equals(t, got.LowStockThreshold, inventory.DefaultLowStockThreshold)
+	testutil.Equals(t, got.TargetMargin, runtimeconfig.DefaultTargetMargin)
+	testutil.Equals(t, got.Currency, currency.USD)
+
+	config := runtimeconfig.Default()
+	config.Currency = "GBP"
+	_, err = app.DefaultsFrom(config)
+	testutil.ErrorIsInvalid(t, err)
+}
+
+func TestAppDefaultsFillUnsetValues(t *testing.T) {
+	t.Parallel()
+
+	ctx := authn.ToContext(context.Background(), authn.Owner())
+	ctx = log.ToContext(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	s, err := store.Open(ctx, teststore.Path(t, "defaults.test"))
+	testutil.Ok(t, err)
+	a, err := app.New(ctx, app.Config{Store: s, Defaults: app.Defaults{Currency: currency.EUR}})
+	testutil.Ok(t, err)
+	t.Cleanup(func() { _ = a.Close() })
+
+	testutil.Equals(t, a.Defaults().LowStockThreshold, inventory.DefaultLowStockThreshold)
+	testutil.Equals(t, a.Defaults().TargetMargin, runtimeconfig.DefaultTargetMargin)
+	testutil.Equals(t, a.Defaults().Currency, currency.EUR)
+}
+
+func TestAppDefaultsKeepConfiguredZeroThreshold(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), runtimeconfig.ConfigFileName)
+	testutil.Ok(t, os.WriteFile(path, []byte("[inventory]\nlow_stock_threshold = 0\n"), 0o600))
+	config, err := runtimeconfig.Load(path, runtimeconfig.Default())
+	testutil.Ok(t, err)
+	defaults, err := app.DefaultsFrom(config)
+	testutil.Ok(t, err)
+
+	ctx := authn.ToContext(context.Background(), authn.Owner())
+	ctx = log.ToContext(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	s, err := store.Open(ctx, teststore.Path(t, "defaults.test"))
+	testutil.Ok(t, err)
+	a, err := app.New(ctx, app.Config{Store: s, Defaults: defaults})
+	testutil.Ok(t, err)
+	t.Cleanup(func() { _ = a.Close() })
+
+	testutil.Equals(t, a.Defaults().LowStockThreshold, 0.0)
+	testutil.Equals(t, a.Defaults().TargetMargin, runtimeconfig.DefaultTargetMargin)
+}
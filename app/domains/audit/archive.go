@@ -0,0 +1,36 @@
+package audit
+
+import (
+	"io"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// ArchiveInstaller runs write against an archive's destination and returns
+// only once the written file is durable, for example synced and renamed into
+// place. A failure leaves the archived entries in the log.
+type ArchiveInstaller func(write func(io.Writer) error) error
+
+// Archive moves the oldest entries selected by policy out of the log,
+// writing them through install as a gzip-compressed file in format. The
+// entries are deleted only after install succeeds, in the same transaction
+// that records the archive. The remaining chain links to the last archived
+// entry, so it still verifies; the archive itself is recorded as an audit
+// entry.
+func (m *Module) Archive(ctx *middleware.Context, policy models.RetentionPolicy, format models.Format, install ArchiveInstaller) (*models.Archive, error) {
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Archive, *models.Archive]{
+		Action: authz.ActionArchive,
+		Load: func(*middleware.Context) (*models.Archive, error) {
+			format, err := models.ParseFormat(string(format))
+			if err != nil {
+				return nil, err
+			}
+			return &models.Archive{Policy: policy, Format: format}, nil
+		},
+		Handle: func(ctx *middleware.Context, archive *models.Archive) (*models.Archive, error) {
+			return m.commands.Archive(ctx, archive, install)
+		},
+	})
+}
@@ -0,0 +1,147 @@
+package audit_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit"
+	auditauthz "github.com/TheFellow/go-modular-monolith/app/domains/audit/authz"
+	auditmodels "github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	ingredientsauthz "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+func TestArchive_MovesOldestEntriesAndKeepsChain(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	createIngredients(t, f, "Archived Gin", "Archived Rum", "Kept Vodka")
+
+	var file bytes.Buffer
+	archive, err := f.Audit.Archive(f.OwnerContext(), auditmodels.RetentionPolicy{Keep: 1}, auditmodels.FormatNDJSON, writeTo(&file))
+	testutil.Ok(t, err)
+	testutil.Equals(t, archive.Entries, 2)
+	testutil.Equals(t, archive.LastSequence, int64(2))
+
+	lines := archiveLines(t, &file)
+	testutil.Equals(t, len(lines), 2)
+	type record struct {
+		Sequence     int64  `json:"sequence"`
+		PreviousHash string `json:"previous_hash"`
+		Hash         string `json:"hash"`
+	}
+	var first, second record
+	testutil.Ok(t, json.Unmarshal(lines[0], &first))
+	testutil.Ok(t, json.Unmarshal(lines[1], &second))
+	testutil.Equals(t, first.Sequence, int64(1))
+	testutil.Equals(t, second.PreviousHash, first.Hash)
+	testutil.Equals(t, second.Hash, archive.LastHash)
+
+	result, err := f.Audit.Verify(f.OwnerContext())
+	testutil.Ok(t, err)
+	testutil.IsTrue(t, result.Valid())
+	testutil.Equals(t, result.Archived, int64(2))
+	testutil.Equals(t, result.Entries, 2)
+
+	recorded := f.LatestAuditEntry(auditauthz.ActionArchive)
+	testutil.IsTrue(t, recorded.Success)
+	remaining, err := f.Audit.Count(f.OwnerContext(), audit.ListRequest{Action: ingredientsauthz.ActionCreate})
+	testutil.Ok(t, err)
+	testutil.Equals(t, remaining, 1)
+}
+
+func TestArchive_RequiresRetentionPolicy(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+
+	var file bytes.Buffer
+	_, err := f.Audit.Archive(f.OwnerContext(), auditmodels.RetentionPolicy{}, auditmodels.FormatNDJSON, writeTo(&file))
+	testutil.IsTrue(t, errors.IsInvalid(err))
+
+	recorded := f.LatestAuditEntry(auditauthz.ActionArchive)
+	testutil.IsTrue(t, !recorded.Success)
+}
+
+func TestExport_StreamsFilteredRangeAsCSV(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	createIngredients(t, f, "Exported Gin", "Exported Rum")
+
+	var out bytes.Buffer
+	export, err := f.Audit.Export(f.OwnerContext(), audit.ExportRequest{
+		Action: ingredientsauthz.ActionCreate,
+		Format: auditmodels.FormatCSV,
+	}, &out)
+	testutil.Ok(t, err)
+	testutil.Equals(t, export.Entries, 2)
+
+	records, err := csv.NewReader(&out).ReadAll()
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(records), 3)
+	testutil.Equals(t, records[0][:4], []string{"id", "sequence", "previous_hash", "hash"})
+	testutil.Equals(t, []string{records[1][1], records[2][1]}, []string{"1", "2"})
+	testutil.Equals(t, records[2][2], records[1][3])
+
+	recorded := f.LatestAuditEntry(auditauthz.ActionExport)
+	testutil.IsTrue(t, recorded.Success)
+	testutil.Equals(t, recorded.Resource, cedar.NewEntityUID(auditauthz.AuditEntryType, auditmodels.ChainResourceID))
+}
+
+func TestArchive_KeepsEntriesWhenInstallFails(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	createIngredients(t, f, "Unarchived Gin", "Unarchived Rum")
+
+	var written bool
+	_, err := f.Audit.Archive(f.OwnerContext(), auditmodels.RetentionPolicy{Keep: 1}, auditmodels.FormatNDJSON,
+		func(write func(io.Writer) error) error {
+			written = true
+			testutil.Ok(t, write(io.Discard))
+			return errors.Internalf("disk full")
+		})
+	testutil.ErrorContains(t, err, "disk full")
+	testutil.IsTrue(t, written)
+
+	result, err := f.Audit.Verify(f.OwnerContext())
+	testutil.Ok(t, err)
+	testutil.IsTrue(t, result.Valid())
+	testutil.Equals(t, result.Archived, int64(0))
+	remaining, err := f.Audit.Count(f.OwnerContext(), audit.ListRequest{Action: ingredientsauthz.ActionCreate})
+	testutil.Ok(t, err)
+	testutil.Equals(t, remaining, 2)
+}
+
+func writeTo(w io.Writer) audit.ArchiveInstaller {
+	return func(write func(io.Writer) error) error { return write(w) }
+}
+
+func createIngredients(t *testing.T, f *testutil.Fixture, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		_, err := f.Ingredients.Create(f.OwnerContext(), &models.Ingredient{
+			Name: name, Category: models.CategorySpirit, Unit: measurement.UnitOz,
+		})
+		testutil.Ok(t, err)
+	}
+}
+
+func archiveLines(t *testing.T, file *bytes.Buffer) [][]byte {
+	t.Helper()
+	r, err := gzip.NewReader(file)
+	testutil.Ok(t, err)
+	var lines [][]byte
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, append([]byte(nil), scanner.Bytes()...))
+	}
+	testutil.Ok(t, scanner.Err())
+	return lines
+}
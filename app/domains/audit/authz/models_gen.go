@@ -44,8 +44,13 @@ func ValidateEntity(entity cedar.Entity) error {
 }
 
 var (
-	ActionGet  = cedar.NewEntityUID(ActionType, "get")
-	ActionList = cedar.NewEntityUID(ActionType, "list")
+	ActionArchive = cedar.NewEntityUID(ActionType, "archive")
+	ActionExport  = cedar.NewEntityUID(ActionType, "export")
+	ActionGet     = cedar.NewEntityUID(ActionType, "get")
+	ActionImport  = cedar.NewEntityUID(ActionType, "import")
+	ActionList    = cedar.NewEntityUID(ActionType, "list")
+	ActionUndo    = cedar.NewEntityUID(ActionType, "undo")
+	ActionVerify  = cedar.NewEntityUID(ActionType, "verify")
 )
 
 // AuditEntry is the Cedar-facing authorization model for Mixology::AuditEntry.
@@ -0,0 +1,119 @@
+package audit_test
+
+import (
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit"
+	auditmodels "github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	drinksauthz "github.com/TheFellow/go-modular-monolith/app/domains/drinks/authz"
+	drinksmodels "github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	ingredientsauthz "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/authz"
+	ingredientsmodels "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	inventoryauthz "github.com/TheFellow/go-modular-monolith/app/domains/inventory/authz"
+	inventorymodels "github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/currency"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/money"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
+	middlewareevents "github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestAudit_UpdateRecordsFieldChanges(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	ctx := f.OwnerContext()
+
+	gin := testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{
+		Name: "Gin", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitOz,
+	})
+	drink, err := f.Drinks.Create(ctx, &drinksmodels.Drink{
+		Name:     "Martini",
+		Category: drinksmodels.DrinkCategoryCocktail,
+		Glass:    drinksmodels.GlassTypeCoupe,
+		Recipe: drinksmodels.Recipe{
+			Ingredients: []drinksmodels.RecipeIngredient{
+				{IngredientID: gin.ID, Amount: measurement.MustAmount(2, measurement.UnitOz)},
+			},
+			Steps: []string{"Stir"},
+		},
+	})
+	testutil.Ok(t, err)
+
+	updated := *drink
+	updated.Description = "Very dry"
+	updated.Recipe.Ingredients = []drinksmodels.RecipeIngredient{
+		{IngredientID: gin.ID, Amount: measurement.MustAmount(2.5, measurement.UnitOz)},
+	}
+	_, err = f.Drinks.Update(ctx, &updated)
+	testutil.Ok(t, err)
+
+	page, err := f.App.Audit.List(ctx, audit.ListRequest{Action: drinksauthz.ActionUpdate})
+	testutil.Ok(t, err)
+	testutil.ErrorIf(t, len(page.Items) != 1, "expected 1 audit entry, got %d", len(page.Items))
+	testutil.Equals(t, page.Items[0].Changes, []auditmodels.Change{
+		{Path: "Description", After: "Very dry"},
+		{Path: "Recipe.Ingredients[0].Amount", Before: "2.00 oz", After: "2.50 oz"},
+		{Path: "Version", Before: "1", After: "2"},
+	})
+}
+
+func TestAudit_PatchRecordsPriorStateAndRedactsCost(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	ctx := f.OwnerContext()
+
+	gin := testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{
+		Name: "Gin", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitOz,
+	})
+	_, err := f.Inventory.Set(ctx, &inventorymodels.Update{
+		IngredientID: gin.ID,
+		Amount:       measurement.MustAmount(4, measurement.UnitOz),
+		CostPerUnit:  money.NewPriceFromCents(100, currency.USD),
+	})
+	testutil.Ok(t, err)
+	_, err = f.Inventory.Adjust(ctx, &inventorymodels.Patch{
+		IngredientID: gin.ID,
+		Reason:       inventorymodels.ReasonReceived,
+		Delta:        optional.Some(measurement.MustAmount(2, measurement.UnitOz)),
+		CostPerUnit:  optional.Some(money.NewPriceFromCents(150, currency.USD)),
+	})
+	testutil.Ok(t, err)
+
+	page, err := f.App.Audit.List(ctx, audit.ListRequest{Action: inventoryauthz.ActionAdjust})
+	testutil.Ok(t, err)
+	testutil.ErrorIf(t, len(page.Items) != 1, "expected 1 audit entry, got %d", len(page.Items))
+	changes := changesByPath(page.Items[0].Changes)
+	testutil.Equals(t, changes["Amount"], auditmodels.Change{Path: "Amount", Before: "4.00 oz", After: "6.00 oz"})
+	testutil.Equals(t, changes["CostPerUnit"], auditmodels.Change{
+		Path: "CostPerUnit", Before: middlewareevents.Redacted, After: middlewareevents.Redacted,
+	})
+	testutil.Equals(t, changes["Version"], auditmodels.Change{Path: "Version", Before: "1", After: "2"})
+	_, recorded := changes["IngredientID"]
+	testutil.IsFalse(t, recorded)
+
+	_, err = f.App.Tags.Upsert(ctx, gin.EntityUID(), tag.Tag{Key: "house"})
+	testutil.Ok(t, err)
+	_, err = f.App.Tags.Upsert(ctx, gin.EntityUID(), tag.Tag{Key: "region", Value: "uk"})
+	testutil.Ok(t, err)
+	page, err = f.App.Audit.List(ctx, audit.ListRequest{Action: ingredientsauthz.ActionTag})
+	testutil.Ok(t, err)
+	testutil.ErrorIf(t, len(page.Items) != 2, "expected 2 audit entries, got %d", len(page.Items))
+	// Each entry records only the tag it added; earlier tags are prior state.
+	added := map[string]bool{}
+	for _, entry := range page.Items {
+		testutil.ErrorIf(t, len(entry.Changes) != 1, "expected 1 change, got %v", entry.Changes)
+		testutil.Equals(t, entry.Changes[0].Before, "")
+		added[entry.Changes[0].Path+" "+entry.Changes[0].After] = true
+	}
+	testutil.Equals(t, added, map[string]bool{"Tags[0] house": true, "Tags[1] region=uk": true})
+}
+
+func changesByPath(changes []auditmodels.Change) map[string]auditmodels.Change {
+	out := make(map[string]auditmodels.Change, len(changes))
+	for _, change := range changes {
+		out[change.Path] = change
+	}
+	return out
+}
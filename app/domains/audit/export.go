@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"io"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	appfilter "github.com/TheFellow/go-modular-monolith/pkg/filter"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+type ExportRequest struct {
+	Action    cedar.EntityUID
+	Principal cedar.EntityUID
+	Entity    cedar.EntityUID
+	From      time.Time
+	To        time.Time
+	Filter    string
+	Format    models.Format
+	Compress  bool
+}
+
+// Export streams the entries matching req to w in chain order, oldest first.
+// Unlike List it is a command: exporting the log is itself recorded as an
+// audit entry. A read-only store cannot record it, so there the export runs
+// unaudited.
+func (m *Module) Export(ctx *middleware.Context, req ExportRequest, w io.Writer) (*models.Export, error) {
+	expression, err := appfilter.Parse(models.ListFilterSchema(), req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	filter := m.listFilter(ListRequest{
+		Action:    req.Action,
+		Principal: req.Principal,
+		Entity:    req.Entity,
+		From:      req.From,
+		To:        req.To,
+	})
+	filter.Expression = expression
+
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Export, *models.Export]{
+		Action:    authz.ActionExport,
+		ReadsOnly: true,
+		Load: func(*middleware.Context) (*models.Export, error) {
+			format, err := models.ParseFormat(string(req.Format))
+			if err != nil {
+				return nil, err
+			}
+			return &models.Export{Format: format, Compressed: req.Compress}, nil
+		},
+		Handle: func(ctx *middleware.Context, export *models.Export) (*models.Export, error) {
+			return m.commands.Export(ctx, export, filter, w)
+		},
+	})
+}
@@ -0,0 +1,45 @@
+package audit
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// ExportAll returns the whole log in chain order for a full database export.
+// Like Export it is a command, so the export is itself audited; its own entry
+// is written after the entries it returns, unless the store is read-only.
+func (m *Module) ExportAll(ctx *middleware.Context) ([]models.AuditEntry, error) {
+	var entries []models.AuditEntry
+	_, err := middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Transfer, *models.Transfer]{
+		Action:    authz.ActionExport,
+		ReadsOnly: true,
+		Load: func(*middleware.Context) (*models.Transfer, error) {
+			return &models.Transfer{}, nil
+		},
+		Handle: func(ctx *middleware.Context, _ *models.Transfer) (*models.Transfer, error) {
+			return m.commands.ExportAll(ctx, func(entry models.AuditEntry) {
+				entries = append(entries, entry)
+			})
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Import recreates an exported log in a database that has none yet. The
+// entries must form an unbroken chain; their hashes are kept, so the imported
+// log verifies exactly as the source did.
+func (m *Module) Import(ctx *middleware.Context, entries []models.AuditEntry) (*models.Transfer, error) {
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Transfer, *models.Transfer]{
+		Action: authz.ActionImport,
+		Load: func(*middleware.Context) (*models.Transfer, error) {
+			return &models.Transfer{}, nil
+		},
+		Handle: func(ctx *middleware.Context, _ *models.Transfer) (*models.Transfer, error) {
+			return m.commands.Import(ctx, entries)
+		},
+	})
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"compress/gzip"
+	"io"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// Archive writes the selected entries through install, which must make the
+// file durable before it returns; only then are the entries deleted.
+func (c *Commands) Archive(ctx *middleware.Context, archive *models.Archive, install func(write func(io.Writer) error) error) (*models.Archive, error) {
+	if archive == nil {
+		return nil, errors.Invalidf("archive is required")
+	}
+	if err := archive.Policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	archived, err := c.dao.Archive(ctx, archive.Policy, func(entries []models.AuditEntry) error {
+		return install(func(w io.Writer) error {
+			return writeArchive(w, archive.Format, entries)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := *archive
+	out.Entries = archived.Entries
+	out.FirstSequence = archived.FirstSequence
+	out.LastSequence = archived.LastSequence
+	out.LastHash = archived.LastHash
+	return &out, nil
+}
+
+func writeArchive(w io.Writer, format models.Format, entries []models.AuditEntry) error {
+	compressed := gzip.NewWriter(w)
+	enc, err := newEncoder(compressed, format)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	if err := compressed.Close(); err != nil {
+		return errors.Internalf("compress audit archive: %w", err)
+	}
+	return nil
+}
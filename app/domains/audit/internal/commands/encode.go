@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+)
+
+// record is the archived and exported form of an entry. It carries every
+// hashed field, with times in the UTC form the chain hashes.
+type record struct {
+	ID            string   `json:"id"`
+	Sequence      int64    `json:"sequence"`
+	PreviousHash  string   `json:"previous_hash"`
+	Hash          string   `json:"hash"`
+	Action        string   `json:"action"`
+	Resource      string   `json:"resource"`
+	Principal     string   `json:"principal"`
+	CorrelationID string   `json:"correlation_id,omitempty"`
+	StartedAt     string   `json:"started_at"`
+	CompletedAt   string   `json:"completed_at"`
+	Success       bool     `json:"success"`
+	Error         string   `json:"error,omitempty"`
+	Touches       []string `json:"touches,omitempty"`
+	Changes       []change `json:"changes,omitempty"`
+}
+
+type change struct {
+	Path   string `json:"path"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+var csvHeader = []string{
+	"id", "sequence", "previous_hash", "hash", "action", "resource", "principal",
+	"started_at", "completed_at", "success", "error", "touches", "changes", "correlation_id",
+}
+
+func toRecord(entry models.AuditEntry) record {
+	r := record{
+		ID:            entry.ID.String(),
+		Sequence:      entry.Sequence,
+		PreviousHash:  entry.PreviousHash,
+		Hash:          entry.Hash,
+		Action:        entry.Action,
+		Resource:      entry.Resource.String(),
+		Principal:     entry.Principal.String(),
+		CorrelationID: entry.CorrelationID,
+		StartedAt:     entry.StartedAt.UTC().Format(time.RFC3339Nano),
+		CompletedAt:   entry.CompletedAt.UTC().Format(time.RFC3339Nano),
+		Success:       entry.Success,
+		Error:         entry.Error,
+	}
+	for _, touch := range entry.Touches {
+		r.Touches = append(r.Touches, touch.String())
+	}
+	for _, c := range entry.Changes {
+		r.Changes = append(r.Changes, change{Path: c.Path, Before: c.Before, After: c.After})
+	}
+	return r
+}
+
+// encoder writes entries in one Format. Close flushes buffered output but
+// does not close the underlying writer.
+type encoder interface {
+	Encode(models.AuditEntry) error
+	Close() error
+}
+
+func newEncoder(w io.Writer, format models.Format) (encoder, error) {
+	switch format {
+	case models.FormatNDJSON:
+		return ndjsonEncoder{json.NewEncoder(w)}, nil
+	case models.FormatCSV:
+		out := csv.NewWriter(w)
+		if err := out.Write(csvHeader); err != nil {
+			return nil, errors.Internalf("write audit csv header: %w", err)
+		}
+		return csvEncoder{out}, nil
+	}
+	return nil, errors.Invalidf("invalid audit format %q (expected ndjson or csv)", format)
+}
+
+type ndjsonEncoder struct{ out *json.Encoder }
+
+func (e ndjsonEncoder) Encode(entry models.AuditEntry) error {
+	if err := e.out.Encode(toRecord(entry)); err != nil {
+		return errors.Internalf("write audit entry %s: %w", entry.ID, err)
+	}
+	return nil
+}
+
+func (ndjsonEncoder) Close() error { return nil }
+
+type csvEncoder struct{ out *csv.Writer }
+
+func (e csvEncoder) Encode(entry models.AuditEntry) error {
+	r := toRecord(entry)
+	touches, err := jsonCell(r.Touches)
+	if err != nil {
+		return err
+	}
+	changes, err := jsonCell(r.Changes)
+	if err != nil {
+		return err
+	}
+	err = e.out.Write([]string{
+		r.ID, strconv.FormatInt(r.Sequence, 10), r.PreviousHash, r.Hash, r.Action, r.Resource, r.Principal,
+		r.StartedAt, r.CompletedAt, strconv.FormatBool(r.Success), r.Error, touches, changes, r.CorrelationID,
+	})
+	if err != nil {
+		return errors.Internalf("write audit entry %s: %w", entry.ID, err)
+	}
+	return nil
+}
+
+func (e csvEncoder) Close() error {
+	e.out.Flush()
+	if err := e.out.Error(); err != nil {
+		return errors.Internalf("flush audit csv: %w", err)
+	}
+	return nil
+}
+
+// jsonCell encodes a list column as a JSON array, or empty when it has no
+// elements, so CSV consumers see the same values as NDJSON consumers.
+func jsonCell[T any](values []T) (string, error) {
+	if len(values) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", errors.Internalf("encode audit csv cell: %w", err)
+	}
+	return string(data), nil
+}
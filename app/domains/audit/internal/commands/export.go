@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"compress/gzip"
+	"io"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/internal/dao"
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+func (c *Commands) Export(ctx *middleware.Context, export *models.Export, filter dao.ListFilter, w io.Writer) (*models.Export, error) {
+	if export == nil {
+		return nil, errors.Invalidf("export is required")
+	}
+
+	var compressed *gzip.Writer
+	if export.Compressed {
+		compressed = gzip.NewWriter(w)
+		w = compressed
+	}
+	enc, err := newEncoder(w, export.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	out := *export
+	for entry, err := range c.dao.Range(ctx, filter) {
+		if err != nil {
+			return nil, err
+		}
+		if err := enc.Encode(*entry); err != nil {
+			return nil, err
+		}
+		out.Entries++
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	if compressed != nil {
+		if err := compressed.Close(); err != nil {
+			return nil, errors.Internalf("compress audit export: %w", err)
+		}
+	}
+	return &out, nil
+}
@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/internal/dao"
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// ExportAll collects the whole remaining chain in order for a full database
+// export.
+func (c *Commands) ExportAll(ctx *middleware.Context, collect func(models.AuditEntry)) (*models.Transfer, error) {
+	var transfer models.Transfer
+	for entry, err := range c.dao.Range(ctx, dao.ListFilter{}) {
+		if err != nil {
+			return nil, err
+		}
+		if transfer.Entries == 0 {
+			transfer.FirstSequence = entry.Sequence
+		}
+		transfer.Entries++
+		transfer.LastSequence, transfer.Head = entry.Sequence, entry.Hash
+		collect(*entry)
+	}
+	return &transfer, nil
+}
+
+func (c *Commands) Import(ctx *middleware.Context, entries []models.AuditEntry) (*models.Transfer, error) {
+	transfer, err := c.dao.Import(ctx, entries)
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/internal/dao"
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// Undo reverses a successful command whose entities no later command has
+// touched. A later change, including an earlier undo, would be overwritten
+// by the reversal, so it is refused rather than guessed at. compensate runs
+// the compensating commands and returns the entities they changed.
+func (c *Commands) Undo(
+	ctx *middleware.Context,
+	entry *models.AuditEntry,
+	compensate func(*middleware.Context, models.AuditEntry) ([]cedar.EntityUID, error),
+) (*models.Undo, error) {
+	if entry == nil {
+		return nil, errors.Invalidf("audit entry is required")
+	}
+	if !entry.Success {
+		return nil, errors.FailedPreconditionf("audit entry %s recorded a failed command; there is nothing to undo", entry.ID.String())
+	}
+	for _, touched := range entry.Touches {
+		later, err := c.laterActivity(ctx, *entry, touched)
+		if err != nil {
+			return nil, err
+		}
+		if later != nil {
+			return nil, errors.FailedPreconditionf("audit entry %s cannot be undone: %s was changed later by %s (%s)",
+				entry.ID.String(), touched, later.ID.String(), later.Action)
+		}
+	}
+
+	restored, err := compensate(ctx, *entry)
+	if err != nil {
+		return nil, err
+	}
+	for _, uid := range restored {
+		ctx.TouchEntity(uid)
+	}
+	return &models.Undo{Entry: *entry, Restored: restored}, nil
+}
+
+func (c *Commands) laterActivity(ctx *middleware.Context, entry models.AuditEntry, touched cedar.EntityUID) (*models.AuditEntry, error) {
+	for candidate, err := range c.dao.Range(ctx, dao.ListFilter{Entity: touched}) {
+		if err != nil {
+			return nil, err
+		}
+		if candidate.Success && candidate.Sequence > entry.Sequence {
+			return candidate, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,82 @@
+package dao
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+// ArchivedRange describes the entries removed by Archive.
+type ArchivedRange struct {
+	Entries       int
+	FirstSequence int64
+	LastSequence  int64
+	LastHash      string
+}
+
+// Archive passes the oldest entries selected by policy to save in chain order,
+// then deletes them and records the last one as the chain's base so the
+// remaining entries still verify. Nothing is deleted unless save succeeds, so
+// save must return only once the entries are durable. The archived entries are
+// verified first: archiving a broken stretch would remove the evidence.
+func (d *DAO) Archive(ctx store.Context, policy models.RetentionPolicy, save func([]models.AuditEntry) error) (ArchivedRange, error) {
+	var archived ArchivedRange
+	err := store.Write(ctx, func(tx *store.Tx) error {
+		head, err := chainHead(tx)
+		if err != nil {
+			return store.MapError(err, "read audit chain head")
+		}
+
+		var rows []AuditEntryRow
+		previous := head.base()
+		q := store.QueryTx[AuditEntryRow](tx).SortAsc("Sequence", "ID")
+		for row, err := range q.All() {
+			if err != nil {
+				return store.MapError(err, "iterate audit entries")
+			}
+			if !selects(policy, head, row) {
+				break
+			}
+			if reason := brokenLink(previous, row); reason != "" {
+				return errors.FailedPreconditionf("audit chain broken at entry %d %s: %s", row.Sequence, row.ID, reason)
+			}
+			rows = append(rows, row)
+			previous = row
+		}
+		entries := make([]models.AuditEntry, 0, len(rows))
+		for _, row := range rows {
+			entries = append(entries, toModel(row))
+		}
+		if err := save(entries); err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		for _, row := range rows {
+			if err := tx.Delete(&row); err != nil {
+				return store.MapError(err, "delete audit entry %q", row.ID)
+			}
+		}
+		head.BaseSequence, head.BaseHash = previous.Sequence, previous.Hash
+		if err := tx.Update(&head); err != nil {
+			return store.MapError(err, "advance audit chain base")
+		}
+		archived = ArchivedRange{
+			Entries:       len(rows),
+			FirstSequence: rows[0].Sequence,
+			LastSequence:  previous.Sequence,
+			LastHash:      previous.Hash,
+		}
+		return nil
+	})
+	return archived, err
+}
+
+func selects(policy models.RetentionPolicy, head ChainHeadRow, row AuditEntryRow) bool {
+	if !policy.Before.IsZero() && !row.StartedAt.Before(policy.Before) {
+		return false
+	}
+	return policy.Keep == 0 || row.Sequence <= head.Sequence-int64(policy.Keep)
+}
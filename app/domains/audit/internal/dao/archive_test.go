@@ -0,0 +1,107 @@
+package dao_test
+
+import (
+	"testing"
+	"time"
+
+	auditdao "github.com/TheFellow/go-modular-monolith/app/domains/audit/internal/dao"
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestArchiveKeepsRemainingChainVerifiable(t *testing.T) {
+	t.Parallel()
+
+	d, s, ctx := newDAO(t)
+	ids := sameSecondIDs(t, 6)
+	insertEntries(t, ctx, s, d, ids[:5]...)
+	rows := chainRows(t, ctx, s)
+
+	archived, emitted := archive(t, ctx, s, d, models.RetentionPolicy{Keep: 2})
+	testutil.Equals(t, emitted, ids[:3])
+	testutil.Equals(t, archived, auditdao.ArchivedRange{Entries: 3, FirstSequence: 1, LastSequence: 3, LastHash: rows[2].Hash})
+
+	result, err := d.Verify(ctx)
+	testutil.Ok(t, err)
+	testutil.IsTrue(t, result.Valid())
+	testutil.Equals(t, result.Entries, 2)
+	testutil.Equals(t, result.Archived, int64(3))
+
+	insertEntries(t, ctx, s, d, ids[5])
+	archived, emitted = archive(t, ctx, s, d, models.RetentionPolicy{Keep: 2})
+	testutil.Equals(t, emitted, ids[3:4])
+	testutil.Equals(t, archived.FirstSequence, int64(4))
+
+	result, err = d.Verify(ctx)
+	testutil.Ok(t, err)
+	testutil.IsTrue(t, result.Valid())
+	testutil.Equals(t, result.Entries, 2)
+	testutil.Equals(t, result.Archived, int64(4))
+}
+
+func TestArchiveStopsAtFirstEntryOutsidePolicy(t *testing.T) {
+	t.Parallel()
+
+	d, s, ctx := newDAO(t)
+	ids := sameSecondIDs(t, 3)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	testutil.Ok(t, s.Write(ctx, func(tx *store.Tx) error {
+		txCtx := testContext{Context: ctx.Context, tx: tx}
+		for i, offset := range []int{1, 3, 2} {
+			id, err := entity.ParseAuditEntryID(ids[i])
+			if err != nil {
+				return err
+			}
+			if err := d.Insert(txCtx, models.AuditEntry{ID: id, StartedAt: start.AddDate(0, 0, offset)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	archived, emitted := archive(t, ctx, s, d, models.RetentionPolicy{Before: start.AddDate(0, 0, 3)})
+	testutil.Equals(t, archived.Entries, 1)
+	testutil.Equals(t, emitted, ids[:1])
+}
+
+func TestArchiveRefusesBrokenChain(t *testing.T) {
+	t.Parallel()
+
+	d, s, ctx := newDAO(t)
+	ids := sameSecondIDs(t, 4)
+	insertEntries(t, ctx, s, d, ids...)
+	rows := chainRows(t, ctx, s)
+	testutil.Ok(t, s.Write(ctx, func(tx *store.Tx) error {
+		rows[1].Success = true
+		return tx.Update(&rows[1])
+	}))
+
+	err := s.Write(ctx, func(tx *store.Tx) error {
+		_, err := d.Archive(testContext{Context: ctx.Context, tx: tx}, models.RetentionPolicy{Keep: 1},
+			func([]models.AuditEntry) error { return nil })
+		return err
+	})
+	testutil.IsTrue(t, errors.IsFailedPrecondition(err))
+	testutil.StringContains(t, err.Error(), "contents do not match the recorded hash")
+	testutil.Equals(t, len(chainRows(t, ctx, s)), 4)
+}
+
+func archive(t *testing.T, ctx testContext, s *store.Store, d *auditdao.DAO, policy models.RetentionPolicy) (auditdao.ArchivedRange, []string) {
+	t.Helper()
+	var archived auditdao.ArchivedRange
+	var emitted []string
+	testutil.Ok(t, s.Write(ctx, func(tx *store.Tx) error {
+		var err error
+		archived, err = d.Archive(testContext{Context: ctx.Context, tx: tx}, policy, func(entries []models.AuditEntry) error {
+			for _, entry := range entries {
+				emitted = append(emitted, entry.ID.String())
+			}
+			return nil
+		})
+		return err
+	}))
+	return archived, emitted
+}
@@ -0,0 +1,178 @@
+package dao
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+// chainContent is the canonical form hashed for each entry. Times are
+// normalized to UTC so the hash does not depend on how the store restores them.
+// Fields added after chaining was introduced are omitted when empty so
+// earlier entries keep their original hashes.
+type chainContent struct {
+	Sequence      int64
+	PreviousHash  string
+	ID            string
+	Action        string
+	ResourceType  string
+	ResourceID    string
+	PrincipalType string
+	PrincipalID   string
+	Touches       []string
+	StartedAt     string
+	CompletedAt   string
+	Success       bool
+	Error         string
+	Changes       []ChangeRow `json:",omitempty"`
+	CorrelationID string      `json:",omitempty"`
+}
+
+func chainHash(row AuditEntryRow) string {
+	touches := make([]string, 0, len(row.Touches))
+	for _, touch := range row.Touches {
+		touches = append(touches, touch.String())
+	}
+	content, err := json.Marshal(chainContent{
+		Sequence:      row.Sequence,
+		PreviousHash:  row.PreviousHash,
+		ID:            row.ID,
+		Action:        row.Action,
+		ResourceType:  row.ResourceType,
+		ResourceID:    row.ResourceID,
+		PrincipalType: row.PrincipalType,
+		PrincipalID:   row.PrincipalID,
+		Touches:       touches,
+		StartedAt:     row.StartedAt.UTC().Format(time.RFC3339Nano),
+		CompletedAt:   row.CompletedAt.UTC().Format(time.RFC3339Nano),
+		Success:       row.Success,
+		Error:         row.Error,
+		Changes:       row.Changes,
+		CorrelationID: row.CorrelationID,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("encode audit chain content: %v", err))
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func chainHead(tx *store.Tx) (ChainHeadRow, error) {
+	head := ChainHeadRow{ID: chainHeadID}
+	err := tx.Get(&head)
+	if errors.Is(err, store.ErrAbsent) {
+		return ChainHeadRow{ID: chainHeadID}, nil
+	}
+	return head, err
+}
+
+// link appends row to the chain ending at h and advances h to it.
+func (h *ChainHeadRow) link(row *AuditEntryRow) {
+	row.Sequence = h.Sequence + 1
+	row.PreviousHash = h.Hash
+	row.Hash = chainHash(*row)
+	h.Sequence, h.Hash = row.Sequence, row.Hash
+}
+
+// base is the archived entry the oldest remaining entry links to, or the
+// zero row before the first entry when nothing has been archived.
+func (h ChainHeadRow) base() AuditEntryRow {
+	return AuditEntryRow{Sequence: h.BaseSequence, Hash: h.BaseHash}
+}
+
+// saveChainHead stores head, which was absent when previous is zero.
+func saveChainHead(tx *store.Tx, previous int64, head ChainHeadRow) error {
+	if previous == 0 {
+		return tx.Insert(&head)
+	}
+	return tx.Update(&head)
+}
+
+// Verify walks the chain in sequence order and reports the first entry whose
+// position, link, or contents disagree with the chain, then checks that the
+// recorded head matches the last entry.
+func (d *DAO) Verify(ctx store.Context) (*models.ChainVerification, error) {
+	var result models.ChainVerification
+	err := d.store.ReadContext(ctx, func(tx *store.Tx) error {
+		head, err := chainHead(tx)
+		if err != nil {
+			return store.MapError(err, "read audit chain head")
+		}
+		result.Head = head.Hash
+		result.Archived = head.BaseSequence
+
+		previous := head.base()
+		q := store.QueryTx[AuditEntryRow](tx).SortAsc("Sequence", "ID")
+		for row, err := range q.All() {
+			if err != nil {
+				return store.MapError(err, "iterate audit entries")
+			}
+			if reason := brokenLink(previous, row); reason != "" {
+				result.Broken = &models.BrokenLink{Sequence: row.Sequence, EntryID: row.ID, Reason: reason}
+				return nil
+			}
+			previous = row
+			result.Entries++
+		}
+		if previous.Sequence != head.Sequence || previous.Hash != head.Hash {
+			result.Broken = &models.BrokenLink{
+				Sequence: previous.Sequence + 1,
+				Reason: fmt.Sprintf("chain head records entry %d with hash %s but the log ends at entry %d",
+					head.Sequence, head.Hash, previous.Sequence),
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func brokenLink(previous, row AuditEntryRow) string {
+	switch {
+	case row.Sequence == 0:
+		return "entry is not part of the chain"
+	case row.Sequence <= previous.Sequence:
+		return fmt.Sprintf("sequence %d repeats an earlier entry", row.Sequence)
+	case row.Sequence != previous.Sequence+1:
+		return fmt.Sprintf("entries %d through %d are missing", previous.Sequence+1, row.Sequence-1)
+	case row.PreviousHash != previous.Hash:
+		return "previous hash does not match the preceding entry"
+	case chainHash(row) != row.Hash:
+		return "contents do not match the recorded hash"
+	}
+	return ""
+}
+
+// chainLegacyEntries is an explicit migration for databases written before
+// the audit log was chained. It links their entries in ID order, which is
+// creation order to KSUID precision, and runs only while no chain exists so
+// rows inserted outside the application later cannot be silently adopted.
+func chainLegacyEntries(_ context.Context, tx *store.Tx) error {
+	head, err := chainHead(tx)
+	if err != nil || head.Sequence != 0 {
+		return err
+	}
+	rows, err := store.QueryTx[AuditEntryRow](tx).SortAsc("ID").List()
+	if err != nil {
+		return err
+	}
+	for i := range rows {
+		head.link(&rows[i])
+		if err := tx.Update(&rows[i]); err != nil {
+			return err
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return saveChainHead(tx, 0, head)
+}
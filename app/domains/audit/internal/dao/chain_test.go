@@ -0,0 +1,153 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+
+	auditdao "github.com/TheFellow/go-modular-monolith/app/domains/audit/internal/dao"
+	"github.com/TheFellow/go-modular-monolith/pkg/migrate"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/teststore"
+)
+
+func TestVerifyAcceptsIntactChain(t *testing.T) {
+	t.Parallel()
+
+	d, s, ctx := newDAO(t)
+	ids := sameSecondIDs(t, 3)
+	insertEntries(t, ctx, s, d, ids[2], ids[0], ids[1])
+
+	result, err := d.Verify(ctx)
+	testutil.Ok(t, err)
+	testutil.IsTrue(t, result.Valid())
+	testutil.Equals(t, result.Entries, 3)
+	rows := chainRows(t, ctx, s)
+	testutil.Equals(t, result.Head, rows[2].Hash)
+	testutil.Equals(t, rows[1].PreviousHash, rows[0].Hash)
+}
+
+func TestVerifyReportsFirstBrokenLink(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		tamper   func(tx *store.Tx, rows []auditdao.AuditEntryRow) error
+		sequence int64
+		reason   string
+	}{
+		{
+			name: "edit",
+			tamper: func(tx *store.Tx, rows []auditdao.AuditEntryRow) error {
+				rows[1].Success = true
+				return tx.Update(&rows[1])
+			},
+			sequence: 2,
+			reason:   "contents do not match the recorded hash",
+		},
+		{
+			name: "deletion",
+			tamper: func(tx *store.Tx, rows []auditdao.AuditEntryRow) error {
+				return tx.Delete(&rows[1])
+			},
+			sequence: 3,
+			reason:   "entries 2 through 2 are missing",
+		},
+		{
+			name: "truncation",
+			tamper: func(tx *store.Tx, rows []auditdao.AuditEntryRow) error {
+				return tx.Delete(&rows[3])
+			},
+			sequence: 4,
+			reason:   "chain head records entry 4",
+		},
+		{
+			name: "reordering",
+			tamper: func(tx *store.Tx, rows []auditdao.AuditEntryRow) error {
+				rows[1].Sequence, rows[2].Sequence = rows[2].Sequence, rows[1].Sequence
+				if err := tx.Update(&rows[1]); err != nil {
+					return err
+				}
+				return tx.Update(&rows[2])
+			},
+			sequence: 2,
+			reason:   "previous hash does not match the preceding entry",
+		},
+		{
+			name: "insertion",
+			tamper: func(tx *store.Tx, rows []auditdao.AuditEntryRow) error {
+				forged := rows[3]
+				forged.ID = rows[3].ID + "x"
+				return tx.Insert(&forged)
+			},
+			sequence: 4,
+			reason:   "sequence 4 repeats an earlier entry",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			d, s, ctx := newDAO(t)
+			ids := sameSecondIDs(t, 4)
+			insertEntries(t, ctx, s, d, ids...)
+			rows := chainRows(t, ctx, s)
+			testutil.Ok(t, s.Write(ctx, func(tx *store.Tx) error { return tc.tamper(tx, rows) }))
+
+			result, err := d.Verify(ctx)
+			testutil.Ok(t, err)
+			testutil.IsTrue(t, !result.Valid())
+			testutil.Equals(t, result.Broken.Sequence, tc.sequence)
+			testutil.StringContains(t, result.Broken.Reason, tc.reason)
+		})
+	}
+}
+
+func TestMigrationChainsLegacyEntries(t *testing.T) {
+	t.Parallel()
+
+	ctx := testContext{Context: telemetry.WithMetrics(context.Background(), telemetry.Memory())}
+	path := teststore.Path(t, "legacy")
+	legacy, err := store.Open(ctx, path)
+	testutil.Ok(t, err)
+	legacy.Register(ctx, auditdao.AuditEntryRow{})
+	ids := sameSecondIDs(t, 3)
+	testutil.Ok(t, legacy.Write(ctx, func(tx *store.Tx) error {
+		for _, id := range []string{ids[1], ids[0], ids[2]} {
+			if err := tx.Insert(&auditdao.AuditEntryRow{ID: id}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	testutil.Ok(t, legacy.Close())
+
+	s, err := store.Open(ctx, path)
+	testutil.Ok(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+	auditdao.Register(ctx, s)
+	migrate.Register(ctx, s)
+	migrator, err := migrate.New(s, migrate.Domain{Name: "audit", Migrations: auditdao.Migrations()})
+	testutil.Ok(t, err)
+	_, err = migrator.Up(ctx)
+	testutil.Ok(t, err)
+
+	result, err := auditdao.New(s).Verify(ctx)
+	testutil.Ok(t, err)
+	testutil.IsTrue(t, result.Valid())
+	testutil.Equals(t, result.Entries, 3)
+	rows := chainRows(t, ctx, s)
+	testutil.Equals(t, []string{rows[0].ID, rows[1].ID, rows[2].ID}, ids)
+}
+
+func chainRows(t *testing.T, ctx testContext, s *store.Store) []auditdao.AuditEntryRow {
+	t.Helper()
+	var rows []auditdao.AuditEntryRow
+	testutil.Ok(t, s.Read(ctx, func(tx *store.Tx) error {
+		var err error
+		rows, err = store.QueryTx[auditdao.AuditEntryRow](tx).SortAsc("Sequence").List()
+		return err
+	}))
+	return rows
+}
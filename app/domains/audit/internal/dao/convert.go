@@ -14,24 +14,56 @@ func toRow(e models.AuditEntry) AuditEntryRow {
 		ResourceID:    string(e.Resource.ID),
 		PrincipalType: string(e.Principal.Type),
 		PrincipalID:   string(e.Principal.ID),
+		CorrelationID: e.CorrelationID,
 		Touches:       e.Touches,
+		Changes:       toChangeRows(e.Changes),
 		StartedAt:     e.StartedAt,
 		CompletedAt:   e.CompletedAt,
 		Success:       e.Success,
 		Error:         e.Error,
+		Sequence:      e.Sequence,
+		PreviousHash:  e.PreviousHash,
+		Hash:          e.Hash,
 	}
 }
 
 func toModel(r AuditEntryRow) models.AuditEntry {
 	return models.AuditEntry{
-		ID:          entity.AuditEntryID(cedar.NewEntityUID(models.AuditEntryEntityType, cedar.String(r.ID))),
-		Action:      r.Action,
-		Resource:    cedar.NewEntityUID(cedar.EntityType(r.ResourceType), cedar.String(r.ResourceID)),
-		Principal:   cedar.NewEntityUID(cedar.EntityType(r.PrincipalType), cedar.String(r.PrincipalID)),
-		Touches:     r.Touches,
-		StartedAt:   r.StartedAt,
-		CompletedAt: r.CompletedAt,
-		Success:     r.Success,
-		Error:       r.Error,
+		ID:            entity.AuditEntryID(cedar.NewEntityUID(models.AuditEntryEntityType, cedar.String(r.ID))),
+		Action:        r.Action,
+		Resource:      cedar.NewEntityUID(cedar.EntityType(r.ResourceType), cedar.String(r.ResourceID)),
+		Principal:     cedar.NewEntityUID(cedar.EntityType(r.PrincipalType), cedar.String(r.PrincipalID)),
+		CorrelationID: r.CorrelationID,
+		Touches:       r.Touches,
+		Changes:       toChanges(r.Changes),
+		StartedAt:     r.StartedAt,
+		CompletedAt:   r.CompletedAt,
+		Success:       r.Success,
+		Error:         r.Error,
+		Sequence:      r.Sequence,
+		PreviousHash:  r.PreviousHash,
+		Hash:          r.Hash,
 	}
 }
+
+func toChangeRows(changes []models.Change) []ChangeRow {
+	if len(changes) == 0 {
+		return nil
+	}
+	rows := make([]ChangeRow, 0, len(changes))
+	for _, change := range changes {
+		rows = append(rows, ChangeRow(change))
+	}
+	return rows
+}
+
+func toChanges(rows []ChangeRow) []models.Change {
+	if len(rows) == 0 {
+		return nil
+	}
+	changes := make([]models.Change, 0, len(rows))
+	for _, row := range rows {
+		changes = append(changes, models.Change(row))
+	}
+	return changes
+}
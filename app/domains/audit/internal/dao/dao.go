@@ -3,6 +3,7 @@ package dao
 import (
 	"context"
 
+	"github.com/TheFellow/go-modular-monolith/pkg/migrate"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 )
 
@@ -11,5 +12,12 @@ type DAO struct{ store *store.Store }
 func New(s *store.Store) *DAO { return &DAO{store: s} }
 
 func Register(ctx context.Context, s *store.Store) {
-	s.Register(ctx, AuditEntryRow{})
+	s.Register(ctx, AuditEntryRow{}, ChainHeadRow{})
+}
+
+// Migrations lists the audit domain's data migrations in version order.
+func Migrations() []migrate.Migration {
+	return []migrate.Migration{
+		{Version: 1, Name: "chain legacy entries", Up: chainLegacyEntries},
+	}
 }
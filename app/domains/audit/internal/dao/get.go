@@ -0,0 +1,19 @@
+package dao
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+func (d *DAO) Get(ctx store.Context, id entity.AuditEntryID) (*models.AuditEntry, error) {
+	row := AuditEntryRow{ID: id.String()}
+	err := d.store.ReadContext(ctx, func(tx *store.Tx) error {
+		return tx.Get(&row)
+	})
+	if err != nil {
+		return nil, store.MapError(err, "audit entry %s not found", id.String())
+	}
+	entry := toModel(row)
+	return &entry, nil
+}
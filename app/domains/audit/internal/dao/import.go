@@ -0,0 +1,52 @@
+package dao
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+// Import stores an exported chain verbatim into an empty log. Entries keep
+// their IDs, sequences and hashes, so each must link to the one before it;
+// the first links to whatever base it names, which becomes the new log's
+// archived base.
+func (d *DAO) Import(ctx store.Context, entries []models.AuditEntry) (models.Transfer, error) {
+	var transfer models.Transfer
+	err := store.Write(ctx, func(tx *store.Tx) error {
+		head, err := chainHead(tx)
+		if err != nil {
+			return store.MapError(err, "read audit chain head")
+		}
+		existing, err := store.QueryTx[AuditEntryRow](tx).Count()
+		if err != nil {
+			return store.MapError(err, "count audit entries")
+		}
+		if head.Sequence != 0 || existing != 0 {
+			return errors.Conflictf("audit log already has %d entries; import requires an empty log", existing)
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+
+		first := toRow(entries[0])
+		previous := AuditEntryRow{Sequence: first.Sequence - 1, Hash: first.PreviousHash}
+		head.BaseSequence, head.BaseHash = previous.Sequence, previous.Hash
+		for _, entry := range entries {
+			row := toRow(entry)
+			if reason := brokenLink(previous, row); reason != "" {
+				return errors.Invalidf("audit entry %d %s: %s", row.Sequence, row.ID, reason)
+			}
+			if err := tx.Insert(&row); err != nil {
+				return store.MapError(err, "import audit entry %q", row.ID)
+			}
+			previous = row
+		}
+		head.Sequence, head.Hash = previous.Sequence, previous.Hash
+		if err := saveChainHead(tx, 0, head); err != nil {
+			return store.MapError(err, "save audit chain head")
+		}
+		transfer = models.Transfer{Entries: len(entries), FirstSequence: first.Sequence, LastSequence: head.Sequence, Head: head.Hash}
+		return nil
+	})
+	return transfer, err
+}
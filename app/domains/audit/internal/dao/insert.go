@@ -3,12 +3,20 @@ package dao
 import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
-	"github.com/mjl-/bstore"
 )
 
 func (d *DAO) Insert(ctx store.Context, entry models.AuditEntry) error {
-	return store.Write(ctx, func(tx *bstore.Tx) error {
+	return store.Write(ctx, func(tx *store.Tx) error {
+		head, err := chainHead(tx)
+		if err != nil {
+			return store.MapError(err, "read audit chain head")
+		}
+		previous := head.Sequence
 		row := toRow(entry)
-		return store.MapError(tx.Insert(&row), "insert audit entry %q", row.ID)
+		head.link(&row)
+		if err := tx.Insert(&row); err != nil {
+			return store.MapError(err, "insert audit entry %q", row.ID)
+		}
+		return store.MapError(saveChainHead(tx, previous, head), "advance audit chain head")
 	})
 }
@@ -17,11 +17,41 @@ type AuditEntryRow struct {
 	PrincipalType string `bstore:"index"`
 	PrincipalID   string `bstore:"index"`
 
+	CorrelationID string `bstore:"index"`
+
 	Touches []cedar.EntityUID
+	Changes []ChangeRow
 
 	StartedAt   time.Time `bstore:"index"`
 	CompletedAt time.Time
 
 	Success bool `bstore:"index"`
 	Error   string
+
+	// Sequence, PreviousHash, and Hash link the row into the tamper-evident
+	// chain. Sequence is not unique so verification can report a duplicate
+	// rather than failing to open an edited database.
+	Sequence     int64 `bstore:"index"`
+	PreviousHash string
+	Hash         string
+}
+
+type ChangeRow struct {
+	Path   string
+	Before string
+	After  string
 }
+
+// ChainHeadRow is the single row recording the newest chained entry. Deleting
+// trailing entries therefore breaks agreement between the head and the log.
+// BaseSequence and BaseHash record the last archived entry, which the oldest
+// remaining entry links to.
+type ChainHeadRow struct {
+	ID           int64
+	Sequence     int64
+	Hash         string
+	BaseSequence int64
+	BaseHash     string
+}
+
+const chainHeadID = 1
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	auditauthz "github.com/TheFellow/go-modular-monolith/app/domains/audit/authz"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// Format names the file format of an archive or export. Both write one record
+// per entry in chain order with the entry's sequence and hashes, so external
+// tools can recompute the chain.
+type Format string
+
+const (
+	FormatNDJSON Format = "ndjson"
+	FormatCSV    Format = "csv"
+)
+
+func ParseFormat(value string) (Format, error) {
+	switch format := Format(strings.ToLower(strings.TrimSpace(value))); format {
+	case FormatNDJSON, FormatCSV:
+		return format, nil
+	case "":
+		return FormatNDJSON, nil
+	}
+	return "", errors.Invalidf("invalid audit format %q (expected ndjson or csv)", value)
+}
+
+// RetentionPolicy selects the oldest entries of the chain for archival. An
+// entry qualifies when it started before Before and at least Keep newer
+// entries remain; a zero field does not constrain. Selection stops at the
+// first entry that does not qualify so the remaining log stays one chain.
+type RetentionPolicy struct {
+	Before time.Time
+	Keep   int
+}
+
+func (p RetentionPolicy) Validate() error {
+	if p.Keep < 0 {
+		return errors.Invalidf("keep must not be negative")
+	}
+	if p.Before.IsZero() && p.Keep == 0 {
+		return errors.Invalidf("retention policy requires a cutoff time or a number of entries to keep")
+	}
+	return nil
+}
+
+// Archive moves the oldest entries out of the log into a compressed file. The
+// request sets Policy and Format; the result adds the archived range, whose
+// last hash becomes the base the remaining chain links to.
+type Archive struct {
+	Policy        RetentionPolicy
+	Format        Format
+	Entries       int
+	FirstSequence int64
+	LastSequence  int64
+	LastHash      string
+}
+
+func (a Archive) CedarEntity() cedar.Entity { return chainEntity() }
+
+// Export streams a filtered range of the log without removing it.
+type Export struct {
+	Format     Format
+	Compressed bool
+	Entries    int
+}
+
+func (e Export) CedarEntity() cedar.Entity { return chainEntity() }
+
+// Transfer summarizes a whole chain copied out of one database by a full
+// export or into another by import. FirstSequence is above 1 when the source
+// had archived its oldest entries; the imported chain links to the same base.
+type Transfer struct {
+	Entries       int
+	FirstSequence int64
+	LastSequence  int64
+	Head          string
+}
+
+func (t Transfer) CedarEntity() cedar.Entity { return chainEntity() }
+
+func chainEntity() cedar.Entity {
+	return auditauthz.AuditEntry{UID: cedar.NewEntityUID(auditauthz.AuditEntryType, ChainResourceID)}.CedarEntity()
+}
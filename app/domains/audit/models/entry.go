@@ -17,6 +17,11 @@ type AuditEntry struct {
 	Resource  cedar.EntityUID
 	Principal cedar.EntityUID
 
+	// CorrelationID is shared with the log records and recorded events of the
+	// operation that produced the entry. Entries written before correlation
+	// IDs existed leave it empty.
+	CorrelationID string
+
 	StartedAt   time.Time
 	CompletedAt time.Time
 
@@ -24,6 +29,35 @@ type AuditEntry struct {
 	Error   string
 
 	Touches []cedar.EntityUID
+	Changes []Change
+
+	// Sequence is the entry's position in the audit hash chain. Hash covers
+	// the entry's contents and PreviousHash, the Hash of the entry before it.
+	Sequence     int64
+	PreviousHash string
+	Hash         string
+}
+
+// Change records one field that differs between a command's loaded input and
+// its result. Empty Before or After values mark fields that were added or
+// removed; redacted values read "[redacted]".
+type Change struct {
+	Path   string
+	Before string
+	After  string
+}
+
+// String renders the change as "path: before -> after", showing absent values
+// as "(none)".
+func (c Change) String() string {
+	return c.Path + ": " + changeValue(c.Before) + " -> " + changeValue(c.After)
+}
+
+func changeValue(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
 }
 
 func (e AuditEntry) CedarEntity() cedar.Entity {
@@ -0,0 +1,13 @@
+package models
+
+import cedar "github.com/cedar-policy/cedar-go"
+
+// Undo is the outcome of reversing the command recorded by Entry. Restored
+// lists the entities the compensating commands changed; they record their own
+// audit entries, and the undo's entry names Entry as its resource.
+type Undo struct {
+	Entry    AuditEntry
+	Restored []cedar.EntityUID
+}
+
+func (u Undo) CedarEntity() cedar.Entity { return u.Entry.CedarEntity() }
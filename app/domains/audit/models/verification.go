@@ -0,0 +1,35 @@
+package models
+
+import (
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// ChainResourceID identifies the audit log as a whole when authorizing
+// operations, such as verification and archival, that are not about one entry.
+const ChainResourceID = "chain"
+
+// ChainVerification reports whether the audit hash chain is intact.
+type ChainVerification struct {
+	// Entries counts the entries verified before the first broken link, or all
+	// entries when the chain is intact.
+	Entries int
+	// Head is the hash recorded for the newest entry. Keeping a copy outside
+	// the database lets a later verification detect a rewritten chain.
+	Head string
+	// Archived is the sequence of the last archived entry, which the oldest
+	// remaining entry links to, or zero when nothing has been archived.
+	Archived int64
+	// Broken is the first link that failed verification, if any.
+	Broken *BrokenLink
+}
+
+// BrokenLink locates the first point at which the audit chain stops verifying.
+type BrokenLink struct {
+	Sequence int64
+	EntryID  string
+	Reason   string
+}
+
+func (v ChainVerification) Valid() bool { return v.Broken == nil }
+
+func (v ChainVerification) CedarEntity() cedar.Entity { return chainEntity() }
@@ -3,9 +3,11 @@ package audit
 import (
 	"context"
 
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/internal/commands"
 	"github.com/TheFellow/go-modular-monolith/app/domains/audit/internal/dao"
 	"github.com/TheFellow/go-modular-monolith/app/domains/audit/queries"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/migrate"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 )
 
@@ -14,13 +16,20 @@ func RegisterSchema(ctx context.Context, s *store.Store) {
 }
 
 type Module struct {
+	commands *commands.Commands
 	queries  *queries.Queries
 	pipeline *middleware.Pipeline
 }
 
 func NewModule(s *store.Store, pipeline *middleware.Pipeline) *Module {
 	return &Module{
+		commands: commands.New(s),
 		queries:  queries.New(s),
 		pipeline: pipeline,
 	}
 }
+
+// Migrations returns the audit domain's data migrations for app.New to apply.
+func Migrations() migrate.Domain {
+	return migrate.Domain{Name: "audit", Migrations: dao.Migrations()}
+}
@@ -1,9 +1,11 @@
 package audit_test
 
 import (
+	"io"
 	"testing"
 
 	"github.com/TheFellow/go-modular-monolith/app/domains/audit"
+	auditmodels "github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
 	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
@@ -46,6 +48,29 @@ func TestPermissions_Audit(t *testing.T) {
 				wantCount = 1
 			}
 			testutil.Equals(t, len(entries.Items), wantCount)
+
+			_, err = f.Audit.Verify(ctx)
+			if tc.canRead {
+				testutil.Ok(t, err)
+			} else {
+				testutil.ErrorIsPermission(t, err)
+			}
+
+			_, err = f.Audit.Export(ctx, audit.ExportRequest{}, io.Discard)
+			if tc.canRead {
+				testutil.Ok(t, err)
+			} else {
+				testutil.ErrorIsPermission(t, err)
+			}
+
+			_, err = f.Audit.Archive(ctx, auditmodels.RetentionPolicy{Keep: 1}, auditmodels.FormatNDJSON, func(write func(io.Writer) error) error {
+				return write(io.Discard)
+			})
+			if tc.canRead {
+				testutil.Ok(t, err)
+			} else {
+				testutil.ErrorIsPermission(t, err)
+			}
 		})
 	}
 }
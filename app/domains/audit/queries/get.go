@@ -0,0 +1,11 @@
+package queries
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+func (q *Queries) Get(ctx store.Context, id entity.AuditEntryID) (*models.AuditEntry, error) {
+	return q.dao.Get(ctx, id)
+}
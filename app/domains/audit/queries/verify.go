@@ -0,0 +1,10 @@
+package queries
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+func (q *Queries) Verify(ctx store.Context) (*models.ChainVerification, error) {
+	return q.dao.Verify(ctx)
+}
@@ -16,6 +16,7 @@ type AuditRow struct {
 	Principal   string `table:"PRINCIPAL" json:"principal"`
 	Success     bool   `table:"SUCCESS" json:"success"`
 	Touches     int    `table:"TOUCHES" json:"touches"`
+	Changes     int    `table:"CHANGES" json:"changes"`
 	Error       string `table:"ERROR" json:"error,omitempty"`
 }
 
@@ -33,6 +34,7 @@ func ToAuditRow(entry *models.AuditEntry) AuditRow {
 		Principal:   entry.Principal.String(),
 		Success:     entry.Success,
 		Touches:     len(entry.Touches),
+		Changes:     len(entry.Changes),
 		Error:       entry.Error,
 	}
 }
@@ -58,3 +60,51 @@ func formatTime(t time.Time) string {
 	}
 	return t.Format(time.RFC3339)
 }
+
+// ChainVerification is the CLI view of an audit chain verification.
+type ChainVerification struct {
+	Valid    bool        `json:"valid"`
+	Entries  int         `json:"entries"`
+	Head     string      `json:"head,omitempty"`
+	Archived int64       `json:"archived,omitempty"`
+	Broken   *BrokenLink `json:"broken,omitempty"`
+}
+
+type BrokenLink struct {
+	Sequence int64  `json:"sequence"`
+	EntryID  string `json:"entry_id,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+func ToChainVerification(v *models.ChainVerification) ChainVerification {
+	if v == nil {
+		return ChainVerification{}
+	}
+	out := ChainVerification{Valid: v.Valid(), Entries: v.Entries, Head: v.Head, Archived: v.Archived}
+	if v.Broken != nil {
+		out.Broken = &BrokenLink{Sequence: v.Broken.Sequence, EntryID: v.Broken.EntryID, Reason: v.Broken.Reason}
+	}
+	return out
+}
+
+// Archive is the CLI view of an audit archive.
+type Archive struct {
+	Entries       int    `json:"entries"`
+	Format        string `json:"format"`
+	FirstSequence int64  `json:"first_sequence,omitempty"`
+	LastSequence  int64  `json:"last_sequence,omitempty"`
+	LastHash      string `json:"last_hash,omitempty"`
+}
+
+func ToArchive(a *models.Archive) Archive {
+	if a == nil {
+		return Archive{}
+	}
+	return Archive{
+		Entries:       a.Entries,
+		Format:        string(a.Format),
+		FirstSequence: a.FirstSequence,
+		LastSequence:  a.LastSequence,
+		LastHash:      a.LastHash,
+	}
+}
@@ -54,6 +54,7 @@ type Filter struct {
 type Row struct {
 	Entry   models.AuditEntry
 	Touches []string
+	Changes []string
 	Actions map[actions.ID]actions.State
 }
 
@@ -345,6 +346,10 @@ func rowFromEntry(entry models.AuditEntry) Row {
 		row.Touches = append(row.Touches, touched.String())
 	}
 	sort.Strings(row.Touches)
+	row.Changes = make([]string, 0, len(entry.Changes))
+	for _, change := range entry.Changes {
+		row.Changes = append(row.Changes, change.String())
+	}
 	return row
 }
 
@@ -361,11 +366,13 @@ func (p *Presenter) publish() {
 
 func cloneEntry(entry models.AuditEntry) models.AuditEntry {
 	entry.Touches = append([]cedar.EntityUID(nil), entry.Touches...)
+	entry.Changes = append([]models.Change(nil), entry.Changes...)
 	return entry
 }
 func cloneRow(row Row) Row {
 	row.Entry = cloneEntry(row.Entry)
 	row.Touches = append([]string(nil), row.Touches...)
+	row.Changes = append([]string(nil), row.Changes...)
 	row.Actions = cloneActions(row.Actions)
 	return row
 }
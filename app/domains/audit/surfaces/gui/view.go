@@ -52,13 +52,13 @@ func NewView(p *Presenter) *View {
 		}, nil, func(expression string) { v.applyExpression(expression) })
 	v.expression, v.apply, v.scope = bar.Expression, bar.Apply, bar.Presets[0]
 
-	columns := []string{"Started", "Completed", "Duration", "Action", "Resource", "Principal", "Success", "Touches", "Error", "Actions"}
+	columns := []string{"Started", "Completed", "Duration", "Action", "Resource", "Principal", "Success", "Touches", "Changes", "Error", "Actions"}
 	v.list = ui.NewAutoPagingRowTable(func() (int, int) { return len(v.state.Rows), len(columns) }, func() framework.CanvasObject {
 		return ui.NewActionCell()
 	}, func(id widget.TableCellID, object framework.CanvasObject) {
 		cell := object
 		r := v.state.Rows[id.Row]
-		values := []string{formatTime(r.Entry.StartedAt), formatTime(r.Entry.CompletedAt), formatDuration(r.Entry.StartedAt, r.Entry.CompletedAt), r.Entry.Action, r.Entry.Resource.String(), r.Entry.Principal.String(), strconv.FormatBool(r.Entry.Success), strconv.Itoa(len(r.Touches)), r.Entry.Error}
+		values := []string{formatTime(r.Entry.StartedAt), formatTime(r.Entry.CompletedAt), formatDuration(r.Entry.StartedAt, r.Entry.CompletedAt), r.Entry.Action, r.Entry.Resource.String(), r.Entry.Principal.String(), strconv.FormatBool(r.Entry.Success), strconv.Itoa(len(r.Touches)), strconv.Itoa(len(r.Changes)), r.Entry.Error}
 		if id.Col == len(columns)-1 {
 			index := id.Row
 			projected := r.Actions[audit.ControlView]
@@ -77,7 +77,7 @@ func NewView(p *Presenter) *View {
 			p.Select(id.Row)
 		}
 	}
-	ui.ConfigureRowTable(v.list, []ui.TableColumn{{Title: "Started", Width: 170}, {Title: "Completed", Width: 170}, {Title: "Duration", Width: 90}, {Title: "Action", Width: 210}, {Title: "Resource", Width: 220}, {Title: "Principal", Width: 190}, {Title: "Success", Width: 70}, {Title: "Touches", Width: 65}, {Title: "Error", Width: 240}, {Title: "Actions", Width: 120}}, nil)
+	ui.ConfigureRowTable(v.list, []ui.TableColumn{{Title: "Started", Width: 170}, {Title: "Completed", Width: 170}, {Title: "Duration", Width: 90}, {Title: "Action", Width: 210}, {Title: "Resource", Width: 220}, {Title: "Principal", Width: 190}, {Title: "Success", Width: 70}, {Title: "Touches", Width: 65}, {Title: "Changes", Width: 65}, {Title: "Error", Width: 240}, {Title: "Actions", Width: 120}}, nil)
 	v.empty = ui.EmptyCollection(ui.IconEmpty, "No audit activity found", "Adjust the filter or return later after application activity occurs.")
 	v.listStack = container.NewStack(v.list, v.empty)
 	v.refresh = ui.WithIcon(ui.NewButton(ControlRefresh, "Refresh", p.Refresh), ui.IconRefresh)
@@ -85,11 +85,11 @@ func NewView(p *Presenter) *View {
 	v.browse = ui.StandardListPage(ui.ListPage{Title: "Audit", Filters: bar.Content, CollectionActions: []framework.CanvasObject{v.refresh}, List: v.listStack, Status: v.status}).(*framework.Container)
 
 	v.detailTitle, v.crumbName, v.detailStatus = widget.NewLabel("Audit activity"), widget.NewLabel(""), widget.NewLabel("")
-	labels := []string{"ID", "Action", "Entity", "Actor", "Started", "Completed", "Duration", "Success", "Error", "Touched entities"}
+	labels := []string{"ID", "Action", "Entity", "Actor", "Started", "Completed", "Duration", "Success", "Correlation ID", "Error", "Touched entities", "Changes"}
 	items := make([]framework.CanvasObject, 0, len(labels))
 	for i, label := range labels {
 		entry := ui.NewEntry(fmt.Sprintf("audit.detail.field.%d", i))
-		entry.MultiLine = label == "Error" || label == "Touched entities"
+		entry.MultiLine = label == "Error" || label == "Touched entities" || label == "Changes"
 		entry.OnChanged = func(string) { v.restoreDetail() }
 		v.detailFields = append(v.detailFields, entry)
 		items = append(items, ui.DetailField(label, entry))
@@ -121,6 +121,10 @@ func (v *View) restoreDetail() {
 func (v *View) populateDetail(row Row) {
 	v.rendering = true
 	defer func() { v.rendering = false }()
+	correlation := row.Entry.CorrelationID
+	if correlation == "" {
+		correlation = "(none)"
+	}
 	errorText := row.Entry.Error
 	if strings.TrimSpace(errorText) == "" {
 		errorText = "(none)"
@@ -129,7 +133,11 @@ func (v *View) populateDetail(row Row) {
 	if len(row.Touches) > 0 {
 		touches = strings.Join(row.Touches, "\n")
 	}
-	values := []string{row.Entry.ID.String(), row.Entry.Action, row.Entry.Resource.String(), row.Entry.Principal.String(), formatTime(row.Entry.StartedAt), formatTime(row.Entry.CompletedAt), formatDuration(row.Entry.StartedAt, row.Entry.CompletedAt), strconv.FormatBool(row.Entry.Success), errorText, touches}
+	changes := "(none)"
+	if len(row.Changes) > 0 {
+		changes = strings.Join(row.Changes, "\n")
+	}
+	values := []string{row.Entry.ID.String(), row.Entry.Action, row.Entry.Resource.String(), row.Entry.Principal.String(), formatTime(row.Entry.StartedAt), formatTime(row.Entry.CompletedAt), formatDuration(row.Entry.StartedAt, row.Entry.CompletedAt), strconv.FormatBool(row.Entry.Success), correlation, errorText, touches, changes}
 	for i, value := range values {
 		if v.detailFields[i].Text != value {
 			v.detailFields[i].SetText(value)
@@ -49,6 +49,9 @@ func (d *DetailViewModel) View() string {
 		d.styles.Muted.Render("Completed: " + formatTime(entry.CompletedAt)),
 		d.styles.Subtitle.Render("Success: ") + fmt.Sprintf("%t", entry.Success),
 	}
+	if entry.CorrelationID != "" {
+		lines = append(lines, d.styles.Muted.Render("Correlation: "+entry.CorrelationID))
+	}
 
 	if strings.TrimSpace(entry.Error) != "" {
 		lines = append(lines, "", d.styles.Subtitle.Render("Error"), entry.Error)
@@ -58,6 +61,9 @@ func (d *DetailViewModel) View() string {
 	lines = append(lines, "", d.styles.Subtitle.Render("Touched Entities"))
 	lines = append(lines, touched...)
 
+	lines = append(lines, "", d.styles.Subtitle.Render("Changes"))
+	lines = append(lines, changeLines(entry.Changes)...)
+
 	content := strings.Join(lines, "\n")
 	if d.width > 0 {
 		content = lipgloss.NewStyle().Width(d.width).Render(content)
@@ -82,3 +88,15 @@ func touchedEntities(entities []cedar.EntityUID) []string {
 	}
 	return lines
 }
+
+func changeLines(changes []models.Change) []string {
+	if len(changes) == 0 {
+		return []string{"(none)"}
+	}
+
+	lines := make([]string, 0, len(changes))
+	for _, change := range changes {
+		lines = append(lines, "- "+change.String())
+	}
+	return lines
+}
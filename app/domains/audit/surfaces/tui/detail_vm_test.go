@@ -67,6 +67,28 @@ func TestDetailViewModel_ShowsTouchedEntities(t *testing.T) {
 	testutil.ErrorIf(t, !strings.Contains(view, "- "+touchB.String()), "expected touched entity in view, got:\n%s", view)
 }
 
+func TestDetailViewModel_ShowsChanges(t *testing.T) {
+	t.Parallel()
+	entry := auditmodels.AuditEntry{
+		ID:        entity.NewAuditEntryID(),
+		Action:    "drink.update",
+		Resource:  entity.NewDrinkID().EntityUID(),
+		Principal: authn.Owner(),
+		Changes: []auditmodels.Change{
+			{Path: "Description", After: "Very dry"},
+			{Path: "Recipe.Ingredients[0].Amount", Before: "2.00 oz", After: "2.50 oz"},
+		},
+	}
+
+	detail := audittui.NewDetailViewModel(tuitest.DefaultListViewStyles[tui.ListViewStyles]())
+	detail.SetEntry(optional.Some(entry))
+	detail.SetSize(80, 40)
+
+	view := detail.View()
+	testutil.ErrorIf(t, !strings.Contains(view, "- Description: (none) -> Very dry"), "expected added field in view, got:\n%s", view)
+	testutil.ErrorIf(t, !strings.Contains(view, "- Recipe.Ingredients[0].Amount: 2.00 oz -> 2.50 oz"), "expected changed field in view, got:\n%s", view)
+}
+
 func TestDetailViewModel_NilEntry(t *testing.T) {
 	t.Parallel()
 	detail := audittui.NewDetailViewModel(tuitest.DefaultListViewStyles[tui.ListViewStyles]())
@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// Compensator runs the compensating commands for an audited command. The
+// application supplies it from the compensations its domains register.
+type Compensator func(ctx *middleware.Context, entry models.AuditEntry) ([]cedar.EntityUID, error)
+
+// Undo reverses the command recorded by entry id. The undo is a command of its
+// own: its audit entry names the undone entry as its resource, and the
+// compensating commands it runs are audited separately in the same
+// transaction.
+func (m *Module) Undo(ctx *middleware.Context, id entity.AuditEntryID, compensate Compensator) (*models.Undo, error) {
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.AuditEntry, *models.Undo]{
+		Action:  authz.ActionUndo,
+		Request: id,
+		Load: func(ctx *middleware.Context) (*models.AuditEntry, error) {
+			return m.queries.Get(ctx, id)
+		},
+		Handle: func(ctx *middleware.Context, entry *models.AuditEntry) (*models.Undo, error) {
+			return m.commands.Undo(ctx, entry, compensate)
+		},
+	})
+}
@@ -0,0 +1,18 @@
+package audit
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+// Verify checks the audit hash chain and reports the first entry that was
+// edited, removed, or moved. A broken chain is a successful verification with
+// a Broken link; only failures to read the log are errors.
+func (m *Module) Verify(ctx *middleware.Context) (*models.ChainVerification, error) {
+	return middleware.RunEntityQuery(m.pipeline, ctx, authz.ActionVerify,
+		func(ctx store.Context, _ struct{}) (*models.ChainVerification, error) {
+			return m.queries.Verify(ctx)
+		}, struct{}{})
+}
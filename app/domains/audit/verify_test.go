@@ -0,0 +1,33 @@
+package audit_test
+
+import (
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+func TestVerify_ChainsSuccessfulAndRejectedActivity(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+
+	_, err := f.Ingredients.Create(f.OwnerContext(), &models.Ingredient{
+		Name: "Chained Gin", Category: models.CategorySpirit, Unit: measurement.UnitOz,
+	})
+	testutil.Ok(t, err)
+	_, err = f.Ingredients.Create(f.ActorContext("anonymous"), &models.Ingredient{
+		Name: "Rejected Gin", Category: models.CategorySpirit, Unit: measurement.UnitOz,
+	})
+	testutil.ErrorIsPermission(t, err)
+
+	result, err := f.Audit.Verify(f.OwnerContext())
+	testutil.Ok(t, err)
+	testutil.IsTrue(t, result.Valid())
+	testutil.Equals(t, result.Entries, 2)
+
+	latest := f.LatestAuditEntry(cedar.EntityUID{})
+	testutil.Equals(t, latest.Sequence, int64(2))
+	testutil.Equals(t, result.Head, latest.Hash)
+}
@@ -17,18 +17,45 @@ func NewWriter(s *store.Store) *Writer {
 	return &Writer{dao: dao.New(s)}
 }
 
+// NewActivityID names a command's activity up front with the ID its audit
+// entry will have, so other records written by the command can link to it.
+func NewActivityID() string {
+	return entity.NewAuditEntryID().String()
+}
+
 func (w *Writer) RecordActivity(ctx *middleware.Context, activity middlewareevents.Activity) error {
+	id := entity.NewAuditEntryID()
+	if activity.ID != "" {
+		parsed, err := entity.ParseAuditEntryID(activity.ID)
+		if err != nil {
+			return err
+		}
+		id = parsed
+	}
 	entry := models.AuditEntry{
-		ID:          entity.NewAuditEntryID(),
-		Action:      activity.Action.String(),
-		Resource:    activity.Resource,
-		Principal:   activity.Principal,
-		StartedAt:   activity.StartedAt,
-		CompletedAt: activity.CompletedAt,
-		Success:     activity.Success,
-		Error:       activity.Error,
-		Touches:     activity.Touches,
+		ID:            id,
+		Action:        activity.Action.String(),
+		Resource:      activity.Resource,
+		Principal:     activity.Principal,
+		CorrelationID: activity.CorrelationID,
+		StartedAt:     activity.StartedAt,
+		CompletedAt:   activity.CompletedAt,
+		Success:       activity.Success,
+		Error:         activity.Error,
+		Touches:       activity.Touches,
+		Changes:       toChanges(activity.Changes),
 	}
 
 	return w.dao.Insert(ctx, entry)
 }
+
+func toChanges(changes []middlewareevents.Change) []models.Change {
+	if len(changes) == 0 {
+		return nil
+	}
+	out := make([]models.Change, 0, len(changes))
+	for _, change := range changes {
+		out = append(out, models.Change(change))
+	}
+	return out
+}
@@ -13,11 +13,12 @@ import (
 // Stable control identities let every presentation adapter bind its native
 // controls to the same drink capabilities.
 const (
-	ControlList   actions.ID = "drinks.list"
-	ControlCreate actions.ID = "drinks.create"
-	ControlEdit   actions.ID = "drinks.edit"
-	ControlDelete actions.ID = "drinks.delete"
-	ControlTags   actions.ID = "drinks.tags"
+	ControlList    actions.ID = "drinks.list"
+	ControlCreate  actions.ID = "drinks.create"
+	ControlEdit    actions.ID = "drinks.edit"
+	ControlDelete  actions.ID = "drinks.delete"
+	ControlTags    actions.ID = "drinks.tags"
+	ControlRestore actions.ID = "drinks.restore"
 )
 
 // ActionProjector produces framework-neutral drink control state. Transient
@@ -54,6 +55,12 @@ func (p ActionProjector) Project(ctx context.Context, principal cedar.EntityUID,
 	}
 
 	resource := selected.CedarEntity()
+	if selected.DeletedAt.IsSome() {
+		// A soft-deleted drink can only be brought back; every other action
+		// would fail against the deleted row.
+		declaration.Controls = append(declaration.Controls, actions.Control{ID: ControlRestore, Permission: permission(drinksauthz.ActionRestore, resource)})
+		return actions.Evaluate(ctx, declaration)
+	}
 	declaration.Controls = append(declaration.Controls,
 		actions.Control{ID: ControlEdit, Permission: permission(drinksauthz.ActionUpdate, resource)},
 		actions.Control{ID: ControlDelete, Permission: permission(drinksauthz.ActionDelete, resource)},
@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/TheFellow/go-modular-monolith/app/domains/drinks"
 	drinksauthz "github.com/TheFellow/go-modular-monolith/app/domains/drinks/authz"
 	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
 	"github.com/TheFellow/go-modular-monolith/pkg/authn"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
 	"github.com/TheFellow/go-modular-monolith/pkg/presentation/actions"
 	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
 	cedar "github.com/cedar-policy/cedar-go"
@@ -71,6 +73,22 @@ func TestDrinkActionProjectorPermissionsAreIndependent(t *testing.T) {
 	testutil.Equals(t, byID[drinks.ControlTags].Enabled, true)
 }
 
+func TestDrinkActionProjectorOffersOnlyRestoreForDeletedDrinks(t *testing.T) {
+	t.Parallel()
+	drink := &models.Drink{ID: models.NewDrinkID("deleted-actions"), DeletedAt: optional.Some(time.Now())}
+	states, err := drinks.NewActionProjector().Project(context.Background(), authn.Owner(), drink)
+	testutil.Ok(t, err)
+	testutil.Equals(t, states, []actions.State{
+		{ID: drinks.ControlList, Visible: true, Enabled: true},
+		{ID: drinks.ControlCreate, Visible: true, Enabled: true},
+		{ID: drinks.ControlRestore, Visible: true, Enabled: true},
+	})
+
+	states, err = drinks.NewActionProjector().Project(context.Background(), authn.Sommelier(), drink)
+	testutil.Ok(t, err)
+	testutil.Equals(t, indexDrinkStates(states)[drinks.ControlRestore].Visible, false)
+}
+
 func TestDrinkActionProjectorReturnsEvaluatorErrors(t *testing.T) {
 	t.Parallel()
 	want := errors.New("policy evaluator unavailable")
@@ -20,9 +20,11 @@ const (
 	ResourceType         cedar.EntityType = DrinkType
 	ActionType           cedar.EntityType = "Mixology::Drink::Action"
 	DrinkCategoryAttr                     = "Category"
+	DrinkCreatedByAttr                    = "CreatedBy"
 	DrinkDescriptionAttr                  = "Description"
 	DrinkGlassAttr                        = "Glass"
 	DrinkNameAttr                         = "Name"
+	DrinkOwnerAttr                        = "Owner"
 )
 
 var (
@@ -48,13 +50,17 @@ func ValidateEntity(entity cedar.Entity) error {
 }
 
 var (
-	ActionCreate = cedar.NewEntityUID(ActionType, "create")
-	ActionDelete = cedar.NewEntityUID(ActionType, "delete")
-	ActionGet    = cedar.NewEntityUID(ActionType, "get")
-	ActionList   = cedar.NewEntityUID(ActionType, "list")
-	ActionTag    = cedar.NewEntityUID(ActionType, "tag")
-	ActionUntag  = cedar.NewEntityUID(ActionType, "untag")
-	ActionUpdate = cedar.NewEntityUID(ActionType, "update")
+	ActionCreate   = cedar.NewEntityUID(ActionType, "create")
+	ActionDelete   = cedar.NewEntityUID(ActionType, "delete")
+	ActionExport   = cedar.NewEntityUID(ActionType, "export")
+	ActionGet      = cedar.NewEntityUID(ActionType, "get")
+	ActionImport   = cedar.NewEntityUID(ActionType, "import")
+	ActionList     = cedar.NewEntityUID(ActionType, "list")
+	ActionRestore  = cedar.NewEntityUID(ActionType, "restore")
+	ActionTag      = cedar.NewEntityUID(ActionType, "tag")
+	ActionTransfer = cedar.NewEntityUID(ActionType, "transfer")
+	ActionUntag    = cedar.NewEntityUID(ActionType, "untag")
+	ActionUpdate   = cedar.NewEntityUID(ActionType, "update")
 )
 
 // Drink is the Cedar-facing authorization model for Mixology::Drink.
@@ -62,9 +68,11 @@ type Drink struct {
 	UID         cedar.EntityUID
 	Tags        map[string]string
 	Category    string
+	CreatedBy   cedar.EntityUID
 	Description string
 	Glass       string
 	Name        string
+	Owner       cedar.EntityUID
 }
 
 // CedarEntity converts m to the entity shape declared in schema.cedarschema.
@@ -79,9 +87,11 @@ func (m Drink) CedarEntity() cedar.Entity {
 		Parents: cedar.NewEntityUIDSet(),
 		Attributes: cedar.NewRecord(cedar.RecordMap{
 			DrinkCategoryAttr:    cedar.String(m.Category),
+			DrinkCreatedByAttr:   cedar.NewEntityUID("Mixology::Actor", m.CreatedBy.ID),
 			DrinkDescriptionAttr: cedar.String(m.Description),
 			DrinkGlassAttr:       cedar.String(m.Glass),
 			DrinkNameAttr:        cedar.String(m.Name),
+			DrinkOwnerAttr:       cedar.NewEntityUID("Mixology::Actor", m.Owner.ID),
 		}),
 		Tags: cedar.NewRecord(tags),
 	}
@@ -19,9 +19,11 @@ func TestDrinkCedarEntity(t *testing.T) {
 		UID:         cedar.NewEntityUID("Wrong::Type", "test-id"),
 		Tags:        map[string]string{"audience": "members", "featured": ""},
 		Category:    "test-category",
+		CreatedBy:   cedar.NewEntityUID("Mixology::Actor", "test-createdby"),
 		Description: "test-description",
 		Glass:       "test-glass",
 		Name:        "test-name",
+		Owner:       cedar.NewEntityUID("Mixology::Actor", "test-owner"),
 	}
 
 	got := model.CedarEntity()
@@ -30,9 +32,11 @@ func TestDrinkCedarEntity(t *testing.T) {
 		Parents: cedar.NewEntityUIDSet(),
 		Attributes: cedar.NewRecord(cedar.RecordMap{
 			moduleauthz.DrinkCategoryAttr:    cedar.String("test-category"),
+			moduleauthz.DrinkCreatedByAttr:   cedar.NewEntityUID("Mixology::Actor", "test-createdby"),
 			moduleauthz.DrinkDescriptionAttr: cedar.String("test-description"),
 			moduleauthz.DrinkGlassAttr:       cedar.String("test-glass"),
 			moduleauthz.DrinkNameAttr:        cedar.String("test-name"),
+			moduleauthz.DrinkOwnerAttr:       cedar.NewEntityUID("Mixology::Actor", "test-owner"),
 		}),
 		Tags: cedar.NewRecord(cedar.RecordMap{
 			"audience": cedar.String("members"),
@@ -8,7 +8,8 @@ import (
 
 func (m *Module) Create(ctx *middleware.Context, drink *models.Drink) (*models.Drink, error) {
 	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Drink, *models.Drink]{
-		Action: authz.ActionCreate,
+		Action:  authz.ActionCreate,
+		Request: drink,
 		Load: func(*middleware.Context) (*models.Drink, error) {
 			return drink, nil
 		},
@@ -9,7 +9,8 @@ import (
 
 func (m *Module) Delete(ctx *middleware.Context, id entity.DrinkID) (*models.Drink, error) {
 	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Drink, *models.Drink]{
-		Action: authz.ActionDelete,
+		Action:  authz.ActionDelete,
+		Request: id,
 		Load: func(ctx *middleware.Context) (*models.Drink, error) {
 			return m.queries.Get(ctx, id)
 		},
@@ -0,0 +1,17 @@
+package events
+
+import (
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+)
+
+// DrinkRestored reports a soft-deleted drink returning to the catalog.
+// RetiredIngredients lists required recipe ingredients retired while it was
+// deleted; when present the drink returns as review_required.
+type DrinkRestored struct {
+	Drink              models.Drink
+	RestoredAt         time.Time
+	RetiredIngredients []entity.IngredientID
+}
@@ -0,0 +1,26 @@
+package drinks
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/authz"
+	drinksdao "github.com/TheFellow/go-modular-monolith/app/domains/drinks/internal/dao"
+	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// ExportAll returns every drink, deleted ones included, with its tags.
+func (m *Module) ExportAll(ctx *middleware.Context) ([]*models.Drink, error) {
+	return middleware.RunCollectQuery(m.pipeline, ctx, authz.ActionExport, m.queries.List, drinksdao.ListFilter{IncludeDeleted: true})
+}
+
+// Import recreates a drink exported from another database. Its ID, status,
+// ownership, version and tags are kept.
+func (m *Module) Import(ctx *middleware.Context, drink *models.Drink) (*models.Drink, error) {
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Drink, *models.Drink]{
+		Action:  authz.ActionImport,
+		Request: drink,
+		Load: func(*middleware.Context) (*models.Drink, error) {
+			return drink, nil
+		},
+		Handle: m.commands.Import,
+	})
+}
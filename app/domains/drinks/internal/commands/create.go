@@ -6,6 +6,7 @@ import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/events"
 	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/ownership"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
 )
@@ -54,8 +55,9 @@ func (c *Commands) Create(ctx *middleware.Context, drink *models.Drink) (*models
 	created := *drink
 	created.ID = entity.NewDrinkID()
 	created.Status = models.StatusActive
+	created.Ownership = ownership.New(ctx.Principal())
 
-	if err := c.dao.Insert(ctx, created); err != nil {
+	if err := c.dao.Insert(ctx, &created); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// Import recreates an exported drink without raising DrinkCreated. Its recipe
+// may name ingredients that have since been retired, so references are left
+// to the caller, which sees the whole export.
+func (c *Commands) Import(ctx *middleware.Context, drink *models.Drink) (*models.Drink, error) {
+	if drink == nil {
+		return nil, errors.Invalidf("drink is required")
+	}
+	if drink.ID.IsZero() {
+		return nil, errors.Invalidf("id is required for import")
+	}
+	if strings.TrimSpace(drink.Name) == "" {
+		return nil, errors.Invalidf("drink %s: name is required", drink.ID.String())
+	}
+	for _, validate := range []func() error{drink.Category.Validate, drink.Glass.Validate, drink.Status.Validate, drink.Recipe.Validate} {
+		if err := validate(); err != nil {
+			return nil, errors.Invalidf("drink %s: %w", drink.ID.String(), err)
+		}
+	}
+
+	imported := *drink
+	imported.Version = max(imported.Version, 1)
+	if err := c.dao.Import(ctx, imported); err != nil {
+		return nil, err
+	}
+	ctx.TouchEntity(imported.ID.EntityUID())
+	return &imported, nil
+}
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"slices"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/events"
+	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// Restore returns a soft-deleted drink to the catalog. Ingredients retired
+// while it was deleted are handled as retirement would have handled them:
+// retired substitutes and optional ingredients are dropped, and a retired
+// required ingredient returns the drink as review_required.
+func (c *Commands) Restore(ctx *middleware.Context, drink *models.Drink) (*models.Drink, error) {
+	if drink == nil {
+		return nil, errors.Invalidf("drink is required")
+	}
+	if !drink.DeletedAt.IsSome() {
+		return nil, errors.FailedPreconditionf("drink %s is not deleted", drink.ID.String())
+	}
+
+	var ids []cedar.String
+	for _, ingredient := range drink.Recipe.Ingredients {
+		ids = append(ids, ingredient.IngredientID.EntityUID().ID)
+		for _, substitute := range ingredient.Substitutes {
+			ids = append(ids, substitute.EntityUID().ID)
+		}
+	}
+	active, err := c.ingredients.ActiveIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	retired := func(id entity.IngredientID) bool { return !active.Contains(id.EntityUID().ID) }
+
+	restored := *drink
+	restored.DeletedAt = optional.None[time.Time]()
+	restored.Recipe.Ingredients = make([]models.RecipeIngredient, 0, len(drink.Recipe.Ingredients))
+	var retiredRequired []entity.IngredientID
+	for _, ingredient := range drink.Recipe.Ingredients {
+		ingredient.Substitutes = slices.DeleteFunc(slices.Clone(ingredient.Substitutes), retired)
+		if retired(ingredient.IngredientID) {
+			if ingredient.Optional {
+				continue
+			}
+			retiredRequired = append(retiredRequired, ingredient.IngredientID)
+		}
+		restored.Recipe.Ingredients = append(restored.Recipe.Ingredients, ingredient)
+	}
+	if len(retiredRequired) > 0 {
+		restored.Status = models.StatusReviewRequired
+	}
+
+	if err := c.dao.Update(ctx, &restored); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	ctx.TouchEntity(restored.ID.EntityUID())
+	ctx.AddEvent(events.DrinkRestored{
+		Drink:              restored,
+		RestoredAt:         now,
+		RetiredIngredients: retiredRequired,
+	})
+	return &restored, nil
+}
@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+func (c *Commands) TransferOwnership(ctx *middleware.Context, drink *models.Drink, owner cedar.EntityUID) (*models.Drink, error) {
+	if drink == nil {
+		return nil, errors.Invalidf("drink is required")
+	}
+	if drink.ID.IsZero() {
+		return nil, errors.Invalidf("id is required")
+	}
+
+	transferred, err := drink.Ownership.TransferTo(owner)
+	if err != nil {
+		return nil, err
+	}
+	updated := *drink
+	updated.Ownership = transferred
+
+	if err := c.dao.Update(ctx, &updated); err != nil {
+		return nil, err
+	}
+
+	ctx.TouchEntity(updated.ID.EntityUID())
+	return &updated, nil
+}
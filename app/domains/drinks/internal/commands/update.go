@@ -53,11 +53,15 @@ func (c *Commands) Update(ctx *middleware.Context, drink *models.Drink) (*models
 	}
 
 	updated := *drink
+	if updated.Version == 0 {
+		updated.Version = existing.Version
+	}
 	updated.Tags = existing.Tags
+	updated.Ownership = existing.Ownership
 	updated.Status = models.StatusActive
 	updated.Description = strings.TrimSpace(updated.Description)
 
-	if err := c.dao.Update(ctx, updated); err != nil {
+	if err := c.dao.Update(ctx, &updated); err != nil {
 		return nil, err
 	}
 
@@ -6,6 +6,7 @@ import (
 	drinksmodels "github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/ownership"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/optional"
 	cedar "github.com/cedar-policy/cedar-go"
@@ -24,7 +25,10 @@ func toRow(d drinksmodels.Drink) DrinkRow {
 		Recipe:      toRecipeRow(d.Recipe),
 		Description: d.Description,
 		Status:      string(d.Status),
+		CreatedBy:   d.Ownership.CreatedByID(),
+		Owner:       d.Ownership.OwnerID(),
 		DeletedAt:   deletedAt,
+		Version:     d.Version,
 	}
 }
 
@@ -47,7 +51,9 @@ func toModel(r DrinkRow) (drinksmodels.Drink, error) {
 		Recipe:      toRecipeModel(r.Recipe),
 		Description: r.Description,
 		Status:      status,
+		Ownership:   ownership.Restore(r.CreatedBy, r.Owner),
 		DeletedAt:   deletedAt,
+		Version:     r.Version,
 	}, nil
 }
 
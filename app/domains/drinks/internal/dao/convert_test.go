@@ -7,7 +7,7 @@ import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
 	apperrors "github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
-	"github.com/mjl-/bstore"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/teststore"
 	"github.com/stretchr/testify/require"
 )
 
@@ -19,20 +19,22 @@ func TestToModelRejectsInvalidPersistedStatusAsInternal(t *testing.T) {
 	require.Contains(t, err.Error(), "invalid persisted status")
 }
 
-func TestRegisterExplicitlyBackfillsLegacyStatus(t *testing.T) {
+func TestMigrationBackfillsLegacyStatus(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
-	s, err := store.Open(ctx, t.TempDir()+"/legacy.db")
+	s, err := store.Open(ctx, teststore.Path(t, "legacy"))
 	require.NoError(t, err)
 	t.Cleanup(func() { require.NoError(t, s.Close()) })
 
 	s.Register(ctx, DrinkRow{})
-	require.NoError(t, s.Write(ctx, func(tx *bstore.Tx) error {
+	require.NoError(t, s.Write(ctx, func(tx *store.Tx) error {
 		return tx.Insert(&DrinkRow{ID: "legacy", Name: "Legacy"})
 	}))
 
-	require.NoError(t, backfillLegacyStatuses(ctx, s))
-	require.NoError(t, s.Read(ctx, func(tx *bstore.Tx) error {
+	require.NoError(t, s.Write(ctx, func(tx *store.Tx) error {
+		return backfillLegacyStatuses(ctx, tx)
+	}))
+	require.NoError(t, s.Read(ctx, func(tx *store.Tx) error {
 		row := DrinkRow{ID: "legacy"}
 		require.NoError(t, tx.Get(&row))
 		require.Equal(t, string(models.StatusActive), row.Status)
@@ -7,13 +7,36 @@ import (
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 	cedar "github.com/cedar-policy/cedar-go"
-	"github.com/mjl-/bstore"
 )
 
 func (d *DAO) Get(ctx store.Context, id entity.DrinkID) (*models.Drink, error) {
+	drink, err := d.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if drink.DeletedAt.IsSome() {
+		return nil, errors.NotFoundf("drink %s not found", id.String())
+	}
+	return drink, nil
+}
+
+// GetDeleted loads a soft-deleted drink. Active and missing drinks are not
+// found.
+func (d *DAO) GetDeleted(ctx store.Context, id entity.DrinkID) (*models.Drink, error) {
+	drink, err := d.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !drink.DeletedAt.IsSome() {
+		return nil, errors.NotFoundf("deleted drink %s not found", id.String())
+	}
+	return drink, nil
+}
+
+func (d *DAO) get(ctx store.Context, id entity.DrinkID) (*models.Drink, error) {
 	var row DrinkRow
 	var tagsByTarget map[cedar.EntityUID]tag.Tags
-	err := d.store.ReadContext(ctx, func(tx *bstore.Tx) error {
+	err := d.store.ReadContext(ctx, func(tx *store.Tx) error {
 		row = DrinkRow{ID: id.String()}
 		if err := tx.Get(&row); err != nil {
 			return err
@@ -25,9 +48,6 @@ func (d *DAO) Get(ctx store.Context, id entity.DrinkID) (*models.Drink, error) {
 	if err != nil {
 		return nil, store.MapError(err, "drink %s not found", id.String())
 	}
-	if row.DeletedAt != nil {
-		return nil, errors.NotFoundf("drink %s not found", id.String())
-	}
 	drink, err := toModel(row)
 	if err != nil {
 		return nil, err
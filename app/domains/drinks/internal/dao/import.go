@@ -0,0 +1,24 @@
+package dao
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+// Import stores a drink exactly as another database held it, keeping its ID,
+// status, ownership, version, deletion time and tags. A taken ID or name
+// conflicts.
+func (d *DAO) Import(ctx store.Context, drink models.Drink) error {
+	return store.Write(ctx, func(tx *store.Tx) error {
+		row := toRow(drink)
+		row.Version = max(row.Version, 1)
+		if err := tx.Insert(&row); err != nil {
+			return store.MapError(err, "import drink %s %q", drink.ID.String(), drink.Name)
+		}
+		if len(drink.Tags) == 0 {
+			return nil
+		}
+		_, err := d.tags.Replace(ctx, drink.ID.EntityUID(), drink.Tags)
+		return err
+	})
+}
@@ -3,12 +3,17 @@ package dao
 import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
-	"github.com/mjl-/bstore"
 )
 
-func (d *DAO) Insert(ctx store.Context, drink models.Drink) error {
-	return store.Write(ctx, func(tx *bstore.Tx) error {
-		row := toRow(drink)
-		return store.MapError(tx.Insert(&row), "insert drink %q", drink.Name)
+// Insert stores a new drink at version 1 and records that on drink.
+func (d *DAO) Insert(ctx store.Context, drink *models.Drink) error {
+	return store.Write(ctx, func(tx *store.Tx) error {
+		row := toRow(*drink)
+		row.Version = 1
+		if err := tx.Insert(&row); err != nil {
+			return store.MapError(err, "insert drink %q", drink.Name)
+		}
+		drink.Version = row.Version
+		return nil
 	})
 }
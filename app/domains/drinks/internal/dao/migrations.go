@@ -0,0 +1,32 @@
+package dao
+
+import (
+	"context"
+
+	drinksmodels "github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/migrate"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+// Migrations lists the drinks domain's data migrations in version order.
+func Migrations() []migrate.Migration {
+	return []migrate.Migration{
+		{Version: 1, Name: "backfill legacy statuses", Up: backfillLegacyStatuses},
+		{Version: 2, Name: "backfill row versions", Up: backfillVersions},
+	}
+}
+
+// backfillLegacyStatuses marks drinks created before lifecycle state was
+// persisted as active. Conversion remains strict so future corrupt or unknown
+// values cannot masquerade as active Drinks.
+func backfillLegacyStatuses(_ context.Context, tx *store.Tx) error {
+	_, err := store.QueryTx[DrinkRow](tx).FilterEqual("Status", "").UpdateField("Status", string(drinksmodels.StatusActive))
+	return err
+}
+
+// backfillVersions gives drinks stored before optimistic concurrency version 1,
+// the version every insert now starts at.
+func backfillVersions(_ context.Context, tx *store.Tx) error {
+	_, err := store.QueryTx[DrinkRow](tx).FilterEqual("Version", int64(0)).UpdateField("Version", int64(1))
+	return err
+}
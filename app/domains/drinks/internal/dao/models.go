@@ -14,7 +14,10 @@ type DrinkRow struct {
 	Recipe      RecipeRow
 	Description string
 	Status      string `bstore:"index"`
+	CreatedBy   string
+	Owner       string `bstore:"index"`
 	DeletedAt   *time.Time
+	Version     int64
 }
 
 type RecipeRow struct {
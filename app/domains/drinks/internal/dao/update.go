@@ -3,12 +3,25 @@ package dao
 import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
-	"github.com/mjl-/bstore"
 )
 
-func (d *DAO) Update(ctx store.Context, drink models.Drink) error {
-	return store.Write(ctx, func(tx *bstore.Tx) error {
-		row := toRow(drink)
-		return store.MapError(tx.Update(&row), "update drink %s", drink.ID.String())
+// Update replaces the stored drink only while it is still at drink.Version,
+// then advances drink.Version to the version written.
+func (d *DAO) Update(ctx store.Context, drink *models.Drink) error {
+	return store.Write(ctx, func(tx *store.Tx) error {
+		row := toRow(*drink)
+		current := DrinkRow{ID: row.ID}
+		if err := tx.Get(&current); err != nil {
+			return store.MapError(err, "update drink %s", drink.ID.String())
+		}
+		if err := store.CheckVersion(current.Version, drink.Version, "drink %s", drink.ID.String()); err != nil {
+			return err
+		}
+		row.Version++
+		if err := tx.Update(&row); err != nil {
+			return store.MapError(err, "update drink %s", drink.ID.String())
+		}
+		drink.Version = row.Version
+		return nil
 	})
 }
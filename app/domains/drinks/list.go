@@ -20,6 +20,9 @@ type ListRequest struct {
 	Filter   string
 	Cursor   paging.Cursor
 	Limit    int
+
+	// IncludeDeleted lists soft-deleted drinks alongside active ones.
+	IncludeDeleted bool
 }
 
 func (m *Module) List(ctx *middleware.Context, req ListRequest) (paging.Page[*models.Drink], error) {
@@ -40,6 +43,8 @@ func (m *Module) List(ctx *middleware.Context, req ListRequest) (paging.Page[*mo
 		Category:   req.Category,
 		Glass:      req.Glass,
 		Expression: expression,
+
+		IncludeDeleted: req.IncludeDeleted,
 	}
 	return middleware.RunPageQuery(
 		m.pipeline, ctx, authz.ActionList,
@@ -5,6 +5,7 @@ import (
 
 	drinkauthz "github.com/TheFellow/go-modular-monolith/app/domains/drinks/authz"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/ownership"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/optional"
@@ -25,8 +26,12 @@ type Drink struct {
 	Recipe      Recipe
 	Description string
 	Status      Status
+	Ownership   ownership.Ownership
 	DeletedAt   optional.Value[time.Time]
 	Tags        tag.Tags
+	// Version counts writes to the stored drink. Updates carrying a stale
+	// Version fail with a Conflict.
+	Version int64
 }
 
 type Status string
@@ -55,5 +60,6 @@ func (d Drink) CedarEntity() cedar.Entity {
 	return drinkauthz.Drink{
 		UID: d.ID.EntityUID(), Name: d.Name, Category: string(d.Category),
 		Glass: string(d.Glass), Description: d.Description, Tags: d.Tags.Map(),
+		CreatedBy: d.Ownership.CreatedBy, Owner: d.Ownership.Owner,
 	}.CedarEntity()
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/tagging"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/migrate"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 )
 
@@ -28,3 +29,8 @@ func NewModule(ctx context.Context, s *store.Store, tags tag.Repository, targets
 	m.registerTagTarget(targets)
 	return m
 }
+
+// Migrations returns the drinks domain's data migrations for app.New to apply.
+func Migrations() migrate.Domain {
+	return migrate.Domain{Name: "drinks", Migrations: dao.Migrations()}
+}
@@ -0,0 +1,74 @@
+package drinks_test
+
+import (
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	ingredientsmodels "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/ownership"
+	"github.com/TheFellow/go-modular-monolith/pkg/authn"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestOwnership_CreateRecordsCreatorAndUpdatePreservesIt(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	bartender := f.ActorContext("bartender")
+
+	base := testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{
+		Name: "Ownership Base", Category: ingredientsmodels.CategoryJuice, Unit: measurement.UnitOz,
+	})
+	input := drinkForPolicy("Ownership Sour", models.DrinkCategoryCocktail, base.ID)
+	input.Ownership = ownership.New(authn.Manager())
+	created, err := f.Drinks.Create(bartender, &input)
+	testutil.Ok(t, err)
+	testutil.Equals(t, created.Ownership, ownership.New(authn.Bartender()))
+
+	edit := *created
+	edit.Ownership = ownership.New(authn.Sommelier())
+	edit.Description = "edited"
+	updated, err := f.Drinks.Update(bartender, &edit)
+	testutil.Ok(t, err)
+	testutil.Equals(t, updated.Ownership, ownership.New(authn.Bartender()))
+
+	stored, err := f.Drinks.Get(f.OwnerContext(), created.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, stored.Ownership, ownership.New(authn.Bartender()))
+}
+
+func TestOwnership_DelegatedOwnerCanEditOutsideUsualCategory(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	bartender := f.ActorContext("bartender")
+	sommelier := f.ActorContext("sommelier")
+
+	base := testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{
+		Name: "Delegation Base", Category: ingredientsmodels.CategoryJuice, Unit: measurement.UnitOz,
+	})
+	drink := testutil.CreateDrink(t, f, drinkForPolicy("Delegated Cocktail", models.DrinkCategoryCocktail, base.ID))
+
+	_, err := f.Drinks.Get(sommelier, drink.ID)
+	testutil.ErrorIsPermission(t, err)
+	_, err = f.Drinks.TransferOwnership(bartender, drink.ID, authn.Sommelier())
+	testutil.ErrorIsPermission(t, err)
+
+	transferred, err := f.Drinks.TransferOwnership(f.ActorContext("manager"), drink.ID, authn.Sommelier())
+	testutil.Ok(t, err)
+	testutil.Equals(t, transferred.Ownership, ownership.Ownership{CreatedBy: authn.Owner(), Owner: authn.Sommelier()})
+
+	loaded, err := f.Drinks.Get(sommelier, drink.ID)
+	testutil.Ok(t, err)
+	loaded.Description = "Sommelier notes"
+	updated, err := f.Drinks.Update(sommelier, loaded)
+	testutil.Ok(t, err)
+	testutil.Equals(t, updated.Description, "Sommelier notes")
+
+	_, err = f.Drinks.Delete(sommelier, drink.ID)
+	testutil.ErrorIsPermission(t, err)
+
+	entry := f.LatestAuditEntry(authz.ActionTransfer)
+	testutil.Equals(t, entry.Principal, authn.Manager())
+	testutil.AuditTouches(t, entry, drink.ID.EntityUID())
+}
@@ -9,3 +9,8 @@ import (
 func (q *Queries) Get(ctx store.Context, id entity.DrinkID) (*models.Drink, error) {
 	return q.dao.Get(ctx, id)
 }
+
+// GetDeleted loads a soft-deleted drink for restore.
+func (q *Queries) GetDeleted(ctx store.Context, id entity.DrinkID) (*models.Drink, error) {
+	return q.dao.GetDeleted(ctx, id)
+}
@@ -0,0 +1,21 @@
+package drinks
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// Restore brings back a soft-deleted drink with the tags it had. Menus it was
+// removed from are not changed.
+func (m *Module) Restore(ctx *middleware.Context, id entity.DrinkID) (*models.Drink, error) {
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Drink, *models.Drink]{
+		Action:  authz.ActionRestore,
+		Request: id,
+		Load: func(ctx *middleware.Context) (*models.Drink, error) {
+			return m.queries.GetDeleted(ctx, id)
+		},
+		Handle: m.commands.Restore,
+	})
+}
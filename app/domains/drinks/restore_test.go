@@ -0,0 +1,85 @@
+package drinks_test
+
+import (
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/drinks"
+	drinksauthz "github.com/TheFellow/go-modular-monolith/app/domains/drinks/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	ingredientsmodels "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestDrinks_RestoreReturnsDeletedDrinkForReviewWhenIngredientRetired(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	ctx := f.OwnerContext()
+
+	gin := testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{Name: "Gin", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitOz})
+	tonic := testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{Name: "Tonic", Category: ingredientsmodels.CategoryMixer, Unit: measurement.UnitOz})
+	lime := testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{Name: "Lime Wedge", Category: ingredientsmodels.CategoryGarnish, Unit: measurement.UnitPiece})
+	drink := testutil.CreateDrink(t, f, models.Drink{
+		Name: "Gin and Tonic", Category: models.DrinkCategoryHighball, Glass: models.GlassTypeHighball,
+		Recipe: models.Recipe{
+			Ingredients: []models.RecipeIngredient{
+				{IngredientID: gin.ID, Amount: measurement.MustAmount(2, measurement.UnitOz)},
+				{IngredientID: tonic.ID, Amount: measurement.MustAmount(4, measurement.UnitOz)},
+				{IngredientID: lime.ID, Amount: measurement.MustAmount(1, measurement.UnitPiece), Optional: true},
+			},
+			Steps: []string{"Build over ice"},
+		},
+	})
+
+	_, err := f.Drinks.Delete(ctx, drink.ID)
+	testutil.Ok(t, err)
+	_, err = f.Ingredients.Retire(ctx, tonic.ID, ingredientsmodels.Retirement{})
+	testutil.Ok(t, err)
+	_, err = f.Ingredients.Retire(ctx, lime.ID, ingredientsmodels.Retirement{})
+	testutil.Ok(t, err)
+
+	restored, err := f.Drinks.Restore(ctx, drink.ID)
+	testutil.Ok(t, err)
+	testutil.ErrorIf(t, restored.DeletedAt.IsSome(), "expected DeletedAt to be cleared")
+	testutil.Equals(t, restored.Status, models.StatusReviewRequired)
+	testutil.Equals(t, len(restored.Recipe.Ingredients), 2)
+
+	got, err := f.Drinks.Get(ctx, drink.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, got.Status, models.StatusReviewRequired)
+	testutil.AuditTouches(t, f.LatestAuditEntry(drinksauthz.ActionRestore), drink.ID.EntityUID())
+}
+
+func TestDrinks_RestoreRejectsActiveAndUnknownDrinks(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	ctx := f.OwnerContext()
+	ingredient := testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{Name: "Rum", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitOz})
+	drink := testutil.CreateDrink(t, f, drinkForPolicy("Daiquiri", models.DrinkCategoryCocktail, ingredient.ID))
+
+	_, err := f.Drinks.Restore(ctx, drink.ID)
+	testutil.ErrorIsNotFound(t, err)
+	_, err = f.Drinks.Restore(ctx, entity.NewDrinkID())
+	testutil.ErrorIsNotFound(t, err)
+}
+
+func TestDrinks_ListIncludesDeletedOnRequest(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	ctx := f.OwnerContext()
+	ingredient := testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{Name: "Rum", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitOz})
+	kept := testutil.CreateDrink(t, f, drinkForPolicy("Daiquiri", models.DrinkCategoryCocktail, ingredient.ID))
+	deleted := testutil.CreateDrink(t, f, drinkForPolicy("Mojito", models.DrinkCategoryCocktail, ingredient.ID))
+	_, err := f.Drinks.Delete(ctx, deleted.ID)
+	testutil.Ok(t, err)
+
+	page, err := f.Drinks.List(ctx, drinks.ListRequest{})
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(page.Items), 1)
+	testutil.Equals(t, page.Items[0].ID, kept.ID)
+
+	page, err = f.Drinks.List(ctx, drinks.ListRequest{IncludeDeleted: true})
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(page.Items), 2)
+}
@@ -19,7 +19,11 @@ type Drink struct {
 	Status      string               `json:"status,omitempty"`
 	Description string               `json:"description,omitempty"`
 	Recipe      Recipe               `json:"recipe"`
+	CreatedBy   string               `json:"created_by,omitempty"`
+	Owner       string               `json:"owner,omitempty"`
+	DeletedAt   string               `json:"deleted_at,omitempty"`
 	Tags        tag.CanonicalStrings `json:"tags"`
+	Version     int64                `json:"version,omitempty"`
 }
 
 func FromDomainDrink(d models.Drink) Drink {
@@ -31,7 +35,11 @@ func FromDomainDrink(d models.Drink) Drink {
 		Status:      string(d.Status),
 		Description: d.Description,
 		Recipe:      FromDomainRecipe(d.Recipe),
+		CreatedBy:   d.Ownership.CreatedByID(),
+		Owner:       d.Ownership.OwnerID(),
+		DeletedAt:   formatDeletedAt(&d),
 		Tags:        d.Tags.Canonical(),
+		Version:     d.Version,
 	}
 }
 
@@ -76,6 +84,7 @@ func (d Drink) ToDomainForUpdate() (models.Drink, error) {
 		Glass:       models.GlassType(d.Glass),
 		Recipe:      recipe,
 		Description: d.Description,
+		Version:     d.Version,
 	}
 
 	if err := out.Category.Validate(); err != nil {
@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"time"
+
 	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
 )
@@ -12,6 +14,7 @@ type DrinkRow struct {
 	Glass       string               `table:"GLASS" json:"glass"`
 	Status      string               `table:"STATUS" json:"status"`
 	Ingredients int                  `table:"INGREDIENTS" json:"ingredients"`
+	DeletedAt   string               `table:"DELETED_AT" json:"deleted_at,omitempty"`
 	Tags        tag.CanonicalStrings `table:"TAGS" json:"tags"`
 }
 
@@ -26,10 +29,18 @@ func ToDrinkRow(d *models.Drink) DrinkRow {
 		Glass:       string(d.Glass),
 		Status:      string(d.Status),
 		Ingredients: len(d.Recipe.Ingredients),
+		DeletedAt:   formatDeletedAt(d),
 		Tags:        d.Tags.Canonical(),
 	}
 }
 
+func formatDeletedAt(d *models.Drink) string {
+	if t, ok := d.DeletedAt.Unwrap(); ok {
+		return t.Format(time.RFC3339)
+	}
+	return ""
+}
+
 func ToDrinkRows(items []*models.Drink) []DrinkRow {
 	rows := make([]DrinkRow, 0, len(items))
 	for _, item := range items {
@@ -39,6 +39,8 @@ const (
 type Filter struct {
 	Name, Category, Glass, Expression string
 	Limit                             int
+	// IncludeDeleted lists soft-deleted drinks so they can be restored.
+	IncludeDeleted bool
 }
 type RecipeRow struct {
 	Ingredient  entity.IngredientID
@@ -62,6 +64,7 @@ type State struct {
 	FormInstance                                            uint64
 	Loading, Submitting                                     bool
 	CanList, CanCreate, CanUpdate, CanDelete, CanTag, Dirty bool
+	CanRestore                                              bool
 	Actions                                                 map[actions.ID]actions.State
 	Items                                                   []*models.Drink
 	Selected                                                *models.Drink
@@ -138,7 +141,7 @@ func (p *Presenter) loadPage(appendPage bool) {
 	cursor := p.state.Cursor
 	p.load.LoadContext(p.app.Context(), func(ctx context.Context) (drinkCatalog, error) {
 		op := p.app.ContextFrom(ctx)
-		page, err := p.app.Drinks.List(op, domain.ListRequest{Name: f.Name, Category: models.DrinkCategory(strings.TrimSpace(f.Category)), Glass: models.GlassType(strings.TrimSpace(f.Glass)), Filter: f.Expression, Cursor: cursor, Limit: f.Limit})
+		page, err := p.app.Drinks.List(op, domain.ListRequest{Name: f.Name, Category: models.DrinkCategory(strings.TrimSpace(f.Category)), Glass: models.GlassType(strings.TrimSpace(f.Glass)), Filter: f.Expression, Cursor: cursor, Limit: f.Limit, IncludeDeleted: f.IncludeDeleted})
 		if err != nil {
 			return drinkCatalog{}, err
 		}
@@ -391,6 +394,15 @@ func (p *Presenter) Delete() {
 		}
 	})
 }
+
+// Restore brings the selected soft-deleted drink back to the catalog.
+func (p *Presenter) Restore() bool {
+	target := cloneDrink(p.state.Selected)
+	if target == nil || !p.actionEnabled(domain.ControlRestore) {
+		return false
+	}
+	return p.mutate(func() error { _, err := p.app.Drinks.Restore(p.app.Context(), target.ID); return err })
+}
 func countMenusWithDrink(items []*menusmodels.Menu, id entity.DrinkID) int {
 	n := 0
 	for _, menu := range items {
@@ -428,6 +440,11 @@ func (p *Presenter) mutate(work func() error) bool {
 	p.publish()
 	accepted := p.submit.Submit(work, func(err error) {
 		p.state.Submitting = false
+		if errors.IsConflict(err) && p.state.Mode == Editing && p.offerMerge() {
+			p.state.Err = ui.PresentError(err)
+			p.publish()
+			return
+		}
 		if err != nil {
 			p.fail(err)
 			return
@@ -460,6 +477,39 @@ func (p *Presenter) mutate(work func() error) bool {
 	}
 	return accepted
 }
+
+// offerMerge asks whether to reload a drink that changed while it was being
+// edited. Accepting rebases the unsaved edits onto the latest version. It
+// reports false when the latest version cannot be loaded.
+func (p *Presenter) offerMerge() bool {
+	base := cloneDrink(p.state.Selected)
+	if base == nil || p.dialogs == nil {
+		return false
+	}
+	latest, err := p.app.Drinks.Get(p.app.Context(), base.ID)
+	if err != nil {
+		return false
+	}
+	edited := cloneForm(p.state.Form)
+	message := fmt.Sprintf("%q was changed by someone else while you were editing.\n\nReload the latest version and keep your edits?", base.Name)
+	p.dialogs.Confirm("Drink Changed", message, func(ok bool) {
+		if !ok || p.state.Selected == nil || p.state.Selected.ID != latest.ID {
+			return
+		}
+		p.state.Selected = cloneDrink(latest)
+		p.state.Form = ui.MergeForm(formFromDrink(base), edited, formFromDrink(latest))
+		p.state.Dirty = !reflect.DeepEqual(p.state.Form, formFromDrink(latest))
+		p.state.FormInstance++
+		p.state.Err = nil
+		for i, item := range p.state.Items {
+			if item.ID == latest.ID {
+				p.state.Items[i] = cloneDrink(latest)
+			}
+		}
+		p.publish()
+	})
+	return true
+}
 func (p *Presenter) fail(err error) {
 	p.state.Err = ui.PresentError(err)
 	ui.ShowPresentation(p.dialogs, err)
@@ -480,7 +530,7 @@ func (p *Presenter) permissions() {
 
 func (p *Presenter) permissionsFor(drink *models.Drink) error {
 	p.state.Actions = nil
-	p.state.CanList, p.state.CanCreate, p.state.CanUpdate, p.state.CanDelete, p.state.CanTag = false, false, false, false, false
+	p.state.CanList, p.state.CanCreate, p.state.CanUpdate, p.state.CanDelete, p.state.CanTag, p.state.CanRestore = false, false, false, false, false, false
 	states, err := p.projector.Project(p.app.Context(), p.app.Context().Principal(), drink)
 	if err != nil {
 		return err
@@ -494,6 +544,7 @@ func (p *Presenter) permissionsFor(drink *models.Drink) error {
 	p.state.CanUpdate = p.state.Actions[domain.ControlEdit].Visible
 	p.state.CanDelete = p.state.Actions[domain.ControlDelete].Visible
 	p.state.CanTag = p.state.Actions[domain.ControlTags].Visible
+	p.state.CanRestore = p.state.Actions[domain.ControlRestore].Visible
 	return nil
 }
 
@@ -554,7 +605,7 @@ func (p *Presenter) formDrink() (*models.Drink, error) {
 	}
 	d := &models.Drink{Name: name, Category: category, Glass: glass, Description: description, Recipe: recipe}
 	if p.state.Mode == Editing && p.state.Selected != nil {
-		d.ID = p.state.Selected.ID
+		d.ID, d.Version = p.state.Selected.ID, p.state.Selected.Version
 	}
 	return d, nil
 }
@@ -325,6 +325,55 @@ func TestAcceptedSubmitCannotBeCancelledAndSaveRequiresAForm(t *testing.T) {
 	testutil.Equals(t, p.State().Mode, Browsing)
 }
 
+func TestShowDeletedListsDeletedDrinksAndRestoreBringsThemBack(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	f, _, drink := fixtureDrink(t, "Retired")
+	_, err := f.Drinks.Delete(f.OwnerContext(), drink.ID)
+	testutil.Ok(t, err)
+	p := NewPresenter(f.App, Dependencies{Executor: appgui.InlineExecutor{}, Dispatcher: appgui.InlineDispatcher{}})
+	v := NewView(p)
+	driver := fynetest.NewDriver(t, v.Content())
+	p.Refresh()
+	testutil.Equals(t, len(p.State().Items), 0)
+
+	driver.Tap(ControlFilterDeleted)
+	testutil.Equals(t, p.State().Filter.IncludeDeleted, true)
+	testutil.Equals(t, len(p.State().Items), 1)
+	p.Select(0)
+	testutil.Equals(t, p.State().Mode, Viewing)
+	testutil.Equals(t, p.State().CanRestore, true)
+	testutil.Equals(t, p.State().CanDelete, false)
+	for _, action := range v.detailActions {
+		testutil.Equals(t, action.Hidden, action.SemanticID() != ControlRestore)
+	}
+
+	driver.Tap(ControlRestore)
+	got, err := f.Drinks.Get(f.OwnerContext(), drink.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, got.DeletedAt.IsNone(), true)
+	testutil.AuditTouches(t, f.LatestAuditEntry(authz.ActionRestore), drink.EntityUID())
+	testutil.Equals(t, p.State().Mode, Browsing)
+
+	p.ResetList()
+	testutil.Equals(t, p.State().Filter.IncludeDeleted, false)
+	testutil.Equals(t, v.showDeleted.Checked, false)
+}
+
+func TestRestoreRequiresProjectedPermission(t *testing.T) {
+	f, _, drink := fixtureDrink(t, "Guarded")
+	_, err := f.Drinks.Delete(f.OwnerContext(), drink.ID)
+	testutil.Ok(t, err)
+	deleted, err := f.Drinks.List(f.OwnerContext(), domain.ListRequest{IncludeDeleted: true})
+	testutil.Ok(t, err)
+	readOnly := appcore.NewSession(f.ActorContext("anonymous"), f.App.App)
+	p := NewPresenter(readOnly, Dependencies{Executor: appgui.InlineExecutor{}, Dispatcher: appgui.InlineDispatcher{}})
+	p.state.Items = deleted.Items
+	p.Select(0)
+	testutil.Equals(t, p.State().CanRestore, false)
+	testutil.Equals(t, p.Restore(), false)
+}
+
 func TestDeleteOpensOnlyOneConfirmationUntilResponse(t *testing.T) {
 	f, _, drink := fixtureDrink(t, "Existing")
 	testutil.CreateMenu(t, f, "Featured", testutil.WithDrink(drink))
@@ -342,6 +391,33 @@ func TestDeleteOpensOnlyOneConfirmationUntilResponse(t *testing.T) {
 	testutil.Equals(t, len(dialogs.Confirmations()), 2)
 }
 
+func TestStaleEditOffersReloadAndKeepsLocalEdits(t *testing.T) {
+	f, _, drink := fixtureDrink(t, "Negroni")
+	dialogs := &fynetest.Dialogs{}
+	p := NewPresenter(f.App, Dependencies{Executor: appgui.InlineExecutor{}, Dispatcher: appgui.InlineDispatcher{}, Dialogs: dialogs})
+	p.state.Items = []*models.Drink{drink}
+	p.Select(0)
+	elsewhere := *drink
+	elsewhere.Description = "Bitter and bright"
+	_, err := f.Drinks.Update(f.OwnerContext(), &elsewhere)
+	testutil.Ok(t, err)
+
+	form := p.State().Form
+	form.Name = "Negroni Sbagliato"
+	p.SetForm(form)
+	testutil.Equals(t, p.Save(), true)
+	testutil.Equals(t, len(dialogs.Confirmations()), 1)
+	dialogs.Confirmations()[0].Respond(true)
+	testutil.Equals(t, p.State().Form.Name, "Negroni Sbagliato")
+	testutil.Equals(t, p.State().Form.Description, "Bitter and bright")
+
+	testutil.Equals(t, p.Save(), true)
+	got, err := f.Drinks.Get(f.OwnerContext(), drink.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, got.Name, "Negroni Sbagliato")
+	testutil.Equals(t, got.Description, "Bitter and bright")
+}
+
 func TestRecipeRowsRequireStructuredIngredientSelection(t *testing.T) {
 	_, err := parseRecipe(Form{Recipe: []RecipeRow{{Amount: "1", Unit: measurement.UnitOz}}, Steps: "Stir"})
 	testutil.ErrorIf(t, err == nil, "%v", "recipe row with unexpected fields was accepted")
@@ -720,7 +796,8 @@ func TestCLIWorkflowAndFyneSharePersistenceContract(t *testing.T) {
 	ctx = pkglog.ToContext(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)))
 	database, err := store.Open(ctx, filepath.Join(dir, "data", "mixology.db"))
 	testutil.Ok(t, err)
-	application := appcore.New(ctx, appcore.Config{Store: database})
+	application, err := appcore.New(ctx, appcore.Config{Store: database})
+	testutil.Ok(t, err)
 	session := appcore.NewSession(ctx, application)
 	p := NewPresenter(session, Dependencies{Executor: appgui.InlineExecutor{}, Dispatcher: appgui.InlineDispatcher{}})
 	p.Refresh()
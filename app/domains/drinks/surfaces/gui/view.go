@@ -40,11 +40,13 @@ const (
 	ControlEdit             = "drinks.edit"
 	ControlDelete           = "drinks.delete"
 	ControlTags             = "drinks.tags"
+	ControlRestore          = "drinks.restore"
 	ControlApplyFilter      = "drinks.filter.apply"
 	ControlFilterName       = "drinks.filter.name"
 	ControlFilterCategory   = "drinks.filter.category"
 	ControlFilterGlass      = "drinks.filter.glass"
 	ControlFilterExpression = "drinks.filter.expression"
+	ControlFilterDeleted    = "drinks.filter.deleted"
 	ControlName             = "drinks.form.name"
 	ControlCategory         = "drinks.form.category"
 	ControlGlass            = "drinks.form.glass"
@@ -117,6 +119,7 @@ type View struct {
 	browse, formPanel, tagsPanel      *framework.Container
 	filterExpression                  *ui.SemanticEntry
 	filterBar                         *ui.FilterBar
+	showDeleted                       *ui.SemanticCheck
 	name, description, steps, garnish *ui.SemanticEntry
 	tags, mutationTags                *ui.TagTokenEditor
 	category, glass                   *semanticSelect
@@ -147,10 +150,20 @@ func NewView(p *Presenter) *View {
 	for _, glass := range glassOptions() {
 		glassPresets = append(glassPresets, ui.FilterOption{Label: glass, Expression: fmt.Sprintf(`glass == %q`, glass)})
 	}
+	v.showDeleted = ui.NewCheck(ControlFilterDeleted, "Show deleted", func(on bool) {
+		if v.rendering {
+			return
+		}
+		f := p.State().Filter
+		f.IncludeDeleted = on
+		if p.SetFilter(f) {
+			p.Refresh()
+		}
+	})
 	bar := ui.NewSingleRowFilterBar(ControlFilterExpression, ControlApplyFilter, `Filter drinks (for example: name.contains("martini"))`, p.State().Filter.Expression,
 		[]ui.FilterPreset{{ID: ControlFilterCategory, Placeholder: "Category", Options: categoryPresets}, {ID: ControlFilterGlass, Placeholder: "Glass", Options: glassPresets}},
-		nil, func(expression string) {
-			if p.SetFilter(Filter{Expression: expression, Limit: ui.PageLimit}) {
+		v.showDeleted, func(expression string) {
+			if p.SetFilter(Filter{Expression: expression, Limit: ui.PageLimit, IncludeDeleted: v.showDeleted.Checked}) {
 				p.Refresh()
 			}
 		})
@@ -163,7 +176,11 @@ func NewView(p *Presenter) *View {
 	}, func(id widget.TableCellID, o framework.CanvasObject) {
 		cell := o
 		item := p.State().Items[id.Row]
-		values := []string{item.Name, string(item.Category), string(item.Glass), string(item.Status), strconv.Itoa(len(item.Recipe.Ingredients)), item.Tags.Canonical().String()}
+		status := string(item.Status)
+		if item.DeletedAt.IsSome() {
+			status = "deleted"
+		}
+		values := []string{item.Name, string(item.Category), string(item.Glass), status, strconv.Itoa(len(item.Recipe.Ingredients)), item.Tags.Canonical().String()}
 		if id.Col == len(columns)-1 {
 			index := id.Row
 			ui.ShowCellActions(cell, []ui.RowAction{{Label: "View", Run: func() { p.Select(index) }}})
@@ -187,7 +204,8 @@ func NewView(p *Presenter) *View {
 	edit := ui.NewButton(ControlEdit, "Edit", p.StartEdit)
 	tagsAction := ui.NewButton(ControlTags, "Tags", p.StartTags)
 	deleteAction := ui.Destructive(ui.WithIcon(ui.NewButton(ControlDelete, "Delete", p.Delete), ui.IconDelete))
-	v.detailActions = []*ui.SemanticButton{tagsAction, deleteAction}
+	restoreAction := ui.Primary(ui.NewButton(ControlRestore, "Restore", func() { p.Restore() }))
+	v.detailActions = []*ui.SemanticButton{tagsAction, deleteAction, restoreAction}
 	v.status = widget.NewLabel("")
 	v.browse = ui.StandardListPage(ui.ListPage{
 		Title: "Drinks", Filters: filters,
@@ -225,7 +243,8 @@ func NewView(p *Presenter) *View {
 	edit.Hide()
 	tagsAction.Hide()
 	deleteAction.Hide()
-	breadcrumb := container.NewHBox(back, crumb, widget.NewLabel(">"), v.crumbName, edit, tagsAction, deleteAction)
+	restoreAction.Hide()
+	breadcrumb := container.NewHBox(back, crumb, widget.NewLabel(">"), v.crumbName, edit, tagsAction, deleteAction, restoreAction)
 	v.formPanel = ui.StandardFormPage(ui.FormPage{TitleLabel: v.detailTitle, Breadcrumb: breadcrumb, Fields: fields, Status: v.formStatus, Save: v.save, Cancel: v.cancel}).(*framework.Container)
 	v.tags = ui.NewTagTokenEditor(ControlTagValues, "")
 	v.tags.Normalize = tag.UpsertCollection
@@ -326,6 +345,9 @@ func (v *View) render(state State) {
 	v.formPanel.Hidden = state.Mode != Creating && state.Mode != Editing && state.Mode != Viewing
 	v.tagsPanel.Hidden = state.Mode != Tagging
 	v.create.Hidden = !state.CanCreate
+	if v.showDeleted.Checked != state.Filter.IncludeDeleted {
+		v.showDeleted.SetChecked(state.Filter.IncludeDeleted)
+	}
 	formChanged := !v.formRendered || v.renderedMode != state.Mode || v.renderedFormInstance != state.FormInstance || !reflect.DeepEqual(v.renderedForm, state.Form)
 	if (state.Mode == Creating || state.Mode == Editing || state.Mode == Viewing) && formChanged {
 		v.name.SetText(state.Form.Name)
@@ -405,6 +427,8 @@ func (v *View) render(state State) {
 			allowed = allowed && state.CanTag
 		case ControlDelete:
 			allowed = allowed && state.CanDelete
+		case ControlRestore:
+			allowed = allowed && state.CanRestore
 		}
 		action.Hidden = !allowed
 		action.Refresh()
@@ -9,8 +9,11 @@ import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/drinks"
 	drinksauthz "github.com/TheFellow/go-modular-monolith/app/domains/drinks/authz"
 	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	ingredientmodels "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/tuitest"
 	cedar "github.com/cedar-policy/cedar-go"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -80,3 +83,30 @@ func TestActionProjectionEvaluatorErrorRecovers(t *testing.T) {
 	testutil.Equals(t, vm.actionEnabled(drinks.ControlList), true)
 	testutil.Equals(t, vm.actionEnabled(drinks.ControlCreate), true)
 }
+
+func TestRestoreKeyBringsBackSelectedDeletedDrink(t *testing.T) {
+	fix := testutil.NewFixture(t)
+	ingredient := testutil.CreateIngredient(t, fix, ingredientmodels.Ingredient{Name: "Restore base", Category: ingredientmodels.CategoryOther, Unit: measurement.UnitOz})
+	drink := testutil.CreateDrink(t, fix, models.Drink{Name: "Restorable", Category: models.DrinkCategoryCocktail, Glass: models.GlassTypeCoupe, Recipe: models.Recipe{Ingredients: []models.RecipeIngredient{{IngredientID: ingredient.ID, Amount: measurement.MustAmount(1, measurement.UnitOz)}}, Steps: []string{"Stir"}}})
+	_, err := fix.Drinks.Delete(fix.OwnerContext(), drink.ID)
+	testutil.Ok(t, err)
+
+	reader := NewListViewModel(application.NewSession(fix.ActorContext("anonymous"), fix.App.App))
+	reader.request.IncludeDeleted = true
+	tuitest.NewDriver(t, &drinksPagingProgram{vm: reader}).Press("b")
+	testutil.Equals(t, reader.actionEnabled(drinks.ControlRestore), false)
+	_, err = fix.Drinks.Get(fix.OwnerContext(), drink.ID)
+	testutil.ErrorIf(t, err == nil, "%v", "read-only actor restored the drink")
+
+	program := &drinksPagingProgram{vm: NewListViewModel(fix.App)}
+	program.vm.request.IncludeDeleted = true
+	driver := tuitest.NewDriver(t, program)
+	testutil.Equals(t, len(program.vm.list.Items()), 1)
+	testutil.Equals(t, program.vm.actionEnabled(drinks.ControlRestore), true)
+	testutil.Equals(t, program.vm.actionEnabled(drinks.ControlEdit), false)
+	driver.Press("b")
+	restored, err := fix.Drinks.Get(fix.OwnerContext(), drink.ID)
+	testutil.Ok(t, err)
+	testutil.ErrorIf(t, restored.DeletedAt.IsSome(), "%v", "drink was not restored")
+	testutil.Equals(t, program.vm.actionEnabled(drinks.ControlRestore), false)
+}
@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	ingredientmodels "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestEditDrinkMergesConcurrentChangeAfterConflict(t *testing.T) {
+	t.Parallel()
+	fix := testutil.NewFixture(t)
+	gin := testutil.CreateIngredient(t, fix, ingredientmodels.Ingredient{Name: "Gin", Category: ingredientmodels.CategorySpirit, Unit: measurement.UnitOz})
+	opened := testutil.CreateDrink(t, fix, models.Drink{Name: "Martini", Category: models.DrinkCategoryCocktail, Glass: models.GlassTypeCoupe, Recipe: models.Recipe{
+		Ingredients: []models.RecipeIngredient{{IngredientID: gin.ID, Amount: measurement.MustAmount(2, measurement.UnitOz)}},
+		Steps:       []string{"Stir"},
+	}})
+	vm := NewEditDrinkVM(fix.App, opened)
+	vm.recipe.AcceptCatalog(vm.recipe.Init()().(ingredientCatalogLoadedMsg))
+
+	elsewhere := *opened
+	elsewhere.Description = "Bone dry"
+	_, err := fix.Drinks.Update(fix.OwnerContext(), &elsewhere)
+	testutil.Ok(t, err)
+
+	_ = vm.nameField.SetValue("Dry Martini")
+	msg := vm.submit()()
+	conflict, ok := msg.(UpdateConflictMsg)
+	testutil.ErrorIf(t, !ok, "submit = %#v", msg)
+	vm, _ = vm.Update(conflict)
+	testutil.ErrorIsConflict(t, vm.err)
+	testutil.StringContains(t, vm.err.Error(), "reloaded version 2, submit again")
+
+	msg = vm.submit()()
+	updated, ok := msg.(DrinkUpdatedMsg)
+	testutil.ErrorIf(t, !ok, "resubmit = %#v", msg)
+	testutil.Equals(t, updated.Drink.Name, "Dry Martini")
+	testutil.Equals(t, updated.Drink.Description, "Bone dry")
+	testutil.Equals(t, updated.Drink.Recipe, opened.Recipe)
+}
@@ -44,6 +44,13 @@ type UpdateErrorMsg struct {
 	Err error
 }
 
+// UpdateConflictMsg is sent when the drink changed after the form was opened.
+// Latest is the stored drink to merge the form onto.
+type UpdateConflictMsg struct {
+	Err    error
+	Latest *models.Drink
+}
+
 // NewEditDrinkVM builds an EditDrinkVM with fields configured.
 func NewEditDrinkVM(app *app.Session, drink *models.Drink) *EditDrinkVM {
 	if drink == nil {
@@ -132,6 +139,10 @@ func (m *EditDrinkVM) Update(msg tea.Msg) (*EditDrinkVM, tea.Cmd) {
 		m.submitting = false
 		m.err = typed.Err
 		return m, nil
+	case UpdateConflictMsg:
+		m.submitting = false
+		m.err = m.rebase(typed.Latest)
+		return m, nil
 	case DrinkUpdatedMsg:
 		m.submitting = false
 		m.err = nil
@@ -214,6 +225,11 @@ func (m *EditDrinkVM) submit() tea.Cmd {
 		drink, err := app.RunTaggedMutation(m.app.App, m.context(), desired, func(ctx *middleware.Context) (*models.Drink, error) {
 			return m.app.Drinks.Update(ctx, &updated)
 		})
+		if errors.IsConflict(err) {
+			if latest, getErr := m.app.Drinks.Get(m.context(), updated.ID); getErr == nil {
+				return UpdateConflictMsg{Err: err, Latest: latest}
+			}
+		}
 		if err != nil {
 			return UpdateErrorMsg{Err: err}
 		}
@@ -221,6 +237,38 @@ func (m *EditDrinkVM) submit() tea.Cmd {
 	}
 }
 
+// rebase moves the form onto latest after a version conflict, keeping the
+// fields the user edited and taking the stored values for the rest.
+func (m *EditDrinkVM) rebase(latest *models.Drink) error {
+	base := m.drink
+	fields := []struct {
+		field        forms.Field
+		base, latest any
+	}{
+		{m.nameField, base.Name, latest.Name},
+		{m.category, base.Category, latest.Category},
+		{m.glass, base.Glass, latest.Glass},
+		{m.description, base.Description, latest.Description},
+		{m.recipe, base.Recipe, latest.Recipe},
+		{m.tags, base.Tags.Canonical().String(), latest.Tags.Canonical().String()},
+	}
+	var contested []string
+	for _, f := range fields {
+		kept, err := forms.Rebase(f.field, f.base, f.latest)
+		if err != nil {
+			return err
+		}
+		if kept {
+			contested = append(contested, f.field.Label())
+		}
+	}
+	m.drink = latest
+	if len(contested) == 0 {
+		return errors.Conflictf("drink changed while editing; reloaded version %d, submit again to apply your edits", latest.Version)
+	}
+	return errors.Conflictf("drink changed while editing; reloaded version %d, your edits to %s replace newer values, submit again to keep them", latest.Version, strings.Join(contested, ", "))
+}
+
 func (m *EditDrinkVM) context() *middleware.Context {
 	return m.app.Context()
 }
@@ -20,6 +20,7 @@ type filterVM struct {
 	name, expression *forms.TextField
 	category, glass  *forms.SelectField
 	limit            *forms.NumberField
+	deleted          *forms.SelectField
 	err              error
 }
 
@@ -42,8 +43,12 @@ func newFilterVM(req drinks.ListRequest) *filterVM {
 		glass:      forms.NewSelectField("Glass", glasses, forms.WithInitialValue(req.Glass)),
 		expression: forms.NewTextField("Expression", forms.WithInitialValue(req.Filter)),
 		limit:      forms.NewNumberField("Page size", forms.WithRequired(), forms.WithMin(1), forms.WithInitialValue(limit)),
+		deleted: forms.NewSelectField("Deleted", []forms.SelectOption{
+			{Label: "hide", Value: false},
+			{Label: "show", Value: true},
+		}, forms.WithInitialValue(req.IncludeDeleted)),
 	}
-	v.form = forms.New(styles.Standard.Form, keys.Standard.Form, v.name, v.category, v.glass, v.expression, v.limit)
+	v.form = forms.New(styles.Standard.Form, keys.Standard.Form, v.name, v.category, v.glass, v.expression, v.limit, v.deleted)
 	return v
 }
 
@@ -70,6 +75,6 @@ func (v *filterVM) Request() (drinks.ListRequest, error) {
 		v.err = fmt.Errorf("page size must be greater than zero")
 		return drinks.ListRequest{}, v.err
 	}
-	return drinks.ListRequest{Name: strings.TrimSpace(fmt.Sprint(v.name.Value())), Category: v.category.Value().(models.DrinkCategory), Glass: v.glass.Value().(models.GlassType), Filter: strings.TrimSpace(fmt.Sprint(v.expression.Value())), Limit: limit}, nil
+	return drinks.ListRequest{Name: strings.TrimSpace(fmt.Sprint(v.name.Value())), Category: v.category.Value().(models.DrinkCategory), Glass: v.glass.Value().(models.GlassType), Filter: strings.TrimSpace(fmt.Sprint(v.expression.Value())), Limit: limit, IncludeDeleted: v.deleted.Value().(bool)}, nil
 }
 func filterSubmit(msg tea.KeyMsg) bool { return key.Matches(msg, keys.Standard.Form.Submit) }
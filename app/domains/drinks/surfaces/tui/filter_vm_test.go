@@ -26,7 +26,7 @@ func (p *drinksPagingProgram) View() string { return p.vm.View() }
 
 func TestFilterVMComposesEveryStructuredFieldAndExpression(t *testing.T) {
 	t.Parallel()
-	want := drinks.ListRequest{Name: "Martini", Category: models.DrinkCategoryCocktail, Glass: models.GlassTypeCoupe, Filter: `tags contains "featured"`, Limit: 17}
+	want := drinks.ListRequest{Name: "Martini", Category: models.DrinkCategoryCocktail, Glass: models.GlassTypeCoupe, Filter: `tags contains "featured"`, Limit: 17, IncludeDeleted: true}
 	form := newFilterVM(want)
 	got, err := form.Request()
 	testutil.Ok(t, err)
@@ -10,5 +10,9 @@ import (
 type drinkItem = tui.ListItem[models.Drink]
 
 func newDrinkItem(drink models.Drink) drinkItem {
-	return tui.NewListItem(drink, drink.Name, fmt.Sprintf("%s • %s • %s", drink.Category, drink.Glass, drink.Status), drink.Name)
+	status := string(drink.Status)
+	if drink.DeletedAt.IsSome() {
+		status = "deleted"
+	}
+	return tui.NewListItem(drink, drink.Name, fmt.Sprintf("%s • %s • %s", drink.Category, drink.Glass, status), drink.Name)
 }
@@ -7,9 +7,9 @@ import (
 
 type listViewKeys struct {
 	keys.ListViewKeys
-	Tags key.Binding
+	Tags, Restore key.Binding
 }
 
 func newListViewKeys() listViewKeys {
-	return listViewKeys{ListViewKeys: keys.Standard.ListView, Tags: keys.NewBinding("t", "manage tags", "t")}
+	return listViewKeys{ListViewKeys: keys.Standard.ListView, Tags: keys.NewBinding("t", "manage tags", "t"), Restore: keys.NewBinding("b", "restore", "b")}
 }
@@ -131,6 +131,13 @@ func (m *ListViewModel) Interaction() tui.Interaction {
 
 func (m *ListViewModel) Update(msg tea.Msg) (tui.ViewModel, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tui.RefreshMsg:
+		if m.mode != listModeBrowsing || !m.actionEnabled(drinks.ControlList) {
+			return m, nil
+		}
+		m.loading = true
+		m.err = nil
+		return m, tea.Batch(m.spinner.Init(), m.loadDrinks(m.request.Cursor))
 	case tea.WindowSizeMsg:
 		m.setSize(msg.Width, msg.Height)
 		switch m.mode {
@@ -178,6 +185,13 @@ func (m *ListViewModel) Update(msg tea.Msg) (tui.ViewModel, tea.Cmd) {
 		m.deleteTarget = nil
 		m.err = msg.Err
 		return m, nil
+	case DrinkRestoredMsg:
+		m.loading = true
+		m.err = nil
+		return m, tea.Batch(m.spinner.Init(), m.loadDrinks(m.request.Cursor))
+	case RestoreErrorMsg:
+		m.err = msg.Err
+		return m, nil
 	case showDeleteDialogMsg:
 		m.mode = listModeConfirmingDelete
 		m.dialog = msg.dialog
@@ -285,6 +299,11 @@ func (m *ListViewModel) Update(msg tea.Msg) (tui.ViewModel, tea.Cmd) {
 				return m, nil
 			}
 			return m, m.startDelete()
+		case key.Matches(msg, m.keys.Restore):
+			if !m.actionEnabled(drinks.ControlRestore) {
+				return m, nil
+			}
+			return m, m.performRestore()
 		case key.Matches(msg, m.keys.Tags):
 			if !m.actionEnabled(drinks.ControlTags) {
 				return m, nil
@@ -523,6 +542,7 @@ func (m *ListViewModel) visibleBindings() []key.Binding {
 		{drinks.ControlEdit, m.keys.Edit},
 		{drinks.ControlDelete, m.keys.Delete},
 		{drinks.ControlTags, m.keys.Tags},
+		{drinks.ControlRestore, m.keys.Restore},
 	}
 	bindings := make([]key.Binding, 0, len(pairs))
 	for _, pair := range pairs {
@@ -596,6 +616,21 @@ func (m *ListViewModel) performDelete() tea.Cmd {
 	}
 }
 
+func (m *ListViewModel) performRestore() tea.Cmd {
+	drink := m.selectedDrink()
+	if drink == nil {
+		return nil
+	}
+	id := drink.ID
+	return func() tea.Msg {
+		restored, err := m.app.Drinks.Restore(m.context(), id)
+		if err != nil {
+			return RestoreErrorMsg{Err: err}
+		}
+		return DrinkRestoredMsg{Drink: restored}
+	}
+}
+
 func (m *ListViewModel) context() *middleware.Context {
 	return m.app.Context()
 }
@@ -20,3 +20,13 @@ type DrinkDeletedMsg struct {
 type DeleteErrorMsg struct {
 	Err error
 }
+
+// DrinkRestoredMsg is sent when a deleted drink has been restored.
+type DrinkRestoredMsg struct {
+	Drink *models.Drink
+}
+
+// RestoreErrorMsg is sent when restore fails.
+type RestoreErrorMsg struct {
+	Err error
+}
@@ -21,7 +21,18 @@ func (m *Module) registerTagTarget(targets *tagging.Registry) {
 			if err != nil {
 				return tagging.TargetState{}, err
 			}
-			return tagging.TargetState{Entity: value.CedarEntity(), DisplayName: value.Name, Tags: value.Tags}, nil
+			return tagging.TargetState{Entity: value.CedarEntity(), DisplayName: value.Name, Tags: value.Tags, Value: *value}, nil
+		},
+		LoadDeleted: func(ctx store.Context, raw cedar.String) (tagging.TargetState, error) {
+			id, err := entity.ParseDrinkID(string(raw))
+			if err != nil {
+				return tagging.TargetState{}, err
+			}
+			value, err := m.queries.GetDeleted(ctx, id)
+			if err != nil {
+				return tagging.TargetState{}, err
+			}
+			return tagging.TargetState{Entity: value.CedarEntity(), DisplayName: value.Name, Tags: value.Tags, Value: *value}, nil
 		},
 	})
 }
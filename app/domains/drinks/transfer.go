@@ -0,0 +1,27 @@
+package drinks
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// TransferOwnership makes owner the current owner of a drink. The creator is
+// retained, so policies can still grant authors control after delegation.
+func (m *Module) TransferOwnership(ctx *middleware.Context, id entity.DrinkID, owner cedar.EntityUID) (*models.Drink, error) {
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Drink, *models.Drink]{
+		Action: authz.ActionTransfer,
+		Request: struct {
+			ID    entity.DrinkID
+			Owner cedar.EntityUID
+		}{id, owner},
+		Load: func(ctx *middleware.Context) (*models.Drink, error) {
+			return m.queries.Get(ctx, id)
+		},
+		Handle: func(ctx *middleware.Context, drink *models.Drink) (*models.Drink, error) {
+			return m.commands.TransferOwnership(ctx, drink, owner)
+		},
+	})
+}
@@ -9,7 +9,8 @@ import (
 func (m *Module) Update(ctx *middleware.Context, drink *models.Drink) (*models.Drink, error) {
 	authorizedUpdate := middleware.AuthorizeCommand(authz.ActionUpdate, m.commands.Update)
 	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Drink, *models.Drink]{
-		Action: authz.ActionUpdate,
+		Action:  authz.ActionUpdate,
+		Request: drink,
 		Load: func(ctx *middleware.Context) (*models.Drink, error) {
 			return m.queries.Get(ctx, drink.ID)
 		},
@@ -0,0 +1,64 @@
+// Code generated by authz/gen from schema.cedarschema. DO NOT EDIT.
+
+package authz
+
+import (
+	_ "embed"
+	"sync"
+
+	cedar "github.com/cedar-policy/cedar-go"
+	"github.com/cedar-policy/cedar-go/x/exp/schema"
+	"github.com/cedar-policy/cedar-go/x/exp/schema/resolved"
+	"github.com/cedar-policy/cedar-go/x/exp/schema/validate"
+)
+
+//go:embed schema.cedarschema
+var Schema string
+
+const (
+	DomainEventType cedar.EntityType = "Mixology::DomainEvent"
+	ResourceType    cedar.EntityType = DomainEventType
+	ActionType      cedar.EntityType = "Mixology::DomainEvent::Action"
+)
+
+var (
+	schemaOnce     sync.Once
+	resolvedSchema *resolved.Schema
+	schemaErr      error
+)
+
+// ValidateEntity validates entity against the module's Cedar schema.
+func ValidateEntity(entity cedar.Entity) error {
+	schemaOnce.Do(func() {
+		var parsed schema.Schema
+		parsed.SetFilename("schema.cedarschema")
+		if schemaErr = parsed.UnmarshalCedar([]byte(Schema)); schemaErr != nil {
+			return
+		}
+		resolvedSchema, schemaErr = parsed.Resolve()
+	})
+	if schemaErr != nil {
+		return schemaErr
+	}
+	return validate.New(resolvedSchema).Entity(entity)
+}
+
+var (
+	ActionList   = cedar.NewEntityUID(ActionType, "list")
+	ActionReplay = cedar.NewEntityUID(ActionType, "replay")
+)
+
+// DomainEvent is the Cedar-facing authorization model for Mixology::DomainEvent.
+type DomainEvent struct {
+	UID cedar.EntityUID
+}
+
+// CedarEntity converts m to the entity shape declared in schema.cedarschema.
+func (m DomainEvent) CedarEntity() cedar.Entity {
+	return cedar.Entity{
+		UID:        cedar.NewEntityUID(DomainEventType, m.UID.ID),
+		Parents:    cedar.NewEntityUIDSet(),
+		Attributes: cedar.NewRecord(cedar.RecordMap{}),
+		Tags:       cedar.NewRecord(nil),
+	}
+}
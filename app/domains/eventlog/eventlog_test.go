@@ -0,0 +1,200 @@
+package eventlog_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app"
+	drinksmodels "github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog"
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog/models"
+	ingredientsmodels "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	inventoryauthz "github.com/TheFellow/go-modular-monolith/app/domains/inventory/authz"
+	inventoryevents "github.com/TheFellow/go-modular-monolith/app/domains/inventory/events"
+	inventorymodels "github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
+	menumodels "github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	ordersmodels "github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/currency"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/money"
+	"github.com/TheFellow/go-modular-monolith/pkg/dispatcher"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/teststore"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+func TestEventLog_RecordsDispatchedEventsWithTheirActivity(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	gin, _ := publishedMenu(t, f)
+
+	setStock(t, f, gin, 0)
+
+	adjusted := listEvents(t, f, eventlog.ListRequest{Type: "inventory.StockAdjusted"})
+	last := adjusted[len(adjusted)-1]
+	entry := f.LatestAuditEntry(inventoryauthz.ActionSet)
+	testutil.Equals(t, last.ActivityID, entry.ID.String())
+	testutil.Equals(t, last.Principal.String(), f.OwnerContext().Principal().String())
+
+	decoded, err := dispatcher.DecodeEvent(last.Type, last.Data)
+	testutil.Ok(t, err)
+	event, ok := decoded.(inventoryevents.StockAdjusted)
+	testutil.IsTrue(t, ok)
+	testutil.Equals(t, event.Inventory.IngredientID, gin.ID)
+	testutil.IsTrue(t, event.Inventory.Amount.IsZero())
+
+	published := listEvents(t, f, eventlog.ListRequest{Type: "menus.MenuPublished"})
+	testutil.Equals(t, len(published), 1)
+	testutil.IsTrue(t, published[0].Sequence < last.Sequence)
+}
+
+func TestEventLog_FailedCommandRecordsNothing(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	menu := testutil.CreateMenu(t, f, "Empty Menu")
+	before := len(listEvents(t, f, eventlog.ListRequest{}))
+
+	_, err := f.Menus.Publish(f.OwnerContext(), &menumodels.Menu{ID: menu.ID})
+	testutil.ErrorIf(t, err == nil, "expected publishing an empty menu to fail")
+
+	testutil.Equals(t, len(listEvents(t, f, eventlog.ListRequest{})), before)
+}
+
+func TestEventLog_ReplayRebuildsMenusInScratchDatabase(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	_, menu := publishedMenu(t, f)
+	dir := t.TempDir()
+	base := filepath.Join(dir, teststore.File("base"))
+	testutil.Ok(t, f.Store.CopyTo(context.Background(), base))
+
+	_, err := f.Drinks.Delete(f.OwnerContext(), menu.Items[0].DrinkID)
+	testutil.Ok(t, err)
+	live, err := f.Menus.Get(f.OwnerContext(), menu.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(live.Items), 0)
+	deleted := listEvents(t, f, eventlog.ListRequest{Type: "drinks.DrinkDeleted"})
+	testutil.Equals(t, len(deleted), 1)
+	// A change no replayed handler reproduces shows up as a difference.
+	_, err = f.Menus.Draft(f.OwnerContext(), &menumodels.Menu{ID: menu.ID})
+	testutil.Ok(t, err)
+
+	scratch := filepath.Join(dir, teststore.File("scratch"))
+	replay, err := f.App.ReplayEvents(f.OwnerContext(), models.ReplayRange{From: deleted[0].Sequence, To: deleted[0].Sequence}, app.ReplayOptions{
+		Scratch:  scratch,
+		Base:     base,
+		Handlers: []string{"menus"},
+	})
+	testutil.Ok(t, err)
+	testutil.Equals(t, replay.Handlers, []string{"menus"})
+	testutil.Equals(t, len(replay.Events), 1)
+	testutil.Equals(t, replay.Events[0].Sequence, deleted[0].Sequence)
+	testutil.Equals(t, replay.Touched, []cedar.EntityUID{menu.ID.EntityUID()})
+	testutil.Equals(t, len(replay.Differences), 1)
+	testutil.Equals(t, replay.Differences[0].Entity, menu.ID.EntityUID())
+	fields := map[string][2]string{}
+	for _, change := range replay.Differences[0].Changes {
+		fields[change.Path] = [2]string{change.Before, change.After}
+	}
+	testutil.Equals(t, fields["Status"], [2]string{"draft", "published"})
+	_, itemsDiffer := fields["Items[0].DrinkID"]
+	testutil.IsFalse(t, itemsDiffer)
+
+	replayed := openMenu(t, scratch, f.OwnerContext(), menu)
+	testutil.Equals(t, len(replayed.Items), len(live.Items))
+
+	// The base database is only read.
+	original := openMenu(t, base, f.OwnerContext(), menu)
+	testutil.Equals(t, len(original.Items), 1)
+}
+
+func TestEventLog_ReplayRefusesToOverwriteOrGuessHandlers(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	publishedMenu(t, f)
+	dir := t.TempDir()
+
+	existing := filepath.Join(dir, teststore.File("existing"))
+	testutil.Ok(t, os.WriteFile(existing, []byte("keep"), 0o600))
+	_, err := f.App.ReplayEvents(f.OwnerContext(), models.ReplayRange{}, app.ReplayOptions{Scratch: existing})
+	testutil.ErrorIsFailedPrecondition(t, err)
+	data, err := os.ReadFile(existing)
+	testutil.Ok(t, err)
+	testutil.Equals(t, string(data), "keep")
+
+	unknown := filepath.Join(dir, teststore.File("unknown"))
+	_, err = f.App.ReplayEvents(f.OwnerContext(), models.ReplayRange{}, app.ReplayOptions{Scratch: unknown, Handlers: []string{"audit"}})
+	testutil.ErrorIsInvalid(t, err)
+	_, err = os.Stat(unknown)
+	testutil.IsTrue(t, os.IsNotExist(err))
+
+	_, err = f.App.ReplayEvents(f.OwnerContext(), models.ReplayRange{From: 5, To: 2}, app.ReplayOptions{Scratch: unknown})
+	testutil.ErrorIsInvalid(t, err)
+}
+
+func publishedMenu(t *testing.T, f *testutil.Fixture) (*ingredientsmodels.Ingredient, *menumodels.Menu) {
+	t.Helper()
+	gin := testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{
+		Name: "Gin", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitOz,
+	})
+	drink := testutil.CreateDrink(t, f, drinksmodels.Drink{
+		Name: "Gin Rickey", Category: drinksmodels.DrinkCategoryHighball, Glass: drinksmodels.GlassTypeHighball,
+		Recipe: drinksmodels.Recipe{
+			Ingredients: []drinksmodels.RecipeIngredient{{IngredientID: gin.ID, Amount: measurement.MustAmount(2, measurement.UnitOz)}},
+			Steps:       []string{"Build"},
+		},
+	})
+	return gin, testutil.CreateMenu(t, f, "Bar Menu", testutil.WithDrink(drink), testutil.Published())
+}
+
+func setStock(t *testing.T, f *testutil.Fixture, ingredient *ingredientsmodels.Ingredient, quantity float64) {
+	t.Helper()
+	testutil.SetInventory(t, f, inventorymodels.Update{
+		IngredientID: ingredient.ID,
+		Amount:       measurement.MustAmount(quantity, ingredient.Unit),
+		CostPerUnit:  money.NewPriceFromCents(100, currency.USD),
+	})
+}
+
+func listEvents(t *testing.T, f *testutil.Fixture, req eventlog.ListRequest) []*models.Event {
+	t.Helper()
+	page, err := f.EventLog.List(f.OwnerContext(), req)
+	testutil.Ok(t, err)
+	return page.Items
+}
+
+func openMenu(t *testing.T, path string, ctx *middleware.Context, menu *menumodels.Menu) *menumodels.Menu {
+	t.Helper()
+	s, err := store.Open(ctx, path)
+	testutil.Ok(t, err)
+	scratch, err := app.New(ctx, app.Config{Store: s})
+	testutil.Ok(t, err)
+	t.Cleanup(func() { testutil.Ok(t, scratch.Close()) })
+	got, err := scratch.Menus.Get(ctx, menu.ID)
+	testutil.Ok(t, err)
+	return got
+}
+
+func TestEventLog_ReplayRecordsNoLiveMetrics(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	_, menu := publishedMenu(t, f)
+	order := testutil.PlaceOrder(t, f, ordersmodels.Order{
+		MenuID: menu.ID, Items: []ordersmodels.OrderItem{{DrinkID: menu.Items[0].DrinkID, Quantity: 1}},
+	})
+	_, err := f.Orders.Complete(f.OwnerContext(), &ordersmodels.Order{ID: order.ID})
+	testutil.Ok(t, err)
+	testutil.Equals(t, f.Metrics.HistogramCount(telemetry.MetricOrderFulfillmentDuration), 1)
+
+	_, err = f.App.ReplayEvents(f.OwnerContext(), models.ReplayRange{Types: []string{"orders.OrderCompleted"}}, app.ReplayOptions{
+		Scratch:  filepath.Join(t.TempDir(), teststore.File("scratch")),
+		Handlers: []string{"orders"},
+	})
+	testutil.Ok(t, err)
+	testutil.Equals(t, f.Metrics.HistogramCount(telemetry.MetricOrderFulfillmentDuration), 1)
+}
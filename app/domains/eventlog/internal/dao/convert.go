@@ -0,0 +1,31 @@
+package dao
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog/models"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+func toRow(e models.Event) EventRow {
+	return EventRow{
+		Sequence:      e.Sequence,
+		ActivityID:    e.ActivityID,
+		CorrelationID: e.CorrelationID,
+		Type:          e.Type,
+		PrincipalType: string(e.Principal.Type),
+		PrincipalID:   string(e.Principal.ID),
+		OccurredAt:    e.OccurredAt,
+		Data:          e.Data,
+	}
+}
+
+func toModel(r EventRow) models.Event {
+	return models.Event{
+		Sequence:      r.Sequence,
+		ActivityID:    r.ActivityID,
+		CorrelationID: r.CorrelationID,
+		Type:          r.Type,
+		Principal:     cedar.NewEntityUID(cedar.EntityType(r.PrincipalType), cedar.String(r.PrincipalID)),
+		OccurredAt:    r.OccurredAt,
+		Data:          r.Data,
+	}
+}
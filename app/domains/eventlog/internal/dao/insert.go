@@ -0,0 +1,13 @@
+package dao
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+func (d *DAO) Insert(ctx store.Context, event models.Event) error {
+	return store.Write(ctx, func(tx *store.Tx) error {
+		row := toRow(event)
+		return store.MapError(tx.Insert(&row), "insert %s event", row.Type)
+	})
+}
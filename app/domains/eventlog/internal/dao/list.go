@@ -0,0 +1,67 @@
+package dao
+
+import (
+	"iter"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog/models"
+	appfilter "github.com/TheFellow/go-modular-monolith/pkg/filter"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// ListFilter specifies optional filters for listing recorded events.
+// Sequence bounds are inclusive and zero is open.
+type ListFilter struct {
+	Types      []string
+	ActivityID string
+	From       int64
+	To         int64
+	AfterSeq   int64
+	Expression *appfilter.Expression[models.ListFilterView]
+}
+
+// List returns events in sequence order inside one read transaction for the
+// duration of iteration.
+func (d *DAO) List(ctx store.Context, filter ListFilter) iter.Seq2[*models.Event, error] {
+	return func(yield func(*models.Event, error) bool) {
+		err := d.store.ReadContext(ctx, func(tx *store.Tx) error {
+			q := store.QueryTx[EventRow](tx)
+			if len(filter.Types) > 0 {
+				types := make([]any, 0, len(filter.Types))
+				for _, typ := range filter.Types {
+					types = append(types, typ)
+				}
+				q = q.FilterEqual("Type", types...)
+			}
+			if filter.ActivityID != "" {
+				q = q.FilterEqual("ActivityID", filter.ActivityID)
+			}
+			if from := max(filter.From, filter.AfterSeq+1); from > 1 {
+				q = q.FilterGreaterEqual("Sequence", from)
+			}
+			if filter.To > 0 {
+				q = q.FilterLessEqual("Sequence", filter.To)
+			}
+			q = appfilter.ApplyStore(q, filter.Expression, func(r EventRow) models.ListFilterView {
+				return models.ListFilterView{
+					Sequence: r.Sequence, Type: r.Type, ActivityID: r.ActivityID, OccurredAt: r.OccurredAt,
+					CorrelationID: r.CorrelationID,
+					Principal:     cedar.NewEntityUID(cedar.EntityType(r.PrincipalType), cedar.String(r.PrincipalID)).String(),
+				}
+			})
+			for row, err := range q.SortAsc("Sequence").All() {
+				if err != nil {
+					return store.MapError(err, "iterate recorded events")
+				}
+				event := toModel(row)
+				if !yield(&event, nil) {
+					return nil
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
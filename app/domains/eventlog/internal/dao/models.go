@@ -0,0 +1,15 @@
+package dao
+
+import "time"
+
+type EventRow struct {
+	Sequence int64
+
+	ActivityID    string `bstore:"index"`
+	CorrelationID string `bstore:"index"`
+	Type          string `bstore:"index"`
+	PrincipalType string
+	PrincipalID   string
+	OccurredAt    time.Time
+	Data          []byte
+}
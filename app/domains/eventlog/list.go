@@ -0,0 +1,62 @@
+package eventlog
+
+import (
+	"iter"
+	"strconv"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog/internal/dao"
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog/models"
+	appfilter "github.com/TheFellow/go-modular-monolith/pkg/filter"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/paging"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+type ListRequest struct {
+	Type       string
+	ActivityID string
+	From       int64
+	To         int64
+	Filter     string
+	Cursor     paging.Cursor
+	Limit      int
+}
+
+// List returns recorded events in sequence order.
+func (m *Module) List(ctx *middleware.Context, req ListRequest) (paging.Page[*models.Event], error) {
+	rng := models.ReplayRange{From: req.From, To: req.To}
+	if err := rng.Validate(); err != nil {
+		return paging.Page[*models.Event]{}, err
+	}
+	expression, err := appfilter.Parse(models.ListFilterSchema(), req.Filter)
+	if err != nil {
+		return paging.Page[*models.Event]{}, err
+	}
+	if req.Limit == 0 {
+		req.Limit = paging.DefaultLimit
+	}
+	if req.Cursor != "" {
+		if _, err := models.ParseSequence(string(req.Cursor)); err != nil {
+			return paging.Page[*models.Event]{}, err
+		}
+	}
+	filter := dao.ListFilter{ActivityID: req.ActivityID, From: req.From, To: req.To, Expression: expression}
+	if req.Type != "" {
+		filter.Types = []string{req.Type}
+	}
+	return middleware.RunPageQuery(
+		m.pipeline,
+		ctx,
+		authz.ActionList,
+		func(ctx store.Context, filter dao.ListFilter, cursor paging.Cursor) iter.Seq2[*models.Event, error] {
+			if cursor != "" {
+				filter.AfterSeq, _ = models.ParseSequence(string(cursor))
+			}
+			return m.queries.List(ctx, filter)
+		},
+		func(event *models.Event) paging.Cursor { return paging.Cursor(strconv.FormatInt(event.Sequence, 10)) },
+		filter,
+		paging.Request{Cursor: req.Cursor, Limit: req.Limit},
+	)
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	eventlogauthz "github.com/TheFellow/go-modular-monolith/app/domains/eventlog/authz"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// Event is one dispatched domain event as it was recorded. Sequences increase
+// in commit order; Data is the event encoded by middlewareevents.Encode and
+// can be rebuilt only by code that knows Type. CorrelationID matches the log
+// records and audit entry of the command that raised the event.
+type Event struct {
+	Sequence      int64
+	ActivityID    string
+	CorrelationID string
+	Type          string
+	Principal     cedar.EntityUID
+	OccurredAt    time.Time
+	Data          []byte
+}
+
+func (e Event) CedarEntity() cedar.Entity {
+	return eventlogauthz.DomainEvent{UID: cedar.NewEntityUID(eventlogauthz.DomainEventType, cedar.String(strconv.FormatInt(e.Sequence, 10)))}.CedarEntity()
+}
+
+func ParseSequence(value string) (int64, error) {
+	sequence, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || sequence <= 0 {
+		return 0, errors.Invalidf("invalid event sequence %q", value)
+	}
+	return sequence, nil
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/filter"
+)
+
+type ListFilterView struct {
+	Sequence      int64     `expr:"sequence" filter:"Event sequence number" filter-column:"Sequence"`
+	Type          string    `expr:"type" filter:"Event type (domain.Type)" filter-column:"Type"`
+	ActivityID    string    `expr:"activity_id" filter:"Audit entry ID of the originating command" filter-column:"ActivityID"`
+	CorrelationID string    `expr:"correlation_id" filter:"Correlation ID shared with the command's logs and audit entry" filter-column:"CorrelationID"`
+	Principal     string    `expr:"principal" filter:"Principal entity UID"`
+	OccurredAt    time.Time `expr:"occurred_at" filter:"Time the command ran" filter-column:"OccurredAt"`
+}
+
+func ListFilterSchema() filter.Schema[ListFilterView] {
+	return filter.NewSchema[ListFilterView](
+		`type.startsWith("menus.") && occurred_at >= date("2026-07-01T00:00:00Z")`,
+		`activity_id == "aud-2ZyQ4RYV9bM0eZkq0ZlrKNn7J1Z"`,
+		`sequence > 100 && principal.contains("manager")`,
+	)
+}
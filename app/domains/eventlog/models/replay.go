@@ -0,0 +1,62 @@
+package models
+
+import (
+	eventlogauthz "github.com/TheFellow/go-modular-monolith/app/domains/eventlog/authz"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	middlewareevents "github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// LogResourceID identifies the event log as a whole when authorizing
+// operations, such as replay, that span many events.
+const LogResourceID = "log"
+
+// ReplayRange selects recorded events by inclusive sequence bounds and,
+// optionally, type. A zero bound is open.
+type ReplayRange struct {
+	From  int64
+	To    int64
+	Types []string
+}
+
+func (r ReplayRange) Validate() error {
+	if r.From < 0 || r.To < 0 {
+		return errors.Invalidf("event sequences must be positive")
+	}
+	if r.To > 0 && r.From > r.To {
+		return errors.Invalidf("replay range starts at %d after it ends at %d", r.From, r.To)
+	}
+	return nil
+}
+
+// ReplayedEvent is the outcome of replaying one event.
+type ReplayedEvent struct {
+	Sequence int64
+	Type     string
+	Touched  []cedar.EntityUID
+}
+
+// ReplayDifference is how the replayed state of a touched entity differs from
+// the live database. Each change's Before is the live value and After the
+// replayed one; an entity missing on one side has every field on the other.
+type ReplayDifference struct {
+	Entity  cedar.EntityUID
+	Changes []middlewareevents.Change
+}
+
+// Replay summarizes a replay into a scratch database.
+type Replay struct {
+	Range    ReplayRange
+	Handlers []string
+	Events   []ReplayedEvent
+	// Touched lists every entity any replayed handler touched, in first-touch
+	// order: the state to compare against the source database.
+	Touched []cedar.EntityUID
+	// Differences lists the touched entities whose replayed state differs
+	// from the live database, in Touched order.
+	Differences []ReplayDifference
+}
+
+func (r Replay) CedarEntity() cedar.Entity {
+	return eventlogauthz.DomainEvent{UID: cedar.NewEntityUID(eventlogauthz.DomainEventType, LogResourceID)}.CedarEntity()
+}
@@ -0,0 +1,26 @@
+package eventlog
+
+import (
+	"context"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog/internal/dao"
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog/queries"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+func RegisterSchema(ctx context.Context, s *store.Store) {
+	dao.Register(ctx, s)
+}
+
+type Module struct {
+	queries  *queries.Queries
+	pipeline *middleware.Pipeline
+}
+
+func NewModule(s *store.Store, pipeline *middleware.Pipeline) *Module {
+	return &Module{
+		queries:  queries.New(s),
+		pipeline: pipeline,
+	}
+}
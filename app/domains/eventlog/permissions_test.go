@@ -0,0 +1,53 @@
+package eventlog_test
+
+import (
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app"
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog"
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/teststore"
+)
+
+func TestPermissions_EventLog(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		canUse bool
+	}{
+		{name: "owner", canUse: true},
+		{name: "manager", canUse: false},
+		{name: "sommelier", canUse: false},
+		{name: "bartender", canUse: false},
+		{name: "anonymous", canUse: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			f := testutil.NewFixture(t)
+			publishedMenu(t, f)
+			var ctx *middleware.Context
+			if tc.name == "owner" {
+				ctx = f.OwnerContext()
+			} else {
+				ctx = f.ActorContext(tc.name)
+			}
+
+			page, err := f.EventLog.List(ctx, eventlog.ListRequest{})
+			testutil.Ok(t, err)
+			testutil.Equals(t, len(page.Items) > 0, tc.canUse)
+
+			scratch := teststore.Path(t, "scratch")
+			_, err = f.App.ReplayEvents(ctx, models.ReplayRange{}, app.ReplayOptions{Scratch: scratch})
+			if tc.canUse {
+				testutil.Ok(t, err)
+			} else {
+				testutil.ErrorIsPermission(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package queries
+
+import (
+	"iter"
+
+	eventlogdao "github.com/TheFellow/go-modular-monolith/app/domains/eventlog/internal/dao"
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+func (q *Queries) List(ctx store.Context, filter eventlogdao.ListFilter) iter.Seq2[*models.Event, error] {
+	return q.dao.List(ctx, filter)
+}
@@ -0,0 +1,41 @@
+package eventlog
+
+import (
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog/internal/dao"
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/authz"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	middlewareevents "github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+// Recorder appends dispatched domain events to the event log within the
+// transaction of the command that raised them.
+type Recorder struct {
+	dao *dao.DAO
+}
+
+func NewRecorder(s *store.Store) *Recorder {
+	return &Recorder{dao: dao.New(s)}
+}
+
+func (r *Recorder) RecordEvent(ctx *middleware.Context, activityID string, event any) error {
+	data, err := middlewareevents.Encode(event)
+	if err != nil {
+		return err
+	}
+	occurredAt := authz.RequestFromContext(ctx).Time
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+	return r.dao.Insert(ctx, models.Event{
+		ActivityID:    activityID,
+		CorrelationID: ctx.CorrelationID(),
+		Type:          middlewareevents.Name(event),
+		Principal:     ctx.Principal(),
+		OccurredAt:    occurredAt,
+		Data:          data,
+	})
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"strings"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog/models"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+type EventRow struct {
+	Sequence   int64  `table:"SEQUENCE" json:"sequence"`
+	Type       string `table:"TYPE" json:"type"`
+	OccurredAt string `table:"OCCURRED_AT" json:"occurred_at"`
+	Principal  string `table:"PRINCIPAL" json:"principal"`
+	ActivityID string `table:"ACTIVITY_ID" json:"activity_id"`
+	Size       int    `table:"SIZE" json:"size"`
+}
+
+func ToEventRow(event *models.Event) EventRow {
+	if event == nil {
+		return EventRow{}
+	}
+	return EventRow{
+		Sequence:   event.Sequence,
+		Type:       event.Type,
+		OccurredAt: event.OccurredAt.Format(time.RFC3339),
+		Principal:  event.Principal.String(),
+		ActivityID: event.ActivityID,
+		Size:       len(event.Data),
+	}
+}
+
+func ToEventRows(events []*models.Event) []EventRow {
+	rows := make([]EventRow, 0, len(events))
+	for _, event := range events {
+		rows = append(rows, ToEventRow(event))
+	}
+	return rows
+}
+
+type ReplayedEventRow struct {
+	Sequence int64  `table:"SEQUENCE" json:"sequence"`
+	Type     string `table:"TYPE" json:"type"`
+	Touched  string `table:"TOUCHED" json:"-"`
+
+	TouchedList []string `table:"-" json:"touched"`
+}
+
+// DifferenceRow is one field of a touched entity whose replayed value differs
+// from the live one.
+type DifferenceRow struct {
+	Entity   string `table:"ENTITY" json:"entity"`
+	Field    string `table:"FIELD" json:"field"`
+	Live     string `table:"LIVE" json:"live"`
+	Replayed string `table:"REPLAYED" json:"replayed"`
+}
+
+// Replay is the CLI view of a replay into a scratch database.
+type Replay struct {
+	Scratch     string             `json:"scratch"`
+	Handlers    []string           `json:"handlers"`
+	Events      []ReplayedEventRow `json:"events"`
+	Touched     []string           `json:"touched"`
+	Differences []DifferenceRow    `json:"differences"`
+}
+
+func ToReplay(scratch string, replay models.Replay) Replay {
+	view := Replay{
+		Scratch:     scratch,
+		Handlers:    replay.Handlers,
+		Events:      make([]ReplayedEventRow, 0, len(replay.Events)),
+		Touched:     uidStrings(replay.Touched),
+		Differences: []DifferenceRow{},
+	}
+	for _, event := range replay.Events {
+		touched := uidStrings(event.Touched)
+		view.Events = append(view.Events, ReplayedEventRow{
+			Sequence:    event.Sequence,
+			Type:        event.Type,
+			Touched:     strings.Join(touched, ", "),
+			TouchedList: touched,
+		})
+	}
+	for _, difference := range replay.Differences {
+		for _, change := range difference.Changes {
+			view.Differences = append(view.Differences, DifferenceRow{
+				Entity: difference.Entity.String(), Field: change.Path, Live: change.Before, Replayed: change.After,
+			})
+		}
+	}
+	return view
+}
+
+func uidStrings(uids []cedar.EntityUID) []string {
+	out := make([]string, 0, len(uids))
+	for _, uid := range uids {
+		out = append(out, uid.String())
+	}
+	return out
+}
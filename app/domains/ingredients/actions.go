@@ -20,6 +20,8 @@ const (
 	// ControlDelete preserves source compatibility for presentation adapters.
 	ControlDelete            = ControlRetire
 	ControlTags   actions.ID = "ingredients.tags"
+	// ControlRestore is the only action offered on a retired ingredient.
+	ControlRestore actions.ID = "ingredients.restore"
 )
 
 // ActionProjector produces framework-neutral ingredient control state.
@@ -57,6 +59,10 @@ func (p ActionProjector) Project(ctx context.Context, principal cedar.EntityUID,
 	}
 
 	resource := selected.CedarEntity()
+	if selected.DeletedAt.IsSome() {
+		declaration.Controls = append(declaration.Controls, actions.Control{ID: ControlRestore, Permission: permission(ingredientauthz.ActionRestore, resource)})
+		return actions.Evaluate(ctx, declaration)
+	}
 	declaration.Controls = append(declaration.Controls,
 		actions.Control{ID: ControlEdit, Permission: permission(ingredientauthz.ActionUpdate, resource)},
 		actions.Control{ID: ControlRetire, Permission: permission(ingredientauthz.ActionRetire, resource)},
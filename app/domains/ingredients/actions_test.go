@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients"
 	ingredientauthz "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/authz"
@@ -11,6 +12,7 @@ import (
 	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
 	"github.com/TheFellow/go-modular-monolith/pkg/authn"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
 	"github.com/TheFellow/go-modular-monolith/pkg/presentation/actions"
 	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
 	cedar "github.com/cedar-policy/cedar-go"
@@ -72,6 +74,18 @@ func TestIngredientActionProjectorPermissionsAreIndependent(t *testing.T) {
 	testutil.Equals(t, byID[ingredients.ControlTags].Enabled, true)
 }
 
+func TestIngredientActionProjectorOffersOnlyRestoreForRetiredIngredients(t *testing.T) {
+	t.Parallel()
+	retired := &models.Ingredient{ID: entity.NewIngredientID(), DeletedAt: optional.Some(time.Now())}
+	states, err := ingredients.NewActionProjector().Project(context.Background(), authn.Owner(), retired)
+	testutil.Ok(t, err)
+	testutil.Equals(t, states, []actions.State{
+		{ID: ingredients.ControlList, Visible: true, Enabled: true},
+		{ID: ingredients.ControlCreate, Visible: true, Enabled: true},
+		{ID: ingredients.ControlRestore, Visible: true, Enabled: true},
+	})
+}
+
 func TestIngredientActionProjectorReturnsEvaluatorErrors(t *testing.T) {
 	t.Parallel()
 	want := errors.New("policy evaluator unavailable")
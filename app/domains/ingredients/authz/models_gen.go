@@ -47,13 +47,16 @@ func ValidateEntity(entity cedar.Entity) error {
 }
 
 var (
-	ActionCreate = cedar.NewEntityUID(ActionType, "create")
-	ActionGet    = cedar.NewEntityUID(ActionType, "get")
-	ActionList   = cedar.NewEntityUID(ActionType, "list")
-	ActionRetire = cedar.NewEntityUID(ActionType, "retire")
-	ActionTag    = cedar.NewEntityUID(ActionType, "tag")
-	ActionUntag  = cedar.NewEntityUID(ActionType, "untag")
-	ActionUpdate = cedar.NewEntityUID(ActionType, "update")
+	ActionCreate  = cedar.NewEntityUID(ActionType, "create")
+	ActionExport  = cedar.NewEntityUID(ActionType, "export")
+	ActionGet     = cedar.NewEntityUID(ActionType, "get")
+	ActionImport  = cedar.NewEntityUID(ActionType, "import")
+	ActionList    = cedar.NewEntityUID(ActionType, "list")
+	ActionRestore = cedar.NewEntityUID(ActionType, "restore")
+	ActionRetire  = cedar.NewEntityUID(ActionType, "retire")
+	ActionTag     = cedar.NewEntityUID(ActionType, "tag")
+	ActionUntag   = cedar.NewEntityUID(ActionType, "untag")
+	ActionUpdate  = cedar.NewEntityUID(ActionType, "update")
 )
 
 // Ingredient is the Cedar-facing authorization model for Mixology::Ingredient.
@@ -8,7 +8,8 @@ import (
 
 func (m *Module) Create(ctx *middleware.Context, ingredient *models.Ingredient) (*models.Ingredient, error) {
 	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Ingredient, *models.Ingredient]{
-		Action: authz.ActionCreate,
+		Action:  authz.ActionCreate,
+		Request: ingredient,
 		Load: func(*middleware.Context) (*models.Ingredient, error) {
 			return ingredient, nil
 		},
@@ -36,6 +36,7 @@ func TestIngredients_CreateGetUpdateDelete(t *testing.T) {
 	wantUpdated := *created
 	wantUpdated.Name = "Fresh Lime Juice"
 	wantUpdated.Unit = measurement.UnitMl
+	wantUpdated.Version = created.Version + 1
 	testutil.Equals(t, updated, &wantUpdated)
 
 	got, err = f.Ingredients.Get(ctx, created.ID)
@@ -51,3 +52,27 @@ func TestIngredients_CreateGetUpdateDelete(t *testing.T) {
 	testutil.Ok(t, err)
 	testutil.Equals(t, count, 0)
 }
+
+func TestIngredients_UpdateRejectsStaleVersion(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	ctx := f.OwnerContext()
+
+	created, err := f.Ingredients.Create(ctx, &models.Ingredient{
+		Name: "Lime Juice", Category: models.CategoryJuice, Unit: measurement.UnitOz,
+	})
+	testutil.Ok(t, err)
+	testutil.Equals(t, created.Version, int64(1))
+
+	first, err := f.Ingredients.Update(ctx, &models.Ingredient{ID: created.ID, Name: "Fresh Lime Juice", Version: created.Version})
+	testutil.Ok(t, err)
+	testutil.Equals(t, first.Version, int64(2))
+
+	_, err = f.Ingredients.Update(ctx, &models.Ingredient{ID: created.ID, Name: "Key Lime Juice", Version: created.Version})
+	testutil.ErrorIsConflict(t, err)
+	testutil.StringContains(t, err.Error(), "changed since version 1 (now version 2)")
+
+	got, err := f.Ingredients.Get(ctx, created.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, got.Name, "Fresh Lime Juice")
+}
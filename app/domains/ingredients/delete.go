@@ -15,10 +15,17 @@ func (m *Module) Delete(ctx *middleware.Context, id entity.IngredientID) (*model
 func (m *Module) Retire(ctx *middleware.Context, id entity.IngredientID, retirement models.Retirement) (*models.Ingredient, error) {
 	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[commands.RetirementTarget, *models.Ingredient]{
 		Action: authz.ActionRetire,
+		Request: struct {
+			ID         entity.IngredientID
+			Retirement models.Retirement
+		}{id, retirement},
 		Load: func(ctx *middleware.Context) (commands.RetirementTarget, error) {
 			ingredient, err := m.queries.Get(ctx, id)
 			return commands.RetirementTarget{Ingredient: ingredient, Retirement: retirement}, err
 		},
+		Before: func(_ *middleware.Context, target commands.RetirementTarget) (*models.Ingredient, error) {
+			return target.Ingredient, nil
+		},
 		Handle: m.commands.Retire,
 	})
 }
@@ -12,3 +12,27 @@ type IngredientDeleted struct {
 	Replacement      *models.Ingredient
 	ReplacementRatio float64
 }
+
+// Topic and Payload publish IngredientDeleted through the outbox.
+func (e IngredientDeleted) Topic() string { return "ingredients.ingredient_deleted" }
+
+func (e IngredientDeleted) Payload() any {
+	payload := ingredientDeletedPayload{
+		IngredientID: e.Ingredient.ID.String(),
+		Name:         e.Ingredient.Name,
+		DeletedAt:    e.DeletedAt.UTC(),
+	}
+	if e.Replacement != nil {
+		payload.ReplacementID = e.Replacement.ID.String()
+		payload.ReplacementRatio = e.ReplacementRatio
+	}
+	return payload
+}
+
+type ingredientDeletedPayload struct {
+	IngredientID     string    `json:"ingredient_id"`
+	Name             string    `json:"name"`
+	DeletedAt        time.Time `json:"deleted_at"`
+	ReplacementID    string    `json:"replacement_id,omitempty"`
+	ReplacementRatio float64   `json:"replacement_ratio,omitempty"`
+}
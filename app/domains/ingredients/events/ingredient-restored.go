@@ -0,0 +1,32 @@
+package events
+
+import (
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+)
+
+// IngredientRestored reports a retired ingredient returning to use. Its stock
+// was removed at retirement and is not recreated.
+type IngredientRestored struct {
+	Ingredient models.Ingredient
+	RestoredAt time.Time
+}
+
+// Topic and Payload publish IngredientRestored through the outbox, so
+// consumers of ingredient_deleted can undo what they did.
+func (e IngredientRestored) Topic() string { return "ingredients.ingredient_restored" }
+
+func (e IngredientRestored) Payload() any {
+	return ingredientRestoredPayload{
+		IngredientID: e.Ingredient.ID.String(),
+		Name:         e.Ingredient.Name,
+		RestoredAt:   e.RestoredAt.UTC(),
+	}
+}
+
+type ingredientRestoredPayload struct {
+	IngredientID string    `json:"ingredient_id"`
+	Name         string    `json:"name"`
+	RestoredAt   time.Time `json:"restored_at"`
+}
@@ -0,0 +1,27 @@
+package ingredients
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/authz"
+	ingredientsdao "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/internal/dao"
+	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// ExportAll returns every ingredient, retired ones included, with its tags.
+// It fails rather than omit an ingredient the caller may not export.
+func (m *Module) ExportAll(ctx *middleware.Context) ([]*models.Ingredient, error) {
+	return middleware.RunCollectQuery(m.pipeline, ctx, authz.ActionExport, m.queries.List, ingredientsdao.ListFilter{IncludeDeleted: true})
+}
+
+// Import recreates an ingredient exported from another database with its ID,
+// version, retirement and tags intact.
+func (m *Module) Import(ctx *middleware.Context, ingredient *models.Ingredient) (*models.Ingredient, error) {
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Ingredient, *models.Ingredient]{
+		Action:  authz.ActionImport,
+		Request: ingredient,
+		Load: func(*middleware.Context) (*models.Ingredient, error) {
+			return ingredient, nil
+		},
+		Handle: m.commands.Import,
+	})
+}
@@ -42,7 +42,7 @@ func (c *Commands) Retire(ctx *middleware.Context, target RetirementTarget) (*mo
 		if err != nil {
 			return nil, errors.Invalidf("replacement ingredient %s must exist and be active: %w", target.Retirement.ReplacementID.String(), err)
 		}
-		if err := pkgAuthz.AuthorizeWithEntity(ctx.Principal(), ingredientauthz.ActionGet, replacement.CedarEntity()); err != nil {
+		if err := pkgAuthz.AuthorizeEntity(ctx, ctx.Principal(), ingredientauthz.ActionGet, replacement.CedarEntity()); err != nil {
 			return nil, err
 		}
 		if replacement.Category != ingredient.Category {
@@ -69,7 +69,7 @@ func (c *Commands) Retire(ctx *middleware.Context, target RetirementTarget) (*mo
 	deleted := *ingredient
 	deleted.DeletedAt = optional.Some(now)
 
-	if err := c.dao.Update(ctx, deleted); err != nil {
+	if err := c.dao.Update(ctx, &deleted); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// Import recreates an exported ingredient. It raises no events: the
+// ingredient is being restored, not created, so no other domain reacts.
+func (c *Commands) Import(ctx *middleware.Context, ingredient *models.Ingredient) (*models.Ingredient, error) {
+	if ingredient == nil {
+		return nil, errors.Invalidf("ingredient is required")
+	}
+	if ingredient.ID.IsZero() {
+		return nil, errors.Invalidf("id is required for import")
+	}
+	if strings.TrimSpace(ingredient.Name) == "" {
+		return nil, errors.Invalidf("ingredient %s: name is required", ingredient.ID.String())
+	}
+	if ingredient.Category == "" || ingredient.Unit == "" {
+		return nil, errors.Invalidf("ingredient %s: category and unit are required", ingredient.ID.String())
+	}
+
+	imported := *ingredient
+	imported.Version = max(imported.Version, 1)
+	if err := c.dao.Import(ctx, imported); err != nil {
+		return nil, err
+	}
+	ctx.TouchEntity(imported.ID.EntityUID())
+	return &imported, nil
+}
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/events"
+	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
+)
+
+// Restore un-retires an ingredient. Recipes rewritten or flagged for review
+// at retirement stay as they are; bringing them back is a recipe decision.
+func (c *Commands) Restore(ctx *middleware.Context, ingredient *models.Ingredient) (*models.Ingredient, error) {
+	if ingredient == nil {
+		return nil, errors.Invalidf("ingredient is required")
+	}
+	if !ingredient.DeletedAt.IsSome() {
+		return nil, errors.FailedPreconditionf("ingredient %s is not retired", ingredient.ID.String())
+	}
+
+	restored := *ingredient
+	restored.DeletedAt = optional.None[time.Time]()
+	if err := c.dao.Update(ctx, &restored); err != nil {
+		return nil, err
+	}
+
+	ctx.TouchEntity(restored.ID.EntityUID())
+	ctx.AddEvent(events.IngredientRestored{
+		Ingredient: restored,
+		RestoredAt: time.Now().UTC(),
+	})
+	return &restored, nil
+}
@@ -23,6 +23,9 @@ func (c *Commands) Update(ctx *middleware.Context, ingredient *models.Ingredient
 	}
 
 	updated := *existing
+	if ingredient.Version != 0 {
+		updated.Version = ingredient.Version
+	}
 	if name := strings.TrimSpace(ingredient.Name); name != "" {
 		updated.Name = name
 	}
@@ -47,7 +50,7 @@ func (c *Commands) Update(ctx *middleware.Context, ingredient *models.Ingredient
 	}
 	updated.Description = strings.TrimSpace(updated.Description)
 
-	if err := c.dao.Update(ctx, updated); err != nil {
+	if err := c.dao.Update(ctx, &updated); err != nil {
 		return nil, err
 	}
 
@@ -2,7 +2,6 @@ package dao
 
 import (
 	"context"
-	"path/filepath"
 	"slices"
 	"testing"
 	"time"
@@ -10,21 +9,21 @@ import (
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
 	testutil "github.com/TheFellow/go-modular-monolith/pkg/testutil/assert"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/teststore"
 	cedar "github.com/cedar-policy/cedar-go"
-	"github.com/mjl-/bstore"
 )
 
 type activeIDsTestContext struct {
 	context.Context
 }
 
-func (activeIDsTestContext) Transaction() (*bstore.Tx, bool) { return nil, false }
+func (activeIDsTestContext) Transaction() (*store.Tx, bool) { return nil, false }
 
 func TestActiveIDsFiltersAndDeduplicatesRequestedIDs(t *testing.T) {
 	t.Parallel()
 
 	ctx := activeIDsTestContext{telemetry.WithMetrics(context.Background(), telemetry.Memory())}
-	s, err := store.Open(ctx, filepath.Join(t.TempDir(), "ingredients.db"))
+	s, err := store.Open(ctx, teststore.Path(t, "ingredients"))
 	testutil.ErrorIf(t, err != nil, "open store: %v", err)
 	t.Cleanup(func() {
 		err := s.Close()
@@ -33,7 +32,7 @@ func TestActiveIDsFiltersAndDeduplicatesRequestedIDs(t *testing.T) {
 	Register(ctx, s)
 
 	deletedAt := time.Now()
-	err = s.Write(ctx, func(tx *bstore.Tx) error {
+	err = s.Write(ctx, func(tx *store.Tx) error {
 		return tx.Insert(
 			&IngredientRow{ID: "active", Name: "Active"},
 			&IngredientRow{ID: "deleted", Name: "Deleted", DeletedAt: &deletedAt},
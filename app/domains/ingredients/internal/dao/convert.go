@@ -22,6 +22,7 @@ func toRow(i models.Ingredient) IngredientRow {
 		Unit:        string(i.Unit),
 		Description: i.Description,
 		DeletedAt:   deletedAt,
+		Version:     i.Version,
 	}
 }
 
@@ -39,5 +40,6 @@ func toModel(r IngredientRow) models.Ingredient {
 		Unit:        measurement.Unit(r.Unit),
 		Description: r.Description,
 		DeletedAt:   deletedAt,
+		Version:     r.Version,
 	}
 }
@@ -7,13 +7,36 @@ import (
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 	cedar "github.com/cedar-policy/cedar-go"
-	"github.com/mjl-/bstore"
 )
 
 func (d *DAO) Get(ctx store.Context, id entity.IngredientID) (*models.Ingredient, error) {
+	ingredient, err := d.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ingredient.DeletedAt.IsSome() {
+		return nil, errors.NotFoundf("ingredient %s not found", id.String())
+	}
+	return ingredient, nil
+}
+
+// GetDeleted loads a soft-deleted ingredient. Active and missing ingredients are not
+// found.
+func (d *DAO) GetDeleted(ctx store.Context, id entity.IngredientID) (*models.Ingredient, error) {
+	ingredient, err := d.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !ingredient.DeletedAt.IsSome() {
+		return nil, errors.NotFoundf("deleted ingredient %s not found", id.String())
+	}
+	return ingredient, nil
+}
+
+func (d *DAO) get(ctx store.Context, id entity.IngredientID) (*models.Ingredient, error) {
 	var row IngredientRow
 	var tagsByTarget map[cedar.EntityUID]tag.Tags
-	err := d.store.ReadContext(ctx, func(tx *bstore.Tx) error {
+	err := d.store.ReadContext(ctx, func(tx *store.Tx) error {
 		row = IngredientRow{ID: id.String()}
 		if err := tx.Get(&row); err != nil {
 			return err
@@ -25,9 +48,6 @@ func (d *DAO) Get(ctx store.Context, id entity.IngredientID) (*models.Ingredient
 	if err != nil {
 		return nil, store.MapError(err, "ingredient %s not found", id.String())
 	}
-	if row.DeletedAt != nil {
-		return nil, errors.NotFoundf("ingredient %s not found", id.String())
-	}
 	ingredient := toModel(row)
 	ingredient.Tags = tagsByTarget[id.EntityUID()]
 	return &ingredient, nil
@@ -0,0 +1,24 @@
+package dao
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+// Import stores an ingredient exactly as another database held it, keeping
+// its ID, version, retirement and tags. An ingredient stored before versions
+// existed starts at version 1. A taken ID or name conflicts.
+func (d *DAO) Import(ctx store.Context, ingredient models.Ingredient) error {
+	return store.Write(ctx, func(tx *store.Tx) error {
+		row := toRow(ingredient)
+		row.Version = max(row.Version, 1)
+		if err := tx.Insert(&row); err != nil {
+			return store.MapError(err, "import ingredient %s %q", ingredient.ID.String(), ingredient.Name)
+		}
+		if len(ingredient.Tags) == 0 {
+			return nil
+		}
+		_, err := d.tags.Replace(ctx, ingredient.ID.EntityUID(), ingredient.Tags)
+		return err
+	})
+}
@@ -3,12 +3,17 @@ package dao
 import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
-	"github.com/mjl-/bstore"
 )
 
-func (d *DAO) Insert(ctx store.Context, ingredient models.Ingredient) error {
-	return store.Write(ctx, func(tx *bstore.Tx) error {
-		row := toRow(ingredient)
-		return store.MapError(tx.Insert(&row), "insert ingredient %q", ingredient.Name)
+// Insert stores a new ingredient at version 1 and records that on ingredient.
+func (d *DAO) Insert(ctx store.Context, ingredient *models.Ingredient) error {
+	return store.Write(ctx, func(tx *store.Tx) error {
+		row := toRow(*ingredient)
+		row.Version = 1
+		if err := tx.Insert(&row); err != nil {
+			return store.MapError(err, "insert ingredient %q", ingredient.Name)
+		}
+		ingredient.Version = row.Version
+		return nil
 	})
 }
@@ -9,4 +9,5 @@ type IngredientRow struct {
 	Unit        string
 	Description string
 	DeletedAt   *time.Time
+	Version     int64
 }
@@ -3,12 +3,25 @@ package dao
 import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
-	"github.com/mjl-/bstore"
 )
 
-func (d *DAO) Update(ctx store.Context, ingredient models.Ingredient) error {
-	return store.Write(ctx, func(tx *bstore.Tx) error {
-		row := toRow(ingredient)
-		return store.MapError(tx.Update(&row), "update ingredient %s", ingredient.ID.String())
+// Update replaces the stored ingredient only while it is still at ingredient.Version,
+// then advances ingredient.Version to the version written.
+func (d *DAO) Update(ctx store.Context, ingredient *models.Ingredient) error {
+	return store.Write(ctx, func(tx *store.Tx) error {
+		row := toRow(*ingredient)
+		current := IngredientRow{ID: row.ID}
+		if err := tx.Get(&current); err != nil {
+			return store.MapError(err, "update ingredient %s", ingredient.ID.String())
+		}
+		if err := store.CheckVersion(current.Version, ingredient.Version, "ingredient %s", ingredient.ID.String()); err != nil {
+			return err
+		}
+		row.Version++
+		if err := tx.Update(&row); err != nil {
+			return store.MapError(err, "update ingredient %s", ingredient.ID.String())
+		}
+		ingredient.Version = row.Version
+		return nil
 	})
 }
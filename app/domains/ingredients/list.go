@@ -18,6 +18,9 @@ type ListRequest struct {
 	Filter   string
 	Cursor   paging.Cursor
 	Limit    int
+
+	// IncludeDeleted lists retired ingredients alongside active ones.
+	IncludeDeleted bool
 }
 
 func (m *Module) List(ctx *middleware.Context, req ListRequest) (paging.Page[*models.Ingredient], error) {
@@ -33,7 +36,7 @@ func (m *Module) List(ctx *middleware.Context, req ListRequest) (paging.Page[*mo
 			return paging.Page[*models.Ingredient]{}, err
 		}
 	}
-	filter := ingredientsdao.ListFilter{Category: req.Category, Expression: expression}
+	filter := ingredientsdao.ListFilter{Category: req.Category, Expression: expression, IncludeDeleted: req.IncludeDeleted}
 	return middleware.RunPageQuery(
 		m.pipeline, ctx, authz.ActionList,
 		func(ctx store.Context, filter ingredientsdao.ListFilter, cursor paging.Cursor) iter.Seq2[*models.Ingredient, error] {
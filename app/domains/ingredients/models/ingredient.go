@@ -24,6 +24,8 @@ type Ingredient struct {
 	Description string
 	DeletedAt   optional.Value[time.Time]
 	Tags        tag.Tags
+	// Version is bumped on every write; a stale Version on update conflicts.
+	Version int64
 }
 
 // Retirement describes a deliberate ingredient lifecycle transition. A
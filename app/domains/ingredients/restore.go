@@ -0,0 +1,21 @@
+package ingredients
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// Restore brings back a retired ingredient with the tags it had. Stock must
+// be set again before drinks that use it become available.
+func (m *Module) Restore(ctx *middleware.Context, id entity.IngredientID) (*models.Ingredient, error) {
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Ingredient, *models.Ingredient]{
+		Action:  authz.ActionRestore,
+		Request: id,
+		Load: func(ctx *middleware.Context) (*models.Ingredient, error) {
+			return m.queries.GetDeleted(ctx, id)
+		},
+		Handle: m.commands.Restore,
+	})
+}
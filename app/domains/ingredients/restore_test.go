@@ -0,0 +1,34 @@
+package ingredients_test
+
+import (
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients"
+	ingredientsauthz "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/authz"
+	ingredientsM "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestIngredients_RestoreReturnsRetiredIngredientWithoutStock(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	ctx := f.OwnerContext()
+	ingredient := testutil.CreateIngredient(t, f, ingredientsM.Ingredient{Name: "Orgeat", Category: ingredientsM.CategorySyrup, Unit: measurement.UnitOz})
+
+	_, err := f.Ingredients.Retire(ctx, ingredient.ID, ingredientsM.Retirement{})
+	testutil.Ok(t, err)
+	page, err := f.Ingredients.List(ctx, ingredients.ListRequest{IncludeDeleted: true})
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(page.Items), 1)
+	testutil.ErrorIf(t, !page.Items[0].DeletedAt.IsSome(), "expected listed ingredient to be retired")
+
+	restored, err := f.Ingredients.Restore(ctx, ingredient.ID)
+	testutil.Ok(t, err)
+	testutil.ErrorIf(t, restored.DeletedAt.IsSome(), "expected DeletedAt to be cleared")
+	_, err = f.Ingredients.Get(ctx, ingredient.ID)
+	testutil.Ok(t, err)
+	_, err = f.Inventory.Get(ctx, ingredient.ID)
+	testutil.ErrorIsNotFound(t, err)
+	testutil.AuditTouches(t, f.LatestAuditEntry(ingredientsauthz.ActionRestore), ingredient.ID.EntityUID())
+}
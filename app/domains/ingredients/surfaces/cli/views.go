@@ -3,6 +3,7 @@ package cli
 import (
 	"encoding/json"
 	"io"
+	"time"
 
 	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
@@ -17,20 +18,28 @@ type IngredientRow struct {
 	Category string               `table:"CATEGORY" json:"category"`
 	Unit     string               `table:"UNIT" json:"unit"`
 	Desc     string               `table:"DESCRIPTION" json:"description,omitempty"`
+	Deleted  string               `table:"DELETED_AT" json:"deleted_at,omitempty"`
 	Tags     tag.CanonicalStrings `table:"TAGS" json:"tags"`
+	Version  int64                `table:"-" json:"version,omitempty"`
 }
 
 func ToIngredientRow(i *models.Ingredient) IngredientRow {
 	if i == nil {
 		return IngredientRow{}
 	}
+	var deletedAt string
+	if t, ok := i.DeletedAt.Unwrap(); ok {
+		deletedAt = t.Format(time.RFC3339)
+	}
 	return IngredientRow{
 		ID:       i.ID.String(),
 		Name:     i.Name,
 		Category: string(i.Category),
 		Unit:     string(i.Unit),
 		Desc:     i.Description,
+		Deleted:  deletedAt,
 		Tags:     i.Tags.Canonical(),
+		Version:  i.Version,
 	}
 }
 
@@ -92,5 +101,6 @@ func DecodeUpdate(r io.Reader) (*models.Ingredient, error) {
 		Category:    models.Category(row.Category),
 		Unit:        measurement.Unit(row.Unit),
 		Description: row.Desc,
+		Version:     row.Version,
 	}, nil
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
 	"github.com/TheFellow/go-modular-monolith/pkg/paging"
 	"github.com/TheFellow/go-modular-monolith/pkg/presentation/actions"
+	"github.com/TheFellow/go-modular-monolith/pkg/presentation/preview"
 	toolkit "github.com/TheFellow/go-modular-monolith/pkg/toolkits/gui"
 )
 
@@ -47,26 +48,29 @@ type Form struct {
 }
 
 type State struct {
-	Status       toolkit.LoadStatus
-	Items        []models.Ingredient
-	Selected     *models.Ingredient
-	Category     models.Category
-	Expression   string
-	Limit        int
-	Cursor, Next paging.Cursor
-	History      []paging.Cursor
-	Mode         Mode
-	Form         Form
-	Err          error
-	Submitting   bool
-	Dirty        bool
-	CanUpdate    bool
-	CanDelete    bool
-	CanTag       bool
-	CanCreate    bool
-	CanList      bool
-	Actions      map[actions.ID]actions.State
-	FormInstance uint64
+	Status     toolkit.LoadStatus
+	Items      []models.Ingredient
+	Selected   *models.Ingredient
+	Category   models.Category
+	Expression string
+	Limit      int
+	// IncludeDeleted lists retired ingredients so they can be restored.
+	IncludeDeleted bool
+	Cursor, Next   paging.Cursor
+	History        []paging.Cursor
+	Mode           Mode
+	Form           Form
+	Err            error
+	Submitting     bool
+	Dirty          bool
+	CanUpdate      bool
+	CanDelete      bool
+	CanTag         bool
+	CanCreate      bool
+	CanList        bool
+	CanRestore     bool
+	Actions        map[actions.ID]actions.State
+	FormInstance   uint64
 }
 
 type Presenter struct {
@@ -119,7 +123,7 @@ func (p *Presenter) Load() {
 
 func (p *Presenter) loadPage(appendPage bool) {
 	p.mu.Lock()
-	request := ingredients.ListRequest{Category: p.state.Category, Filter: p.state.Expression, Cursor: p.state.Cursor, Limit: p.state.Limit}
+	request := ingredients.ListRequest{Category: p.state.Category, Filter: p.state.Expression, Cursor: p.state.Cursor, Limit: p.state.Limit, IncludeDeleted: p.state.IncludeDeleted}
 	p.mu.Unlock()
 	p.loads.LoadContext(p.app.Context(), func(ctx context.Context) (loadResult, error) {
 		page, err := p.app.Ingredients.List(p.app.ContextFrom(ctx), request)
@@ -174,6 +178,16 @@ func (p *Presenter) Filter(category models.Category, expression string, limits .
 	p.loadPage(false)
 	return true
 }
+
+// ShowDeleted toggles whether retired ingredients are listed and reloads the
+// first page.
+func (p *Presenter) ShowDeleted(on bool) {
+	p.mu.Lock()
+	p.state.IncludeDeleted = on
+	p.state.Cursor, p.state.Next, p.state.History = "", "", nil
+	p.mu.Unlock()
+	p.loadPage(false)
+}
 func (p *Presenter) NextPage() {
 	p.mu.Lock()
 	if p.state.Next == "" || p.state.Status == toolkit.Loading {
@@ -234,7 +248,7 @@ func (p *Presenter) leaveDetail(reset bool) {
 	proceed := func() {
 		p.mu.Lock()
 		if reset {
-			p.state.Category, p.state.Expression, p.state.Limit = "", "", toolkit.PageLimit
+			p.state.Category, p.state.Expression, p.state.Limit, p.state.IncludeDeleted = "", "", toolkit.PageLimit, false
 			p.state.Cursor, p.state.Next, p.state.History = "", "", nil
 		}
 		p.state.Mode, p.state.Dirty, p.state.Err = Browse, false, nil
@@ -372,7 +386,7 @@ func (p *Presenter) Submit(form Form) bool {
 				return errors.Invalidf("ingredient is required")
 			}
 			_, err = app.RunTaggedMutation(p.app.App, p.app.Context(), desired, func(ctx *middleware.Context) (*models.Ingredient, error) {
-				return p.app.Ingredients.Update(ctx, &models.Ingredient{ID: selected.ID, Name: strings.TrimSpace(form.Name), Category: category, Unit: unit, Description: strings.TrimSpace(form.Description)})
+				return p.app.Ingredients.Update(ctx, &models.Ingredient{ID: selected.ID, Name: strings.TrimSpace(form.Name), Category: category, Unit: unit, Description: strings.TrimSpace(form.Description), Version: selected.Version})
 			})
 		case Tags:
 			if selected == nil {
@@ -394,6 +408,9 @@ func (p *Presenter) Submit(form Form) bool {
 		stayDetail := err == nil && mode == Edit && selected != nil
 		p.publishLocked()
 		p.mu.Unlock()
+		if errors.IsConflict(err) && mode == Edit && selected != nil && p.offerMerge(selected, form) {
+			return
+		}
 		toolkit.ShowPresentation(p.dialogs, err)
 		if stayDetail {
 			updated, getErr := p.app.Ingredients.Get(p.app.Context(), selected.ID)
@@ -429,37 +446,49 @@ func (p *Presenter) Submit(form Form) bool {
 	return accepted
 }
 
+// offerMerge asks whether to reload an ingredient that changed while it was
+// being edited. Accepting rebases the edits onto the latest version, leaving
+// them unsaved for review. It reports false when the latest version cannot be
+// loaded, so the caller presents the conflict itself.
+func (p *Presenter) offerMerge(base *models.Ingredient, edited Form) bool {
+	latest, err := p.app.Ingredients.Get(p.app.Context(), base.ID)
+	if err != nil || p.dialogs == nil {
+		return false
+	}
+	message := fmt.Sprintf("%q was changed by someone else while you were editing.\n\nReload the latest version and keep your edits?", base.Name)
+	p.dialogs.Confirm("Ingredient Changed", message, func(ok bool) {
+		if !ok {
+			return
+		}
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.state.Selected == nil || p.state.Selected.ID != latest.ID {
+			return
+		}
+		p.state.Selected = latest
+		p.state.Form = toolkit.MergeForm(formFromIngredient(base), edited, formFromIngredient(latest))
+		p.state.Dirty = !reflect.DeepEqual(p.state.Form, formFromIngredient(latest))
+		p.state.FormInstance++
+		p.state.Err = nil
+		for i := range p.state.Items {
+			if p.state.Items[i].ID == latest.ID {
+				p.state.Items[i] = *latest
+			}
+		}
+		p.publishLocked()
+	})
+	return true
+}
+
 func (p *Presenter) RequestDelete() {
 	p.RequestRetire("", "")
 }
 
 func (p *Presenter) RequestRetire(replacementID, replacementRatio string) {
-	p.mu.Lock()
-	target := p.state.Selected
-	allowed := p.actionEnabledLocked(ingredients.ControlDelete)
-	p.mu.Unlock()
-	if target == nil || !allowed {
+	target, retirement, ok := p.retirementRequest(replacementID, replacementRatio)
+	if !ok {
 		return
 	}
-	retirement := models.Retirement{}
-	replacementID = strings.TrimSpace(replacementID)
-	replacementRatio = strings.TrimSpace(replacementRatio)
-	if replacementID != "" {
-		parsed, err := entity.ParseIngredientID(replacementID)
-		if err != nil {
-			p.presentRetirementError(err)
-			return
-		}
-		retirement.ReplacementID = parsed
-		if replacementRatio != "" {
-			ratio, err := strconv.ParseFloat(replacementRatio, 64)
-			if err != nil {
-				p.presentRetirementError(errors.Invalidf("invalid replacement ratio %q", replacementRatio))
-				return
-			}
-			retirement.Ratio = ratio
-		}
-	}
 	p.executor.Execute(func() {
 		count, err := p.countDrinksUsing(target.ID)
 		p.dispatcher.Dispatch(func() {
@@ -484,6 +513,56 @@ func (p *Presenter) RequestRetire(replacementID, replacementRatio string) {
 	})
 }
 
+// PreviewRetire retires the selected ingredient in a dry run and shows what
+// would change across drinks, menus and inventory. Nothing is saved.
+func (p *Presenter) PreviewRetire(replacementID, replacementRatio string) {
+	target, retirement, ok := p.retirementRequest(replacementID, replacementRatio)
+	if !ok {
+		return
+	}
+	p.executor.Execute(func() {
+		dry, pv := p.app.DryRun()
+		_, err := dry.Ingredients.Retire(dry.Context(), target.ID, retirement)
+		p.dispatcher.Dispatch(func() {
+			if err != nil {
+				p.presentRetirementError(err)
+				return
+			}
+			p.dialogs.ShowWarning("Retire Preview (not saved)", strings.Join(preview.Lines(pv), "\n"))
+		})
+	})
+}
+
+func (p *Presenter) retirementRequest(replacementID, replacementRatio string) (*models.Ingredient, models.Retirement, bool) {
+	p.mu.Lock()
+	target := p.state.Selected
+	allowed := p.actionEnabledLocked(ingredients.ControlDelete)
+	p.mu.Unlock()
+	if target == nil || !allowed {
+		return nil, models.Retirement{}, false
+	}
+	retirement := models.Retirement{}
+	replacementID = strings.TrimSpace(replacementID)
+	replacementRatio = strings.TrimSpace(replacementRatio)
+	if replacementID != "" {
+		parsed, err := entity.ParseIngredientID(replacementID)
+		if err != nil {
+			p.presentRetirementError(err)
+			return nil, models.Retirement{}, false
+		}
+		retirement.ReplacementID = parsed
+		if replacementRatio != "" {
+			ratio, err := strconv.ParseFloat(replacementRatio, 64)
+			if err != nil {
+				p.presentRetirementError(errors.Invalidf("invalid replacement ratio %q", replacementRatio))
+				return nil, models.Retirement{}, false
+			}
+			retirement.Ratio = ratio
+		}
+	}
+	return target, retirement, true
+}
+
 func (p *Presenter) presentRetirementError(err error) {
 	p.mu.Lock()
 	p.state.Err = toolkit.PresentError(err)
@@ -522,6 +601,43 @@ func (p *Presenter) retire(id entity.IngredientID, retirement models.Retirement)
 	return accepted
 }
 
+// Restore returns the selected retired ingredient to the catalog.
+func (p *Presenter) Restore() bool {
+	p.mu.Lock()
+	target := p.state.Selected
+	if target == nil || !p.actionEnabledLocked(ingredients.ControlRestore) || p.state.Submitting {
+		p.mu.Unlock()
+		return false
+	}
+	id := target.ID
+	p.state.Submitting = true
+	p.publishLocked()
+	p.mu.Unlock()
+	accepted := p.mutation.Submit(func() error {
+		_, err := p.app.Ingredients.Restore(p.app.Context(), id)
+		return err
+	}, func(err error) {
+		p.mu.Lock()
+		p.state.Submitting, p.state.Err = false, toolkit.PresentError(err)
+		if err == nil {
+			p.state.Mode, p.state.Dirty = Browse, false
+		}
+		p.publishLocked()
+		p.mu.Unlock()
+		toolkit.ShowPresentation(p.dialogs, err)
+		if err == nil {
+			p.Load()
+		}
+	})
+	if !accepted {
+		p.mu.Lock()
+		p.state.Submitting = p.mutation.Active()
+		p.publishLocked()
+		p.mu.Unlock()
+	}
+	return accepted
+}
+
 func (p *Presenter) countDrinksUsing(ingredientID entity.IngredientID) (int, error) {
 	count := 0
 	request := drinks.ListRequest{Limit: paging.DefaultLimit}
@@ -570,7 +686,7 @@ func (p *Presenter) publishLocked() {
 
 func (p *Presenter) permissionsForLocked(ingredient *models.Ingredient) error {
 	p.state.Actions = nil
-	p.state.CanList, p.state.CanCreate, p.state.CanUpdate, p.state.CanDelete, p.state.CanTag = false, false, false, false, false
+	p.state.CanList, p.state.CanCreate, p.state.CanUpdate, p.state.CanDelete, p.state.CanTag, p.state.CanRestore = false, false, false, false, false, false
 	states, err := p.projector.Project(p.app.Context(), p.app.Context().Principal(), ingredient)
 	if err != nil {
 		return err
@@ -584,6 +700,7 @@ func (p *Presenter) permissionsForLocked(ingredient *models.Ingredient) error {
 	p.state.CanUpdate = p.state.Actions[ingredients.ControlEdit].Visible
 	p.state.CanDelete = p.state.Actions[ingredients.ControlDelete].Visible
 	p.state.CanTag = p.state.Actions[ingredients.ControlTags].Visible
+	p.state.CanRestore = p.state.Actions[ingredients.ControlRestore].Visible
 	return nil
 }
 
@@ -268,6 +268,33 @@ func TestMutationFormUpdatesIngredientAndTagsAtomically(t *testing.T) {
 	testutil.Equals(t, got.Tags.Canonical().String(), "featured,region=west")
 }
 
+func TestStaleEditOffersReloadAndKeepsLocalEdits(t *testing.T) {
+	fix, gin, _ := ingredientFixture(t)
+	presenter, dialogs := newTestPresenter(fix.App, toolkit.InlineExecutor{})
+	presenter.Load()
+	presenter.Select(gin.ID)
+	_, err := fix.Ingredients.Update(fix.OwnerContext(), &models.Ingredient{ID: gin.ID, Name: gin.Name, Category: gin.Category, Unit: gin.Unit, Description: "Navy strength"})
+	testutil.Ok(t, err)
+
+	form := presenter.Snapshot().Form
+	form.Name = "Plymouth Gin"
+	testutil.Equals(t, presenter.Submit(form), true)
+	testutil.Equals(t, len(dialogs.Confirmations()), 1)
+	testutil.Equals(t, dialogs.Confirmations()[0].Title, "Ingredient Changed")
+	dialogs.Confirmations()[0].Respond(true)
+	state := presenter.Snapshot()
+	testutil.Equals(t, state.Form.Name, "Plymouth Gin")
+	testutil.Equals(t, state.Form.Description, "Navy strength")
+	testutil.ErrorIf(t, !state.Dirty || state.Err != nil, "merged form state = %#v", state)
+
+	testutil.Equals(t, presenter.Submit(state.Form), true)
+	got, err := fix.Ingredients.Get(fix.OwnerContext(), gin.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, got.Name, "Plymouth Gin")
+	testutil.Equals(t, got.Description, "Navy strength")
+	testutil.Equals(t, got.Version, int64(3))
+}
+
 func TestPresenterDeleteRequiresConfirmationAndPersists(t *testing.T) {
 	fix, gin, _ := ingredientFixture(t)
 	presenter, dialogs := newTestPresenter(fix.App, toolkit.InlineExecutor{})
@@ -305,6 +332,25 @@ func TestPresenterRetireWithExplicitReplacementRewritesDrink(t *testing.T) {
 	testutil.Equals(t, got.Status, drinksmodels.StatusActive)
 }
 
+func TestPresenterPreviewRetireReportsDrinkWithoutRetiring(t *testing.T) {
+	fix := testutil.NewFixture(t)
+	retired := testutil.CreateIngredient(t, fix, models.Ingredient{Name: "Herradura", Category: models.CategorySpirit, Unit: measurement.UnitOz})
+	drink := testutil.CreateDrink(t, fix, drinksmodels.Drink{Name: "House Margarita", Category: drinksmodels.DrinkCategoryCocktail, Glass: drinksmodels.GlassTypeCoupe, Recipe: drinksmodels.Recipe{Ingredients: []drinksmodels.RecipeIngredient{{IngredientID: retired.ID, Amount: measurement.MustAmount(1, retired.Unit)}}, Steps: []string{"Shake"}}})
+	presenter, dialogs := newTestPresenter(fix.App, toolkit.InlineExecutor{})
+	presenter.Load()
+	presenter.Select(retired.ID)
+	presenter.PreviewRetire("", "")
+	warnings := dialogs.Warnings()
+	testutil.ErrorIf(t, len(warnings) != 1, "preview warnings = %#v", warnings)
+	testutil.StringContains(t, warnings[0].Message, drink.ID.String())
+	testutil.Equals(t, len(dialogs.Confirmations()), 0)
+	_, err := fix.Ingredients.Get(fix.OwnerContext(), retired.ID)
+	testutil.Ok(t, err)
+	got, err := fix.Drinks.Get(fix.OwnerContext(), drink.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, got.Status, drinksmodels.StatusActive)
+}
+
 func TestPresenterDeletePermissionFailureIsShownAndDoesNotMutate(t *testing.T) {
 	fix, gin, _ := ingredientFixture(t)
 	denied := application.NewSession(fix.ActorContext("bartender"), fix.App.App)
@@ -442,3 +488,49 @@ func TestTagsFormKeepsInvalidSyntaxVisibleAndRetainsInput(t *testing.T) {
 	testutil.ErrorIf(t, view.tagOnly.Input.Text != invalid, "%v", "invalid pending tag input is not visible")
 	testutil.ErrorIf(t, len(dialogs.Errors()) != 0 || len(dialogs.Warnings()) != 0, "%v", "inline validation unexpectedly opened a dialog")
 }
+
+func TestShowRetiredListsRetiredIngredientsAndRestoreBringsThemBack(t *testing.T) {
+	gui := frameworktest.NewApp()
+	t.Cleanup(gui.Quit)
+	fix, gin, _ := ingredientFixture(t)
+	_, err := fix.Ingredients.Retire(fix.OwnerContext(), gin.ID, models.Retirement{})
+	testutil.Ok(t, err)
+	presenter, _ := newTestPresenter(fix.App, toolkit.InlineExecutor{})
+	view := NewView(presenter)
+	view.Activate()
+	driver := fynetest.NewDriver(t, view.Content())
+	testutil.Equals(t, len(presenter.Snapshot().Items), 1)
+
+	driver.Tap(ControlShowDeleted)
+	state := presenter.Snapshot()
+	testutil.Equals(t, state.IncludeDeleted, true)
+	testutil.Equals(t, len(state.Items), 2)
+	presenter.Select(gin.ID)
+	state = presenter.Snapshot()
+	testutil.ErrorIf(t, state.Mode != Viewing || !state.CanRestore || state.CanDelete || state.CanUpdate, "retired ingredient state = %#v", state)
+	testutil.ErrorIf(t, view.restore.Hidden || !view.delete.Hidden || !view.tagAction.Hidden, "%v", "retired ingredient detail did not offer only restore")
+
+	driver.Tap(ControlRestore)
+	got, err := fix.Ingredients.Get(fix.OwnerContext(), gin.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, got.DeletedAt.IsNone(), true)
+	testutil.AuditTouches(t, fix.LatestAuditEntry(ingredientauthz.ActionRestore), gin.EntityUID())
+	testutil.Equals(t, presenter.Snapshot().Mode, Browse)
+
+	presenter.ResetList()
+	testutil.Equals(t, presenter.Snapshot().IncludeDeleted, false)
+	testutil.Equals(t, view.showDeleted.Checked, false)
+}
+
+func TestRestoreRequiresProjectedPermission(t *testing.T) {
+	fix, gin, _ := ingredientFixture(t)
+	_, err := fix.Ingredients.Retire(fix.OwnerContext(), gin.ID, models.Retirement{})
+	testutil.Ok(t, err)
+	denied := application.NewSession(fix.ActorContext("bartender"), fix.App.App)
+	presenter, _ := newTestPresenter(denied, toolkit.InlineExecutor{})
+	presenter.ShowDeleted(true)
+	presenter.Select(gin.ID)
+	testutil.NotNil(t, presenter.Snapshot().Selected)
+	testutil.Equals(t, presenter.Snapshot().CanRestore, false)
+	testutil.Equals(t, presenter.Restore(), false)
+}
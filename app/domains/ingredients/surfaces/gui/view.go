@@ -23,6 +23,8 @@ const (
 	ControlEdit         = string(domain.ControlEdit)
 	ControlDelete       = string(domain.ControlDelete)
 	ControlTags         = string(domain.ControlTags)
+	ControlRestore      = string(domain.ControlRestore)
+	ControlShowDeleted  = "ingredients-show-deleted"
 	ControlSelectPrefix = "ingredient-select-"
 	ControlFormTags     = "ingredient-form-tags"
 	ControlName         = "ingredient-form-name"
@@ -46,7 +48,8 @@ type View struct {
 	tags, tagOnly                                      *ui.TagTokenEditor
 	save, cancel, refresh, create                      *ui.SemanticButton
 	tagSave, tagCancel                                 *ui.SemanticButton
-	tagAction, delete                                  *ui.SemanticButton
+	tagAction, delete, previewRetire, restore          *ui.SemanticButton
+	showDeleted                                        *ui.SemanticCheck
 	status, formStatus, detailTitle, crumbName         *widget.Label
 	tagStatus                                          *widget.Label
 	rendering                                          bool
@@ -66,9 +69,14 @@ func NewView(p *Presenter) *View {
 	for _, category := range models.AllCategories() {
 		presets = append(presets, ui.FilterOption{Label: string(category), Expression: fmt.Sprintf(`category == %q`, category)})
 	}
+	v.showDeleted = ui.NewCheck(ControlShowDeleted, "Show retired", func(on bool) {
+		if !v.rendering {
+			p.ShowDeleted(on)
+		}
+	})
 	bar := ui.NewSingleRowFilterBar(ControlFilter, ControlApplyFilter, `Filter ingredients (for example: name.contains("gin"))`, p.Snapshot().Expression,
 		[]ui.FilterPreset{{ID: "ingredients-filter-category", Placeholder: "Category", Options: presets}},
-		nil, func(expression string) { p.Filter("", expression, ui.PageLimit) })
+		v.showDeleted, func(expression string) { p.Filter("", expression, ui.PageLimit) })
 	v.expression = bar.Expression
 	v.state = p.Snapshot()
 	columns := []string{"Name", "Category", "Unit", "Description", "Tags", "Actions"}
@@ -77,7 +85,11 @@ func NewView(p *Presenter) *View {
 	}, func(id widget.TableCellID, object framework.CanvasObject) {
 		cell := object
 		item := v.state.Items[id.Row]
-		values := []string{item.Name, string(item.Category), string(item.Unit), item.Description, item.Tags.Canonical().String()}
+		name := item.Name
+		if item.DeletedAt.IsSome() {
+			name += " (retired)"
+		}
+		values := []string{name, string(item.Category), string(item.Unit), item.Description, item.Tags.Canonical().String()}
 		if id.Col == len(columns)-1 {
 			itemID := item.ID
 			ui.ShowCellActions(cell, []ui.RowAction{{Label: "View", Run: func() { p.Select(itemID) }}})
@@ -108,6 +120,10 @@ func NewView(p *Presenter) *View {
 	v.delete = ui.Destructive(ui.WithIcon(ui.NewButton(ControlDelete, "Retire", func() {
 		p.RequestRetire(v.replacementID.Text, v.replacementRatio.Text)
 	}), ui.IconDelete))
+	v.previewRetire = ui.NewButton(ControlDelete+".preview", "Preview retire", func() {
+		p.PreviewRetire(v.replacementID.Text, v.replacementRatio.Text)
+	})
+	v.restore = ui.Primary(ui.NewButton(ControlRestore, "Restore", func() { p.Restore() }))
 	v.status = widget.NewLabel("")
 	v.browse = ui.StandardListPage(ui.ListPage{Title: "Ingredients", Filters: bar.Content, CollectionActions: []framework.CanvasObject{v.create, v.refresh}, List: v.listStack, Status: v.status, ListRatio: .35}).(*framework.Container)
 
@@ -132,7 +148,7 @@ func NewView(p *Presenter) *View {
 	v.crumbName = widget.NewLabel("")
 	v.formStatus = widget.NewLabel("")
 	fields := ui.DetailForm(ui.DetailField("Name", v.name), ui.DetailField("Category", v.formCategory), ui.DetailField("Unit", v.formUnit), ui.DetailField("Description", v.description), ui.DetailField("Tags", v.tags.Content), ui.DetailField("Permanent replacement", v.replacementID), ui.DetailField("Replacement ratio", v.replacementRatio))
-	breadcrumb := container.NewHBox(ui.WithIcon(ui.NewButton(ControlBack, "Back", p.Back), ui.IconBack), ui.NewButton(ControlBreadcrumb, "Ingredients", p.ResetList), widget.NewLabel(">"), v.crumbName, v.tagAction, v.delete)
+	breadcrumb := container.NewHBox(ui.WithIcon(ui.NewButton(ControlBack, "Back", p.Back), ui.IconBack), ui.NewButton(ControlBreadcrumb, "Ingredients", p.ResetList), widget.NewLabel(">"), v.crumbName, v.tagAction, v.previewRetire, v.delete, v.restore)
 	v.formPanel = ui.StandardFormPage(ui.FormPage{TitleLabel: v.detailTitle, Breadcrumb: breadcrumb, Fields: fields, Status: v.formStatus, Save: v.save, Cancel: v.cancel}).(*framework.Container)
 	v.tagOnly = ui.NewTagTokenEditor(ControlFormTags, "")
 	v.tagOnly.Normalize = tag.UpsertCollection
@@ -254,6 +270,11 @@ func (v *View) render(s State) {
 	v.create.Hidden = !s.CanCreate
 	v.tagAction.Hidden = s.Selected == nil || !s.CanTag || s.Mode == Create
 	v.delete.Hidden = s.Selected == nil || !s.CanDelete || s.Mode == Create
+	v.previewRetire.Hidden = v.delete.Hidden
+	v.restore.Hidden = s.Selected == nil || !s.CanRestore || s.Mode == Create
+	if v.showDeleted.Checked != s.IncludeDeleted {
+		v.showDeleted.SetChecked(s.IncludeDeleted)
+	}
 	v.empty.Hidden = s.Status != ui.Loaded || len(s.Items) != 0
 	v.list.Hidden = s.Status == ui.Loaded && len(s.Items) == 0
 	if s.Selected != nil {
@@ -11,8 +11,10 @@ import (
 	ingredientauthz "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/authz"
 	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/tuitest"
 	cedar "github.com/cedar-policy/cedar-go"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -84,3 +86,29 @@ func TestIngredientActionProjectionEvaluatorErrorRecovers(t *testing.T) {
 	testutil.Equals(t, vm.actionEnabled(ingredients.ControlCreate), true)
 	testutil.ErrorIf(t, strings.Contains(vm.View(), want.Error()), "recovered projection still renders error: %s", vm.View())
 }
+
+func TestRestoreKeyBringsBackSelectedRetiredIngredient(t *testing.T) {
+	fix := testutil.NewFixture(t)
+	ingredient := testutil.CreateIngredient(t, fix, models.Ingredient{Name: "Restorable", Category: models.CategoryOther, Unit: measurement.UnitOz})
+	_, err := fix.Ingredients.Delete(fix.OwnerContext(), ingredient.ID)
+	testutil.Ok(t, err)
+
+	reader := NewListViewModel(application.NewSession(fix.ActorContext("anonymous"), fix.App.App))
+	reader.request.IncludeDeleted = true
+	tuitest.NewDriver(t, &ingredientsPagingProgram{vm: reader}).Press("b")
+	testutil.Equals(t, reader.actionEnabled(ingredients.ControlRestore), false)
+	_, err = fix.Ingredients.Get(fix.OwnerContext(), ingredient.ID)
+	testutil.ErrorIf(t, err == nil, "%v", "read-only actor restored the ingredient")
+
+	program := &ingredientsPagingProgram{vm: NewListViewModel(fix.App)}
+	program.vm.request.IncludeDeleted = true
+	driver := tuitest.NewDriver(t, program)
+	testutil.Equals(t, program.vm.selectedIngredient().ID, ingredient.ID)
+	testutil.Equals(t, program.vm.actionEnabled(ingredients.ControlRestore), true)
+	testutil.Equals(t, program.vm.actionEnabled(ingredients.ControlEdit), false)
+	driver.Press("b")
+	restored, err := fix.Ingredients.Get(fix.OwnerContext(), ingredient.ID)
+	testutil.Ok(t, err)
+	testutil.ErrorIf(t, restored.DeletedAt.IsSome(), "%v", "ingredient was not restored")
+	testutil.Equals(t, program.vm.actionEnabled(ingredients.ControlRestore), false)
+}
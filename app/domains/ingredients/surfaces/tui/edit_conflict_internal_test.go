@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestEditIngredientMergesConcurrentChangeAfterConflict(t *testing.T) {
+	t.Parallel()
+	fix := testutil.NewFixture(t)
+	opened, err := fix.Ingredients.Create(fix.OwnerContext(), &models.Ingredient{
+		Name: "Gin", Category: models.CategorySpirit, Unit: measurement.UnitOz,
+	})
+	testutil.Ok(t, err)
+	vm := NewEditIngredientVM(fix.App, opened)
+
+	_, err = fix.Ingredients.Update(fix.OwnerContext(), &models.Ingredient{
+		ID: opened.ID, Name: "Gin", Category: models.CategorySpirit, Unit: measurement.UnitOz,
+		Description: "Juniper forward", Version: opened.Version,
+	})
+	testutil.Ok(t, err)
+
+	_ = vm.nameField.SetValue("London Dry Gin")
+	msg := vm.submit()()
+	conflict, ok := msg.(UpdateConflictMsg)
+	testutil.ErrorIf(t, !ok, "submit = %#v", msg)
+	testutil.ErrorIsConflict(t, conflict.Err)
+	vm, _ = vm.Update(conflict)
+	testutil.ErrorIsConflict(t, vm.err)
+	testutil.Equals(t, vm.description.Value(), "Juniper forward")
+
+	msg = vm.submit()()
+	updated, ok := msg.(IngredientUpdatedMsg)
+	testutil.ErrorIf(t, !ok, "resubmit = %#v", msg)
+	testutil.Equals(t, updated.Ingredient.Name, "London Dry Gin")
+	testutil.Equals(t, updated.Ingredient.Description, "Juniper forward")
+	testutil.Equals(t, updated.Ingredient.Version, int64(3))
+}
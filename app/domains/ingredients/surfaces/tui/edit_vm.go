@@ -43,6 +43,13 @@ type UpdateErrorMsg struct {
 	Err error
 }
 
+// UpdateConflictMsg is sent when the ingredient changed after the form was
+// opened. Latest is the stored ingredient to merge the form onto.
+type UpdateConflictMsg struct {
+	Err    error
+	Latest *models.Ingredient
+}
+
 // NewEditIngredientVM builds an EditIngredientVM with fields configured.
 func NewEditIngredientVM(app *app.Session, ingredient *models.Ingredient) *EditIngredientVM {
 	if ingredient == nil {
@@ -121,6 +128,10 @@ func (m *EditIngredientVM) Update(msg tea.Msg) (*EditIngredientVM, tea.Cmd) {
 		m.submitting = false
 		m.err = typed.Err
 		return m, nil
+	case UpdateConflictMsg:
+		m.submitting = false
+		m.err = m.rebase(typed.Latest)
+		return m, nil
 	case tea.KeyMsg:
 		if key.Matches(typed, m.keys.Submit) {
 			return m, m.submit()
@@ -178,12 +189,18 @@ func (m *EditIngredientVM) submit() tea.Cmd {
 		Category:    toCategory(m.category.Value()),
 		Unit:        toUnit(m.unit.Value()),
 		Description: strings.TrimSpace(toString(m.description.Value())),
+		Version:     m.ingredient.Version,
 	}
 
 	return func() tea.Msg {
 		ingredient, err := app.RunTaggedMutation(m.app.App, m.context(), desired, func(ctx *middleware.Context) (*models.Ingredient, error) {
 			return m.app.Ingredients.Update(ctx, updated)
 		})
+		if errors.IsConflict(err) {
+			if latest, getErr := m.app.Ingredients.Get(m.context(), updated.ID); getErr == nil {
+				return UpdateConflictMsg{Err: err, Latest: latest}
+			}
+		}
 		if err != nil {
 			return UpdateErrorMsg{Err: err}
 		}
@@ -191,6 +208,38 @@ func (m *EditIngredientVM) submit() tea.Cmd {
 	}
 }
 
+// rebase moves the form onto latest after a version conflict. Fields the user
+// left alone take the stored values and edited fields keep their input, so
+// submitting again applies the edits on top of the other change.
+func (m *EditIngredientVM) rebase(latest *models.Ingredient) error {
+	base := m.ingredient
+	fields := []struct {
+		field        forms.Field
+		base, latest any
+	}{
+		{m.nameField, base.Name, latest.Name},
+		{m.category, base.Category, latest.Category},
+		{m.unit, base.Unit, latest.Unit},
+		{m.description, base.Description, latest.Description},
+		{m.tags, base.Tags.Canonical().String(), latest.Tags.Canonical().String()},
+	}
+	var contested []string
+	for _, f := range fields {
+		kept, err := forms.Rebase(f.field, f.base, f.latest)
+		if err != nil {
+			return err
+		}
+		if kept {
+			contested = append(contested, f.field.Label())
+		}
+	}
+	m.ingredient = latest
+	if len(contested) == 0 {
+		return errors.Conflictf("ingredient changed while editing; reloaded version %d, submit again to apply your edits", latest.Version)
+	}
+	return errors.Conflictf("ingredient changed while editing; reloaded version %d, your edits to %s replace newer values, submit again to keep them", latest.Version, strings.Join(contested, ", "))
+}
+
 func (m *EditIngredientVM) context() *middleware.Context {
 	return m.app.Context()
 }
@@ -20,6 +20,7 @@ type filterVM struct {
 	category   *forms.SelectField
 	expression *forms.TextField
 	limit      *forms.NumberField
+	deleted    *forms.SelectField
 	err        error
 }
 
@@ -36,8 +37,12 @@ func newFilterVM(req ingredients.ListRequest) *filterVM {
 		category:   forms.NewSelectField("Category", options, forms.WithInitialValue(req.Category)),
 		expression: forms.NewTextField("Expression", forms.WithInitialValue(req.Filter)),
 		limit:      forms.NewNumberField("Page size", forms.WithRequired(), forms.WithMin(1), forms.WithInitialValue(limit)),
+		deleted: forms.NewSelectField("Deleted", []forms.SelectOption{
+			{Label: "hide", Value: false},
+			{Label: "show", Value: true},
+		}, forms.WithInitialValue(req.IncludeDeleted)),
 	}
-	v.form = forms.New(styles.Standard.Form, keys.Standard.Form, v.category, v.expression, v.limit)
+	v.form = forms.New(styles.Standard.Form, keys.Standard.Form, v.category, v.expression, v.limit, v.deleted)
 	return v
 }
 func (v *filterVM) Init() tea.Cmd { return v.form.Init() }
@@ -63,6 +68,6 @@ func (v *filterVM) Request() (ingredients.ListRequest, error) {
 		v.err = fmt.Errorf("page size must be greater than zero")
 		return ingredients.ListRequest{}, v.err
 	}
-	return ingredients.ListRequest{Category: v.category.Value().(models.Category), Filter: strings.TrimSpace(fmt.Sprint(v.expression.Value())), Limit: limit}, nil
+	return ingredients.ListRequest{Category: v.category.Value().(models.Category), Filter: strings.TrimSpace(fmt.Sprint(v.expression.Value())), Limit: limit, IncludeDeleted: v.deleted.Value().(bool)}, nil
 }
 func filterSubmit(msg tea.KeyMsg) bool { return key.Matches(msg, keys.Standard.Form.Submit) }
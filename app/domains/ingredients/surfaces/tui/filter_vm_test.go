@@ -25,7 +25,7 @@ func (p *ingredientsPagingProgram) View() string { return p.vm.View() }
 
 func TestFilterVMComposesEveryStructuredFieldAndExpression(t *testing.T) {
 	t.Parallel()
-	want := ingredients.ListRequest{Category: models.CategorySpirit, Filter: `tags contains "featured"`, Limit: 17}
+	want := ingredients.ListRequest{Category: models.CategorySpirit, Filter: `tags contains "featured"`, Limit: 17, IncludeDeleted: true}
 	form := newFilterVM(want)
 	got, err := form.Request()
 	testutil.Ok(t, err)
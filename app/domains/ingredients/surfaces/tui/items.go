@@ -10,5 +10,9 @@ import (
 type ingredientItem = tui.ListItem[models.Ingredient]
 
 func newIngredientItem(ingredient models.Ingredient) ingredientItem {
-	return tui.NewListItem(ingredient, ingredient.Name, fmt.Sprintf("%s • %s", ingredient.Category, ingredient.Unit), ingredient.Name)
+	description := fmt.Sprintf("%s • %s", ingredient.Category, ingredient.Unit)
+	if ingredient.DeletedAt.IsSome() {
+		description += " • retired"
+	}
+	return tui.NewListItem(ingredient, ingredient.Name, description, ingredient.Name)
 }
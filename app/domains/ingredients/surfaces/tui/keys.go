@@ -9,8 +9,9 @@ type listViewKeys struct {
 	keys.ListViewKeys
 	Tags    key.Binding
 	Replace key.Binding
+	Restore key.Binding
 }
 
 func newListViewKeys() listViewKeys {
-	return listViewKeys{ListViewKeys: keys.Standard.ListView, Tags: keys.NewBinding("t", "manage tags", "t"), Replace: keys.NewBinding("R", "retire with replacement", "R")}
+	return listViewKeys{ListViewKeys: keys.Standard.ListView, Tags: keys.NewBinding("t", "manage tags", "t"), Replace: keys.NewBinding("R", "retire with replacement", "R"), Restore: keys.NewBinding("b", "restore", "b")}
 }
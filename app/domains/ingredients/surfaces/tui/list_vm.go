@@ -114,6 +114,11 @@ func (m *ListViewModel) Interaction() tui.Interaction {
 
 func (m *ListViewModel) Update(msg tea.Msg) (tui.ViewModel, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tui.RefreshMsg:
+		if m.mode != listModeBrowsing || !m.actionEnabled(ingredients.ControlList) {
+			return m, nil
+		}
+		return m, tea.Batch(m.shell.BeginLoading(), m.loadIngredients(m.request.Cursor))
 	case tea.WindowSizeMsg:
 		m.setSize(msg.Width, msg.Height)
 		switch m.mode {
@@ -158,6 +163,11 @@ func (m *ListViewModel) Update(msg tea.Msg) (tui.ViewModel, tea.Cmd) {
 		m.deleteTarget = nil
 		m.shell.SetError(msg.Err)
 		return m, nil
+	case IngredientRestoredMsg:
+		return m, tea.Batch(m.shell.BeginLoading(), m.loadIngredients(m.request.Cursor))
+	case RestoreErrorMsg:
+		m.shell.SetError(msg.Err)
+		return m, nil
 	case showDeleteDialogMsg:
 		m.mode = listModeConfirmingDelete
 		m.dialog = msg.dialog
@@ -271,6 +281,11 @@ func (m *ListViewModel) Update(msg tea.Msg) (tui.ViewModel, tea.Cmd) {
 			m.mode, m.retire = listModeRetiring, NewRetireIngredientVM(m.app, ingredient)
 			m.retire.SetWidth(m.detailWidth)
 			return m, m.retire.Init()
+		case key.Matches(msg, m.keys.Restore):
+			if !m.actionEnabled(ingredients.ControlRestore) {
+				return m, nil
+			}
+			return m, m.performRestore()
 		case key.Matches(msg, m.keys.Tags):
 			if !m.actionEnabled(ingredients.ControlTags) {
 				return m, nil
@@ -368,8 +383,10 @@ func (m *ListViewModel) ShortHelp() []key.Binding {
 		return []key.Binding{m.dialogKeys.Confirm, m.keys.Back, m.dialogKeys.Switch}
 	case listModeTagging:
 		return []key.Binding{m.formKeys.Submit, m.keys.Back}
-	case listModeCreating, listModeEditing, listModeRetiring:
+	case listModeCreating, listModeEditing:
 		return []key.Binding{m.keys.Up, m.keys.Down, m.keys.Edit, m.keys.Enter, m.formKeys.Submit, m.keys.Back}
+	case listModeRetiring:
+		return []key.Binding{m.keys.Up, m.keys.Down, m.keys.Edit, m.keys.Enter, m.formKeys.Submit, m.formKeys.Preview, m.keys.Back}
 	case listModeBrowsing:
 		bindings := []key.Binding{}
 		if m.actionEnabled(ingredients.ControlList) {
@@ -394,11 +411,16 @@ func (m *ListViewModel) FullHelp() [][]key.Binding {
 		}
 	case listModeTagging:
 		return [][]key.Binding{{m.formKeys.Submit, m.keys.Back}}
-	case listModeCreating, listModeEditing, listModeRetiring:
+	case listModeCreating, listModeEditing:
 		return [][]key.Binding{
 			{m.keys.Up, m.keys.Down, m.keys.Edit, m.keys.Enter, m.formKeys.Submit},
 			{m.keys.Back},
 		}
+	case listModeRetiring:
+		return [][]key.Binding{
+			{m.keys.Up, m.keys.Down, m.keys.Edit, m.keys.Enter, m.formKeys.Submit, m.formKeys.Preview},
+			{m.keys.Back},
+		}
 	case listModeBrowsing:
 		navigation := []key.Binding{}
 		pagingHelp := []key.Binding{}
@@ -491,6 +513,7 @@ func (m *ListViewModel) visibleBindings() []key.Binding {
 		{ingredients.ControlDelete, m.keys.Delete},
 		{ingredients.ControlDelete, m.keys.Replace},
 		{ingredients.ControlTags, m.keys.Tags},
+		{ingredients.ControlRestore, m.keys.Restore},
 	}
 	bindings := make([]key.Binding, 0, len(pairs))
 	for _, pair := range pairs {
@@ -569,6 +592,21 @@ func (m *ListViewModel) performDelete() tea.Cmd {
 	}
 }
 
+func (m *ListViewModel) performRestore() tea.Cmd {
+	ingredient := m.selectedIngredient()
+	if ingredient == nil {
+		return nil
+	}
+	id := ingredient.ID
+	return func() tea.Msg {
+		restored, err := m.app.Ingredients.Restore(m.context(), id)
+		if err != nil {
+			return RestoreErrorMsg{Err: err}
+		}
+		return IngredientRestoredMsg{Ingredient: restored}
+	}
+}
+
 func (m *ListViewModel) context() *middleware.Context {
 	return m.app.Context()
 }
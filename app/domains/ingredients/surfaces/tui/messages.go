@@ -20,3 +20,13 @@ type IngredientDeletedMsg struct {
 type DeleteErrorMsg struct {
 	Err error
 }
+
+// IngredientRestoredMsg is sent when a retired ingredient has been restored.
+type IngredientRestoredMsg struct {
+	Ingredient *models.Ingredient
+}
+
+// RestoreErrorMsg is sent when a restore operation fails.
+type RestoreErrorMsg struct {
+	Err error
+}
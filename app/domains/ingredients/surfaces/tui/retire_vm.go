@@ -8,6 +8,7 @@ import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/presentation/preview"
 	"github.com/TheFellow/go-modular-monolith/pkg/toolkits/tui/forms"
 	"github.com/TheFellow/go-modular-monolith/pkg/toolkits/tui/keys"
 	"github.com/TheFellow/go-modular-monolith/pkg/toolkits/tui/styles"
@@ -27,6 +28,13 @@ type RetireIngredientVM struct {
 	keys               forms.FormKeys
 	err                error
 	submitting         bool
+	preview            []string
+}
+
+// RetirePreviewMsg carries what a retirement would change, from a dry run.
+type RetirePreviewMsg struct {
+	Lines []string
+	Err   error
 }
 
 func NewRetireIngredientVM(application *app.Session, ingredient *models.Ingredient) *RetireIngredientVM {
@@ -41,8 +49,18 @@ func (m *RetireIngredientVM) SetWidth(width int) { m.form.SetWidth(width) }
 func (m *RetireIngredientVM) IsEditing() bool    { return m.form.IsEditing() }
 
 func (m *RetireIngredientVM) Update(msg tea.Msg) (*RetireIngredientVM, tea.Cmd) {
-	if typed, ok := msg.(tea.KeyMsg); ok && key.Matches(typed, m.keys.Submit) {
-		return m, m.submit()
+	switch typed := msg.(type) {
+	case RetirePreviewMsg:
+		m.submitting = false
+		m.err, m.preview = typed.Err, typed.Lines
+		return m, nil
+	case tea.KeyMsg:
+		if key.Matches(typed, m.keys.Submit) {
+			return m, m.submit()
+		}
+		if key.Matches(typed, m.keys.Preview) {
+			return m, m.previewRetirement()
+		}
 	}
 	var cmd tea.Cmd
 	m.form, cmd = m.form.Update(msg)
@@ -51,6 +69,9 @@ func (m *RetireIngredientVM) Update(msg tea.Msg) (*RetireIngredientVM, tea.Cmd)
 
 func (m *RetireIngredientVM) View() string {
 	content := "Retire Ingredient\n\n" + m.form.View() + "\n\nLeave replacement blank to retire into review/degraded state."
+	if len(m.preview) > 0 {
+		content += "\n\nPreview (not saved):\n" + strings.Join(m.preview, "\n")
+	}
 	if m.err != nil {
 		return m.styles.Error.Render("Error: "+m.err.Error()) + "\n\n" + content
 	}
@@ -61,13 +82,49 @@ func (m *RetireIngredientVM) submit() tea.Cmd {
 	if m.submitting || m.ingredient == nil {
 		return nil
 	}
+	retirement, ok := m.retirement()
+	if !ok {
+		return nil
+	}
+	m.submitting = true
+	return func() tea.Msg {
+		retired, err := m.app.Ingredients.Retire(m.app.Context(), m.ingredient.ID, retirement)
+		if err != nil {
+			return DeleteErrorMsg{Err: err}
+		}
+		return IngredientDeletedMsg{Ingredient: retired}
+	}
+}
+
+// previewRetirement dry-runs the retirement so the drinks, menus, and orders
+// it reaches can be reviewed before submitting.
+func (m *RetireIngredientVM) previewRetirement() tea.Cmd {
+	if m.submitting || m.ingredient == nil {
+		return nil
+	}
+	retirement, ok := m.retirement()
+	if !ok {
+		return nil
+	}
+	m.err = nil
+	m.submitting = true
+	return func() tea.Msg {
+		dry, p := m.app.DryRun()
+		if _, err := dry.Ingredients.Retire(dry.Context(), m.ingredient.ID, retirement); err != nil {
+			return RetirePreviewMsg{Err: err}
+		}
+		return RetirePreviewMsg{Lines: preview.Lines(p)}
+	}
+}
+
+func (m *RetireIngredientVM) retirement() (models.Retirement, bool) {
 	retirement := models.Retirement{}
 	replacement := strings.TrimSpace(toString(m.replacement.Value()))
 	if replacement != "" {
 		id, err := entity.ParseIngredientID(replacement)
 		if err != nil {
 			m.err = err
-			return nil
+			return retirement, false
 		}
 		retirement.ReplacementID = id
 		ratio := strings.TrimSpace(toString(m.ratio.Value()))
@@ -75,17 +132,10 @@ func (m *RetireIngredientVM) submit() tea.Cmd {
 			value, err := strconv.ParseFloat(ratio, 64)
 			if err != nil {
 				m.err = errors.Invalidf("invalid replacement ratio %q", ratio)
-				return nil
+				return retirement, false
 			}
 			retirement.Ratio = value
 		}
 	}
-	m.submitting = true
-	return func() tea.Msg {
-		retired, err := m.app.Ingredients.Retire(m.app.Context(), m.ingredient.ID, retirement)
-		if err != nil {
-			return DeleteErrorMsg{Err: err}
-		}
-		return IngredientDeletedMsg{Ingredient: retired}
-	}
+	return retirement, true
 }
@@ -26,3 +26,19 @@ func TestRetireIngredientVMAppliesExplicitReplacement(t *testing.T) {
 	testutil.Ok(t, err)
 	testutil.Equals(t, got.Recipe.Ingredients[0].IngredientID, replacement.ID)
 }
+
+func TestRetireIngredientVMPreviewShowsAffectedDrinkWithoutRetiring(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	retired := testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{Name: "Previewed", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitOz})
+	drink := testutil.CreateDrink(t, f, drinksmodels.Drink{Name: "TUI preview", Category: drinksmodels.DrinkCategoryCocktail, Glass: drinksmodels.GlassTypeCoupe, Recipe: drinksmodels.Recipe{Ingredients: []drinksmodels.RecipeIngredient{{IngredientID: retired.ID, Amount: measurement.MustAmount(1, retired.Unit)}}, Steps: []string{"Mix"}}})
+	vm := NewRetireIngredientVM(f.App, retired)
+
+	vm, _ = vm.Update(vm.previewRetirement()())
+	testutil.Ok(t, vm.err)
+	testutil.StringContains(t, vm.View(), "Preview (not saved):")
+	testutil.StringContains(t, vm.View(), "touches "+drink.ID.EntityUID().String())
+	got, err := f.Ingredients.Get(f.OwnerContext(), retired.ID)
+	testutil.Ok(t, err)
+	testutil.IsTrue(t, got.DeletedAt.IsNone())
+}
@@ -8,7 +8,8 @@ import (
 
 func (m *Module) Update(ctx *middleware.Context, ingredient *models.Ingredient) (*models.Ingredient, error) {
 	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Ingredient, *models.Ingredient]{
-		Action: authz.ActionUpdate,
+		Action:  authz.ActionUpdate,
+		Request: ingredient,
 		Load: func(ctx *middleware.Context) (*models.Ingredient, error) {
 			return m.queries.Get(ctx, ingredient.ID)
 		},
@@ -8,10 +8,14 @@ import (
 
 func (m *Module) Adjust(ctx *middleware.Context, patch *models.Patch) (*models.Inventory, error) {
 	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Patch, *models.Inventory]{
-		Action: authz.ActionAdjust,
+		Action:  authz.ActionAdjust,
+		Request: patch,
 		Load: func(*middleware.Context) (*models.Patch, error) {
 			return patch, nil
 		},
+		Before: func(ctx *middleware.Context, in *models.Patch) (*models.Inventory, error) {
+			return m.queries.Get(ctx, in.IngredientID)
+		},
 		Handle: m.commands.Adjust,
 	})
 }
@@ -46,12 +46,15 @@ func ValidateEntity(entity cedar.Entity) error {
 }
 
 var (
-	ActionAdjust = cedar.NewEntityUID(ActionType, "adjust")
-	ActionGet    = cedar.NewEntityUID(ActionType, "get")
-	ActionList   = cedar.NewEntityUID(ActionType, "list")
-	ActionSet    = cedar.NewEntityUID(ActionType, "set")
-	ActionTag    = cedar.NewEntityUID(ActionType, "tag")
-	ActionUntag  = cedar.NewEntityUID(ActionType, "untag")
+	ActionAdjust  = cedar.NewEntityUID(ActionType, "adjust")
+	ActionExport  = cedar.NewEntityUID(ActionType, "export")
+	ActionGet     = cedar.NewEntityUID(ActionType, "get")
+	ActionImport  = cedar.NewEntityUID(ActionType, "import")
+	ActionList    = cedar.NewEntityUID(ActionType, "list")
+	ActionRelease = cedar.NewEntityUID(ActionType, "release")
+	ActionSet     = cedar.NewEntityUID(ActionType, "set")
+	ActionTag     = cedar.NewEntityUID(ActionType, "tag")
+	ActionUntag   = cedar.NewEntityUID(ActionType, "untag")
 )
 
 // Inventory is the Cedar-facing authorization model for Mixology::Inventory.
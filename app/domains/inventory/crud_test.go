@@ -36,7 +36,7 @@ func TestInventory_SetGetAdjustAndRemove(t *testing.T) {
 	wantSet := &models.Inventory{
 		ID: set.ID, IngredientID: ingredient.ID,
 		Amount: measurement.MustAmount(10, ingredient.Unit), CostPerUnit: optional.Some(cost),
-		LastUpdated: set.LastUpdated,
+		LastUpdated: set.LastUpdated, Version: 1,
 	}
 	testutil.Equals(t, set, wantSet)
 
@@ -53,6 +53,7 @@ func TestInventory_SetGetAdjustAndRemove(t *testing.T) {
 	wantAdjusted := *set
 	wantAdjusted.Amount = measurement.MustAmount(12.5, ingredient.Unit)
 	wantAdjusted.LastUpdated = adjusted.LastUpdated
+	wantAdjusted.Version = set.Version + 1
 	testutil.Equals(t, adjusted, &wantAdjusted)
 
 	got, err = f.Inventory.Get(ctx, ingredient.ID)
@@ -1,9 +1,63 @@
 package events
 
-import "github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
+import (
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/money"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
+)
 
 type StockAdjusted struct {
 	Inventory models.Inventory
 	Reason    string
 	Shortage  bool
+
+	// Applied is the change adjust made to the stock, after clamping at zero,
+	// and PreviousCostPerUnit the cost it replaced. Undo reverses both. Set
+	// leaves them empty, as did adjust before they were recorded.
+	Applied             measurement.Amount
+	PreviousCostPerUnit optional.Value[money.Price]
+}
+
+// Topic and Payload publish StockAdjusted through the outbox.
+func (e StockAdjusted) Topic() string { return "inventory.stock_adjusted" }
+
+func (e StockAdjusted) Payload() any {
+	return stockAdjustedPayload{
+		InventoryID:  e.Inventory.ID.String(),
+		IngredientID: e.Inventory.IngredientID.String(),
+		Amount:       amountValue(e.Inventory.Amount),
+		Reserved:     amountValue(e.Inventory.Reserved),
+		Unit:         amountUnit(e.Inventory.Amount),
+		Reason:       e.Reason,
+		Shortage:     e.Shortage,
+		UpdatedAt:    e.Inventory.LastUpdated.UTC(),
+	}
+}
+
+type stockAdjustedPayload struct {
+	InventoryID  string    `json:"inventory_id"`
+	IngredientID string    `json:"ingredient_id"`
+	Amount       float64   `json:"amount"`
+	Reserved     float64   `json:"reserved"`
+	Unit         string    `json:"unit,omitempty"`
+	Reason       string    `json:"reason,omitempty"`
+	Shortage     bool      `json:"shortage"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func amountValue(amount measurement.Amount) float64 {
+	if amount == nil {
+		return 0
+	}
+	return amount.Value()
+}
+
+func amountUnit(amount measurement.Amount) string {
+	if amount == nil {
+		return ""
+	}
+	return string(amount.Unit())
 }
@@ -0,0 +1,51 @@
+package inventory
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/inventory/authz"
+	inventorydao "github.com/TheFellow/go-modular-monolith/app/domains/inventory/internal/dao"
+	"github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// ExportAll returns all stock with its tags and every reservation open orders
+// hold against it. Reservations are exported only with the stock they draw on,
+// so they need no authorization of their own.
+func (m *Module) ExportAll(ctx *middleware.Context) ([]*models.Inventory, []models.Reservation, error) {
+	stock, err := middleware.RunCollectQuery(m.pipeline, ctx, authz.ActionExport, m.queries.List, inventorydao.ListFilter{})
+	if err != nil {
+		return nil, nil, err
+	}
+	reservations, err := m.queries.ListReservations(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stock, reservations, nil
+}
+
+// Import recreates stock exported from another database, keeping its
+// inventory ID, cost, version and last update time. Its reservations are
+// imported separately once their orders exist.
+func (m *Module) Import(ctx *middleware.Context, stock *models.Inventory) (*models.Inventory, error) {
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Inventory, *models.Inventory]{
+		Action:  authz.ActionImport,
+		Request: stock,
+		Load: func(*middleware.Context) (*models.Inventory, error) {
+			return stock, nil
+		},
+		Handle: m.commands.Import,
+	})
+}
+
+// ImportReservation restores an exported reservation against imported stock.
+func (m *Module) ImportReservation(ctx *middleware.Context, reservation models.Reservation) (*models.Inventory, error) {
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Inventory, *models.Inventory]{
+		Action:  authz.ActionImport,
+		Request: reservation,
+		Load: func(c *middleware.Context) (*models.Inventory, error) {
+			return m.queries.Get(c, reservation.IngredientID)
+		},
+		Handle: func(c *middleware.Context, _ *models.Inventory) (*models.Inventory, error) {
+			return m.commands.ImportReservation(c, reservation)
+		},
+	})
+}
@@ -85,6 +85,7 @@ func (c *Commands) Adjust(ctx *middleware.Context, patch *models.Patch) (*models
 	if err != nil {
 		return nil, err
 	}
+	previousAmount, previousCost := updatedAmount, updated.CostPerUnit
 	if hasDelta {
 		delta, err = delta.Convert(ingredient.Unit)
 		if err != nil {
@@ -105,7 +106,7 @@ func (c *Commands) Adjust(ctx *middleware.Context, patch *models.Patch) (*models
 	}
 	updated.LastUpdated = time.Now().UTC()
 
-	if err := c.dao.Upsert(ctx, updated); err != nil {
+	if err := c.dao.Upsert(ctx, &updated); err != nil {
 		return nil, err
 	}
 
@@ -115,10 +116,16 @@ func (c *Commands) Adjust(ctx *middleware.Context, patch *models.Patch) (*models
 		if err != nil {
 			return nil, err
 		}
+		applied, err := updated.Amount.Sub(previousAmount)
+		if err != nil {
+			return nil, err
+		}
 		ctx.AddEvent(events.StockAdjusted{
-			Inventory: updated,
-			Reason:    string(patch.Reason),
-			Shortage:  updated.Amount.Value() < reserved.Value(),
+			Inventory:           updated,
+			Reason:              string(patch.Reason),
+			Shortage:            updated.Amount.Value() < reserved.Value(),
+			Applied:             applied,
+			PreviousCostPerUnit: previousCost,
 		})
 	}
 
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// Import recreates exported stock without raising StockAdjusted; the stock
+// level is restored, not changed.
+func (c *Commands) Import(ctx *middleware.Context, stock *models.Inventory) (*models.Inventory, error) {
+	if stock == nil {
+		return nil, errors.Invalidf("stock is required")
+	}
+	if stock.ID.IsZero() || stock.IngredientID.IsZero() {
+		return nil, errors.Invalidf("id and ingredient id are required for import")
+	}
+	if stock.Amount == nil || stock.Amount.Unit() == "" {
+		return nil, errors.Invalidf("stock for ingredient %s: amount is required", stock.IngredientID.String())
+	}
+	if cost, ok := stock.CostPerUnit.Unwrap(); ok {
+		if err := cost.Validate(); err != nil {
+			return nil, errors.Invalidf("stock for ingredient %s: %w", stock.IngredientID.String(), err)
+		}
+	}
+
+	imported := *stock
+	imported.Reserved = nil
+	imported.Version = max(imported.Version, 1)
+	if err := c.dao.Import(ctx, imported); err != nil {
+		return nil, err
+	}
+	ctx.TouchEntity(imported.EntityUID())
+	return &imported, nil
+}
+
+// ImportReservation restores one of an open order's reservations against
+// stock and returns the stock with its reserved total.
+func (c *Commands) ImportReservation(ctx *middleware.Context, reservation models.Reservation) (*models.Inventory, error) {
+	if reservation.OrderID.IsZero() || reservation.IngredientID.IsZero() {
+		return nil, errors.Invalidf("reservation order and ingredient are required")
+	}
+	if reservation.Amount == nil || reservation.Amount.Value() <= 0 {
+		return nil, errors.Invalidf("reservation of ingredient %s for order %s: amount must be positive", reservation.IngredientID.String(), reservation.OrderID.String())
+	}
+	if err := c.dao.ImportReservation(ctx, reservation); err != nil {
+		return nil, err
+	}
+	stock, err := c.dao.Get(ctx, reservation.IngredientID)
+	if err != nil {
+		return nil, err
+	}
+	ctx.TouchEntity(stock.EntityUID())
+	return stock, nil
+}
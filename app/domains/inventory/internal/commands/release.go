@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// ReleaseReservation frees stock held for an order that no longer needs it.
+// Order lifecycle handlers release reservations themselves, so this only
+// repairs reservations those handlers missed. No StockAdjusted event is raised
+// because the stock level itself is unchanged.
+func (c *Commands) ReleaseReservation(ctx *middleware.Context, reservation models.Reservation) (*models.Inventory, error) {
+	if reservation.OrderID.IsZero() || reservation.IngredientID.IsZero() {
+		return nil, errors.Invalidf("reservation order and ingredient are required")
+	}
+	deleted, err := c.dao.DeleteReservation(ctx, reservation.OrderID, reservation.IngredientID)
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return nil, errors.NotFoundf("order %s holds no reservation of ingredient %s", reservation.OrderID.String(), reservation.IngredientID.String())
+	}
+	stock, err := c.dao.Get(ctx, reservation.IngredientID)
+	if err != nil {
+		return nil, err
+	}
+	ctx.TouchEntity(stock.EntityUID())
+	return stock, nil
+}
@@ -49,6 +49,9 @@ func (c *Commands) Set(ctx *middleware.Context, update *models.Update) (*models.
 	} else {
 		updated = *existing
 	}
+	if update.Version != 0 {
+		updated.Version = update.Version
+	}
 	if updated.ID.IsZero() {
 		updated.ID = entity.NewInventoryID()
 	}
@@ -65,7 +68,7 @@ func (c *Commands) Set(ctx *middleware.Context, update *models.Update) (*models.
 	updated.CostPerUnit = optional.Some(update.CostPerUnit)
 	updated.LastUpdated = time.Now().UTC()
 
-	if err := c.dao.Upsert(ctx, updated); err != nil {
+	if err := c.dao.Upsert(ctx, &updated); err != nil {
 		return nil, err
 	}
 
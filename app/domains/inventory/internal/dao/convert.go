@@ -21,6 +21,7 @@ func toRow(s inventorymodels.Inventory) StockRow {
 		Unit:         string(s.Amount.Unit()),
 		CostPerUnit:  costPerUnit,
 		LastUpdated:  s.LastUpdated,
+		Version:      s.Version,
 	}
 }
 
@@ -37,5 +38,6 @@ func toModel(r StockRow) inventorymodels.Inventory {
 		Amount:       measurement.MustAmount(r.Quantity, measurement.Unit(r.Unit)),
 		CostPerUnit:  costPerUnit,
 		LastUpdated:  r.LastUpdated,
+		Version:      r.Version,
 	}
 }
@@ -0,0 +1,76 @@
+package dao
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+// Import stores stock exactly as another database held it, keeping its
+// inventory ID, cost, version, last update time and tags. Existing stock for
+// the ingredient conflicts.
+func (d *DAO) Import(ctx store.Context, stock models.Inventory) error {
+	return store.Write(ctx, func(tx *store.Tx) error {
+		row := toRow(stock)
+		row.Version = max(row.Version, 1)
+		if err := tx.Insert(&row); err != nil {
+			return store.MapError(err, "import stock for ingredient %s", stock.IngredientID.String())
+		}
+		if len(stock.Tags) == 0 {
+			return nil
+		}
+		_, err := d.tags.Replace(ctx, stock.EntityUID(), stock.Tags)
+		return err
+	})
+}
+
+// ImportReservation restores a reservation without the availability check
+// Reserve applies: the exporting database already accepted it.
+func (d *DAO) ImportReservation(ctx store.Context, reservation models.Reservation) error {
+	return store.Write(ctx, func(tx *store.Tx) error {
+		stock := StockRow{IngredientID: reservation.IngredientID.String()}
+		if err := tx.Get(&stock); err != nil {
+			return store.MapError(err, "stock for ingredient %s not found", reservation.IngredientID.String())
+		}
+		amount, err := reservation.Amount.Convert(measurement.Unit(stock.Unit))
+		if err != nil {
+			return err
+		}
+		row := ReservationRow{
+			ID:           reservationID(reservation.OrderID, reservation.IngredientID),
+			OrderID:      reservation.OrderID.String(),
+			IngredientID: reservation.IngredientID.String(),
+			Quantity:     amount.Value(),
+			Unit:         stock.Unit,
+		}
+		if err := tx.Insert(&row); err != nil {
+			return store.MapError(err, "import reservation of ingredient %s for order %s", reservation.IngredientID.String(), reservation.OrderID.String())
+		}
+		return nil
+	})
+}
+
+// ListReservations returns every reservation ordered by order, then ingredient.
+func (d *DAO) ListReservations(ctx store.Context) ([]models.Reservation, error) {
+	var result []models.Reservation
+	err := d.store.ReadContext(ctx, func(tx *store.Tx) error {
+		rows, err := store.QueryTx[ReservationRow](tx).SortAsc("ID").List()
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			orderID, err := entity.ParseOrderID(row.OrderID)
+			if err != nil {
+				return err
+			}
+			ingredientID, err := entity.ParseIngredientID(row.IngredientID)
+			if err != nil {
+				return err
+			}
+			result = append(result, models.Reservation{OrderID: orderID, IngredientID: ingredientID, Amount: measurement.MustAmount(row.Quantity, measurement.Unit(row.Unit))})
+		}
+		return nil
+	})
+	return result, store.MapError(err, "list reservations")
+}
@@ -13,6 +13,7 @@ type StockRow struct {
 	Unit         string
 	CostPerUnit  *money.Price
 	LastUpdated  time.Time `bstore:"index"`
+	Version      int64
 }
 
 // ReservationRow is owned by Inventory. OrderID is an external correlation
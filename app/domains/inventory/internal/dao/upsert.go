@@ -4,18 +4,35 @@ import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
-	"github.com/mjl-/bstore"
 )
 
-func (d *DAO) Upsert(ctx store.Context, stock models.Inventory) error {
-	return store.Write(ctx, func(tx *bstore.Tx) error {
-		row := toRow(stock)
-		if err := tx.Update(&row); err != nil {
-			if errors.Is(err, bstore.ErrAbsent) {
-				return store.MapError(tx.Insert(&row), "insert stock for ingredient %s", stock.IngredientID.String())
+// Upsert inserts stock at version 1 or replaces it while it is still at
+// stock.Version, then advances stock.Version to the version written.
+func (d *DAO) Upsert(ctx store.Context, stock *models.Inventory) error {
+	return store.Write(ctx, func(tx *store.Tx) error {
+		row := toRow(*stock)
+		current := StockRow{IngredientID: row.IngredientID}
+		switch err := tx.Get(&current); {
+		case errors.Is(err, store.ErrAbsent):
+			if err := store.CheckVersion(0, stock.Version, "stock for ingredient %s", stock.IngredientID.String()); err != nil {
+				return err
+			}
+			row.Version = 1
+			if err := tx.Insert(&row); err != nil {
+				return store.MapError(err, "insert stock for ingredient %s", stock.IngredientID.String())
+			}
+		case err != nil:
+			return store.MapError(err, "get stock for ingredient %s", stock.IngredientID.String())
+		default:
+			if err := store.CheckVersion(current.Version, stock.Version, "stock for ingredient %s", stock.IngredientID.String()); err != nil {
+				return err
+			}
+			row.Version++
+			if err := tx.Update(&row); err != nil {
+				return store.MapError(err, "update stock for ingredient %s", stock.IngredientID.String())
 			}
-			return store.MapError(err, "update stock for ingredient %s", stock.IngredientID.String())
 		}
+		stock.Version = row.Version
 		return nil
 	})
 }
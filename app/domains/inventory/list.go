@@ -24,8 +24,9 @@ type ListRequest struct {
 	Limit    int
 }
 
-// DefaultLowStockThreshold is the application-wide quantity boundary used by
-// stock status and dashboard summaries when a surface does not choose another.
+// DefaultLowStockThreshold is the built-in quantity boundary used by stock
+// status and dashboard summaries when neither the configuration nor a surface
+// chooses another.
 const DefaultLowStockThreshold = 10.0
 
 func (m *Module) List(ctx *middleware.Context, req ListRequest) (paging.Page[*models.Inventory], error) {
@@ -22,6 +22,8 @@ type Inventory struct {
 	CostPerUnit  optional.Value[money.Price]
 	LastUpdated  time.Time
 	Tags         tag.Tags
+	// Version is bumped on every stock write.
+	Version int64
 }
 
 func (s Inventory) Available() measurement.Amount {
@@ -0,0 +1,14 @@
+package models
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+)
+
+// Reservation is stock an open order has committed but not yet used. Amount
+// is in the stock's unit.
+type Reservation struct {
+	OrderID      entity.OrderID
+	IngredientID entity.IngredientID
+	Amount       measurement.Amount
+}
@@ -12,6 +12,9 @@ type Update struct {
 	IngredientID entity.IngredientID
 	Amount       measurement.Amount
 	CostPerUnit  money.Price
+	// Version, when non-zero, is the stock version the update was prepared
+	// from; the set fails with a Conflict if the stock has changed since.
+	Version int64
 }
 
 func (u Update) EntityUID() cedar.EntityUID {
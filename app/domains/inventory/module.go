@@ -10,7 +10,9 @@ import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/tagging"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/migrate"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
 )
 
 type Module struct {
@@ -18,6 +20,7 @@ type Module struct {
 	ingredients *ingredientsqueries.Queries
 	commands    *commands.Commands
 	pipeline    *middleware.Pipeline
+	gauges      stockGauges
 }
 
 func NewModule(ctx context.Context, s *store.Store, tags tag.Repository, targets *tagging.Registry, pipeline *middleware.Pipeline) *Module {
@@ -27,7 +30,13 @@ func NewModule(ctx context.Context, s *store.Store, tags tag.Repository, targets
 		ingredients: ingredientsqueries.New(s, tags),
 		commands:    commands.New(s, tags),
 		pipeline:    pipeline,
+		gauges:      newStockGauges(telemetry.FromContext(ctx)),
 	}
 	m.registerTagTarget(targets)
 	return m
 }
+
+// Migrations returns the inventory domain's data migrations for app.New to apply.
+func Migrations() migrate.Domain {
+	return migrate.Domain{Name: "inventory", Migrations: dao.Migrations()}
+}
@@ -0,0 +1,10 @@
+package queries
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+func (q *Queries) ListReservations(ctx store.Context) ([]models.Reservation, error) {
+	return q.dao.ListReservations(ctx)
+}
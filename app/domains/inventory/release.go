@@ -0,0 +1,22 @@
+package inventory
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/inventory/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// ReleaseReservation deletes a reservation whose order is closed or gone,
+// returning the stock with its reduced reserved total.
+func (m *Module) ReleaseReservation(ctx *middleware.Context, reservation models.Reservation) (*models.Inventory, error) {
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Inventory, *models.Inventory]{
+		Action:  authz.ActionRelease,
+		Request: reservation,
+		Load: func(c *middleware.Context) (*models.Inventory, error) {
+			return m.queries.Get(c, reservation.IngredientID)
+		},
+		Handle: func(c *middleware.Context, _ *models.Inventory) (*models.Inventory, error) {
+			return m.commands.ReleaseReservation(c, reservation)
+		},
+	})
+}
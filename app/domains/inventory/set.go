@@ -8,10 +8,14 @@ import (
 
 func (m *Module) Set(ctx *middleware.Context, update *models.Update) (*models.Inventory, error) {
 	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Update, *models.Inventory]{
-		Action: authz.ActionSet,
+		Action:  authz.ActionSet,
+		Request: update,
 		Load: func(*middleware.Context) (*models.Update, error) {
 			return update, nil
 		},
+		Before: func(ctx *middleware.Context, in *models.Update) (*models.Inventory, error) {
+			return m.queries.Get(ctx, in.IngredientID)
+		},
 		Handle: m.commands.Set,
 	})
 }
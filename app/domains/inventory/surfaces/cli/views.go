@@ -25,6 +25,7 @@ type InventoryRow struct {
 	CostPerUnit  string               `table:"COST_PER_UNIT" json:"cost_per_unit,omitempty"`
 	LastUpdated  string               `table:"LAST_UPDATED" json:"last_updated"`
 	Tags         tag.CanonicalStrings `table:"TAGS" json:"tags"`
+	Version      int64                `table:"-" json:"version,omitempty"`
 }
 
 type InventoryInput struct {
@@ -32,6 +33,7 @@ type InventoryInput struct {
 	Quantity     *float64 `json:"quantity"`
 	Unit         string   `json:"unit,omitempty"`
 	CostPerUnit  string   `json:"cost_per_unit,omitempty"`
+	Version      int64    `json:"version,omitempty"`
 }
 
 type InventoryPatch struct {
@@ -59,6 +61,7 @@ func ToInventoryRow(s *models.Inventory) InventoryRow {
 		CostPerUnit:  costPerUnit,
 		LastUpdated:  formatTime(s.LastUpdated),
 		Tags:         s.Tags.Canonical(),
+		Version:      s.Version,
 	}
 }
 
@@ -24,11 +24,10 @@ import (
 	"github.com/TheFellow/go-modular-monolith/pkg/optional"
 	"github.com/TheFellow/go-modular-monolith/pkg/paging"
 	"github.com/TheFellow/go-modular-monolith/pkg/presentation/actions"
+	"github.com/TheFellow/go-modular-monolith/pkg/presentation/preview"
 	toolkit "github.com/TheFellow/go-modular-monolith/pkg/toolkits/gui"
 )
 
-const LowStockThreshold = inventory.DefaultLowStockThreshold
-
 type Mode uint8
 
 const (
@@ -97,6 +96,7 @@ type Presenter struct {
 	dialogs   toolkit.Dialogs
 	load      *toolkit.LatestRequest[loadResult]
 	submit    *toolkit.Submission
+	preview   *toolkit.Submission
 	mu        sync.Mutex
 	state     State
 	changed   func(State)
@@ -105,12 +105,13 @@ type Presenter struct {
 
 func NewPresenter(session *app.Session, executor toolkit.Executor, dispatcher toolkit.Dispatcher, dialogs ...toolkit.Dialogs) *Presenter {
 	projector := inventory.NewActionProjector()
-	p := &Presenter{app: session, state: State{Limit: toolkit.PageLimit, LowStock: LowStockThreshold}, projector: projector}
+	p := &Presenter{app: session, state: State{Limit: toolkit.PageLimit, LowStock: session.Defaults().LowStockThreshold}, projector: projector}
 	if len(dialogs) > 0 {
 		p.dialogs = dialogs[0]
 	}
 	p.load = toolkit.NewLatestRequest[loadResult](executor, dispatcher)
 	p.submit = toolkit.NewSubmission(executor, dispatcher)
+	p.preview = toolkit.NewSubmission(executor, dispatcher)
 	if err := p.permissionsLocked(); err != nil {
 		p.state.Err = toolkit.PresentError(err)
 		toolkit.ShowPresentation(p.dialogs, err)
@@ -276,7 +277,7 @@ func (p *Presenter) leaveDetail(reset bool) {
 	proceed := func() {
 		p.mu.Lock()
 		if reset {
-			p.state.Expression, p.state.Stock, p.state.LowStock, p.state.Limit = "", AllStock, LowStockThreshold, toolkit.PageLimit
+			p.state.Expression, p.state.Stock, p.state.LowStock, p.state.Limit = "", AllStock, p.app.Defaults().LowStockThreshold, toolkit.PageLimit
 			p.state.Cursor, p.state.Next, p.state.History = "", "", nil
 		}
 		p.state.Mode, p.state.Dirty, p.state.Err = Browse, false, nil
@@ -382,13 +383,17 @@ func (p *Presenter) SetForm(form Form) {
 }
 
 func (p *Presenter) formForModeLocked(mode Mode) Form {
-	if p.state.Selected == nil {
+	return formFor(p.state.Selected, mode)
+}
+
+func formFor(row *Row, mode Mode) Form {
+	if row == nil {
 		return Form{}
 	}
-	f := Form{Tags: p.state.Selected.Inventory.Tags.Canonical().String(), ReplaceTags: mode != Tags}
+	f := Form{Tags: row.Inventory.Tags.Canonical().String(), ReplaceTags: mode != Tags}
 	if mode == Set {
-		f.Amount = fmt.Sprintf("%.2f", p.state.Selected.Inventory.Amount.Value())
-		if price, ok := p.state.Selected.Inventory.CostPerUnit.Unwrap(); ok {
+		f.Amount = fmt.Sprintf("%.2f", row.Inventory.Amount.Value())
+		if price, ok := row.Inventory.CostPerUnit.Unwrap(); ok {
 			cents, _ := price.Cents()
 			f.Cost = fmt.Sprintf("%.2f", float64(cents)/100)
 		}
@@ -406,7 +411,7 @@ func (p *Presenter) Submit(form Form) bool {
 		p.mu.Unlock()
 		return false
 	}
-	validated, err := validate(mode, form, selected.Ingredient.Unit, selected.Inventory.CostPerUnit)
+	validated, err := validate(mode, form, selected.Ingredient.Unit, selected.Inventory.CostPerUnit, p.app.Defaults().Currency)
 	if err != nil {
 		p.state.Err = toolkit.PresentError(err)
 		p.publishLocked()
@@ -418,27 +423,7 @@ func (p *Presenter) Submit(form Form) bool {
 	p.publishLocked()
 	p.mu.Unlock()
 	accepted := p.submit.Submit(func() error {
-		var desired *tag.Tags
-		if form.ReplaceTags {
-			desired = &validated.tags
-		}
-		switch mode {
-		case Adjust:
-			_, err = app.RunTaggedMutation(p.app.App, p.app.Context(), desired, func(ctx *middleware.Context) (*inventorymodels.Inventory, error) {
-				return p.app.Inventory.Adjust(ctx, &inventorymodels.Patch{IngredientID: selected.Ingredient.ID, Reason: form.Reason, Delta: validated.amount, CostPerUnit: validated.cost})
-			})
-		case Set:
-			amount, _ := validated.amount.Unwrap()
-			cost, _ := validated.cost.Unwrap()
-			_, err = app.RunTaggedMutation(p.app.App, p.app.Context(), desired, func(ctx *middleware.Context) (*inventorymodels.Inventory, error) {
-				return p.app.Inventory.Set(ctx, &inventorymodels.Update{IngredientID: selected.Ingredient.ID, Amount: amount, CostPerUnit: cost})
-			})
-		case Tags:
-			_, err = p.app.Tags.Replace(p.app.Context(), selected.Inventory.EntityUID(), validated.tags)
-		case Browse, Viewing:
-			err = errors.Invalidf("inventory form is not active")
-		}
-		return err
+		return p.apply(p.app, mode, selected, form, validated)
 	}, func(err error) {
 		p.mu.Lock()
 		p.state.Submitting = false
@@ -448,6 +433,9 @@ func (p *Presenter) Submit(form Form) bool {
 		}
 		p.publishLocked()
 		p.mu.Unlock()
+		if errors.IsConflict(err) && mode == Set && p.offerMerge(selected, form) {
+			return
+		}
 		toolkit.ShowPresentation(p.dialogs, err)
 		if err == nil {
 			p.Load()
@@ -462,13 +450,111 @@ func (p *Presenter) Submit(form Form) bool {
 	return accepted
 }
 
+// offerMerge asks whether to reload stock that changed while it was being set,
+// for example by an order. Accepting rebases the unsaved form onto the latest
+// stock. It reports false when the latest stock cannot be loaded.
+func (p *Presenter) offerMerge(base *Row, edited Form) bool {
+	latest, err := p.app.Inventory.Get(p.app.Context(), base.Ingredient.ID)
+	if err != nil || p.dialogs == nil {
+		return false
+	}
+	message := fmt.Sprintf("Stock for %q changed while you were editing.\n\nReload the latest stock and keep your edits?", base.Ingredient.Name)
+	p.dialogs.Confirm("Stock Changed", message, func(ok bool) {
+		if !ok {
+			return
+		}
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.state.Selected == nil || p.state.Selected.Ingredient.ID != base.Ingredient.ID {
+			return
+		}
+		reloaded := *p.state.Selected
+		reloaded.Inventory = *latest
+		p.state.Selected = &reloaded
+		p.state.Form = toolkit.MergeForm(formFor(base, Set), edited, formFor(&reloaded, Set))
+		p.state.Dirty = !reflect.DeepEqual(p.state.Form, formFor(&reloaded, Set))
+		p.state.FormInstance++
+		p.state.Err = nil
+		p.publishLocked()
+	})
+	return true
+}
+
+// Preview applies the stock form in a dry run and shows what it would change.
+// Nothing is saved; the form stays open for editing or submission.
+func (p *Presenter) Preview(form Form) bool {
+	p.mu.Lock()
+	mode, selected := p.state.Mode, p.state.Selected
+	p.state.Form = form
+	if selected == nil || (mode != Adjust && mode != Set) {
+		p.mu.Unlock()
+		return false
+	}
+	validated, err := validate(mode, form, selected.Ingredient.Unit, selected.Inventory.CostPerUnit, p.app.Defaults().Currency)
+	if err != nil {
+		p.state.Err = toolkit.PresentError(err)
+		p.publishLocked()
+		p.mu.Unlock()
+		return false
+	}
+	p.state.Err = nil
+	p.publishLocked()
+	p.mu.Unlock()
+	var lines []string
+	return p.preview.Submit(func() error {
+		dry, pv := p.app.DryRun()
+		if err := p.apply(dry, mode, selected, form, validated); err != nil {
+			return err
+		}
+		lines = preview.Lines(pv)
+		return nil
+	}, func(err error) {
+		if err != nil {
+			p.mu.Lock()
+			p.state.Err = toolkit.PresentError(err)
+			p.publishLocked()
+			p.mu.Unlock()
+			toolkit.ShowPresentation(p.dialogs, err)
+			return
+		}
+		if p.dialogs != nil {
+			p.dialogs.ShowWarning("Stock Preview (not saved)", strings.Join(lines, "\n"))
+		}
+	})
+}
+
+func (p *Presenter) apply(session *app.Session, mode Mode, selected *Row, form Form, validated validatedForm) error {
+	var desired *tag.Tags
+	if form.ReplaceTags {
+		desired = &validated.tags
+	}
+	var err error
+	switch mode {
+	case Adjust:
+		_, err = app.RunTaggedMutation(session.App, session.Context(), desired, func(ctx *middleware.Context) (*inventorymodels.Inventory, error) {
+			return session.Inventory.Adjust(ctx, &inventorymodels.Patch{IngredientID: selected.Ingredient.ID, Reason: form.Reason, Delta: validated.amount, CostPerUnit: validated.cost})
+		})
+	case Set:
+		amount, _ := validated.amount.Unwrap()
+		cost, _ := validated.cost.Unwrap()
+		_, err = app.RunTaggedMutation(session.App, session.Context(), desired, func(ctx *middleware.Context) (*inventorymodels.Inventory, error) {
+			return session.Inventory.Set(ctx, &inventorymodels.Update{IngredientID: selected.Ingredient.ID, Amount: amount, CostPerUnit: cost, Version: selected.Inventory.Version})
+		})
+	case Tags:
+		_, err = session.Tags.Replace(session.Context(), selected.Inventory.EntityUID(), validated.tags)
+	case Browse, Viewing:
+		err = errors.Invalidf("inventory form is not active")
+	}
+	return err
+}
+
 type validatedForm struct {
 	amount optional.Value[measurement.Amount]
 	cost   optional.Value[money.Price]
 	tags   tag.Tags
 }
 
-func validate(mode Mode, form Form, unit measurement.Unit, existingCost optional.Value[money.Price]) (validatedForm, error) {
+func validate(mode Mode, form Form, unit measurement.Unit, existingCost optional.Value[money.Price], curr currency.Currency) (validatedForm, error) {
 	var out validatedForm
 	if mode == Tags || form.ReplaceTags {
 		tags, err := tag.ParseCollection(form.Tags)
@@ -507,7 +593,7 @@ func validate(mode Mode, form Form, unit measurement.Unit, existingCost optional
 		cost = "0.00"
 	}
 	if cost != "" {
-		price, err := parseInventoryPrice(cost, existingCost)
+		price, err := parseInventoryPrice(cost, existingCost, curr)
 		if err != nil {
 			return out, err
 		}
@@ -519,14 +605,13 @@ func validate(mode Mode, form Form, unit measurement.Unit, existingCost optional
 	return out, nil
 }
 
-func parseInventoryPrice(raw string, existing optional.Value[money.Price]) (money.Price, error) {
+func parseInventoryPrice(raw string, existing optional.Value[money.Price], curr currency.Currency) (money.Price, error) {
 	if strings.HasPrefix(strings.TrimSpace(raw), "$") || len(strings.Fields(raw)) == 2 {
 		return money.ParsePrice(raw)
 	}
 	if _, err := parsePrecision2(raw, "cost"); err != nil {
 		return money.Price{}, err
 	}
-	curr := currency.USD
 	if price, ok := existing.Unwrap(); ok {
 		curr = price.Currency
 	}
@@ -3,6 +3,7 @@ package gui
 
 import (
 	"context"
+	"fmt"
 	"slices"
 	"strings"
 	"testing"
@@ -103,7 +104,7 @@ func TestValidateSetOptionalCostContract(t *testing.T) {
 		{"explicit EUR changes currency", "EUR 2.50", "2.50 €", existing},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			validated, err := validate(Set, Form{Amount: "1", Cost: tc.raw}, measurement.UnitOz, tc.existing)
+			validated, err := validate(Set, Form{Amount: "1", Cost: tc.raw}, measurement.UnitOz, tc.existing, currency.USD)
 			testutil.Ok(t, err)
 			price, ok := validated.cost.Unwrap()
 			testutil.ErrorIf(t, !ok, "%v", "cost missing")
@@ -176,6 +177,23 @@ func TestPresenterAdjustSetAndTagClearPersist(t *testing.T) {
 	testutil.ErrorIf(t, stock.Tags.Canonical().String() != "", "tags not cleared: %q", stock.Tags.Canonical().String())
 }
 
+func TestPresenterPreviewReportsAdjustmentWithoutSaving(t *testing.T) {
+	fix, ingredient := inventoryFixture(t)
+	dialogs := &fynetest.Dialogs{}
+	p := NewPresenter(fix.App, toolkit.InlineExecutor{}, toolkit.InlineDispatcher{}, dialogs)
+	p.Load()
+	p.StartAdjust()
+	testutil.ErrorIf(t, !p.Preview(Form{Amount: "-2.25", Reason: inventorymodels.ReasonUsed}), "%v", "preview rejected")
+	warnings := dialogs.Warnings()
+	testutil.ErrorIf(t, len(warnings) != 1, "preview warnings = %#v", warnings)
+	testutil.StringContains(t, warnings[0].Message, `Action::"adjust"`)
+	testutil.StringContains(t, warnings[0].Message, "10.25 oz")
+	testutil.Equals(t, p.Snapshot().Mode, Adjust)
+	stock, err := fix.Inventory.Get(fix.OwnerContext(), ingredient.ID)
+	testutil.Ok(t, err)
+	testutil.ErrorIf(t, stock.Amount.Value() != 12.5, "preview saved stock=%v", stock.Amount.Value())
+}
+
 func TestPresenterPermissionFailureRetainsFormWithoutMutation(t *testing.T) {
 	fix, ingredient := inventoryFixture(t)
 	denied := application.NewSession(fix.ActorContext("bartender"), fix.App.App)
@@ -412,6 +430,33 @@ func TestPresenterSetBlankCostPreservesExistingPrice(t *testing.T) {
 	testutil.ErrorIf(t, cents != 325, "cost=%d cents", cents)
 }
 
+func TestStaleSetOffersReloadAndKeepsLocalEdits(t *testing.T) {
+	fix, ingredient := inventoryFixture(t)
+	dialogs := &fynetest.Dialogs{}
+	p := NewPresenter(fix.App, toolkit.InlineExecutor{}, toolkit.InlineDispatcher{}, dialogs)
+	p.Load()
+	p.StartSet()
+	_, err := fix.Inventory.Adjust(fix.OwnerContext(), &inventorymodels.Patch{IngredientID: ingredient.ID, Reason: inventorymodels.ReasonUsed, Delta: optional.Some(measurement.MustAmount(-1, measurement.UnitOz))})
+	testutil.Ok(t, err)
+
+	form := p.Snapshot().Form
+	form.Cost = "4.00"
+	testutil.ErrorIf(t, !p.Submit(form), "%v", "set rejected")
+	testutil.Equals(t, len(dialogs.Confirmations()), 1)
+	dialogs.Confirmations()[0].Respond(true)
+	state := p.Snapshot()
+	testutil.Equals(t, state.Form.Amount, "11.50")
+	testutil.Equals(t, state.Form.Cost, "4.00")
+
+	testutil.ErrorIf(t, !p.Submit(state.Form), "%v", "merged set rejected")
+	stock, err := fix.Inventory.Get(fix.OwnerContext(), ingredient.ID)
+	testutil.Ok(t, err)
+	price, _ := stock.CostPerUnit.Unwrap()
+	cents, _ := price.Cents()
+	testutil.Equals(t, fmt.Sprintf("%.2f", stock.Amount.Value()), "11.50")
+	testutil.Equals(t, cents, 400)
+}
+
 func TestPresenterAdjustsCostWithoutQuantityMutation(t *testing.T) {
 	fix, ingredient := inventoryFixture(t)
 	p := NewPresenter(fix.App, toolkit.InlineExecutor{}, toolkit.InlineDispatcher{})
@@ -428,7 +473,7 @@ func TestPresenterAdjustsCostWithoutQuantityMutation(t *testing.T) {
 }
 
 func TestValidateAdjustAcceptsCurrencyBearingPrice(t *testing.T) {
-	validated, err := validate(Adjust, Form{Cost: "EUR 4.10", Reason: inventorymodels.ReasonCorrected}, measurement.UnitOz, optional.None[money.Price]())
+	validated, err := validate(Adjust, Form{Cost: "EUR 4.10", Reason: inventorymodels.ReasonCorrected}, measurement.UnitOz, optional.None[money.Price](), currency.USD)
 	testutil.Ok(t, err)
 	price, ok := validated.cost.Unwrap()
 	testutil.ErrorIf(t, !ok || price.Currency != currency.EUR || price.String() != "4.10 €", "currency-bearing cost = %#v", validated.cost)
@@ -32,6 +32,7 @@ const (
 	ControlFormTags     = "inventory-form-tags"
 	ControlSave         = "inventory-form-save"
 	ControlCancel       = "inventory-form-cancel"
+	ControlPreview      = "inventory-form-preview"
 	ControlBack         = "inventory-detail-back"
 	ControlBreadcrumb   = "inventory-detail-breadcrumb"
 )
@@ -44,7 +45,7 @@ type View struct {
 	expression, amount, cost         *ui.SemanticEntry
 	tags                             *ui.TagTokenEditor
 	reason                           *widget.Select
-	save, cancel, refresh            *ui.SemanticButton
+	save, cancel, refresh, preview   *ui.SemanticButton
 	adjust, set, tagAction           *ui.SemanticButton
 	status, formStatus, title, crumb *widget.Label
 	state                            State
@@ -120,6 +121,7 @@ func NewView(p *Presenter) *View {
 	v.reason = widget.NewSelect([]string{string(inventorymodels.ReasonReceived), string(inventorymodels.ReasonUsed), string(inventorymodels.ReasonSpilled), string(inventorymodels.ReasonExpired), string(inventorymodels.ReasonCorrected)}, nil)
 	v.save = ui.WithIcon(ui.NewButton(ControlSave, "Save", func() { v.readForm(); p.Submit(p.Snapshot().Form) }), ui.IconSave)
 	v.cancel = ui.WithIcon(ui.NewButton(ControlCancel, "Cancel", p.Cancel), ui.IconCancel)
+	v.preview = ui.NewButton(ControlPreview, "Preview", func() { v.readForm(); p.Preview(p.Snapshot().Form) })
 	v.mutation = ui.StandardFormPage(ui.FormPage{Title: "Inventory item", Breadcrumb: v.breadcrumb(""), Fields: v.mutationFields(Adjust), Status: v.formStatus, Save: v.save, Cancel: v.cancel}).(*framework.Container)
 	v.root = container.NewStack(v.browse)
 	v.amount.OnChanged = func(string) { v.changed() }
@@ -159,9 +161,9 @@ func (v *View) mutationFields(mode Mode) framework.CanvasObject {
 	case Browse, Viewing:
 		return container.NewVBox()
 	case Adjust:
-		return ui.DetailForm(ui.DetailField("Signed amount", v.amount), ui.DetailField("Cost per unit", v.cost), ui.DetailField("Reason", v.reason), ui.DetailField("Tags", v.tags.Content))
+		return ui.DetailForm(ui.DetailField("Signed amount", v.amount), ui.DetailField("Cost per unit", v.cost), ui.DetailField("Reason", v.reason), ui.DetailField("Tags", v.tags.Content), ui.DetailField("", v.preview))
 	case Set:
-		return ui.DetailForm(ui.DetailField("Quantity", v.amount), ui.DetailField("Cost per unit", v.cost), ui.DetailField("Tags", v.tags.Content))
+		return ui.DetailForm(ui.DetailField("Quantity", v.amount), ui.DetailField("Cost per unit", v.cost), ui.DetailField("Tags", v.tags.Content), ui.DetailField("", v.preview))
 	case Tags:
 		return ui.DetailForm(ui.DetailField("Tags", v.tags.Content))
 	}
@@ -263,6 +265,11 @@ func (v *View) render(s State) {
 	v.set.Hidden = s.Selected == nil || !actionVisible(s.Actions, inventory.ControlSet)
 	v.tagAction.Hidden = s.Selected == nil || !actionVisible(s.Actions, inventory.ControlTags)
 	v.refresh.Hidden = !actionVisible(s.Actions, inventory.ControlList)
+	if s.Submitting {
+		v.preview.Disable()
+	} else {
+		v.preview.Enable()
+	}
 	if s.Submitting || !s.Dirty {
 		v.save.Disable()
 		v.cancel.Disable()
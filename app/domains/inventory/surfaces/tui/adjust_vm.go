@@ -13,6 +13,7 @@ import (
 	"github.com/TheFellow/go-modular-monolith/app/kernel/money"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
 	"github.com/TheFellow/go-modular-monolith/pkg/optional"
+	"github.com/TheFellow/go-modular-monolith/pkg/presentation/preview"
 	"github.com/TheFellow/go-modular-monolith/pkg/toolkits/tui/components"
 	"github.com/TheFellow/go-modular-monolith/pkg/toolkits/tui/forms"
 	"github.com/TheFellow/go-modular-monolith/pkg/toolkits/tui/keys"
@@ -30,6 +31,7 @@ type AdjustInventoryVM struct {
 	keys       forms.FormKeys
 	err        error
 	submitting bool
+	preview    []string
 	amount     *forms.NumberField
 	cost       *forms.TextField
 	reason     *forms.SelectField
@@ -41,6 +43,12 @@ type AdjustErrorMsg struct {
 	Err error
 }
 
+// AdjustPreviewMsg carries what an adjustment would change, from a dry run.
+type AdjustPreviewMsg struct {
+	Lines []string
+	Err   error
+}
+
 // NewAdjustInventoryVM builds an AdjustInventoryVM with fields configured.
 func NewAdjustInventoryVM(app *app.Session, row InventoryRow) *AdjustInventoryVM {
 	reasonOptions := []forms.SelectOption{
@@ -105,10 +113,17 @@ func (m *AdjustInventoryVM) Update(msg tea.Msg) (*AdjustInventoryVM, tea.Cmd) {
 		m.submitting = false
 		m.err = nil
 		return m, nil
+	case AdjustPreviewMsg:
+		m.submitting = false
+		m.err, m.preview = typed.Err, typed.Lines
+		return m, nil
 	case tea.KeyMsg:
 		if key.Matches(typed, m.keys.Submit) {
 			return m, m.submit()
 		}
+		if key.Matches(typed, m.keys.Preview) {
+			return m, m.previewAdjustment()
+		}
 	}
 
 	var cmd tea.Cmd
@@ -132,6 +147,9 @@ func (m *AdjustInventoryVM) View() string {
 	}
 
 	view := strings.Join([]string{title, current, "", m.form.View()}, "\n")
+	if len(m.preview) > 0 {
+		view = strings.Join(append([]string{view, "", "Preview (not saved):"}, m.preview...), "\n")
+	}
 	if m.err != nil {
 		errText := m.styles.Error.Render("Error: " + m.err.Error())
 		return strings.Join([]string{errText, "", view}, "\n")
@@ -153,6 +171,47 @@ func (m *AdjustInventoryVM) submit() tea.Cmd {
 	if m.submitting {
 		return nil
 	}
+	run := m.adjustment()
+	if run == nil {
+		return nil
+	}
+	m.err = nil
+	m.submitting = true
+
+	return func() tea.Msg {
+		adjusted, err := run(m.app)
+		if err != nil {
+			return AdjustErrorMsg{Err: err}
+		}
+		return InventoryAdjustedMsg{Inventory: adjusted}
+	}
+}
+
+// previewAdjustment dry-runs the adjustment so its effects can be reviewed
+// before submitting.
+func (m *AdjustInventoryVM) previewAdjustment() tea.Cmd {
+	if m.submitting {
+		return nil
+	}
+	run := m.adjustment()
+	if run == nil {
+		return nil
+	}
+	m.err = nil
+	m.submitting = true
+
+	return func() tea.Msg {
+		dry, p := m.app.DryRun()
+		if _, err := run(dry); err != nil {
+			return AdjustPreviewMsg{Err: err}
+		}
+		return AdjustPreviewMsg{Lines: preview.Lines(p)}
+	}
+}
+
+// adjustment validates the form and returns the adjustment to run in a
+// session, or nil after recording why it cannot run.
+func (m *AdjustInventoryVM) adjustment() func(*app.Session) (*models.Inventory, error) {
 	if err := m.form.Validate(); err != nil {
 		m.err = err
 		return nil
@@ -199,24 +258,13 @@ func (m *AdjustInventoryVM) submit() tea.Cmd {
 		Delta:        delta,
 		CostPerUnit:  cost,
 	}
-	m.err = nil
-	m.submitting = true
-
-	return func() tea.Msg {
-		adjusted, err := app.RunTaggedMutation(m.app.App, m.context(), desired, func(ctx *middleware.Context) (*models.Inventory, error) {
-			return m.app.Inventory.Adjust(ctx, patch)
+	return func(session *app.Session) (*models.Inventory, error) {
+		return app.RunTaggedMutation(session.App, session.Context(), desired, func(ctx *middleware.Context) (*models.Inventory, error) {
+			return session.Inventory.Adjust(ctx, patch)
 		})
-		if err != nil {
-			return AdjustErrorMsg{Err: err}
-		}
-		return InventoryAdjustedMsg{Inventory: adjusted}
 	}
 }
 
-func (m *AdjustInventoryVM) context() *middleware.Context {
-	return m.app.Context()
-}
-
 func toAdjustmentReason(value any) models.AdjustmentReason {
 	switch typed := value.(type) {
 	case models.AdjustmentReason:
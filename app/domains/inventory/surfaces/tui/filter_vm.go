@@ -26,8 +26,10 @@ type filterVM struct {
 
 func filterSubmit(msg tea.KeyMsg) bool { return key.Matches(msg, keys.Standard.Form.Submit) }
 
-func newFilterVM(req inventory.ListRequest) *filterVM {
-	threshold := inventory.DefaultLowStockThreshold
+// newFilterVM starts from req, offering defaultThreshold for a low-stock
+// filter when req has none.
+func newFilterVM(req inventory.ListRequest, defaultThreshold float64) *filterVM {
+	threshold := defaultThreshold
 	stock := "all"
 	if value, ok := req.LowStock.Unwrap(); ok {
 		threshold = value
@@ -123,6 +123,13 @@ func (m *ListViewModel) Interaction() tui.Interaction {
 
 func (m *ListViewModel) Update(msg tea.Msg) (tui.ViewModel, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tui.RefreshMsg:
+		if m.mode != listModeBrowsing || !m.actionEnabled(inventory.ControlList) {
+			return m, nil
+		}
+		m.loading = true
+		m.err = nil
+		return m, tea.Batch(m.spinner.Init(), m.loadInventory())
 	case tea.WindowSizeMsg:
 		m.setSize(msg.Width, msg.Height)
 		switch m.mode {
@@ -208,7 +215,7 @@ func (m *ListViewModel) Update(msg tea.Msg) (tui.ViewModel, tea.Cmd) {
 			if !m.actionEnabled(inventory.ControlList) {
 				return m, nil
 			}
-			m.mode, m.filter = listModeFiltering, newFilterVM(m.request)
+			m.mode, m.filter = listModeFiltering, newFilterVM(m.request, m.app.Defaults().LowStockThreshold)
 			m.filter.form.SetWidth(m.detailWidth)
 			return m, m.filter.Init()
 		case msg.String() == "]" && m.next != "" && m.actionEnabled(inventory.ControlList):
@@ -323,7 +330,9 @@ func (m *ListViewModel) ShortHelp() []key.Binding {
 	switch m.mode {
 	case listModeTagging:
 		return []key.Binding{m.formKeys.Submit, m.keys.Back}
-	case listModeAdjusting, listModeSetting:
+	case listModeAdjusting:
+		return []key.Binding{m.keys.Up, m.keys.Down, m.keys.Edit, m.keys.Enter, m.formKeys.Submit, m.formKeys.Preview, m.keys.Back}
+	case listModeSetting:
 		return []key.Binding{m.keys.Up, m.keys.Down, m.keys.Edit, m.keys.Enter, m.formKeys.Submit, m.keys.Back}
 	case listModeBrowsing:
 		base := []key.Binding{m.keys.Back}
@@ -340,7 +349,12 @@ func (m *ListViewModel) FullHelp() [][]key.Binding {
 	switch m.mode {
 	case listModeTagging:
 		return [][]key.Binding{{m.formKeys.Submit, m.keys.Back}}
-	case listModeAdjusting, listModeSetting:
+	case listModeAdjusting:
+		return [][]key.Binding{
+			{m.keys.Up, m.keys.Down, m.keys.Edit, m.keys.Enter, m.formKeys.Submit, m.formKeys.Preview},
+			{m.keys.Back},
+		}
+	case listModeSetting:
 		return [][]key.Binding{
 			{m.keys.Up, m.keys.Down, m.keys.Edit, m.keys.Enter, m.formKeys.Submit},
 			{m.keys.Back},
@@ -364,6 +378,7 @@ func (m *ListViewModel) loadInventory() tea.Cmd {
 	m.loadToken++
 	token := m.loadToken
 	req := m.request
+	defaultThreshold := m.app.Defaults().LowStockThreshold
 	return func() tea.Msg {
 		inventoryList, err := m.app.Inventory.List(m.context(), req)
 		if err != nil {
@@ -398,7 +413,7 @@ func (m *ListViewModel) loadInventory() tea.Cmd {
 			if price, ok := item.CostPerUnit.Unwrap(); ok {
 				cost = price.String()
 			}
-			threshold := inventory.DefaultLowStockThreshold
+			threshold := defaultThreshold
 			if value, ok := req.LowStock.Unwrap(); ok {
 				threshold = value
 			}
@@ -120,7 +120,7 @@ func TestAdjustInventoryPermissionFailureDoesNotWrite(t *testing.T) {
 }
 
 func TestInventoryFilterRequestPreservesAllStockAndConfiguresLowStock(t *testing.T) {
-	all := newFilterVM(inventory.ListRequest{Filter: `unit == "oz"`, Limit: 25})
+	all := newFilterVM(inventory.ListRequest{Filter: `unit == "oz"`, Limit: 25}, inventory.DefaultLowStockThreshold)
 	req, err := all.Request()
 	testutil.ErrorIf(t, err != nil, "%v", err)
 	testutil.ErrorIf(t, req.Filter != `unit == "oz"` || req.Limit != 25 || req.LowStock.IsSome(), "all request = %#v", req)
@@ -37,6 +37,13 @@ type SetErrorMsg struct {
 	Err error
 }
 
+// SetConflictMsg is sent when the stock changed after the form was opened.
+// Latest is the stored stock to merge the form onto.
+type SetConflictMsg struct {
+	Err    error
+	Latest *models.Inventory
+}
+
 // NewSetInventoryVM builds a SetInventoryVM with fields configured.
 func NewSetInventoryVM(app *app.Session, row InventoryRow) *SetInventoryVM {
 	quantityField := forms.NewNumberField(
@@ -45,9 +52,7 @@ func NewSetInventoryVM(app *app.Session, row InventoryRow) *SetInventoryVM {
 		forms.WithPrecision(2),
 		forms.WithMin(0),
 	)
-	if row.Inventory.Amount != nil {
-		_ = quantityField.SetValue(row.Inventory.Amount.Value())
-	}
+	_ = quantityField.SetValue(stockQuantity(row.Inventory))
 
 	costField := forms.NewTextField("Cost Per Unit", forms.WithPlaceholder("Optional, e.g. $1.23 or EUR 1.23"))
 	tagsField := components.NewOptionalTagsField(row.Inventory.Tags.Canonical().String())
@@ -86,6 +91,10 @@ func (m *SetInventoryVM) Update(msg tea.Msg) (*SetInventoryVM, tea.Cmd) {
 		m.submitting = false
 		m.err = typed.Err
 		return m, nil
+	case SetConflictMsg:
+		m.submitting = false
+		m.err = m.rebase(typed.Latest)
+		return m, nil
 	case InventorySetMsg:
 		m.submitting = false
 		m.err = nil
@@ -170,6 +179,7 @@ func (m *SetInventoryVM) submit() tea.Cmd {
 		IngredientID: m.row.Ingredient.ID,
 		Amount:       amount,
 		CostPerUnit:  cost,
+		Version:      m.row.Inventory.Version,
 	}
 	m.err = nil
 	m.submitting = true
@@ -178,6 +188,11 @@ func (m *SetInventoryVM) submit() tea.Cmd {
 		updated, err := app.RunTaggedMutation(m.app.App, m.context(), desired, func(ctx *middleware.Context) (*models.Inventory, error) {
 			return m.app.Inventory.Set(ctx, update)
 		})
+		if errors.IsConflict(err) {
+			if latest, getErr := m.app.Inventory.Get(m.context(), update.IngredientID); getErr == nil {
+				return SetConflictMsg{Err: err, Latest: latest}
+			}
+		}
 		if err != nil {
 			return SetErrorMsg{Err: err}
 		}
@@ -185,6 +200,42 @@ func (m *SetInventoryVM) submit() tea.Cmd {
 	}
 }
 
+// rebase moves the form onto latest after a version conflict, keeping the
+// fields the user edited and taking the stored values for the rest.
+func (m *SetInventoryVM) rebase(latest *models.Inventory) error {
+	base := m.row.Inventory
+	fields := []struct {
+		field        forms.Field
+		base, latest any
+	}{
+		{m.quantity, stockQuantity(base), stockQuantity(*latest)},
+		{m.tags, base.Tags.Canonical().String(), latest.Tags.Canonical().String()},
+	}
+	var contested []string
+	for _, f := range fields {
+		kept, err := forms.Rebase(f.field, f.base, f.latest)
+		if err != nil {
+			return err
+		}
+		if kept {
+			contested = append(contested, f.field.Label())
+		}
+	}
+	m.row.Inventory = *latest
+	if len(contested) == 0 {
+		return errors.Conflictf("stock changed while editing; reloaded version %d, submit again to apply your edits", latest.Version)
+	}
+	return errors.Conflictf("stock changed while editing; reloaded version %d, your edits to %s replace newer values, submit again to keep them", latest.Version, strings.Join(contested, ", "))
+}
+
+// stockQuantity is the quantity field value for stock, or nil when unknown.
+func stockQuantity(stock models.Inventory) any {
+	if stock.Amount == nil {
+		return nil
+	}
+	return stock.Amount.Value()
+}
+
 func (m *SetInventoryVM) context() *middleware.Context {
 	return m.app.Context()
 }
@@ -195,7 +246,7 @@ func (m *SetInventoryVM) parseCost() (money.Price, error) {
 		if price, ok := m.row.Inventory.CostPerUnit.Unwrap(); ok {
 			return price, nil
 		}
-		return money.ParsePrice("USD 0.00")
+		return money.NewPriceFromCents(0, m.app.Defaults().Currency), nil
 	}
 	return money.ParsePrice(value)
 }
@@ -27,7 +27,7 @@ func (m *Module) registerTagTarget(targets *tagging.Registry) {
 			if err != nil {
 				return tagging.TargetState{}, err
 			}
-			return tagging.TargetState{Entity: value.CedarEntity(), DisplayName: fmt.Sprintf("Inventory for %s", ingredient.Name), Tags: value.Tags}, nil
+			return tagging.TargetState{Entity: value.CedarEntity(), DisplayName: fmt.Sprintf("Inventory for %s", ingredient.Name), Tags: value.Tags, Value: *value}, nil
 		},
 	})
 }
@@ -0,0 +1,43 @@
+package inventory
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/inventory/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/inventory/events"
+	"github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
+	"github.com/TheFellow/go-modular-monolith/pkg/undo"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// Compensations returns the inventory commands undo can reverse. An
+// adjustment is reversed by adjusting the stock back by the change it
+// applied and restoring the cost it replaced; a cost set where there was
+// none stays, since a patch cannot clear it.
+func (m *Module) Compensations() []undo.Compensation {
+	return []undo.Compensation{
+		{Action: authz.ActionAdjust, Compensate: m.reverseAdjustment},
+	}
+}
+
+func (m *Module) reverseAdjustment(ctx *middleware.Context, recorded []any) ([]cedar.EntityUID, error) {
+	adjusted, err := undo.Find[events.StockAdjusted](recorded)
+	if err != nil {
+		return nil, err
+	}
+	if adjusted.Applied == nil {
+		return nil, errors.FailedPreconditionf("adjustment of %s was recorded without the change it applied", adjusted.Inventory.IngredientID.String())
+	}
+	patch := &models.Patch{
+		IngredientID: adjusted.Inventory.IngredientID,
+		Reason:       models.ReasonCorrected,
+		Delta:        optional.Some(adjusted.Applied.Mul(-1)),
+		CostPerUnit:  adjusted.PreviousCostPerUnit,
+	}
+	restored, err := m.Adjust(ctx, patch)
+	if err != nil {
+		return nil, err
+	}
+	return []cedar.EntityUID{restored.EntityUID()}, nil
+}
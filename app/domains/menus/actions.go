@@ -24,6 +24,7 @@ const (
 	ControlPublish     actions.ID = "menus.publish"
 	ControlDraft       actions.ID = "menus.draft"
 	ControlReadiness   actions.ID = "menus.readiness"
+	ControlRestore     actions.ID = "menus.restore"
 )
 
 // ActionProjector produces framework-neutral menu control state. It does not
@@ -86,6 +87,14 @@ func (p ActionProjector) Project(ctx context.Context, principal cedar.EntityUID,
 	}
 
 	resource := selected.CedarEntity()
+	if selected.DeletedAt.IsSome() {
+		// A deleted menu offers only restore; its lifecycle actions resume once
+		// it is back.
+		declaration.Controls = append(declaration.Controls, actions.Control{
+			ID: ControlRestore, Permission: permission(menusauthz.ActionRestore, resource),
+		})
+		return actions.Evaluate(ctx, declaration)
+	}
 	draftOnly := lifecycleCondition(selected.RequireDraft, "Available only while the menu is a draft.")
 	declaration.Groups = []actions.Group{{
 		// Editing is the broad default for the detail surface. Commands with
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/TheFellow/go-modular-monolith/app/domains/menus"
 	menusauthz "github.com/TheFellow/go-modular-monolith/app/domains/menus/authz"
@@ -11,6 +12,7 @@ import (
 	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
 	"github.com/TheFellow/go-modular-monolith/pkg/authn"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
 	"github.com/TheFellow/go-modular-monolith/pkg/presentation/actions"
 	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
 	cedar "github.com/cedar-policy/cedar-go"
@@ -112,6 +114,19 @@ func TestMenuActionProjectorPublishOverridesEditPermission(t *testing.T) {
 	testutil.Equals(t, byID[menus.ControlPublish], actions.State{ID: menus.ControlPublish, Visible: true, Enabled: true})
 }
 
+func TestMenuActionProjectorOffersOnlyRestoreForDeletedMenus(t *testing.T) {
+	t.Parallel()
+	menu := actionMenu(models.MenuStatusDraft, 1)
+	menu.DeletedAt = optional.Some(time.Now())
+	states, err := menus.NewActionProjector().Project(context.Background(), authn.Owner(), menu)
+	testutil.Ok(t, err)
+	testutil.Equals(t, states, []actions.State{
+		{ID: menus.ControlList, Visible: true, Enabled: true},
+		{ID: menus.ControlCreate, Visible: true, Enabled: true},
+		{ID: menus.ControlRestore, Visible: true, Enabled: true},
+	})
+}
+
 func TestMenuActionProjectorReturnsEvaluatorErrors(t *testing.T) {
 	t.Parallel()
 	want := errors.New("policy evaluator unavailable")
@@ -8,10 +8,14 @@ import (
 
 func (m *Module) AddDrink(ctx *middleware.Context, change *models.MenuPatch) (*models.Menu, error) {
 	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.MenuPatch, *models.Menu]{
-		Action: authz.ActionAddDrink,
+		Action:  authz.ActionAddDrink,
+		Request: change,
 		Load: func(*middleware.Context) (*models.MenuPatch, error) {
 			return change, nil
 		},
+		Before: func(ctx *middleware.Context, in *models.MenuPatch) (*models.Menu, error) {
+			return m.queries.Get(ctx, in.MenuID)
+		},
 		Handle: m.commands.AddDrink,
 	})
 }
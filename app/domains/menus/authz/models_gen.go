@@ -16,11 +16,13 @@ import (
 var Schema string
 
 const (
-	MenuType       cedar.EntityType = "Mixology::Menu"
-	ResourceType   cedar.EntityType = MenuType
-	ActionType     cedar.EntityType = "Mixology::Menu::Action"
-	MenuNameAttr                    = "Name"
-	MenuStatusAttr                  = "Status"
+	MenuType          cedar.EntityType = "Mixology::Menu"
+	ResourceType      cedar.EntityType = MenuType
+	ActionType        cedar.EntityType = "Mixology::Menu::Action"
+	MenuCreatedByAttr                  = "CreatedBy"
+	MenuNameAttr                       = "Name"
+	MenuOwnerAttr                      = "Owner"
+	MenuStatusAttr                     = "Status"
 )
 
 var (
@@ -50,22 +52,29 @@ var (
 	ActionCreate      = cedar.NewEntityUID(ActionType, "create")
 	ActionDelete      = cedar.NewEntityUID(ActionType, "delete")
 	ActionDraft       = cedar.NewEntityUID(ActionType, "draft")
+	ActionExport      = cedar.NewEntityUID(ActionType, "export")
 	ActionGet         = cedar.NewEntityUID(ActionType, "get")
+	ActionImport      = cedar.NewEntityUID(ActionType, "import")
 	ActionList        = cedar.NewEntityUID(ActionType, "list")
 	ActionPublish     = cedar.NewEntityUID(ActionType, "publish")
 	ActionReadiness   = cedar.NewEntityUID(ActionType, "readiness")
+	ActionRefresh     = cedar.NewEntityUID(ActionType, "refresh")
 	ActionRemoveDrink = cedar.NewEntityUID(ActionType, "remove_drink")
+	ActionRestore     = cedar.NewEntityUID(ActionType, "restore")
 	ActionTag         = cedar.NewEntityUID(ActionType, "tag")
+	ActionTransfer    = cedar.NewEntityUID(ActionType, "transfer")
 	ActionUntag       = cedar.NewEntityUID(ActionType, "untag")
 	ActionUpdate      = cedar.NewEntityUID(ActionType, "update")
 )
 
 // Menu is the Cedar-facing authorization model for Mixology::Menu.
 type Menu struct {
-	UID    cedar.EntityUID
-	Tags   map[string]string
-	Name   string
-	Status string
+	UID       cedar.EntityUID
+	Tags      map[string]string
+	CreatedBy cedar.EntityUID
+	Name      string
+	Owner     cedar.EntityUID
+	Status    string
 }
 
 // CedarEntity converts m to the entity shape declared in schema.cedarschema.
@@ -79,8 +88,10 @@ func (m Menu) CedarEntity() cedar.Entity {
 		UID:     cedar.NewEntityUID(MenuType, m.UID.ID),
 		Parents: cedar.NewEntityUIDSet(),
 		Attributes: cedar.NewRecord(cedar.RecordMap{
-			MenuNameAttr:   cedar.String(m.Name),
-			MenuStatusAttr: cedar.String(m.Status),
+			MenuCreatedByAttr: cedar.NewEntityUID("Mixology::Actor", m.CreatedBy.ID),
+			MenuNameAttr:      cedar.String(m.Name),
+			MenuOwnerAttr:     cedar.NewEntityUID("Mixology::Actor", m.Owner.ID),
+			MenuStatusAttr:    cedar.String(m.Status),
 		}),
 		Tags: cedar.NewRecord(tags),
 	}
@@ -16,10 +16,12 @@ func TestMenuCedarEntity(t *testing.T) {
 	t.Parallel()
 
 	model := moduleauthz.Menu{
-		UID:    cedar.NewEntityUID("Wrong::Type", "test-id"),
-		Tags:   map[string]string{"audience": "members", "featured": ""},
-		Name:   "test-name",
-		Status: "test-status",
+		UID:       cedar.NewEntityUID("Wrong::Type", "test-id"),
+		Tags:      map[string]string{"audience": "members", "featured": ""},
+		CreatedBy: cedar.NewEntityUID("Mixology::Actor", "test-createdby"),
+		Name:      "test-name",
+		Owner:     cedar.NewEntityUID("Mixology::Actor", "test-owner"),
+		Status:    "test-status",
 	}
 
 	got := model.CedarEntity()
@@ -27,8 +29,10 @@ func TestMenuCedarEntity(t *testing.T) {
 		UID:     cedar.NewEntityUID(moduleauthz.MenuType, "test-id"),
 		Parents: cedar.NewEntityUIDSet(),
 		Attributes: cedar.NewRecord(cedar.RecordMap{
-			moduleauthz.MenuNameAttr:   cedar.String("test-name"),
-			moduleauthz.MenuStatusAttr: cedar.String("test-status"),
+			moduleauthz.MenuCreatedByAttr: cedar.NewEntityUID("Mixology::Actor", "test-createdby"),
+			moduleauthz.MenuNameAttr:      cedar.String("test-name"),
+			moduleauthz.MenuOwnerAttr:     cedar.NewEntityUID("Mixology::Actor", "test-owner"),
+			moduleauthz.MenuStatusAttr:    cedar.String("test-status"),
 		}),
 		Tags: cedar.NewRecord(cedar.RecordMap{
 			"audience": cedar.String("members"),
@@ -0,0 +1,41 @@
+package menus
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+type driftResult struct {
+	menu  *models.Menu
+	drift []models.AvailabilityDrift
+}
+
+func (r driftResult) CedarEntity() cedar.Entity { return r.menu.CedarEntity() }
+
+// AvailabilityDrift lists the menu's items whose recorded availability differs
+// from a fresh calculation. It is authorized as a readiness check.
+func (m *Module) AvailabilityDrift(ctx *middleware.Context, id entity.MenuID) ([]models.AvailabilityDrift, error) {
+	result, err := middleware.RunEntityQuery(m.pipeline, ctx, authz.ActionReadiness,
+		func(ctx store.Context, id entity.MenuID) (driftResult, error) {
+			menu, drift, err := m.queries.AvailabilityDrift(ctx, id)
+			return driftResult{menu: menu, drift: drift}, err
+		}, id)
+	return result.drift, err
+}
+
+// RefreshAvailability rewrites the menu's recorded item availability from a
+// fresh calculation.
+func (m *Module) RefreshAvailability(ctx *middleware.Context, id entity.MenuID) (*models.Menu, error) {
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Menu, *models.Menu]{
+		Action:  authz.ActionRefresh,
+		Request: id,
+		Load: func(ctx *middleware.Context) (*models.Menu, error) {
+			return m.queries.Get(ctx, id)
+		},
+		Handle: m.commands.RefreshAvailability,
+	})
+}
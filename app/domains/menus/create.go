@@ -8,7 +8,8 @@ import (
 
 func (m *Module) Create(ctx *middleware.Context, menu *models.Menu) (*models.Menu, error) {
 	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Menu, *models.Menu]{
-		Action: authz.ActionCreate,
+		Action:  authz.ActionCreate,
+		Request: menu,
 		Load: func(*middleware.Context) (*models.Menu, error) {
 			return menu, nil
 		},
@@ -9,7 +9,8 @@ import (
 
 func (m *Module) Delete(ctx *middleware.Context, id entity.MenuID) (*models.Menu, error) {
 	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Menu, *models.Menu]{
-		Action: authz.ActionDelete,
+		Action:  authz.ActionDelete,
+		Request: id,
 		Load: func(ctx *middleware.Context) (*models.Menu, error) {
 			return m.queries.Get(ctx, id)
 		},
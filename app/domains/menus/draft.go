@@ -8,7 +8,8 @@ import (
 
 func (m *Module) Draft(ctx *middleware.Context, menu *models.Menu) (*models.Menu, error) {
 	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Menu, *models.Menu]{
-		Action: authz.ActionDraft,
+		Action:  authz.ActionDraft,
+		Request: menu,
 		Load: func(ctx *middleware.Context) (*models.Menu, error) {
 			return m.queries.Get(ctx, menu.ID)
 		},
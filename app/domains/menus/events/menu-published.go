@@ -1,9 +1,58 @@
 package events
 
 import (
+	"time"
+
 	"github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
 )
 
 type MenuPublished struct {
 	Menu models.Menu
 }
+
+// Topic and Payload publish MenuPublished through the outbox.
+func (e MenuPublished) Topic() string { return "menus.menu_published" }
+
+func (e MenuPublished) Payload() any {
+	items := make([]menuPublishedItem, 0, len(e.Menu.Items))
+	for _, item := range e.Menu.Items {
+		published := menuPublishedItem{
+			DrinkID:      item.DrinkID.String(),
+			Featured:     item.Featured,
+			Availability: string(item.Availability),
+		}
+		if name, ok := item.DisplayName.Unwrap(); ok {
+			published.DisplayName = name
+		}
+		if price, ok := item.Price.Unwrap(); ok {
+			published.Price = price.String()
+		}
+		items = append(items, published)
+	}
+	payload := menuPublishedPayload{
+		MenuID:      e.Menu.ID.String(),
+		Name:        e.Menu.Name,
+		Description: e.Menu.Description,
+		Items:       items,
+	}
+	if at, ok := e.Menu.PublishedAt.Unwrap(); ok {
+		payload.PublishedAt = at.UTC()
+	}
+	return payload
+}
+
+type menuPublishedPayload struct {
+	MenuID      string              `json:"menu_id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Items       []menuPublishedItem `json:"items"`
+	PublishedAt time.Time           `json:"published_at"`
+}
+
+type menuPublishedItem struct {
+	DrinkID      string `json:"drink_id"`
+	DisplayName  string `json:"display_name,omitempty"`
+	Price        string `json:"price,omitempty"`
+	Featured     bool   `json:"featured"`
+	Availability string `json:"availability"`
+}
@@ -0,0 +1,16 @@
+package events
+
+import (
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+)
+
+// MenuRestored reports a deleted menu returning as a draft. RemovedDrinks
+// lists items dropped because their drinks were deleted in the meantime.
+type MenuRestored struct {
+	Menu          models.Menu
+	RestoredAt    time.Time
+	RemovedDrinks []entity.DrinkID
+}
@@ -0,0 +1,27 @@
+package menus
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/authz"
+	menusdao "github.com/TheFellow/go-modular-monolith/app/domains/menus/internal/dao"
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// ExportAll returns every menu in any state, deleted ones included, with its
+// tags.
+func (m *Module) ExportAll(ctx *middleware.Context) ([]*models.Menu, error) {
+	return middleware.RunCollectQuery(m.pipeline, ctx, authz.ActionExport, m.queries.List, menusdao.ListFilter{IncludeDeleted: true})
+}
+
+// Import recreates a menu exported from another database, keeping its ID,
+// status, items, timestamps and tags.
+func (m *Module) Import(ctx *middleware.Context, menu *models.Menu) (*models.Menu, error) {
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Menu, *models.Menu]{
+		Action:  authz.ActionImport,
+		Request: menu,
+		Load: func(*middleware.Context) (*models.Menu, error) {
+			return menu, nil
+		},
+		Handle: m.commands.Import,
+	})
+}
@@ -49,7 +49,7 @@ func (h *StockAdjusted) Handle(ctx *middleware.HandlerContext, e inventoryevents
 		if !changed {
 			continue
 		}
-		if err := h.dao.Update(ctx, *menu); err != nil {
+		if err := h.dao.Update(ctx, menu); err != nil {
 			return err
 		}
 		ctx.TouchEntity(menu.ID.EntityUID())
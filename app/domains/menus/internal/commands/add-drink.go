@@ -41,20 +41,25 @@ func (c *Commands) AddDrink(ctx *middleware.Context, patch *models.MenuPatch) (*
 		}
 	}
 
-	updated.Items = append(updated.Items, models.MenuItem{
-		DrinkID:      patch.DrinkID,
-		DisplayName:  optional.None[string](),
-		Price:        optional.None[models.Price](),
-		Availability: c.availability.Calculate(ctx, patch.DrinkID),
-		SortOrder:    nextSort,
-	})
+	item := models.MenuItem{
+		DrinkID:     patch.DrinkID,
+		DisplayName: optional.None[string](),
+		Price:       optional.None[models.Price](),
+		SortOrder:   nextSort,
+	}
+	if reinstated, ok := patch.Item.Unwrap(); ok {
+		item = reinstated
+		item.DrinkID = patch.DrinkID
+	}
+	item.Availability = c.availability.Calculate(ctx, patch.DrinkID)
+	updated.Items = append(updated.Items, item)
 	added := updated.Items[len(updated.Items)-1]
 
 	if err := updated.Validate(); err != nil {
 		return nil, err
 	}
 
-	if err := c.dao.Update(ctx, updated); err != nil {
+	if err := c.dao.Update(ctx, &updated); err != nil {
 		return nil, err
 	}
 
@@ -7,6 +7,7 @@ import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/menus/events"
 	"github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/ownership"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
 	"github.com/TheFellow/go-modular-monolith/pkg/optional"
@@ -32,6 +33,7 @@ func (c *Commands) Create(ctx *middleware.Context, menu *models.Menu) (*models.M
 		Description: strings.TrimSpace(menu.Description),
 		Items:       nil,
 		Status:      models.MenuStatusDraft,
+		Ownership:   ownership.New(ctx.Principal()),
 		CreatedAt:   now,
 		PublishedAt: optional.None[time.Time](),
 	}
@@ -40,7 +42,7 @@ func (c *Commands) Create(ctx *middleware.Context, menu *models.Menu) (*models.M
 		return nil, err
 	}
 
-	if err := c.dao.Insert(ctx, created); err != nil {
+	if err := c.dao.Insert(ctx, &created); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// Import recreates an exported menu in whatever lifecycle state it had. No
+// publication rules are re-run and no events are raised.
+func (c *Commands) Import(ctx *middleware.Context, menu *models.Menu) (*models.Menu, error) {
+	if menu == nil {
+		return nil, errors.Invalidf("menu is required")
+	}
+	if menu.ID.IsZero() {
+		return nil, errors.Invalidf("id is required for import")
+	}
+	if err := menu.Validate(); err != nil {
+		return nil, errors.Invalidf("menu %s: %w", menu.ID.String(), err)
+	}
+
+	imported := *menu
+	imported.Version = max(imported.Version, 1)
+	if err := c.dao.Import(ctx, imported); err != nil {
+		return nil, err
+	}
+	ctx.TouchEntity(imported.ID.EntityUID())
+	return &imported, nil
+}
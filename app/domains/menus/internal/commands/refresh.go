@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"slices"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// RefreshAvailability recalculates every item's recorded availability. Stock
+// handlers normally keep it current, so an unchanged menu is not rewritten.
+func (c *Commands) RefreshAvailability(ctx *middleware.Context, menu *models.Menu) (*models.Menu, error) {
+	if menu == nil {
+		return nil, errors.Invalidf("menu is required")
+	}
+	updated := *menu
+	updated.Items = slices.Clone(menu.Items)
+	changed := false
+	for i := range updated.Items {
+		status := c.availability.Calculate(ctx, updated.Items[i].DrinkID)
+		if updated.Items[i].Availability != status {
+			updated.Items[i].Availability = status
+			changed = true
+		}
+	}
+	if !changed {
+		return menu, nil
+	}
+	if err := c.dao.Update(ctx, &updated); err != nil {
+		return nil, err
+	}
+	ctx.TouchEntity(updated.ID.EntityUID())
+	return &updated, nil
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/events"
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// Restore returns a deleted menu as a draft. Deleted menus are skipped when
+// drinks are deleted, so items whose drinks are gone are dropped here, and
+// the remaining items' availability is recalculated.
+func (c *Commands) Restore(ctx *middleware.Context, menu *models.Menu) (*models.Menu, error) {
+	if menu == nil {
+		return nil, errors.Invalidf("menu is required")
+	}
+	if err := menu.RequireRestorable(); err != nil {
+		return nil, err
+	}
+
+	ids := make([]cedar.String, 0, len(menu.Items))
+	for _, item := range menu.Items {
+		ids = append(ids, item.DrinkID.EntityUID().ID)
+	}
+	active, err := c.drinks.ActiveIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	restored := *menu
+	restored.Status = models.MenuStatusDraft
+	restored.DeletedAt = optional.None[time.Time]()
+	restored.Items = make([]models.MenuItem, 0, len(menu.Items))
+	var removed []entity.DrinkID
+	for _, item := range menu.Items {
+		if !active.Contains(item.DrinkID.EntityUID().ID) {
+			removed = append(removed, item.DrinkID)
+			continue
+		}
+		item.Availability = c.availability.Calculate(ctx, item.DrinkID)
+		restored.Items = append(restored.Items, item)
+	}
+
+	if err := restored.Validate(); err != nil {
+		return nil, err
+	}
+	if err := c.dao.Update(ctx, &restored); err != nil {
+		return nil, err
+	}
+
+	ctx.TouchEntity(restored.ID.EntityUID())
+	ctx.AddEvent(events.MenuRestored{
+		Menu:          restored,
+		RestoredAt:    time.Now().UTC(),
+		RemovedDrinks: removed,
+	})
+	return &restored, nil
+}
@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+func (c *Commands) TransferOwnership(ctx *middleware.Context, menu *models.Menu, owner cedar.EntityUID) (*models.Menu, error) {
+	if menu == nil {
+		return nil, errors.Invalidf("menu is required")
+	}
+	if menu.ID.IsZero() {
+		return nil, errors.Invalidf("id is required")
+	}
+
+	transferred, err := menu.Ownership.TransferTo(owner)
+	if err != nil {
+		return nil, err
+	}
+	updated := *menu
+	updated.Ownership = transferred
+
+	if err := c.dao.Update(ctx, &updated); err != nil {
+		return nil, err
+	}
+
+	ctx.TouchEntity(updated.ID.EntityUID())
+	return &updated, nil
+}
@@ -25,6 +25,9 @@ func (c *Commands) Update(ctx *middleware.Context, menu *models.Menu) (*models.M
 	}
 
 	updated := *existing
+	if menu.Version != 0 {
+		updated.Version = menu.Version
+	}
 	name := strings.TrimSpace(menu.Name)
 	if name == "" {
 		return nil, errors.Invalidf("name is required")
@@ -38,7 +41,7 @@ func (c *Commands) Update(ctx *middleware.Context, menu *models.Menu) (*models.M
 		return nil, err
 	}
 
-	if err := c.dao.Update(ctx, updated); err != nil {
+	if err := c.dao.Update(ctx, &updated); err != nil {
 		return nil, err
 	}
 
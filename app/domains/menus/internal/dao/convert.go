@@ -6,6 +6,7 @@ import (
 	menumodels "github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/money"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/ownership"
 	"github.com/TheFellow/go-modular-monolith/pkg/optional"
 )
 
@@ -43,9 +44,12 @@ func toRow(m menumodels.Menu) MenuRow {
 		Description: m.Description,
 		Items:       items,
 		Status:      string(m.Status),
+		CreatedBy:   m.Ownership.CreatedByID(),
+		Owner:       m.Ownership.OwnerID(),
 		CreatedAt:   m.CreatedAt,
 		PublishedAt: publishedAt,
 		DeletedAt:   deletedAt,
+		Version:     m.Version,
 	}
 }
 
@@ -87,8 +91,10 @@ func toModel(r MenuRow) menumodels.Menu {
 		Description: r.Description,
 		Items:       items,
 		Status:      menumodels.MenuStatus(r.Status),
+		Ownership:   ownership.Restore(r.CreatedBy, r.Owner),
 		CreatedAt:   r.CreatedAt,
 		PublishedAt: publishedAt,
 		DeletedAt:   deletedAt,
+		Version:     r.Version,
 	}
 }
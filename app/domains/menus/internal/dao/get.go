@@ -7,13 +7,36 @@ import (
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 	cedar "github.com/cedar-policy/cedar-go"
-	"github.com/mjl-/bstore"
 )
 
 func (d *DAO) Get(ctx store.Context, id entity.MenuID) (*models.Menu, error) {
+	menu, err := d.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if menu.DeletedAt.IsSome() {
+		return nil, errors.NotFoundf("menu %s not found", id.String())
+	}
+	return menu, nil
+}
+
+// GetDeleted loads a soft-deleted menu. Active and missing menus are not
+// found.
+func (d *DAO) GetDeleted(ctx store.Context, id entity.MenuID) (*models.Menu, error) {
+	menu, err := d.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !menu.DeletedAt.IsSome() {
+		return nil, errors.NotFoundf("deleted menu %s not found", id.String())
+	}
+	return menu, nil
+}
+
+func (d *DAO) get(ctx store.Context, id entity.MenuID) (*models.Menu, error) {
 	var row MenuRow
 	var tagsByTarget map[cedar.EntityUID]tag.Tags
-	err := d.store.ReadContext(ctx, func(tx *bstore.Tx) error {
+	err := d.store.ReadContext(ctx, func(tx *store.Tx) error {
 		row = MenuRow{ID: id.String()}
 		if err := tx.Get(&row); err != nil {
 			return err
@@ -25,9 +48,6 @@ func (d *DAO) Get(ctx store.Context, id entity.MenuID) (*models.Menu, error) {
 	if err != nil {
 		return nil, store.MapError(err, "menu %s not found", id.String())
 	}
-	if row.DeletedAt != nil {
-		return nil, errors.NotFoundf("menu %s not found", id.String())
-	}
 	menu := toModel(row)
 	menu.Tags = tagsByTarget[id.EntityUID()]
 	return &menu, nil
@@ -0,0 +1,23 @@
+package dao
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+// Import stores a menu exactly as another database held it, including its
+// creation and publication times and its tags. A taken ID or name conflicts.
+func (d *DAO) Import(ctx store.Context, menu models.Menu) error {
+	return store.Write(ctx, func(tx *store.Tx) error {
+		row := toRow(menu)
+		row.Version = max(row.Version, 1)
+		if err := tx.Insert(&row); err != nil {
+			return store.MapError(err, "import menu %s %q", menu.ID.String(), menu.Name)
+		}
+		if len(menu.Tags) == 0 {
+			return nil
+		}
+		_, err := d.tags.Replace(ctx, menu.ID.EntityUID(), menu.Tags)
+		return err
+	})
+}
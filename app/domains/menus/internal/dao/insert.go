@@ -3,12 +3,17 @@ package dao
 import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
-	"github.com/mjl-/bstore"
 )
 
-func (d *DAO) Insert(ctx store.Context, menu models.Menu) error {
-	return store.Write(ctx, func(tx *bstore.Tx) error {
-		row := toRow(menu)
-		return store.MapError(tx.Insert(&row), "insert menu %q", menu.Name)
+// Insert stores a new menu at version 1 and records that on menu.
+func (d *DAO) Insert(ctx store.Context, menu *models.Menu) error {
+	return store.Write(ctx, func(tx *store.Tx) error {
+		row := toRow(*menu)
+		row.Version = 1
+		if err := tx.Insert(&row); err != nil {
+			return store.MapError(err, "insert menu %q", menu.Name)
+		}
+		menu.Version = row.Version
+		return nil
 	})
 }
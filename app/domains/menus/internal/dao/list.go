@@ -8,12 +8,11 @@ import (
 	appfilter "github.com/TheFellow/go-modular-monolith/pkg/filter"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 	cedar "github.com/cedar-policy/cedar-go"
-	"github.com/mjl-/bstore"
 )
 
 // ListFilter specifies optional filters for listing menus.
 type ListFilter struct {
-	Status models.MenuStatus // Exact match on Status (uses bstore index)
+	Status models.MenuStatus // Exact match on Status (uses index)
 	// IncludeDeleted includes soft-deleted rows (DeletedAt != nil).
 	IncludeDeleted bool
 	BeforeID       string
@@ -22,7 +21,7 @@ type ListFilter struct {
 
 func (d *DAO) List(ctx store.Context, filter ListFilter) iter.Seq2[*models.Menu, error] {
 	return func(yield func(*models.Menu, error) bool) {
-		err := d.store.ReadContext(ctx, func(tx *bstore.Tx) error {
+		err := d.store.ReadContext(ctx, func(tx *store.Tx) error {
 			rows, err := d.query(tx, filter).SortDesc("ID").List()
 			if err != nil {
 				return store.MapError(err, "list menus")
@@ -57,8 +56,8 @@ func (d *DAO) List(ctx store.Context, filter ListFilter) iter.Seq2[*models.Menu,
 	}
 }
 
-func (d *DAO) query(tx *bstore.Tx, filter ListFilter) *bstore.Query[MenuRow] {
-	q := bstore.QueryTx[MenuRow](tx)
+func (d *DAO) query(tx *store.Tx, filter ListFilter) *store.Query[MenuRow] {
+	q := store.QueryTx[MenuRow](tx)
 	if filter.Status != "" {
 		q = q.FilterEqual("Status", string(filter.Status))
 	}
@@ -70,7 +69,7 @@ func (d *DAO) query(tx *bstore.Tx, filter ListFilter) *bstore.Query[MenuRow] {
 			return r.DeletedAt == nil
 		})
 	}
-	q = appfilter.ApplyBstorePushdowns(q, filter.Expression)
+	q = appfilter.ApplyStorePushdowns(q, filter.Expression)
 	return q
 }
 
@@ -14,9 +14,12 @@ type MenuRow struct {
 	Description string
 	Items       []MenuItemRow
 	Status      string    `bstore:"index"`
+	Owner       string    `bstore:"index"`
 	CreatedAt   time.Time `bstore:"index"`
+	CreatedBy   string
 	PublishedAt *time.Time
 	DeletedAt   *time.Time
+	Version     int64
 }
 
 type MenuItemRow struct {
@@ -3,12 +3,25 @@ package dao
 import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
-	"github.com/mjl-/bstore"
 )
 
-func (d *DAO) Update(ctx store.Context, menu models.Menu) error {
-	return store.Write(ctx, func(tx *bstore.Tx) error {
-		row := toRow(menu)
-		return store.MapError(tx.Update(&row), "update menu %s", menu.ID.String())
+// Update replaces the stored menu only while it is still at menu.Version,
+// then advances menu.Version to the version written.
+func (d *DAO) Update(ctx store.Context, menu *models.Menu) error {
+	return store.Write(ctx, func(tx *store.Tx) error {
+		row := toRow(*menu)
+		current := MenuRow{ID: row.ID}
+		if err := tx.Get(&current); err != nil {
+			return store.MapError(err, "update menu %s", menu.ID.String())
+		}
+		if err := store.CheckVersion(current.Version, menu.Version, "menu %s", menu.ID.String()); err != nil {
+			return err
+		}
+		row.Version++
+		if err := tx.Update(&row); err != nil {
+			return store.MapError(err, "update menu %s", menu.ID.String())
+		}
+		menu.Version = row.Version
+		return nil
 	})
 }
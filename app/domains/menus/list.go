@@ -18,6 +18,9 @@ type ListRequest struct {
 	Filter string
 	Cursor paging.Cursor
 	Limit  int
+
+	// IncludeDeleted lists deleted menus alongside live ones.
+	IncludeDeleted bool
 }
 
 func (m *Module) List(ctx *middleware.Context, req ListRequest) (paging.Page[*models.Menu], error) {
@@ -33,7 +36,7 @@ func (m *Module) List(ctx *middleware.Context, req ListRequest) (paging.Page[*mo
 			return paging.Page[*models.Menu]{}, err
 		}
 	}
-	filter := menudao.ListFilter{Status: req.Status, Expression: expression}
+	filter := menudao.ListFilter{Status: req.Status, Expression: expression, IncludeDeleted: req.IncludeDeleted}
 	return middleware.RunPageQuery(
 		m.pipeline, ctx, authz.ActionList,
 		func(ctx store.Context, filter menudao.ListFilter, cursor paging.Cursor) iter.Seq2[*models.Menu, error] {
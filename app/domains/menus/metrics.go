@@ -0,0 +1,41 @@
+package menus
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/internal/dao"
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+var findingSeverities = []models.ReadinessSeverity{models.ReadinessBlocker, models.ReadinessWarning}
+
+// CollectMetrics refreshes the menu health gauge: how many draft and
+// published menus have at least one readiness finding of each severity.
+// Archived menus are never published again, so their findings are not
+// counted. The readiness reports are computed without authorization and only
+// their counts are published.
+func (m *Module) CollectMetrics(ctx *middleware.Context) error {
+	counts := make(map[models.ReadinessSeverity]int, len(findingSeverities))
+	for menu, err := range m.queries.List(ctx, dao.ListFilter{}) {
+		if err != nil {
+			return err
+		}
+		if menu.Status == models.MenuStatusArchived {
+			continue
+		}
+		_, report, err := m.queries.Readiness(ctx, menu.ID)
+		if err != nil {
+			return err
+		}
+		seen := make(map[models.ReadinessSeverity]bool, len(findingSeverities))
+		for _, finding := range report.Findings {
+			if !seen[finding.Severity] {
+				seen[finding.Severity] = true
+				counts[finding.Severity]++
+			}
+		}
+	}
+	for _, severity := range findingSeverities {
+		m.menuFindings.Set(float64(counts[severity]), string(severity))
+	}
+	return nil
+}
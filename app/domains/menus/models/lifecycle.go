@@ -34,3 +34,12 @@ func (m Menu) RequireReturnToDraft() error {
 
 	return errors.FailedPreconditionf("menu %q must be published, got %q", m.ID.String(), m.Status)
 }
+
+// RequireRestorable ensures the menu was deleted, which also archived it.
+func (m Menu) RequireRestorable() error {
+	if m.DeletedAt.IsSome() {
+		return nil
+	}
+
+	return errors.FailedPreconditionf("menu %q is not deleted", m.ID.String())
+}
@@ -3,12 +3,17 @@ package models
 import (
 	menuauthz "github.com/TheFellow/go-modular-monolith/app/domains/menus/authz"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
 	cedar "github.com/cedar-policy/cedar-go"
 )
 
 type MenuPatch struct {
 	MenuID  entity.MenuID
 	DrinkID entity.DrinkID
+	// Item, when set, is a removed item that add-drink reinstates with its
+	// display name, price, featured flag and sort order. Undo of remove-drink
+	// uses it; other callers add a plain item.
+	Item optional.Value[MenuItem]
 }
 
 func (c MenuPatch) EntityUID() cedar.EntityUID {
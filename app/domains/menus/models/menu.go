@@ -6,6 +6,7 @@ import (
 	menuauthz "github.com/TheFellow/go-modular-monolith/app/domains/menus/authz"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/money"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/ownership"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/optional"
@@ -22,10 +23,13 @@ type Menu struct {
 	Description string
 	Items       []MenuItem
 	Status      MenuStatus
+	Ownership   ownership.Ownership
 	CreatedAt   time.Time
 	PublishedAt optional.Value[time.Time]
 	DeletedAt   optional.Value[time.Time]
 	Tags        tag.Tags
+	// Version is bumped on every write; a stale Version on update conflicts.
+	Version int64
 }
 
 func (m Menu) EntityUID() cedar.EntityUID {
@@ -37,6 +41,7 @@ func (m *Menu) SetTags(tags tag.Tags) { m.Tags = tags }
 func (m Menu) CedarEntity() cedar.Entity {
 	return menuauthz.Menu{
 		UID: m.ID.EntityUID(), Name: m.Name, Status: string(m.Status), Tags: m.Tags.Map(),
+		CreatedBy: m.Ownership.CreatedBy, Owner: m.Ownership.Owner,
 	}.CedarEntity()
 }
 
@@ -114,3 +119,11 @@ func (s MenuStatus) Validate() error {
 }
 
 type Price = money.Price
+
+// AvailabilityDrift is a menu item whose recorded availability no longer
+// matches what the availability calculator derives from current stock.
+type AvailabilityDrift struct {
+	DrinkID    entity.DrinkID
+	Recorded   Availability
+	Calculated Availability
+}
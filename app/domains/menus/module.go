@@ -9,7 +9,9 @@ import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/tagging"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/migrate"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
 )
 
 type Module struct {
@@ -17,6 +19,8 @@ type Module struct {
 	analytics *queries.AnalyticsCalculator
 	commands  *commands.Commands
 	pipeline  *middleware.Pipeline
+
+	menuFindings telemetry.Gauge
 }
 
 func NewModule(ctx context.Context, s *store.Store, tags tag.Repository, targets *tagging.Registry, pipeline *middleware.Pipeline) *Module {
@@ -26,7 +30,14 @@ func NewModule(ctx context.Context, s *store.Store, tags tag.Repository, targets
 		analytics: queries.NewAnalyticsCalculator(s, tags),
 		commands:  commands.New(s, tags),
 		pipeline:  pipeline,
+
+		menuFindings: telemetry.FromContext(ctx).Gauge(telemetry.MetricMenusWithFindings, telemetry.LabelSeverity),
 	}
 	m.registerTagTarget(targets)
 	return m
 }
+
+// Migrations returns the menus domain's data migrations for app.New to apply.
+func Migrations() migrate.Domain {
+	return migrate.Domain{Name: "menus", Migrations: dao.Migrations()}
+}
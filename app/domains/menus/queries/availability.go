@@ -0,0 +1,24 @@
+package queries
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+// AvailabilityDrift recalculates each item's availability and returns the
+// items whose recorded value differs.
+func (q *Queries) AvailabilityDrift(ctx store.Context, id entity.MenuID) (*models.Menu, []models.AvailabilityDrift, error) {
+	menu, err := q.dao.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	var drift []models.AvailabilityDrift
+	for _, item := range menu.Items {
+		calculated := q.availability.Calculate(ctx, item.DrinkID)
+		if calculated != item.Availability {
+			drift = append(drift, models.AvailabilityDrift{DrinkID: item.DrinkID, Recorded: item.Availability, Calculated: calculated})
+		}
+	}
+	return menu, drift, nil
+}
@@ -0,0 +1,20 @@
+package menus
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// Restore brings back a deleted menu as a draft with the tags it had.
+func (m *Module) Restore(ctx *middleware.Context, id entity.MenuID) (*models.Menu, error) {
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Menu, *models.Menu]{
+		Action:  authz.ActionRestore,
+		Request: id,
+		Load: func(ctx *middleware.Context) (*models.Menu, error) {
+			return m.queries.GetDeleted(ctx, id)
+		},
+		Handle: m.commands.Restore,
+	})
+}
@@ -0,0 +1,40 @@
+package menus_test
+
+import (
+	"testing"
+
+	menusauthz "github.com/TheFellow/go-modular-monolith/app/domains/menus/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestMenuRestoreReturnsDraftWithoutDeletedDrinks(t *testing.T) {
+	t.Parallel()
+	fix := testutil.NewFixture(t)
+	ctx := fix.OwnerContext()
+
+	kept := createMenuTestDrink(t, fix, "Kept drink")
+	gone := createMenuTestDrink(t, fix, "Gone drink")
+	menu := testutil.CreateMenu(t, fix, "Winter menu", testutil.WithDrink(kept), testutil.WithDrink(gone))
+
+	_, err := fix.Menus.Delete(ctx, menu.ID)
+	testutil.Ok(t, err)
+	_, err = fix.Drinks.Delete(ctx, gone.ID)
+	testutil.Ok(t, err)
+
+	restored, err := fix.Menus.Restore(ctx, menu.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, restored.Status, models.MenuStatusDraft)
+	testutil.ErrorIf(t, restored.DeletedAt.IsSome(), "expected DeletedAt to be cleared")
+	testutil.Equals(t, len(restored.Items), 1)
+	testutil.Equals(t, restored.Items[0].DrinkID, kept.ID)
+	testutil.Equals(t, restored.Items[0].Availability, models.AvailabilityAvailable)
+
+	got, err := fix.Menus.Get(ctx, menu.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, got.Status, models.MenuStatusDraft)
+	testutil.AuditTouches(t, fix.LatestAuditEntry(menusauthz.ActionRestore), menu.ID.EntityUID())
+
+	_, err = fix.Menus.Restore(ctx, menu.ID)
+	testutil.ErrorIsNotFound(t, err)
+}
@@ -12,8 +12,12 @@ type Menu struct {
 	Status      string               `json:"status"`
 	CreatedAt   string               `json:"created_at"`
 	PublishedAt *string              `json:"published_at,omitempty"`
+	DeletedAt   *string              `json:"deleted_at,omitempty"`
 	Items       []MenuItem           `json:"items,omitempty"`
+	CreatedBy   string               `json:"created_by,omitempty"`
+	Owner       string               `json:"owner,omitempty"`
 	Tags        tag.CanonicalStrings `json:"tags"`
+	Version     int64                `json:"version,omitempty"`
 }
 
 type MenuItem struct {
@@ -32,6 +36,12 @@ func FromDomainMenu(m models.Menu) Menu {
 		publishedAt = &s
 	}
 
+	var deletedAt *string
+	if t, ok := m.DeletedAt.Unwrap(); ok {
+		s := t.Format("2006-01-02T15:04:05Z07:00")
+		deletedAt = &s
+	}
+
 	items := make([]MenuItem, 0, len(m.Items))
 	for _, item := range m.Items {
 		items = append(items, FromDomainMenuItem(item))
@@ -44,8 +54,12 @@ func FromDomainMenu(m models.Menu) Menu {
 		Status:      string(m.Status),
 		CreatedAt:   m.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		PublishedAt: publishedAt,
+		DeletedAt:   deletedAt,
 		Items:       items,
+		CreatedBy:   m.Ownership.CreatedByID(),
+		Owner:       m.Ownership.OwnerID(),
 		Tags:        m.Tags.Canonical(),
+		Version:     m.Version,
 	}
 }
 
@@ -18,8 +18,10 @@ type MenuRow struct {
 	Items       string               `table:"ITEMS" json:"items,omitempty"`
 	CreatedAt   string               `table:"CREATED_AT" json:"created_at,omitempty"`
 	PublishedAt string               `table:"PUBLISHED_AT" json:"published_at,omitempty"`
+	DeletedAt   string               `table:"DELETED_AT" json:"deleted_at,omitempty"`
 	Desc        string               `table:"-" json:"description,omitempty"`
 	Tags        tag.CanonicalStrings `table:"TAGS" json:"tags"`
+	Version     int64                `table:"-" json:"version,omitempty"`
 }
 
 type MenuItemRow struct {
@@ -39,6 +41,10 @@ func ToMenuRow(m *models.Menu) MenuRow {
 	if t, ok := m.PublishedAt.Unwrap(); ok {
 		publishedAt = formatTime(t)
 	}
+	var deletedAt string
+	if t, ok := m.DeletedAt.Unwrap(); ok {
+		deletedAt = formatTime(t)
+	}
 	return MenuRow{
 		ID:          m.ID.String(),
 		Name:        m.Name,
@@ -46,8 +52,10 @@ func ToMenuRow(m *models.Menu) MenuRow {
 		Items:       fmt.Sprintf("%d", len(m.Items)),
 		CreatedAt:   formatTime(m.CreatedAt),
 		PublishedAt: publishedAt,
+		DeletedAt:   deletedAt,
 		Desc:        m.Description,
 		Tags:        m.Tags.Canonical(),
+		Version:     m.Version,
 	}
 }
 
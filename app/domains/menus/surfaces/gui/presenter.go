@@ -45,6 +45,8 @@ type Filter struct {
 	Status     models.MenuStatus
 	Expression string
 	Limit      int
+	// IncludeDeleted lists deleted menus so they can be restored.
+	IncludeDeleted bool
 }
 
 type Form struct {
@@ -76,6 +78,7 @@ type State struct {
 	CanPublish, CanDraft            bool
 	CanList                         bool
 	CanCreate                       bool
+	CanRestore                      bool
 	Actions                         map[actions.ID]actions.State
 	FormInstance                    uint64
 }
@@ -167,7 +170,7 @@ func (p *Presenter) loadPage(appendPage bool) {
 	cursor := p.state.Cursor
 	p.load.LoadContext(p.app.Context(), func(ctx context.Context) (catalog, error) {
 		op := p.app.ContextFrom(ctx)
-		page, err := p.app.Menus.List(op, menus.ListRequest{Status: f.Status, Filter: f.Expression, Cursor: cursor, Limit: f.Limit})
+		page, err := p.app.Menus.List(op, menus.ListRequest{Status: f.Status, Filter: f.Expression, Cursor: cursor, Limit: f.Limit, IncludeDeleted: f.IncludeDeleted})
 		if err != nil {
 			return catalog{}, err
 		}
@@ -338,7 +341,7 @@ func (p *Presenter) StartAnalysis() {
 	if p.state.Loading || p.state.Submitting || p.state.Confirming || p.state.Selected == nil {
 		return
 	}
-	p.state.Mode, p.state.AnalysisForm, p.state.Analysis, p.state.Err = Analyzing, AnalysisForm{TargetMargin: "0.70"}, nil, nil
+	p.state.Mode, p.state.AnalysisForm, p.state.Analysis, p.state.Err = Analyzing, AnalysisForm{TargetMargin: strconv.FormatFloat(p.app.Defaults().TargetMargin, 'f', -1, 64)}, nil, nil
 	p.publish()
 }
 func (p *Presenter) SetAnalysisForm(form AnalysisForm) { p.state.AnalysisForm = form; p.publish() }
@@ -482,7 +485,7 @@ func (p *Presenter) Save() bool {
 				return errors.Invalidf("menu not selected")
 			}
 			_, err := app.RunTaggedMutation(p.app.App, p.app.Context(), desired, func(ctx *middleware.Context) (*models.Menu, error) {
-				return p.app.Menus.Update(ctx, &models.Menu{ID: target.ID, Name: name, Description: description})
+				return p.app.Menus.Update(ctx, &models.Menu{ID: target.ID, Name: name, Description: description, Version: target.Version})
 			})
 			return err
 		})
@@ -578,6 +581,15 @@ func (p *Presenter) Delete() {
 		}
 	})
 }
+
+// Restore brings the selected deleted menu back as a draft.
+func (p *Presenter) Restore() bool {
+	target := cloneMenu(p.state.Selected)
+	if target == nil || !p.actionEnabled(menus.ControlRestore) || p.confirming {
+		return false
+	}
+	return p.mutate(func() error { _, err := p.app.Menus.Restore(p.app.Context(), target.ID); return err })
+}
 func (p *Presenter) Publish() {
 	target := cloneMenu(p.state.Selected)
 	if target == nil || !p.actionEnabled(menus.ControlPublish) || p.state.Dirty || p.dialogs == nil || p.confirming || p.submit.Active() {
@@ -654,6 +666,11 @@ func (p *Presenter) mutate(work func() error) bool {
 	p.publish()
 	accepted := p.submit.Submit(work, func(err error) {
 		p.state.Submitting = false
+		if errors.IsConflict(err) && (p.state.Mode == Editing || p.state.Mode == Renaming) && p.offerMerge() {
+			p.state.Err = ui.PresentError(err)
+			p.publish()
+			return
+		}
 		if err != nil {
 			p.fail(err)
 			return
@@ -696,6 +713,39 @@ func (p *Presenter) mutate(work func() error) bool {
 	return accepted
 }
 
+// offerMerge asks whether to reload a menu that changed while it was being
+// edited. Accepting rebases the unsaved edits onto the latest version. It
+// reports false when the latest version cannot be loaded.
+func (p *Presenter) offerMerge() bool {
+	base := cloneMenu(p.state.Selected)
+	if base == nil || p.dialogs == nil {
+		return false
+	}
+	latest, err := p.app.Menus.Get(p.app.Context(), base.ID)
+	if err != nil {
+		return false
+	}
+	edited := p.state.Form
+	message := fmt.Sprintf("%q was changed by someone else while you were editing.\n\nReload the latest version and keep your edits?", base.Name)
+	p.dialogs.Confirm("Menu Changed", message, func(ok bool) {
+		if !ok || p.state.Selected == nil || p.state.Selected.ID != latest.ID {
+			return
+		}
+		p.state.Selected = cloneMenu(latest)
+		p.state.Form = ui.MergeForm(formFromMenu(base), edited, formFromMenu(latest))
+		p.state.Dirty = !reflect.DeepEqual(p.state.Form, formFromMenu(latest))
+		p.state.FormInstance++
+		p.state.Err = nil
+		for i, item := range p.state.Items {
+			if item.ID == latest.ID {
+				p.state.Items[i] = cloneMenu(latest)
+			}
+		}
+		p.publish()
+	})
+	return true
+}
+
 func formFromMenu(menu *models.Menu) Form {
 	if menu == nil {
 		return Form{}
@@ -739,7 +789,7 @@ func (p *Presenter) loadReadiness() {
 }
 func (p *Presenter) permissionsFor(menu *models.Menu) error {
 	p.state.Actions = nil
-	p.state.CanList, p.state.CanCreate, p.state.CanUpdate, p.state.CanDelete, p.state.CanTag = false, false, false, false, false
+	p.state.CanList, p.state.CanCreate, p.state.CanUpdate, p.state.CanDelete, p.state.CanTag, p.state.CanRestore = false, false, false, false, false, false
 	p.state.CanAddDrink, p.state.CanRemoveDrink, p.state.CanPublish, p.state.CanDraft = false, false, false, false
 	states, err := p.projector.Project(p.app.Context(), p.app.Context().Principal(), menu)
 	if err != nil {
@@ -757,6 +807,7 @@ func (p *Presenter) permissionsFor(menu *models.Menu) error {
 	p.state.CanUpdate = state(menus.ControlEdit).Visible
 	p.state.CanDelete = state(menus.ControlDelete).Visible
 	p.state.CanTag = state(menus.ControlTags).Visible
+	p.state.CanRestore = state(menus.ControlRestore).Visible
 	p.state.CanAddDrink = state(menus.ControlAddDrink).Visible
 	p.state.CanRemoveDrink = state(menus.ControlRemoveDrink).Visible
 	p.state.CanPublish = state(menus.ControlPublish).Visible
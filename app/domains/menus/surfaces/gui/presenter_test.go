@@ -152,6 +152,61 @@ func TestDraftAndPublishedDetailKeepAuthorizedLifecycleActionsVisible(t *testing
 	testutil.Equals(t, v.delete.Disabled(), true)
 }
 
+func TestShowDeletedListsDeletedMenusAndRestoreReturnsThemAsDrafts(t *testing.T) {
+	gui := frameworktest.NewApp()
+	defer gui.Quit()
+	f := testutil.NewFixture(t)
+	menu := testutil.CreateMenu(t, f, "Retired menu")
+	_, err := f.Menus.Delete(f.OwnerContext(), menu.ID)
+	testutil.Ok(t, err)
+	p := NewPresenter(f.App, Dependencies{Executor: appgui.InlineExecutor{}, Dispatcher: appgui.InlineDispatcher{}})
+	v := NewView(p)
+	driver := fynetest.NewDriver(t, v.Content())
+	p.Refresh()
+	testutil.Equals(t, len(p.State().Items), 0)
+
+	driver.Tap(ControlFilterDeleted)
+	testutil.Equals(t, p.State().Filter.IncludeDeleted, true)
+	testutil.Equals(t, len(p.State().Items), 1)
+	p.Select(0)
+	state := p.State()
+	testutil.Equals(t, state.Mode, Viewing)
+	testutil.Equals(t, state.CanRestore, true)
+	testutil.Equals(t, v.restore.Hidden, false)
+	testutil.Equals(t, v.delete.Hidden, true)
+	testutil.Equals(t, v.publish.Hidden, true)
+	testutil.Equals(t, v.analyze.Hidden, true)
+
+	driver.Tap(ControlRestore)
+	got, err := f.Menus.Get(f.OwnerContext(), menu.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, got.Status, models.MenuStatusDraft)
+	testutil.AuditTouches(t, f.LatestAuditEntry(authz.ActionRestore), menu.EntityUID())
+	state = p.State()
+	testutil.Equals(t, state.Mode, Editing)
+	testutil.Equals(t, state.CanRestore, false)
+	testutil.Equals(t, v.restore.Hidden, true)
+
+	p.ResetList()
+	testutil.Equals(t, p.State().Filter.IncludeDeleted, false)
+	testutil.Equals(t, v.showDeleted.Checked, false)
+}
+
+func TestRestoreRequiresProjectedPermission(t *testing.T) {
+	f := testutil.NewFixture(t)
+	menu := testutil.CreateMenu(t, f, "Guarded menu")
+	_, err := f.Menus.Delete(f.OwnerContext(), menu.ID)
+	testutil.Ok(t, err)
+	deleted, err := f.Menus.List(f.OwnerContext(), menus.ListRequest{IncludeDeleted: true})
+	testutil.Ok(t, err)
+	readOnly := appcore.NewSession(f.ActorContext("bartender"), f.App.App)
+	p := NewPresenter(readOnly, Dependencies{Executor: appgui.InlineExecutor{}, Dispatcher: appgui.InlineDispatcher{}})
+	p.state.Items = deleted.Items
+	p.Select(0)
+	testutil.Equals(t, p.State().CanRestore, false)
+	testutil.Equals(t, p.Restore(), false)
+}
+
 func TestDuplicateNameCreateRetainsFormAndPresentsTypedConflict(t *testing.T) {
 	f := testutil.NewFixture(t)
 	testutil.CreateMenu(t, f, "Duplicate")
@@ -222,6 +277,32 @@ func TestDirtyDetailDisablesWorkflowActionsAndCancelRestores(t *testing.T) {
 	testutil.Equals(t, v.publish.Disabled(), false)
 }
 
+func TestStaleEditOffersReloadAndKeepsLocalEdits(t *testing.T) {
+	f := testutil.NewFixture(t)
+	menu := testutil.CreateMenu(t, f, "Spring")
+	dialogs := &fynetest.Dialogs{}
+	p := NewPresenter(f.App, Dependencies{Executor: appgui.InlineExecutor{}, Dispatcher: appgui.InlineDispatcher{}, Dialogs: dialogs})
+	p.Refresh()
+	p.Select(0)
+	_, err := f.Menus.Update(f.OwnerContext(), &models.Menu{ID: menu.ID, Name: menu.Name, Description: "Garden cocktails"})
+	testutil.Ok(t, err)
+
+	form := p.State().Form
+	form.Name = "Spring Patio"
+	p.SetForm(form)
+	testutil.Equals(t, p.Save(), true)
+	testutil.Equals(t, len(dialogs.Confirmations()), 1)
+	dialogs.Confirmations()[0].Respond(true)
+	testutil.Equals(t, p.State().Form.Name, "Spring Patio")
+	testutil.Equals(t, p.State().Form.Description, "Garden cocktails")
+
+	testutil.Equals(t, p.Save(), true)
+	got, err := f.Menus.Get(f.OwnerContext(), menu.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, got.Name, "Spring Patio")
+	testutil.Equals(t, got.Description, "Garden cocktails")
+}
+
 func TestEmptyDraftPublishIsVisibleDisabledWithProjectedReason(t *testing.T) {
 	gui := frameworktest.NewApp()
 	defer gui.Quit()
@@ -732,7 +813,8 @@ func TestCLIWorkflowAndFyneShareMenuPersistenceContract(t *testing.T) {
 	ctx = pkglog.ToContext(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)))
 	database, err := store.Open(ctx, filepath.Join(dir, "data", "mixology.db"))
 	testutil.Ok(t, err)
-	application := appcore.New(ctx, appcore.Config{Store: database})
+	application, err := appcore.New(ctx, appcore.Config{Store: database})
+	testutil.Ok(t, err)
 	session := appcore.NewSession(ctx, application)
 	p := NewPresenter(session, Dependencies{Executor: appgui.InlineExecutor{}, Dispatcher: appgui.InlineDispatcher{}})
 	p.Refresh()
@@ -30,6 +30,7 @@ const (
 	ControlPublish           = "menus.publish"
 	ControlDraft             = "menus.draft"
 	ControlTags              = "menus.tags"
+	ControlRestore           = "menus.restore"
 	ControlAddDrink          = "menus.drink.add"
 	ControlAnalyze           = "menus.analyze"
 	ControlTargetMargin      = "menus.analysis.target-margin"
@@ -37,6 +38,7 @@ const (
 	ControlApplyFilter       = "menus.filter.apply"
 	ControlFilterStatus      = "menus.filter.status"
 	ControlFilterExpression  = "menus.filter.expression"
+	ControlFilterDeleted     = "menus.filter.deleted"
 	ControlName              = "menus.form.name"
 	ControlDescription       = "menus.form.description"
 	ControlTagValues         = "menus.form.tags"
@@ -62,6 +64,8 @@ type View struct {
 	save, cancel, refresh, create                                  *ui.SemanticButton
 	applyFilter                                                    *ui.SemanticButton
 	rename, delete, publish, draft, analyze, tagAction, addDrink   *ui.SemanticButton
+	restore                                                        *ui.SemanticButton
+	showDeleted                                                    *ui.SemanticCheck
 	drinkSearchAction, drinkCancel, runAnalysis, analysisCancel    *ui.SemanticButton
 	drinkChoices                                                   *framework.Container
 	state                                                          State
@@ -77,10 +81,20 @@ var _ ui.Activated = (*View)(nil)
 
 func NewView(p *Presenter) *View {
 	v := &View{p: p, state: p.State()}
+	v.showDeleted = ui.NewCheck(ControlFilterDeleted, "Show deleted", func(on bool) {
+		if v.rendering {
+			return
+		}
+		f := p.State().Filter
+		f.IncludeDeleted = on
+		if p.SetFilter(f) {
+			p.Refresh()
+		}
+	})
 	bar := ui.NewSingleRowFilterBar(ControlFilterExpression, ControlApplyFilter, `Filter menus (for example: name.contains("summer"))`, v.state.Filter.Expression,
 		[]ui.FilterPreset{{ID: ControlFilterStatus, Placeholder: "Status", Options: []ui.FilterOption{{Label: "Any status"}, {Label: "Draft", Expression: `status == "draft"`}, {Label: "Published", Expression: `status == "published"`}, {Label: "Archived", Expression: `status == "archived"`}}}},
-		nil, func(expression string) {
-			if p.SetFilter(Filter{Expression: expression, Limit: ui.PageLimit}) {
+		v.showDeleted, func(expression string) {
+			if p.SetFilter(Filter{Expression: expression, Limit: ui.PageLimit, IncludeDeleted: v.showDeleted.Checked}) {
 				p.Refresh()
 			}
 		})
@@ -98,7 +112,11 @@ func NewView(p *Presenter) *View {
 		if t, ok := m.PublishedAt.Unwrap(); ok {
 			published = t.Format(time.RFC3339)
 		}
-		values := []string{m.Name, string(m.Status), strconv.Itoa(len(m.Items)), formatTime(m.CreatedAt), published, m.Tags.Canonical().String()}
+		status := string(m.Status)
+		if m.DeletedAt.IsSome() {
+			status = "deleted"
+		}
+		values := []string{m.Name, status, strconv.Itoa(len(m.Items)), formatTime(m.CreatedAt), published, m.Tags.Canonical().String()}
 		if id.Col == len(columns)-1 {
 			index := id.Row
 			actions := []ui.RowAction{{Label: "View", Run: func() { p.Select(index) }}}
@@ -114,6 +132,9 @@ func NewView(p *Presenter) *View {
 			if state := projected[menusdomain.ControlDraft]; state.Visible && state.Enabled {
 				actions = append(actions, ui.RowAction{Label: "Return to draft", Run: func() { p.Select(index); p.ReturnToDraft() }})
 			}
+			if state := projected[menusdomain.ControlRestore]; state.Visible && state.Enabled {
+				actions = append(actions, ui.RowAction{Label: "Restore", Run: func() { p.Select(index); p.Restore() }})
+			}
 			ui.ShowCellActions(cell, actions)
 			return
 		}
@@ -152,6 +173,7 @@ func NewView(p *Presenter) *View {
 	v.draft = ui.NewButton(ControlDraft, "Return to draft", p.ReturnToDraft)
 	v.tagAction = ui.WithIcon(ui.NewButton(ControlTags, "Tags", p.StartTags), ui.IconTag)
 	v.delete = ui.Destructive(ui.WithIcon(ui.NewButton(ControlDelete, "Delete", p.Delete), ui.IconDelete))
+	v.restore = ui.Primary(ui.NewButton(ControlRestore, "Restore", func() { p.Restore() }))
 	v.detail = v.buildDetail(v.state)
 	v.tagsPanel = v.buildTags(v.state)
 
@@ -321,7 +343,9 @@ func (v *View) buildDetail(s State) *framework.Container {
 		if actionVisible(s, menusdomain.ControlAddDrink) {
 			actions = append(actions, v.addDrink)
 		}
-		actions = append(actions, v.analyze)
+		if selected.DeletedAt.IsNone() {
+			actions = append(actions, v.analyze)
+		}
 		if actionVisible(s, menusdomain.ControlPublish) {
 			actions = append(actions, v.publish)
 		}
@@ -334,12 +358,16 @@ func (v *View) buildDetail(s State) *framework.Container {
 		if actionVisible(s, menusdomain.ControlDelete) {
 			actions = append(actions, v.delete)
 		}
+		if actionVisible(s, menusdomain.ControlRestore) {
+			actions = append(actions, v.restore)
+		}
 		transientReady := !s.Dirty && !s.Loading && !s.Submitting && !s.Confirming
 		setEnabled(v.addDrink, transientReady && actionEnabled(s, menusdomain.ControlAddDrink))
 		setEnabled(v.publish, transientReady && actionEnabled(s, menusdomain.ControlPublish))
 		setEnabled(v.draft, transientReady && actionEnabled(s, menusdomain.ControlDraft))
 		setEnabled(v.tagAction, transientReady && actionEnabled(s, menusdomain.ControlTags))
 		setEnabled(v.delete, transientReady && actionEnabled(s, menusdomain.ControlDelete))
+		setEnabled(v.restore, transientReady && actionEnabled(s, menusdomain.ControlRestore))
 	}
 	body := []framework.CanvasObject{}
 	if bar := ui.ActionBar(nil, actions); bar != nil {
@@ -386,11 +414,12 @@ func (v *View) render(s State) {
 	busy := s.Loading || s.Submitting || s.Confirming
 	detail := s.Selected != nil && (s.Mode == Viewing || s.Mode == Editing)
 	v.addDrink.Hidden = !detail || !actionVisible(s, menusdomain.ControlAddDrink)
-	v.analyze.Hidden = !detail
+	v.analyze.Hidden = !detail || s.Selected.DeletedAt.IsSome()
 	v.publish.Hidden = !detail || !actionVisible(s, menusdomain.ControlPublish)
 	v.draft.Hidden = !detail || !actionVisible(s, menusdomain.ControlDraft)
 	v.tagAction.Hidden = !detail || !actionVisible(s, menusdomain.ControlTags)
 	v.delete.Hidden = !detail || !actionVisible(s, menusdomain.ControlDelete)
+	v.restore.Hidden = !detail || !actionVisible(s, menusdomain.ControlRestore)
 	v.descriptionHelp.Hidden = s.Mode != Editing && s.Mode != Renaming
 	listAccess := actionVisible(s, menusdomain.ControlList)
 	setEnabled(v.refresh, !busy && listAccess)
@@ -399,6 +428,12 @@ func (v *View) render(s State) {
 	setEnabled(v.filterExpression, !busy && listAccess)
 	setEnabled(v.filterStatus, !busy && listAccess)
 	setEnabled(v.applyFilter, !busy && listAccess)
+	setEnabled(v.showDeleted, !busy && listAccess)
+	if v.showDeleted.Checked != s.Filter.IncludeDeleted {
+		v.rendering = true
+		v.showDeleted.SetChecked(s.Filter.IncludeDeleted)
+		v.rendering = false
+	}
 	setEnabled(v.rename, !busy)
 	setEnabled(v.tagAction, !busy)
 	setEnabled(v.delete, !busy)
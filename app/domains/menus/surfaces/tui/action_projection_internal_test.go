@@ -12,6 +12,7 @@ import (
 	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/tuitest"
 	cedar "github.com/cedar-policy/cedar-go"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -124,3 +125,40 @@ func TestMenuProjectionErrorRecoveryPreservesUnrelatedError(t *testing.T) {
 	vm.syncActions()
 	testutil.ErrorIf(t, !errors.Is(vm.err, unrelated), "unrelated error was cleared: %v", vm.err)
 }
+
+type restoreProgram struct{ vm *ListViewModel }
+
+func (p *restoreProgram) Init() tea.Cmd { return p.vm.Init() }
+func (p *restoreProgram) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := p.vm.Update(msg)
+	p.vm = next.(*ListViewModel)
+	return p, cmd
+}
+func (p *restoreProgram) View() string { return p.vm.View() }
+
+func TestRestoreKeyBringsBackSelectedDeletedMenuAsDraft(t *testing.T) {
+	fix := testutil.NewFixture(t)
+	menu := testutil.CreateMenu(t, fix, "Restorable")
+	_, err := fix.Menus.Delete(fix.OwnerContext(), menu.ID)
+	testutil.Ok(t, err)
+
+	reader := NewListViewModel(application.NewSession(fix.ActorContext("anonymous"), fix.App.App))
+	reader.request.IncludeDeleted = true
+	tuitest.NewDriver(t, &restoreProgram{vm: reader}).Press("b")
+	testutil.Equals(t, reader.actionEnabled(menus.ControlRestore), false)
+	_, err = fix.Menus.Get(fix.OwnerContext(), menu.ID)
+	testutil.ErrorIf(t, err == nil, "%v", "read-only actor restored the menu")
+
+	program := &restoreProgram{vm: NewListViewModel(fix.App)}
+	program.vm.request.IncludeDeleted = true
+	driver := tuitest.NewDriver(t, program)
+	testutil.Equals(t, len(program.vm.list.Items()), 1)
+	testutil.Equals(t, program.vm.actionEnabled(menus.ControlRestore), true)
+	testutil.Equals(t, program.vm.actionEnabled(menus.ControlPublish), false)
+	driver.Press("b")
+	restored, err := fix.Menus.Get(fix.OwnerContext(), menu.ID)
+	testutil.Ok(t, err)
+	testutil.ErrorIf(t, restored.DeletedAt.IsSome(), "%v", "menu was not restored")
+	testutil.Equals(t, restored.Status, models.MenuStatusDraft)
+	testutil.Equals(t, program.vm.actionEnabled(menus.ControlRestore), false)
+}
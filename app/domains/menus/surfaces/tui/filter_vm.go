@@ -19,6 +19,7 @@ type filterVM struct {
 	status     *forms.SelectField
 	expression *forms.TextField
 	limit      *forms.NumberField
+	deleted    *forms.SelectField
 	err        error
 }
 
@@ -36,8 +37,12 @@ func newFilterVM(req menus.ListRequest) *filterVM {
 		}, forms.WithInitialValue(req.Status)),
 		expression: forms.NewTextField("Expression", forms.WithInitialValue(req.Filter)),
 		limit:      forms.NewNumberField("Page size", forms.WithRequired(), forms.WithMin(1), forms.WithInitialValue(limit)),
+		deleted: forms.NewSelectField("Deleted", []forms.SelectOption{
+			{Label: "hide", Value: false},
+			{Label: "show", Value: true},
+		}, forms.WithInitialValue(req.IncludeDeleted)),
 	}
-	v.form = forms.New(styles.Standard.Form, keys.Standard.Form, v.status, v.expression, v.limit)
+	v.form = forms.New(styles.Standard.Form, keys.Standard.Form, v.status, v.expression, v.limit, v.deleted)
 	return v
 }
 
@@ -66,5 +71,6 @@ func (v *filterVM) Request() (menus.ListRequest, error) {
 	}
 	return menus.ListRequest{
 		Status: v.status.Value().(models.MenuStatus), Filter: strings.TrimSpace(fmt.Sprint(v.expression.Value())), Limit: limit,
+		IncludeDeleted: v.deleted.Value().(bool),
 	}, nil
 }
@@ -12,5 +12,8 @@ type menuItem = tui.ListItem[models.Menu]
 func newMenuItem(menu models.Menu, styles tui.ListViewStyles) menuItem {
 	status := menuStatusBadge(menu.Status, styles)
 	description := fmt.Sprintf("%s | %d drinks", status, len(menu.Items))
+	if menu.DeletedAt.IsSome() {
+		description += " | deleted"
+	}
 	return tui.NewListItem(menu, menu.Name, description, menu.Name)
 }
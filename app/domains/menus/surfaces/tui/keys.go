@@ -7,7 +7,7 @@ import (
 
 type listViewKeys struct {
 	keys.ListViewKeys
-	Tags, Publish, Draft key.Binding
+	Tags, Publish, Draft, Restore key.Binding
 }
 
 func newListViewKeys() listViewKeys {
@@ -16,5 +16,6 @@ func newListViewKeys() listViewKeys {
 		Tags:         keys.NewBinding("t", "manage tags", "t"),
 		Publish:      keys.NewBinding("p", "publish", "p"),
 		Draft:        keys.NewBinding("u", "draft", "u"),
+		Restore:      keys.NewBinding("b", "restore", "b"),
 	}
 }
@@ -167,6 +167,13 @@ func (m *ListViewModel) Interaction() tui.Interaction {
 
 func (m *ListViewModel) Update(msg tea.Msg) (tui.ViewModel, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tui.RefreshMsg:
+		if m.mode != listModeBrowsing || !m.actionEnabled(menus.ControlList) {
+			return m, nil
+		}
+		m.loading = true
+		m.err = nil
+		return m, tea.Batch(m.spinner.Init(), m.loadMenus(m.request.Cursor))
 	case tea.WindowSizeMsg:
 		m.setSize(msg.Width, msg.Height)
 		switch m.mode {
@@ -247,6 +254,13 @@ func (m *ListViewModel) Update(msg tea.Msg) (tui.ViewModel, tea.Cmd) {
 		m.taggedDialog = nil
 		m.err = msg.Err
 		return m, nil
+	case MenuRestoredMsg:
+		m.loading = true
+		m.err = nil
+		return m, tea.Batch(m.spinner.Init(), m.loadMenus(m.request.Cursor))
+	case RestoreErrorMsg:
+		m.err = msg.Err
+		return m, nil
 	case drinkChoicesLoadedMsg:
 		if msg.workflowID == m.workflowID && m.drinkPicker != nil {
 			m.drinkPicker.setChoices(msg.choices, msg.err)
@@ -440,6 +454,11 @@ func (m *ListViewModel) Update(msg tea.Msg) (tui.ViewModel, tea.Cmd) {
 				return m, nil
 			}
 			return m, m.startDraft()
+		case key.Matches(msg, m.keys.Restore):
+			if !m.actionEnabled(menus.ControlRestore) {
+				return m, nil
+			}
+			return m, m.performRestore()
 		case key.Matches(msg, m.keys.Tags):
 			if !m.actionEnabled(menus.ControlTags) {
 				return m, nil
@@ -908,6 +927,21 @@ func (m *ListViewModel) performDraft() tea.Cmd {
 	}
 }
 
+func (m *ListViewModel) performRestore() tea.Cmd {
+	menu := m.selectedMenu()
+	if menu == nil {
+		return nil
+	}
+	id := menu.ID
+	return func() tea.Msg {
+		restored, err := m.app.Menus.Restore(m.context(), id)
+		if err != nil {
+			return RestoreErrorMsg{Err: err}
+		}
+		return MenuRestoredMsg{Menu: restored}
+	}
+}
+
 func (m *ListViewModel) selectedMenu() *menusmodels.Menu {
 	item, ok := m.list.SelectedItem().(menuItem)
 	if !ok {
@@ -1011,7 +1045,7 @@ func (m *ListViewModel) startAnalysis() tea.Cmd {
 		return nil
 	}
 	m.workflowID++
-	m.mode, m.analysis, m.err = listModeAnalyzing, newAnalysisVM(), nil
+	m.mode, m.analysis, m.err = listModeAnalyzing, newAnalysisVM(m.app.Defaults().TargetMargin), nil
 	return m.analysis.input.Focus()
 }
 
@@ -1142,6 +1176,7 @@ func (m *ListViewModel) visibleBindings() []key.Binding {
 		{menus.ControlDelete, m.keys.Delete}, {menus.ControlPublish, m.keys.Publish},
 		{menus.ControlDraft, m.keys.Draft}, {menus.ControlTags, m.keys.Tags},
 		{menus.ControlAddDrink, addDrinkKey}, {menus.ControlRemoveDrink, removeDrinkKey},
+		{menus.ControlRestore, m.keys.Restore},
 	}
 	bindings := make([]key.Binding, 0, len(pairs))
 	for _, pair := range pairs {
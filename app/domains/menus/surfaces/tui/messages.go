@@ -30,6 +30,11 @@ type MenuDraftedMsg struct {
 	Menu *models.Menu
 }
 
+// MenuRestoredMsg is sent when a deleted menu has been restored as a draft.
+type MenuRestoredMsg struct {
+	Menu *models.Menu
+}
+
 // DeleteErrorMsg is sent when a delete operation fails.
 type DeleteErrorMsg struct {
 	Err error
@@ -44,3 +49,8 @@ type PublishErrorMsg struct {
 type DraftErrorMsg struct {
 	Err error
 }
+
+// RestoreErrorMsg is sent when a restore operation fails.
+type RestoreErrorMsg struct {
+	Err error
+}
@@ -40,6 +40,13 @@ type RenameErrorMsg struct {
 	Err error
 }
 
+// RenameConflictMsg is sent when the menu changed after the form was opened.
+// Latest is the stored menu to merge the form onto.
+type RenameConflictMsg struct {
+	Err    error
+	Latest *models.Menu
+}
+
 // NewRenameMenuVM builds a RenameMenuVM with input configured.
 func NewRenameMenuVM(app *app.Session, menu *models.Menu) *RenameMenuVM {
 	if menu == nil {
@@ -69,6 +76,10 @@ func (m *RenameMenuVM) Update(msg tea.Msg) (*RenameMenuVM, tea.Cmd) {
 		m.submitting = false
 		m.err = typed.Err
 		return m, nil
+	case RenameConflictMsg:
+		m.submitting = false
+		m.err = m.rebase(typed.Latest)
+		return m, nil
 	case MenuRenamedMsg:
 		m.submitting = false
 		m.err = nil
@@ -132,12 +143,18 @@ func (m *RenameMenuVM) submit() tea.Cmd {
 		ID:          m.menu.ID,
 		Name:        name,
 		Description: strings.TrimSpace(toString(m.description.Value())),
+		Version:     m.menu.Version,
 	}
 
 	return func() tea.Msg {
 		menu, err := app.RunTaggedMutation(m.app.App, m.context(), desired, func(ctx *middleware.Context) (*models.Menu, error) {
 			return m.app.Menus.Update(ctx, updated)
 		})
+		if errors.IsConflict(err) {
+			if latest, getErr := m.app.Menus.Get(m.context(), updated.ID); getErr == nil {
+				return RenameConflictMsg{Err: err, Latest: latest}
+			}
+		}
 		if err != nil {
 			return RenameErrorMsg{Err: err}
 		}
@@ -145,6 +162,35 @@ func (m *RenameMenuVM) submit() tea.Cmd {
 	}
 }
 
+// rebase moves the form onto latest after a version conflict, keeping the
+// fields the user edited and taking the stored values for the rest.
+func (m *RenameMenuVM) rebase(latest *models.Menu) error {
+	base := m.menu
+	fields := []struct {
+		field        forms.Field
+		base, latest any
+	}{
+		{m.name, base.Name, latest.Name},
+		{m.description, base.Description, latest.Description},
+		{m.tags, base.Tags.Canonical().String(), latest.Tags.Canonical().String()},
+	}
+	var contested []string
+	for _, f := range fields {
+		kept, err := forms.Rebase(f.field, f.base, f.latest)
+		if err != nil {
+			return err
+		}
+		if kept {
+			contested = append(contested, f.field.Label())
+		}
+	}
+	m.menu = latest
+	if len(contested) == 0 {
+		return errors.Conflictf("menu changed while editing; reloaded version %d, submit again to apply your edits", latest.Version)
+	}
+	return errors.Conflictf("menu changed while editing; reloaded version %d, your edits to %s replace newer values, submit again to keep them", latest.Version, strings.Join(contested, ", "))
+}
+
 func (m *RenameMenuVM) context() *middleware.Context {
 	return m.app.Context()
 }
@@ -174,10 +174,10 @@ type analysisVM struct {
 	loading bool
 }
 
-func newAnalysisVM() *analysisVM {
+func newAnalysisVM(target float64) *analysisVM {
 	input := textinput.New()
 	input.Prompt = "Target margin (0-1): "
-	input.SetValue("0.70")
+	input.SetValue(strconv.FormatFloat(target, 'f', -1, 64))
 	input.Focus()
 	return &analysisVM{input: input}
 }
@@ -15,7 +15,7 @@ func TestWorkflowResponsesIgnoreSupersededRequests(t *testing.T) {
 	vm := NewListViewModel(nil)
 	vm.workflowID = 2
 	vm.mode = listModeAnalyzing
-	vm.analysis = newAnalysisVM()
+	vm.analysis = newAnalysisVM(0.7)
 
 	vm.Update(analysisLoadedMsg{workflowID: 1, value: queries.MenuAnalytics{TotalCount: 99}})
 
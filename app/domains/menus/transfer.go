@@ -0,0 +1,27 @@
+package menus
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// TransferOwnership makes owner the current owner of a menu. The creator is
+// retained, so policies can still grant authors control after delegation.
+func (m *Module) TransferOwnership(ctx *middleware.Context, id entity.MenuID, owner cedar.EntityUID) (*models.Menu, error) {
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Menu, *models.Menu]{
+		Action: authz.ActionTransfer,
+		Request: struct {
+			ID    entity.MenuID
+			Owner cedar.EntityUID
+		}{id, owner},
+		Load: func(ctx *middleware.Context) (*models.Menu, error) {
+			return m.queries.Get(ctx, id)
+		},
+		Handle: func(ctx *middleware.Context, menu *models.Menu) (*models.Menu, error) {
+			return m.commands.TransferOwnership(ctx, menu, owner)
+		},
+	})
+}
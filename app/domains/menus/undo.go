@@ -0,0 +1,48 @@
+package menus
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/events"
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
+	"github.com/TheFellow/go-modular-monolith/pkg/undo"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// Compensations returns the menu commands undo can reverse: adding a drink is
+// reversed by removing it, and removing one by reinstating the removed item.
+func (m *Module) Compensations() []undo.Compensation {
+	return []undo.Compensation{
+		{Action: authz.ActionAddDrink, Compensate: m.reverseAddDrink},
+		{Action: authz.ActionRemoveDrink, Compensate: m.reverseRemoveDrink},
+	}
+}
+
+func (m *Module) reverseAddDrink(ctx *middleware.Context, recorded []any) ([]cedar.EntityUID, error) {
+	added, err := undo.Find[events.DrinkAddedToMenu](recorded)
+	if err != nil {
+		return nil, err
+	}
+	menu, err := m.RemoveDrink(ctx, &models.MenuPatch{MenuID: added.Menu.ID, DrinkID: added.Item.DrinkID})
+	if err != nil {
+		return nil, err
+	}
+	return []cedar.EntityUID{menu.ID.EntityUID()}, nil
+}
+
+func (m *Module) reverseRemoveDrink(ctx *middleware.Context, recorded []any) ([]cedar.EntityUID, error) {
+	removed, err := undo.Find[events.DrinkRemovedFromMenu](recorded)
+	if err != nil {
+		return nil, err
+	}
+	menu, err := m.AddDrink(ctx, &models.MenuPatch{
+		MenuID:  removed.Menu.ID,
+		DrinkID: removed.Item.DrinkID,
+		Item:    optional.Some(removed.Item),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []cedar.EntityUID{menu.ID.EntityUID()}, nil
+}
@@ -8,7 +8,8 @@ import (
 
 func (m *Module) Update(ctx *middleware.Context, menu *models.Menu) (*models.Menu, error) {
 	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Menu, *models.Menu]{
-		Action: authz.ActionUpdate,
+		Action:  authz.ActionUpdate,
+		Request: menu,
 		Load: func(ctx *middleware.Context) (*models.Menu, error) {
 			return m.queries.Get(ctx, menu.ID)
 		},
@@ -17,6 +17,7 @@ const (
 	ControlComplete actions.ID = "orders.complete"
 	ControlCancel   actions.ID = "orders.cancel"
 	ControlTags     actions.ID = "orders.tags"
+	ControlRestore  actions.ID = "orders.restore"
 )
 
 // ActionProjector produces framework-neutral order control state. Transient
@@ -46,6 +47,10 @@ func (p ActionProjector) Project(ctx context.Context, principal cedar.EntityUID,
 		return actions.Evaluate(ctx, declaration)
 	}
 	resource := selected.CedarEntity()
+	if selected.DeletedAt.IsSome() {
+		declaration.Controls = append(declaration.Controls, actions.Control{ID: ControlRestore, Permission: permission(ordersauthz.ActionRestore, resource)})
+		return actions.Evaluate(ctx, declaration)
+	}
 	declaration.Controls = append(declaration.Controls,
 		actions.Control{ID: ControlComplete, Permission: permission(ordersauthz.ActionComplete, resource), Conditions: []actions.Condition{completeCondition(selected)}},
 		actions.Control{ID: ControlCancel, Permission: permission(ordersauthz.ActionCancel, resource), Conditions: []actions.Condition{cancelCondition(selected)}},
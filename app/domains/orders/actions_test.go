@@ -3,12 +3,14 @@ package orders_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	orders "github.com/TheFellow/go-modular-monolith/app/domains/orders"
 	ordersauthz "github.com/TheFellow/go-modular-monolith/app/domains/orders/authz"
 	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
 	"github.com/TheFellow/go-modular-monolith/pkg/presentation/actions"
 	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
 	cedar "github.com/cedar-policy/cedar-go"
@@ -60,6 +62,30 @@ func TestActionProjectorKeepsListEntryPublicAndPlacementIndependent(t *testing.T
 	testutil.ErrorIf(t, !got[orders.ControlPlace].Enabled, "list denial leaked into placement")
 }
 
+func TestActionProjectorOffersOnlyRestoreForDeletedOrders(t *testing.T) {
+	t.Parallel()
+	denied := map[cedar.EntityUID]bool{}
+	projector := orders.ActionProjector{Authorize: func(_ context.Context, _ cedar.EntityUID, action cedar.EntityUID, _ cedar.Entity) error {
+		if denied[action] {
+			return errors.Permissionf("denied")
+		}
+		return nil
+	}}
+	order := &models.Order{ID: entity.NewOrderID(), Status: models.OrderStatusCompleted, DeletedAt: optional.Some(time.Now())}
+	states, err := projector.Project(context.Background(), cedar.EntityUID{}, order)
+	testutil.Ok(t, err)
+	got := actionMap(states)
+	testutil.Equals(t, len(got), 3)
+	testutil.ErrorIf(t, !got[orders.ControlRestore].Enabled, "restore = %#v", got[orders.ControlRestore])
+	_, complete := got[orders.ControlComplete]
+	testutil.ErrorIf(t, complete, "deleted order offered complete")
+
+	denied[ordersauthz.ActionRestore] = true
+	states, err = projector.Project(context.Background(), cedar.EntityUID{}, order)
+	testutil.Ok(t, err)
+	testutil.ErrorIf(t, actionMap(states)[orders.ControlRestore].Visible, "denied restore should be hidden")
+}
+
 func TestActionProjectorSurfacesEvaluatorFailure(t *testing.T) {
 	t.Parallel()
 	want := errors.New("evaluator unavailable")
@@ -16,11 +16,13 @@ import (
 var Schema string
 
 const (
-	OrderType       cedar.EntityType = "Mixology::Order"
-	ResourceType    cedar.EntityType = OrderType
-	ActionType      cedar.EntityType = "Mixology::Order::Action"
-	OrderMenuIDAttr                  = "MenuID"
-	OrderStatusAttr                  = "Status"
+	OrderType          cedar.EntityType = "Mixology::Order"
+	ResourceType       cedar.EntityType = OrderType
+	ActionType         cedar.EntityType = "Mixology::Order::Action"
+	OrderCreatedByAttr                  = "CreatedBy"
+	OrderMenuIDAttr                     = "MenuID"
+	OrderOwnerAttr                      = "Owner"
+	OrderStatusAttr                     = "Status"
 )
 
 var (
@@ -48,19 +50,26 @@ func ValidateEntity(entity cedar.Entity) error {
 var (
 	ActionCancel   = cedar.NewEntityUID(ActionType, "cancel")
 	ActionComplete = cedar.NewEntityUID(ActionType, "complete")
+	ActionDelete   = cedar.NewEntityUID(ActionType, "delete")
+	ActionExport   = cedar.NewEntityUID(ActionType, "export")
 	ActionGet      = cedar.NewEntityUID(ActionType, "get")
+	ActionImport   = cedar.NewEntityUID(ActionType, "import")
 	ActionList     = cedar.NewEntityUID(ActionType, "list")
 	ActionPlace    = cedar.NewEntityUID(ActionType, "place")
+	ActionRestore  = cedar.NewEntityUID(ActionType, "restore")
 	ActionTag      = cedar.NewEntityUID(ActionType, "tag")
+	ActionTransfer = cedar.NewEntityUID(ActionType, "transfer")
 	ActionUntag    = cedar.NewEntityUID(ActionType, "untag")
 )
 
 // Order is the Cedar-facing authorization model for Mixology::Order.
 type Order struct {
-	UID    cedar.EntityUID
-	Tags   map[string]string
-	MenuID cedar.EntityUID
-	Status string
+	UID       cedar.EntityUID
+	Tags      map[string]string
+	CreatedBy cedar.EntityUID
+	MenuID    cedar.EntityUID
+	Owner     cedar.EntityUID
+	Status    string
 }
 
 // CedarEntity converts m to the entity shape declared in schema.cedarschema.
@@ -74,8 +83,10 @@ func (m Order) CedarEntity() cedar.Entity {
 		UID:     cedar.NewEntityUID(OrderType, m.UID.ID),
 		Parents: cedar.NewEntityUIDSet(),
 		Attributes: cedar.NewRecord(cedar.RecordMap{
-			OrderMenuIDAttr: cedar.NewEntityUID("Mixology::Menu", m.MenuID.ID),
-			OrderStatusAttr: cedar.String(m.Status),
+			OrderCreatedByAttr: cedar.NewEntityUID("Mixology::Actor", m.CreatedBy.ID),
+			OrderMenuIDAttr:    cedar.NewEntityUID("Mixology::Menu", m.MenuID.ID),
+			OrderOwnerAttr:     cedar.NewEntityUID("Mixology::Actor", m.Owner.ID),
+			OrderStatusAttr:    cedar.String(m.Status),
 		}),
 		Tags: cedar.NewRecord(tags),
 	}
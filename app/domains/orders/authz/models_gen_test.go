@@ -16,10 +16,12 @@ func TestOrderCedarEntity(t *testing.T) {
 	t.Parallel()
 
 	model := moduleauthz.Order{
-		UID:    cedar.NewEntityUID("Wrong::Type", "test-id"),
-		Tags:   map[string]string{"audience": "members", "featured": ""},
-		MenuID: cedar.NewEntityUID("Mixology::Menu", "test-menuid"),
-		Status: "test-status",
+		UID:       cedar.NewEntityUID("Wrong::Type", "test-id"),
+		Tags:      map[string]string{"audience": "members", "featured": ""},
+		CreatedBy: cedar.NewEntityUID("Mixology::Actor", "test-createdby"),
+		MenuID:    cedar.NewEntityUID("Mixology::Menu", "test-menuid"),
+		Owner:     cedar.NewEntityUID("Mixology::Actor", "test-owner"),
+		Status:    "test-status",
 	}
 
 	got := model.CedarEntity()
@@ -27,8 +29,10 @@ func TestOrderCedarEntity(t *testing.T) {
 		UID:     cedar.NewEntityUID(moduleauthz.OrderType, "test-id"),
 		Parents: cedar.NewEntityUIDSet(),
 		Attributes: cedar.NewRecord(cedar.RecordMap{
-			moduleauthz.OrderMenuIDAttr: cedar.NewEntityUID("Mixology::Menu", "test-menuid"),
-			moduleauthz.OrderStatusAttr: cedar.String("test-status"),
+			moduleauthz.OrderCreatedByAttr: cedar.NewEntityUID("Mixology::Actor", "test-createdby"),
+			moduleauthz.OrderMenuIDAttr:    cedar.NewEntityUID("Mixology::Menu", "test-menuid"),
+			moduleauthz.OrderOwnerAttr:     cedar.NewEntityUID("Mixology::Actor", "test-owner"),
+			moduleauthz.OrderStatusAttr:    cedar.String("test-status"),
 		}),
 		Tags: cedar.NewRecord(cedar.RecordMap{
 			"audience": cedar.String("members"),
@@ -8,7 +8,8 @@ import (
 
 func (m *Module) Cancel(ctx *middleware.Context, order *models.Order) (*models.Order, error) {
 	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Order, *models.Order]{
-		Action: authz.ActionCancel,
+		Action:  authz.ActionCancel,
+		Request: order,
 		Load: func(ctx *middleware.Context) (*models.Order, error) {
 			return m.queries.Get(ctx, order.ID)
 		},
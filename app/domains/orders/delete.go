@@ -0,0 +1,20 @@
+package orders
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// Delete soft-deletes a completed or cancelled order; Restore brings it back.
+func (m *Module) Delete(ctx *middleware.Context, id entity.OrderID) (*models.Order, error) {
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Order, *models.Order]{
+		Action:  authz.ActionDelete,
+		Request: id,
+		Load: func(ctx *middleware.Context) (*models.Order, error) {
+			return m.queries.Get(ctx, id)
+		},
+		Handle: m.commands.Delete,
+	})
+}
@@ -0,0 +1,13 @@
+package events
+
+import (
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+)
+
+// OrderDeleted reports a closed order removed from the order history.
+type OrderDeleted struct {
+	Order     models.Order
+	DeletedAt time.Time
+}
@@ -1,9 +1,42 @@
 package events
 
 import (
+	"time"
+
 	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
 )
 
 type OrderPlaced struct {
 	Order models.Order
 }
+
+// Topic and Payload publish OrderPlaced through the outbox.
+func (e OrderPlaced) Topic() string { return "orders.order_placed" }
+
+func (e OrderPlaced) Payload() any {
+	items := make([]orderPlacedItem, 0, len(e.Order.Items))
+	for _, item := range e.Order.Items {
+		items = append(items, orderPlacedItem{DrinkID: item.DrinkID.String(), Quantity: item.Quantity, Notes: item.Notes})
+	}
+	return orderPlacedPayload{
+		OrderID:   e.Order.ID.String(),
+		MenuID:    e.Order.MenuID.String(),
+		Items:     items,
+		Notes:     e.Order.Notes,
+		CreatedAt: e.Order.CreatedAt.UTC(),
+	}
+}
+
+type orderPlacedPayload struct {
+	OrderID   string            `json:"order_id"`
+	MenuID    string            `json:"menu_id"`
+	Items     []orderPlacedItem `json:"items"`
+	Notes     string            `json:"notes,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+type orderPlacedItem struct {
+	DrinkID  string `json:"drink_id"`
+	Quantity int    `json:"quantity"`
+	Notes    string `json:"notes,omitempty"`
+}
@@ -0,0 +1,13 @@
+package events
+
+import (
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+)
+
+// OrderRestored reports a soft-deleted, closed order returning to history.
+type OrderRestored struct {
+	Order      models.Order
+	RestoredAt time.Time
+}
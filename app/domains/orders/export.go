@@ -0,0 +1,27 @@
+package orders
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/authz"
+	ordersdao "github.com/TheFellow/go-modular-monolith/app/domains/orders/internal/dao"
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// ExportAll returns every order in any status, deleted ones included, with
+// its tags.
+func (m *Module) ExportAll(ctx *middleware.Context) ([]*models.Order, error) {
+	return middleware.RunCollectQuery(m.pipeline, ctx, authz.ActionExport, m.queries.List, ordersdao.ListFilter{IncludeDeleted: true})
+}
+
+// Import recreates an order exported from another database, keeping its ID,
+// status, usage snapshot, timestamps and tags.
+func (m *Module) Import(ctx *middleware.Context, order *models.Order) (*models.Order, error) {
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Order, *models.Order]{
+		Action:  authz.ActionImport,
+		Request: order,
+		Load: func(*middleware.Context) (*models.Order, error) {
+			return order, nil
+		},
+		Handle: m.commands.Import,
+	})
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	ordersevents "github.com/TheFellow/go-modular-monolith/app/domains/orders/events"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
+)
+
+// OrderCompleted observes how long an order took from placement to
+// completion. The observation waits for the completing transaction to commit,
+// so dry runs and rolled-back completions are not measured.
+type OrderCompleted struct{}
+
+func NewOrderCompleted(_ *store.Store, _ tag.Repository) *OrderCompleted {
+	return &OrderCompleted{}
+}
+
+func (h *OrderCompleted) Handle(ctx *middleware.HandlerContext, e ordersevents.OrderCompleted) error {
+	completedAt, ok := e.Order.CompletedAt.Unwrap()
+	if !ok || e.Order.CreatedAt.IsZero() {
+		return nil
+	}
+	seconds := completedAt.Sub(e.Order.CreatedAt).Seconds()
+	duration := telemetry.FromContext(ctx).Histogram(telemetry.MetricOrderFulfillmentDuration)
+	observe := func() { duration.ObserveContext(ctx, seconds) }
+	if tx, ok := ctx.Transaction(); ok {
+		store.AfterCommit(tx, observe)
+		return nil
+	}
+	observe()
+	return nil
+}
@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/events"
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
+)
+
+// Delete removes a closed order from the order history. An open order still
+// holds reservations and must be completed or cancelled first, which is also
+// what lets Restore return it as recorded.
+func (c *Commands) Delete(ctx *middleware.Context, order *models.Order) (*models.Order, error) {
+	if order == nil {
+		return nil, errors.Invalidf("order is required")
+	}
+	if order.Status != models.OrderStatusCompleted && order.Status != models.OrderStatusCancelled {
+		return nil, errors.FailedPreconditionf("order %s is %s; complete or cancel it before deleting it", order.ID.String(), order.Status)
+	}
+
+	now := time.Now().UTC()
+	deleted := *order
+	deleted.DeletedAt = optional.Some(now)
+	if err := c.dao.Update(ctx, &deleted); err != nil {
+		return nil, err
+	}
+
+	ctx.TouchEntity(deleted.ID.EntityUID())
+	ctx.AddEvent(events.OrderDeleted{
+		Order:     deleted,
+		DeletedAt: now,
+	})
+	return &deleted, nil
+}
@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// Import recreates an exported order in its recorded status. Stock is not
+// reserved here; the export carries Inventory's reservations, which are
+// imported as they were.
+func (c *Commands) Import(ctx *middleware.Context, order *models.Order) (*models.Order, error) {
+	if order == nil {
+		return nil, errors.Invalidf("order is required")
+	}
+	if order.ID.IsZero() {
+		return nil, errors.Invalidf("id is required for import")
+	}
+	if err := order.Validate(); err != nil {
+		return nil, errors.Invalidf("order %s: %w", order.ID.String(), err)
+	}
+
+	imported := *order
+	imported.Version = max(imported.Version, 1)
+	if err := c.dao.Import(ctx, imported); err != nil {
+		return nil, err
+	}
+	ctx.TouchEntity(imported.ID.EntityUID())
+	return &imported, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/orders/events"
 	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/ownership"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
 	"github.com/TheFellow/go-modular-monolith/pkg/optional"
@@ -60,6 +61,7 @@ func (c *Commands) Place(ctx *middleware.Context, order *models.Order) (*models.
 	created := *order
 	created.ID = entity.NewOrderID()
 	created.Status = models.OrderStatusPending
+	created.Ownership = ownership.New(ctx.Principal())
 	created.CreatedAt = now
 	created.CompletedAt = optional.None[time.Time]()
 	usage, err := c.fulfillmentSnapshot(ctx, created)
@@ -72,7 +74,7 @@ func (c *Commands) Place(ctx *middleware.Context, order *models.Order) (*models.
 		return nil, err
 	}
 
-	if err := c.dao.Insert(ctx, created); err != nil {
+	if err := c.dao.Insert(ctx, &created); err != nil {
 		return nil, err
 	}
 
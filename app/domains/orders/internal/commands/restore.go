@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/events"
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
+)
+
+// Restore returns a soft-deleted order to the order history. Only completed
+// and cancelled orders qualify: an open order's reservations do not survive
+// its deletion, so it could not be fulfilled as recorded.
+func (c *Commands) Restore(ctx *middleware.Context, order *models.Order) (*models.Order, error) {
+	if order == nil {
+		return nil, errors.Invalidf("order is required")
+	}
+	if !order.DeletedAt.IsSome() {
+		return nil, errors.FailedPreconditionf("order %s is not deleted", order.ID.String())
+	}
+	if order.Status != models.OrderStatusCompleted && order.Status != models.OrderStatusCancelled {
+		return nil, errors.FailedPreconditionf("order %s is %s; only completed or cancelled orders can be restored", order.ID.String(), order.Status)
+	}
+
+	restored := *order
+	restored.DeletedAt = optional.None[time.Time]()
+	if err := c.dao.Update(ctx, &restored); err != nil {
+		return nil, err
+	}
+
+	ctx.TouchEntity(restored.ID.EntityUID())
+	ctx.AddEvent(events.OrderRestored{
+		Order:      restored,
+		RestoredAt: time.Now().UTC(),
+	})
+	return &restored, nil
+}
@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+func (c *Commands) TransferOwnership(ctx *middleware.Context, order *models.Order, owner cedar.EntityUID) (*models.Order, error) {
+	if order == nil {
+		return nil, errors.Invalidf("order is required")
+	}
+	if order.ID.IsZero() {
+		return nil, errors.Invalidf("id is required")
+	}
+
+	transferred, err := order.Ownership.TransferTo(owner)
+	if err != nil {
+		return nil, err
+	}
+	updated := *order
+	updated.Ownership = transferred
+
+	if err := c.dao.Update(ctx, &updated); err != nil {
+		return nil, err
+	}
+
+	ctx.TouchEntity(updated.ID.EntityUID())
+	return &updated, nil
+}
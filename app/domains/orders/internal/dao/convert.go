@@ -7,6 +7,7 @@ import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/ownership"
 	"github.com/TheFellow/go-modular-monolith/pkg/optional"
 )
 
@@ -43,10 +44,13 @@ func toRow(o models.Order) OrderRow {
 		IngredientUsage:    usage,
 		BlockedIngredients: blockedIngredients,
 		Status:             string(o.Status),
+		CreatedBy:          o.Ownership.CreatedByID(),
+		Owner:              o.Ownership.OwnerID(),
 		CreatedAt:          o.CreatedAt,
 		CompletedAt:        completedAt,
 		Notes:              o.Notes,
 		DeletedAt:          deletedAt,
+		Version:            o.Version,
 	}
 }
 
@@ -98,9 +102,11 @@ func toModel(r OrderRow) models.Order {
 		IngredientUsage:    usage,
 		BlockedIngredients: blocked,
 		Status:             models.OrderStatus(r.Status),
+		Ownership:          ownership.Restore(r.CreatedBy, r.Owner),
 		CreatedAt:          r.CreatedAt,
 		CompletedAt:        completedAt,
 		Notes:              r.Notes,
 		DeletedAt:          deletedAt,
+		Version:            r.Version,
 	}
 }
@@ -7,13 +7,36 @@ import (
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 	cedar "github.com/cedar-policy/cedar-go"
-	"github.com/mjl-/bstore"
 )
 
 func (d *DAO) Get(ctx store.Context, id entity.OrderID) (*models.Order, error) {
+	order, err := d.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.DeletedAt.IsSome() {
+		return nil, errors.NotFoundf("order %s not found", id.String())
+	}
+	return order, nil
+}
+
+// GetDeleted loads a soft-deleted order. Active and missing orders are not
+// found.
+func (d *DAO) GetDeleted(ctx store.Context, id entity.OrderID) (*models.Order, error) {
+	order, err := d.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !order.DeletedAt.IsSome() {
+		return nil, errors.NotFoundf("deleted order %s not found", id.String())
+	}
+	return order, nil
+}
+
+func (d *DAO) get(ctx store.Context, id entity.OrderID) (*models.Order, error) {
 	var row OrderRow
 	var tagsByTarget map[cedar.EntityUID]tag.Tags
-	err := d.store.ReadContext(ctx, func(tx *bstore.Tx) error {
+	err := d.store.ReadContext(ctx, func(tx *store.Tx) error {
 		row = OrderRow{ID: id.String()}
 		if err := tx.Get(&row); err != nil {
 			return err
@@ -25,9 +48,6 @@ func (d *DAO) Get(ctx store.Context, id entity.OrderID) (*models.Order, error) {
 	if err != nil {
 		return nil, store.MapError(err, "order %s not found", id.String())
 	}
-	if row.DeletedAt != nil {
-		return nil, errors.NotFoundf("order %s not found", id.String())
-	}
 	order := toModel(row)
 	order.Tags = tagsByTarget[id.EntityUID()]
 	return &order, nil
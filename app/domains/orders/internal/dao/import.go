@@ -0,0 +1,23 @@
+package dao
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+// Import stores an order exactly as another database held it, including its
+// ingredient usage snapshot, timestamps and tags. A taken ID conflicts.
+func (d *DAO) Import(ctx store.Context, order models.Order) error {
+	return store.Write(ctx, func(tx *store.Tx) error {
+		row := toRow(order)
+		row.Version = max(row.Version, 1)
+		if err := tx.Insert(&row); err != nil {
+			return store.MapError(err, "import order %s", order.ID.String())
+		}
+		if len(order.Tags) == 0 {
+			return nil
+		}
+		_, err := d.tags.Replace(ctx, order.ID.EntityUID(), order.Tags)
+		return err
+	})
+}
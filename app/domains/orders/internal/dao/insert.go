@@ -3,12 +3,17 @@ package dao
 import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
-	"github.com/mjl-/bstore"
 )
 
-func (d *DAO) Insert(ctx store.Context, order models.Order) error {
-	return store.Write(ctx, func(tx *bstore.Tx) error {
-		row := toRow(order)
-		return store.MapError(tx.Insert(&row), "insert order %s", order.ID.String())
+// Insert stores a new order at version 1 and records that on order.
+func (d *DAO) Insert(ctx store.Context, order *models.Order) error {
+	return store.Write(ctx, func(tx *store.Tx) error {
+		row := toRow(*order)
+		row.Version = 1
+		if err := tx.Insert(&row); err != nil {
+			return store.MapError(err, "insert order %s", order.ID.String())
+		}
+		order.Version = row.Version
+		return nil
 	})
 }
@@ -0,0 +1,22 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/migrate"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+// Migrations lists the orders domain's data migrations in version order.
+func Migrations() []migrate.Migration {
+	return []migrate.Migration{
+		{Version: 1, Name: "backfill row versions", Up: backfillVersions},
+	}
+}
+
+// backfillVersions gives orders placed before optimistic concurrency
+// version 1, matching orders placed since.
+func backfillVersions(_ context.Context, tx *store.Tx) error {
+	_, err := store.QueryTx[OrderRow](tx).FilterEqual("Version", int64(0)).UpdateField("Version", int64(1))
+	return err
+}
@@ -13,10 +13,13 @@ type OrderRow struct {
 	IngredientUsage    []IngredientUsageRow
 	BlockedIngredients []string
 	Status             string    `bstore:"index"`
+	Owner              string    `bstore:"index"`
 	CreatedAt          time.Time `bstore:"index"`
+	CreatedBy          string
 	CompletedAt        *time.Time
 	Notes              string
 	DeletedAt          *time.Time
+	Version            int64
 }
 
 type IngredientUsageRow struct {
@@ -3,12 +3,25 @@ package dao
 import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
-	"github.com/mjl-/bstore"
 )
 
-func (d *DAO) Update(ctx store.Context, order models.Order) error {
-	return store.Write(ctx, func(tx *bstore.Tx) error {
-		row := toRow(order)
-		return store.MapError(tx.Update(&row), "update order %s", order.ID.String())
+// Update replaces the stored order only while it is still at order.Version,
+// then advances order.Version to the version written.
+func (d *DAO) Update(ctx store.Context, order *models.Order) error {
+	return store.Write(ctx, func(tx *store.Tx) error {
+		row := toRow(*order)
+		current := OrderRow{ID: row.ID}
+		if err := tx.Get(&current); err != nil {
+			return store.MapError(err, "update order %s", order.ID.String())
+		}
+		if err := store.CheckVersion(current.Version, order.Version, "order %s", order.ID.String()); err != nil {
+			return err
+		}
+		row.Version++
+		if err := tx.Update(&row); err != nil {
+			return store.MapError(err, "update order %s", order.ID.String())
+		}
+		order.Version = row.Version
+		return nil
 	})
 }
@@ -19,6 +19,9 @@ type ListRequest struct {
 	Filter string
 	Cursor paging.Cursor
 	Limit  int
+
+	// IncludeDeleted lists soft-deleted orders alongside live ones.
+	IncludeDeleted bool
 }
 
 func (m *Module) List(ctx *middleware.Context, req ListRequest) (paging.Page[*models.Order], error) {
@@ -34,7 +37,7 @@ func (m *Module) List(ctx *middleware.Context, req ListRequest) (paging.Page[*mo
 			return paging.Page[*models.Order]{}, err
 		}
 	}
-	filter := ordersdao.ListFilter{Status: req.Status, MenuID: req.MenuID, Expression: expression}
+	filter := ordersdao.ListFilter{Status: req.Status, MenuID: req.MenuID, Expression: expression, IncludeDeleted: req.IncludeDeleted}
 	return middleware.RunPageQuery(
 		m.pipeline, ctx, authz.ActionList,
 		func(ctx store.Context, filter ordersdao.ListFilter, cursor paging.Cursor) iter.Seq2[*models.Order, error] {
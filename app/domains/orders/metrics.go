@@ -0,0 +1,29 @@
+package orders
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/internal/dao"
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// openStatuses are the order states counted by the open orders gauge.
+var openStatuses = []models.OrderStatus{models.OrderStatusPending, models.OrderStatusBlocked}
+
+// CollectMetrics refreshes the open orders gauge with the number of pending
+// and blocked orders. Like the other domain collectors it reads without
+// authorization and publishes counts only.
+func (m *Module) CollectMetrics(ctx *middleware.Context) error {
+	counts := make(map[models.OrderStatus]int, len(openStatuses))
+	for _, status := range openStatuses {
+		for _, err := range m.queries.List(ctx, dao.ListFilter{Status: status}) {
+			if err != nil {
+				return err
+			}
+			counts[status]++
+		}
+	}
+	for _, status := range openStatuses {
+		m.openOrders.Set(float64(counts[status]), string(status))
+	}
+	return nil
+}
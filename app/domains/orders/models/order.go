@@ -6,6 +6,7 @@ import (
 	orderauthz "github.com/TheFellow/go-modular-monolith/app/domains/orders/authz"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/ownership"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/optional"
@@ -25,11 +26,14 @@ type Order struct {
 	IngredientUsage    []IngredientUsage
 	BlockedIngredients []entity.IngredientID
 	Status             OrderStatus
+	Ownership          ownership.Ownership
 	CreatedAt          time.Time
 	CompletedAt        optional.Value[time.Time]
 	Notes              string
 	DeletedAt          optional.Value[time.Time]
 	Tags               tag.Tags
+	// Version is bumped on every write to the order.
+	Version int64
 }
 
 func (o Order) EntityUID() cedar.EntityUID {
@@ -41,6 +45,7 @@ func (o *Order) SetTags(tags tag.Tags) { o.Tags = tags }
 func (o Order) CedarEntity() cedar.Entity {
 	return orderauthz.Order{
 		UID: o.ID.EntityUID(), MenuID: o.MenuID.EntityUID(), Status: string(o.Status), Tags: o.Tags.Map(),
+		CreatedBy: o.Ownership.CreatedBy, Owner: o.Ownership.Owner,
 	}.CedarEntity()
 }
 
@@ -10,7 +10,9 @@ import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/tagging"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/migrate"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
 )
 
 type Module struct {
@@ -18,6 +20,8 @@ type Module struct {
 	menus    *menusqueries.Queries
 	commands *commands.Commands
 	pipeline *middleware.Pipeline
+
+	openOrders telemetry.Gauge
 }
 
 func NewModule(ctx context.Context, s *store.Store, tags tag.Repository, targets *tagging.Registry, pipeline *middleware.Pipeline) *Module {
@@ -27,7 +31,14 @@ func NewModule(ctx context.Context, s *store.Store, tags tag.Repository, targets
 		menus:    menusqueries.New(s, tags),
 		commands: commands.New(s, tags),
 		pipeline: pipeline,
+
+		openOrders: telemetry.FromContext(ctx).Gauge(telemetry.MetricOrdersOpen, telemetry.LabelStatus),
 	}
 	m.registerTagTarget(targets)
 	return m
 }
+
+// Migrations returns the orders domain's data migrations for app.New to apply.
+func Migrations() migrate.Domain {
+	return migrate.Domain{Name: "orders", Migrations: dao.Migrations()}
+}
@@ -0,0 +1,58 @@
+package orders_test
+
+import (
+	"testing"
+
+	drinksM "github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	ingredientsM "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/ownership"
+	"github.com/TheFellow/go-modular-monolith/pkg/authn"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestOwnership_PlacerCanHandOverOrderToSommelier(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	bartender := f.ActorContext("bartender")
+	sommelier := f.ActorContext("sommelier")
+
+	base := testutil.CreateIngredient(t, f, ingredientsM.Ingredient{
+		Name: "Handover Base", Category: ingredientsM.CategoryOther, Unit: measurement.UnitOz,
+	})
+	drink := testutil.CreateDrink(t, f, drinksM.Drink{
+		Name:     "Handover Drink",
+		Category: drinksM.DrinkCategoryCocktail,
+		Glass:    drinksM.GlassTypeCoupe,
+		Recipe: drinksM.Recipe{
+			Ingredients: []drinksM.RecipeIngredient{
+				{IngredientID: base.ID, Amount: measurement.MustAmount(1.0, measurement.UnitOz)},
+			},
+			Steps: []string{"Shake"},
+		},
+	})
+	menu := testutil.CreateMenu(t, f, "Handover Menu", testutil.WithDrink(drink), testutil.Published())
+	testutil.PlaceOrder(t, f, models.Order{MenuID: menu.ID, Items: []models.OrderItem{{DrinkID: drink.ID, Quantity: 1}}})
+
+	placed, err := f.Orders.Place(bartender, &models.Order{
+		MenuID: menu.ID,
+		Items:  []models.OrderItem{{DrinkID: drink.ID, Quantity: 1}},
+	})
+	testutil.Ok(t, err)
+	testutil.Equals(t, placed.Ownership, ownership.New(authn.Bartender()))
+
+	_, err = f.Orders.Cancel(sommelier, placed)
+	testutil.ErrorIsPermission(t, err)
+	_, err = f.Orders.TransferOwnership(sommelier, placed.ID, authn.Sommelier())
+	testutil.ErrorIsPermission(t, err)
+
+	transferred, err := f.Orders.TransferOwnership(bartender, placed.ID, authn.Sommelier())
+	testutil.Ok(t, err)
+	testutil.Equals(t, transferred.Ownership, ownership.Ownership{CreatedBy: authn.Bartender(), Owner: authn.Sommelier()})
+
+	cancelled, err := f.Orders.Cancel(sommelier, placed)
+	testutil.Ok(t, err)
+	testutil.Equals(t, cancelled.Status, models.OrderStatusCancelled)
+	testutil.Equals(t, cancelled.Ownership, transferred.Ownership)
+}
@@ -141,6 +141,24 @@ func TestPermissions_Orders(t *testing.T) {
 				wantCancelStatus = ordersM.OrderStatusCancelled
 			}
 			testutil.Equals(t, gotCancel.Status, wantCancelStatus)
+
+			// Deleting and restoring closed orders is part of managing them.
+			closed, err := a.Orders.Cancel(owner, &ordersM.Order{ID: readOrder.ID})
+			testutil.Ok(t, err)
+			_, err = a.Orders.Delete(ctx, closed.ID)
+			if tc.canManage {
+				testutil.Ok(t, err)
+			} else {
+				testutil.ErrorIsPermission(t, err)
+				_, err = a.Orders.Delete(owner, closed.ID)
+				testutil.Ok(t, err)
+			}
+			_, err = a.Orders.Restore(ctx, closed.ID)
+			if tc.canManage {
+				testutil.Ok(t, err)
+			} else {
+				testutil.ErrorIsPermission(t, err)
+			}
 		})
 	}
 }
@@ -8,7 +8,8 @@ import (
 
 func (m *Module) Place(ctx *middleware.Context, order *models.Order) (*models.Order, error) {
 	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Order, *models.Order]{
-		Action: authz.ActionPlace,
+		Action:  authz.ActionPlace,
+		Request: order,
 		Load: func(*middleware.Context) (*models.Order, error) {
 			return order, nil
 		},
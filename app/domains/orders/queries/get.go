@@ -16,3 +16,8 @@ func (q *Queries) Get(ctx store.Context, id entity.OrderID) (*models.Order, erro
 	}
 	return o, nil
 }
+
+// GetDeleted loads a soft-deleted order for restore.
+func (q *Queries) GetDeleted(ctx store.Context, id entity.OrderID) (*models.Order, error) {
+	return q.dao.GetDeleted(ctx, id)
+}
@@ -0,0 +1,20 @@
+package orders
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// Restore brings back a soft-deleted closed order with the tags it had.
+func (m *Module) Restore(ctx *middleware.Context, id entity.OrderID) (*models.Order, error) {
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Order, *models.Order]{
+		Action:  authz.ActionRestore,
+		Request: id,
+		Load: func(ctx *middleware.Context) (*models.Order, error) {
+			return m.queries.GetDeleted(ctx, id)
+		},
+		Handle: m.commands.Restore,
+	})
+}
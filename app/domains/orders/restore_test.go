@@ -0,0 +1,81 @@
+package orders_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders"
+	ordersauthz "github.com/TheFellow/go-modular-monolith/app/domains/orders/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestOrders_RestoreOnlyReturnsClosedOrders(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	ctx := f.OwnerContext()
+	deletedAt := optional.Some(time.Now().UTC())
+	items := []models.OrderItem{{DrinkID: entity.NewDrinkID(), Quantity: 1}}
+
+	completed, err := f.Orders.Import(ctx, &models.Order{
+		ID: entity.NewOrderID(), MenuID: entity.NewMenuID(), Items: items, Status: models.OrderStatusCompleted,
+		CreatedAt: time.Now().UTC(), DeletedAt: deletedAt,
+	})
+	testutil.Ok(t, err)
+	pending, err := f.Orders.Import(ctx, &models.Order{
+		ID: entity.NewOrderID(), MenuID: entity.NewMenuID(), Items: items, Status: models.OrderStatusPending,
+		CreatedAt: time.Now().UTC(), DeletedAt: deletedAt,
+	})
+	testutil.Ok(t, err)
+
+	count, err := f.Orders.Count(ctx, orders.ListRequest{IncludeDeleted: true})
+	testutil.Ok(t, err)
+	testutil.Equals(t, count, 2)
+
+	_, err = f.Orders.Restore(ctx, pending.ID)
+	testutil.ErrorIsFailedPrecondition(t, err)
+
+	restored, err := f.Orders.Restore(ctx, completed.ID)
+	testutil.Ok(t, err)
+	testutil.ErrorIf(t, restored.DeletedAt.IsSome(), "expected DeletedAt to be cleared")
+	got, err := f.Orders.Get(ctx, completed.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, got.Status, models.OrderStatusCompleted)
+	testutil.AuditTouches(t, f.LatestAuditEntry(ordersauthz.ActionRestore), completed.ID.EntityUID())
+}
+
+func TestOrders_DeleteOnlyRemovesClosedOrdersAndRestoreReturnsThem(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	ctx := f.ActorContext("bartender")
+	items := []models.OrderItem{{DrinkID: entity.NewDrinkID(), Quantity: 1}}
+
+	pending, err := f.Orders.Import(f.OwnerContext(), &models.Order{
+		ID: entity.NewOrderID(), MenuID: entity.NewMenuID(), Items: items, Status: models.OrderStatusPending,
+		CreatedAt: time.Now().UTC(),
+	})
+	testutil.Ok(t, err)
+	cancelled, err := f.Orders.Import(f.OwnerContext(), &models.Order{
+		ID: entity.NewOrderID(), MenuID: entity.NewMenuID(), Items: items, Status: models.OrderStatusCancelled,
+		CreatedAt: time.Now().UTC(),
+	})
+	testutil.Ok(t, err)
+
+	_, err = f.Orders.Delete(ctx, pending.ID)
+	testutil.ErrorIsFailedPrecondition(t, err)
+
+	deleted, err := f.Orders.Delete(ctx, cancelled.ID)
+	testutil.Ok(t, err)
+	testutil.IsTrue(t, deleted.DeletedAt.IsSome())
+	_, err = f.Orders.Get(ctx, cancelled.ID)
+	testutil.ErrorIsNotFound(t, err)
+	testutil.AuditTouches(t, f.LatestAuditEntry(ordersauthz.ActionDelete), cancelled.ID.EntityUID())
+
+	restored, err := f.Orders.Restore(ctx, cancelled.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, restored.Status, models.OrderStatusCancelled)
+	_, err = f.Orders.Get(ctx, cancelled.ID)
+	testutil.Ok(t, err)
+}
@@ -19,7 +19,9 @@ type OrderRow struct {
 	TotalQuantity int                  `table:"TOTAL_QUANTITY" json:"total_quantity"`
 	CreatedAt     string               `table:"CREATED_AT" json:"created_at"`
 	CompletedAt   string               `table:"COMPLETED_AT" json:"completed_at,omitempty"`
+	DeletedAt     string               `table:"DELETED_AT" json:"deleted_at,omitempty"`
 	Tags          tag.CanonicalStrings `table:"TAGS" json:"tags"`
+	Version       int64                `table:"-" json:"version,omitempty"`
 }
 
 type OrderDetail struct {
@@ -28,9 +30,13 @@ type OrderDetail struct {
 	Status             string               `table:"-" json:"status"`
 	CreatedAt          string               `table:"-" json:"created_at"`
 	CompletedAt        string               `table:"-" json:"completed_at,omitempty"`
+	DeletedAt          string               `table:"-" json:"deleted_at,omitempty"`
 	Notes              string               `table:"-" json:"notes,omitempty"`
+	CreatedBy          string               `table:"-" json:"created_by,omitempty"`
+	Owner              string               `table:"-" json:"owner,omitempty"`
 	Tags               tag.CanonicalStrings `table:"-" json:"tags"`
 	BlockedIngredients []string             `table:"-" json:"blocked_ingredients,omitempty"`
+	Version            int64                `table:"-" json:"version,omitempty"`
 }
 
 type OrderItemRow struct {
@@ -65,6 +71,10 @@ func ToOrderRow(o *models.Order) OrderRow {
 	if t, ok := o.CompletedAt.Unwrap(); ok {
 		completedAt = formatTime(t)
 	}
+	var deletedAt string
+	if t, ok := o.DeletedAt.Unwrap(); ok {
+		deletedAt = formatTime(t)
+	}
 	var totalQuantity int
 	for _, item := range o.Items {
 		totalQuantity += item.Quantity
@@ -77,7 +87,9 @@ func ToOrderRow(o *models.Order) OrderRow {
 		TotalQuantity: totalQuantity,
 		CreatedAt:     formatTime(o.CreatedAt),
 		CompletedAt:   completedAt,
+		DeletedAt:     deletedAt,
 		Tags:          o.Tags.Canonical(),
+		Version:       o.Version,
 	}
 }
 
@@ -97,6 +109,10 @@ func ToOrderDetail(o *models.Order) OrderDetail {
 	if t, ok := o.CompletedAt.Unwrap(); ok {
 		completed = formatTime(t)
 	}
+	var deleted string
+	if t, ok := o.DeletedAt.Unwrap(); ok {
+		deleted = formatTime(t)
+	}
 	blocked := make([]string, 0, len(o.BlockedIngredients))
 	for _, id := range o.BlockedIngredients {
 		blocked = append(blocked, id.String())
@@ -107,9 +123,13 @@ func ToOrderDetail(o *models.Order) OrderDetail {
 		Status:             string(o.Status),
 		CreatedAt:          formatTime(o.CreatedAt),
 		CompletedAt:        completed,
+		DeletedAt:          deleted,
 		Notes:              o.Notes,
+		CreatedBy:          o.Ownership.CreatedByID(),
+		Owner:              o.Ownership.OwnerID(),
 		Tags:               o.Tags.Canonical(),
 		BlockedIngredients: blocked,
+		Version:            o.Version,
 	}
 }
 
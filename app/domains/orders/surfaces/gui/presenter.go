@@ -37,6 +37,8 @@ type Filter struct {
 	Status     models.OrderStatus
 	Expression string
 	Limit      int
+	// IncludeDeleted lists soft-deleted orders so they can be restored.
+	IncludeDeleted bool
 }
 
 type MenuOption struct {
@@ -86,6 +88,7 @@ type State struct {
 	Drinks                                            []DrinkOption
 	Err                                               error
 	CanList, CanPlace, CanComplete, CanCancel, CanTag bool
+	CanRestore                                        bool
 	Actions                                           map[actions.ID]actions.State
 	Dirty                                             bool
 }
@@ -151,7 +154,7 @@ func (p *Presenter) loadPage(appendPage bool) {
 	f, cursor := p.state.Filter, p.state.Cursor
 	p.load.LoadContext(p.app.Context(), func(ctx context.Context) (listResult, error) {
 		op := p.app.ContextFrom(ctx)
-		page, err := p.app.Orders.List(op, orders.ListRequest{Status: f.Status, Filter: strings.TrimSpace(f.Expression), Cursor: cursor, Limit: f.Limit})
+		page, err := p.app.Orders.List(op, orders.ListRequest{Status: f.Status, Filter: strings.TrimSpace(f.Expression), Cursor: cursor, Limit: f.Limit, IncludeDeleted: f.IncludeDeleted})
 		if err != nil {
 			return listResult{}, err
 		}
@@ -232,17 +235,17 @@ func (p *Presenter) Select(index int) {
 	p.publish()
 }
 
-func (p *Presenter) ListPermissions(index int) (complete, cancel, tags bool) {
+func (p *Presenter) ListPermissions(index int) (complete, cancel, tags, restore bool) {
 	if index < 0 || index >= len(p.state.Rows) || !actionEnabled(p.state.Actions, orders.ControlList) {
-		return false, false, false
+		return false, false, false, false
 	}
 	states, err := p.projector.Project(p.app.Context(), p.app.Context().Principal(), &p.state.Rows[index].Order)
 	if err != nil {
 		p.fail(err)
-		return false, false, false
+		return false, false, false, false
 	}
 	projected := indexActions(states)
-	return actionEnabled(projected, orders.ControlComplete), actionEnabled(projected, orders.ControlCancel), actionEnabled(projected, orders.ControlTags)
+	return actionEnabled(projected, orders.ControlComplete), actionEnabled(projected, orders.ControlCancel), actionEnabled(projected, orders.ControlTags), actionEnabled(projected, orders.ControlRestore)
 }
 
 // Back returns to the exact list state from which the order was opened.
@@ -585,6 +588,15 @@ func (p *Presenter) confirm(title string, status models.OrderStatus) {
 	})
 }
 
+// Restore brings the selected soft-deleted order back with the tags it had.
+func (p *Presenter) Restore() bool {
+	if p.busy() || p.state.Selected == nil || !p.state.CanRestore {
+		return false
+	}
+	id := p.state.Selected.Order.ID
+	return p.mutate(func() error { _, err := p.app.Orders.Restore(p.app.Context(), id); return err }, false)
+}
+
 func orderTagChoice(mode ui.TagMutationMode, values string) (*tag.Tags, error) {
 	if mode == ui.PreserveTags {
 		return nil, nil
@@ -641,7 +653,7 @@ func (p *Presenter) permissionsFor(selected *models.Order) error {
 	if err != nil {
 		p.actionErr = err
 		p.state.Actions = nil
-		p.state.CanList, p.state.CanPlace, p.state.CanComplete, p.state.CanCancel, p.state.CanTag = false, false, false, false, false
+		p.state.CanList, p.state.CanPlace, p.state.CanComplete, p.state.CanCancel, p.state.CanTag, p.state.CanRestore = false, false, false, false, false, false
 		return err
 	}
 	if p.actionErr != nil && errors.Is(p.state.Err, p.actionErr) {
@@ -654,6 +666,7 @@ func (p *Presenter) permissionsFor(selected *models.Order) error {
 	p.state.CanComplete = actionEnabled(p.state.Actions, orders.ControlComplete)
 	p.state.CanCancel = actionEnabled(p.state.Actions, orders.ControlCancel)
 	p.state.CanTag = actionEnabled(p.state.Actions, orders.ControlTags)
+	p.state.CanRestore = actionEnabled(p.state.Actions, orders.ControlRestore)
 	return nil
 }
 
@@ -508,6 +508,64 @@ func TestHeadlessWidgetsTagAndCompleteSelectedOrder(t *testing.T) {
 	testutil.Equals(t, stored.Status, models.OrderStatusCompleted)
 }
 
+func TestShowDeletedListsDeletedOrdersAndRestoreBringsThemBack(t *testing.T) {
+	gui := frameworktest.NewApp()
+	defer gui.Quit()
+	f := testutil.NewFixture(t)
+	drink := availableDrink(t, f, "Restorable")
+	menu := testutil.CreateMenu(t, f, "Restorable", testutil.WithDrink(drink), testutil.Published())
+	order := testutil.PlaceOrder(t, f, models.Order{MenuID: menu.ID, Items: []models.OrderItem{{DrinkID: drink.ID, Quantity: 1}}})
+	ctx := f.OwnerContext()
+	_, err := f.Orders.Complete(ctx, order)
+	testutil.Ok(t, err)
+	_, err = f.Orders.Delete(ctx, order.ID)
+	testutil.Ok(t, err)
+
+	p := newInlinePresenter(f)
+	v := NewView(p)
+	driver := fynetest.NewDriver(t, v.Content())
+	p.Refresh()
+	testutil.Equals(t, len(p.State().Rows), 0)
+	driver.Tap(ControlShowDeleted)
+	state := p.State()
+	testutil.ErrorIf(t, !state.Filter.IncludeDeleted, "show deleted did not reach the filter: %#v", state.Filter)
+	testutil.Equals(t, len(state.Rows), 1)
+	_, _, _, canRestore := p.ListPermissions(0)
+	testutil.ErrorIf(t, !canRestore, "deleted order row did not offer restore")
+	p.Select(0)
+	testutil.ErrorIf(t, !p.State().CanRestore || p.State().CanTag, "deleted order actions = %#v", p.State().Actions)
+	driver.Tap(ControlRestore)
+	stored, err := f.Orders.Get(ctx, order.ID)
+	testutil.Ok(t, err)
+	testutil.ErrorIf(t, stored.DeletedAt.IsSome(), "order was not restored")
+	testutil.ErrorIf(t, p.State().CanRestore, "restored order still offers restore")
+
+	p.ResetList()
+	testutil.ErrorIf(t, p.State().Filter.IncludeDeleted, "breadcrumb reset kept show deleted")
+}
+
+func TestRestoreRequiresProjectedPermission(t *testing.T) {
+	f := testutil.NewFixture(t)
+	drink := availableDrink(t, f, "Guarded restore")
+	menu := testutil.CreateMenu(t, f, "Guarded restore", testutil.WithDrink(drink), testutil.Published())
+	order := testutil.PlaceOrder(t, f, models.Order{MenuID: menu.ID, Items: []models.OrderItem{{DrinkID: drink.ID, Quantity: 1}}})
+	ctx := f.OwnerContext()
+	_, err := f.Orders.Cancel(ctx, order)
+	testutil.Ok(t, err)
+	_, err = f.Orders.Delete(ctx, order.ID)
+	testutil.Ok(t, err)
+
+	reader := NewPresenter(application.NewSession(f.ActorContext("sommelier"), f.App.App), Dependencies{Executor: appgui.InlineExecutor{}, Dispatcher: appgui.InlineDispatcher{}, Dialogs: &fynetest.Dialogs{}})
+	testutil.Equals(t, reader.ApplyFilter(Filter{IncludeDeleted: true, Limit: appgui.PageLimit}), true)
+	testutil.Equals(t, len(reader.State().Rows), 1)
+	reader.Select(0)
+	testutil.ErrorIf(t, reader.State().CanRestore, "read-only actor was offered restore")
+	testutil.Equals(t, reader.Restore(), false)
+	live, err := f.Orders.Count(ctx, orders.ListRequest{})
+	testutil.Ok(t, err)
+	testutil.Equals(t, live, 0)
+}
+
 func TestCatalogRefreshDisablesEveryPlacementControl(t *testing.T) {
 	gui := frameworktest.NewApp()
 	defer gui.Quit()
@@ -559,7 +617,8 @@ func TestCLIAndFyneShareOrderPersistenceContract(t *testing.T) {
 	dbPath := filepath.Join(dir, "data", "mixology.db")
 	database, err := store.Open(ctx, dbPath)
 	testutil.Ok(t, err)
-	core := application.New(ctx, application.Config{Store: database})
+	core, err := application.New(ctx, application.Config{Store: database})
+	testutil.Ok(t, err)
 	mctx := middleware.NewContext(ctx)
 	ingredient, err := core.Ingredients.Create(mctx, &ingredientmodels.Ingredient{Name: "Shared base", Category: ingredientmodels.CategoryOther, Unit: measurement.UnitOz})
 	testutil.Ok(t, err)
@@ -578,7 +637,8 @@ func TestCLIAndFyneShareOrderPersistenceContract(t *testing.T) {
 	orderID := strings.TrimSpace(run("--log-level", "error", "orders", "place", "--menu-id", menu.ID.String(), drink.ID.String()+":2"))
 	database, err = store.Open(ctx, dbPath)
 	testutil.Ok(t, err)
-	core = application.New(ctx, application.Config{Store: database})
+	core, err = application.New(ctx, application.Config{Store: database})
+	testutil.Ok(t, err)
 	dialogs := &fynetest.Dialogs{}
 	p := NewPresenter(application.NewSession(ctx, core), Dependencies{Executor: appgui.InlineExecutor{}, Dispatcher: appgui.InlineDispatcher{}, Dialogs: dialogs})
 	p.Refresh()
@@ -24,6 +24,8 @@ const (
 	ControlComplete         = "orders-complete"
 	ControlCancelOrder      = "orders-cancel-order"
 	ControlTags             = "orders-tags"
+	ControlRestore          = "orders-restore"
+	ControlShowDeleted      = "orders-show-deleted"
 	ControlSelectPrefix     = "orders-select-"
 	ControlBack             = "orders-detail-back"
 	ControlBreadcrumb       = "orders-detail-breadcrumb"
@@ -100,7 +102,9 @@ func (v *View) render(s State) {
 func (v *View) browser(s State) framework.CanvasObject {
 	bar := ui.NewSingleRowFilterBar(ControlFilter, ControlApplyFilter, `Filter orders (for example: tags contains "featured")`, s.Filter.Expression,
 		[]ui.FilterPreset{{ID: "orders-status", Placeholder: "Status", Options: []ui.FilterOption{{Label: "Any status"}, {Label: "Pending", Expression: `status == "pending"`}, {Label: "Blocked", Expression: `status == "blocked"`}, {Label: "Completed", Expression: `status == "completed"`}, {Label: "Cancelled", Expression: `status == "cancelled"`}}}},
-		nil, func(expression string) { v.presenter.ApplyFilter(Filter{Expression: expression, Limit: ui.PageLimit}) })
+		v.showDeleted(s), func(expression string) {
+			v.presenter.ApplyFilter(Filter{Expression: expression, Limit: ui.PageLimit, IncludeDeleted: v.state.Filter.IncludeDeleted})
+		})
 	v.expression = bar.Expression
 	v.refresh = ui.WithIcon(ui.NewButton(ControlRefresh, "Refresh", v.presenter.Refresh), ui.IconRefresh)
 	v.create = ui.Primary(ui.WithIcon(ui.NewButton(ControlPlace, "Place order", v.presenter.StartPlace), ui.IconAdd))
@@ -126,11 +130,15 @@ func (v *View) browser(s State) framework.CanvasObject {
 			for _, item := range row.Order.Items {
 				qty += item.Quantity
 			}
-			values := []string{row.MenuName, row.Order.MenuID.String(), string(row.Order.Status), strconv.Itoa(len(row.Order.Items)), strconv.Itoa(qty), row.Total, formatTime(row.Order.CreatedAt), completed, row.Order.Tags.Canonical().String()}
+			status := string(row.Order.Status)
+			if row.Order.DeletedAt.IsSome() {
+				status = "deleted"
+			}
+			values := []string{row.MenuName, row.Order.MenuID.String(), status, strconv.Itoa(len(row.Order.Items)), strconv.Itoa(qty), row.Total, formatTime(row.Order.CreatedAt), completed, row.Order.Tags.Canonical().String()}
 			if id.Col == len(columns)-1 {
 				index := id.Row
 				actions := []ui.RowAction{{Label: "View", Run: func() { v.presenter.Select(index) }}}
-				canComplete, canCancel, canTag := v.presenter.ListPermissions(index)
+				canComplete, canCancel, canTag, canRestore := v.presenter.ListPermissions(index)
 				if canComplete {
 					actions = append(actions, ui.RowAction{Label: "Complete", Run: func() { v.presenter.Select(index); v.presenter.ConfirmComplete() }})
 				}
@@ -140,6 +148,9 @@ func (v *View) browser(s State) framework.CanvasObject {
 				if canTag {
 					actions = append(actions, ui.RowAction{Label: "Tags", Run: func() { v.presenter.Select(index); v.presenter.StartTags() }})
 				}
+				if canRestore {
+					actions = append(actions, ui.RowAction{Label: "Restore", Run: func() { v.presenter.Select(index); v.presenter.Restore() }})
+				}
 				ui.ShowCellActions(cell, actions)
 				return
 			}
@@ -185,6 +196,20 @@ func (v *View) browser(s State) framework.CanvasObject {
 	return ui.StandardListPage(ui.ListPage{Title: "Orders", Subtitle: "Browse orders and select one for complete details.", Filters: bar.Content, CollectionActions: []framework.CanvasObject{v.create, v.refresh}, List: list, Status: widget.NewLabel(status)})
 }
 
+// showDeleted builds the list toggle that includes soft-deleted orders;
+// the check is rebuilt each render, so it is seeded before it listens.
+func (v *View) showDeleted(s State) *ui.SemanticCheck {
+	check := ui.NewCheck(ControlShowDeleted, "Show deleted", nil)
+	check.SetChecked(s.Filter.IncludeDeleted)
+	check.OnChanged = func(on bool) {
+		filter := v.presenter.State().Filter
+		filter.IncludeDeleted = on
+		v.presenter.ApplyFilter(filter)
+	}
+	setEnabled(check, !(s.Loading || s.Submitting || s.Confirming) && s.CanList)
+	return check
+}
+
 func (v *View) breadcrumb(name string) framework.CanvasObject {
 	return container.NewHBox(ui.WithIcon(ui.NewButton(ControlBack, "Back", v.presenter.Back), ui.IconBack), ui.NewButton(ControlBreadcrumb, "Orders", v.presenter.ResetList), widget.NewLabel(">"), widget.NewLabel(name))
 }
@@ -261,6 +286,11 @@ func (v *View) detail(s State) framework.CanvasObject {
 		setEnabled(button, clean && action.Enabled)
 		actions = append(actions, button)
 	}
+	if action, ok := s.Actions[orders.ControlRestore]; ok && action.Visible {
+		button := ui.Primary(ui.NewButton(ControlRestore, "Restore", func() { v.presenter.Restore() }))
+		setEnabled(button, clean && action.Enabled)
+		actions = append(actions, button)
+	}
 	if actionBar := ui.ActionBar(nil, actions); actionBar != nil {
 		fields.Objects = append([]framework.CanvasObject{actionBar}, fields.Objects...)
 	}
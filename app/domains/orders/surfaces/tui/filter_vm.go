@@ -19,6 +19,7 @@ type filterVM struct {
 	status     *forms.SelectField
 	expression *forms.TextField
 	limit      *forms.NumberField
+	deleted    *forms.SelectField
 	err        error
 }
 
@@ -37,8 +38,12 @@ func newFilterVM(req orders.ListRequest) *filterVM {
 		}, forms.WithInitialValue(req.Status)),
 		expression: forms.NewTextField("Expression", forms.WithInitialValue(req.Filter)),
 		limit:      forms.NewNumberField("Page size", forms.WithRequired(), forms.WithMin(1), forms.WithInitialValue(limit)),
+		deleted: forms.NewSelectField("Deleted", []forms.SelectOption{
+			{Label: "hide", Value: false},
+			{Label: "show", Value: true},
+		}, forms.WithInitialValue(req.IncludeDeleted)),
 	}
-	v.form = forms.New(styles.Standard.Form, keys.Standard.Form, v.status, v.expression, v.limit)
+	v.form = forms.New(styles.Standard.Form, keys.Standard.Form, v.status, v.expression, v.limit, v.deleted)
 	return v
 }
 
@@ -65,5 +70,5 @@ func (v *filterVM) Request() (orders.ListRequest, error) {
 		v.err = fmt.Errorf("page size must be greater than zero")
 		return orders.ListRequest{}, v.err
 	}
-	return orders.ListRequest{Status: v.status.Value().(models.OrderStatus), Filter: strings.TrimSpace(fmt.Sprint(v.expression.Value())), Limit: limit}, nil
+	return orders.ListRequest{Status: v.status.Value().(models.OrderStatus), Filter: strings.TrimSpace(fmt.Sprint(v.expression.Value())), Limit: limit, IncludeDeleted: v.deleted.Value().(bool)}, nil
 }
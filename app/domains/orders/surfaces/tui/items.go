@@ -12,6 +12,9 @@ type orderItem = tui.ListItem[models.Order]
 func newOrderItem(order models.Order, menuName string, styles tui.ListViewStyles) orderItem {
 	status := orderStatusBadge(order.Status, styles)
 	description := fmt.Sprintf("%s | %s | %d items", status, menuName, len(order.Items))
+	if order.DeletedAt.IsSome() {
+		description += " | deleted"
+	}
 	return tui.NewListItem(order, truncateID(order.ID.String()), description, order.ID.String())
 }
 
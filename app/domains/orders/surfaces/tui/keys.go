@@ -7,7 +7,7 @@ import (
 
 type listViewKeys struct {
 	keys.ListViewKeys
-	Tags, Complete, Cancel key.Binding
+	Tags, Complete, Cancel, Restore key.Binding
 }
 
 func newListViewKeys() listViewKeys {
@@ -16,5 +16,6 @@ func newListViewKeys() listViewKeys {
 		Tags:         keys.NewBinding("t", "manage tags", "t"),
 		Complete:     keys.NewBinding("o", "complete", "o"),
 		Cancel:       keys.NewBinding("x", "cancel order", "x"),
+		Restore:      keys.NewBinding("b", "restore", "b"),
 	}
 }
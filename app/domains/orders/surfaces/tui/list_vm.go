@@ -138,6 +138,13 @@ func (m *ListViewModel) Interaction() tui.Interaction {
 
 func (m *ListViewModel) Update(msg tea.Msg) (tui.ViewModel, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tui.RefreshMsg:
+		if m.mutating || m.mode != listModeBrowsing || m.list.SettingFilter() || !m.actionEnabled(orders.ControlList) {
+			return m, nil
+		}
+		m.loading = true
+		m.err = nil
+		return m, tea.Batch(m.spinner.Init(), m.loadOrders())
 	case tea.WindowSizeMsg:
 		m.setSize(msg.Width, msg.Height)
 		if m.mode.isConfirming() {
@@ -162,6 +169,11 @@ func (m *ListViewModel) Update(msg tea.Msg) (tui.ViewModel, tea.Cmd) {
 		m.loading = true
 		m.err = nil
 		return m, tea.Batch(m.spinner.Init(), m.loadOrders())
+	case OrderRestoredMsg:
+		m.mutating = false
+		m.loading = true
+		m.err = nil
+		return m, tea.Batch(m.spinner.Init(), m.loadOrders())
 	case components.TagsSavedMsg[cedar.EntityUID, tag.Tags]:
 		if m.mode != listModeTagging || m.tags == nil || !m.tags.Owns(msg.Target) {
 			return m, nil
@@ -198,6 +210,10 @@ func (m *ListViewModel) Update(msg tea.Msg) (tui.ViewModel, tea.Cmd) {
 		m.cancelTarget = nil
 		m.err = msg.Err
 		return m, nil
+	case RestoreErrorMsg:
+		m.mutating = false
+		m.err = msg.Err
+		return m, nil
 	case showCompleteDialogMsg:
 		m.mode = listModeConfirmingComplete
 		m.dialog = msg.dialog
@@ -305,6 +321,11 @@ func (m *ListViewModel) Update(msg tea.Msg) (tui.ViewModel, tea.Cmd) {
 				return m, nil
 			}
 			return m, m.startCancel()
+		case key.Matches(msg, m.keys.Restore):
+			if !m.actionEnabled(orders.ControlRestore) {
+				return m, nil
+			}
+			return m, m.performRestore()
 		case key.Matches(msg, m.keys.Tags):
 			if !m.actionEnabled(orders.ControlTags) {
 				return m, nil
@@ -450,7 +471,7 @@ func (m *ListViewModel) ShortHelp() []key.Binding {
 	if m.actionEnabled(orders.ControlList) {
 		bindings = append(bindings, m.keys.Up, m.keys.Down, m.list.KeyMap.PrevPage, m.list.KeyMap.NextPage)
 	}
-	bindings = append(bindings, m.visibleBindings([]key.Binding{m.keys.Create, m.keys.Complete, m.keys.Cancel, m.keys.Tags})...)
+	bindings = append(bindings, m.visibleBindings([]key.Binding{m.keys.Create, m.keys.Complete, m.keys.Cancel, m.keys.Tags, m.keys.Restore})...)
 	if m.actionEnabled(orders.ControlList) {
 		bindings = append(bindings, m.keys.Refresh)
 	}
@@ -479,7 +500,7 @@ func (m *ListViewModel) FullHelp() [][]key.Binding {
 		paging = append(paging, m.list.KeyMap.PrevPage, m.list.KeyMap.NextPage)
 		last = append([]key.Binding{m.keys.Refresh}, last...)
 	}
-	return m.visibleBindingGroups([][]key.Binding{navigation, paging, []key.Binding{m.keys.Create, m.keys.Complete, m.keys.Cancel, m.keys.Tags}, last})
+	return m.visibleBindingGroups([][]key.Binding{navigation, paging, []key.Binding{m.keys.Create, m.keys.Complete, m.keys.Cancel, m.keys.Tags, m.keys.Restore}, last})
 }
 
 func (m *ListViewModel) syncActions() {
@@ -519,6 +540,8 @@ func (m *ListViewModel) actionVisibleForBinding(binding key.Binding) bool {
 		return m.actions[orders.ControlCancel].Visible
 	case m.keys.Tags.Help().Key:
 		return m.actions[orders.ControlTags].Visible
+	case m.keys.Restore.Help().Key:
+		return m.actions[orders.ControlRestore].Visible
 	default:
 		return true
 	}
@@ -702,6 +725,22 @@ func (m *ListViewModel) performCancel() tea.Cmd {
 	}
 }
 
+func (m *ListViewModel) performRestore() tea.Cmd {
+	order := m.selectedOrder()
+	if order == nil {
+		return nil
+	}
+	id := order.ID
+	m.mutating = true
+	return func() tea.Msg {
+		restored, err := m.app.Orders.Restore(m.context(), id)
+		if err != nil {
+			return RestoreErrorMsg{Err: err}
+		}
+		return OrderRestoredMsg{Order: restored}
+	}
+}
+
 func (m *ListViewModel) selectedOrder() *ordersmodels.Order {
 	item, ok := m.list.SelectedItem().(orderItem)
 	if !ok {
@@ -9,6 +9,7 @@ import (
 	drinksmodels "github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
 	ingredientsmodels "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
 	inventorymodels "github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
+	orders "github.com/TheFellow/go-modular-monolith/app/domains/orders"
 	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/currency"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
@@ -77,3 +78,37 @@ func TestListVMPagesForwardAndBackAcrossMoreThanOneServerPage(t *testing.T) {
 	testutil.Equals(t, len(vm.list.Items()), 100)
 	testutil.ErrorIf(t, vm.err == nil, "invalid filter error was not presented")
 }
+
+func TestListVMRestoreKeyBringsBackSelectedDeletedOrder(t *testing.T) {
+	fix := testutil.NewFixture(t)
+	ingredient := testutil.CreateIngredient(t, fix, ingredientsmodels.Ingredient{Name: "Restore Base", Category: ingredientsmodels.CategoryOther, Unit: measurement.UnitOz})
+	testutil.SetInventory(t, fix, inventorymodels.Update{IngredientID: ingredient.ID, Amount: measurement.MustAmount(10, measurement.UnitOz), CostPerUnit: money.NewPriceFromCents(10, currency.USD)})
+	drink := testutil.CreateDrink(t, fix, drinksmodels.Drink{Name: "Restore Drink", Category: drinksmodels.DrinkCategoryHighball, Recipe: drinksmodels.Recipe{Ingredients: []drinksmodels.RecipeIngredient{{IngredientID: ingredient.ID, Amount: measurement.MustAmount(1, measurement.UnitOz)}}, Steps: []string{"Build"}}})
+	menu := testutil.CreateMenu(t, fix, "Restore Menu", testutil.WithDrink(drink), testutil.Published())
+	order := testutil.PlaceOrder(t, fix, models.Order{MenuID: menu.ID, Items: []models.OrderItem{{DrinkID: drink.ID, Quantity: 1}}})
+	_, err := fix.Orders.Cancel(fix.OwnerContext(), order)
+	testutil.Ok(t, err)
+	_, err = fix.Orders.Delete(fix.OwnerContext(), order.ID)
+	testutil.Ok(t, err)
+
+	vm := NewListViewModel(fix.App)
+	vm.setSize(100, 30)
+	vm.request.IncludeDeleted = true
+	vm.Update(vm.loadOrders()().(OrdersLoadedMsg))
+	testutil.Equals(t, len(vm.list.Items()), 1)
+	testutil.ErrorIf(t, !vm.actionEnabled(orders.ControlRestore) || vm.actionEnabled(orders.ControlTags), "deleted order actions = %#v", vm.actions)
+
+	_, command := vm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	testutil.ErrorIf(t, command == nil, "restore key produced no command")
+	testutil.ErrorIf(t, !vm.mutating, "restore did not claim mutation ownership")
+	vm.Update(command())
+	testutil.ErrorIf(t, vm.mutating, "restore did not release mutation ownership")
+	restored, err := fix.Orders.Get(fix.OwnerContext(), order.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, restored.Status, models.OrderStatusCancelled)
+
+	vm.Update(vm.loadOrders()().(OrdersLoadedMsg))
+	testutil.Equals(t, vm.actionEnabled(orders.ControlRestore), false)
+	_, command = vm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	testutil.ErrorIf(t, command != nil, "restore key acted on a live order")
+}
@@ -20,6 +20,11 @@ type OrderCancelledMsg struct {
 	Order *models.Order
 }
 
+// OrderRestoredMsg is sent when a deleted order is restored.
+type OrderRestoredMsg struct {
+	Order *models.Order
+}
+
 // CompleteErrorMsg is sent when completing fails.
 type CompleteErrorMsg struct {
 	Err error
@@ -29,3 +34,8 @@ type CompleteErrorMsg struct {
 type CancelErrorMsg struct {
 	Err error
 }
+
+// RestoreErrorMsg is sent when restoring fails.
+type RestoreErrorMsg struct {
+	Err error
+}
@@ -27,7 +27,18 @@ func (m *Module) registerTagTarget(targets *tagging.Registry) {
 			if err != nil {
 				return tagging.TargetState{}, err
 			}
-			return tagging.TargetState{Entity: value.CedarEntity(), DisplayName: fmt.Sprintf("Order for %s", menu.Name), Tags: value.Tags}, nil
+			return tagging.TargetState{Entity: value.CedarEntity(), DisplayName: fmt.Sprintf("Order for %s", menu.Name), Tags: value.Tags, Value: *value}, nil
+		},
+		LoadDeleted: func(ctx store.Context, raw cedar.String) (tagging.TargetState, error) {
+			id, err := entity.ParseOrderID(string(raw))
+			if err != nil {
+				return tagging.TargetState{}, err
+			}
+			value, err := m.queries.GetDeleted(ctx, id)
+			if err != nil {
+				return tagging.TargetState{}, err
+			}
+			return tagging.TargetState{Entity: value.CedarEntity(), DisplayName: "Order " + value.ID.String(), Tags: value.Tags, Value: *value}, nil
 		},
 	})
 }
@@ -0,0 +1,27 @@
+package orders
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// TransferOwnership makes owner the current owner of an order. The creator is
+// retained, so policies can still grant authors control after delegation.
+func (m *Module) TransferOwnership(ctx *middleware.Context, id entity.OrderID, owner cedar.EntityUID) (*models.Order, error) {
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Order, *models.Order]{
+		Action: authz.ActionTransfer,
+		Request: struct {
+			ID    entity.OrderID
+			Owner cedar.EntityUID
+		}{id, owner},
+		Load: func(ctx *middleware.Context) (*models.Order, error) {
+			return m.queries.Get(ctx, id)
+		},
+		Handle: func(ctx *middleware.Context, order *models.Order) (*models.Order, error) {
+			return m.commands.TransferOwnership(ctx, order, owner)
+		},
+	})
+}
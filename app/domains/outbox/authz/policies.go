@@ -0,0 +1,6 @@
+package authz
+
+import _ "embed"
+
+//go:embed policies.cedar
+var Policies string
@@ -0,0 +1,13 @@
+package commands
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// Enqueue stores message as pending in the command's transaction.
+func (c *Commands) Enqueue(ctx *middleware.Context, message models.Message) error {
+	message.State = models.StatePending
+	message.NextAttemptAt = message.OccurredAt
+	return c.dao.Insert(ctx, message)
+}
@@ -0,0 +1,25 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// Retry returns a dead message to the queue with a fresh attempt budget so
+// the next relay pass delivers it.
+func (c *Commands) Retry(ctx *middleware.Context, message *models.Message, now time.Time) (*models.Message, error) {
+	if message.State != models.StateDead {
+		return nil, errors.FailedPreconditionf("outbox message %d is %s, only dead messages can be retried", message.ID, message.State)
+	}
+	updated := *message
+	updated.State = models.StatePending
+	updated.Attempts = 0
+	updated.NextAttemptAt = now
+	if err := c.dao.Update(ctx, updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
+)
+
+// Delivered marks message as delivered at now.
+func (c *Commands) Delivered(ctx *middleware.Context, message models.Message, now time.Time) error {
+	message.State = models.StateDelivered
+	message.Attempts++
+	message.LastError = ""
+	message.DeliveredAt = optional.Some(now)
+	return c.dao.Settle(ctx, message)
+}
+
+// Failed records a failed attempt and schedules the next one under policy,
+// or marks the message dead once its attempts are used up. It reports
+// whether the message is now dead.
+func (c *Commands) Failed(ctx *middleware.Context, message models.Message, cause error, policy models.RetryPolicy, now time.Time) (bool, error) {
+	message.Attempts++
+	message.LastError = cause.Error()
+	if message.Attempts >= policy.MaxAttempts {
+		message.State = models.StateDead
+	} else {
+		message.NextAttemptAt = now.Add(policy.Backoff(message.Attempts))
+	}
+	return message.State == models.StateDead, c.dao.Settle(ctx, message)
+}
@@ -0,0 +1,42 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
+)
+
+func toRow(m models.Message) MessageRow {
+	row := MessageRow{
+		ID:            m.ID,
+		Topic:         m.Topic,
+		Payload:       m.Payload,
+		OccurredAt:    m.OccurredAt,
+		State:         string(m.State),
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		LastError:     m.LastError,
+	}
+	if at, ok := m.DeliveredAt.Unwrap(); ok {
+		row.DeliveredAt = at
+	}
+	return row
+}
+
+func toModel(r MessageRow) models.Message {
+	m := models.Message{
+		ID:            r.ID,
+		Topic:         r.Topic,
+		Payload:       r.Payload,
+		OccurredAt:    r.OccurredAt,
+		State:         models.State(r.State),
+		Attempts:      r.Attempts,
+		NextAttemptAt: r.NextAttemptAt,
+		LastError:     r.LastError,
+	}
+	if !r.DeliveredAt.IsZero() {
+		m.DeliveredAt = optional.Some[time.Time](r.DeliveredAt)
+	}
+	return m
+}
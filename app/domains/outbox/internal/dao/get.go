@@ -0,0 +1,19 @@
+package dao
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+func (d *DAO) Get(ctx store.Context, id int64) (models.Message, error) {
+	var message models.Message
+	err := d.store.ReadContext(ctx, func(tx *store.Tx) error {
+		row := MessageRow{ID: id}
+		if err := tx.Get(&row); err != nil {
+			return store.MapError(err, "outbox message %d", id)
+		}
+		message = toModel(row)
+		return nil
+	})
+	return message, err
+}
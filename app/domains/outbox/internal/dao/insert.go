@@ -0,0 +1,13 @@
+package dao
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+func (d *DAO) Insert(ctx store.Context, message models.Message) error {
+	return store.Write(ctx, func(tx *store.Tx) error {
+		row := toRow(message)
+		return store.MapError(tx.Insert(&row), "insert outbox message for %s", row.Topic)
+	})
+}
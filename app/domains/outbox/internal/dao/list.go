@@ -0,0 +1,80 @@
+package dao
+
+import (
+	"iter"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
+	appfilter "github.com/TheFellow/go-modular-monolith/pkg/filter"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+// ListFilter specifies optional filters for listing outbox messages.
+type ListFilter struct {
+	State   models.State
+	Topic   string
+	AfterID int64
+
+	Expression *appfilter.Expression[models.ListFilterView]
+}
+
+// List returns messages in ID order, oldest first, inside one read
+// transaction for the duration of iteration.
+func (d *DAO) List(ctx store.Context, filter ListFilter) iter.Seq2[*models.Message, error] {
+	return func(yield func(*models.Message, error) bool) {
+		err := d.store.ReadContext(ctx, func(tx *store.Tx) error {
+			q := store.QueryTx[MessageRow](tx)
+			if filter.State != "" {
+				q = q.FilterEqual("State", string(filter.State))
+			}
+			if filter.Topic != "" {
+				q = q.FilterEqual("Topic", filter.Topic)
+			}
+			if filter.AfterID > 0 {
+				q = q.FilterGreater("ID", filter.AfterID)
+			}
+			q = appfilter.ApplyStore(q, filter.Expression, func(r MessageRow) models.ListFilterView {
+				return models.ListFilterView{
+					ID: r.ID, Topic: r.Topic, State: r.State, OccurredAt: r.OccurredAt,
+					Attempts: int64(r.Attempts), NextAttemptAt: r.NextAttemptAt, LastError: r.LastError,
+				}
+			})
+			for row, err := range q.SortAsc("ID").All() {
+				if err != nil {
+					return store.MapError(err, "iterate outbox messages")
+				}
+				message := toModel(row)
+				if !yield(&message, nil) {
+					return nil
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// Due returns up to limit pending messages after afterID whose next attempt
+// is at or before now, oldest first.
+func (d *DAO) Due(ctx store.Context, now time.Time, afterID int64, limit int) ([]models.Message, error) {
+	var messages []models.Message
+	err := d.store.ReadContext(ctx, func(tx *store.Tx) error {
+		rows, err := store.QueryTx[MessageRow](tx).
+			FilterEqual("State", string(models.StatePending)).
+			FilterLessEqual("NextAttemptAt", now).
+			FilterGreater("ID", afterID).
+			SortAsc("ID").
+			Limit(limit).
+			List()
+		if err != nil {
+			return store.MapError(err, "list due outbox messages")
+		}
+		for _, row := range rows {
+			messages = append(messages, toModel(row))
+		}
+		return nil
+	})
+	return messages, err
+}
@@ -0,0 +1,17 @@
+package dao
+
+import "time"
+
+type MessageRow struct {
+	ID int64
+
+	Topic      string `bstore:"index"`
+	Payload    []byte
+	OccurredAt time.Time
+
+	State         string `bstore:"index"`
+	Attempts      int
+	NextAttemptAt time.Time `bstore:"index"`
+	LastError     string
+	DeliveredAt   time.Time
+}
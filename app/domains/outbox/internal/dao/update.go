@@ -0,0 +1,24 @@
+package dao
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+func (d *DAO) Update(ctx store.Context, message models.Message) error {
+	return store.Write(ctx, func(tx *store.Tx) error {
+		row := toRow(message)
+		return store.MapError(tx.Update(&row), "update outbox message %d", row.ID)
+	})
+}
+
+// Settle records the outcome of a delivery attempt in its own transaction.
+// The relay delivers outside any command, and committing each outcome
+// separately means a later failure never causes an earlier message to be
+// sent again.
+func (d *DAO) Settle(ctx store.Context, message models.Message) error {
+	return d.store.Write(ctx, func(tx *store.Tx) error {
+		row := toRow(message)
+		return store.MapError(tx.Update(&row), "settle outbox message %d", row.ID)
+	})
+}
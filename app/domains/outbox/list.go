@@ -0,0 +1,56 @@
+package outbox
+
+import (
+	"iter"
+	"strconv"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/internal/dao"
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
+	appfilter "github.com/TheFellow/go-modular-monolith/pkg/filter"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/paging"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+type ListRequest struct {
+	State  models.State
+	Topic  string
+	Filter string
+	Cursor paging.Cursor
+	Limit  int
+}
+
+// List returns messages oldest first. Listing StateDead shows the
+// dead-letter queue.
+func (m *Module) List(ctx *middleware.Context, req ListRequest) (paging.Page[*models.Message], error) {
+	if _, err := models.ParseState(string(req.State)); err != nil {
+		return paging.Page[*models.Message]{}, err
+	}
+	expression, err := appfilter.Parse(models.ListFilterSchema(), req.Filter)
+	if err != nil {
+		return paging.Page[*models.Message]{}, err
+	}
+	if req.Limit == 0 {
+		req.Limit = paging.DefaultLimit
+	}
+	if req.Cursor != "" {
+		if _, err := models.ParseMessageID(string(req.Cursor)); err != nil {
+			return paging.Page[*models.Message]{}, err
+		}
+	}
+	return middleware.RunPageQuery(
+		m.pipeline,
+		ctx,
+		authz.ActionList,
+		func(ctx store.Context, filter dao.ListFilter, cursor paging.Cursor) iter.Seq2[*models.Message, error] {
+			if cursor != "" {
+				filter.AfterID, _ = models.ParseMessageID(string(cursor))
+			}
+			return m.queries.List(ctx, filter)
+		},
+		func(message *models.Message) paging.Cursor { return paging.Cursor(strconv.FormatInt(message.ID, 10)) },
+		dao.ListFilter{State: req.State, Topic: req.Topic, Expression: expression},
+		paging.Request{Cursor: req.Cursor, Limit: req.Limit},
+	)
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/filter"
+)
+
+type ListFilterView struct {
+	ID            int64     `expr:"id" filter:"Message ID" filter-column:"ID"`
+	Topic         string    `expr:"topic" filter:"Integration event topic" filter-column:"Topic"`
+	State         string    `expr:"state" filter:"Delivery state (pending, delivered, dead)" filter-column:"State"`
+	OccurredAt    time.Time `expr:"occurred_at" filter:"Time the command raised the event" filter-column:"OccurredAt"`
+	Attempts      int64     `expr:"attempts" filter:"Failed or completed delivery attempts"`
+	NextAttemptAt time.Time `expr:"next_attempt_at" filter:"Earliest next delivery attempt" filter-column:"NextAttemptAt"`
+	LastError     string    `expr:"last_error" filter:"Error from the last failed attempt" filter-column:"LastError"`
+}
+
+func ListFilterSchema() filter.Schema[ListFilterView] {
+	return filter.NewSchema[ListFilterView](
+		`state == "dead" && last_error.contains("connection refused")`,
+		`topic.startsWith("orders.") && occurred_at >= date("2026-07-01T00:00:00Z")`,
+		`attempts > 3`,
+	)
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	outboxauthz "github.com/TheFellow/go-modular-monolith/app/domains/outbox/authz"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// State is a message's position in delivery.
+type State string
+
+const (
+	StatePending   State = "pending"
+	StateDelivered State = "delivered"
+	StateDead      State = "dead"
+)
+
+func ParseState(value string) (State, error) {
+	switch state := State(strings.ToLower(strings.TrimSpace(value))); state {
+	case StatePending, StateDelivered, StateDead, "":
+		return state, nil
+	}
+	return "", errors.Invalidf("invalid outbox state %q (expected pending, delivered, or dead)", value)
+}
+
+// Message is one integration event stored with the command that raised it
+// and delivered at least once by the relay. IDs increase in commit order, so
+// subscribers can use them to discard redeliveries.
+type Message struct {
+	ID            int64
+	Topic         string
+	Payload       json.RawMessage
+	OccurredAt    time.Time
+	State         State
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	DeliveredAt   optional.Value[time.Time]
+}
+
+func (m Message) CedarEntity() cedar.Entity {
+	return outboxauthz.OutboxMessage{UID: cedar.NewEntityUID(outboxauthz.OutboxMessageType, cedar.String(strconv.FormatInt(m.ID, 10)))}.CedarEntity()
+}
+
+// Envelope is the form in which sinks receive a message.
+type Envelope struct {
+	ID         int64           `json:"id"`
+	Topic      string          `json:"topic"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Attempt    int             `json:"attempt"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// Envelope wraps m for its next delivery attempt.
+func (m Message) Envelope() Envelope {
+	return Envelope{ID: m.ID, Topic: m.Topic, OccurredAt: m.OccurredAt.UTC(), Attempt: m.Attempts + 1, Payload: m.Payload}
+}
+
+func ParseMessageID(value string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, errors.Invalidf("invalid outbox message id %q", value)
+	}
+	return id, nil
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	outboxauthz "github.com/TheFellow/go-modular-monolith/app/domains/outbox/authz"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// QueueResourceID identifies the outbox as a whole when authorizing
+// operations, such as relaying, that are not about one message.
+const QueueResourceID = "queue"
+
+// RetryPolicy spaces out redelivery of a failing message. The delay doubles
+// from InitialBackoff after each failure up to MaxBackoff; a message that has
+// failed MaxAttempts times is dead and waits for an explicit retry.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    8,
+	InitialBackoff: time.Second,
+	MaxBackoff:     5 * time.Minute,
+}
+
+// Backoff returns the delay after the given number of failed attempts.
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < attempts && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxBackoff)
+}
+
+// RelayResult counts the outcomes of one relay pass.
+type RelayResult struct {
+	Delivered int
+	Failed    int
+	Dead      int
+}
+
+func (r RelayResult) CedarEntity() cedar.Entity {
+	return outboxauthz.OutboxMessage{UID: cedar.NewEntityUID(outboxauthz.OutboxMessageType, QueueResourceID)}.CedarEntity()
+}
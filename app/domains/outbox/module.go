@@ -0,0 +1,29 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/internal/commands"
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/internal/dao"
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/queries"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+func RegisterSchema(ctx context.Context, s *store.Store) {
+	dao.Register(ctx, s)
+}
+
+type Module struct {
+	commands *commands.Commands
+	queries  *queries.Queries
+	pipeline *middleware.Pipeline
+}
+
+func NewModule(s *store.Store, pipeline *middleware.Pipeline) *Module {
+	return &Module{
+		commands: commands.New(s),
+		queries:  queries.New(s),
+		pipeline: pipeline,
+	}
+}
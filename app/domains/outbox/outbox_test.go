@@ -0,0 +1,197 @@
+package outbox_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	drinksmodels "github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	ingredientsmodels "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	menumodels "github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	ordersmodels "github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox"
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestOutbox_PlacingAnOrderEnqueuesOrderPlaced(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	order := placeOrder(t, f)
+
+	messages := listMessages(t, f, outbox.ListRequest{Topic: "orders.order_placed"})
+	testutil.Equals(t, len(messages), 1)
+	testutil.Equals(t, messages[0].State, models.StatePending)
+	testutil.Equals(t, messages[0].Attempts, 0)
+
+	var payload struct {
+		OrderID string `json:"order_id"`
+		MenuID  string `json:"menu_id"`
+		Items   []struct {
+			DrinkID  string `json:"drink_id"`
+			Quantity int    `json:"quantity"`
+		} `json:"items"`
+	}
+	testutil.Ok(t, json.Unmarshal(messages[0].Payload, &payload))
+	testutil.Equals(t, payload.OrderID, order.ID.String())
+	testutil.Equals(t, payload.MenuID, order.MenuID.String())
+	testutil.Equals(t, len(payload.Items), 1)
+	testutil.Equals(t, payload.Items[0].Quantity, 2)
+
+	published := listMessages(t, f, outbox.ListRequest{Topic: "menus.menu_published"})
+	testutil.Equals(t, len(published), 1)
+}
+
+func TestOutbox_FailedCommandEnqueuesNothing(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	menu := testutil.CreateMenu(t, f, "Empty Menu")
+
+	_, err := f.Menus.Publish(f.OwnerContext(), &menumodels.Menu{ID: menu.ID})
+	testutil.ErrorIf(t, err == nil, "expected publishing an empty menu to fail")
+
+	testutil.Equals(t, len(listMessages(t, f, outbox.ListRequest{})), 0)
+}
+
+func TestOutbox_RelayDeliversPendingMessagesOnce(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	placeOrder(t, f)
+	sink := &recordingSink{}
+
+	result, err := f.Outbox.Relay(f.OwnerContext(), sink, outbox.RelayOptions{})
+	testutil.Ok(t, err)
+	testutil.Equals(t, result, models.RelayResult{Delivered: 3})
+	testutil.Equals(t, sink.topics(), []string{"inventory.stock_adjusted", "menus.menu_published", "orders.order_placed"})
+	testutil.Equals(t, sink.envelopes[2].Attempt, 1)
+
+	delivered := listMessages(t, f, outbox.ListRequest{State: models.StateDelivered})
+	testutil.Equals(t, len(delivered), 3)
+	_, ok := delivered[0].DeliveredAt.Unwrap()
+	testutil.IsTrue(t, ok)
+
+	result, err = f.Outbox.Relay(f.OwnerContext(), sink, outbox.RelayOptions{})
+	testutil.Ok(t, err)
+	testutil.Equals(t, result, models.RelayResult{})
+	testutil.Equals(t, len(sink.envelopes), 3)
+}
+
+func TestOutbox_RelayBacksOffThenDeadLettersFailingMessages(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	placeOrder(t, f)
+	sink := &recordingSink{fail: errors.Internalf("connection refused")}
+	now := time.Now().Add(time.Hour).UTC()
+	opts := outbox.RelayOptions{
+		Retry: models.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Minute, MaxBackoff: time.Hour},
+		Now:   func() time.Time { return now },
+	}
+
+	result, err := f.Outbox.Relay(f.OwnerContext(), sink, opts)
+	testutil.Ok(t, err)
+	testutil.Equals(t, result, models.RelayResult{Failed: 3})
+	pending := listMessages(t, f, outbox.ListRequest{State: models.StatePending})
+	testutil.Equals(t, len(pending), 3)
+	testutil.Equals(t, pending[0].Attempts, 1)
+	testutil.Equals(t, pending[0].NextAttemptAt.UTC(), now.Add(time.Minute))
+	testutil.StringContains(t, pending[0].LastError, "connection refused")
+
+	result, err = f.Outbox.Relay(f.OwnerContext(), sink, opts)
+	testutil.Ok(t, err)
+	testutil.Equals(t, result, models.RelayResult{})
+
+	now = now.Add(time.Minute)
+	result, err = f.Outbox.Relay(f.OwnerContext(), sink, opts)
+	testutil.Ok(t, err)
+	testutil.Equals(t, result, models.RelayResult{Dead: 3})
+	dead := listMessages(t, f, outbox.ListRequest{State: models.StateDead})
+	testutil.Equals(t, len(dead), 3)
+	testutil.Equals(t, dead[0].Attempts, 2)
+}
+
+func TestOutbox_RetryRequeuesDeadMessage(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	placeOrder(t, f)
+	opts := outbox.RelayOptions{Retry: models.RetryPolicy{MaxAttempts: 1, InitialBackoff: time.Second, MaxBackoff: time.Second}}
+	_, err := f.Outbox.Relay(f.OwnerContext(), &recordingSink{fail: errors.Internalf("down")}, opts)
+	testutil.Ok(t, err)
+	dead := listMessages(t, f, outbox.ListRequest{State: models.StateDead})
+	testutil.Equals(t, len(dead), 3)
+
+	retried, err := f.Outbox.Retry(f.OwnerContext(), dead[0].ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, retried.State, models.StatePending)
+	testutil.Equals(t, retried.Attempts, 0)
+
+	_, err = f.Outbox.Retry(f.OwnerContext(), dead[0].ID)
+	testutil.ErrorIsFailedPrecondition(t, err)
+
+	sink := &recordingSink{}
+	result, err := f.Outbox.Relay(f.OwnerContext(), sink, opts)
+	testutil.Ok(t, err)
+	testutil.Equals(t, result, models.RelayResult{Delivered: 1})
+	testutil.Equals(t, sink.envelopes[0].ID, dead[0].ID)
+}
+
+func TestOutbox_RetryUnknownMessageIsNotFound(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+
+	_, err := f.Outbox.Retry(f.OwnerContext(), 42)
+	testutil.ErrorIsNotFound(t, err)
+}
+
+func placeOrder(t *testing.T, f *testutil.Fixture) *ordersmodels.Order {
+	t.Helper()
+	gin := testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{
+		Name: "Gin", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitOz,
+	})
+	drink := testutil.CreateDrink(t, f, drinksmodels.Drink{
+		Name: "Gin Rickey", Category: drinksmodels.DrinkCategoryHighball, Glass: drinksmodels.GlassTypeHighball,
+		Recipe: drinksmodels.Recipe{
+			Ingredients: []drinksmodels.RecipeIngredient{{IngredientID: gin.ID, Amount: measurement.MustAmount(2, measurement.UnitOz)}},
+			Steps:       []string{"Build"},
+		},
+	})
+	menu := testutil.CreateMenu(t, f, "Bar Menu", testutil.WithDrink(drink), testutil.Published())
+	return testutil.PlaceOrder(t, f, ordersmodels.Order{
+		MenuID: menu.ID,
+		Items:  []ordersmodels.OrderItem{{DrinkID: drink.ID, Quantity: 2}},
+	})
+}
+
+func listMessages(t *testing.T, f *testutil.Fixture, req outbox.ListRequest) []*models.Message {
+	t.Helper()
+	page, err := f.Outbox.List(f.OwnerContext(), req)
+	testutil.Ok(t, err)
+	return page.Items
+}
+
+type recordingSink struct {
+	mu        sync.Mutex
+	fail      error
+	envelopes []models.Envelope
+}
+
+func (s *recordingSink) Deliver(_ context.Context, envelope models.Envelope) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail != nil {
+		return s.fail
+	}
+	s.envelopes = append(s.envelopes, envelope)
+	return nil
+}
+
+func (s *recordingSink) topics() []string {
+	topics := make([]string, 0, len(s.envelopes))
+	for _, envelope := range s.envelopes {
+		topics = append(topics, envelope.Topic)
+	}
+	return topics
+}
@@ -0,0 +1,67 @@
+package outbox_test
+
+import (
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox"
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestPermissions_Outbox(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		canUse bool
+	}{
+		{name: "owner", canUse: true},
+		{name: "manager", canUse: false},
+		{name: "sommelier", canUse: false},
+		{name: "bartender", canUse: false},
+		{name: "anonymous", canUse: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			f := testutil.NewFixture(t)
+			placeOrder(t, f)
+			var ctx *middleware.Context
+			if tc.name == "owner" {
+				ctx = f.OwnerContext()
+			} else {
+				ctx = f.ActorContext(tc.name)
+			}
+
+			page, err := f.Outbox.List(ctx, outbox.ListRequest{})
+			testutil.Ok(t, err)
+			wantCount := 0
+			if tc.canUse {
+				wantCount = 3
+			}
+			testutil.Equals(t, len(page.Items), wantCount)
+
+			_, err = f.Outbox.Relay(ctx, &recordingSink{}, outbox.RelayOptions{})
+			if tc.canUse {
+				testutil.Ok(t, err)
+			} else {
+				testutil.ErrorIsPermission(t, err)
+			}
+
+			_, err = f.Outbox.Retry(ctx, 1)
+			if tc.canUse {
+				testutil.ErrorIsFailedPrecondition(t, err)
+			} else {
+				testutil.ErrorIsPermission(t, err)
+			}
+			message := listMessages(t, f, outbox.ListRequest{})[0]
+			if tc.canUse {
+				testutil.Equals(t, message.State, models.StateDelivered)
+			} else {
+				testutil.Equals(t, message.State, models.StatePending)
+			}
+		})
+	}
+}
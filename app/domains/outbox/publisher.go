@@ -0,0 +1,44 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/internal/commands"
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/authz"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	middlewareevents "github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+// Publisher stores integration events in the outbox within the transaction
+// of the command that raised them.
+type Publisher struct {
+	commands *commands.Commands
+}
+
+func NewPublisher(s *store.Store) *Publisher {
+	return &Publisher{commands: commands.New(s)}
+}
+
+func (p *Publisher) Publish(ctx *middleware.Context, event middlewareevents.Integration) error {
+	payload, err := json.Marshal(event.Payload())
+	if err != nil {
+		return errors.Internalf("encode %s payload: %w", event.Topic(), err)
+	}
+	return p.commands.Enqueue(ctx, models.Message{
+		Topic:      event.Topic(),
+		Payload:    payload,
+		OccurredAt: requestTime(ctx),
+	})
+}
+
+// requestTime is the time the pipeline stamped on the current operation.
+func requestTime(ctx *middleware.Context) time.Time {
+	if at := authz.RequestFromContext(ctx).Time; !at.IsZero() {
+		return at
+	}
+	return time.Now()
+}
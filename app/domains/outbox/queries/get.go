@@ -0,0 +1,10 @@
+package queries
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+func (q *Queries) Get(ctx store.Context, id int64) (models.Message, error) {
+	return q.dao.Get(ctx, id)
+}
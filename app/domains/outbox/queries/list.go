@@ -0,0 +1,18 @@
+package queries
+
+import (
+	"iter"
+	"time"
+
+	outboxdao "github.com/TheFellow/go-modular-monolith/app/domains/outbox/internal/dao"
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+func (q *Queries) List(ctx store.Context, filter outboxdao.ListFilter) iter.Seq2[*models.Message, error] {
+	return q.dao.List(ctx, filter)
+}
+
+func (q *Queries) Due(ctx store.Context, now time.Time, afterID int64, limit int) ([]models.Message, error) {
+	return q.dao.Due(ctx, now, afterID, limit)
+}
@@ -0,0 +1,115 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
+	pkgauthz "github.com/TheFellow/go-modular-monolith/pkg/authz"
+	"github.com/TheFellow/go-modular-monolith/pkg/log"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+const defaultRelayBatchSize = 100
+
+type RelayOptions struct {
+	// Retry governs redelivery of failing messages; the zero value uses
+	// models.DefaultRetryPolicy.
+	Retry models.RetryPolicy
+	// BatchSize bounds how many messages are read per query. Zero uses 100.
+	BatchSize int
+	// Now is the relay's clock. Nil uses time.Now.
+	Now func() time.Time
+}
+
+func (o RelayOptions) withDefaults() RelayOptions {
+	if o.Retry.MaxAttempts <= 0 {
+		o.Retry = models.DefaultRetryPolicy
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = defaultRelayBatchSize
+	}
+	if o.Now == nil {
+		o.Now = time.Now
+	}
+	return o
+}
+
+// Relay makes one pass over the outbox, delivering every pending message
+// that is due to sink in ID order. Each outcome is committed as soon as it is
+// known: a delivered message is never sent again, and a failed one waits out
+// its backoff or becomes dead. A crash between delivery and commit redelivers
+// the message, so sinks receive every message at least once and should
+// discard envelope IDs they have already seen.
+func (m *Module) Relay(ctx *middleware.Context, sink Sink, opts RelayOptions) (models.RelayResult, error) {
+	var result models.RelayResult
+	if err := pkgauthz.AuthorizeEntity(ctx, ctx.Principal(), authz.ActionRelay, result.CedarEntity()); err != nil {
+		return result, err
+	}
+	opts = opts.withDefaults()
+
+	var afterID int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		batch, err := m.queries.Due(ctx, opts.Now(), afterID, opts.BatchSize)
+		if err != nil {
+			return result, err
+		}
+		for _, message := range batch {
+			afterID = message.ID
+			if err := m.deliver(ctx, sink, message, opts, &result); err != nil {
+				return result, err
+			}
+		}
+		if len(batch) < opts.BatchSize {
+			return result, nil
+		}
+	}
+}
+
+func (m *Module) deliver(ctx *middleware.Context, sink Sink, message models.Message, opts RelayOptions, result *models.RelayResult) error {
+	deliveryErr := sink.Deliver(ctx, message.Envelope())
+	if deliveryErr == nil {
+		result.Delivered++
+		return m.commands.Delivered(ctx, message, opts.Now())
+	}
+
+	log.FromContext(ctx).Warn("outbox delivery failed",
+		"message_id", message.ID, "topic", message.Topic, "attempt", message.Attempts+1, log.Err(deliveryErr))
+	dead, err := m.commands.Failed(ctx, message, deliveryErr, opts.Retry, opts.Now())
+	if err != nil {
+		return err
+	}
+	if dead {
+		result.Dead++
+	} else {
+		result.Failed++
+	}
+	return nil
+}
+
+// RunRelay repeats Relay every interval until ctx is cancelled. Each pass's
+// result is passed to report, which may be nil.
+func (m *Module) RunRelay(ctx *middleware.Context, sink Sink, opts RelayOptions, interval time.Duration, report func(models.RelayResult)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		result, err := m.Relay(ctx, sink, opts)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if report != nil {
+			report(result)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
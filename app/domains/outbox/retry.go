@@ -0,0 +1,25 @@
+package outbox
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+)
+
+// Retry returns a dead message to the queue for the next relay pass.
+func (m *Module) Retry(ctx *middleware.Context, id int64) (*models.Message, error) {
+	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[*models.Message, *models.Message]{
+		Action:  authz.ActionRetry,
+		Request: id,
+		Load: func(ctx *middleware.Context) (*models.Message, error) {
+			message, err := m.queries.Get(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return &message, nil
+		},
+		Handle: func(ctx *middleware.Context, message *models.Message) (*models.Message, error) {
+			return m.commands.Retry(ctx, message, requestTime(ctx))
+		},
+	})
+}
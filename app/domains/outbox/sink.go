@@ -0,0 +1,163 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+)
+
+// Sink receives outbox messages from the relay. Deliver returns nil only once
+// the envelope is durably accepted; any error schedules a retry.
+type Sink interface {
+	Deliver(ctx context.Context, envelope models.Envelope) error
+}
+
+const sinkTimeout = 10 * time.Second
+
+// ParseSink builds a sink from a spec: "file:PATH" appends NDJSON to PATH,
+// "unix:PATH" writes one JSON line per message to a Unix socket, and an
+// http:// or https:// URL receives a POST per message.
+func ParseSink(spec string) (Sink, error) {
+	switch {
+	case strings.HasPrefix(spec, "file:"):
+		return NewFileSink(strings.TrimPrefix(spec, "file:"))
+	case strings.HasPrefix(spec, "unix:"):
+		return NewSocketSink(strings.TrimPrefix(spec, "unix:"))
+	case strings.HasPrefix(spec, "http://"), strings.HasPrefix(spec, "https://"):
+		return NewWebhookSink(spec, nil), nil
+	}
+	return nil, errors.Invalidf("invalid outbox sink %q (expected file:PATH, unix:PATH, or an http(s) URL)", spec)
+}
+
+// FileSink appends each envelope as one JSON line and syncs the file before
+// reporting success.
+type FileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileSink(path string) (*FileSink, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, errors.Invalidf("file sink path is required")
+	}
+	return &FileSink{path: path}, nil
+}
+
+func (s *FileSink) Deliver(_ context.Context, envelope models.Envelope) error {
+	line, err := encodeLine(envelope)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return errors.Internalf("open outbox file %s: %w", s.path, err)
+	}
+	if _, err := file.Write(line); err != nil {
+		_ = file.Close()
+		return errors.Internalf("write outbox file %s: %w", s.path, err)
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return errors.Internalf("sync outbox file %s: %w", s.path, err)
+	}
+	return file.Close()
+}
+
+// WebhookSink POSTs each envelope as JSON. Any response other than 2xx is a
+// failed delivery. The X-Outbox-Message-ID header carries the envelope ID so
+// receivers can discard redeliveries without parsing the body.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink posts to url with client; nil uses a client with a short
+// timeout.
+func NewWebhookSink(url string, client *http.Client) *WebhookSink {
+	if client == nil {
+		client = &http.Client{Timeout: sinkTimeout}
+	}
+	return &WebhookSink{url: url, client: client}
+}
+
+func (s *WebhookSink) Deliver(ctx context.Context, envelope models.Envelope) error {
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return errors.Internalf("encode outbox message %d: %w", envelope.ID, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Invalidf("webhook request for %s: %v", s.url, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Outbox-Message-ID", strconv.FormatInt(envelope.ID, 10))
+	req.Header.Set("X-Outbox-Topic", envelope.Topic)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Internalf("post to %s: %w", s.url, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Internalf("post to %s: %s", s.url, resp.Status)
+	}
+	return nil
+}
+
+// SocketSink writes each envelope as one JSON line on a fresh connection to
+// a Unix socket. A delivery succeeds once the line is written and the
+// connection closed cleanly.
+type SocketSink struct {
+	path string
+}
+
+func NewSocketSink(path string) (*SocketSink, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, errors.Invalidf("socket sink path is required")
+	}
+	return &SocketSink{path: path}, nil
+}
+
+func (s *SocketSink) Deliver(ctx context.Context, envelope models.Envelope) error {
+	line, err := encodeLine(envelope)
+	if err != nil {
+		return err
+	}
+	dialer := net.Dialer{Timeout: sinkTimeout}
+	conn, err := dialer.DialContext(ctx, "unix", s.path)
+	if err != nil {
+		return errors.Internalf("dial %s: %w", s.path, err)
+	}
+	_ = conn.SetWriteDeadline(time.Now().Add(sinkTimeout))
+	if _, err := conn.Write(line); err != nil {
+		_ = conn.Close()
+		return errors.Internalf("write to %s: %w", s.path, err)
+	}
+	if err := conn.Close(); err != nil {
+		return errors.Internalf("close %s: %w", s.path, err)
+	}
+	return nil
+}
+
+func encodeLine(envelope models.Envelope) ([]byte, error) {
+	line, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, errors.Internalf("encode outbox message %d: %w", envelope.ID, err)
+	}
+	return append(line, '\n'), nil
+}
@@ -0,0 +1,113 @@
+package outbox_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox"
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func testEnvelope(id int64) models.Envelope {
+	return models.Envelope{
+		ID:         id,
+		Topic:      "orders.order_placed",
+		OccurredAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		Attempt:    1,
+		Payload:    json.RawMessage(`{"order_id":"ord-1"}`),
+	}
+}
+
+func TestFileSink_AppendsOneLinePerEnvelope(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	sink, err := outbox.ParseSink("file:" + path)
+	testutil.Ok(t, err)
+
+	testutil.Ok(t, sink.Deliver(context.Background(), testEnvelope(1)))
+	testutil.Ok(t, sink.Deliver(context.Background(), testEnvelope(2)))
+
+	data, err := os.ReadFile(path)
+	testutil.Ok(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	testutil.Equals(t, len(lines), 2)
+	var got models.Envelope
+	testutil.Ok(t, json.Unmarshal([]byte(lines[1]), &got))
+	testutil.Equals(t, got, testEnvelope(2))
+}
+
+func TestWebhookSink_PostsEnvelopeAndRejectsErrorStatus(t *testing.T) {
+	t.Parallel()
+	type request struct {
+		header   string
+		envelope models.Envelope
+	}
+	requests := make(chan request, 2)
+	var status atomic.Int32
+	status.Store(http.StatusAccepted)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var got request
+		got.header = r.Header.Get("X-Outbox-Message-ID")
+		_ = json.NewDecoder(r.Body).Decode(&got.envelope)
+		requests <- got
+		w.WriteHeader(int(status.Load()))
+	}))
+	t.Cleanup(server.Close)
+	sink, err := outbox.ParseSink(server.URL + "/hooks/mixology")
+	testutil.Ok(t, err)
+
+	testutil.Ok(t, sink.Deliver(context.Background(), testEnvelope(7)))
+	got := <-requests
+	testutil.Equals(t, got.envelope, testEnvelope(7))
+	testutil.Equals(t, got.header, "7")
+
+	status.Store(http.StatusServiceUnavailable)
+	err = sink.Deliver(context.Background(), testEnvelope(8))
+	testutil.ErrorContains(t, err, "503")
+}
+
+func TestSocketSink_WritesJSONLine(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "outbox.sock")
+	listener, err := net.Listen("unix", path)
+	testutil.Ok(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		received <- line
+	}()
+	sink, err := outbox.ParseSink("unix:" + path)
+	testutil.Ok(t, err)
+
+	testutil.Ok(t, sink.Deliver(context.Background(), testEnvelope(3)))
+
+	var got models.Envelope
+	testutil.Ok(t, json.Unmarshal([]byte(<-received), &got))
+	testutil.Equals(t, got, testEnvelope(3))
+}
+
+func TestParseSink_RejectsUnknownScheme(t *testing.T) {
+	t.Parallel()
+
+	_, err := outbox.ParseSink("kafka://localhost:9092")
+	testutil.ErrorIsInvalid(t, err)
+	_, err = outbox.ParseSink("file:")
+	testutil.ErrorIsInvalid(t, err)
+}
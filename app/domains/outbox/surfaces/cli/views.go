@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
+)
+
+type MessageRow struct {
+	ID          int64  `table:"ID" json:"id"`
+	Topic       string `table:"TOPIC" json:"topic"`
+	State       string `table:"STATE" json:"state"`
+	OccurredAt  string `table:"OCCURRED_AT" json:"occurred_at"`
+	Attempts    int    `table:"ATTEMPTS" json:"attempts"`
+	NextAttempt string `table:"NEXT_ATTEMPT" json:"next_attempt,omitempty"`
+	DeliveredAt string `table:"DELIVERED_AT" json:"delivered_at,omitempty"`
+	LastError   string `table:"LAST_ERROR" json:"last_error,omitempty"`
+}
+
+func ToMessageRow(message *models.Message) MessageRow {
+	if message == nil {
+		return MessageRow{}
+	}
+	row := MessageRow{
+		ID:         message.ID,
+		Topic:      message.Topic,
+		State:      string(message.State),
+		OccurredAt: formatTime(message.OccurredAt),
+		Attempts:   message.Attempts,
+		LastError:  message.LastError,
+	}
+	if message.State == models.StatePending {
+		row.NextAttempt = formatTime(message.NextAttemptAt)
+	}
+	if at, ok := message.DeliveredAt.Unwrap(); ok {
+		row.DeliveredAt = formatTime(at)
+	}
+	return row
+}
+
+func ToMessageRows(messages []*models.Message) []MessageRow {
+	rows := make([]MessageRow, 0, len(messages))
+	for _, message := range messages {
+		rows = append(rows, ToMessageRow(message))
+	}
+	return rows
+}
+
+// RelayResult is the CLI view of one relay pass.
+type RelayResult struct {
+	Delivered int `json:"delivered"`
+	Failed    int `json:"failed"`
+	Dead      int `json:"dead"`
+}
+
+func ToRelayResult(result models.RelayResult) RelayResult {
+	return RelayResult(result)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
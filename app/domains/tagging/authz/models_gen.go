@@ -47,6 +47,8 @@ func ValidateEntity(entity cedar.Entity) error {
 }
 
 var (
+	ActionCheck   = cedar.NewEntityUID(ActionType, "check")
+	ActionPurge   = cedar.NewEntityUID(ActionType, "purge")
 	ActionShow    = cedar.NewEntityUID(ActionType, "show")
 	ActionSummary = cedar.NewEntityUID(ActionType, "summary")
 )
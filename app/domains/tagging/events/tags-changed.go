@@ -0,0 +1,15 @@
+package events
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// TagsChanged records one tag mutation of Target with its complete tag sets
+// before and after, so the change can be reversed without knowing which
+// operation made it.
+type TagsChanged struct {
+	Target cedar.EntityUID
+	Before tag.Tags
+	After  tag.Tags
+}
@@ -0,0 +1,79 @@
+package tagging
+
+import (
+	taggingauthz "github.com/TheFellow/go-modular-monolith/app/domains/tagging/authz"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/set"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// TaggedTargets returns every entity that carries at least one tag, whether or
+// not it is still active, in the order the repository stores them. Integrity
+// checks compare the result with the entities their owning domains hold.
+func (m *Module) TaggedTargets(ctx *middleware.Context) ([]cedar.EntityUID, error) {
+	resource := taggingauthz.TagDiscovery{
+		UID: cedar.NewEntityUID(taggingauthz.TagDiscoveryType, "check"),
+	}
+	result, err := middleware.RunEntityQuery(m.pipeline, ctx, taggingauthz.ActionCheck,
+		func(queryCtx store.Context, _ struct{}) (discoveryResult[[]cedar.EntityUID], error) {
+			associations, err := m.repository.all(queryCtx)
+			if err != nil {
+				return discoveryResult[[]cedar.EntityUID]{}, err
+			}
+			var seen set.Set[cedar.EntityUID]
+			var targets []cedar.EntityUID
+			for _, association := range associations {
+				if seen.Contains(association.target) {
+					continue
+				}
+				seen.Add(association.target)
+				targets = append(targets, association.target)
+			}
+			return discoveryResult[[]cedar.EntityUID]{value: targets, entity: resource.CedarEntity()}, nil
+		}, struct{}{})
+	if err != nil {
+		return nil, err
+	}
+	return result.value, nil
+}
+
+// Purge deletes every tag of a target whose entity no longer exists and
+// returns how many were removed. Owning domains keep tags of soft-deleted
+// entities, so callers must confirm the entity is gone entirely; Purge itself
+// only refuses targets that are still active.
+func (m *Module) Purge(ctx *middleware.Context, target cedar.EntityUID) (int, error) {
+	registration, err := m.resolve(target)
+	if err != nil {
+		return 0, err
+	}
+	resource := taggingauthz.TagDiscovery{
+		UID: cedar.NewEntityUID(taggingauthz.TagDiscoveryType, cedar.String(target.String())),
+	}
+	result, err := middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[discoveryResult[int], discoveryResult[int]]{
+		Action:  taggingauthz.ActionPurge,
+		Request: struct{ Target cedar.EntityUID }{target},
+		Load: func(*middleware.Context) (discoveryResult[int], error) {
+			return discoveryResult[int]{entity: resource.CedarEntity()}, nil
+		},
+		Handle: func(ctx *middleware.Context, _ discoveryResult[int]) (discoveryResult[int], error) {
+			active, err := registration.Active(ctx, []cedar.String{target.ID})
+			if err != nil {
+				return discoveryResult[int]{}, err
+			}
+			if active.Contains(target.ID) {
+				return discoveryResult[int]{}, errors.FailedPreconditionf("%s still exists; remove its tags with untag", target)
+			}
+			deleted, err := m.repository.DeleteTarget(ctx, target)
+			if err != nil {
+				return discoveryResult[int]{}, err
+			}
+			return discoveryResult[int]{value: deleted, entity: resource.CedarEntity()}, nil
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.value, nil
+}
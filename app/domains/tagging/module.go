@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	taggingauthz "github.com/TheFellow/go-modular-monolith/app/domains/tagging/authz"
+	"github.com/TheFellow/go-modular-monolith/app/domains/tagging/events"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
@@ -25,7 +26,7 @@ func (r discoveryResult[T]) CedarEntity() cedar.Entity { return r.entity }
 type Result struct {
 	Target  cedar.EntityUID
 	Tags    tag.Tags
-	Changed bool
+	Changed bool `changes:"-"`
 	entity  cedar.Entity
 }
 
@@ -63,10 +64,15 @@ func (m *Module) Upsert(ctx *middleware.Context, target cedar.EntityUID, value t
 	}
 	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[targetState, Result]{
 		Action: registration.TagAction,
+		Request: struct {
+			Target cedar.EntityUID
+			Tag    tag.Tag
+		}{target, value},
 		Load: func(ctx *middleware.Context) (targetState, error) {
 			return loadState(ctx, registration, target)
 		},
-		Handle: func(ctx *middleware.Context, _ targetState) (Result, error) {
+		Before: beforeState,
+		Handle: func(ctx *middleware.Context, current targetState) (Result, error) {
 			changed, err := m.repository.Upsert(ctx, target, value)
 			if err != nil {
 				return Result{}, err
@@ -76,7 +82,7 @@ func (m *Module) Upsert(ctx *middleware.Context, target cedar.EntityUID, value t
 				return Result{}, err
 			}
 			if changed {
-				ctx.TouchEntity(target)
+				recordChange(ctx, current, state)
 			}
 			return resultFromState(state, changed), nil
 		},
@@ -107,13 +113,18 @@ func (m *Module) Replace(ctx *middleware.Context, target cedar.EntityUID, desire
 
 	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[targetState, Result]{
 		Action: registration.TagAction,
+		Request: struct {
+			Target cedar.EntityUID
+			Tags   tag.Tags
+		}{target, desired},
 		AuthorizationActions: func(current targetState) []cedar.EntityUID {
 			return replaceActions(registration, current.tags, desired)
 		},
 		Load: func(ctx *middleware.Context) (targetState, error) {
 			return loadState(ctx, registration, target)
 		},
-		Handle: func(ctx *middleware.Context, _ targetState) (Result, error) {
+		Before: beforeState,
+		Handle: func(ctx *middleware.Context, current targetState) (Result, error) {
 			changed, err := m.repository.Replace(ctx, target, desired)
 			if err != nil {
 				return Result{}, err
@@ -123,7 +134,7 @@ func (m *Module) Replace(ctx *middleware.Context, target cedar.EntityUID, desire
 				return Result{}, err
 			}
 			if changed {
-				ctx.TouchEntity(target)
+				recordChange(ctx, current, state)
 			}
 			return resultFromState(state, changed), nil
 		},
@@ -142,10 +153,15 @@ func (m *Module) Remove(ctx *middleware.Context, target cedar.EntityUID, key str
 	}
 	return middleware.RunCommand(m.pipeline, ctx, middleware.CommandSpec[targetState, Result]{
 		Action: registration.UntagAction,
+		Request: struct {
+			Target cedar.EntityUID
+			Key    string
+		}{target, key},
 		Load: func(ctx *middleware.Context) (targetState, error) {
 			return loadState(ctx, registration, target)
 		},
-		Handle: func(ctx *middleware.Context, _ targetState) (Result, error) {
+		Before: beforeState,
+		Handle: func(ctx *middleware.Context, current targetState) (Result, error) {
 			changed, err := m.repository.Remove(ctx, target, key)
 			if err != nil {
 				return Result{}, err
@@ -155,7 +171,7 @@ func (m *Module) Remove(ctx *middleware.Context, target cedar.EntityUID, key str
 				return Result{}, err
 			}
 			if changed {
-				ctx.TouchEntity(target)
+				recordChange(ctx, current, state)
 			}
 			return resultFromState(state, changed), nil
 		},
@@ -356,10 +372,21 @@ func loadState(ctx store.Context, registration Target, target cedar.EntityUID) (
 	return targetState{target: target, entity: loaded.Entity, name: name, tags: loaded.Tags}, nil
 }
 
+// recordChange touches a target whose tags changed and records the change.
+func recordChange(ctx *middleware.Context, before, after targetState) {
+	ctx.TouchEntity(after.target)
+	ctx.AddEvent(events.TagsChanged{Target: after.target, Before: before.tags, After: after.tags})
+}
+
 func resultFromState(state targetState, changed bool) Result {
 	return Result{Target: state.target, Tags: state.tags, Changed: changed, entity: state.entity}
 }
 
+// beforeState records the loaded tags as the prior state of a tag change.
+func beforeState(_ *middleware.Context, current targetState) (Result, error) {
+	return resultFromState(current, false), nil
+}
+
 func replaceActions(registration Target, current, desired tag.Tags) []cedar.EntityUID {
 	currentByKey := current.Map()
 	desiredByKey := desired.Map()
@@ -1,6 +1,8 @@
 package tagging
 
 import (
+	"slices"
+	"strings"
 	"sync"
 
 	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
@@ -25,6 +27,9 @@ type TargetState struct {
 	Entity      cedar.Entity
 	DisplayName string
 	Tags        tag.Tags
+	// Value is the domain record the state was loaded from, for callers that
+	// compare whole records rather than their Cedar attributes.
+	Value any
 }
 
 // Target describes the domain-owned authorization and loading behavior for a
@@ -35,6 +40,10 @@ type Target struct {
 	TagAction   cedar.EntityUID
 	UntagAction cedar.EntityUID
 	Load        LoadTarget
+	// LoadDeleted optionally loads the retained state of a soft-deleted
+	// entity, so the removal can be authorized like any other change. Nil
+	// means the type has no soft delete.
+	LoadDeleted LoadTarget
 	Active      ActiveTargets
 }
 
@@ -63,6 +72,32 @@ func (r *Registry) Register(target Target) {
 	r.targets[target.Type] = target
 }
 
+// Load returns the registration for uid's type and the entity's current state
+// without authorizing the caller.
+func (r *Registry) Load(ctx store.Context, uid cedar.EntityUID) (Target, TargetState, error) {
+	target, err := r.resolve(uid.Type)
+	if err != nil {
+		return Target{}, TargetState{}, err
+	}
+	state, err := target.Load(ctx, uid.ID)
+	return target, state, err
+}
+
+// LoadDeleted returns the registration for uid's type and a soft-deleted
+// entity's retained state without authorizing the caller. It returns NotFound
+// for live entities and for types without soft delete.
+func (r *Registry) LoadDeleted(ctx store.Context, uid cedar.EntityUID) (Target, TargetState, error) {
+	target, err := r.resolve(uid.Type)
+	if err != nil {
+		return Target{}, TargetState{}, err
+	}
+	if target.LoadDeleted == nil {
+		return Target{}, TargetState{}, errors.NotFoundf("%s not found", uid)
+	}
+	state, err := target.LoadDeleted(ctx, uid.ID)
+	return target, state, err
+}
+
 func (r *Registry) resolve(entityType cedar.EntityType) (Target, error) {
 	if r == nil {
 		return Target{}, errors.Internalf("tag target registry is required")
@@ -75,3 +110,15 @@ func (r *Registry) resolve(entityType cedar.EntityType) (Target, error) {
 	}
 	return target, nil
 }
+
+// registered returns every registered target, ordered by type.
+func (r *Registry) registered() []Target {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	targets := make([]Target, 0, len(r.targets))
+	for _, target := range r.targets {
+		targets = append(targets, target)
+	}
+	slices.SortFunc(targets, func(a, b Target) int { return strings.Compare(string(a.Type), string(b.Type)) })
+	return targets
+}
@@ -0,0 +1,34 @@
+package tagging
+
+import (
+	"github.com/TheFellow/go-modular-monolith/app/domains/tagging/events"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/undo"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// Compensations reverses the tag and untag actions of every registered
+// target type by replacing the target's tags with the set it had before. Call
+// it after the owning domains have registered their targets.
+func (m *Module) Compensations() []undo.Compensation {
+	var compensations []undo.Compensation
+	for _, target := range m.registry.registered() {
+		compensations = append(compensations,
+			undo.Compensation{Action: target.TagAction, Compensate: m.restoreTags},
+			undo.Compensation{Action: target.UntagAction, Compensate: m.restoreTags},
+		)
+	}
+	return compensations
+}
+
+func (m *Module) restoreTags(ctx *middleware.Context, recorded []any) ([]cedar.EntityUID, error) {
+	changed, err := undo.Find[events.TagsChanged](recorded)
+	if err != nil {
+		return nil, err
+	}
+	result, err := m.Replace(ctx, changed.Target, changed.Before)
+	if err != nil {
+		return nil, err
+	}
+	return []cedar.EntityUID{result.Target}, nil
+}
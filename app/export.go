@@ -0,0 +1,348 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	auditmodels "github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	drinksmodels "github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	ingredientsmodels "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	inventorymodels "github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
+	menusmodels "github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	ordersmodels "github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// Export reads every domain's aggregates, deleted ones included, into one
+// document. The reads share a transaction so the document is a consistent
+// snapshot, and exporting the audit log is itself audited there unless the
+// store is read-only. The caller must be allowed to export every entity; none
+// is silently left out.
+func (a *App) Export(ctx *middleware.Context) (*ExportDocument, error) {
+	if tx, ok := ctx.Transaction(); ok && tx != nil {
+		return a.export(ctx)
+	}
+	run := a.Store.Write
+	if a.Store.ReadOnly() {
+		run = a.Store.Read
+	}
+	var doc *ExportDocument
+	err := run(ctx, func(tx *store.Tx) error {
+		var err error
+		doc, err = a.export(ctx.WithTransaction(tx))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func (a *App) export(ctx *middleware.Context) (*ExportDocument, error) {
+	doc := &ExportDocument{Format: ExportFormat, Version: ExportVersion, ExportedAt: time.Now().UTC()}
+
+	ingredients, err := a.Ingredients.ExportAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, ingredient := range ingredients {
+		doc.Ingredients = append(doc.Ingredients, exportIngredient(ingredient))
+	}
+	drinks, err := a.Drinks.ExportAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, drink := range drinks {
+		doc.Drinks = append(doc.Drinks, exportDrink(drink))
+	}
+	stock, reservations, err := a.Inventory.ExportAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range stock {
+		doc.Inventory = append(doc.Inventory, exportStock(s))
+	}
+	for _, reservation := range reservations {
+		doc.Reservations = append(doc.Reservations, exportReservation(reservation))
+	}
+	menus, err := a.Menus.ExportAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, menu := range menus {
+		doc.Menus = append(doc.Menus, exportMenu(menu))
+	}
+	orders, err := a.Orders.ExportAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, order := range orders {
+		doc.Orders = append(doc.Orders, exportOrder(order))
+	}
+	entries, err := a.Audit.ExportAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		doc.Audit = append(doc.Audit, exportAuditEntry(entry))
+	}
+	return doc, nil
+}
+
+// ImportReport counts what an import recreated and lists every conflict with
+// data already in the target database.
+type ImportReport struct {
+	Ingredients  int
+	Drinks       int
+	Stock        int
+	Reservations int
+	Menus        int
+	Orders       int
+	AuditEntries int
+	Conflicts    []ImportConflict
+}
+
+// ImportConflict is a document entry the target database already holds, by
+// ID or by a name that must be unique.
+type ImportConflict struct {
+	Entity cedar.EntityUID
+	Reason string
+}
+
+// Import recreates the aggregates of an exported document through each
+// domain's import command, keeping IDs, versions, timestamps and tags. The
+// document is checked first: every reference must resolve within it, since an
+// export is complete. The whole import is one transaction. A conflicting entry
+// does not stop it, so the report lists every conflict, but any conflict rolls
+// everything back and fails the import with a conflict error.
+func (a *App) Import(ctx *middleware.Context, doc *ExportDocument) (ImportReport, error) {
+	plan, err := planImport(doc)
+	if err != nil {
+		return ImportReport{}, err
+	}
+	if tx, ok := ctx.Transaction(); ok && tx != nil {
+		return a.runImport(ctx, plan)
+	}
+	var report ImportReport
+	err = a.Store.Write(ctx, func(tx *store.Tx) error {
+		var err error
+		report, err = a.runImport(ctx.WithTransaction(tx), plan)
+		return err
+	})
+	return report, err
+}
+
+// importPlan is a document converted to the domains' models.
+type importPlan struct {
+	ingredients  []*ingredientsmodels.Ingredient
+	drinks       []*drinksmodels.Drink
+	stock        []*inventorymodels.Inventory
+	reservations []inventorymodels.Reservation
+	menus        []*menusmodels.Menu
+	orders       []*ordersmodels.Order
+	audit        []auditmodels.AuditEntry
+}
+
+func planImport(doc *ExportDocument) (importPlan, error) {
+	var plan importPlan
+	if doc == nil {
+		return plan, errors.Invalidf("export document is required")
+	}
+	if doc.Format != ExportFormat {
+		return plan, errors.Invalidf("not an export document: format is %q, want %q", doc.Format, ExportFormat)
+	}
+	if doc.Version != ExportVersion {
+		return plan, errors.Invalidf("unsupported export version %d (this binary reads version %d)", doc.Version, ExportVersion)
+	}
+
+	var err error
+	if plan.ingredients, err = convertAll("ingredients", doc.Ingredients, ExportedIngredient.toModel); err != nil {
+		return plan, err
+	}
+	if plan.drinks, err = convertAll("drinks", doc.Drinks, ExportedDrink.toModel); err != nil {
+		return plan, err
+	}
+	if plan.stock, err = convertAll("inventory", doc.Inventory, ExportedStock.toModel); err != nil {
+		return plan, err
+	}
+	if plan.reservations, err = convertAll("reservations", doc.Reservations, ExportedReservation.toModel); err != nil {
+		return plan, err
+	}
+	if plan.menus, err = convertAll("menus", doc.Menus, ExportedMenu.toModel); err != nil {
+		return plan, err
+	}
+	if plan.orders, err = convertAll("orders", doc.Orders, ExportedOrder.toModel); err != nil {
+		return plan, err
+	}
+	if plan.audit, err = convertAll("audit", doc.Audit, ExportedAuditEntry.toModel); err != nil {
+		return plan, err
+	}
+	if problems := plan.unresolved(); len(problems) > 0 {
+		return plan, errors.Invalidf("export document has %d unresolved references: %s", len(problems), strings.Join(problems, "; "))
+	}
+	return plan, nil
+}
+
+func convertAll[In, Out any](section string, entries []In, convert func(In) (Out, error)) ([]Out, error) {
+	out := make([]Out, 0, len(entries))
+	for i, entry := range entries {
+		converted, err := convert(entry)
+		if err != nil {
+			return nil, errors.Invalidf("%s[%d]: %w", section, i, err)
+		}
+		out = append(out, converted)
+	}
+	return out, nil
+}
+
+// unresolved lists duplicate entries and references to entities the document
+// does not contain.
+func (p importPlan) unresolved() []string {
+	var problems []string
+	seen := func(kind string, ids []string) map[string]bool {
+		set := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			if set[id] {
+				problems = append(problems, fmt.Sprintf("%s %s appears more than once", kind, id))
+			}
+			set[id] = true
+		}
+		return set
+	}
+	ingredients := seen("ingredient", collect(p.ingredients, func(i *ingredientsmodels.Ingredient) string { return i.ID.String() }))
+	drinks := seen("drink", collect(p.drinks, func(d *drinksmodels.Drink) string { return d.ID.String() }))
+	stock := seen("stock for ingredient", collect(p.stock, func(s *inventorymodels.Inventory) string { return s.IngredientID.String() }))
+	menus := seen("menu", collect(p.menus, func(m *menusmodels.Menu) string { return m.ID.String() }))
+	orders := seen("order", collect(p.orders, func(o *ordersmodels.Order) string { return o.ID.String() }))
+	seen("reservation", collect(p.reservations, func(r inventorymodels.Reservation) string {
+		return r.OrderID.String() + ":" + r.IngredientID.String()
+	}))
+	seen("audit entry", collect(p.audit, func(e auditmodels.AuditEntry) string { return e.ID.String() }))
+
+	missing := func(from string, set map[string]bool, kind string, id interface{ String() string }) {
+		if !set[id.String()] {
+			problems = append(problems, fmt.Sprintf("%s refers to missing %s %s", from, kind, id.String()))
+		}
+	}
+	for _, d := range p.drinks {
+		from := "drink " + d.ID.String()
+		for _, ingredient := range d.Recipe.Ingredients {
+			missing(from, ingredients, "ingredient", ingredient.IngredientID)
+			for _, substitute := range ingredient.Substitutes {
+				missing(from, ingredients, "ingredient", substitute)
+			}
+		}
+	}
+	for _, s := range p.stock {
+		missing("stock "+s.ID.String(), ingredients, "ingredient", s.IngredientID)
+	}
+	for _, m := range p.menus {
+		for _, item := range m.Items {
+			missing("menu "+m.ID.String(), drinks, "drink", item.DrinkID)
+		}
+	}
+	for _, o := range p.orders {
+		from := "order " + o.ID.String()
+		missing(from, menus, "menu", o.MenuID)
+		for _, item := range o.Items {
+			missing(from, drinks, "drink", item.DrinkID)
+		}
+		for _, used := range o.IngredientUsage {
+			missing(from, ingredients, "ingredient", used.IngredientID)
+		}
+		for _, blocked := range o.BlockedIngredients {
+			missing(from, ingredients, "ingredient", blocked)
+		}
+	}
+	for _, r := range p.reservations {
+		from := "reservation for order " + r.OrderID.String()
+		missing(from, orders, "order", r.OrderID)
+		missing(from, stock, "stock for ingredient", r.IngredientID)
+	}
+	return problems
+}
+
+func collect[T any](items []T, id func(T) string) []string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, id(item))
+	}
+	return ids
+}
+
+// runImport imports in dependency order. The audit log goes first because it
+// requires an empty log, and every import command below appends its own entry.
+func (a *App) runImport(ctx *middleware.Context, plan importPlan) (ImportReport, error) {
+	var report ImportReport
+	// record counts an imported entity, or lists a conflict and carries on.
+	record := func(count *int, uid cedar.EntityUID, err error) error {
+		switch {
+		case err == nil:
+			*count++
+			return nil
+		case errors.IsConflict(err):
+			report.Conflicts = append(report.Conflicts, ImportConflict{Entity: uid, Reason: err.Error()})
+			return nil
+		}
+		return err
+	}
+
+	if len(plan.audit) > 0 {
+		transfer, err := a.Audit.Import(ctx, plan.audit)
+		switch {
+		case err == nil:
+			report.AuditEntries = transfer.Entries
+		case errors.IsConflict(err):
+			chain := cedar.NewEntityUID(entity.TypeAuditEntry, auditmodels.ChainResourceID)
+			report.Conflicts = append(report.Conflicts, ImportConflict{Entity: chain, Reason: err.Error()})
+		default:
+			return report, err
+		}
+	}
+	for _, ingredient := range plan.ingredients {
+		_, err := a.Ingredients.Import(ctx, ingredient)
+		if err := record(&report.Ingredients, ingredient.EntityUID(), err); err != nil {
+			return report, err
+		}
+	}
+	for _, drink := range plan.drinks {
+		_, err := a.Drinks.Import(ctx, drink)
+		if err := record(&report.Drinks, drink.EntityUID(), err); err != nil {
+			return report, err
+		}
+	}
+	for _, stock := range plan.stock {
+		_, err := a.Inventory.Import(ctx, stock)
+		if err := record(&report.Stock, stock.EntityUID(), err); err != nil {
+			return report, err
+		}
+	}
+	for _, menu := range plan.menus {
+		_, err := a.Menus.Import(ctx, menu)
+		if err := record(&report.Menus, menu.EntityUID(), err); err != nil {
+			return report, err
+		}
+	}
+	for _, order := range plan.orders {
+		_, err := a.Orders.Import(ctx, order)
+		if err := record(&report.Orders, order.EntityUID(), err); err != nil {
+			return report, err
+		}
+	}
+	for _, reservation := range plan.reservations {
+		_, err := a.Inventory.ImportReservation(ctx, reservation)
+		if err := record(&report.Reservations, reservation.OrderID.EntityUID(), err); err != nil {
+			return report, err
+		}
+	}
+
+	if len(report.Conflicts) > 0 {
+		return report, errors.Conflictf("import found %d conflicts with existing data; nothing was imported", len(report.Conflicts))
+	}
+	return report, nil
+}
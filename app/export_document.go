@@ -0,0 +1,611 @@
+package app
+
+import (
+	"time"
+
+	auditmodels "github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	drinksmodels "github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	ingredientsmodels "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	inventorymodels "github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
+	menusmodels "github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	ordersmodels "github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/money"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/ownership"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+const (
+	// ExportFormat identifies a document written by Export.
+	ExportFormat = "mixology-export"
+	// ExportVersion is the document layout Export writes and Import reads.
+	// Bump it when a field changes meaning or is removed.
+	ExportVersion = 1
+)
+
+// ExportDocument is a complete, portable copy of the database. Every section
+// is converted from and to the domains' public models, so the layout does not
+// follow storage rows. Entities carry their tags; reservations are listed
+// apart from the stock they draw on because they name orders.
+type ExportDocument struct {
+	Format       string                `json:"format"`
+	Version      int                   `json:"version"`
+	ExportedAt   time.Time             `json:"exported_at"`
+	Ingredients  []ExportedIngredient  `json:"ingredients"`
+	Drinks       []ExportedDrink       `json:"drinks"`
+	Inventory    []ExportedStock       `json:"inventory"`
+	Reservations []ExportedReservation `json:"reservations"`
+	Menus        []ExportedMenu        `json:"menus"`
+	Orders       []ExportedOrder       `json:"orders"`
+	Audit        []ExportedAuditEntry  `json:"audit"`
+}
+
+type ExportedAmount struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+type ExportedIngredient struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Category    string     `json:"category"`
+	Unit        string     `json:"unit"`
+	Description string     `json:"description,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	Version     int64      `json:"version"`
+}
+
+type ExportedDrink struct {
+	ID          string                     `json:"id"`
+	Name        string                     `json:"name"`
+	Category    string                     `json:"category"`
+	Glass       string                     `json:"glass"`
+	Ingredients []ExportedRecipeIngredient `json:"ingredients"`
+	Steps       []string                   `json:"steps"`
+	Garnish     string                     `json:"garnish,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Status      string                     `json:"status"`
+	CreatedBy   string                     `json:"created_by"`
+	Owner       string                     `json:"owner"`
+	DeletedAt   *time.Time                 `json:"deleted_at,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Version     int64                      `json:"version"`
+}
+
+type ExportedRecipeIngredient struct {
+	IngredientID string         `json:"ingredient_id"`
+	Amount       ExportedAmount `json:"amount"`
+	Optional     bool           `json:"optional,omitempty"`
+	Substitutes  []string       `json:"substitutes,omitempty"`
+}
+
+type ExportedStock struct {
+	ID           string         `json:"id"`
+	IngredientID string         `json:"ingredient_id"`
+	Amount       ExportedAmount `json:"amount"`
+	CostPerUnit  *money.Price   `json:"cost_per_unit,omitempty"`
+	LastUpdated  time.Time      `json:"last_updated"`
+	Tags         []string       `json:"tags,omitempty"`
+	Version      int64          `json:"version"`
+}
+
+type ExportedReservation struct {
+	OrderID      string         `json:"order_id"`
+	IngredientID string         `json:"ingredient_id"`
+	Amount       ExportedAmount `json:"amount"`
+}
+
+type ExportedMenu struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Items       []ExportedMenuItem `json:"items"`
+	Status      string             `json:"status"`
+	CreatedBy   string             `json:"created_by"`
+	Owner       string             `json:"owner"`
+	CreatedAt   time.Time          `json:"created_at"`
+	PublishedAt *time.Time         `json:"published_at,omitempty"`
+	DeletedAt   *time.Time         `json:"deleted_at,omitempty"`
+	Tags        []string           `json:"tags,omitempty"`
+	Version     int64              `json:"version"`
+}
+
+type ExportedMenuItem struct {
+	DrinkID      string       `json:"drink_id"`
+	DisplayName  *string      `json:"display_name,omitempty"`
+	Price        *money.Price `json:"price,omitempty"`
+	Featured     bool         `json:"featured,omitempty"`
+	Availability string       `json:"availability"`
+	SortOrder    int          `json:"sort_order"`
+}
+
+type ExportedOrder struct {
+	ID                 string                    `json:"id"`
+	MenuID             string                    `json:"menu_id"`
+	Items              []ExportedOrderItem       `json:"items"`
+	IngredientUsage    []ExportedIngredientUsage `json:"ingredient_usage,omitempty"`
+	BlockedIngredients []string                  `json:"blocked_ingredients,omitempty"`
+	Status             string                    `json:"status"`
+	CreatedBy          string                    `json:"created_by"`
+	Owner              string                    `json:"owner"`
+	CreatedAt          time.Time                 `json:"created_at"`
+	CompletedAt        *time.Time                `json:"completed_at,omitempty"`
+	Notes              string                    `json:"notes,omitempty"`
+	DeletedAt          *time.Time                `json:"deleted_at,omitempty"`
+	Tags               []string                  `json:"tags,omitempty"`
+	Version            int64                     `json:"version"`
+}
+
+type ExportedOrderItem struct {
+	DrinkID  string `json:"drink_id"`
+	Quantity int    `json:"quantity"`
+	Notes    string `json:"notes,omitempty"`
+}
+
+type ExportedIngredientUsage struct {
+	IngredientID string         `json:"ingredient_id"`
+	Name         string         `json:"name"`
+	Amount       ExportedAmount `json:"amount"`
+}
+
+// ExportedAuditEntry keeps every field the chain hash covers, so an imported
+// log verifies against the hashes it was exported with.
+type ExportedAuditEntry struct {
+	ID            string            `json:"id"`
+	Action        string            `json:"action"`
+	Resource      cedar.EntityUID   `json:"resource"`
+	Principal     cedar.EntityUID   `json:"principal"`
+	CorrelationID string            `json:"correlation_id,omitempty"`
+	StartedAt     time.Time         `json:"started_at"`
+	CompletedAt   time.Time         `json:"completed_at"`
+	Success       bool              `json:"success"`
+	Error         string            `json:"error,omitempty"`
+	Touches       []cedar.EntityUID `json:"touches,omitempty"`
+	Changes       []ExportedChange  `json:"changes,omitempty"`
+	Sequence      int64             `json:"sequence"`
+	PreviousHash  string            `json:"previous_hash"`
+	Hash          string            `json:"hash"`
+}
+
+type ExportedChange struct {
+	Path   string `json:"path"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+func exportAmount(amount measurement.Amount) ExportedAmount {
+	if amount == nil {
+		return ExportedAmount{}
+	}
+	return ExportedAmount{Value: amount.Value(), Unit: string(amount.Unit())}
+}
+
+func (a ExportedAmount) toModel() (measurement.Amount, error) {
+	return measurement.NewAmount(a.Value, measurement.Unit(a.Unit))
+}
+
+func exportOptional[T any](value optional.Value[T]) *T {
+	if v, ok := value.Unwrap(); ok {
+		return &v
+	}
+	return nil
+}
+
+func importOptional[T any](value *T) optional.Value[T] {
+	if value == nil {
+		return optional.None[T]()
+	}
+	return optional.Some(*value)
+}
+
+func importTags(values []string) (tag.Tags, error) {
+	tags := make(tag.Tags, 0, len(values))
+	for _, value := range values {
+		parsed, err := tag.Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, parsed)
+	}
+	return tags, nil
+}
+
+func parseIDs[ID any](values []string, parse func(string) (ID, error)) ([]ID, error) {
+	ids := make([]ID, 0, len(values))
+	for _, value := range values {
+		id, err := parse(value)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func idStrings[ID interface{ String() string }](ids []ID) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, id.String())
+	}
+	return out
+}
+
+func exportIngredient(i *ingredientsmodels.Ingredient) ExportedIngredient {
+	return ExportedIngredient{
+		ID:          i.ID.String(),
+		Name:        i.Name,
+		Category:    string(i.Category),
+		Unit:        string(i.Unit),
+		Description: i.Description,
+		DeletedAt:   exportOptional(i.DeletedAt),
+		Tags:        i.Tags.Strings(),
+		Version:     i.Version,
+	}
+}
+
+func (e ExportedIngredient) toModel() (*ingredientsmodels.Ingredient, error) {
+	id, err := entity.ParseIngredientID(e.ID)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := importTags(e.Tags)
+	if err != nil {
+		return nil, err
+	}
+	return &ingredientsmodels.Ingredient{
+		ID:          id,
+		Name:        e.Name,
+		Category:    ingredientsmodels.Category(e.Category),
+		Unit:        measurement.Unit(e.Unit),
+		Description: e.Description,
+		DeletedAt:   importOptional(e.DeletedAt),
+		Tags:        tags,
+		Version:     e.Version,
+	}, nil
+}
+
+func exportDrink(d *drinksmodels.Drink) ExportedDrink {
+	ingredients := make([]ExportedRecipeIngredient, 0, len(d.Recipe.Ingredients))
+	for _, ingredient := range d.Recipe.Ingredients {
+		ingredients = append(ingredients, ExportedRecipeIngredient{
+			IngredientID: ingredient.IngredientID.String(),
+			Amount:       exportAmount(ingredient.Amount),
+			Optional:     ingredient.Optional,
+			Substitutes:  idStrings(ingredient.Substitutes),
+		})
+	}
+	return ExportedDrink{
+		ID:          d.ID.String(),
+		Name:        d.Name,
+		Category:    string(d.Category),
+		Glass:       string(d.Glass),
+		Ingredients: ingredients,
+		Steps:       d.Recipe.Steps,
+		Garnish:     d.Recipe.Garnish,
+		Description: d.Description,
+		Status:      string(d.Status),
+		CreatedBy:   d.Ownership.CreatedByID(),
+		Owner:       d.Ownership.OwnerID(),
+		DeletedAt:   exportOptional(d.DeletedAt),
+		Tags:        d.Tags.Strings(),
+		Version:     d.Version,
+	}
+}
+
+func (e ExportedDrink) toModel() (*drinksmodels.Drink, error) {
+	id, err := entity.ParseDrinkID(e.ID)
+	if err != nil {
+		return nil, err
+	}
+	ingredients := make([]drinksmodels.RecipeIngredient, 0, len(e.Ingredients))
+	for _, ingredient := range e.Ingredients {
+		ingredientID, err := entity.ParseIngredientID(ingredient.IngredientID)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := ingredient.Amount.toModel()
+		if err != nil {
+			return nil, err
+		}
+		substitutes, err := parseIDs(ingredient.Substitutes, entity.ParseIngredientID)
+		if err != nil {
+			return nil, err
+		}
+		ingredients = append(ingredients, drinksmodels.RecipeIngredient{
+			IngredientID: ingredientID, Amount: amount, Optional: ingredient.Optional, Substitutes: substitutes,
+		})
+	}
+	tags, err := importTags(e.Tags)
+	if err != nil {
+		return nil, err
+	}
+	return &drinksmodels.Drink{
+		ID:          id,
+		Name:        e.Name,
+		Category:    drinksmodels.DrinkCategory(e.Category),
+		Glass:       drinksmodels.GlassType(e.Glass),
+		Recipe:      drinksmodels.Recipe{Ingredients: ingredients, Steps: e.Steps, Garnish: e.Garnish},
+		Description: e.Description,
+		Status:      drinksmodels.Status(e.Status),
+		Ownership:   ownership.Restore(e.CreatedBy, e.Owner),
+		DeletedAt:   importOptional(e.DeletedAt),
+		Tags:        tags,
+		Version:     e.Version,
+	}, nil
+}
+
+func exportStock(s *inventorymodels.Inventory) ExportedStock {
+	return ExportedStock{
+		ID:           s.ID.String(),
+		IngredientID: s.IngredientID.String(),
+		Amount:       exportAmount(s.Amount),
+		CostPerUnit:  exportOptional(s.CostPerUnit),
+		LastUpdated:  s.LastUpdated,
+		Tags:         s.Tags.Strings(),
+		Version:      s.Version,
+	}
+}
+
+func (e ExportedStock) toModel() (*inventorymodels.Inventory, error) {
+	id, err := entity.ParseInventoryID(e.ID)
+	if err != nil {
+		return nil, err
+	}
+	ingredientID, err := entity.ParseIngredientID(e.IngredientID)
+	if err != nil {
+		return nil, err
+	}
+	amount, err := e.Amount.toModel()
+	if err != nil {
+		return nil, err
+	}
+	tags, err := importTags(e.Tags)
+	if err != nil {
+		return nil, err
+	}
+	return &inventorymodels.Inventory{
+		ID:           id,
+		IngredientID: ingredientID,
+		Amount:       amount,
+		CostPerUnit:  importOptional(e.CostPerUnit),
+		LastUpdated:  e.LastUpdated,
+		Tags:         tags,
+		Version:      e.Version,
+	}, nil
+}
+
+func exportReservation(r inventorymodels.Reservation) ExportedReservation {
+	return ExportedReservation{OrderID: r.OrderID.String(), IngredientID: r.IngredientID.String(), Amount: exportAmount(r.Amount)}
+}
+
+func (e ExportedReservation) toModel() (inventorymodels.Reservation, error) {
+	orderID, err := entity.ParseOrderID(e.OrderID)
+	if err != nil {
+		return inventorymodels.Reservation{}, err
+	}
+	ingredientID, err := entity.ParseIngredientID(e.IngredientID)
+	if err != nil {
+		return inventorymodels.Reservation{}, err
+	}
+	amount, err := e.Amount.toModel()
+	if err != nil {
+		return inventorymodels.Reservation{}, err
+	}
+	return inventorymodels.Reservation{OrderID: orderID, IngredientID: ingredientID, Amount: amount}, nil
+}
+
+func exportMenu(m *menusmodels.Menu) ExportedMenu {
+	items := make([]ExportedMenuItem, 0, len(m.Items))
+	for _, item := range m.Items {
+		items = append(items, ExportedMenuItem{
+			DrinkID:      item.DrinkID.String(),
+			DisplayName:  exportOptional(item.DisplayName),
+			Price:        exportOptional(item.Price),
+			Featured:     item.Featured,
+			Availability: string(item.Availability),
+			SortOrder:    item.SortOrder,
+		})
+	}
+	return ExportedMenu{
+		ID:          m.ID.String(),
+		Name:        m.Name,
+		Description: m.Description,
+		Items:       items,
+		Status:      string(m.Status),
+		CreatedBy:   m.Ownership.CreatedByID(),
+		Owner:       m.Ownership.OwnerID(),
+		CreatedAt:   m.CreatedAt,
+		PublishedAt: exportOptional(m.PublishedAt),
+		DeletedAt:   exportOptional(m.DeletedAt),
+		Tags:        m.Tags.Strings(),
+		Version:     m.Version,
+	}
+}
+
+func (e ExportedMenu) toModel() (*menusmodels.Menu, error) {
+	id, err := entity.ParseMenuID(e.ID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]menusmodels.MenuItem, 0, len(e.Items))
+	for _, item := range e.Items {
+		drinkID, err := entity.ParseDrinkID(item.DrinkID)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, menusmodels.MenuItem{
+			DrinkID:      drinkID,
+			DisplayName:  importOptional(item.DisplayName),
+			Price:        importOptional(item.Price),
+			Featured:     item.Featured,
+			Availability: menusmodels.Availability(item.Availability),
+			SortOrder:    item.SortOrder,
+		})
+	}
+	tags, err := importTags(e.Tags)
+	if err != nil {
+		return nil, err
+	}
+	return &menusmodels.Menu{
+		ID:          id,
+		Name:        e.Name,
+		Description: e.Description,
+		Items:       items,
+		Status:      menusmodels.MenuStatus(e.Status),
+		Ownership:   ownership.Restore(e.CreatedBy, e.Owner),
+		CreatedAt:   e.CreatedAt,
+		PublishedAt: importOptional(e.PublishedAt),
+		DeletedAt:   importOptional(e.DeletedAt),
+		Tags:        tags,
+		Version:     e.Version,
+	}, nil
+}
+
+func exportOrder(o *ordersmodels.Order) ExportedOrder {
+	items := make([]ExportedOrderItem, 0, len(o.Items))
+	for _, item := range o.Items {
+		items = append(items, ExportedOrderItem{DrinkID: item.DrinkID.String(), Quantity: item.Quantity, Notes: item.Notes})
+	}
+	usage := make([]ExportedIngredientUsage, 0, len(o.IngredientUsage))
+	for _, used := range o.IngredientUsage {
+		usage = append(usage, ExportedIngredientUsage{IngredientID: used.IngredientID.String(), Name: used.Name, Amount: exportAmount(used.Amount)})
+	}
+	return ExportedOrder{
+		ID:                 o.ID.String(),
+		MenuID:             o.MenuID.String(),
+		Items:              items,
+		IngredientUsage:    usage,
+		BlockedIngredients: idStrings(o.BlockedIngredients),
+		Status:             string(o.Status),
+		CreatedBy:          o.Ownership.CreatedByID(),
+		Owner:              o.Ownership.OwnerID(),
+		CreatedAt:          o.CreatedAt,
+		CompletedAt:        exportOptional(o.CompletedAt),
+		Notes:              o.Notes,
+		DeletedAt:          exportOptional(o.DeletedAt),
+		Tags:               o.Tags.Strings(),
+		Version:            o.Version,
+	}
+}
+
+func (e ExportedOrder) toModel() (*ordersmodels.Order, error) {
+	id, err := entity.ParseOrderID(e.ID)
+	if err != nil {
+		return nil, err
+	}
+	menuID, err := entity.ParseMenuID(e.MenuID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]ordersmodels.OrderItem, 0, len(e.Items))
+	for _, item := range e.Items {
+		drinkID, err := entity.ParseDrinkID(item.DrinkID)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, ordersmodels.OrderItem{DrinkID: drinkID, Quantity: item.Quantity, Notes: item.Notes})
+	}
+	usage := make([]ordersmodels.IngredientUsage, 0, len(e.IngredientUsage))
+	for _, used := range e.IngredientUsage {
+		ingredientID, err := entity.ParseIngredientID(used.IngredientID)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := used.Amount.toModel()
+		if err != nil {
+			return nil, err
+		}
+		usage = append(usage, ordersmodels.IngredientUsage{IngredientID: ingredientID, Name: used.Name, Amount: amount})
+	}
+	blocked, err := parseIDs(e.BlockedIngredients, entity.ParseIngredientID)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := importTags(e.Tags)
+	if err != nil {
+		return nil, err
+	}
+	return &ordersmodels.Order{
+		ID:                 id,
+		MenuID:             menuID,
+		Items:              items,
+		IngredientUsage:    usage,
+		BlockedIngredients: blocked,
+		Status:             ordersmodels.OrderStatus(e.Status),
+		Ownership:          ownership.Restore(e.CreatedBy, e.Owner),
+		CreatedAt:          e.CreatedAt,
+		CompletedAt:        importOptional(e.CompletedAt),
+		Notes:              e.Notes,
+		DeletedAt:          importOptional(e.DeletedAt),
+		Tags:               tags,
+		Version:            e.Version,
+	}, nil
+}
+
+func exportAuditEntry(e auditmodels.AuditEntry) ExportedAuditEntry {
+	return ExportedAuditEntry{
+		ID:            e.ID.String(),
+		Action:        e.Action,
+		Resource:      e.Resource,
+		Principal:     e.Principal,
+		CorrelationID: e.CorrelationID,
+		StartedAt:     e.StartedAt,
+		CompletedAt:   e.CompletedAt,
+		Success:       e.Success,
+		Error:         e.Error,
+		Touches:       e.Touches,
+		Changes:       exportChanges(e.Changes),
+		Sequence:      e.Sequence,
+		PreviousHash:  e.PreviousHash,
+		Hash:          e.Hash,
+	}
+}
+
+func exportChanges(changes []auditmodels.Change) []ExportedChange {
+	if len(changes) == 0 {
+		return nil
+	}
+	out := make([]ExportedChange, 0, len(changes))
+	for _, change := range changes {
+		out = append(out, ExportedChange(change))
+	}
+	return out
+}
+
+func (e ExportedAuditEntry) toModel() (auditmodels.AuditEntry, error) {
+	id, err := entity.ParseAuditEntryID(e.ID)
+	if err != nil {
+		return auditmodels.AuditEntry{}, err
+	}
+	return auditmodels.AuditEntry{
+		ID:            id,
+		Action:        e.Action,
+		Resource:      e.Resource,
+		Principal:     e.Principal,
+		CorrelationID: e.CorrelationID,
+		StartedAt:     e.StartedAt,
+		CompletedAt:   e.CompletedAt,
+		Success:       e.Success,
+		Error:         e.Error,
+		Touches:       e.Touches,
+		Changes:       importChanges(e.Changes),
+		Sequence:      e.Sequence,
+		PreviousHash:  e.PreviousHash,
+		Hash:          e.Hash,
+	}, nil
+}
+
+func importChanges(changes []ExportedChange) []auditmodels.Change {
+	if len(changes) == 0 {
+		return nil
+	}
+	out := make([]auditmodels.Change, 0, len(changes))
+	for _, change := range changes {
+		out = append(out, auditmodels.Change(change))
+	}
+	return out
+}
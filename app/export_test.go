@@ -0,0 +1,124 @@
+package app_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app"
+	drinksmodels "github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	ingredientsmodels "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	inventorymodels "github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
+	ordersmodels "github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/currency"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/money"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+// exportFixture populates every exported section: a tagged ingredient, a
+// retired one, a drink, stock, a published menu and an order holding a
+// reservation.
+func exportFixture(t *testing.T) *testutil.Fixture {
+	t.Helper()
+	f := testutil.NewFixture(t)
+	gin := testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{
+		Name: "Export Gin", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitOz,
+	})
+	retired := testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{
+		Name: "Export Genever", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitOz,
+	})
+	_, err := f.Ingredients.Delete(f.OwnerContext(), retired.ID)
+	testutil.Ok(t, err)
+	_, err = f.App.ReplaceTags(gin.EntityUID(), tag.Tags{{Key: "region", Value: "east"}})
+	testutil.Ok(t, err)
+	drink := testutil.CreateDrink(t, f, drinksmodels.Drink{
+		Name: "Export Gimlet", Category: drinksmodels.DrinkCategoryCocktail, Glass: drinksmodels.GlassTypeCoupe,
+		Recipe: drinksmodels.Recipe{
+			Ingredients: []drinksmodels.RecipeIngredient{{IngredientID: gin.ID, Amount: measurement.MustAmount(2, measurement.UnitOz)}},
+			Steps:       []string{"Shake"},
+		},
+	})
+	testutil.SetInventory(t, f, inventorymodels.Update{
+		IngredientID: gin.ID, Amount: measurement.MustAmount(10, measurement.UnitOz),
+		CostPerUnit: money.NewPriceFromCents(150, currency.USD),
+	})
+	menu := testutil.CreateMenu(t, f, "Export Menu", testutil.WithDrink(drink), testutil.Published())
+	testutil.PlaceOrder(t, f, ordersmodels.Order{MenuID: menu.ID, Items: []ordersmodels.OrderItem{{DrinkID: drink.ID, Quantity: 1}}})
+	return f
+}
+
+// roundTrip passes doc through JSON as the CLI does.
+func roundTrip(t *testing.T, doc *app.ExportDocument) *app.ExportDocument {
+	t.Helper()
+	data, err := json.Marshal(doc)
+	testutil.Ok(t, err)
+	var decoded app.ExportDocument
+	testutil.Ok(t, json.Unmarshal(data, &decoded))
+	return &decoded
+}
+
+func TestApp_ImportRecreatesExportedDatabase(t *testing.T) {
+	t.Parallel()
+	source := exportFixture(t)
+	doc, err := source.App.Export(source.OwnerContext())
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(doc.Ingredients), 2)
+	testutil.Equals(t, len(doc.Reservations), 1)
+
+	target := testutil.NewFixture(t)
+	report, err := target.App.Import(target.OwnerContext(), roundTrip(t, doc))
+	testutil.Ok(t, err)
+	testutil.Equals(t, report, app.ImportReport{
+		Ingredients: 2, Drinks: 1, Stock: 1, Reservations: 1, Menus: 1, Orders: 1, AuditEntries: len(doc.Audit),
+	})
+
+	again, err := target.App.Export(target.OwnerContext())
+	testutil.Ok(t, err)
+	testutil.Equals(t, again.Ingredients, doc.Ingredients)
+	testutil.Equals(t, again.Drinks, doc.Drinks)
+	testutil.Equals(t, again.Inventory, doc.Inventory)
+	testutil.Equals(t, again.Reservations, doc.Reservations)
+	testutil.Equals(t, again.Menus, doc.Menus)
+	testutil.Equals(t, again.Orders, doc.Orders)
+	testutil.Equals(t, again.Audit[:len(doc.Audit)], roundTrip(t, doc).Audit)
+
+	chain, err := target.Audit.Verify(target.OwnerContext())
+	testutil.Ok(t, err)
+	testutil.Nil(t, chain.Broken)
+}
+
+func TestApp_ImportReportsConflictsAndKeepsNothing(t *testing.T) {
+	t.Parallel()
+	f := exportFixture(t)
+	doc, err := f.App.Export(f.OwnerContext())
+	testutil.Ok(t, err)
+
+	report, err := f.App.Import(f.OwnerContext(), doc)
+	testutil.ErrorIsConflict(t, err)
+	// The audit log and all seven entities are already present.
+	testutil.Equals(t, len(report.Conflicts), 8)
+
+	after, err := f.App.Export(f.OwnerContext())
+	testutil.Ok(t, err)
+	testutil.Equals(t, after.Ingredients, doc.Ingredients)
+	testutil.Equals(t, len(after.Audit), len(doc.Audit)+1)
+}
+
+func TestApp_ImportRejectsUnresolvedReferences(t *testing.T) {
+	t.Parallel()
+	source := exportFixture(t)
+	doc, err := source.App.Export(source.OwnerContext())
+	testutil.Ok(t, err)
+	doc.Ingredients = nil
+
+	target := testutil.NewFixture(t)
+	_, err = target.App.Import(target.OwnerContext(), doc)
+	testutil.ErrorIsInvalid(t, err)
+	testutil.ErrorContains(t, err, "refers to missing ingredient")
+
+	doc.Version = app.ExportVersion + 1
+	_, err = target.App.Import(target.OwnerContext(), doc)
+	testutil.IsTrue(t, errors.IsInvalid(err))
+}
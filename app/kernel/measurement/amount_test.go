@@ -1,6 +1,8 @@
 package measurement_test
 
 import (
+	"bytes"
+	"encoding/gob"
 	"math"
 	"testing"
 
@@ -40,3 +42,24 @@ func TestAmountAddDiscreteMismatch(t *testing.T) {
 	_, err := a.Add(b)
 	testutil.ErrorIsInvalid(t, err)
 }
+
+func TestAmountGobRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	type recipe struct {
+		Spirit  measurement.Amount
+		Garnish measurement.Amount
+	}
+	want := recipe{
+		Spirit:  measurement.MustAmount(1.5, measurement.UnitOz),
+		Garnish: measurement.MustAmount(2, measurement.UnitPiece),
+	}
+	var buf bytes.Buffer
+	testutil.Ok(t, gob.NewEncoder(&buf).Encode(want))
+
+	var got recipe
+	testutil.Ok(t, gob.NewDecoder(&buf).Decode(&got))
+	testutil.Equals(t, got.Spirit.String(), want.Spirit.String())
+	testutil.Equals(t, got.Garnish.String(), want.Garnish.String())
+	testutil.ErrorIf(t, math.Abs(got.Spirit.Value()-1.5) > 0.0001, "expected 1.5 oz, got %s", got.Spirit)
+}
@@ -0,0 +1,29 @@
+package measurement
+
+import (
+	"encoding/binary"
+	"encoding/gob"
+	"math"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+)
+
+// Amount fields are interfaces, so gob must know their concrete types to
+// decode persisted values such as recorded domain events.
+func init() {
+	gob.Register(VolumeAmount{})
+	gob.Register(DiscreteAmount{})
+}
+
+// GobEncode stores the volume in milliliters.
+func (v Volume) GobEncode() ([]byte, error) {
+	return binary.BigEndian.AppendUint64(nil, math.Float64bits(v.ml)), nil
+}
+
+func (v *Volume) GobDecode(data []byte) error {
+	if len(data) != 8 {
+		return errors.Invalidf("invalid encoded volume: %d bytes", len(data))
+	}
+	v.ml = math.Float64frombits(binary.BigEndian.Uint64(data))
+	return nil
+}
@@ -0,0 +1,65 @@
+// Package ownership records which actor created a resource and which actor
+// currently owns it. Ownership has no built-in authorization meaning; domains
+// expose it to Cedar and policies decide what an owner may do.
+package ownership
+
+import (
+	"github.com/TheFellow/go-modular-monolith/pkg/authn"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// Ownership pairs the immutable creator of a resource with its current owner.
+type Ownership struct {
+	CreatedBy cedar.EntityUID
+	Owner     cedar.EntityUID
+}
+
+// New returns the ownership of a resource created by creator, who also
+// becomes its first owner.
+func New(creator cedar.EntityUID) Ownership {
+	return Ownership{CreatedBy: creator, Owner: creator}
+}
+
+// Restore rebuilds ownership from persisted actor identifiers. Records written
+// before ownership was tracked belong to the owner actor, which already holds
+// every permission.
+func Restore(createdBy, owner string) Ownership {
+	return Ownership{CreatedBy: restoreActor(createdBy), Owner: restoreActor(owner)}
+}
+
+func restoreActor(id string) cedar.EntityUID {
+	if id == "" {
+		return authn.Owner()
+	}
+	return cedar.NewEntityUID(authn.Owner().Type, cedar.String(id))
+}
+
+// CreatedByID returns the persisted identifier of the creating actor.
+func (o Ownership) CreatedByID() string { return string(o.CreatedBy.ID) }
+
+// OwnerID returns the persisted identifier of the owning actor.
+func (o Ownership) OwnerID() string { return string(o.Owner.ID) }
+
+// TransferTo returns o owned by owner. The creator is preserved so policies can
+// continue to distinguish authorship from delegation.
+func (o Ownership) TransferTo(owner cedar.EntityUID) (Ownership, error) {
+	if err := ValidateOwner(owner); err != nil {
+		return Ownership{}, err
+	}
+	o.Owner = owner
+	return o, nil
+}
+
+// ValidateOwner reports whether owner is a named actor that may own resources.
+// Anonymous callers have no stable identity and therefore cannot own anything.
+func ValidateOwner(owner cedar.EntityUID) error {
+	actor, err := authn.ParseActor(string(owner.ID))
+	if owner.ID == "" || err != nil || actor != owner {
+		return errors.Invalidf("owner %s::%q is not a known actor", owner.Type, owner.ID)
+	}
+	if owner == authn.Anonymous() {
+		return errors.Invalidf("anonymous actors cannot own resources")
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package ownership_test
+
+import (
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app/kernel/ownership"
+	"github.com/TheFellow/go-modular-monolith/pkg/authn"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+func TestTransferToPreservesCreator(t *testing.T) {
+	t.Parallel()
+
+	created := ownership.New(authn.Bartender())
+	transferred, err := created.TransferTo(authn.Sommelier())
+	testutil.Ok(t, err)
+	testutil.Equals(t, transferred, ownership.Ownership{CreatedBy: authn.Bartender(), Owner: authn.Sommelier()})
+}
+
+func TestTransferToRejectsUnknownAndAnonymousOwners(t *testing.T) {
+	t.Parallel()
+
+	created := ownership.New(authn.Manager())
+	for _, owner := range []cedar.EntityUID{
+		{},
+		authn.Anonymous(),
+		cedar.NewEntityUID(authn.Owner().Type, "nobody"),
+		cedar.NewEntityUID("Mixology::Drink", "manager"),
+	} {
+		_, err := created.TransferTo(owner)
+		testutil.ErrorIsInvalid(t, err)
+	}
+}
+
+func TestRestoreDefaultsLegacyRecordsToOwner(t *testing.T) {
+	t.Parallel()
+
+	testutil.Equals(t, ownership.Restore("", ""), ownership.New(authn.Owner()))
+	restored := ownership.Restore("bartender", "sommelier")
+	testutil.Equals(t, restored, ownership.Ownership{CreatedBy: authn.Bartender(), Owner: authn.Sommelier()})
+	testutil.Equals(t, restored.CreatedByID(), "bartender")
+	testutil.Equals(t, restored.OwnerID(), "sommelier")
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/log"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
+)
+
+// CollectMetrics refreshes every domain gauge that is computed from stored
+// state rather than recorded as operations happen: stock per ingredient
+// category, open orders per status, and menus with readiness findings. A
+// failing domain keeps its previous values and does not stop the others.
+func (a *App) CollectMetrics(ctx context.Context) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, telemetry.SpanMetricsCollect)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	mctx := middleware.NewContext(ctx)
+	return errors.Join(
+		a.Inventory.CollectMetrics(mctx),
+		a.Orders.CollectMetrics(mctx),
+		a.Menus.CollectMetrics(mctx),
+	)
+}
+
+// StartMetricsCollector runs CollectMetrics now and then every interval in
+// the background until ctx is cancelled or the App is closed. Failures are
+// logged and retried on the next tick. Calling it again replaces the running
+// collector.
+func (a *App) StartMetricsCollector(ctx context.Context, interval time.Duration) {
+	a.stopMetricsCollector()
+
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := a.CollectMetrics(ctx); err != nil && ctx.Err() == nil {
+				log.FromContext(ctx).Warn("metrics collection failed", log.Err(err))
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	a.collectorMu.Lock()
+	a.stopCollector = func() {
+		cancel()
+		wg.Wait()
+	}
+	a.collectorMu.Unlock()
+}
+
+func (a *App) stopMetricsCollector() {
+	a.collectorMu.Lock()
+	stop := a.stopCollector
+	a.stopCollector = nil
+	a.collectorMu.Unlock()
+	if stop != nil {
+		stop()
+	}
+}
@@ -0,0 +1,111 @@
+package app_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	drinksmodels "github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	ingredientsauthz "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/authz"
+	ingredientsmodels "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	inventorymodels "github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
+	menusmodels "github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	ordersmodels "github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/currency"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/money"
+	"github.com/TheFellow/go-modular-monolith/pkg/authn"
+	"github.com/TheFellow/go-modular-monolith/pkg/authz"
+	"github.com/TheFellow/go-modular-monolith/pkg/log"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/teststore"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestApp_CollectMetricsPublishesDomainGauges(t *testing.T) {
+	t.Parallel()
+
+	metrics := telemetry.Memory()
+	baseCtx := authn.ToContext(context.Background(), authn.Owner())
+	baseCtx = log.ToContext(baseCtx, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	baseCtx = telemetry.WithMetrics(baseCtx, metrics)
+	a := openRestartTestApp(t, baseCtx, teststore.Path(t, "metrics.test"))
+	t.Cleanup(func() { testutil.Ok(t, a.Close()) })
+	ctx := middleware.NewContext(baseCtx)
+
+	ingredient, err := a.Ingredients.Create(ctx, &ingredientsmodels.Ingredient{
+		Name: "Metrics Gin", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitOz,
+	})
+	testutil.Ok(t, err)
+	_, err = a.Inventory.Set(ctx, &inventorymodels.Update{
+		IngredientID: ingredient.ID,
+		Amount:       measurement.MustAmount(5, ingredient.Unit),
+		CostPerUnit:  money.NewPriceFromCents(125, currency.USD),
+	})
+	testutil.Ok(t, err)
+	drink, err := a.Drinks.Create(ctx, &drinksmodels.Drink{
+		Name: "Metrics Martini", Category: drinksmodels.DrinkCategoryCocktail, Glass: drinksmodels.GlassTypeMartini,
+		Recipe: drinksmodels.Recipe{
+			Ingredients: []drinksmodels.RecipeIngredient{{
+				IngredientID: ingredient.ID,
+				Amount:       measurement.MustAmount(2, ingredient.Unit),
+			}},
+			Steps: []string{"Stir"},
+		},
+	})
+	testutil.Ok(t, err)
+	menu, err := a.Menus.Create(ctx, &menusmodels.Menu{Name: "Metrics Menu"})
+	testutil.Ok(t, err)
+	_, err = a.Menus.AddDrink(ctx, &menusmodels.MenuPatch{MenuID: menu.ID, DrinkID: drink.ID})
+	testutil.Ok(t, err)
+	_, err = a.Menus.Publish(ctx, &menusmodels.Menu{ID: menu.ID})
+	testutil.Ok(t, err)
+	order, err := a.Orders.Place(ctx, &ordersmodels.Order{
+		MenuID: menu.ID,
+		Items:  []ordersmodels.OrderItem{{DrinkID: drink.ID, Quantity: 2}},
+	})
+	testutil.Ok(t, err)
+
+	testutil.Ok(t, a.CollectMetrics(baseCtx))
+	spirit, oz := string(ingredientsmodels.CategorySpirit), string(measurement.UnitOz)
+	testutil.Equals(t, metrics.GaugeValue(telemetry.MetricInventoryOnHand, spirit, oz), 5.0)
+	testutil.Equals(t, metrics.GaugeValue(telemetry.MetricInventoryReserved, spirit, oz), 4.0)
+	testutil.Equals(t, metrics.GaugeValue(telemetry.MetricInventoryAvailable, spirit, oz), 1.0, cmpopts.EquateApprox(0, 1e-9))
+	testutil.Equals(t, metrics.GaugeValue(telemetry.MetricOrdersOpen, string(ordersmodels.OrderStatusPending)), 1.0)
+	testutil.Equals(t, metrics.GaugeValue(telemetry.MetricOrdersOpen, string(ordersmodels.OrderStatusBlocked)), 0.0)
+	testutil.Equals(t, metrics.HistogramCount(telemetry.MetricOrderFulfillmentDuration), 0)
+
+	_, err = a.Orders.Complete(ctx, &ordersmodels.Order{ID: order.ID})
+	testutil.Ok(t, err)
+	testutil.Equals(t, metrics.HistogramCount(telemetry.MetricOrderFulfillmentDuration), 1)
+
+	testutil.Ok(t, a.CollectMetrics(baseCtx))
+	testutil.Equals(t, metrics.GaugeValue(telemetry.MetricInventoryOnHand, spirit, oz), 1.0, cmpopts.EquateApprox(0, 1e-9))
+	testutil.Equals(t, metrics.GaugeValue(telemetry.MetricInventoryReserved, spirit, oz), 0.0)
+	testutil.Equals(t, metrics.GaugeValue(telemetry.MetricOrdersOpen, string(ordersmodels.OrderStatusPending)), 0.0)
+	testutil.Equals(t, metrics.GaugeValue(telemetry.MetricMenusWithFindings, string(menusmodels.ReadinessBlocker)), 1.0)
+}
+
+func TestApp_AuthorizationDenialsAreCountedByAction(t *testing.T) {
+	t.Parallel()
+
+	metrics := telemetry.Memory()
+	baseCtx := authn.ToContext(context.Background(), authn.Owner())
+	baseCtx = log.ToContext(baseCtx, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	baseCtx = telemetry.WithMetrics(baseCtx, metrics)
+	a := openRestartTestApp(t, baseCtx, teststore.Path(t, "denials.test"))
+	t.Cleanup(func() { testutil.Ok(t, a.Close()) })
+
+	anonymous := middleware.NewContext(authn.ToContext(baseCtx, authn.Anonymous()))
+	_, err := a.Ingredients.Create(anonymous, &ingredientsmodels.Ingredient{
+		Name: "Denied Gin", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitOz,
+	})
+	testutil.ErrorIsPermission(t, err)
+
+	action := authz.ActionLabel(ingredientsauthz.ActionCreate)
+	testutil.Equals(t, metrics.CounterValue(telemetry.MetricAuthZDenied, action), 1.0)
+	testutil.Equals(t, metrics.CounterValue(telemetry.MetricAuthZTotal, action, "deny"), 1.0)
+}
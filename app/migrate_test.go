@@ -0,0 +1,65 @@
+package app_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app"
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit"
+	"github.com/TheFellow/go-modular-monolith/app/domains/drinks"
+	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients"
+	"github.com/TheFellow/go-modular-monolith/app/domains/inventory"
+	"github.com/TheFellow/go-modular-monolith/app/domains/menus"
+	"github.com/TheFellow/go-modular-monolith/app/domains/orders"
+	"github.com/TheFellow/go-modular-monolith/pkg/authn"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/log"
+	"github.com/TheFellow/go-modular-monolith/pkg/migrate"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/teststore"
+)
+
+func TestApp_NewAppliesMigrationsAndRefusesNewerDatabase(t *testing.T) {
+	t.Parallel()
+
+	ctx := authn.ToContext(context.Background(), authn.Owner())
+	ctx = log.ToContext(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	path := teststore.Path(t, "migrate.test")
+
+	a := openRestartTestApp(t, ctx, path)
+	statuses, err := a.Migrations.Status(ctx)
+	testutil.Ok(t, err)
+	testutil.IsTrue(t, len(statuses) > 0)
+	for _, status := range statuses {
+		testutil.IsFalse(t, status.Pending())
+	}
+	testutil.Ok(t, a.Close())
+
+	// A newer binary records a migration this one has never heard of.
+	s, err := store.Open(ctx, path)
+	testutil.Ok(t, err)
+	migrate.Register(ctx, s)
+	future, err := migrate.New(s,
+		audit.Migrations(), drinks.Migrations(), ingredients.Migrations(),
+		inventory.Migrations(), menus.Migrations(), orders.Migrations(),
+		migrate.Domain{Name: "future", Migrations: []migrate.Migration{{
+			Version: 1, Name: "reshape everything", Up: func(context.Context, *store.Tx) error { return nil },
+		}}},
+	)
+	testutil.Ok(t, err)
+	_, err = future.Up(ctx)
+	testutil.Ok(t, err)
+
+	testutil.Ok(t, s.Close())
+
+	for _, deferred := range []bool{false, true} {
+		s, err := store.Open(ctx, path)
+		testutil.Ok(t, err)
+		_, err = app.New(ctx, app.Config{Store: s, DeferMigrations: deferred})
+		testutil.IsTrue(t, errors.IsFailedPrecondition(err))
+		testutil.Ok(t, s.Close())
+	}
+}
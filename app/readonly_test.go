@@ -0,0 +1,79 @@
+package app_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app"
+	"github.com/TheFellow/go-modular-monolith/app/domains/audit"
+	ingredientsmodels "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/pkg/authn"
+	"github.com/TheFellow/go-modular-monolith/pkg/log"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/teststore"
+)
+
+func TestApp_ReadOnlyServesQueriesAndRefusesCommandsBesideWriter(t *testing.T) {
+	t.Parallel()
+
+	baseCtx := authn.ToContext(context.Background(), authn.Owner())
+	baseCtx = log.ToContext(baseCtx, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	path := teststore.Path(t, "readonly.test")
+
+	writer := openRestartTestApp(t, baseCtx, path)
+	t.Cleanup(func() { testutil.Ok(t, writer.Close()) })
+	ctx := middleware.NewContext(baseCtx)
+	gin, err := writer.Ingredients.Create(ctx, &ingredientsmodels.Ingredient{
+		Name: "Read-Only Gin", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitOz,
+	})
+	testutil.Ok(t, err)
+
+	// The writer stays open: the reader must neither wait for it nor block it.
+	s, err := store.Open(baseCtx, path, store.ReadOnly())
+	testutil.Ok(t, err)
+	reader, err := app.New(baseCtx, app.Config{Store: s})
+	testutil.Ok(t, err)
+	t.Cleanup(func() { testutil.Ok(t, reader.Close()) })
+
+	got, err := reader.Ingredients.Get(middleware.NewContext(baseCtx), gin.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, got.Name, gin.Name)
+	// The export's audit entry cannot be written, so it is logged instead.
+	var warnings bytes.Buffer
+	exportCtx := log.ToContext(baseCtx, slog.New(slog.NewTextHandler(&warnings, &slog.HandlerOptions{Level: slog.LevelWarn})))
+	_, err = reader.Export(middleware.NewContext(exportCtx))
+	testutil.Ok(t, err)
+	testutil.StringContains(t, warnings.String(), "command not audited")
+	testutil.StringContains(t, warnings.String(), `Mixology::AuditEntry::Action::\"export\"`)
+	testutil.StringContains(t, warnings.String(), "success=true")
+
+	// A check only reads; repairing needs the writer.
+	report, err := reader.Check(middleware.NewContext(baseCtx), app.CheckOptions{})
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(report.Violations), 0)
+	_, err = reader.Check(middleware.NewContext(baseCtx), app.CheckOptions{Repair: true})
+	testutil.ErrorIsFailedPrecondition(t, err)
+
+	_, err = reader.Ingredients.Create(middleware.NewContext(baseCtx), &ingredientsmodels.Ingredient{
+		Name: "Refused Rum", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitOz,
+	})
+	testutil.ErrorIsFailedPrecondition(t, err)
+	_, err = reader.Migrations.Up(baseCtx)
+	testutil.ErrorIsFailedPrecondition(t, err)
+
+	_, err = writer.Ingredients.Create(ctx, &ingredientsmodels.Ingredient{
+		Name: "Writer Rum", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitOz,
+	})
+	testutil.Ok(t, err)
+	entries, err := writer.Audit.List(ctx, audit.ListRequest{})
+	testutil.Ok(t, err)
+	// Only the writer's two creates were audited; the reader's export and
+	// refused create left nothing.
+	testutil.Equals(t, len(entries.Items), 2)
+}
@@ -0,0 +1,130 @@
+package app
+
+import (
+	"context"
+	"os"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog"
+	eventlogmodels "github.com/TheFellow/go-modular-monolith/app/domains/eventlog/models"
+	"github.com/TheFellow/go-modular-monolith/app/domains/tagging"
+	"github.com/TheFellow/go-modular-monolith/pkg/dispatcher"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	middlewareevents "github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+type ReplayOptions struct {
+	// Scratch is the database the events are replayed into. It must not
+	// exist; replay never writes to the live database.
+	Scratch string
+	// Base seeds the scratch database, for example with a backup taken before
+	// the events were recorded. Empty copies the live database.
+	Base string
+	// Handlers names the domains whose event handlers run. Empty runs all.
+	Handlers []string
+}
+
+// ReplayEvents replays recorded domain events into a scratch database and
+// compares the state the chosen handlers derived with the live one.
+func (a *App) ReplayEvents(ctx *middleware.Context, rng eventlogmodels.ReplayRange, opts ReplayOptions) (eventlogmodels.Replay, error) {
+	var scratch *App
+	defer func() {
+		if scratch != nil {
+			_ = scratch.Close()
+		}
+	}()
+	return a.EventLog.Replay(ctx, rng, func() (eventlog.Target, error) {
+		// Reject unknown handler sets before copying anything.
+		if _, err := dispatcher.New(nil, nil).Only(opts.Handlers...); err != nil {
+			return eventlog.Target{}, err
+		}
+		var err error
+		scratch, err = openScratch(ctx, a.Store, opts)
+		if err != nil {
+			return eventlog.Target{}, err
+		}
+		d, err := dispatcher.New(scratch.Store, tagging.NewRepository(scratch.Store)).Only(opts.Handlers...)
+		if err != nil {
+			return eventlog.Target{}, err
+		}
+		handlers := opts.Handlers
+		if len(handlers) == 0 {
+			handlers = dispatcher.HandlerDomains()
+		}
+		return eventlog.Target{
+			Store:      scratch.Store,
+			Dispatcher: d,
+			Handlers:   handlers,
+			Decode:     dispatcher.DecodeEvent,
+			Compare: func(ctx *middleware.Context, uid cedar.EntityUID) ([]middlewareevents.Change, error) {
+				live, err := a.loadRecord(ctx, uid)
+				if err != nil {
+					return nil, err
+				}
+				replayed, err := scratch.loadRecord(ctx, uid)
+				if err != nil {
+					return nil, err
+				}
+				return middlewareevents.Diff(live, replayed, nil), nil
+			},
+		}, nil
+	})
+}
+
+// loadRecord snapshots the entity uid names, soft-deleted or not, for
+// comparison. An entity the database does not hold has an empty snapshot.
+func (a *App) loadRecord(ctx store.Context, uid cedar.EntityUID) (map[string]string, error) {
+	_, state, err := a.targets.Load(ctx, uid)
+	if errors.IsNotFound(err) {
+		_, state, err = a.targets.LoadDeleted(ctx, uid)
+	}
+	if errors.IsNotFound(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return middlewareevents.Snapshot(state.Value), nil
+}
+
+// openScratch prepares the scratch database and an application over it, which
+// registers every domain's schema so handlers can read and write the copy.
+func openScratch(ctx context.Context, live *store.Store, opts ReplayOptions) (*App, error) {
+	if opts.Scratch == "" {
+		return nil, errors.Invalidf("scratch database path is required")
+	}
+	if _, err := os.Stat(opts.Scratch); err == nil {
+		return nil, errors.FailedPreconditionf("scratch database %s already exists", opts.Scratch)
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Internalf("stat scratch database: %w", err)
+	}
+
+	source := live
+	if opts.Base != "" {
+		if _, err := os.Stat(opts.Base); err != nil {
+			return nil, errors.NotFoundf("base database %s: %w", opts.Base, err)
+		}
+		base, err := store.Open(ctx, opts.Base, store.Sealed(live.Key()))
+		if err != nil {
+			return nil, errors.Internalf("open base database %s: %w", opts.Base, err)
+		}
+		defer base.Close()
+		source = base
+	}
+	if err := source.CopyTo(ctx, opts.Scratch); err != nil {
+		return nil, err
+	}
+
+	scratch, err := store.Open(ctx, opts.Scratch, store.Sealed(live.Key()))
+	if err != nil {
+		return nil, errors.Internalf("open scratch database: %w", err)
+	}
+	scratchApp, err := New(ctx, Config{Store: scratch})
+	if err != nil {
+		_ = scratch.Close()
+		return nil, err
+	}
+	return scratchApp, nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"io"
 	"log/slog"
-	"path/filepath"
 	"testing"
 
 	"github.com/TheFellow/go-modular-monolith/app"
@@ -25,6 +24,7 @@ import (
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
 	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/teststore"
 	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
@@ -33,7 +33,9 @@ func openRestartTestApp(t *testing.T, ctx context.Context, path string) *app.App
 
 	s, err := store.Open(ctx, path)
 	testutil.Ok(t, err)
-	return app.New(ctx, app.Config{Store: s})
+	a, err := app.New(ctx, app.Config{Store: s})
+	testutil.Ok(t, err)
+	return a
 }
 
 func restartTestMenuAvailability(t *testing.T, menu *menusmodels.Menu, drinkID entity.DrinkID) menusmodels.Availability {
@@ -54,7 +56,7 @@ func TestApp_RestartPreservesCompletedOrderWorkflowAndAudit(t *testing.T) {
 	baseCtx := authn.ToContext(context.Background(), authn.Owner())
 	baseCtx = log.ToContext(baseCtx, slog.New(slog.NewTextHandler(io.Discard, nil)))
 	baseCtx = telemetry.WithMetrics(baseCtx, telemetry.Memory())
-	dbPath := filepath.Join(t.TempDir(), "restart.test.db")
+	dbPath := teststore.Path(t, "restart.test")
 
 	first := openRestartTestApp(t, baseCtx, dbPath)
 	firstClosed := false
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/seal"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+// KeyRotation lists the backups RotateKey resealed alongside the database.
+type KeyRotation struct {
+	Backups []store.Backup
+}
+
+// Seal converts the live database, opened with a key but still plain, to a
+// sealed one. Nothing else may have the database open while it runs.
+func (a *App) Seal(ctx *middleware.Context) error {
+	return a.Store.Seal(ctx)
+}
+
+// RotateKey reseals the sealed backups in backupDir and then the live
+// database with key. Backups go first so that an interrupted rotation can be
+// rerun with the old key: the database still opens with it, and backups
+// already under the new key are skipped.
+func (a *App) RotateKey(ctx *middleware.Context, key *seal.Key, backupDir string) (KeyRotation, error) {
+	if !a.Store.IsSealed() {
+		return KeyRotation{}, errors.FailedPreconditionf("database %s is not sealed", a.Store.Path())
+	}
+	backups, err := store.RekeyBackups(backupDir, a.Store.Key(), key)
+	if err != nil {
+		return KeyRotation{Backups: backups}, err
+	}
+	if err := a.Store.Rekey(ctx, key); err != nil {
+		return KeyRotation{Backups: backups}, err
+	}
+	return KeyRotation{Backups: backups}, nil
+}
@@ -0,0 +1,100 @@
+package app_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app"
+	ingredientsmodels "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/pkg/authn"
+	"github.com/TheFellow/go-modular-monolith/pkg/log"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/seal"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/teststore"
+)
+
+func openSealedTestApp(t *testing.T, ctx context.Context, path string, key *seal.Key) (*app.App, error) {
+	t.Helper()
+
+	s, err := store.Open(ctx, path, store.Sealed(key))
+	if err != nil {
+		return nil, err
+	}
+	a, err := app.New(ctx, app.Config{Store: s})
+	testutil.Ok(t, err)
+	return a, nil
+}
+
+func TestApp_SealedDatabaseBacksUpAndRotatesKeys(t *testing.T) {
+	t.Parallel()
+
+	baseCtx := authn.ToContext(context.Background(), authn.Owner())
+	baseCtx = log.ToContext(baseCtx, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	path := teststore.Path(t, "sealed.test")
+	dir := filepath.Join(t.TempDir(), "backups")
+	old, err := seal.NewKey("old house key")
+	testutil.Ok(t, err)
+	next, err := seal.NewKey("new house key")
+	testutil.Ok(t, err)
+
+	a, err := openSealedTestApp(t, baseCtx, path, old)
+	testutil.Ok(t, err)
+	testutil.IsTrue(t, a.Store.IsSealed())
+	gin, err := a.Ingredients.Create(middleware.NewContext(baseCtx), &ingredientsmodels.Ingredient{
+		Name: "Sealed Gin", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitOz,
+	})
+	testutil.Ok(t, err)
+	backup, err := a.Backup(middleware.NewContext(baseCtx), app.BackupOptions{Dir: dir})
+	testutil.Ok(t, err)
+	testutil.Equals(t, backup.Verification.Records["IngredientRow"], 1)
+
+	rotation, err := a.RotateKey(middleware.NewContext(baseCtx), next, dir)
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(rotation.Backups), 1)
+	_, err = a.VerifyBackup(middleware.NewContext(baseCtx), backup.Backup.Path)
+	testutil.Ok(t, err)
+	testutil.Ok(t, a.Close())
+
+	_, err = store.Open(baseCtx, path, store.Sealed(old))
+	testutil.ErrorIsPermission(t, err)
+	a, err = openSealedTestApp(t, baseCtx, path, next)
+	testutil.Ok(t, err)
+	t.Cleanup(func() { _ = a.Close() })
+	got, err := a.Ingredients.Get(middleware.NewContext(baseCtx), gin.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, got.Name, gin.Name)
+}
+
+func TestApp_SealConvertsPlainDatabase(t *testing.T) {
+	t.Parallel()
+
+	baseCtx := authn.ToContext(context.Background(), authn.Owner())
+	baseCtx = log.ToContext(baseCtx, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	path := teststore.Path(t, "plain.test")
+	key, err := seal.NewKey("house key")
+	testutil.Ok(t, err)
+
+	plain := openRestartTestApp(t, baseCtx, path)
+	testutil.Ok(t, plain.Close())
+	a, err := openSealedTestApp(t, baseCtx, path, key)
+	testutil.Ok(t, err)
+	testutil.IsFalse(t, a.Store.IsSealed())
+	_, err = a.RotateKey(middleware.NewContext(baseCtx), key, t.TempDir())
+	testutil.ErrorIsFailedPrecondition(t, err)
+
+	testutil.Ok(t, a.Seal(middleware.NewContext(baseCtx)))
+	_, err = a.Ingredients.Create(middleware.NewContext(baseCtx), &ingredientsmodels.Ingredient{
+		Name: "Sealed Rum", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitOz,
+	})
+	testutil.Ok(t, err)
+	testutil.Ok(t, a.Close())
+
+	_, err = store.Open(baseCtx, path)
+	testutil.ErrorIsFailedPrecondition(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
+	"github.com/TheFellow/go-modular-monolith/pkg/changes"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
 	cedar "github.com/cedar-policy/cedar-go"
 )
@@ -21,6 +22,19 @@ func (s *Session) ReplaceTags(target cedar.EntityUID, desired tag.Tags) (tag.Tag
 	return result.Tags, err
 }
 
+// Subscribe delivers committed changes this session's principal may see until
+// the subscription is closed.
+func (s *Session) Subscribe(filter changes.Filter) *changes.Subscription {
+	return s.Changes.Subscribe(s.ctx, filter)
+}
+
+// DryRun returns a session whose commands run completely and then roll back,
+// with what they would have done collected in the preview.
+func (s *Session) DryRun() (*Session, *middleware.Preview) {
+	ctx, preview := middleware.NewContext(s.ctx).WithDryRun()
+	return &Session{App: s.App, ctx: ctx}, preview
+}
+
 func NewSession(ctx context.Context, application *App) *Session {
 	return &Session{App: application, ctx: ctx}
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"io"
 	"log/slog"
-	"path/filepath"
 	"testing"
 
 	"github.com/TheFellow/go-modular-monolith/app"
@@ -27,6 +26,7 @@ import (
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/teststore"
 	cedar "github.com/cedar-policy/cedar-go"
 )
 
@@ -131,7 +131,7 @@ func TestTagAssociationsRemainIsolatedAndFollowDeletionSemantics(t *testing.T) {
 
 func TestHydratedTagsPersistAcrossApplicationRestart(t *testing.T) {
 	t.Parallel()
-	path := filepath.Join(t.TempDir(), "restart.db")
+	path := teststore.Path(t, "restart")
 	principal, err := authn.ParseActor("owner")
 	testutil.Ok(t, err)
 	baseCtx := appLog.ToContext(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)))
@@ -140,7 +140,8 @@ func TestHydratedTagsPersistAcrossApplicationRestart(t *testing.T) {
 
 	s, err := store.Open(baseCtx, path)
 	testutil.Ok(t, err)
-	first := app.New(baseCtx, app.Config{Store: s})
+	first, err := app.New(baseCtx, app.Config{Store: s})
+	testutil.Ok(t, err)
 	ingredient, err := first.Ingredients.Create(requestCtx, &ingredientsmodels.Ingredient{
 		Name: "Restart Gin", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitOz,
 	})
@@ -152,7 +153,8 @@ func TestHydratedTagsPersistAcrossApplicationRestart(t *testing.T) {
 
 	s, err = store.Open(baseCtx, path)
 	testutil.Ok(t, err)
-	second := app.New(baseCtx, app.Config{Store: s})
+	second, err := app.New(baseCtx, app.Config{Store: s})
+	testutil.Ok(t, err)
 	t.Cleanup(func() { testutil.Ok(t, second.Close()) })
 	got, err := second.Ingredients.Get(requestCtx, ingredient.ID)
 	testutil.Ok(t, err)
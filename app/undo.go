@@ -0,0 +1,40 @@
+package app
+
+import (
+	auditmodels "github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog"
+	eventlogmodels "github.com/TheFellow/go-modular-monolith/app/domains/eventlog/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/dispatcher"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/paging"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// Undo reverses the command recorded by an audit entry with the compensating
+// commands its domain registered, reading what the command did from the
+// events recorded under the entry. Commands whose entities were touched by a
+// later command, and actions without a compensation, are refused.
+func (a *App) Undo(ctx *middleware.Context, id entity.AuditEntryID) (*auditmodels.Undo, error) {
+	return a.Audit.Undo(ctx, id, func(ctx *middleware.Context, entry auditmodels.AuditEntry) ([]cedar.EntityUID, error) {
+		compensation, err := a.compensations.Lookup(entry.Action)
+		if err != nil {
+			return nil, err
+		}
+		recorded, err := paging.Collect(func(cursor paging.Cursor) (paging.Page[*eventlogmodels.Event], error) {
+			return a.EventLog.List(ctx, eventlog.ListRequest{ActivityID: entry.ID.String(), Cursor: cursor})
+		})
+		if err != nil {
+			return nil, err
+		}
+		events := make([]any, 0, len(recorded))
+		for _, event := range recorded {
+			decoded, err := dispatcher.DecodeEvent(event.Type, event.Data)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, decoded)
+		}
+		return compensation.Compensate(ctx, events)
+	})
+}
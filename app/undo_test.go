@@ -0,0 +1,143 @@
+package app_test
+
+import (
+	"testing"
+
+	auditauthz "github.com/TheFellow/go-modular-monolith/app/domains/audit/authz"
+	drinksauthz "github.com/TheFellow/go-modular-monolith/app/domains/drinks/authz"
+	drinksmodels "github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	ingredientsmodels "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	inventoryauthz "github.com/TheFellow/go-modular-monolith/app/domains/inventory/authz"
+	inventorymodels "github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
+	menusauthz "github.com/TheFellow/go-modular-monolith/app/domains/menus/authz"
+	menusmodels "github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/currency"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/money"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func undoFixture(t *testing.T) (*testutil.Fixture, *ingredientsmodels.Ingredient, *drinksmodels.Drink) {
+	t.Helper()
+	f := testutil.NewFixture(t)
+	rum := testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{Name: "Undo Rum", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitOz})
+	testutil.SetInventory(t, f, inventorymodels.Update{
+		IngredientID: rum.ID, Amount: measurement.MustAmount(10, measurement.UnitOz),
+		CostPerUnit: money.NewPriceFromCents(100, currency.USD),
+	})
+	drink := testutil.CreateDrink(t, f, drinksmodels.Drink{
+		Name: "Undo Daiquiri", Category: drinksmodels.DrinkCategoryCocktail, Glass: drinksmodels.GlassTypeCoupe,
+		Recipe: drinksmodels.Recipe{
+			Ingredients: []drinksmodels.RecipeIngredient{{IngredientID: rum.ID, Amount: measurement.MustAmount(2, measurement.UnitOz)}},
+			Steps:       []string{"Shake"},
+		},
+	})
+	return f, rum, drink
+}
+
+func TestApp_UndoReversesAdjustmentAndLinksItsAuditEntry(t *testing.T) {
+	t.Parallel()
+	f, rum, _ := undoFixture(t)
+	ctx := f.OwnerContext()
+
+	_, err := f.Inventory.Adjust(ctx, &inventorymodels.Patch{
+		IngredientID: rum.ID, Reason: inventorymodels.ReasonSpilled,
+		Delta:       optional.Some(measurement.MustAmount(-12, measurement.UnitOz)),
+		CostPerUnit: optional.Some(money.NewPriceFromCents(250, currency.USD)),
+	})
+	testutil.Ok(t, err)
+	adjusted := f.LatestAuditEntry(inventoryauthz.ActionAdjust)
+
+	undone, err := f.App.Undo(ctx, adjusted.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, undone.Entry.ID, adjusted.ID)
+
+	stock, err := f.Inventory.Get(ctx, rum.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, stock.Amount.Value(), 10.0)
+	testutil.Equals(t, stock.CostPerUnit, optional.Some(money.NewPriceFromCents(100, currency.USD)))
+
+	entry := f.LatestAuditEntry(auditauthz.ActionUndo)
+	testutil.Equals(t, entry.Resource, adjusted.ID.EntityUID())
+	testutil.AuditTouches(t, entry, stock.EntityUID())
+	testutil.Equals(t, entry.CorrelationID, f.LatestAuditEntry(inventoryauthz.ActionAdjust).CorrelationID)
+
+	_, err = f.App.Undo(ctx, adjusted.ID)
+	testutil.ErrorIsFailedPrecondition(t, err)
+}
+
+func TestApp_UndoReversesMenuItemChanges(t *testing.T) {
+	t.Parallel()
+	f, _, drink := undoFixture(t)
+	ctx := f.OwnerContext()
+	menu := testutil.CreateMenu(t, f, "Undo Menu")
+
+	_, err := f.Menus.AddDrink(ctx, &menusmodels.MenuPatch{MenuID: menu.ID, DrinkID: drink.ID})
+	testutil.Ok(t, err)
+	_, err = f.App.Undo(ctx, f.LatestAuditEntry(menusauthz.ActionAddDrink).ID)
+	testutil.Ok(t, err)
+	got, err := f.Menus.Get(ctx, menu.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(got.Items), 0)
+
+	featured := testutil.CreateMenu(t, f, "Featured Menu", testutil.WithDrink(drink))
+	featured.Items[0].Featured = true
+	featured.Items[0].DisplayName = optional.Some("House Daiquiri")
+	featured, err = f.Menus.Update(ctx, featured)
+	testutil.Ok(t, err)
+	_, err = f.Menus.RemoveDrink(ctx, &menusmodels.MenuPatch{MenuID: featured.ID, DrinkID: drink.ID})
+	testutil.Ok(t, err)
+	_, err = f.App.Undo(ctx, f.LatestAuditEntry(menusauthz.ActionRemoveDrink).ID)
+	testutil.Ok(t, err)
+	got, err = f.Menus.Get(ctx, featured.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, got.Items, featured.Items, cmpopts.EquateEmpty())
+}
+
+func TestApp_UndoRestoresPreviousTags(t *testing.T) {
+	t.Parallel()
+	f, _, drink := undoFixture(t)
+	ctx := f.OwnerContext()
+	_, err := f.App.Tags.Upsert(ctx, drink.EntityUID(), tag.Tag{Key: "season", Value: "summer"})
+	testutil.Ok(t, err)
+	_, err = f.App.Tags.Upsert(ctx, drink.EntityUID(), tag.Tag{Key: "season", Value: "winter"})
+	testutil.Ok(t, err)
+
+	_, err = f.App.Undo(ctx, f.LatestAuditEntry(drinksauthz.ActionTag).ID)
+	testutil.Ok(t, err)
+	tags, err := f.App.Tags.List(ctx, drink.EntityUID())
+	testutil.Ok(t, err)
+	testutil.Equals(t, tags, tag.Tags{{Key: "season", Value: "summer"}})
+}
+
+func TestApp_UndoRefusesLaterChangesAndUnknownActions(t *testing.T) {
+	t.Parallel()
+	f, rum, _ := undoFixture(t)
+	ctx := f.OwnerContext()
+	adjust := func() {
+		_, err := f.Inventory.Adjust(ctx, &inventorymodels.Patch{
+			IngredientID: rum.ID, Reason: inventorymodels.ReasonReceived,
+			Delta: optional.Some(measurement.MustAmount(1, measurement.UnitOz)),
+		})
+		testutil.Ok(t, err)
+	}
+	adjust()
+	first := f.LatestAuditEntry(inventoryauthz.ActionAdjust)
+	adjust()
+	before, err := f.Inventory.Get(ctx, rum.ID)
+	testutil.Ok(t, err)
+
+	_, err = f.App.Undo(ctx, first.ID)
+	testutil.ErrorIsFailedPrecondition(t, err)
+	_, err = f.App.Undo(ctx, f.LatestAuditEntry(drinksauthz.ActionCreate).ID)
+	testutil.ErrorIsFailedPrecondition(t, err)
+	_, err = f.App.Undo(f.ActorContext("manager"), first.ID)
+	testutil.ErrorIsPermission(t, err)
+
+	after, err := f.Inventory.Get(ctx, rum.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, after.Amount, before.Amount)
+}
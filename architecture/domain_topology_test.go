@@ -25,11 +25,11 @@ var (
 	}
 	auditDomain = domainProfile{
 		rootPackages:     []string{"authz", "internal", "models", "queries", "surfaces"},
-		internalPackages: []string{"dao"},
+		internalPackages: []string{"commands", "dao"},
 		surfacePackages:  []string{"cli", "gui", "tui"},
 	}
 	taggingDomain = domainProfile{
-		rootPackages:    []string{"authz", "surfaces"},
+		rootPackages:    []string{"authz", "events", "surfaces"},
 		surfacePackages: []string{"gui"},
 	}
 )
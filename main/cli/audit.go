@@ -11,6 +11,7 @@ import (
 	auditmodels "github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
 	auditcli "github.com/TheFellow/go-modular-monolith/app/domains/audit/surfaces/cli"
 	"github.com/TheFellow/go-modular-monolith/pkg/authn"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
 	clitable "github.com/TheFellow/go-modular-monolith/pkg/toolkits/cli/table"
 	cedar "github.com/cedar-policy/cedar-go"
@@ -78,13 +79,52 @@ func (c *CLI) auditCommands() *cli.Command {
 					return c.printAuditList(ctx, cmd, req)
 				}),
 			},
+			{
+				Name:  "archive",
+				Usage: "Move the oldest audit entries into a compressed archive file",
+				Flags: []cli.Flag{
+					clitoolkit.JSONFlag,
+					&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Usage: "Archive file to write (gzip-compressed)", Required: true},
+					&cli.StringFlag{Name: "format", Value: string(auditmodels.FormatNDJSON), Usage: "Archive format (ndjson|csv)"},
+					&cli.StringFlag{Name: "before", Usage: "Archive entries started before this time (RFC3339 or YYYY-MM-DD)"},
+					&cli.DurationFlag{Name: "older-than", Usage: "Archive entries older than this age (for example 2160h)"},
+					&cli.IntFlag{Name: "keep", Usage: "Keep at least this many of the newest entries"},
+				},
+				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+					return c.archiveAudit(ctx, cmd)
+				}),
+			},
+			{
+				Name:  "export",
+				Usage: "Export audit entries in chain order for external tools",
+				Flags: appendFilterFlags(append(auditFilterFlags(),
+					&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Usage: "File to write (default stdout)"},
+					&cli.StringFlag{Name: "format", Value: string(auditmodels.FormatNDJSON), Usage: "Export format (ndjson|csv)"},
+					&cli.BoolFlag{Name: "gzip", Usage: "Compress the export with gzip"},
+				)),
+				Action: filterAction(c, auditmodels.ListFilterSchema(), func(ctx *middleware.Context, cmd *cli.Command) error {
+					return c.exportAudit(ctx, cmd)
+				}),
+			},
+			{
+				Name:  "verify",
+				Usage: "Verify the audit hash chain and report the first broken link",
+				Flags: []cli.Flag{clitoolkit.JSONFlag},
+				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+					return c.verifyAudit(ctx, cmd)
+				}),
+			},
 		},
 	}
 }
 
 func auditListFlags() []cli.Flag {
-	return append([]cli.Flag{
-		clitoolkit.JSONFlag,
+	flags := append([]cli.Flag{clitoolkit.JSONFlag}, auditFilterFlags()...)
+	return append(flags, listPagingFlags()...)
+}
+
+func auditFilterFlags() []cli.Flag {
+	return []cli.Flag{
 		&cli.StringFlag{
 			Name:  "entity",
 			Usage: "Filter by entity (Type::id)",
@@ -105,7 +145,7 @@ func auditListFlags() []cli.Flag {
 			Name:  "to",
 			Usage: "Filter by end time (RFC3339 or YYYY-MM-DD)",
 		},
-	}, listPagingFlags()...)
+	}
 }
 
 func auditHistoryFlags() []cli.Flag {
@@ -135,6 +175,108 @@ func (c *CLI) printAuditList(ctx *middleware.Context, cmd *cli.Command, req audi
 	return printNextCursor(cmd.Writer, page.Next)
 }
 
+func (c *CLI) verifyAudit(ctx *middleware.Context, cmd *cli.Command) error {
+	result, err := c.app.Audit.Verify(ctx)
+	if err != nil {
+		return err
+	}
+	view := auditcli.ToChainVerification(result)
+	if cmd.Bool("json") {
+		err = clitoolkit.WriteJSON(cmd.Writer, view)
+	} else if view.Broken == nil && view.Archived > 0 {
+		_, err = fmt.Fprintf(cmd.Writer, "audit chain intact: %d entries after %d archived, head %s\n", view.Entries, view.Archived, view.Head)
+	} else if view.Broken == nil {
+		_, err = fmt.Fprintf(cmd.Writer, "audit chain intact: %d entries, head %s\n", view.Entries, view.Head)
+	}
+	if err != nil {
+		return err
+	}
+	if broken := view.Broken; broken != nil {
+		return errors.FailedPreconditionf("audit chain broken at entry %d %s: %s", broken.Sequence, broken.EntryID, broken.Reason)
+	}
+	return nil
+}
+
+func (c *CLI) archiveAudit(ctx *middleware.Context, cmd *cli.Command) error {
+	format, err := auditmodels.ParseFormat(cmd.String("format"))
+	if err != nil {
+		return err
+	}
+	policy := auditmodels.RetentionPolicy{Keep: int(cmd.Int("keep"))}
+	if raw := strings.TrimSpace(cmd.String("before")); raw != "" {
+		if policy.Before, err = parseTimeFilter(raw); err != nil {
+			return err
+		}
+	}
+	if age := cmd.Duration("older-than"); age > 0 {
+		cutoff := time.Now().Add(-age)
+		if policy.Before.IsZero() || cutoff.Before(policy.Before) {
+			policy.Before = cutoff
+		}
+	}
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	// Archived entries are deleted once the file is in place, so they must
+	// reach a file that can be synced first, never a pipe.
+	path := cmd.String("output")
+	if path == "" || path == "-" {
+		return errors.Invalidf("audit archive needs an output file; stdout cannot be synced before entries are deleted")
+	}
+	archive, err := c.app.Audit.Archive(ctx, policy, format, func(write func(io.Writer) error) error {
+		return writeOutput(cmd.Writer, path, c.app.Store.Key(), write)
+	})
+	if err != nil {
+		return err
+	}
+	view := auditcli.ToArchive(archive)
+	if cmd.Bool("json") {
+		return clitoolkit.WriteJSON(cmd.Writer, view)
+	}
+	if view.Entries == 0 {
+		_, err = fmt.Fprintf(cmd.Writer, "no audit entries matched the retention policy; wrote empty archive %s\n", path)
+		return err
+	}
+	_, err = fmt.Fprintf(cmd.Writer, "archived %d entries (%d through %d) to %s; chain continues from %s\n",
+		view.Entries, view.FirstSequence, view.LastSequence, path, view.LastHash)
+	return err
+}
+
+func (c *CLI) exportAudit(ctx *middleware.Context, cmd *cli.Command) error {
+	format, err := auditmodels.ParseFormat(cmd.String("format"))
+	if err != nil {
+		return err
+	}
+	list, err := auditListRequest(cmd)
+	if err != nil {
+		return err
+	}
+	req := audit.ExportRequest{
+		Action:    list.Action,
+		Principal: list.Principal,
+		Entity:    list.Entity,
+		From:      list.From,
+		To:        list.To,
+		Filter:    list.Filter,
+		Format:    format,
+		Compress:  cmd.Bool("gzip"),
+	}
+
+	path := cmd.String("output")
+	var export *auditmodels.Export
+	err = writeOutput(cmd.Writer, path, c.app.Store.Key(), func(w io.Writer) error {
+		var err error
+		export, err = c.app.Audit.Export(ctx, req, w)
+		return err
+	})
+	if err != nil || path == "" || path == "-" {
+		return err
+	}
+	_, err = fmt.Fprintf(cmd.Writer, "exported %d entries to %s\n", export.Entries, path)
+	return err
+}
+
 func auditListRequest(cmd *cli.Command) (audit.ListRequest, error) {
 	var req audit.ListRequest
 	pageReq := pagingRequest(cmd)
@@ -231,5 +373,21 @@ func parseEntityUID(value string) (cedar.EntityUID, error) {
 }
 
 func printAuditEntries(output io.Writer, entries []*auditmodels.AuditEntry) error {
-	return clitable.PrintTable(output, auditcli.ToAuditRows(entries))
+	if err := clitable.PrintTable(output, auditcli.ToAuditRows(entries)); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if len(entry.Changes) == 0 {
+			continue
+		}
+		if _, err := fmt.Fprintf(output, "\n%s %s\n", entry.ID, entry.Action); err != nil {
+			return err
+		}
+		for _, change := range entry.Changes {
+			if _, err := fmt.Fprintln(output, "  "+change.String()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
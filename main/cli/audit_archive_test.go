@@ -0,0 +1,55 @@
+//nolint:paralleltest // fresh-process integration tests deliberately serialize database lifecycles.
+package main
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestAuditCLIArchiveAndExport(t *testing.T) {
+	dir := t.TempDir()
+	cli := newCLIE2E(filepath.Join(dir, "audit-archive.db"))
+	for _, name := range []string{"Archived Gin", "Archived Rum", "Kept Vodka"} {
+		testutil.Ok(t, cli.Run("ingredients", "create", name, "--category", "spirit", "--unit", "oz").Err)
+	}
+
+	archivePath := filepath.Join(dir, "audit.ndjson.gz")
+	archived := cli.Run("audit", "archive", "--keep", "1", "--output", archivePath)
+	testutil.Ok(t, archived.Err)
+	testutil.StringContains(t, archived.Stdout, "archived 2 entries (1 through 2)")
+	file, err := os.Open(archivePath)
+	testutil.Ok(t, err)
+	defer func() { _ = file.Close() }()
+	_, err = gzip.NewReader(file)
+	testutil.Ok(t, err)
+
+	verified := cli.Run("audit", "verify")
+	testutil.Ok(t, verified.Err)
+	testutil.StringContains(t, verified.Stdout, "audit chain intact: 2 entries after 2 archived")
+
+	exported := cli.Run("audit", "export", "--format", "csv", "--filter", `action.contains("archive")`)
+	testutil.Ok(t, exported.Err)
+	lines := strings.Split(strings.TrimSpace(exported.Stdout), "\n")
+	testutil.Equals(t, len(lines), 2)
+	testutil.StringContains(t, lines[1], `Mixology::AuditEntry::Action::""archive""`)
+
+	missing := cli.Run("audit", "archive", "--output", filepath.Join(dir, "empty.ndjson.gz"))
+	testutil.Equals(t, missing.ExitCode, errors.ExitInvalid)
+	_, err = os.Stat(filepath.Join(dir, "empty.ndjson.gz"))
+	testutil.IsTrue(t, os.IsNotExist(err))
+
+	piped := cli.Run("audit", "archive", "--keep", "1", "--output", "-")
+	testutil.Equals(t, piped.ExitCode, errors.ExitInvalid)
+	testutil.StringContains(t, piped.Stderr, "stdout cannot be synced")
+	verified = cli.Run("audit", "verify")
+	testutil.StringContains(t, verified.Stdout, "after 2 archived")
+
+	denied := cli.As("manager").Run("audit", "export")
+	testutil.Equals(t, denied.ExitCode, errors.ExitPermission)
+}
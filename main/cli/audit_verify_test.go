@@ -0,0 +1,33 @@
+//nolint:paralleltest // fresh-process integration tests deliberately serialize database lifecycles.
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	auditcli "github.com/TheFellow/go-modular-monolith/app/domains/audit/surfaces/cli"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestAuditCLIVerifyReportsIntactChain(t *testing.T) {
+	cli := newCLIE2E(filepath.Join(t.TempDir(), "audit-verify.db"))
+	testutil.Ok(t, cli.Run("ingredients", "create", "Verified Gin", "--category", "spirit", "--unit", "oz").Err)
+	testutil.Ok(t, cli.Run("ingredients", "create", "Verified Tonic", "--category", "mixer", "--unit", "oz").Err)
+
+	text := cli.Run("audit", "verify")
+	testutil.Ok(t, text.Err)
+	testutil.StringContains(t, text.Stdout, "audit chain intact: 2 entries")
+
+	result := cli.Run("audit", "verify", "--json")
+	testutil.Ok(t, result.Err)
+	var view auditcli.ChainVerification
+	testutil.Ok(t, json.Unmarshal([]byte(result.Stdout), &view))
+	testutil.IsTrue(t, view.Valid)
+	testutil.Equals(t, view.Entries, 2)
+	testutil.Equals(t, len(view.Head), 64)
+
+	denied := cli.As("manager").Run("audit", "verify")
+	testutil.Equals(t, denied.ExitCode, errors.ExitPermission)
+}
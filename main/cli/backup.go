@@ -0,0 +1,212 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/runtimeconfig"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	clitoolkit "github.com/TheFellow/go-modular-monolith/pkg/toolkits/cli"
+	clitable "github.com/TheFellow/go-modular-monolith/pkg/toolkits/cli/table"
+	"github.com/urfave/cli/v3"
+)
+
+type backupRow struct {
+	Name      string    `table:"NAME" json:"name"`
+	CreatedAt time.Time `table:"CREATED" json:"created_at"`
+	Size      int64     `table:"BYTES" json:"size"`
+	Path      string    `table:"-" json:"path"`
+}
+
+type backupResult struct {
+	Backup  backupRow      `json:"backup"`
+	Records map[string]int `json:"records"`
+	Audit   auditSummary   `json:"audit"`
+	Pruned  []backupRow    `json:"pruned,omitempty"`
+}
+
+type auditSummary struct {
+	Entries int    `json:"entries"`
+	Head    string `json:"head"`
+}
+
+func (c *CLI) backupCommands() *cli.Command {
+	dirFlag := &cli.StringFlag{
+		Name:    "dir",
+		Usage:   "Backup directory (default: backups beside the database)",
+		Sources: cli.EnvVars(runtimeconfig.EnvBackupDir),
+	}
+	target := []cli.Argument{&cli.StringArgs{Name: "backup", UsageText: "Backup name in --dir, or a snapshot file path", Max: 1}}
+	return &cli.Command{
+		Name:  "backup",
+		Usage: "Database backups",
+		Commands: []*cli.Command{
+			{
+				Name:  "create",
+				Usage: "Snapshot the database, verify the snapshot, and apply retention",
+				Flags: []cli.Flag{
+					clitoolkit.JSONFlag,
+					dirFlag,
+					&cli.IntFlag{Name: "keep", Value: runtimeconfig.DefaultBackupKeep, Usage: "Backups to retain after this one (0 keeps all)"},
+				},
+				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+					return c.createBackup(ctx, cmd)
+				}),
+			},
+			{
+				Name:  "list",
+				Usage: "List backups, newest first",
+				Flags: []cli.Flag{clitoolkit.JSONFlag, dirFlag},
+				Action: c.action(func(_ *middleware.Context, cmd *cli.Command) error {
+					return c.listBackups(cmd)
+				}),
+			},
+			{
+				Name:      "verify",
+				Usage:     "Check that a backup opens, registers every schema, and has an intact audit chain",
+				Arguments: target,
+				Flags:     []cli.Flag{clitoolkit.JSONFlag, dirFlag},
+				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+					return c.verifyBackup(ctx, cmd)
+				}),
+			},
+			{
+				Name:      "restore",
+				Usage:     "Replace the database with a verified backup",
+				Arguments: target,
+				Flags:     []cli.Flag{clitoolkit.JSONFlag, dirFlag},
+				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+					return c.restoreBackup(ctx, cmd)
+				}),
+			},
+		},
+	}
+}
+
+func (c *CLI) backupDir(cmd *cli.Command) string {
+	if dir := cmd.String("dir"); dir != "" {
+		return dir
+	}
+	return runtimeconfig.BackupDir(c.dbPath)
+}
+
+// backupPath resolves the backup argument: an existing file is used as is,
+// anything else names a backup in the backup directory.
+func (c *CLI) backupPath(cmd *cli.Command) (string, error) {
+	arg, err := requiredStringArg(cmd, "backup")
+	if err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(arg); err == nil && info.Mode().IsRegular() {
+		return arg, nil
+	}
+	backup, err := store.FindBackup(c.backupDir(cmd), arg)
+	if err != nil {
+		return "", err
+	}
+	return backup.Path, nil
+}
+
+func (c *CLI) createBackup(ctx *middleware.Context, cmd *cli.Command) error {
+	keep := cmd.Int("keep")
+	if keep < 0 {
+		return errors.Invalidf("--keep must not be negative")
+	}
+	result, err := c.app.Backup(ctx, app.BackupOptions{Dir: c.backupDir(cmd), Keep: keep})
+	if err != nil {
+		return err
+	}
+	view := toBackupResult(result.Backup, result.Verification)
+	view.Pruned = toBackupRows(result.Pruned)
+	if cmd.Bool("json") {
+		return clitoolkit.WriteJSON(cmd.Writer, view)
+	}
+	if _, err := fmt.Fprintf(cmd.Writer, "backup %s verified: %d records, %d audit entries, head %s\n",
+		result.Backup.Path, totalRecords(view.Records), view.Audit.Entries, view.Audit.Head); err != nil {
+		return err
+	}
+	for _, pruned := range view.Pruned {
+		if _, err := fmt.Fprintf(cmd.Writer, "removed %s\n", pruned.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *CLI) listBackups(cmd *cli.Command) error {
+	backups, err := store.ListBackups(c.backupDir(cmd))
+	if err != nil {
+		return err
+	}
+	rows := toBackupRows(backups)
+	if cmd.Bool("json") {
+		return clitoolkit.WriteJSON(cmd.Writer, rows)
+	}
+	return clitable.PrintTable(cmd.Writer, rows)
+}
+
+func (c *CLI) verifyBackup(ctx *middleware.Context, cmd *cli.Command) error {
+	path, err := c.backupPath(cmd)
+	if err != nil {
+		return err
+	}
+	verification, err := c.app.VerifyBackup(ctx, path)
+	if err != nil {
+		return err
+	}
+	return printBackupVerification(cmd, "backup %s verified", path, verification)
+}
+
+func (c *CLI) restoreBackup(ctx *middleware.Context, cmd *cli.Command) error {
+	path, err := c.backupPath(cmd)
+	if err != nil {
+		return err
+	}
+	verification, err := c.app.Restore(ctx, path)
+	if err != nil {
+		return err
+	}
+	return printBackupVerification(cmd, "restored %s", path, verification)
+}
+
+func printBackupVerification(cmd *cli.Command, format, path string, verification app.BackupVerification) error {
+	view := toBackupResult(store.Backup{Path: path}, verification)
+	if cmd.Bool("json") {
+		return clitoolkit.WriteJSON(cmd.Writer, view)
+	}
+	_, err := fmt.Fprintf(cmd.Writer, format+": %d records, %d audit entries, head %s\n",
+		path, totalRecords(view.Records), view.Audit.Entries, view.Audit.Head)
+	return err
+}
+
+func toBackupResult(backup store.Backup, verification app.BackupVerification) backupResult {
+	return backupResult{
+		Backup:  toBackupRow(backup),
+		Records: verification.Records,
+		Audit:   auditSummary{Entries: verification.AuditEntries, Head: verification.AuditHead},
+	}
+}
+
+func toBackupRow(backup store.Backup) backupRow {
+	return backupRow{Name: backup.Name, CreatedAt: backup.CreatedAt, Size: backup.Size, Path: backup.Path}
+}
+
+func toBackupRows(backups []store.Backup) []backupRow {
+	rows := make([]backupRow, 0, len(backups))
+	for _, backup := range backups {
+		rows = append(rows, toBackupRow(backup))
+	}
+	return rows
+}
+
+func totalRecords(records map[string]int) int {
+	total := 0
+	for _, n := range records {
+		total += n
+	}
+	return total
+}
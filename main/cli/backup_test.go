@@ -0,0 +1,50 @@
+//nolint:paralleltest // fresh-process integration tests deliberately serialize database lifecycles.
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestBackupCLICreatesListsAndRestoresVerifiedSnapshots(t *testing.T) {
+	root := t.TempDir()
+	cli := newCLIE2E(filepath.Join(root, "backup.db"))
+	testutil.Ok(t, cli.Run("ingredients", "create", "Snapshot Gin", "--category", "spirit", "--unit", "oz").Err)
+
+	created := cli.Run("backup", "create", "--keep", "1", "--json")
+	testutil.Ok(t, created.Err)
+	var view backupResult
+	testutil.Ok(t, json.Unmarshal([]byte(created.Stdout), &view))
+	testutil.Equals(t, view.Records["IngredientRow"], 1)
+	testutil.Equals(t, view.Audit.Entries, 1)
+	testutil.Equals(t, filepath.Dir(view.Backup.Path), filepath.Join(root, "backups"))
+
+	second := cli.Run("backup", "create", "--keep", "1")
+	testutil.Ok(t, second.Err)
+	testutil.StringContains(t, second.Stdout, "verified: ")
+	testutil.StringContains(t, second.Stdout, "removed "+view.Backup.Name)
+
+	list := cli.Run("backup", "list", "--json")
+	testutil.Ok(t, list.Err)
+	var rows []backupRow
+	testutil.Ok(t, json.Unmarshal([]byte(list.Stdout), &rows))
+	testutil.Equals(t, len(rows), 1)
+
+	testutil.Ok(t, cli.Run("ingredients", "create", "Later Tonic", "--category", "mixer", "--unit", "oz").Err)
+	restored := cli.Run("backup", "restore", rows[0].Name)
+	testutil.Ok(t, restored.Err)
+	testutil.StringContains(t, restored.Stdout, "restored "+rows[0].Path)
+
+	ingredients := cli.Run("ingredients", "list")
+	testutil.Ok(t, ingredients.Err)
+	testutil.StringContains(t, ingredients.Stdout, "Snapshot Gin")
+	testutil.IsFalse(t, strings.Contains(ingredients.Stdout, "Later Tonic"))
+
+	missing := cli.Run("backup", "verify", "mixology-missing.db")
+	testutil.Equals(t, missing.ExitCode, errors.ExitNotFound)
+}
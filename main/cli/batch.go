@@ -0,0 +1,310 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/TheFellow/go-modular-monolith/app"
+	drinkscli "github.com/TheFellow/go-modular-monolith/app/domains/drinks/surfaces/cli"
+	ingredientsmodels "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	ingredientscli "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/surfaces/cli"
+	inventorycli "github.com/TheFellow/go-modular-monolith/app/domains/inventory/surfaces/cli"
+	menumodels "github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
+	menucli "github.com/TheFellow/go-modular-monolith/app/domains/menus/surfaces/cli"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	clitoolkit "github.com/TheFellow/go-modular-monolith/pkg/toolkits/cli"
+	cedar "github.com/cedar-policy/cedar-go"
+	"github.com/urfave/cli/v3"
+)
+
+// batchScript is the file read by the batch command. Each step's args use the
+// JSON the matching single command accepts with --file; a string "$alias"
+// anywhere in args becomes the ID of the entity an earlier step produced.
+// Aliases start with a letter, so prices such as "$0.50" stay literal.
+type batchScript struct {
+	Steps []batchScriptStep `json:"steps"`
+}
+
+type batchScriptStep struct {
+	Op   string          `json:"op"`
+	As   string          `json:"as,omitempty"`
+	Args json.RawMessage `json:"args"`
+}
+
+type batchMenuDrink struct {
+	MenuID  string `json:"menu_id"`
+	DrinkID string `json:"drink_id"`
+}
+
+type batchMenu struct {
+	ID string `json:"id"`
+}
+
+type batchOutput struct {
+	Op            string `json:"op"`
+	Alias         string `json:"as,omitempty"`
+	EntityID      string `json:"entity_id"`
+	CorrelationID string `json:"correlation_id"`
+}
+
+var batchAlias = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+type batchOperation func(c *CLI, ctx *middleware.Context, args json.RawMessage) (cedar.EntityUID, error)
+
+var batchOperations = map[string]batchOperation{
+	"ingredients.create": func(c *CLI, ctx *middleware.Context, args json.RawMessage) (cedar.EntityUID, error) {
+		row, err := decodeBatchArgs[ingredientscli.IngredientRow](args)
+		if err != nil {
+			return cedar.EntityUID{}, err
+		}
+		created, err := c.app.Ingredients.Create(ctx, &ingredientsmodels.Ingredient{
+			Name:        row.Name,
+			Category:    ingredientsmodels.Category(row.Category),
+			Unit:        measurement.Unit(row.Unit),
+			Description: row.Desc,
+		})
+		if err != nil {
+			return cedar.EntityUID{}, err
+		}
+		return created.EntityUID(), nil
+	},
+	"drinks.create": func(c *CLI, ctx *middleware.Context, args json.RawMessage) (cedar.EntityUID, error) {
+		input, err := decodeBatchArgs[drinkscli.CreateDrink](args)
+		if err != nil {
+			return cedar.EntityUID{}, err
+		}
+		drink, err := input.ToDomain()
+		if err != nil {
+			return cedar.EntityUID{}, err
+		}
+		created, err := c.app.Drinks.Create(ctx, &drink)
+		if err != nil {
+			return cedar.EntityUID{}, err
+		}
+		return created.EntityUID(), nil
+	},
+	"inventory.set": func(c *CLI, ctx *middleware.Context, args json.RawMessage) (cedar.EntityUID, error) {
+		input, err := decodeBatchArgs[inventorycli.InventoryInput](args)
+		if err != nil {
+			return cedar.EntityUID{}, err
+		}
+		update, err := c.inventoryUpdateFromInput(ctx, input)
+		if err != nil {
+			return cedar.EntityUID{}, err
+		}
+		stock, err := c.app.Inventory.Set(ctx, update)
+		if err != nil {
+			return cedar.EntityUID{}, err
+		}
+		return stock.EntityUID(), nil
+	},
+	"menus.create": func(c *CLI, ctx *middleware.Context, args json.RawMessage) (cedar.EntityUID, error) {
+		row, err := decodeBatchArgs[menucli.MenuRow](args)
+		if err != nil {
+			return cedar.EntityUID{}, err
+		}
+		created, err := c.app.Menus.Create(ctx, &menumodels.Menu{Name: row.Name, Description: row.Desc})
+		if err != nil {
+			return cedar.EntityUID{}, err
+		}
+		return created.EntityUID(), nil
+	},
+	"menus.add-drink": func(c *CLI, ctx *middleware.Context, args json.RawMessage) (cedar.EntityUID, error) {
+		input, err := decodeBatchArgs[batchMenuDrink](args)
+		if err != nil {
+			return cedar.EntityUID{}, err
+		}
+		menuID, err := entity.ParseMenuID(input.MenuID)
+		if err != nil {
+			return cedar.EntityUID{}, err
+		}
+		drinkID, err := entity.ParseDrinkID(input.DrinkID)
+		if err != nil {
+			return cedar.EntityUID{}, err
+		}
+		updated, err := c.app.Menus.AddDrink(ctx, &menumodels.MenuPatch{MenuID: menuID, DrinkID: drinkID})
+		if err != nil {
+			return cedar.EntityUID{}, err
+		}
+		return updated.EntityUID(), nil
+	},
+	"menus.publish": func(c *CLI, ctx *middleware.Context, args json.RawMessage) (cedar.EntityUID, error) {
+		input, err := decodeBatchArgs[batchMenu](args)
+		if err != nil {
+			return cedar.EntityUID{}, err
+		}
+		menuID, err := entity.ParseMenuID(input.ID)
+		if err != nil {
+			return cedar.EntityUID{}, err
+		}
+		published, err := c.app.Menus.Publish(ctx, &menumodels.Menu{ID: menuID})
+		if err != nil {
+			return cedar.EntityUID{}, err
+		}
+		return published.EntityUID(), nil
+	},
+}
+
+func (c *CLI) batchCommand() *cli.Command {
+	return c.mutation(&cli.Command{
+		Name:  "batch",
+		Usage: "Run a script of commands in one transaction, rolling back all of them on the first failure",
+		Flags: []cli.Flag{
+			clitoolkit.TemplateFlag,
+			clitoolkit.StdinFlag,
+			clitoolkit.FileFlag,
+			clitoolkit.JSONFlag,
+		},
+		Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+			if cmd.Bool("template") {
+				return clitoolkit.WriteJSON(cmd.Writer, templateBatchScript())
+			}
+			script, err := clitoolkit.ReadJSONInput[batchScript](cmd)
+			if err != nil {
+				return err
+			}
+			steps, err := c.batchSteps(script)
+			if err != nil {
+				return err
+			}
+			results, err := c.app.Batch(ctx, steps)
+			if err != nil {
+				return err
+			}
+			out := make([]batchOutput, 0, len(results))
+			for _, result := range results {
+				out = append(out, batchOutput{Op: result.Name, Alias: result.Alias, EntityID: string(result.Entity.ID), CorrelationID: result.CorrelationID})
+			}
+			if cmd.Bool("json") {
+				return clitoolkit.WriteJSON(cmd.Writer, out)
+			}
+			for _, result := range out {
+				if _, err := fmt.Fprintf(cmd.Writer, "%s\t%s\t%s\n", result.Op, result.EntityID, result.Alias); err != nil {
+					return err
+				}
+			}
+			_, err = fmt.Fprintf(cmd.ErrWriter, "batch correlation ID %s\n", out[0].CorrelationID)
+			return err
+		}),
+	})
+}
+
+// batchSteps validates the whole script before anything runs, so an unknown
+// operation fails without opening a transaction.
+func (c *CLI) batchSteps(script batchScript) ([]app.BatchStep, error) {
+	steps := make([]app.BatchStep, 0, len(script.Steps))
+	for i, step := range script.Steps {
+		op, ok := batchOperations[step.Op]
+		if !ok {
+			return nil, errors.Invalidf("batch step %d: unknown op %q (expected one of %s)", i+1, step.Op, strings.Join(batchOperationNames(), ", "))
+		}
+		if step.As != "" && !batchAlias.MatchString(step.As) {
+			return nil, errors.Invalidf("batch step %d: alias %q must start with a letter and contain only letters, digits, - and _", i+1, step.As)
+		}
+		args := step.Args
+		steps = append(steps, app.BatchStep{
+			Name:  step.Op,
+			Alias: step.As,
+			Run: func(ctx *middleware.Context, results app.BatchResults) (cedar.EntityUID, error) {
+				resolved, err := resolveBatchAliases(args, results)
+				if err != nil {
+					return cedar.EntityUID{}, err
+				}
+				return op(c, ctx, resolved)
+			},
+		})
+	}
+	return steps, nil
+}
+
+func batchOperationNames() []string {
+	return slices.Sorted(maps.Keys(batchOperations))
+}
+
+// resolveBatchAliases replaces every string "$alias" in args with the ID of
+// the entity produced under alias.
+func resolveBatchAliases(args json.RawMessage, results app.BatchResults) (json.RawMessage, error) {
+	if len(bytes.TrimSpace(args)) == 0 {
+		return nil, errors.Invalidf("args are required")
+	}
+	var doc any
+	if err := json.Unmarshal(args, &doc); err != nil {
+		return nil, errors.Invalidf("parse args: %w", err)
+	}
+	var resolve func(any) (any, error)
+	resolve = func(value any) (any, error) {
+		switch v := value.(type) {
+		case string:
+			alias, ok := strings.CutPrefix(v, "$")
+			if !ok || !batchAlias.MatchString(alias) {
+				return v, nil
+			}
+			uid, err := results.Entity(alias)
+			if err != nil {
+				return nil, err
+			}
+			return string(uid.ID), nil
+		case []any:
+			for i := range v {
+				resolved, err := resolve(v[i])
+				if err != nil {
+					return nil, err
+				}
+				v[i] = resolved
+			}
+		case map[string]any:
+			for key := range v {
+				resolved, err := resolve(v[key])
+				if err != nil {
+					return nil, err
+				}
+				v[key] = resolved
+			}
+		}
+		return value, nil
+	}
+	resolved, err := resolve(doc)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(resolved)
+}
+
+func decodeBatchArgs[T any](args json.RawMessage) (T, error) {
+	var out T
+	decoder := json.NewDecoder(bytes.NewReader(args))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&out); err != nil {
+		return out, errors.Invalidf("parse args: %w", err)
+	}
+	return out, nil
+}
+
+func templateBatchScript() batchScript {
+	raw := func(v any) json.RawMessage {
+		b, _ := json.Marshal(v)
+		return b
+	}
+	drink := drinkscli.TemplateCreateDrink()
+	drink.Recipe = drinkscli.Recipe{
+		Ingredients: []drinkscli.RecipeIngredient{{IngredientID: "$tequila", Amount: 2, Unit: string(measurement.UnitOz)}},
+		Steps:       []string{"Shake with ice", "Strain into glass"},
+	}
+	quantity := 750.0
+	return batchScript{Steps: []batchScriptStep{
+		{Op: "ingredients.create", As: "tequila", Args: raw(ingredientscli.IngredientRow{Name: "Tequila", Category: string(ingredientsmodels.CategorySpirit), Unit: string(measurement.UnitOz)})},
+		{Op: "inventory.set", Args: raw(inventorycli.InventoryInput{IngredientID: "$tequila", Quantity: &quantity, CostPerUnit: "$0.50"})},
+		{Op: "drinks.create", As: "margarita", Args: raw(drink)},
+		{Op: "menus.create", As: "house", Args: raw(menucli.MenuRow{Name: "House"})},
+		{Op: "menus.add-drink", Args: raw(batchMenuDrink{MenuID: "$house", DrinkID: "$margarita"})},
+		{Op: "menus.publish", Args: raw(batchMenu{ID: "$house"})},
+	}}
+}
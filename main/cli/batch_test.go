@@ -0,0 +1,78 @@
+//nolint:paralleltest // CLI integration owns a persistent database lifecycle.
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestBatchCLIRunsTemplateScriptInOneTransaction(t *testing.T) {
+	dir := t.TempDir()
+	cli := newCLIE2E(filepath.Join(dir, "batch.db"))
+	template := cli.Run("batch", "--template")
+	testutil.Ok(t, template.Err)
+	script := filepath.Join(dir, "ops.json")
+	testutil.Ok(t, os.WriteFile(script, []byte(template.Stdout), 0o600))
+
+	result := cli.Run("batch", "--file", script, "--json")
+	testutil.Ok(t, result.Err)
+	var steps []batchOutput
+	testutil.Ok(t, json.Unmarshal([]byte(result.Stdout), &steps))
+	testutil.Equals(t, len(steps), 6)
+	testutil.Equals(t, steps[0].Alias, "tequila")
+	house := steps[3].EntityID
+	testutil.IsTrue(t, strings.HasPrefix(house, "mnu-"))
+	testutil.Equals(t, steps[5].EntityID, house)
+
+	menu := cli.Run("menus", "show", "--id", house, "--json")
+	testutil.Ok(t, menu.Err)
+	testutil.StringContains(t, menu.Stdout, `"status": "published"`)
+	testutil.StringContains(t, menu.Stdout, steps[2].EntityID)
+
+	audit := cli.Run("audit", "list", "--json")
+	testutil.Ok(t, audit.Err)
+	testutil.Equals(t, strings.Count(audit.Stdout, `"Sequence"`), 6)
+	for _, action := range []string{"create", "set", "add_drink", "publish"} {
+		testutil.StringContains(t, audit.Stdout, `Action::\"`+action+`\"`)
+	}
+}
+
+func TestBatchCLIRollsBackEveryStepOnFirstFailure(t *testing.T) {
+	dir := t.TempDir()
+	cli := newCLIE2E(filepath.Join(dir, "batch.db"))
+	auditBefore := cli.Run("audit", "list", "--json").Stdout
+	script := filepath.Join(dir, "ops.json")
+	testutil.Ok(t, os.WriteFile(script, []byte(`{"steps":[
+		{"op":"ingredients.create","as":"gin","args":{"name":"Batch Gin","category":"spirit","unit":"oz"}},
+		{"op":"menus.create","as":"bar","args":{"name":"Batch Bar"}},
+		{"op":"menus.add-drink","args":{"menu_id":"$bar","drink_id":"$missing"}}
+	]}`), 0o600))
+
+	result := cli.Run("batch", "--file", script)
+	testutil.ErrorIf(t, result.Err == nil, "%v", "batch with an unknown alias succeeded")
+	testutil.StringContains(t, result.Stderr, `batch step 3 (menus.add-drink): unknown batch alias "missing"`)
+
+	testutil.ErrorIf(t, strings.Contains(cli.Run("ingredients", "list").Stdout, "Batch Gin"), "%v", "failed batch kept its ingredient")
+	testutil.ErrorIf(t, strings.Contains(cli.Run("menus", "list").Stdout, "Batch Bar"), "%v", "failed batch kept its menu")
+	testutil.Equals(t, cli.Run("audit", "list", "--json").Stdout, auditBefore)
+}
+
+func TestBatchCLIRejectsUnknownOpBeforeRunning(t *testing.T) {
+	dir := t.TempDir()
+	cli := newCLIE2E(filepath.Join(dir, "batch.db"))
+	script := filepath.Join(dir, "ops.json")
+	testutil.Ok(t, os.WriteFile(script, []byte(`{"steps":[
+		{"op":"ingredients.create","args":{"name":"Early Gin","category":"spirit","unit":"oz"}},
+		{"op":"orders.place","args":{}}
+	]}`), 0o600))
+
+	result := cli.Run("batch", "--file", script)
+	testutil.ErrorIf(t, result.Err == nil, "%v", "batch with an unknown op succeeded")
+	testutil.StringContains(t, result.Stderr, `unknown op "orders.place"`)
+	testutil.ErrorIf(t, strings.Contains(cli.Run("ingredients", "list").Stdout, "Early Gin"), "%v", "rejected batch ran a step")
+}
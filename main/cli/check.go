@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/TheFellow/go-modular-monolith/app"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	clitoolkit "github.com/TheFellow/go-modular-monolith/pkg/toolkits/cli"
+	"github.com/urfave/cli/v3"
+)
+
+type checkResult struct {
+	Violations []checkViolation `json:"violations"`
+	Errors     int              `json:"errors"`
+	Warnings   int              `json:"warnings"`
+	Repaired   int              `json:"repaired"`
+}
+
+type checkViolation struct {
+	Invariant  string `json:"invariant"`
+	Severity   string `json:"severity"`
+	Entity     string `json:"entity"`
+	Message    string `json:"message"`
+	Repairable bool   `json:"repairable"`
+	Repaired   bool   `json:"repaired"`
+}
+
+// checkCommand validates cross-domain invariants. It fails only while errors
+// remain; warnings are reported but leave the exit code alone.
+func (c *CLI) checkCommand() *cli.Command {
+	return c.mutation(&cli.Command{
+		Name:  "check",
+		Usage: "Validate invariants that span domains and optionally repair the safe ones",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "repair", Usage: "Fix repairable violations through audited commands"},
+			clitoolkit.JSONFlag,
+		},
+		Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+			report, err := c.app.Check(ctx, app.CheckOptions{Repair: cmd.Bool("repair")})
+			if err != nil {
+				return err
+			}
+			out := toCheckResult(report)
+			if cmd.Bool("json") {
+				err = clitoolkit.WriteJSON(cmd.Writer, out)
+			} else {
+				err = printCheckResult(cmd, out)
+			}
+			if err != nil {
+				return err
+			}
+			if out.Errors > 0 {
+				return errors.FailedPreconditionf("integrity check found %d unrepaired errors", out.Errors)
+			}
+			return nil
+		}),
+	})
+}
+
+func printCheckResult(cmd *cli.Command, out checkResult) error {
+	for _, violation := range out.Violations {
+		note := ""
+		switch {
+		case violation.Repaired:
+			note = " (repaired)"
+		case violation.Repairable:
+			note = " (repairable with --repair)"
+		}
+		if _, err := fmt.Fprintf(cmd.Writer, "%-7s %-18s %s: %s%s\n", violation.Severity, violation.Invariant, violation.Entity, violation.Message, note); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(cmd.Writer, "%d errors, %d warnings, %d repaired\n", out.Errors, out.Warnings, out.Repaired)
+	return err
+}
+
+func toCheckResult(report app.CheckReport) checkResult {
+	out := checkResult{
+		Violations: make([]checkViolation, 0, len(report.Violations)),
+		Errors:     report.Outstanding(app.SeverityError),
+		Warnings:   report.Outstanding(app.SeverityWarning),
+		Repaired:   report.Repaired(),
+	}
+	for _, violation := range report.Violations {
+		out.Violations = append(out.Violations, checkViolation{
+			Invariant:  string(violation.Invariant),
+			Severity:   string(violation.Severity),
+			Entity:     violation.Entity.String(),
+			Message:    violation.Message,
+			Repairable: violation.Repairable,
+			Repaired:   violation.Repaired,
+		})
+	}
+	return out
+}
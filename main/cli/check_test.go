@@ -0,0 +1,66 @@
+//nolint:paralleltest // fresh-process integration tests deliberately serialize database lifecycles.
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestCheckCLIReportsAndRepairsMenuAvailabilityDrift(t *testing.T) {
+	dir := t.TempDir()
+	source := newCLIE2E(filepath.Join(dir, "source.db"))
+	template := source.Run("batch", "--template")
+	testutil.Ok(t, template.Err)
+	script := filepath.Join(dir, "ops.json")
+	testutil.Ok(t, os.WriteFile(script, []byte(template.Stdout), 0o600))
+	testutil.Ok(t, source.Run("batch", "--file", script).Err)
+	clean := source.Run("check")
+	testutil.Ok(t, clean.Err)
+	testutil.StringContains(t, clean.Stdout, "0 errors, 0 warnings, 0 repaired")
+
+	// Record the opposite of each published item's availability, as a
+	// missed stock handler would leave it.
+	exported := source.Run("export")
+	testutil.Ok(t, exported.Err)
+	var doc map[string]any
+	testutil.Ok(t, json.Unmarshal([]byte(exported.Stdout), &doc))
+	for _, menu := range doc["menus"].([]any) {
+		for _, item := range menu.(map[string]any)["items"].([]any) {
+			fields := item.(map[string]any)
+			if fields["availability"] == "unavailable" {
+				fields["availability"] = "available"
+			} else {
+				fields["availability"] = "unavailable"
+			}
+		}
+	}
+	data, err := json.Marshal(doc)
+	testutil.Ok(t, err)
+	document := filepath.Join(dir, "drifted.json")
+	testutil.Ok(t, os.WriteFile(document, data, 0o600))
+
+	target := newCLIE2E(filepath.Join(dir, "target.db"))
+	testutil.Ok(t, target.Run("import", "--file", document).Err)
+	found := target.Run("check")
+	testutil.Ok(t, found.Err)
+	testutil.StringContains(t, found.Stdout, "menu-availability")
+	testutil.StringContains(t, found.Stdout, "(repairable with --repair)")
+
+	dry := target.Run("check", "--repair", "--dry-run")
+	testutil.Ok(t, dry.Err)
+	testutil.StringContains(t, dry.Stderr, "nothing was saved")
+	testutil.StringContains(t, target.Run("check").Stdout, "0 errors, 1 warnings, 0 repaired")
+
+	repaired := target.Run("check", "--repair", "--json")
+	testutil.Ok(t, repaired.Err)
+	var report checkResult
+	testutil.Ok(t, json.Unmarshal([]byte(repaired.Stdout), &report))
+	testutil.Equals(t, report.Repaired, 1)
+	testutil.Equals(t, report.Violations[0].Repaired, true)
+	testutil.StringContains(t, target.Run("check").Stdout, "0 errors, 0 warnings, 0 repaired")
+	testutil.StringContains(t, target.Run("audit", "list", "--json").Stdout, `Action::\"refresh\"`)
+}
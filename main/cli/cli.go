@@ -9,10 +9,12 @@ import (
 
 	"github.com/TheFellow/go-modular-monolith/app"
 	"github.com/TheFellow/go-modular-monolith/pkg/authn"
+	"github.com/TheFellow/go-modular-monolith/pkg/authz"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	pkglog "github.com/TheFellow/go-modular-monolith/pkg/log"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
 	"github.com/TheFellow/go-modular-monolith/pkg/runtimeconfig"
+	"github.com/TheFellow/go-modular-monolith/pkg/seal"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
 	"github.com/urfave/cli/v3"
@@ -27,20 +29,65 @@ type CLI struct {
 	logFile         string
 	logFileHandle   *os.File
 	enableMetrics   bool
+	metricsAddr     string
 	metricsServer   *http.Server
 	metricsShutdown func(context.Context) error
+	traceFile       string
+	traceShutdown   func(context.Context) error
+	correlationID   string
+	readOnly        bool
+	keyFile         string
+	configFile      string
+	// config is the effective configuration Before resolved, with the
+	// source of every setting.
+	config runtimeconfig.Config
 }
 
 func NewCLI() (*CLI, error) {
 	defaults := runtimeconfig.Default()
 	return &CLI{
-		dbPath:    defaults.DatabasePath,
-		actor:     defaults.Actor,
-		logLevel:  defaults.LogLevel,
-		logFormat: defaults.LogFormat,
+		dbPath:      defaults.DatabasePath,
+		actor:       defaults.Actor,
+		logLevel:    defaults.LogLevel,
+		logFormat:   defaults.LogFormat,
+		metricsAddr: defaults.MetricsAddr,
 	}, nil
 }
 
+// runtimeConfig reports the CLI's current settings over the shared defaults.
+// Taken before parsing, it is the base the config file and environment are
+// layered over.
+func (c *CLI) runtimeConfig() runtimeconfig.Config {
+	config := runtimeconfig.Default()
+	config.DatabasePath, config.Actor, config.ReadOnly, config.KeyFile = c.dbPath, c.actor, c.readOnly, c.keyFile
+	config.LogLevel, config.LogFormat, config.LogFile = c.logLevel, c.logFormat, c.logFile
+	config.EnableMetrics, config.MetricsAddr, config.TraceFile = c.enableMetrics, c.metricsAddr, c.traceFile
+	return config
+}
+
+// resolveConfig layers the config file, the environment, and the flags given
+// on the command line over base, and adopts the result.
+func (c *CLI) resolveConfig(cmd *cli.Command, base runtimeconfig.Config) error {
+	config, err := runtimeconfig.Load(c.configFile, base)
+	if err != nil {
+		return err
+	}
+	err = config.ApplyFlags(func(name string) (string, bool) {
+		if !cmd.IsSet(name) {
+			return "", false
+		}
+		return fmt.Sprint(cmd.Value(name)), true
+	})
+	if err != nil {
+		return err
+	}
+	c.config = config
+	c.dbPath, c.actor, c.readOnly, c.keyFile = config.DatabasePath, config.Actor, config.ReadOnly, config.KeyFile
+	c.logLevel, c.logFormat, c.logFile = config.LogLevel, config.LogFormat, config.LogFile
+	c.enableMetrics, c.metricsAddr, c.traceFile = config.EnableMetrics, config.MetricsAddr, config.TraceFile
+	return nil
+}
+
 func (c *CLI) action(fn func(*middleware.Context, *cli.Command) error) cli.ActionFunc {
 	return func(ctx context.Context, cmd *cli.Command) error {
 		mctx, ok := ctx.(*middleware.Context)
@@ -52,10 +99,17 @@ func (c *CLI) action(fn func(*middleware.Context, *cli.Command) error) cli.Actio
 }
 
 func (c *CLI) Command() *cli.Command {
+	base := c.runtimeConfig()
 	return &cli.Command{
 		Name:  "mixology",
 		Usage: "Mixology as a Service",
 		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "config",
+				Usage:       "Config file (default: ./" + runtimeconfig.ConfigFileName + ", then the user config directory)",
+				Destination: &c.configFile,
+				Sources:     cli.EnvVars(runtimeconfig.EnvConfigFile),
+			},
 			&cli.StringFlag{
 				Name:        "db",
 				Usage:       "Database path",
@@ -93,10 +147,41 @@ func (c *CLI) Command() *cli.Command {
 			},
 			&cli.BoolFlag{
 				Name:        "metrics",
-				Usage:       "Enable Prometheus metrics endpoint on :9090/metrics",
+				Usage:       "Enable Prometheus metrics endpoint at /metrics on --metrics-addr",
 				Destination: &c.enableMetrics,
 				Sources:     cli.EnvVars(runtimeconfig.EnvMetrics),
 			},
+			&cli.StringFlag{
+				Name:        "metrics-addr",
+				Usage:       "Address the metrics endpoint listens on",
+				Value:       c.metricsAddr,
+				Destination: &c.metricsAddr,
+				Sources:     cli.EnvVars(runtimeconfig.EnvMetricsAddr),
+			},
+			&cli.StringFlag{
+				Name:        "correlation-id",
+				Usage:       "Correlation ID for the command's logs, audit entry, and events (generated when empty)",
+				Destination: &c.correlationID,
+				Sources:     cli.EnvVars(runtimeconfig.EnvCorrelationID),
+			},
+			&cli.StringFlag{
+				Name:        "trace-file",
+				Usage:       "Append OTLP JSON traces to file",
+				Destination: &c.traceFile,
+				Sources:     cli.EnvVars(runtimeconfig.EnvTraceFile),
+			},
+			&cli.BoolFlag{
+				Name:        "read-only",
+				Usage:       "Open the database read-only beside a running writer; commands that change data fail",
+				Destination: &c.readOnly,
+				Sources:     cli.EnvVars(runtimeconfig.EnvReadOnly),
+			},
+			&cli.StringFlag{
+				Name:        "key-file",
+				Usage:       "File holding the passphrase or key that seals the database, backups, and exported files",
+				Destination: &c.keyFile,
+				Sources:     cli.EnvVars(runtimeconfig.EnvKeyFile),
+			},
 		},
 		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
 			// Filter help is schema-only and must not require a database or a
@@ -107,6 +192,20 @@ func (c *CLI) Command() *cli.Command {
 				ctx = pkglog.ToContext(ctx, pkglog.Setup("error", "text", io.Discard))
 				return middleware.NewContext(ctx), nil
 			}
+			if err := c.resolveConfig(cmd, base); err != nil {
+				return ctx, err
+			}
+			// config commands report the configuration without opening the
+			// database it names.
+			if cmd.Args().First() == "config" {
+				ctx = authn.ToContext(ctx, authn.Anonymous())
+				ctx = pkglog.ToContext(ctx, pkglog.Setup("error", "text", io.Discard))
+				return middleware.NewContext(ctx), nil
+			}
+			defaults, err := app.DefaultsFrom(c.config)
+			if err != nil {
+				return ctx, err
+			}
 			var logOutput io.Writer = os.Stderr
 			if c.logFile != "" {
 				f, err := os.OpenFile(c.logFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
@@ -129,9 +228,17 @@ func (c *CLI) Command() *cli.Command {
 
 				mux := http.NewServeMux()
 				mux.Handle("/metrics", prom.Handler)
-				c.metricsServer = &http.Server{Addr: runtimeconfig.DefaultMetricsAddr, Handler: mux}
+				c.metricsServer = &http.Server{Addr: c.metricsAddr, Handler: mux}
 				go func() { _ = c.metricsServer.ListenAndServe() }()
 			}
+			if c.traceFile != "" {
+				traces, err := telemetry.NewTraceFile(c.traceFile)
+				if err != nil {
+					return ctx, err
+				}
+				ctx = telemetry.WithTracing(ctx, traces.Provider)
+				c.traceShutdown = traces.Shutdown
+			}
 
 			p, err := authn.ParseActor(c.actor)
 			if err != nil {
@@ -140,18 +247,40 @@ func (c *CLI) Command() *cli.Command {
 			ctx = pkglog.ToContext(ctx, logger)
 			ctx = telemetry.WithMetrics(ctx, metrics)
 			ctx = authn.ToContext(ctx, p)
+			ctx = authz.WithRequest(ctx, authz.Request{Surface: authz.SurfaceCLI, SessionID: authz.NewSessionID()})
+			ctx = middleware.WithCorrelationID(ctx, c.correlationID)
 
-			s, err := store.Open(ctx, c.dbPath)
+			var opts []store.Option
+			if c.readOnly {
+				opts = append(opts, store.ReadOnly())
+			}
+			if c.keyFile != "" {
+				key, err := seal.LoadKey(c.keyFile)
+				if err != nil {
+					return ctx, err
+				}
+				opts = append(opts, store.Sealed(key))
+			}
+			s, err := store.Open(ctx, c.dbPath, opts...)
+			if err != nil {
+				return ctx, err
+			}
+			// migrate commands report and apply pending migrations themselves.
+			c.app, err = app.New(ctx, app.Config{Store: s, DeferMigrations: cmd.Args().First() == "migrate", Defaults: defaults})
 			if err != nil {
+				_ = s.Close()
 				return ctx, err
 			}
-			c.app = app.New(ctx, app.Config{Store: s})
+			if c.enableMetrics {
+				c.app.StartMetricsCollector(ctx, runtimeconfig.DefaultMetricsInterval)
+			}
 
 			return middleware.NewContext(ctx), nil
 		},
 		After: func(ctx context.Context, _ *cli.Command) error {
+			var closeErr error
 			if c.app != nil {
-				_ = c.app.Close()
+				closeErr = c.app.Close()
 			}
 			if c.metricsServer != nil {
 				_ = c.metricsServer.Shutdown(ctx)
@@ -159,10 +288,15 @@ func (c *CLI) Command() *cli.Command {
 			if c.metricsShutdown != nil {
 				_ = c.metricsShutdown(ctx)
 			}
+			if c.traceShutdown != nil {
+				_ = c.traceShutdown(ctx)
+			}
 			if c.logFileHandle != nil {
 				_ = c.logFileHandle.Close()
 			}
-			return nil
+			// Closing seals a sealed database's last changes; a failure leaves
+			// them unsealed, which the command's result must not hide.
+			return closeErr
 		},
 		ExitErrHandler: func(_ context.Context, _ *cli.Command, _ error) {},
 		OnUsageError: func(_ context.Context, _ *cli.Command, err error, _ bool) error {
@@ -177,6 +311,17 @@ func (c *CLI) Command() *cli.Command {
 			c.ordersCommands(),
 			c.tagsCommands(),
 			c.auditCommands(),
+			c.outboxCommands(),
+			c.eventsCommands(),
+			c.backupCommands(),
+			c.keyCommands(),
+			c.configCommands(),
+			c.migrateCommands(),
+			c.exportCommand(),
+			c.importCommand(),
+			c.checkCommand(),
+			c.undoCommand(),
+			c.batchCommand(),
 		},
 	}
 }
@@ -41,7 +41,7 @@ func TestCommandNouns(t *testing.T) {
 		names = append(names, command.Name)
 	}
 
-	want := []string{"status", "drinks", "ingredients", "inventory", "menus", "orders", "tags", "audit"}
+	want := []string{"status", "drinks", "ingredients", "inventory", "menus", "orders", "tags", "audit", "outbox", "events", "backup", "key", "config", "migrate", "export", "import", "check", "undo", "batch"}
 	testutil.Equals(t, names, want)
 }
 
@@ -69,14 +69,14 @@ func TestTableColumns(t *testing.T) {
 		row  any
 		want []string
 	}{
-		{"drink", drinkscli.DrinkRow{}, []string{"ID", "NAME", "CATEGORY", "GLASS", "STATUS", "INGREDIENTS", "TAGS"}},
-		{"ingredient", ingredientscli.IngredientRow{}, []string{"ID", "NAME", "CATEGORY", "UNIT", "DESCRIPTION", "TAGS"}},
+		{"drink", drinkscli.DrinkRow{}, []string{"ID", "NAME", "CATEGORY", "GLASS", "STATUS", "INGREDIENTS", "DELETED_AT", "TAGS"}},
+		{"ingredient", ingredientscli.IngredientRow{}, []string{"ID", "NAME", "CATEGORY", "UNIT", "DESCRIPTION", "DELETED_AT", "TAGS"}},
 		{"inventory", inventorycli.InventoryRow{}, []string{"ID", "INGREDIENT_ID", "QUANTITY", "RESERVED", "AVAILABLE", "UNIT", "COST_PER_UNIT", "LAST_UPDATED", "TAGS"}},
-		{"menu", menuscli.MenuRow{}, []string{"ID", "NAME", "STATUS", "ITEMS", "CREATED_AT", "PUBLISHED_AT", "TAGS"}},
+		{"menu", menuscli.MenuRow{}, []string{"ID", "NAME", "STATUS", "ITEMS", "CREATED_AT", "PUBLISHED_AT", "DELETED_AT", "TAGS"}},
 		{"menu item", menuscli.MenuItemRow{}, []string{"DRINK_ID", "DISPLAY_NAME", "PRICE", "FEATURED", "AVAILABILITY", "SORT_ORDER"}},
-		{"order", orderscli.OrderRow{}, []string{"ID", "MENU_ID", "STATUS", "ITEMS", "TOTAL_QUANTITY", "CREATED_AT", "COMPLETED_AT", "TAGS"}},
+		{"order", orderscli.OrderRow{}, []string{"ID", "MENU_ID", "STATUS", "ITEMS", "TOTAL_QUANTITY", "CREATED_AT", "COMPLETED_AT", "DELETED_AT", "TAGS"}},
 		{"order item", orderscli.OrderItemRow{}, []string{"DRINK_ID", "QUANTITY", "NOTES"}},
-		{"audit", auditcli.AuditRow{}, []string{"ID", "STARTED_AT", "COMPLETED_AT", "DURATION", "ACTION", "RESOURCE", "PRINCIPAL", "SUCCESS", "TOUCHES", "ERROR"}},
+		{"audit", auditcli.AuditRow{}, []string{"ID", "STARTED_AT", "COMPLETED_AT", "DURATION", "ACTION", "RESOURCE", "PRINCIPAL", "SUCCESS", "TOUCHES", "CHANGES", "ERROR"}},
 	}
 
 	for _, tt := range tests {
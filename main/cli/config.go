@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	clitoolkit "github.com/TheFellow/go-modular-monolith/pkg/toolkits/cli"
+	clitable "github.com/TheFellow/go-modular-monolith/pkg/toolkits/cli/table"
+	"github.com/urfave/cli/v3"
+)
+
+type configRow struct {
+	Key    string `table:"KEY" json:"key"`
+	Value  string `table:"VALUE" json:"value"`
+	Source string `table:"SOURCE" json:"source"`
+	Env    string `table:"ENV" json:"env"`
+}
+
+type configReport struct {
+	File     string      `json:"file"`
+	Settings []configRow `json:"settings"`
+}
+
+// configCommands inspect the configuration Before resolved. They run without
+// opening the database, so they also work when its settings are wrong.
+func (c *CLI) configCommands() *cli.Command {
+	return &cli.Command{
+		Name:  "config",
+		Usage: "Inspect the runtime configuration",
+		Commands: []*cli.Command{
+			{
+				Name:  "show",
+				Usage: "Print the effective configuration and the source of each setting",
+				Flags: []cli.Flag{clitoolkit.JSONFlag},
+				Action: c.action(func(_ *middleware.Context, cmd *cli.Command) error {
+					return c.showConfig(cmd)
+				}),
+			},
+		},
+	}
+}
+
+func (c *CLI) showConfig(cmd *cli.Command) error {
+	report := configReport{File: c.config.ConfigFile}
+	for _, setting := range c.config.Settings() {
+		report.Settings = append(report.Settings, configRow{
+			Key: setting.Key, Value: setting.Value, Source: string(setting.Source), Env: setting.Env,
+		})
+	}
+	if cmd.Bool("json") {
+		return clitoolkit.WriteJSON(cmd.Writer, report)
+	}
+	file := report.File
+	if file == "" {
+		file = "(none)"
+	}
+	if _, err := fmt.Fprintf(cmd.Writer, "Config file: %s\n\n", file); err != nil {
+		return err
+	}
+	return clitable.PrintTable(cmd.Writer, report.Settings)
+}
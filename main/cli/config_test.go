@@ -0,0 +1,84 @@
+//nolint:paralleltest // CLI integration owns a persistent database lifecycle.
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/app"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/runtimeconfig"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func writeConfigFile(t *testing.T, dir, body string) string {
+	t.Helper()
+	path := filepath.Join(dir, "mixology.toml")
+	testutil.Ok(t, os.WriteFile(path, []byte(body), 0o600))
+	return path
+}
+
+func TestConfigShowReportsEffectiveSettingsAndSources(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "config.db")
+	config := writeConfigFile(t, dir, "actor = \"manager\"\n\n[log]\nformat = \"json\"\nlevel = \"debug\"\n")
+	t.Setenv(runtimeconfig.EnvMetricsAddr, ":9393")
+	cli := newCLIE2E(dbPath)
+
+	shown := cli.Run("--config", config, "--log-level", "warn", "config", "show", "--json")
+	testutil.Ok(t, shown.Err)
+	var report configReport
+	testutil.Ok(t, json.Unmarshal([]byte(shown.Stdout), &report))
+	testutil.Equals(t, report.File, config)
+	got := map[string]configRow{}
+	for _, row := range report.Settings {
+		got[row.Key] = row
+	}
+	testutil.Equals(t, got["actor"], configRow{Key: "actor", Value: "manager", Source: "file", Env: runtimeconfig.EnvActor})
+	testutil.Equals(t, got["log.format"].Source, "file")
+	testutil.Equals(t, got["log.level"].Value, "warn")
+	testutil.Equals(t, got["log.level"].Source, "flag")
+	testutil.Equals(t, got["metrics.addr"].Value, ":9393")
+	testutil.Equals(t, got["metrics.addr"].Source, "env")
+	testutil.Equals(t, got["database.path"].Value, dbPath)
+	testutil.Equals(t, got["database.path"].Source, "default")
+
+	text := cli.Run("--config", config, "config", "show")
+	testutil.Ok(t, text.Err)
+	testutil.StringContains(t, text.Stdout, "Config file: "+config)
+	testutil.StringContains(t, text.Stdout, "menus.target_margin")
+	// Showing the configuration never opens the database it names.
+	_, err := os.Stat(dbPath)
+	testutil.IsTrue(t, os.IsNotExist(err))
+
+	missing := cli.Run("--config", filepath.Join(dir, "missing.toml"), "config", "show")
+	testutil.Equals(t, missing.ExitCode, errors.ExitNotFound)
+	invalid := cli.Run("--config", writeConfigFile(t, t.TempDir(), "[menus]\ntarget_margin = 2\n"), "config", "show")
+	testutil.Equals(t, invalid.ExitCode, errors.ExitInvalid)
+	testutil.StringContains(t, invalid.Stderr, "menus.target_margin")
+}
+
+func TestConfigFileDefaultsReachCommands(t *testing.T) {
+	dir := t.TempDir()
+	config := writeConfigFile(t, dir, "[inventory]\nlow_stock_threshold = 12\n\n[money]\ncurrency = \"EUR\"\n")
+	cli := newCLIE2E(filepath.Join(dir, "defaults.db"))
+
+	ingredientID := strings.TrimSpace(cli.Run("ingredients", "create", "Configured Gin", "--category", "spirit", "--unit", "oz").Stdout)
+	testutil.Ok(t, cli.Run("--config", config, "inventory", "set", "--ingredient-id", ingredientID, "--quantity", "11").Err)
+	stock := cli.Run("inventory", "get", "--ingredient-id", ingredientID, "--json")
+	testutil.Ok(t, stock.Err)
+	testutil.StringContains(t, stock.Stdout, `"cost_per_unit": "0.00 €"`)
+
+	var dashboard app.Dashboard
+	status := cli.Run("status", "--json")
+	testutil.Ok(t, status.Err)
+	testutil.Ok(t, json.Unmarshal([]byte(status.Stdout), &dashboard))
+	testutil.Equals(t, dashboard.LowStockCount, 0)
+	status = cli.Run("--config", config, "status", "--json")
+	testutil.Ok(t, status.Err)
+	testutil.Ok(t, json.Unmarshal([]byte(status.Stdout), &dashboard))
+	testutil.Equals(t, dashboard.LowStockCount, 1)
+}
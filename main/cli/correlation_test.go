@@ -0,0 +1,59 @@
+//nolint:paralleltest // CLI integration owns a persistent database lifecycle.
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestCLICorrelationIDTiesLogsAuditAndEvents(t *testing.T) {
+	dir := t.TempDir()
+	cli := newCLIE2E(filepath.Join(dir, "correlation.db"))
+	logs := filepath.Join(dir, "mixology.log")
+	id := strings.TrimSpace(cli.Run("ingredients", "create", "Reposado", "--category", "spirit", "--unit", "oz").Stdout)
+
+	const correlationID = "5f0c6e2a9d1b4c7e8a3f2b1d0c9e8f7a"
+	testutil.Ok(t, cli.Run(
+		"--correlation-id", correlationID, "--log-file", logs, "--log-format", "json", "--log-level", "debug",
+		"ingredients", "delete", "--id", id,
+	).Err)
+
+	filter := `correlation_id == "` + correlationID + `"`
+	audited := cli.Run("audit", "list", "--filter", filter, "--json")
+	testutil.Ok(t, audited.Err)
+	var page struct {
+		Items []struct {
+			Action        string
+			CorrelationID string
+		} `json:"items"`
+	}
+	testutil.Ok(t, json.Unmarshal([]byte(audited.Stdout), &page))
+	testutil.Equals(t, len(page.Items), 1)
+	testutil.StringContains(t, page.Items[0].Action, `Action::"retire"`)
+	testutil.Equals(t, page.Items[0].CorrelationID, correlationID)
+
+	events := cli.Run("events", "list", "--filter", filter, "--json")
+	testutil.Ok(t, events.Err)
+	testutil.StringContains(t, events.Stdout, "ingredients.IngredientDeleted")
+	testutil.StringContains(t, events.Stdout, `"CorrelationID": "`+correlationID+`"`)
+
+	data, err := os.ReadFile(logs)
+	testutil.Ok(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	testutil.IsTrue(t, len(lines) > 1)
+	for _, line := range lines {
+		var record map[string]any
+		testutil.Ok(t, json.Unmarshal([]byte(line), &record))
+		testutil.Equals(t, record["correlation_id"], correlationID)
+	}
+
+	all := cli.Run("audit", "list", "--json")
+	testutil.Ok(t, all.Err)
+	testutil.Equals(t, strings.Count(all.Stdout, correlationID), 1)
+	testutil.Equals(t, strings.Count(all.Stdout, `"CorrelationID": "`), 2)
+}
@@ -42,6 +42,7 @@ func (c *CLI) drinksCommands() *cli.Command {
 							return drinksmodels.GlassType(strings.TrimSpace(s)).Validate()
 						},
 					},
+					includeDeletedFlag(),
 				}, listPagingFlags()...)),
 				Action: filterAction(c, drinksmodels.ListFilterSchema(), func(ctx *middleware.Context, cmd *cli.Command) error {
 					pageReq := pagingRequest(cmd)
@@ -52,6 +53,8 @@ func (c *CLI) drinksCommands() *cli.Command {
 						Filter:   cmd.String("filter"),
 						Cursor:   pageReq.Cursor,
 						Limit:    pageReq.Limit,
+
+						IncludeDeleted: cmd.Bool("include-deleted"),
 					})
 					if err != nil {
 						return err
@@ -95,7 +98,7 @@ func (c *CLI) drinksCommands() *cli.Command {
 					return clitable.PrintDetail(cmd.Writer, drinkscli.ToDrinkRow(res))
 				}),
 			},
-			{
+			c.mutation(&cli.Command{
 				Name:  "create",
 				Usage: "Create a new drink",
 				Flags: appendTagsFlag([]cli.Flag{
@@ -128,8 +131,8 @@ func (c *CLI) drinksCommands() *cli.Command {
 					_, err = fmt.Fprintln(cmd.Writer, res.ID.String())
 					return err
 				}),
-			},
-			{
+			}),
+			c.mutation(&cli.Command{
 				Name:  "update",
 				Usage: "Update a drink",
 				Flags: appendTagsFlag([]cli.Flag{
@@ -162,8 +165,8 @@ func (c *CLI) drinksCommands() *cli.Command {
 					_, err = fmt.Fprintln(cmd.Writer, res.ID.String())
 					return err
 				}),
-			},
-			{
+			}),
+			c.mutation(&cli.Command{
 				Name:  "delete",
 				Usage: "Delete a drink by ID",
 				Flags: []cli.Flag{
@@ -187,7 +190,56 @@ func (c *CLI) drinksCommands() *cli.Command {
 					_, err = fmt.Fprintln(cmd.Writer, res.ID.String())
 					return err
 				}),
-			},
+			}),
+			c.mutation(&cli.Command{
+				Name:  "restore",
+				Usage: "Restore a deleted drink",
+				Flags: []cli.Flag{
+					clitoolkit.JSONFlag,
+					&cli.StringFlag{Name: "id", Usage: "Drink ID", Required: true},
+				},
+				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+					id, err := entity.ParseDrinkID(cmd.String("id"))
+					if err != nil {
+						return err
+					}
+					restored, err := c.app.Drinks.Restore(ctx, id)
+					if err != nil {
+						return err
+					}
+					if cmd.Bool("json") {
+						return clitoolkit.WriteJSON(cmd.Writer, drinkscli.FromDomainDrink(*restored))
+					}
+					_, err = fmt.Fprintln(cmd.Writer, restored.ID.String())
+					return err
+				}),
+			}),
+			c.mutation(&cli.Command{
+				Name:  "transfer",
+				Usage: "Transfer ownership of a drink to another actor",
+				Flags: transferFlags("Drink ID"),
+				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+					drinkID, err := entity.ParseDrinkID(cmd.String("id"))
+					if err != nil {
+						return err
+					}
+					owner, err := transferOwner(cmd)
+					if err != nil {
+						return err
+					}
+					res, err := c.app.Drinks.TransferOwnership(ctx, drinkID, owner)
+					if err != nil {
+						return err
+					}
+
+					if cmd.Bool("json") {
+						return clitoolkit.WriteJSON(cmd.Writer, drinkscli.FromDomainDrink(*res))
+					}
+
+					_, err = fmt.Fprintln(cmd.Writer, res.ID.String())
+					return err
+				}),
+			}),
 		},
 	}
 }
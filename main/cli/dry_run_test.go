@@ -0,0 +1,75 @@
+//nolint:paralleltest // CLI integration owns a persistent database lifecycle.
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"github.com/urfave/cli/v3"
+)
+
+func TestIngredientsCLIRetireDryRunReportsBlastRadiusAndKeepsNothing(t *testing.T) {
+	dir := t.TempDir()
+	cli := newCLIE2E(filepath.Join(dir, "dry-run.db"))
+	ingredient := strings.TrimSpace(cli.Run("ingredients", "create", "Herradura", "--category", "spirit", "--unit", "oz").Stdout)
+	input := filepath.Join(dir, "drink.json")
+	testutil.Ok(t, os.WriteFile(input, []byte(`{"name":"House Margarita","category":"cocktail","glass":"coupe","recipe":{"ingredients":[{"ingredient_id":"`+ingredient+`","amount":1,"unit":"oz"}],"steps":["shake"]}}`), 0o600))
+	drink := strings.TrimSpace(cli.Run("drinks", "create", "--file", input).Stdout)
+	auditBefore := cli.Run("audit", "list", "--json").Stdout
+
+	result := cli.Run("ingredients", "retire", "--id", ingredient, "--dry-run")
+	testutil.Ok(t, result.Err)
+	testutil.StringContains(t, result.Stderr, "Dry run: rolled back, nothing was saved")
+	testutil.StringContains(t, result.Stderr, `Action::"retire"`)
+	testutil.StringContains(t, result.Stderr, "touches Mixology::Drink::\""+drink+"\"")
+	testutil.StringContains(t, result.Stderr, "raises ingredients.IngredientDeleted")
+
+	shown := cli.Run("drinks", "get", "--id", drink, "--json")
+	testutil.Ok(t, shown.Err)
+	testutil.StringContains(t, shown.Stdout, `"status": "active"`)
+	testutil.Equals(t, cli.Run("audit", "list", "--json").Stdout, auditBefore)
+}
+
+func TestEveryMutationOffersDryRun(t *testing.T) {
+	c, err := NewCLI()
+	testutil.Ok(t, err)
+
+	var got []string
+	var walk func(prefix string, command *cli.Command)
+	walk = func(prefix string, command *cli.Command) {
+		path := strings.TrimSpace(prefix + " " + command.Name)
+		for _, flag := range command.Flags {
+			if slices.Contains(flag.Names(), "dry-run") {
+				got = append(got, path)
+			}
+		}
+		for _, child := range command.Commands {
+			walk(path, child)
+		}
+	}
+	for _, command := range c.Command().Commands {
+		walk("", command)
+	}
+	slices.Sort(got)
+
+	want := []string{
+		"batch",
+		"check",
+		"drinks create", "drinks delete", "drinks restore", "drinks transfer", "drinks update",
+		"import",
+		"ingredients create", "ingredients restore", "ingredients retire", "ingredients update",
+		"inventory adjust", "inventory set",
+		"menus add-drink", "menus create", "menus delete", "menus draft", "menus publish",
+		"menus remove-drink", "menus restore", "menus transfer", "menus update",
+		"migrate up",
+		"orders cancel", "orders complete", "orders delete", "orders place", "orders restore", "orders transfer",
+		"outbox retry",
+		"tags add", "tags remove",
+		"undo",
+	}
+	testutil.Equals(t, got, want)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TheFellow/go-modular-monolith/app"
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog"
+	eventlogmodels "github.com/TheFellow/go-modular-monolith/app/domains/eventlog/models"
+	eventlogcli "github.com/TheFellow/go-modular-monolith/app/domains/eventlog/surfaces/cli"
+	"github.com/TheFellow/go-modular-monolith/pkg/dispatcher"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	clitoolkit "github.com/TheFellow/go-modular-monolith/pkg/toolkits/cli"
+	clitable "github.com/TheFellow/go-modular-monolith/pkg/toolkits/cli/table"
+	"github.com/urfave/cli/v3"
+)
+
+func (c *CLI) eventsCommands() *cli.Command {
+	return &cli.Command{
+		Name:  "events",
+		Usage: "Recorded domain events",
+		Commands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "List recorded events in the order they were dispatched",
+				Flags: appendFilterFlags(append([]cli.Flag{
+					clitoolkit.JSONFlag,
+					&cli.StringFlag{Name: "type", Usage: "Filter by event type (for example menus.MenuPublished)"},
+					&cli.StringFlag{Name: "activity", Usage: "Filter by originating audit entry ID"},
+					&cli.Int64Flag{Name: "from", Usage: "First sequence number"},
+					&cli.Int64Flag{Name: "to", Usage: "Last sequence number"},
+				}, listPagingFlags()...)),
+				Action: filterAction(c, eventlogmodels.ListFilterSchema(), func(ctx *middleware.Context, cmd *cli.Command) error {
+					return c.listEvents(ctx, cmd)
+				}),
+			},
+			{
+				Name:  "replay",
+				Usage: "Replay recorded events into a scratch database",
+				Flags: []cli.Flag{
+					clitoolkit.JSONFlag,
+					&cli.StringFlag{Name: "scratch", Usage: "Database to create and replay into", Required: true},
+					&cli.StringFlag{Name: "base", Usage: "Database to seed the scratch copy from (default: the live database)"},
+					&cli.Int64Flag{Name: "from", Usage: "First sequence number"},
+					&cli.Int64Flag{Name: "to", Usage: "Last sequence number"},
+					&cli.StringFlag{Name: "type", Usage: "Replay only events of this type"},
+					&cli.StringFlag{Name: "handlers", Usage: "Comma-separated handler domains (" + strings.Join(dispatcher.HandlerDomains(), ", ") + "); default all"},
+				},
+				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+					return c.replayEvents(ctx, cmd)
+				}),
+			},
+		},
+	}
+}
+
+func (c *CLI) listEvents(ctx *middleware.Context, cmd *cli.Command) error {
+	pageReq := pagingRequest(cmd)
+	page, err := c.app.EventLog.List(ctx, eventlog.ListRequest{
+		Type:       cmd.String("type"),
+		ActivityID: cmd.String("activity"),
+		From:       cmd.Int64("from"),
+		To:         cmd.Int64("to"),
+		Filter:     cmd.String("filter"),
+		Cursor:     pageReq.Cursor,
+		Limit:      pageReq.Limit,
+	})
+	if err != nil {
+		return err
+	}
+	if cmd.Bool("json") {
+		return clitoolkit.WriteJSON(cmd.Writer, page)
+	}
+	if err := clitable.PrintTable(cmd.Writer, eventlogcli.ToEventRows(page.Items)); err != nil {
+		return err
+	}
+	return printNextCursor(cmd.Writer, page.Next)
+}
+
+func (c *CLI) replayEvents(ctx *middleware.Context, cmd *cli.Command) error {
+	rng := eventlogmodels.ReplayRange{From: cmd.Int64("from"), To: cmd.Int64("to")}
+	if typ := cmd.String("type"); typ != "" {
+		rng.Types = []string{typ}
+	}
+	var handlers []string
+	for _, handler := range strings.Split(cmd.String("handlers"), ",") {
+		if handler = strings.TrimSpace(handler); handler != "" {
+			handlers = append(handlers, handler)
+		}
+	}
+	scratch := cmd.String("scratch")
+	replay, err := c.app.ReplayEvents(ctx, rng, app.ReplayOptions{
+		Scratch:  scratch,
+		Base:     cmd.String("base"),
+		Handlers: handlers,
+	})
+	if err != nil {
+		return err
+	}
+	view := eventlogcli.ToReplay(scratch, replay)
+	if cmd.Bool("json") {
+		return clitoolkit.WriteJSON(cmd.Writer, view)
+	}
+	if err := clitable.PrintTable(cmd.Writer, view.Events); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(cmd.Writer, "replayed %d events into %s with %s handlers; %d entities touched, %d differ from the live database\n",
+		len(view.Events), scratch, strings.Join(view.Handlers, ", "), len(view.Touched), len(replay.Differences))
+	if err != nil || len(view.Differences) == 0 {
+		return err
+	}
+	if _, err := fmt.Fprintln(cmd.Writer); err != nil {
+		return err
+	}
+	return clitable.PrintTable(cmd.Writer, view.Differences)
+}
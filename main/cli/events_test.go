@@ -0,0 +1,51 @@
+//nolint:paralleltest // fresh-process integration tests deliberately serialize database lifecycles.
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestEventsCLIListAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	cli := newCLIE2E(filepath.Join(dir, "events.db"))
+	ingredient := cli.Run("ingredients", "create", "Replay Gin", "--category", "spirit", "--unit", "oz")
+	testutil.Ok(t, ingredient.Err)
+	ingredientID := strings.TrimSpace(ingredient.Stdout)
+	testutil.Ok(t, cli.Run("inventory", "set", "--ingredient-id", ingredientID, "--quantity", "10", "--cost-per-unit", "$1.00").Err)
+
+	listed := cli.Run("events", "list", "--type", "inventory.StockAdjusted")
+	testutil.Ok(t, listed.Err)
+	testutil.StringContains(t, listed.Stdout, "inventory.StockAdjusted")
+	testutil.StringContains(t, listed.Stdout, "aud-")
+	filtered := cli.Run("events", "list", "--filter", `type.startsWith("ingredients.")`)
+	testutil.Ok(t, filtered.Err)
+	testutil.StringContains(t, filtered.Stdout, "ingredients.IngredientCreated")
+
+	scratch := filepath.Join(dir, "scratch.db")
+	replayed := cli.Run("events", "replay", "--scratch", scratch, "--handlers", "menus", "--json")
+	testutil.Ok(t, replayed.Err)
+	var view struct {
+		Scratch  string   `json:"scratch"`
+		Handlers []string `json:"handlers"`
+		Events   []struct {
+			Type string `json:"type"`
+		} `json:"events"`
+	}
+	testutil.Ok(t, json.Unmarshal([]byte(replayed.Stdout), &view))
+	testutil.Equals(t, view.Scratch, scratch)
+	testutil.Equals(t, view.Handlers, []string{"menus"})
+	testutil.Equals(t, len(view.Events), 2)
+
+	again := cli.Run("events", "replay", "--scratch", scratch)
+	testutil.Equals(t, again.ExitCode, errors.ExitFailedPrecondition)
+	unknown := cli.Run("events", "replay", "--scratch", filepath.Join(dir, "other.db"), "--handlers", "audit")
+	testutil.Equals(t, unknown.ExitCode, errors.ExitInvalid)
+	denied := cli.As("manager").Run("events", "replay", "--scratch", filepath.Join(dir, "denied.db"))
+	testutil.Equals(t, denied.ExitCode, errors.ExitPermission)
+}
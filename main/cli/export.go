@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/TheFellow/go-modular-monolith/app"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/seal"
+	clitoolkit "github.com/TheFellow/go-modular-monolith/pkg/toolkits/cli"
+	"github.com/urfave/cli/v3"
+)
+
+type importResult struct {
+	Ingredients  int              `json:"ingredients"`
+	Drinks       int              `json:"drinks"`
+	Stock        int              `json:"stock"`
+	Reservations int              `json:"reservations"`
+	Menus        int              `json:"menus"`
+	Orders       int              `json:"orders"`
+	AuditEntries int              `json:"audit_entries"`
+	Conflicts    []importConflict `json:"conflicts,omitempty"`
+}
+
+type importConflict struct {
+	Entity string `json:"entity"`
+	Reason string `json:"reason"`
+}
+
+func (c *CLI) exportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "Write every domain's data, with tags and the audit log, as a versioned JSON document",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Usage: "Write the document to this file instead of stdout; sealed when a key is configured"},
+		},
+		Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+			doc, err := c.app.Export(ctx)
+			if err != nil {
+				return err
+			}
+			path := strings.TrimSpace(cmd.String("output"))
+			if path == "" {
+				return clitoolkit.WriteJSON(cmd.Writer, doc)
+			}
+			err = writeOutput(cmd.Writer, path, c.app.Store.Key(), func(w io.Writer) error {
+				if err := clitoolkit.WriteJSON(w, doc); err != nil {
+					return errors.Internalf("write export file: %w", err)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(cmd.Writer, "exported %d ingredients, %d drinks, %d stock, %d menus, %d orders and %d audit entries to %s\n",
+				len(doc.Ingredients), len(doc.Drinks), len(doc.Inventory), len(doc.Menus), len(doc.Orders), len(doc.Audit), path)
+			return err
+		}),
+	}
+}
+
+// importCommand loads an export into this database. Conflicts are printed
+// before the command fails, so one run shows everything that collides.
+func (c *CLI) importCommand() *cli.Command {
+	return c.mutation(&cli.Command{
+		Name:  "import",
+		Usage: "Recreate an exported document's data, keeping IDs and timestamps, in one transaction",
+		Flags: []cli.Flag{
+			clitoolkit.StdinFlag,
+			clitoolkit.FileFlag,
+			clitoolkit.JSONFlag,
+		},
+		Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+			doc, err := c.readExportDocument(cmd)
+			if err != nil {
+				return err
+			}
+			report, importErr := c.app.Import(ctx, &doc)
+			if importErr != nil && len(report.Conflicts) == 0 {
+				return importErr
+			}
+			out := toImportResult(report)
+			if cmd.Bool("json") {
+				if err := clitoolkit.WriteJSON(cmd.Writer, out); err != nil {
+					return err
+				}
+				return importErr
+			}
+			for _, conflict := range out.Conflicts {
+				if _, err := fmt.Fprintf(cmd.ErrWriter, "conflict %s: %s\n", conflict.Entity, conflict.Reason); err != nil {
+					return err
+				}
+			}
+			if importErr != nil {
+				return importErr
+			}
+			_, err = fmt.Fprintf(cmd.Writer, "imported %d ingredients, %d drinks, %d stock, %d reservations, %d menus, %d orders and %d audit entries\n",
+				out.Ingredients, out.Drinks, out.Stock, out.Reservations, out.Menus, out.Orders, out.AuditEntries)
+			return err
+		}),
+	})
+}
+
+// readExportDocument reads the document to import. A sealed export file is
+// unsealed with the configured key.
+func (c *CLI) readExportDocument(cmd *cli.Command) (app.ExportDocument, error) {
+	var doc app.ExportDocument
+	path := strings.TrimSpace(cmd.String("file"))
+	sealed := false
+	if path != "" && !cmd.Bool("stdin") {
+		var err error
+		if sealed, err = seal.IsSealed(path); err != nil && !os.IsNotExist(err) {
+			return doc, errors.Internalf("open %s: %w", path, err)
+		}
+	}
+	if !sealed {
+		doc, err := clitoolkit.ReadJSONInput[app.ExportDocument](cmd)
+		if err != nil {
+			return doc, errors.Invalidf("read export document: %w", err)
+		}
+		return doc, nil
+	}
+
+	key := c.app.Store.Key()
+	if key == nil {
+		return doc, errors.FailedPreconditionf("%s is sealed; import it with its key", path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return doc, errors.Internalf("open %s: %w", path, err)
+	}
+	defer f.Close()
+	r, err := seal.NewReader(f, key, path)
+	if err != nil {
+		return doc, err
+	}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		if errors.IsInvalid(err) {
+			return doc, err
+		}
+		return doc, errors.Invalidf("read export document: %w", err)
+	}
+	return doc, nil
+}
+
+func toImportResult(report app.ImportReport) importResult {
+	out := importResult{
+		Ingredients:  report.Ingredients,
+		Drinks:       report.Drinks,
+		Stock:        report.Stock,
+		Reservations: report.Reservations,
+		Menus:        report.Menus,
+		Orders:       report.Orders,
+		AuditEntries: report.AuditEntries,
+	}
+	for _, conflict := range report.Conflicts {
+		out.Conflicts = append(out.Conflicts, importConflict{Entity: conflict.Entity.String(), Reason: conflict.Reason})
+	}
+	return out
+}
@@ -0,0 +1,47 @@
+//nolint:paralleltest // fresh-process integration tests deliberately serialize database lifecycles.
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestExportImportCLICopiesDatabaseAndReportsConflicts(t *testing.T) {
+	dir := t.TempDir()
+	source := newCLIE2E(filepath.Join(dir, "source.db"))
+	gin := source.Run("ingredients", "create", "Export Gin", "--category", "spirit", "--unit", "oz", "--tags", "region=east")
+	testutil.Ok(t, gin.Err)
+	ginID := strings.TrimSpace(gin.Stdout)
+	testutil.Ok(t, source.Run("inventory", "set", "--ingredient-id", ginID, "--quantity", "5", "--cost-per-unit", "$1.00").Err)
+
+	document := filepath.Join(dir, "export.json")
+	exported := source.Run("export", "--output", document)
+	testutil.Ok(t, exported.Err)
+	testutil.StringContains(t, exported.Stdout, "exported 1 ingredients")
+
+	target := newCLIE2E(filepath.Join(dir, "target.db"))
+	dry := target.Run("import", "--file", document, "--dry-run")
+	testutil.Ok(t, dry.Err)
+	testutil.StringContains(t, dry.Stderr, "nothing was saved")
+	testutil.StringContains(t, target.Run("ingredients", "list", "--json").Stdout, `"items": []`)
+
+	imported := target.Run("import", "--file", document, "--json")
+	testutil.Ok(t, imported.Err)
+	var report importResult
+	testutil.Ok(t, json.Unmarshal([]byte(imported.Stdout), &report))
+	testutil.Equals(t, report.Ingredients, 1)
+	testutil.Equals(t, report.Stock, 1)
+	shown := target.Run("ingredients", "get", "--id", ginID, "--json")
+	testutil.Ok(t, shown.Err)
+	testutil.StringContains(t, shown.Stdout, "region=east")
+	testutil.Ok(t, target.Run("audit", "verify").Err)
+
+	again := target.Run("import", "--file", document)
+	testutil.Equals(t, again.ExitCode, errors.ExitConflict)
+	testutil.StringContains(t, again.Stderr, "conflict Mixology::Ingredient::\""+ginID+"\"")
+}
@@ -8,10 +8,12 @@ import (
 
 	auditmodels "github.com/TheFellow/go-modular-monolith/app/domains/audit/models"
 	drinksmodels "github.com/TheFellow/go-modular-monolith/app/domains/drinks/models"
+	eventlogmodels "github.com/TheFellow/go-modular-monolith/app/domains/eventlog/models"
 	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
 	inventorymodels "github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
 	menusmodels "github.com/TheFellow/go-modular-monolith/app/domains/menus/models"
 	ordersmodels "github.com/TheFellow/go-modular-monolith/app/domains/orders/models"
+	outboxmodels "github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
 	"github.com/TheFellow/go-modular-monolith/pkg/filter"
 	"github.com/TheFellow/go-modular-monolith/pkg/set"
 	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
@@ -45,6 +47,8 @@ func TestEveryGeneratedFilterExampleParses(t *testing.T) {
 	checkFilterExamples(t, inventorymodels.ListFilterSchema())
 	checkFilterExamples(t, menusmodels.ListFilterSchema())
 	checkFilterExamples(t, ordersmodels.ListFilterSchema())
+	checkFilterExamples(t, outboxmodels.ListFilterSchema())
+	checkFilterExamples(t, eventlogmodels.ListFilterSchema())
 }
 
 func TestIdentifierFilterExamplesMatchPersistedFormats(t *testing.T) {
@@ -113,6 +117,9 @@ func TestEveryListCommandHasFilterFlags(t *testing.T) {
 		if noun.Name == "tags" { // Cross-cutting tag operations, not a domain entity list.
 			continue
 		}
+		if noun.Name == "backup" { // Snapshot files in a directory, not stored entities.
+			continue
+		}
 		for _, command := range noun.Commands {
 			if command.Name != "list" {
 				continue
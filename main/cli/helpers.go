@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"math"
@@ -12,10 +13,14 @@ import (
 	"github.com/TheFellow/go-modular-monolith/app"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/money"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
+	"github.com/TheFellow/go-modular-monolith/pkg/authn"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	appfilter "github.com/TheFellow/go-modular-monolith/pkg/filter"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
 	"github.com/TheFellow/go-modular-monolith/pkg/paging"
+	"github.com/TheFellow/go-modular-monolith/pkg/presentation/preview"
+	clitoolkit "github.com/TheFellow/go-modular-monolith/pkg/toolkits/cli"
+	cedar "github.com/cedar-policy/cedar-go"
 	"github.com/urfave/cli/v3"
 )
 
@@ -26,6 +31,10 @@ func listPagingFlags() []cli.Flag {
 	}
 }
 
+func includeDeletedFlag() cli.Flag {
+	return &cli.BoolFlag{Name: "include-deleted", Usage: "Include soft-deleted entries (shown with DELETED_AT)"}
+}
+
 func filterFlags() []cli.Flag {
 	return []cli.Flag{
 		&cli.StringFlag{Name: "filter", Usage: "Filter expression (run with --filter-help for fields and examples)"},
@@ -48,6 +57,86 @@ func appendTagsFlag(flags []cli.Flag) []cli.Flag {
 	return append(flags, tagsFlag())
 }
 
+func dryRunFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Run the command, report what it would change on stderr, then roll it back",
+	}
+}
+
+func idempotencyKeyFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "idempotency-key",
+		Usage: "Remember the result under this key; repeating the command with the same key and input returns it without running again",
+	}
+}
+
+func expectedVersionFlag() cli.Flag {
+	return &cli.Int64Flag{
+		Name:  "expected-version",
+		Usage: "Fail with a conflict unless the stored version still matches",
+	}
+}
+
+// mutation gives a command that runs domain commands --dry-run and
+// --idempotency-key flags. A dry run executes the action completely, so its
+// usual output describes the result, then prints the preview and keeps
+// nothing.
+func (c *CLI) mutation(command *cli.Command) *cli.Command {
+	command.Flags = append(command.Flags, dryRunFlag(), idempotencyKeyFlag())
+	action := command.Action
+	command.Action = func(ctx context.Context, cmd *cli.Command) error {
+		key := cmd.String("idempotency-key")
+		if !cmd.Bool("dry-run") && key == "" {
+			return action(ctx, cmd)
+		}
+		mctx, ok := ctx.(*middleware.Context)
+		if !ok {
+			return errors.ToCLIExit(fmt.Errorf("expected middleware context"))
+		}
+		if key != "" {
+			keyed, err := mctx.WithIdempotencyKey(key)
+			if err != nil {
+				return errors.ToCLIExit(err)
+			}
+			mctx = keyed
+		}
+		if !cmd.Bool("dry-run") {
+			return action(mctx, cmd)
+		}
+		dryCtx, p := mctx.WithDryRun()
+		if err := action(dryCtx, cmd); err != nil {
+			return err
+		}
+		lines := append([]string{"Dry run: rolled back, nothing was saved"}, preview.Lines(p)...)
+		_, err := fmt.Fprintln(cmd.ErrWriter, strings.Join(lines, "\n"))
+		return errors.ToCLIExit(err)
+	}
+	return command
+}
+
+func transferFlags(idUsage string) []cli.Flag {
+	return []cli.Flag{
+		clitoolkit.JSONFlag,
+		&cli.StringFlag{Name: "id", Usage: idUsage, Required: true},
+		&cli.StringFlag{Name: "to", Usage: "New owner (manager, sommelier, bartender, or owner)", Required: true},
+	}
+}
+
+// transferOwner parses the --to flag. An explicit name is required: the
+// process-wide actor default must not silently make the owner actor an owner.
+func transferOwner(cmd *cli.Command) (cedar.EntityUID, error) {
+	value := strings.TrimSpace(cmd.String("to"))
+	if value == "" {
+		return cedar.EntityUID{}, errors.Invalidf("--to is required")
+	}
+	owner, err := authn.ParseActor(value)
+	if err != nil {
+		return cedar.EntityUID{}, errors.Invalidf("--to: %w", err)
+	}
+	return owner, nil
+}
+
 // runTaggedMutation keeps a domain mutation and its optional complete tag-set
 // replacement in one caller-owned transaction. Parsing happens first so bad
 // input cannot execute the domain mutation. An omitted flag preserves tags;
@@ -167,7 +256,7 @@ func printNextCursor(w io.Writer, cursor paging.Cursor) error {
 var (
 	CostsFlag        cli.Flag = &cli.BoolFlag{Name: "costs", Usage: "Include cost/margin analytics"}
 	TargetMarginFlag cli.Flag = &cli.Float64Flag{
-		Name: "target-margin", Usage: "Target margin for suggested prices (0-1)", Value: 0.7,
+		Name: "target-margin", Usage: "Target margin for suggested prices (0-1) (default: the configured target margin)",
 		Validator: func(value float64) error {
 			if math.IsNaN(value) || math.IsInf(value, 0) || value <= 0 || value >= 1 {
 				return errors.Invalidf("target margin must be a number between 0 and 1")
@@ -177,6 +266,15 @@ var (
 	}
 )
 
+// targetMargin is --target-margin when given and the configured default
+// otherwise.
+func (c *CLI) targetMargin(cmd *cli.Command) float64 {
+	if cmd.IsSet("target-margin") {
+		return cmd.Float64("target-margin")
+	}
+	return c.app.Defaults().TargetMargin
+}
+
 func newTabWriter(output io.Writer) *tabwriter.Writer {
 	return tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
 }
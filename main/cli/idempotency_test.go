@@ -0,0 +1,41 @@
+//nolint:paralleltest // CLI integration owns a persistent database lifecycle.
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestOrdersCLIIdempotencyKeyPlacesOrderOnce(t *testing.T) {
+	dir := t.TempDir()
+	cli := newCLIE2E(filepath.Join(dir, "idempotency.db"))
+	script := filepath.Join(dir, "ops.json")
+	testutil.Ok(t, os.WriteFile(script, []byte(cli.Run("batch", "--template").Stdout), 0o600))
+	setup := cli.Run("batch", "--file", script, "--json")
+	testutil.Ok(t, setup.Err)
+	var steps []batchOutput
+	testutil.Ok(t, json.Unmarshal([]byte(setup.Stdout), &steps))
+	menu, drink := steps[3].EntityID, steps[2].EntityID
+
+	place := func(quantity string) cliResult {
+		return cli.Run("orders", "place", "--menu-id", menu, drink+":"+quantity, "--idempotency-key", "pos-42")
+	}
+	first := place("1")
+	testutil.Ok(t, first.Err)
+	retry := place("1")
+	testutil.Ok(t, retry.Err)
+	testutil.Equals(t, retry.Stdout, first.Stdout)
+
+	reused := place("2")
+	testutil.ErrorIf(t, reused.Err == nil, "%v", "reusing a key for a different order succeeded")
+	testutil.StringContains(t, reused.Stderr, `idempotency key "pos-42" was already used for a different request`)
+
+	orders := cli.Run("orders", "list")
+	testutil.Ok(t, orders.Err)
+	testutil.Equals(t, strings.Count(orders.Stdout, "ord-"), 1)
+}
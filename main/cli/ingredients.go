@@ -33,6 +33,7 @@ func (c *CLI) ingredientsCommands() *cli.Command {
 						Usage:     ingredientscli.CategoryUsage(),
 						Validator: ingredientscli.ValidateCategory,
 					},
+					includeDeletedFlag(),
 				}, listPagingFlags()...)),
 				Action: filterAction(c, models.ListFilterSchema(), func(ctx *middleware.Context, cmd *cli.Command) error {
 					pageReq := pagingRequest(cmd)
@@ -41,6 +42,8 @@ func (c *CLI) ingredientsCommands() *cli.Command {
 						Filter:   cmd.String("filter"),
 						Cursor:   pageReq.Cursor,
 						Limit:    pageReq.Limit,
+
+						IncludeDeleted: cmd.Bool("include-deleted"),
 					})
 					if err != nil {
 						return err
@@ -82,7 +85,7 @@ func (c *CLI) ingredientsCommands() *cli.Command {
 					return clitable.PrintDetail(cmd.Writer, ingredientscli.ToIngredientRow(res))
 				}),
 			},
-			{
+			c.mutation(&cli.Command{
 				Name:  "create",
 				Usage: "Create a new ingredient",
 				Arguments: []cli.Argument{
@@ -163,8 +166,8 @@ func (c *CLI) ingredientsCommands() *cli.Command {
 					_, err = fmt.Fprintln(cmd.Writer, res.ID.String())
 					return err
 				}),
-			},
-			{
+			}),
+			c.mutation(&cli.Command{
 				Name:  "update",
 				Usage: "Update an ingredient",
 				Flags: appendTagsFlag([]cli.Flag{
@@ -195,6 +198,7 @@ func (c *CLI) ingredientsCommands() *cli.Command {
 						Aliases: []string{"d"},
 						Usage:   "Description",
 					},
+					expectedVersionFlag(),
 				}),
 				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
 					if cmd.Bool("template") {
@@ -220,6 +224,7 @@ func (c *CLI) ingredientsCommands() *cli.Command {
 							Category:    models.Category(row.Category),
 							Unit:        measurement.Unit(row.Unit),
 							Description: row.Desc,
+							Version:     row.Version,
 						}
 					} else {
 						id := strings.TrimSpace(cmd.String("id"))
@@ -236,6 +241,7 @@ func (c *CLI) ingredientsCommands() *cli.Command {
 							Category:    models.Category(cmd.String("category")),
 							Unit:        measurement.Unit(cmd.String("unit")),
 							Description: cmd.String("description"),
+							Version:     cmd.Int64("expected-version"),
 						}
 					}
 
@@ -253,8 +259,8 @@ func (c *CLI) ingredientsCommands() *cli.Command {
 					_, err = fmt.Fprintln(cmd.Writer, res.ID.String())
 					return err
 				}),
-			},
-			{
+			}),
+			c.mutation(&cli.Command{
 				Name:    "retire",
 				Aliases: []string{"delete"},
 				Usage:   "Retire an ingredient and mark dependent drinks for review",
@@ -288,7 +294,30 @@ func (c *CLI) ingredientsCommands() *cli.Command {
 					_, err = fmt.Fprintln(cmd.Writer, res.ID.String())
 					return err
 				}),
-			},
+			}),
+			c.mutation(&cli.Command{
+				Name:  "restore",
+				Usage: "Restore a deleted ingredient",
+				Flags: []cli.Flag{
+					clitoolkit.JSONFlag,
+					&cli.StringFlag{Name: "id", Usage: "Ingredient ID", Required: true},
+				},
+				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+					id, err := entity.ParseIngredientID(cmd.String("id"))
+					if err != nil {
+						return err
+					}
+					restored, err := c.app.Ingredients.Restore(ctx, id)
+					if err != nil {
+						return err
+					}
+					if cmd.Bool("json") {
+						return clitoolkit.WriteJSON(cmd.Writer, ingredientscli.ToIngredientRow(restored))
+					}
+					_, err = fmt.Fprintln(cmd.Writer, restored.ID.String())
+					return err
+				}),
+			}),
 		},
 	}
 }
@@ -9,7 +9,6 @@ import (
 	"github.com/TheFellow/go-modular-monolith/app/domains/inventory"
 	inventorymodels "github.com/TheFellow/go-modular-monolith/app/domains/inventory/models"
 	inventorycli "github.com/TheFellow/go-modular-monolith/app/domains/inventory/surfaces/cli"
-	"github.com/TheFellow/go-modular-monolith/app/kernel/currency"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/money"
@@ -87,7 +86,7 @@ func (c *CLI) inventoryCommands() *cli.Command {
 					return clitable.PrintDetail(cmd.Writer, inventorycli.ToInventoryRow(res))
 				}),
 			},
-			{
+			c.mutation(&cli.Command{
 				Name:  "adjust",
 				Usage: "Patch stock quantity and/or cost",
 				Flags: appendTagsFlag([]cli.Flag{
@@ -242,8 +241,8 @@ func (c *CLI) inventoryCommands() *cli.Command {
 					_, err = fmt.Fprintln(cmd.Writer, res.IngredientID.String())
 					return err
 				}),
-			},
-			{
+			}),
+			c.mutation(&cli.Command{
 				Name:  "set",
 				Usage: "Set stock quantity",
 				Flags: appendTagsFlag([]cli.Flag{
@@ -257,6 +256,7 @@ func (c *CLI) inventoryCommands() *cli.Command {
 						Name:  "cost-per-unit",
 						Usage: "Cost per unit in ingredient unit (e.g. \"$1.23\" or \"USD 1.23\")",
 					},
+					expectedVersionFlag(),
 				}),
 				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
 					if cmd.Bool("template") {
@@ -269,39 +269,10 @@ func (c *CLI) inventoryCommands() *cli.Command {
 						if err != nil {
 							return err
 						}
-						if strings.TrimSpace(input.IngredientID) == "" {
-							return errors.Invalidf("ingredient_id is required")
-						}
-						if input.Quantity == nil {
-							return errors.Invalidf("quantity is required")
-						}
-						parsedIngredientID, err := entity.ParseIngredientID(input.IngredientID)
-						if err != nil {
-							return err
-						}
-						ingredient, err := c.app.Ingredients.Get(ctx, parsedIngredientID)
-						if err != nil {
-							return err
-						}
-
-						unit := ingredient.Unit
-						if s := strings.TrimSpace(input.Unit); s != "" {
-							unit = measurement.Unit(s)
-						}
-						amount, err := measurement.NewAmount(*input.Quantity, unit)
-						if err != nil {
-							return err
-						}
-
-						cost, err := c.inventorySetCost(ctx, parsedIngredientID, input.CostPerUnit)
+						update, err = c.inventoryUpdateFromInput(ctx, input)
 						if err != nil {
 							return err
 						}
-						update = &inventorymodels.Update{
-							IngredientID: parsedIngredientID,
-							Amount:       amount,
-							CostPerUnit:  cost,
-						}
 					} else {
 						ingredientID := strings.TrimSpace(cmd.String("ingredient-id"))
 						if ingredientID == "" {
@@ -333,6 +304,7 @@ func (c *CLI) inventoryCommands() *cli.Command {
 							IngredientID: parsedIngredientID,
 							Amount:       amount,
 							CostPerUnit:  cost,
+							Version:      cmd.Int64("expected-version"),
 						}
 					}
 
@@ -350,11 +322,43 @@ func (c *CLI) inventoryCommands() *cli.Command {
 					_, err = fmt.Fprintln(cmd.Writer, res.IngredientID.String())
 					return err
 				}),
-			},
+			}),
 		},
 	}
 }
 
+// inventoryUpdateFromInput converts the JSON accepted by inventory set into
+// an update, defaulting the unit and cost from the ingredient and stock.
+func (c *CLI) inventoryUpdateFromInput(ctx *middleware.Context, input inventorycli.InventoryInput) (*inventorymodels.Update, error) {
+	if strings.TrimSpace(input.IngredientID) == "" {
+		return nil, errors.Invalidf("ingredient_id is required")
+	}
+	if input.Quantity == nil {
+		return nil, errors.Invalidf("quantity is required")
+	}
+	ingredientID, err := entity.ParseIngredientID(input.IngredientID)
+	if err != nil {
+		return nil, err
+	}
+	ingredient, err := c.app.Ingredients.Get(ctx, ingredientID)
+	if err != nil {
+		return nil, err
+	}
+	unit := ingredient.Unit
+	if s := strings.TrimSpace(input.Unit); s != "" {
+		unit = measurement.Unit(s)
+	}
+	amount, err := measurement.NewAmount(*input.Quantity, unit)
+	if err != nil {
+		return nil, err
+	}
+	cost, err := c.inventorySetCost(ctx, ingredientID, input.CostPerUnit)
+	if err != nil {
+		return nil, err
+	}
+	return &inventorymodels.Update{IngredientID: ingredientID, Amount: amount, CostPerUnit: cost, Version: input.Version}, nil
+}
+
 func (c *CLI) inventorySetCost(ctx *middleware.Context, ingredientID entity.IngredientID, raw string) (money.Price, error) {
 	if strings.TrimSpace(raw) != "" {
 		return parsePrice(raw)
@@ -364,10 +368,10 @@ func (c *CLI) inventorySetCost(ctx *middleware.Context, ingredientID entity.Ingr
 		if price, ok := stock.CostPerUnit.Unwrap(); ok {
 			return price, nil
 		}
-		return money.NewPriceFromCents(0, currency.USD), nil
+		return money.NewPriceFromCents(0, c.app.Defaults().Currency), nil
 	}
 	if errors.IsNotFound(err) {
-		return money.NewPriceFromCents(0, currency.USD), nil
+		return money.NewPriceFromCents(0, c.app.Defaults().Currency), nil
 	}
 	return money.Price{}, err
 }
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/runtimeconfig"
+	"github.com/TheFellow/go-modular-monolith/pkg/seal"
+	"github.com/urfave/cli/v3"
+)
+
+// keyCommands manage the key a sealed database is opened with. Both use the
+// key from --key-file, and neither may run while another process has the
+// database open.
+func (c *CLI) keyCommands() *cli.Command {
+	return &cli.Command{
+		Name:  "key",
+		Usage: "Seal the database and rotate its key",
+		Commands: []*cli.Command{
+			{
+				Name:  "seal",
+				Usage: "Seal a plain database with the key from --key-file",
+				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+					if err := c.app.Seal(ctx); err != nil {
+						return err
+					}
+					_, err := fmt.Fprintf(cmd.Writer, "sealed %s\n", c.dbPath)
+					return err
+				}),
+			},
+			{
+				Name:  "rotate",
+				Usage: "Reseal the database and its sealed backups with a new key",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "new-key-file", Usage: "File holding the new passphrase or key", Required: true},
+					&cli.StringFlag{
+						Name:    "dir",
+						Usage:   "Backup directory (default: backups beside the database)",
+						Sources: cli.EnvVars(runtimeconfig.EnvBackupDir),
+					},
+				},
+				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+					return c.rotateKey(ctx, cmd)
+				}),
+			},
+		},
+	}
+}
+
+func (c *CLI) rotateKey(ctx *middleware.Context, cmd *cli.Command) error {
+	if c.app.Store.Key() == nil {
+		return errors.FailedPreconditionf("--key-file is required to rotate the key of %s", c.dbPath)
+	}
+	key, err := seal.LoadKey(cmd.String("new-key-file"))
+	if err != nil {
+		return err
+	}
+	dir := cmd.String("dir")
+	if dir == "" {
+		dir = runtimeconfig.BackupDir(c.dbPath)
+	}
+	rotation, err := c.app.RotateKey(ctx, key, dir)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(cmd.Writer, "resealed %s and %d backups in %s; use %s as --key-file from now on\n",
+		c.dbPath, len(rotation.Backups), dir, cmd.String("new-key-file"))
+	return err
+}
@@ -0,0 +1,96 @@
+//nolint:paralleltest // CLI integration owns a persistent database lifecycle.
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/seal"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func writeKeyFile(t *testing.T, path, secret string) string {
+	t.Helper()
+	testutil.Ok(t, os.WriteFile(path, []byte(secret+"\n"), 0o600))
+	return path
+}
+
+func assertSealed(t *testing.T, path, plain string) {
+	t.Helper()
+	sealed, err := seal.IsSealed(path)
+	testutil.Ok(t, err)
+	testutil.IsTrue(t, sealed)
+	data, err := os.ReadFile(path)
+	testutil.Ok(t, err)
+	testutil.IsFalse(t, bytes.Contains(data, []byte(plain)))
+}
+
+func TestSealedDatabaseCLI(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sealed.db")
+	key := writeKeyFile(t, filepath.Join(dir, "mixology.key"), "house passphrase")
+	wrong := writeKeyFile(t, filepath.Join(dir, "wrong.key"), "guess")
+	cli := newCLIE2E(path)
+
+	gin := cli.Run("--key-file", key, "ingredients", "create", "Cost Price Gin", "--category", "spirit", "--unit", "oz")
+	testutil.Ok(t, gin.Err)
+	ginID := strings.TrimSpace(gin.Stdout)
+	assertSealed(t, path, "Cost Price Gin")
+
+	unkeyed := cli.Run("ingredients", "list")
+	testutil.Equals(t, unkeyed.ExitCode, errors.ExitFailedPrecondition)
+	testutil.StringContains(t, unkeyed.Stderr, "sealed")
+	wrongKey := cli.Run("--key-file", wrong, "ingredients", "list")
+	testutil.Equals(t, wrongKey.ExitCode, errors.ExitPermission)
+	testutil.StringContains(t, wrongKey.Stderr, "different key or passphrase")
+	testutil.Ok(t, cli.Run("--key-file", key, "--read-only", "ingredients", "get", "--id", ginID).Err)
+
+	exported := filepath.Join(dir, "export.json")
+	testutil.Ok(t, cli.Run("--key-file", key, "export", "--output", exported).Err)
+	assertSealed(t, exported, "Cost Price Gin")
+	testutil.Ok(t, cli.Run("--key-file", key, "audit", "export", "--output", filepath.Join(dir, "audit.jsonl")).Err)
+	assertSealed(t, filepath.Join(dir, "audit.jsonl"), "Cost Price Gin")
+	other := newCLIE2E(filepath.Join(dir, "other.db"))
+	testutil.Equals(t, other.Run("import", "--file", exported).ExitCode, errors.ExitFailedPrecondition)
+	testutil.Ok(t, other.Run("--key-file", key, "import", "--file", exported).Err)
+
+	backups := filepath.Join(dir, "backups")
+	testutil.Ok(t, cli.Run("--key-file", key, "backup", "create", "--dir", backups).Err)
+	listed, err := os.ReadDir(backups)
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(listed), 1)
+	assertSealed(t, filepath.Join(backups, listed[0].Name()), "Cost Price Gin")
+
+	rotated := writeKeyFile(t, filepath.Join(dir, "rotated.key"), "new house passphrase")
+	rotation := cli.Run("--key-file", key, "key", "rotate", "--new-key-file", rotated, "--dir", backups)
+	testutil.Ok(t, rotation.Err)
+	testutil.StringContains(t, rotation.Stdout, "and 1 backups")
+	testutil.Equals(t, cli.Run("--key-file", key, "ingredients", "list").ExitCode, errors.ExitPermission)
+	shown := cli.Run("--key-file", rotated, "ingredients", "get", "--id", ginID)
+	testutil.Ok(t, shown.Err)
+	testutil.StringContains(t, shown.Stdout, "Cost Price Gin")
+	testutil.Ok(t, cli.Run("--key-file", rotated, "backup", "verify", "--dir", backups, listed[0].Name()).Err)
+}
+
+func TestKeySealConvertsPlainDatabaseCLI(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plain.db")
+	key := writeKeyFile(t, filepath.Join(dir, "mixology.key"), "house passphrase")
+	cli := newCLIE2E(path)
+	testutil.Ok(t, cli.Run("ingredients", "create", "Staff Rum", "--category", "spirit", "--unit", "oz").Err)
+
+	testutil.Equals(t, cli.Run("key", "seal").ExitCode, errors.ExitFailedPrecondition)
+	sealed := cli.Run("--key-file", key, "key", "seal")
+	testutil.Ok(t, sealed.Err)
+	testutil.StringContains(t, sealed.Stdout, "sealed "+path)
+	assertSealed(t, path, "Staff Rum")
+	testutil.Equals(t, cli.Run("--key-file", key, "key", "seal").ExitCode, errors.ExitFailedPrecondition)
+
+	listed := cli.Run("--key-file", key, "ingredients", "list")
+	testutil.Ok(t, listed.Err)
+	testutil.StringContains(t, listed.Stdout, "Staff Rum")
+}
@@ -67,6 +67,7 @@ func (c *CLI) menuCommands() *cli.Command {
 							return menumodels.MenuStatus(s).Validate()
 						},
 					},
+					includeDeletedFlag(),
 				}, listPagingFlags()...)),
 				Action: filterAction(c, menumodels.ListFilterSchema(), func(ctx *middleware.Context, cmd *cli.Command) error {
 					pageReq := pagingRequest(cmd)
@@ -75,6 +76,8 @@ func (c *CLI) menuCommands() *cli.Command {
 						Filter: cmd.String("filter"),
 						Cursor: pageReq.Cursor,
 						Limit:  pageReq.Limit,
+
+						IncludeDeleted: cmd.Bool("include-deleted"),
 					})
 					if err != nil {
 						return err
@@ -92,7 +95,7 @@ func (c *CLI) menuCommands() *cli.Command {
 					for _, m := range res.Items {
 						rows = append(rows, menucli.ToMenuRow(m))
 						if cmd.Bool("costs") && len(m.Items) > 0 {
-							an, err := c.app.Menus.Analyze(ctx, *m, cmd.Float64("target-margin"))
+							an, err := c.app.Menus.Analyze(ctx, *m, c.targetMargin(cmd))
 							if err != nil {
 								return err
 							}
@@ -135,7 +138,7 @@ func (c *CLI) menuCommands() *cli.Command {
 
 					if cmd.Bool("json") {
 						if cmd.Bool("costs") {
-							an, err := c.app.Menus.Analyze(ctx, *res, cmd.Float64("target-margin"))
+							an, err := c.app.Menus.Analyze(ctx, *res, c.targetMargin(cmd))
 							if err != nil {
 								return err
 							}
@@ -150,7 +153,7 @@ func (c *CLI) menuCommands() *cli.Command {
 					}
 
 					if cmd.Bool("costs") {
-						an, err := c.app.Menus.Analyze(ctx, m, cmd.Float64("target-margin"))
+						an, err := c.app.Menus.Analyze(ctx, m, c.targetMargin(cmd))
 						if err != nil {
 							return err
 						}
@@ -224,7 +227,7 @@ func (c *CLI) menuCommands() *cli.Command {
 					return clitable.PrintTable(cmd.Writer, menucli.ToMenuItemRows(m.Items))
 				}),
 			},
-			{
+			c.mutation(&cli.Command{
 				Name:  "create",
 				Usage: "Create a new menu",
 				Arguments: []cli.Argument{
@@ -273,8 +276,8 @@ func (c *CLI) menuCommands() *cli.Command {
 					_, err = fmt.Fprintln(cmd.Writer, created.ID.String())
 					return err
 				}),
-			},
-			{
+			}),
+			c.mutation(&cli.Command{
 				Name:  "update",
 				Usage: "Rename a draft menu or update its non-empty description",
 				Flags: appendTagsFlag([]cli.Flag{
@@ -285,6 +288,7 @@ func (c *CLI) menuCommands() *cli.Command {
 					&cli.StringFlag{Name: "id", Usage: "Menu ID"},
 					&cli.StringFlag{Name: "name", Aliases: []string{"n"}, Usage: "New name"},
 					&cli.StringFlag{Name: "description", Aliases: []string{"d"}, Usage: "New non-empty description (blank preserves the current description)"},
+					expectedVersionFlag(),
 				}),
 				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
 					if cmd.Bool("template") {
@@ -301,7 +305,7 @@ func (c *CLI) menuCommands() *cli.Command {
 						if err != nil {
 							return err
 						}
-						input = &menumodels.Menu{ID: menuID, Name: row.Name, Description: row.Desc}
+						input = &menumodels.Menu{ID: menuID, Name: row.Name, Description: row.Desc, Version: row.Version}
 					} else {
 						rawID := strings.TrimSpace(cmd.String("id"))
 						if rawID == "" {
@@ -318,7 +322,12 @@ func (c *CLI) menuCommands() *cli.Command {
 						if err != nil {
 							return err
 						}
-						input = &menumodels.Menu{ID: menuID, Name: existing.Name, Description: existing.Description}
+						// The unchanged fields come from this read, so the
+						// update must not land on a newer version.
+						input = &menumodels.Menu{ID: menuID, Name: existing.Name, Description: existing.Description, Version: existing.Version}
+						if cmd.IsSet("expected-version") {
+							input.Version = cmd.Int64("expected-version")
+						}
 						if cmd.IsSet("name") {
 							input.Name = cmd.String("name")
 						}
@@ -339,8 +348,8 @@ func (c *CLI) menuCommands() *cli.Command {
 					_, err = fmt.Fprintln(cmd.Writer, updated.ID.String())
 					return err
 				}),
-			},
-			{
+			}),
+			c.mutation(&cli.Command{
 				Name:  "delete",
 				Usage: "Delete a draft menu",
 				Flags: []cli.Flag{
@@ -362,8 +371,31 @@ func (c *CLI) menuCommands() *cli.Command {
 					_, err = fmt.Fprintln(cmd.Writer, deleted.ID.String())
 					return err
 				}),
-			},
-			{
+			}),
+			c.mutation(&cli.Command{
+				Name:  "restore",
+				Usage: "Restore a deleted menu as a draft",
+				Flags: []cli.Flag{
+					clitoolkit.JSONFlag,
+					&cli.StringFlag{Name: "id", Usage: "Menu ID", Required: true},
+				},
+				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+					id, err := entity.ParseMenuID(cmd.String("id"))
+					if err != nil {
+						return err
+					}
+					restored, err := c.app.Menus.Restore(ctx, id)
+					if err != nil {
+						return err
+					}
+					if cmd.Bool("json") {
+						return clitoolkit.WriteJSON(cmd.Writer, menucli.FromDomainMenu(*restored))
+					}
+					_, err = fmt.Fprintln(cmd.Writer, restored.ID.String())
+					return err
+				}),
+			}),
+			c.mutation(&cli.Command{
 				Name:  "add-drink",
 				Usage: "Add a drink to a menu",
 				Flags: appendTagsFlag([]cli.Flag{
@@ -396,8 +428,8 @@ func (c *CLI) menuCommands() *cli.Command {
 					_, err = fmt.Fprintln(cmd.Writer, updated.ID.String())
 					return err
 				}),
-			},
-			{
+			}),
+			c.mutation(&cli.Command{
 				Name:  "remove-drink",
 				Usage: "Remove a drink from a menu",
 				Flags: appendTagsFlag([]cli.Flag{
@@ -430,8 +462,8 @@ func (c *CLI) menuCommands() *cli.Command {
 					_, err = fmt.Fprintln(cmd.Writer, updated.ID.String())
 					return err
 				}),
-			},
-			{
+			}),
+			c.mutation(&cli.Command{
 				Name:  "publish",
 				Usage: "Publish a menu",
 				Flags: appendTagsFlag([]cli.Flag{
@@ -457,8 +489,8 @@ func (c *CLI) menuCommands() *cli.Command {
 					_, err = fmt.Fprintln(cmd.Writer, published.ID.String())
 					return err
 				}),
-			},
-			{
+			}),
+			c.mutation(&cli.Command{
 				Name:  "draft",
 				Usage: "Return a published menu to draft status",
 				Flags: appendTagsFlag([]cli.Flag{
@@ -484,7 +516,33 @@ func (c *CLI) menuCommands() *cli.Command {
 					_, err = fmt.Fprintln(cmd.Writer, drafted.ID.String())
 					return err
 				}),
-			},
+			}),
+			c.mutation(&cli.Command{
+				Name:  "transfer",
+				Usage: "Transfer ownership of a menu to another actor",
+				Flags: transferFlags("Menu ID"),
+				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+					menuID, err := entity.ParseMenuID(cmd.String("id"))
+					if err != nil {
+						return err
+					}
+					owner, err := transferOwner(cmd)
+					if err != nil {
+						return err
+					}
+					transferred, err := c.app.Menus.TransferOwnership(ctx, menuID, owner)
+					if err != nil {
+						return err
+					}
+
+					if cmd.Bool("json") {
+						return clitoolkit.WriteJSON(cmd.Writer, menucli.FromDomainMenu(*transferred))
+					}
+
+					_, err = fmt.Fprintln(cmd.Writer, transferred.ID.String())
+					return err
+				}),
+			}),
 		},
 	}
 }
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/migrate"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	clitoolkit "github.com/TheFellow/go-modular-monolith/pkg/toolkits/cli"
+	clitable "github.com/TheFellow/go-modular-monolith/pkg/toolkits/cli/table"
+	"github.com/urfave/cli/v3"
+)
+
+type migrationRow struct {
+	Domain    string     `table:"DOMAIN" json:"domain"`
+	Version   int        `table:"VERSION" json:"version"`
+	Name      string     `table:"NAME" json:"name"`
+	State     string     `table:"STATE" json:"state"`
+	AppliedAt *time.Time `table:"-" json:"applied_at,omitempty"`
+}
+
+// migrateCommands inspect and apply data migrations. The CLI opens the
+// application with migrations deferred for these commands, so status shows
+// what is pending rather than what bootstrap just applied.
+func (c *CLI) migrateCommands() *cli.Command {
+	return &cli.Command{
+		Name:  "migrate",
+		Usage: "Database data migrations",
+		Commands: []*cli.Command{
+			{
+				Name:  "status",
+				Usage: "List every migration and whether it has been applied",
+				Flags: []cli.Flag{clitoolkit.JSONFlag},
+				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+					return c.migrationStatus(ctx, cmd)
+				}),
+			},
+			{
+				Name:  "up",
+				Usage: "Apply pending migrations in one transaction",
+				Flags: []cli.Flag{clitoolkit.JSONFlag, dryRunFlag()},
+				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+					return c.migrateUp(ctx, cmd)
+				}),
+			},
+		},
+	}
+}
+
+func (c *CLI) migrationStatus(ctx *middleware.Context, cmd *cli.Command) error {
+	statuses, err := c.app.Migrations.Status(ctx)
+	if err != nil {
+		return err
+	}
+	rows := toMigrationRows(statuses, "applied")
+	if cmd.Bool("json") {
+		return clitoolkit.WriteJSON(cmd.Writer, rows)
+	}
+	return clitable.PrintTable(cmd.Writer, rows)
+}
+
+func (c *CLI) migrateUp(ctx *middleware.Context, cmd *cli.Command) error {
+	dryRun := cmd.Bool("dry-run")
+	runCtx := ctx.Context
+	state := "applied"
+	if dryRun {
+		runCtx = store.RollbackOnly(runCtx)
+		state = "would apply"
+	}
+	ran, err := c.app.Migrations.Up(runCtx)
+	if err != nil {
+		return err
+	}
+	rows := toMigrationRows(ran, state)
+	if dryRun {
+		for i := range rows {
+			rows[i].AppliedAt = nil
+		}
+	}
+	if cmd.Bool("json") {
+		return clitoolkit.WriteJSON(cmd.Writer, rows)
+	}
+	if len(rows) == 0 {
+		_, err := fmt.Fprintln(cmd.Writer, "database is up to date")
+		return err
+	}
+	for _, row := range rows {
+		if _, err := fmt.Fprintf(cmd.Writer, "%s %s migration %d: %s\n", row.State, row.Domain, row.Version, row.Name); err != nil {
+			return err
+		}
+	}
+	if dryRun {
+		_, err := fmt.Fprintln(cmd.ErrWriter, "Dry run: rolled back, nothing was saved")
+		return err
+	}
+	return nil
+}
+
+// toMigrationRows labels applied migrations with state; pending ones are
+// always "pending".
+func toMigrationRows(statuses []migrate.Status, state string) []migrationRow {
+	rows := make([]migrationRow, 0, len(statuses))
+	for _, status := range statuses {
+		row := migrationRow{Domain: status.Domain, Version: status.Version, Name: status.Name, State: "pending"}
+		if !status.Pending() {
+			appliedAt := status.AppliedAt
+			row.State = state
+			row.AppliedAt = &appliedAt
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
@@ -0,0 +1,43 @@
+//nolint:paralleltest // fresh-process integration tests deliberately serialize database lifecycles.
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestMigrateCLIReportsDryRunsAndAppliesPendingMigrations(t *testing.T) {
+	cli := newCLIE2E(filepath.Join(t.TempDir(), "migrate.db"))
+
+	status := cli.Run("migrate", "status", "--json")
+	testutil.Ok(t, status.Err)
+	var pending []migrationRow
+	testutil.Ok(t, json.Unmarshal([]byte(status.Stdout), &pending))
+	testutil.IsTrue(t, len(pending) > 0)
+	for _, row := range pending {
+		testutil.Equals(t, row.State, "pending")
+	}
+
+	dry := cli.Run("migrate", "up", "--dry-run")
+	testutil.Ok(t, dry.Err)
+	testutil.StringContains(t, dry.Stdout, "would apply drinks migration 1: backfill legacy statuses")
+	testutil.StringContains(t, dry.Stderr, "nothing was saved")
+	still := cli.Run("migrate", "status")
+	testutil.Ok(t, still.Err)
+	testutil.StringContains(t, still.Stdout, "pending")
+
+	up := cli.Run("migrate", "up")
+	testutil.Ok(t, up.Err)
+	testutil.StringContains(t, up.Stdout, "applied audit migration 1: chain legacy entries")
+	again := cli.Run("migrate", "up")
+	testutil.Ok(t, again.Err)
+	testutil.StringContains(t, again.Stdout, "database is up to date")
+
+	applied := cli.Run("migrate", "status")
+	testutil.Ok(t, applied.Err)
+	testutil.IsFalse(t, strings.Contains(applied.Stdout, "pending"))
+}
@@ -22,7 +22,7 @@ func (c *CLI) ordersCommands() *cli.Command {
 		Name:  "orders",
 		Usage: "Manage orders",
 		Commands: []*cli.Command{
-			{
+			c.mutation(&cli.Command{
 				Name:  "place",
 				Usage: "Place an order",
 				Arguments: []cli.Argument{
@@ -104,7 +104,7 @@ func (c *CLI) ordersCommands() *cli.Command {
 					_, err = fmt.Fprintln(cmd.Writer, created.ID.String())
 					return err
 				}),
-			},
+			}),
 			{
 				Name:  "list",
 				Usage: "List orders",
@@ -121,6 +121,7 @@ func (c *CLI) ordersCommands() *cli.Command {
 							return ordersmodels.OrderStatus(s).Validate()
 						},
 					},
+					includeDeletedFlag(),
 				}, listPagingFlags()...)),
 				Action: filterAction(c, ordersmodels.ListFilterSchema(), func(ctx *middleware.Context, cmd *cli.Command) error {
 					pageReq := pagingRequest(cmd)
@@ -129,6 +130,8 @@ func (c *CLI) ordersCommands() *cli.Command {
 						Filter: cmd.String("filter"),
 						Cursor: pageReq.Cursor,
 						Limit:  pageReq.Limit,
+
+						IncludeDeleted: cmd.Bool("include-deleted"),
 					})
 					if err != nil {
 						return err
@@ -173,7 +176,7 @@ func (c *CLI) ordersCommands() *cli.Command {
 					return clitable.PrintTable(cmd.Writer, orderscli.ToOrderItemRows(res.Items))
 				}),
 			},
-			{
+			c.mutation(&cli.Command{
 				Name:  "complete",
 				Usage: "Complete an order",
 				Flags: appendTagsFlag([]cli.Flag{
@@ -197,8 +200,8 @@ func (c *CLI) ordersCommands() *cli.Command {
 					_, err = fmt.Fprintln(cmd.Writer, updated.ID.String())
 					return err
 				}),
-			},
-			{
+			}),
+			c.mutation(&cli.Command{
 				Name:  "cancel",
 				Usage: "Cancel an order",
 				Flags: appendTagsFlag([]cli.Flag{
@@ -222,7 +225,77 @@ func (c *CLI) ordersCommands() *cli.Command {
 					_, err = fmt.Fprintln(cmd.Writer, updated.ID.String())
 					return err
 				}),
-			},
+			}),
+			c.mutation(&cli.Command{
+				Name:  "delete",
+				Usage: "Delete a completed or cancelled order",
+				Flags: []cli.Flag{
+					clitoolkit.JSONFlag,
+					&cli.StringFlag{Name: "id", Usage: "Order ID", Required: true},
+				},
+				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+					id, err := entity.ParseOrderID(cmd.String("id"))
+					if err != nil {
+						return err
+					}
+					deleted, err := c.app.Orders.Delete(ctx, id)
+					if err != nil {
+						return err
+					}
+					if cmd.Bool("json") {
+						return clitoolkit.WriteJSON(cmd.Writer, orderscli.ToOrderView(deleted))
+					}
+					_, err = fmt.Fprintln(cmd.Writer, deleted.ID.String())
+					return err
+				}),
+			}),
+			c.mutation(&cli.Command{
+				Name:  "restore",
+				Usage: "Restore a deleted completed or cancelled order",
+				Flags: []cli.Flag{
+					clitoolkit.JSONFlag,
+					&cli.StringFlag{Name: "id", Usage: "Order ID", Required: true},
+				},
+				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+					id, err := entity.ParseOrderID(cmd.String("id"))
+					if err != nil {
+						return err
+					}
+					restored, err := c.app.Orders.Restore(ctx, id)
+					if err != nil {
+						return err
+					}
+					if cmd.Bool("json") {
+						return clitoolkit.WriteJSON(cmd.Writer, orderscli.ToOrderView(restored))
+					}
+					_, err = fmt.Fprintln(cmd.Writer, restored.ID.String())
+					return err
+				}),
+			}),
+			c.mutation(&cli.Command{
+				Name:  "transfer",
+				Usage: "Transfer ownership of an order to another actor",
+				Flags: transferFlags("Order ID"),
+				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+					orderID, err := entity.ParseOrderID(cmd.String("id"))
+					if err != nil {
+						return err
+					}
+					owner, err := transferOwner(cmd)
+					if err != nil {
+						return err
+					}
+					updated, err := c.app.Orders.TransferOwnership(ctx, orderID, owner)
+					if err != nil {
+						return err
+					}
+					if cmd.Bool("json") {
+						return clitoolkit.WriteJSON(cmd.Writer, orderscli.ToOrderView(updated))
+					}
+					_, err = fmt.Fprintln(cmd.Writer, updated.ID.String())
+					return err
+				}),
+			}),
 		},
 	}
 }
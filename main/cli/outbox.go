@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox"
+	outboxmodels "github.com/TheFellow/go-modular-monolith/app/domains/outbox/models"
+	outboxcli "github.com/TheFellow/go-modular-monolith/app/domains/outbox/surfaces/cli"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	clitoolkit "github.com/TheFellow/go-modular-monolith/pkg/toolkits/cli"
+	clitable "github.com/TheFellow/go-modular-monolith/pkg/toolkits/cli/table"
+	"github.com/urfave/cli/v3"
+)
+
+func (c *CLI) outboxCommands() *cli.Command {
+	return &cli.Command{
+		Name:  "outbox",
+		Usage: "Integration event outbox",
+		Commands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "List outbox messages (--state dead shows the dead-letter queue)",
+				Flags: appendFilterFlags(append([]cli.Flag{
+					clitoolkit.JSONFlag,
+					&cli.StringFlag{Name: "state", Usage: "Filter by state (pending|delivered|dead)"},
+					&cli.StringFlag{Name: "topic", Usage: "Filter by topic (for example orders.order_placed)"},
+				}, listPagingFlags()...)),
+				Action: filterAction(c, outboxmodels.ListFilterSchema(), func(ctx *middleware.Context, cmd *cli.Command) error {
+					return c.listOutbox(ctx, cmd)
+				}),
+			},
+			c.mutation(&cli.Command{
+				Name:      "retry",
+				Usage:     "Return a dead message to the queue",
+				Arguments: []cli.Argument{&cli.StringArgs{Name: "id", UsageText: "Message ID", Max: 1}},
+				Flags:     []cli.Flag{clitoolkit.JSONFlag},
+				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+					return c.retryOutbox(ctx, cmd)
+				}),
+			}),
+			{
+				Name:  "relay",
+				Usage: "Deliver pending messages to a sink until interrupted",
+				Flags: []cli.Flag{
+					clitoolkit.JSONFlag,
+					&cli.StringFlag{Name: "sink", Usage: "Destination (file:PATH, unix:PATH, or an http(s) URL)", Required: true},
+					&cli.BoolFlag{Name: "once", Usage: "Make one pass and exit"},
+					&cli.DurationFlag{Name: "interval", Value: time.Second, Usage: "Time between passes"},
+					&cli.IntFlag{Name: "max-attempts", Value: outboxmodels.DefaultRetryPolicy.MaxAttempts, Usage: "Failed attempts before a message is dead"},
+				},
+				Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+					return c.relayOutbox(ctx, cmd)
+				}),
+			},
+		},
+	}
+}
+
+func (c *CLI) listOutbox(ctx *middleware.Context, cmd *cli.Command) error {
+	state, err := outboxmodels.ParseState(cmd.String("state"))
+	if err != nil {
+		return err
+	}
+	pageReq := pagingRequest(cmd)
+	page, err := c.app.Outbox.List(ctx, outbox.ListRequest{
+		State:  state,
+		Topic:  cmd.String("topic"),
+		Filter: cmd.String("filter"),
+		Cursor: pageReq.Cursor,
+		Limit:  pageReq.Limit,
+	})
+	if err != nil {
+		return err
+	}
+	if cmd.Bool("json") {
+		return clitoolkit.WriteJSON(cmd.Writer, page)
+	}
+	if err := clitable.PrintTable(cmd.Writer, outboxcli.ToMessageRows(page.Items)); err != nil {
+		return err
+	}
+	return printNextCursor(cmd.Writer, page.Next)
+}
+
+func (c *CLI) retryOutbox(ctx *middleware.Context, cmd *cli.Command) error {
+	raw, err := requiredStringArg(cmd, "id")
+	if err != nil {
+		return err
+	}
+	id, err := outboxmodels.ParseMessageID(raw)
+	if err != nil {
+		return err
+	}
+	message, err := c.app.Outbox.Retry(ctx, id)
+	if err != nil {
+		return err
+	}
+	if cmd.Bool("json") {
+		return clitoolkit.WriteJSON(cmd.Writer, outboxcli.ToMessageRow(message))
+	}
+	return clitable.PrintDetail(cmd.Writer, outboxcli.ToMessageRow(message))
+}
+
+func (c *CLI) relayOutbox(ctx *middleware.Context, cmd *cli.Command) error {
+	sink, err := outbox.ParseSink(cmd.String("sink"))
+	if err != nil {
+		return err
+	}
+	opts := outbox.RelayOptions{Retry: outboxmodels.DefaultRetryPolicy}
+	opts.Retry.MaxAttempts = cmd.Int("max-attempts")
+	if opts.Retry.MaxAttempts <= 0 {
+		return errors.Invalidf("--max-attempts must be greater than zero")
+	}
+	report := func(result outboxmodels.RelayResult) {
+		view := outboxcli.ToRelayResult(result)
+		if cmd.Bool("json") {
+			_ = clitoolkit.WriteJSON(cmd.Writer, view)
+			return
+		}
+		if view != (outboxcli.RelayResult{}) || cmd.Bool("once") {
+			_, _ = fmt.Fprintf(cmd.Writer, "delivered %d, failed %d, dead %d\n", view.Delivered, view.Failed, view.Dead)
+		}
+	}
+
+	if cmd.Bool("once") {
+		result, err := c.app.Outbox.Relay(ctx, sink, opts)
+		if err != nil {
+			return err
+		}
+		report(result)
+		return nil
+	}
+	interval := cmd.Duration("interval")
+	if interval <= 0 {
+		return errors.Invalidf("--interval must be greater than zero")
+	}
+	signalCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return c.app.Outbox.RunRelay(middleware.NewContext(signalCtx), sink, opts, interval, report)
+}
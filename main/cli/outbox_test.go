@@ -0,0 +1,61 @@
+//nolint:paralleltest // fresh-process integration tests deliberately serialize database lifecycles.
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestOutboxCLIRelayAndDeadLetters(t *testing.T) {
+	dir := t.TempDir()
+	cli := newCLIE2E(filepath.Join(dir, "outbox.db"))
+	ingredient := cli.Run("ingredients", "create", "Outbox Gin", "--category", "spirit", "--unit", "oz")
+	testutil.Ok(t, ingredient.Err)
+	ingredientID := strings.TrimSpace(ingredient.Stdout)
+	testutil.Ok(t, cli.Run("inventory", "set", "--ingredient-id", ingredientID, "--quantity", "10", "--cost-per-unit", "$1.00").Err)
+
+	pending := cli.Run("outbox", "list", "--state", "pending")
+	testutil.Ok(t, pending.Err)
+	testutil.StringContains(t, pending.Stdout, "inventory.stock_adjusted")
+
+	unreachable := "unix:" + filepath.Join(dir, "missing.sock")
+	failed := cli.Run("outbox", "relay", "--once", "--max-attempts", "1", "--sink", unreachable)
+	testutil.Ok(t, failed.Err)
+	testutil.StringContains(t, failed.Stdout, "delivered 0, failed 0, dead 1")
+	dead := cli.Run("outbox", "list", "--state", "dead")
+	testutil.Ok(t, dead.Err)
+	testutil.StringContains(t, dead.Stdout, "missing.sock")
+	filtered := cli.Run("outbox", "list", "--filter", `attempts == 1 && topic.startsWith("inventory.")`)
+	testutil.Ok(t, filtered.Err)
+	testutil.StringContains(t, filtered.Stdout, "dead")
+
+	testutil.Ok(t, cli.Run("outbox", "retry", "1").Err)
+	eventsPath := filepath.Join(dir, "events.ndjson")
+	relayed := cli.Run("outbox", "relay", "--once", "--sink", "file:"+eventsPath)
+	testutil.Ok(t, relayed.Err)
+	testutil.StringContains(t, relayed.Stdout, "delivered 1, failed 0, dead 0")
+	data, err := os.ReadFile(eventsPath)
+	testutil.Ok(t, err)
+	var envelope struct {
+		ID      int64  `json:"id"`
+		Topic   string `json:"topic"`
+		Payload struct {
+			IngredientID string `json:"ingredient_id"`
+		} `json:"payload"`
+	}
+	testutil.Ok(t, json.Unmarshal(data, &envelope))
+	testutil.Equals(t, envelope.ID, int64(1))
+	testutil.Equals(t, envelope.Topic, "inventory.stock_adjusted")
+	testutil.Equals(t, envelope.Payload.IngredientID, ingredientID)
+
+	again := cli.Run("outbox", "retry", "1")
+	testutil.Equals(t, again.ExitCode, errors.ExitFailedPrecondition)
+	denied := cli.As("manager").Run("outbox", "relay", "--once", "--sink", "file:"+eventsPath)
+	testutil.Equals(t, denied.ExitCode, errors.ExitPermission)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/seal"
+)
+
+// writeOutput streams write to path, or to stdout when path is empty or "-".
+// Files are written beside their destination, synced, and renamed into place
+// only after write succeeds, so a failed command never leaves a partial file
+// and a returned file survives a crash.
+// With a key, files are sealed; stdout never is, so pipes keep working.
+func writeOutput(stdout io.Writer, path string, key *seal.Key, write func(io.Writer) error) error {
+	if path == "" || path == "-" {
+		return write(stdout)
+	}
+	if key != nil {
+		return seal.WriteFile(path, key, write)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return errors.Internalf("create %s: %w", path, err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if err := write(tmp); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return errors.Internalf("sync %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Internalf("write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return errors.Internalf("write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+//nolint:paralleltest // fresh-process integration tests deliberately serialize database lifecycles.
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	menucli "github.com/TheFellow/go-modular-monolith/app/domains/menus/surfaces/cli"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestMenusCLITransferDelegatesDraftToSommelier(t *testing.T) {
+	cli := newCLIE2E(filepath.Join(t.TempDir(), "ownership.db"))
+	created := cli.As("manager").Run("menus", "create", "Wine Flight", "--json")
+	testutil.Ok(t, created.Err)
+	var menu menucli.Menu
+	testutil.Ok(t, json.Unmarshal([]byte(created.Stdout), &menu))
+	testutil.Equals(t, menu.CreatedBy, "manager")
+	testutil.Equals(t, menu.Owner, "manager")
+
+	denied := cli.As("sommelier").Run("menus", "update", "--id", menu.ID, "--name", "Sommelier Flight")
+	testutil.Equals(t, denied.ExitCode, errors.ExitPermission)
+
+	missing := cli.As("manager").Run("menus", "transfer", "--id", menu.ID, "--to", "")
+	testutil.Equals(t, missing.ExitCode, errors.ExitInvalid)
+	unknown := cli.As("manager").Run("menus", "transfer", "--id", menu.ID, "--to", "guest")
+	testutil.Equals(t, unknown.ExitCode, errors.ExitInvalid)
+
+	transferred := cli.As("manager").Run("menus", "transfer", "--id", menu.ID, "--to", "sommelier", "--json")
+	testutil.Ok(t, transferred.Err)
+	testutil.Ok(t, json.Unmarshal([]byte(transferred.Stdout), &menu))
+	testutil.Equals(t, menu.CreatedBy, "manager")
+	testutil.Equals(t, menu.Owner, "sommelier")
+
+	updated := cli.As("sommelier").Run("menus", "update", "--id", menu.ID, "--name", "Sommelier Flight", "--json")
+	testutil.Ok(t, updated.Err)
+	testutil.StringContains(t, updated.Stdout, `"name": "Sommelier Flight"`)
+}
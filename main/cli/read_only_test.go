@@ -0,0 +1,44 @@
+//nolint:paralleltest // CLI integration owns a persistent database lifecycle.
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestReadOnlyCLIReportsWhileAnotherProcessHoldsTheDatabase(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "held.db")
+	cli := newCLIE2E(path)
+	gin := cli.Run("ingredients", "create", "Held Gin", "--category", "spirit", "--unit", "oz")
+	testutil.Ok(t, gin.Err)
+	ginID := strings.TrimSpace(gin.Stdout)
+
+	// A writer such as the TUI keeps the database open and locked.
+	held, err := store.Open(context.Background(), path)
+	testutil.Ok(t, err)
+	t.Cleanup(func() { testutil.Ok(t, held.Close()) })
+
+	shown := cli.Run("--read-only", "ingredients", "get", "--id", ginID)
+	testutil.Ok(t, shown.Err)
+	testutil.StringContains(t, shown.Stdout, "Held Gin")
+	testutil.Ok(t, cli.Run("--read-only", "audit", "list").Err)
+	testutil.Ok(t, cli.Run("--read-only", "status").Err)
+	testutil.Ok(t, cli.Run("--read-only", "check").Err)
+	testutil.Equals(t, cli.Run("--read-only", "check", "--repair").ExitCode, errors.ExitFailedPrecondition)
+	exported := cli.Run("--read-only", "export", "--output", filepath.Join(dir, "export.json"))
+	testutil.Ok(t, exported.Err)
+	testutil.StringContains(t, exported.Stdout, "exported 1 ingredients")
+
+	testutil.Ok(t, cli.Run("--read-only", "backup", "create", "--dir", filepath.Join(dir, "backups")).Err)
+
+	refused := cli.Run("--read-only", "ingredients", "create", "Held Rum", "--category", "spirit", "--unit", "oz")
+	testutil.Equals(t, refused.ExitCode, errors.ExitFailedPrecondition)
+	testutil.StringContains(t, refused.Stderr, "read-only")
+}
@@ -0,0 +1,41 @@
+//nolint:paralleltest // CLI integration owns a persistent database lifecycle.
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestDrinksCLIRestoreAfterIngredientRetired(t *testing.T) {
+	dir := t.TempDir()
+	cli := newCLIE2E(filepath.Join(dir, "restore.db"))
+	ingredient := strings.TrimSpace(cli.Run("ingredients", "create", "Campari", "--category", "spirit", "--unit", "oz").Stdout)
+	input := filepath.Join(dir, "drink.json")
+	testutil.Ok(t, os.WriteFile(input, []byte(`{"name":"Negroni","category":"cocktail","glass":"rocks","recipe":{"ingredients":[{"ingredient_id":"`+ingredient+`","amount":1,"unit":"oz"}],"steps":["stir"]}}`), 0o600))
+	drinkID := strings.TrimSpace(cli.Run("drinks", "create", "--file", input).Stdout)
+	testutil.Ok(t, cli.Run("drinks", "delete", "--id", drinkID).Err)
+	testutil.Ok(t, cli.Run("ingredients", "retire", "--id", ingredient).Err)
+
+	listed := cli.Run("drinks", "list")
+	testutil.Ok(t, listed.Err)
+	testutil.ErrorIf(t, strings.Contains(listed.Stdout, drinkID), "deleted drink listed without --include-deleted:\n%s", listed.Stdout)
+	listed = cli.Run("drinks", "list", "--include-deleted")
+	testutil.Ok(t, listed.Err)
+	testutil.StringContains(t, listed.Stdout, "DELETED_AT")
+	testutil.StringContains(t, listed.Stdout, drinkID)
+
+	restored := cli.Run("drinks", "restore", "--id", drinkID, "--json")
+	testutil.Ok(t, restored.Err)
+	testutil.StringContains(t, restored.Stdout, `"status": "review_required"`)
+	testutil.ErrorIf(t, strings.Contains(restored.Stdout, "deleted_at"), "restored drink still deleted:\n%s", restored.Stdout)
+
+	again := cli.Run("drinks", "restore", "--id", drinkID)
+	testutil.Equals(t, again.ExitCode, errors.ExitNotFound)
+	testutil.Ok(t, cli.Run("ingredients", "restore", "--id", ingredient).Err)
+	testutil.Ok(t, cli.Run("ingredients", "get", "--id", ingredient).Err)
+}
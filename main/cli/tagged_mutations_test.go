@@ -176,7 +176,8 @@ func TestTaggedMutationRollsBackWhenTagReplacementFails(t *testing.T) {
 	parent = pkglog.ToContext(parent, slog.New(slog.NewTextHandler(io.Discard, nil)))
 	s, err := store.Open(parent, filepath.Join(t.TempDir(), "rollback.db"))
 	testutil.Ok(t, err)
-	a := app.New(parent, app.Config{Store: s})
+	a, err := app.New(parent, app.Config{Store: s})
+	testutil.Ok(t, err)
 	t.Cleanup(func() { testutil.Ok(t, a.Close()) })
 	ctx := middleware.NewContext(parent)
 
@@ -81,7 +81,7 @@ func (c *CLI) tagsCommands() *cli.Command {
 					return clitable.PrintTable(cmd.Writer, rows)
 				}),
 			},
-			{
+			c.mutation(&cli.Command{
 				Name:      "add",
 				Usage:     "Add or replace a tag",
 				UsageText: "mixology tags add [--json] <entity-id> <key[=value]>",
@@ -109,8 +109,8 @@ func (c *CLI) tagsCommands() *cli.Command {
 					}
 					return printTagMutation(cmd, result)
 				}),
-			},
-			{
+			}),
+			c.mutation(&cli.Command{
 				Name:      "remove",
 				Usage:     "Remove a tag by key",
 				UsageText: "mixology tags remove [--json] <entity-id> <key>",
@@ -134,7 +134,7 @@ func (c *CLI) tagsCommands() *cli.Command {
 					}
 					return printTagMutation(cmd, result)
 				}),
-			},
+			}),
 			{
 				Name:      "list",
 				Usage:     "List tags on an entity",
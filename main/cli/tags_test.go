@@ -218,7 +218,8 @@ func seedCLITagTargets(t *testing.T, dbPath string) cliTagTargets {
 	ctx = pkglog.ToContext(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)))
 	s, err := store.Open(ctx, dbPath)
 	testutil.Ok(t, err)
-	a := app.New(ctx, app.Config{Store: s})
+	a, err := app.New(ctx, app.Config{Store: s})
+	testutil.Ok(t, err)
 	mctx := middleware.NewContext(ctx)
 
 	ingredient, err := a.Ingredients.Create(mctx, &ingredientsmodels.Ingredient{
@@ -263,7 +264,8 @@ func softDeleteCLIDrink(t *testing.T, dbPath, rawID string) {
 	ctx = pkglog.ToContext(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)))
 	s, err := store.Open(ctx, dbPath)
 	testutil.Ok(t, err)
-	a := app.New(ctx, app.Config{Store: s})
+	a, err := app.New(ctx, app.Config{Store: s})
+	testutil.Ok(t, err)
 	id, err := entity.ParseDrinkID(rawID)
 	testutil.Ok(t, err)
 	_, err = a.Drinks.Delete(middleware.NewContext(ctx), id)
@@ -0,0 +1,31 @@
+//nolint:paralleltest // CLI integration owns a persistent database lifecycle.
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestCLITraceFileRecordsCommandSpans(t *testing.T) {
+	dir := t.TempDir()
+	cli := newCLIE2E(filepath.Join(dir, "tracing.db"))
+	traces := filepath.Join(dir, "traces.jsonl")
+	id := strings.TrimSpace(cli.Run("ingredients", "create", "Blanco", "--category", "spirit", "--unit", "oz").Stdout)
+
+	testutil.Ok(t, cli.Run("--trace-file", traces, "ingredients", "delete", "--id", id).Err)
+
+	data, err := os.ReadFile(traces)
+	testutil.Ok(t, err)
+	for _, name := range []string{
+		`"name":"command Ingredient.retire"`,
+		`"name":"authz.authorize"`,
+		`"name":"store.write"`,
+		`"name":"inventory.IngredientDeleted.Handle"`,
+	} {
+		testutil.StringContains(t, string(data), name)
+	}
+}
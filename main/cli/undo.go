@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	clitoolkit "github.com/TheFellow/go-modular-monolith/pkg/toolkits/cli"
+	"github.com/urfave/cli/v3"
+)
+
+type undoResult struct {
+	Entry    string   `json:"entry"`
+	Action   string   `json:"action"`
+	Restored []string `json:"restored"`
+}
+
+// undoCommand reverses one audited command. The audit ID comes from
+// `audit list` or `audit history`.
+func (c *CLI) undoCommand() *cli.Command {
+	return c.mutation(&cli.Command{
+		Name:      "undo",
+		Usage:     "Reverse an audited command with its compensating commands",
+		Arguments: []cli.Argument{&cli.StringArgs{Name: "audit-id", UsageText: "Audit entry ID", Max: 1}},
+		Flags:     []cli.Flag{clitoolkit.JSONFlag},
+		Action: c.action(func(ctx *middleware.Context, cmd *cli.Command) error {
+			value, err := requiredStringArg(cmd, "audit-id")
+			if err != nil {
+				return err
+			}
+			id, err := entity.ParseAuditEntryID(value)
+			if err != nil {
+				return errors.Invalidf("invalid audit id %q: %w", value, err)
+			}
+			undone, err := c.app.Undo(ctx, id)
+			if err != nil {
+				return err
+			}
+			out := undoResult{Entry: undone.Entry.ID.String(), Action: undone.Entry.Action, Restored: make([]string, 0, len(undone.Restored))}
+			for _, uid := range undone.Restored {
+				out.Restored = append(out.Restored, uid.String())
+			}
+			if cmd.Bool("json") {
+				return clitoolkit.WriteJSON(cmd.Writer, out)
+			}
+			if _, err := fmt.Fprintf(cmd.Writer, "undid %s (%s)\n", out.Entry, out.Action); err != nil {
+				return err
+			}
+			for _, uid := range out.Restored {
+				if _, err := fmt.Fprintf(cmd.Writer, "restored %s\n", uid); err != nil {
+					return err
+				}
+			}
+			return nil
+		}),
+	})
+}
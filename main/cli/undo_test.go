@@ -0,0 +1,44 @@
+//nolint:paralleltest // CLI integration owns a persistent database lifecycle.
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestUndoCLIReversesInventoryAdjustment(t *testing.T) {
+	cli := newCLIE2E(filepath.Join(t.TempDir(), "undo.db"))
+	ingredientID := strings.TrimSpace(cli.Run("ingredients", "create", "Undo Gin", "--category", "spirit", "--unit", "oz").Stdout)
+	testutil.Ok(t, cli.Run("inventory", "set", "--ingredient-id", ingredientID, "--quantity", "10", "--cost-per-unit", "$1.00").Err)
+	testutil.Ok(t, cli.Run("inventory", "adjust", "--ingredient-id", ingredientID, "--delta", "-4", "--reason", "spilled").Err)
+
+	listed := cli.Run("audit", "list", "--action", `Mixology::Inventory::Action::"adjust"`, "--json")
+	testutil.Ok(t, listed.Err)
+	var page struct {
+		Items []struct {
+			ID struct{ ID string }
+		} `json:"items"`
+	}
+	testutil.Ok(t, json.Unmarshal([]byte(listed.Stdout), &page))
+	testutil.Equals(t, len(page.Items), 1)
+	auditID := page.Items[0].ID.ID
+
+	undone := cli.Run("undo", auditID)
+	testutil.Ok(t, undone.Err)
+	testutil.StringContains(t, undone.Stdout, "undid "+auditID)
+	testutil.StringContains(t, undone.Stdout, "restored Mixology::Inventory::")
+
+	stock := cli.Run("inventory", "get", "--ingredient-id", ingredientID, "--json")
+	testutil.Ok(t, stock.Err)
+	testutil.StringContains(t, stock.Stdout, `"quantity": 10`)
+
+	again := cli.Run("undo", auditID)
+	testutil.Equals(t, again.ExitCode, errors.ExitFailedPrecondition)
+	denied := cli.As("manager").Run("undo", auditID)
+	testutil.Equals(t, denied.ExitCode, errors.ExitPermission)
+}
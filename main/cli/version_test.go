@@ -0,0 +1,31 @@
+//nolint:paralleltest // CLI integration owns a persistent database lifecycle.
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestIngredientsCLIUpdateFromStaleFileConflicts(t *testing.T) {
+	dir := t.TempDir()
+	cli := newCLIE2E(filepath.Join(dir, "version.db"))
+	id := strings.TrimSpace(cli.Run("ingredients", "create", "Blanco", "--category", "spirit", "--unit", "oz").Stdout)
+
+	got := cli.Run("ingredients", "get", "--id", id, "--json")
+	testutil.Ok(t, got.Err)
+	testutil.StringContains(t, got.Stdout, `"version": 1`)
+	doc := filepath.Join(dir, "ingredient.json")
+	testutil.Ok(t, os.WriteFile(doc, []byte(got.Stdout), 0o600))
+
+	testutil.Ok(t, cli.Run("ingredients", "update", "--file", doc).Err)
+	stale := cli.Run("ingredients", "update", "--file", doc)
+	testutil.ErrorIf(t, stale.Err == nil, "%v", "update from a stale document succeeded")
+	testutil.StringContains(t, stale.Stderr, "changed since version 1 (now version 2)")
+
+	flagged := cli.Run("ingredients", "update", "--id", id, "--name", "Plata", "--expected-version", "2")
+	testutil.Ok(t, flagged.Err)
+}
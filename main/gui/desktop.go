@@ -29,10 +29,13 @@ import (
 	taggingdomain "github.com/TheFellow/go-modular-monolith/app/domains/tagging"
 	tagginggui "github.com/TheFellow/go-modular-monolith/app/domains/tagging/surfaces/gui"
 	"github.com/TheFellow/go-modular-monolith/pkg/authn"
+	"github.com/TheFellow/go-modular-monolith/pkg/authz"
+	"github.com/TheFellow/go-modular-monolith/pkg/changes"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	pkglog "github.com/TheFellow/go-modular-monolith/pkg/log"
 	"github.com/TheFellow/go-modular-monolith/pkg/presentation/actions"
 	"github.com/TheFellow/go-modular-monolith/pkg/runtimeconfig"
+	"github.com/TheFellow/go-modular-monolith/pkg/seal"
 	"github.com/TheFellow/go-modular-monolith/pkg/set"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
@@ -53,6 +56,11 @@ type desktopConfig struct {
 	logFormat     string
 	logFile       string
 	enableMetrics bool
+	metricsAddr   string
+	traceFile     string
+	readOnly      bool
+	keyFile       string
+	defaults      application.Defaults
 }
 
 type desktop struct {
@@ -64,6 +72,7 @@ type desktop struct {
 	logFile         *os.File
 	metricsServer   *http.Server
 	metricsShutdown func(context.Context) error
+	traceShutdown   func(context.Context) error
 	closeOnce       sync.Once
 	closeErr        error
 	dashboard       *dashboardViewModel
@@ -71,8 +80,10 @@ type desktop struct {
 	presenters      map[string]any
 	executor        interface{ Close() }
 	dispatcher      interface{ Close() }
+	changes         *changes.Subscription
 	showInformation func(string, string, framework.Window)
 	openURL         func(*url.URL) error
+	backUp          func()
 }
 
 func (d *desktop) mainMenu() *framework.MainMenu {
@@ -95,6 +106,7 @@ func (d *desktop) mainMenu() *framework.MainMenu {
 	save.Shortcut = commandShortcut(framework.KeyS)
 	cancel := framework.NewMenuItem("Cancel or Back", func() { d.shell.ExecuteCommand(gui.CommandCancel) })
 	cancel.Shortcut = &fynedesktop.CustomShortcut{KeyName: framework.KeyEscape}
+	backUp := framework.NewMenuItem("Back Up Database", d.backUp)
 	viewItems := make([]*framework.MenuItem, 0, len(d.shell.RouteIDs()))
 	for i, route := range d.shell.RouteIDs() {
 		item := framework.NewMenuItem(d.shell.RouteLabel(route), func() { _ = d.shell.Navigate(route) })
@@ -105,7 +117,7 @@ func (d *desktop) mainMenu() *framework.MainMenu {
 	}
 	return framework.NewMainMenu(
 		framework.NewMenu("Mixology", about, framework.NewMenuItemSeparator(), quit),
-		framework.NewMenu("File", create, save, framework.NewMenuItemSeparator(), refresh, cancel),
+		framework.NewMenu("File", create, save, framework.NewMenuItemSeparator(), refresh, cancel, framework.NewMenuItemSeparator(), backUp),
 		framework.NewMenu("View", viewItems...),
 		framework.NewMenu("Help", guide),
 	)
@@ -246,6 +258,7 @@ func openDesktopWithDependencies(ctx context.Context, fyneApp framework.App, con
 		return nil, fmt.Errorf("open desktop log: %w", err)
 	}
 	ctx = authn.ToContext(ctx, principal)
+	ctx = authz.WithRequest(ctx, authz.Request{Surface: authz.SurfaceGUI, SessionID: authz.NewSessionID()})
 	logLevel, logFormat := config.logLevel, config.logFormat
 	if logLevel == "" {
 		logLevel = "info"
@@ -267,10 +280,30 @@ func openDesktopWithDependencies(ctx context.Context, fyneApp framework.App, con
 		metricsShutdown = prom.Shutdown
 		mux := http.NewServeMux()
 		mux.Handle("/metrics", prom.Handler)
-		metricsServer = &http.Server{Addr: runtimeconfig.DefaultMetricsAddr, Handler: mux}
+		metricsAddr := config.metricsAddr
+		if metricsAddr == "" {
+			metricsAddr = runtimeconfig.DefaultMetricsAddr
+		}
+		metricsServer = &http.Server{Addr: metricsAddr, Handler: mux}
 		go func() { _ = metricsServer.ListenAndServe() }()
 	}
 	ctx = telemetry.WithMetrics(ctx, metrics)
+	var traceShutdown func(context.Context) error
+	if config.traceFile != "" {
+		traces, traceErr := telemetry.NewTraceFile(config.traceFile)
+		if traceErr != nil {
+			if metricsServer != nil {
+				_ = metricsServer.Shutdown(context.Background())
+			}
+			if metricsShutdown != nil {
+				_ = metricsShutdown(context.Background())
+			}
+			_ = logFile.Close()
+			return nil, traceErr
+		}
+		ctx = telemetry.WithTracing(ctx, traces.Provider)
+		traceShutdown = traces.Shutdown
+	}
 
 	databasePath := config.databasePath
 	if databasePath == "" {
@@ -278,21 +311,47 @@ func openDesktopWithDependencies(ctx context.Context, fyneApp framework.App, con
 		// supplies the shared CLI/TUI default explicitly.
 		databasePath = filepath.Join(config.dataDirectory, databaseFilename)
 	}
-	s, err := store.Open(ctx, databasePath)
-	if err != nil {
+	release := func() {
 		if metricsServer != nil {
 			_ = metricsServer.Shutdown(context.Background())
 		}
 		if metricsShutdown != nil {
 			_ = metricsShutdown(context.Background())
 		}
+		if traceShutdown != nil {
+			_ = traceShutdown(context.Background())
+		}
 		_ = logFile.Close()
+	}
+	var opts []store.Option
+	if config.readOnly {
+		opts = append(opts, store.ReadOnly())
+	}
+	if config.keyFile != "" {
+		key, err := seal.LoadKey(config.keyFile)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		opts = append(opts, store.Sealed(key))
+	}
+	s, err := store.Open(ctx, databasePath, opts...)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	app, err := application.New(ctx, application.Config{Store: s, Defaults: config.defaults})
+	if err != nil {
+		_ = s.Close()
+		release()
 		return nil, err
 	}
-	app := application.New(ctx, application.Config{Store: s})
+	if config.enableMetrics {
+		app.StartMetricsCollector(ctx, runtimeconfig.DefaultMetricsInterval)
+	}
 	d := &desktop{
 		gui: fyneApp, application: app, session: application.NewSession(ctx, app), logFile: logFile,
-		metricsServer: metricsServer, metricsShutdown: metricsShutdown,
+		metricsServer: metricsServer, metricsShutdown: metricsShutdown, traceShutdown: traceShutdown,
 		views: make(map[string]gui.View), presenters: make(map[string]any),
 	}
 	if closer, ok := deps.executor.(interface{ Close() }); ok {
@@ -378,14 +437,49 @@ func openDesktopWithDependencies(ctx context.Context, fyneApp framework.App, con
 	d.window.SetContent(d.shell.Content())
 	d.window.Resize(framework.NewSize(1100, 720))
 	d.window.SetCloseIntercept(d.closeWindow)
+	backups := application.BackupOptions{Dir: runtimeconfig.BackupDir(databasePath), Keep: runtimeconfig.DefaultBackupKeep}
+	d.backUp = func() {
+		deps.executor.Execute(func() {
+			result, err := d.session.Backup(d.session.Context(), backups)
+			deps.dispatcher.Dispatch(func() {
+				if err != nil {
+					gui.ShowPresentation(dialogs(), err)
+					return
+				}
+				d.showInformation("Backup Complete", backupSummary(result), d.window)
+			})
+		})
+	}
 	d.window.SetMainMenu(d.mainMenu())
 	d.registerShortcuts()
 	d.showInformation = deps.showInformation
 	d.openURL = deps.openURL
 	d.shell.ActivateCurrent()
+	d.changes = d.session.Subscribe(changes.Filter{})
+	go d.refreshOnChanges(deps.dispatcher)
 	return d, nil
 }
 
+func backupSummary(result application.BackupResult) string {
+	summary := fmt.Sprintf("Saved and verified %s.", result.Backup.Path)
+	if n := len(result.Pruned); n > 0 {
+		summary += fmt.Sprintf(" Removed %d older backups.", n)
+	}
+	return summary
+}
+
+// refreshOnChanges refreshes the open workspace when a change committed
+// elsewhere may alter it. Other workspaces refresh when they are activated.
+func (d *desktop) refreshOnChanges(dispatcher gui.Dispatcher) {
+	for change := range d.changes.Changes() {
+		dispatcher.Dispatch(func() {
+			if workspace(d.shell.Current()).showsChange(change) {
+				d.shell.ExecuteCommand(gui.CommandRefresh)
+			}
+		})
+	}
+}
+
 // closeWindow is kept separate from composition so lifecycle behavior can be
 // exercised without requiring a native close event.
 func (d *desktop) closeWindow() {
@@ -398,6 +492,9 @@ func (d *desktop) closeWindow() {
 func (d *desktop) Close() error {
 	d.closeOnce.Do(func() {
 		var appErr, logErr error
+		if d.changes != nil {
+			d.changes.Close()
+		}
 		// Stop the separately owned dashboard lifecycle before closing executor
 		// admission. Otherwise a concurrent activation can account work that the
 		// executor rejects, leaving dashboard shutdown waiting forever.
@@ -423,7 +520,11 @@ func (d *desktop) Close() error {
 		if d.metricsShutdown != nil {
 			metricsErr = errors.Join(metricsErr, d.metricsShutdown(context.Background()))
 		}
-		d.closeErr = errors.Join(appErr, logErr, metricsErr)
+		var traceErr error
+		if d.traceShutdown != nil {
+			traceErr = d.traceShutdown(context.Background())
+		}
+		d.closeErr = errors.Join(appErr, logErr, metricsErr, traceErr)
 	})
 	return d.closeErr
 }
@@ -368,6 +368,26 @@ func TestDesktopProvidesNativeAboutHelpAndQuitMenus(t *testing.T) {
 	testutil.ErrorIf(t, openedURL != "https://thefellow.github.io/go-modular-monolith/", "help URL = %q", openedURL)
 }
 
+func TestDesktopFileMenuBacksUpTheOpenDatabase(t *testing.T) {
+	dataDirectory := t.TempDir()
+	gui := test.NewApp()
+	t.Cleanup(gui.Quit)
+	var informationTitle string
+	desktop, err := openDesktopWithDependencies(context.Background(), gui, desktopConfig{
+		dataDirectory: dataDirectory, actor: "owner",
+	}, deterministicDesktopDependencies(func(title, _ string, _ framework.Window) { informationTitle = title }))
+	testutil.ErrorIf(t, err != nil, "%v", err)
+	t.Cleanup(func() { _ = desktop.Close() })
+
+	file := desktop.window.MainMenu().Items[1]
+	backUp := file.Items[len(file.Items)-1]
+	testutil.ErrorIf(t, backUp.Label != "Back Up Database", "last File item = %q", backUp.Label)
+	backUp.Action()
+	testutil.ErrorIf(t, informationTitle != "Backup Complete", "information title = %q", informationTitle)
+	backups, err := store.ListBackups(filepath.Join(dataDirectory, "backups"))
+	testutil.ErrorIf(t, err != nil || len(backups) != 1, "backups = %#v, %v", backups, err)
+}
+
 func TestDesktopMenuShortcutsNavigateAndRespectWorkspaceMode(t *testing.T) {
 	gui := test.NewApp()
 	t.Cleanup(gui.Quit)
@@ -7,11 +7,11 @@ import (
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"io"
 	"os"
-	"strconv"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 
+	application "github.com/TheFellow/go-modular-monolith/app"
 	"github.com/TheFellow/go-modular-monolith/pkg/authn"
 	"github.com/TheFellow/go-modular-monolith/pkg/runtimeconfig"
 )
@@ -48,32 +48,27 @@ func withFyneDoMigration(metadata fyne.AppMetadata) fyne.AppMetadata {
 
 func startupConfig(args []string, output io.Writer) (*desktopConfig, error) {
 	defaults := runtimeconfig.Default()
-	enableMetrics, err := environmentBool(runtimeconfig.EnvMetrics)
-	if err != nil {
-		return nil, err
-	}
 	dataDirectory, err := defaultDataDirectory()
 	if err != nil {
 		return nil, err
 	}
-	config := desktopConfig{
-		dataDirectory: environmentOr(runtimeconfig.EnvDataDir, dataDirectory),
-		databasePath:  environmentOr(runtimeconfig.EnvDatabasePath, defaults.DatabasePath),
-		actor:         environmentOr(runtimeconfig.EnvActor, defaults.Actor),
-		logLevel:      environmentOr(runtimeconfig.EnvLogLevel, defaults.LogLevel),
-		logFormat:     environmentOr(runtimeconfig.EnvLogFormat, defaults.LogFormat),
-		enableMetrics: enableMetrics,
-	}
+	config := desktopConfig{dataDirectory: environmentOr(runtimeconfig.EnvDataDir, dataDirectory)}
+	var configFile string
 	flags := flag.NewFlagSet("mixology-fyne", flag.ContinueOnError)
 	flags.SetOutput(output)
+	flags.StringVar(&configFile, "config", "", "config file (or "+runtimeconfig.EnvConfigFile+"; default ./"+runtimeconfig.ConfigFileName+", then the user config directory)")
 	flags.StringVar(&config.dataDirectory, "data-dir", config.dataDirectory, "application data and default log directory (or "+runtimeconfig.EnvDataDir+")")
-	flags.StringVar(&config.databasePath, "db", config.databasePath, "database path (or "+runtimeconfig.EnvDatabasePath+")")
-	flags.StringVar(&config.logLevel, "log-level", config.logLevel, "log level (debug, info, warn, error)")
-	flags.StringVar(&config.logFormat, "log-format", config.logFormat, "log format (text, json)")
-	flags.StringVar(&config.logFile, "log-file", environmentOr(runtimeconfig.EnvLogFile, config.logFile), "diagnostic log path (or "+runtimeconfig.EnvLogFile+")")
-	flags.BoolVar(&config.enableMetrics, "metrics", config.enableMetrics, "enable Prometheus metrics on "+runtimeconfig.DefaultMetricsAddr+"/metrics")
-	flags.StringVar(&config.actor, "actor", config.actor, "actor to run as (owner|manager|sommelier|bartender|anonymous)")
-	flags.StringVar(&config.actor, "as", config.actor, "alias for -actor")
+	flags.String("db", defaults.DatabasePath, "database path (or "+runtimeconfig.EnvDatabasePath+")")
+	flags.String("log-level", defaults.LogLevel, "log level (debug, info, warn, error)")
+	flags.String("log-format", defaults.LogFormat, "log format (text, json)")
+	flags.String("log-file", "", "diagnostic log path (or "+runtimeconfig.EnvLogFile+")")
+	flags.String("trace-file", "", "append OTLP JSON traces to file (or "+runtimeconfig.EnvTraceFile+")")
+	flags.Bool("metrics", false, "enable Prometheus metrics at /metrics on -metrics-addr (or "+runtimeconfig.EnvMetrics+")")
+	flags.String("metrics-addr", defaults.MetricsAddr, "address the metrics endpoint listens on (or "+runtimeconfig.EnvMetricsAddr+")")
+	flags.Bool("read-only", false, "open the database read-only beside a running writer (or "+runtimeconfig.EnvReadOnly+")")
+	flags.String("key-file", "", "file holding the passphrase or key that seals the database and its backups (or "+runtimeconfig.EnvKeyFile+")")
+	flags.String("actor", defaults.Actor, "actor to run as (owner|manager|sommelier|bartender|anonymous)")
+	flags.String("as", defaults.Actor, "alias for -actor")
 	if err := flags.Parse(args); err != nil {
 		if errors.Is(err, flag.ErrHelp) {
 			return nil, nil
@@ -83,9 +78,36 @@ func startupConfig(args []string, output io.Writer) (*desktopConfig, error) {
 	if flags.NArg() != 0 {
 		return nil, fmt.Errorf("unexpected arguments: %v", flags.Args())
 	}
-	if _, err := authn.ParseActor(config.actor); err != nil {
+
+	resolved, err := runtimeconfig.Load(configFile, defaults)
+	if err != nil {
+		return nil, err
+	}
+	given := make(map[string]string)
+	flags.Visit(func(f *flag.Flag) {
+		name := f.Name
+		if name == "as" {
+			name = "actor"
+		}
+		given[name] = f.Value.String()
+	})
+	err = resolved.ApplyFlags(func(name string) (string, bool) {
+		value, ok := given[name]
+		return value, ok
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authn.ParseActor(resolved.Actor); err != nil {
+		return nil, err
+	}
+	config.defaults, err = application.DefaultsFrom(resolved)
+	if err != nil {
 		return nil, err
 	}
+	config.databasePath, config.actor, config.readOnly, config.keyFile = resolved.DatabasePath, resolved.Actor, resolved.ReadOnly, resolved.KeyFile
+	config.logLevel, config.logFormat, config.logFile = resolved.LogLevel, resolved.LogFormat, resolved.LogFile
+	config.enableMetrics, config.metricsAddr, config.traceFile = resolved.EnableMetrics, resolved.MetricsAddr, resolved.TraceFile
 	return &config, nil
 }
 
@@ -95,15 +117,3 @@ func environmentOr(name, fallback string) string {
 	}
 	return fallback
 }
-
-func environmentBool(name string) (bool, error) {
-	value, ok := os.LookupEnv(name)
-	if !ok || value == "" {
-		return false, nil
-	}
-	parsed, err := strconv.ParseBool(value)
-	if err != nil {
-		return false, fmt.Errorf("parse %s: %w", name, err)
-	}
-	return parsed, nil
-}
@@ -22,7 +22,7 @@ func TestFyneMutationWorkflowsUseAtomicTagComposition(t *testing.T) {
 	workflows := []workflow{
 		{"drinks", "Save", []string{"Create", "Update"}},
 		{"ingredients", "Submit", []string{"Create", "Update"}},
-		{"inventory", "Submit", []string{"Adjust", "Set"}},
+		{"inventory", "apply", []string{"Adjust", "Set"}},
 		{"menus", "Save", []string{"Create", "Update"}},
 		{"menus", "AddDrink", []string{"AddDrink"}},
 		{"menus", "RemoveDrink", []string{"RemoveDrink"}},
@@ -165,7 +165,7 @@ func TestRenderWorkspaceReview(t *testing.T) {
 			presenter.Select(state.Rows[0].Inventory.ID)
 			captureReview(t, desktop, directory, route+"-london-dry-gin.png")
 			presenter.Back()
-			presenter.Filter(inventorygui.AllStock, `quantity < 0`, inventorygui.LowStockThreshold, 25)
+			presenter.Filter(inventorygui.AllStock, `quantity < 0`, state.LowStock, 25)
 			captureReview(t, desktop, directory, route+"-empty.png")
 			presenter.ResetList()
 			presenter.Select(presenter.Snapshot().Rows[0].Inventory.ID)
@@ -1,5 +1,11 @@
 package main
 
+import (
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
+	"github.com/TheFellow/go-modular-monolith/pkg/changes"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
 // workspace identifies one top-level desktop destination. Keeping this closed
 // vocabulary typed prevents route wiring, authorization probes, and dashboard
 // cards from drifting apart through string literals.
@@ -17,3 +23,28 @@ const (
 )
 
 func (w workspace) routeID() string { return string(w) }
+
+// entityTypes lists the entities a workspace shows; nil means any change may
+// affect it.
+func (w workspace) entityTypes() []cedar.EntityType {
+	switch w {
+	case workspaceDrinks:
+		return []cedar.EntityType{entity.TypeDrink, entity.TypeIngredient}
+	case workspaceIngredients:
+		return []cedar.EntityType{entity.TypeIngredient}
+	case workspaceInventory:
+		return []cedar.EntityType{entity.TypeInventory, entity.TypeIngredient}
+	case workspaceMenus:
+		return []cedar.EntityType{entity.TypeMenu, entity.TypeDrink, entity.TypeInventory}
+	case workspaceOrders:
+		return []cedar.EntityType{entity.TypeOrder, entity.TypeMenu}
+	default:
+		return nil
+	}
+}
+
+// showsChange reports whether a change may alter what the workspace shows.
+func (w workspace) showsChange(change changes.Change) bool {
+	types := w.entityTypes()
+	return change.Missed > 0 || types == nil || change.Touches(types...)
+}
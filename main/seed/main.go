@@ -4,6 +4,7 @@ import (
 	"context"
 	_ "embed"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log/slog"
 	"os"
@@ -20,9 +21,11 @@ import (
 	"github.com/TheFellow/go-modular-monolith/app/kernel/money"
 	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
 	"github.com/TheFellow/go-modular-monolith/pkg/authn"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/log"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
 	"github.com/TheFellow/go-modular-monolith/pkg/runtimeconfig"
+	"github.com/TheFellow/go-modular-monolith/pkg/seal"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
 	cedar "github.com/cedar-policy/cedar-go"
@@ -68,32 +71,49 @@ type seedDrink struct {
 }
 
 func main() {
-	if err := run(); err != nil {
+	if err := run(os.Args[1:]); err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-func run() error {
+func run(args []string) error {
+	config, err := seedConfig(args)
+	if err != nil || config == nil {
+		return err
+	}
+	defaults, err := app.DefaultsFrom(*config)
+	if err != nil {
+		return err
+	}
+
 	fmt.Println("=== Mixology Seed ===")
 	fmt.Println()
 
 	// Open store
-	dbPath := runtimeconfig.DefaultDatabasePath
-	if p := os.Getenv(runtimeconfig.EnvDatabasePath); p != "" {
-		dbPath = p
+	var opts []store.Option
+	if config.KeyFile != "" {
+		key, err := seal.LoadKey(config.KeyFile)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, store.Sealed(key))
 	}
 
 	bootstrapCtx := log.ToContext(context.Background(), slog.Default())
 	bootstrapCtx = telemetry.WithMetrics(bootstrapCtx, telemetry.Nop())
 	bootstrapCtx = authn.ToContext(bootstrapCtx, authn.Owner())
-	s, err := store.Open(bootstrapCtx, dbPath)
+	s, err := store.Open(bootstrapCtx, config.DatabasePath, opts...)
 	if err != nil {
 		return fmt.Errorf("open store: %w", err)
 	}
 
 	// Create app
-	a := app.New(bootstrapCtx, app.Config{Store: s})
+	a, err := app.New(bootstrapCtx, app.Config{Store: s, Defaults: defaults})
+	if err != nil {
+		_ = s.Close()
+		return fmt.Errorf("open application: %w", err)
+	}
 	defer func() { _ = a.Close() }()
 
 	// Create context as owner
@@ -270,6 +290,35 @@ func run() error {
 	return nil
 }
 
+// seedConfig resolves the database to seed the way the other entry points
+// do: -db over MIXOLOGY_DB over the config file. It returns nil after -help.
+func seedConfig(args []string) (*runtimeconfig.Config, error) {
+	flags := flag.NewFlagSet("mixology-seed", flag.ContinueOnError)
+	configFile := flags.String("config", "", "config file (or "+runtimeconfig.EnvConfigFile+")")
+	flags.String("db", runtimeconfig.DefaultDatabasePath, "database path (or "+runtimeconfig.EnvDatabasePath+")")
+	flags.String("key-file", "", "file holding the key of a sealed database (or "+runtimeconfig.EnvKeyFile+")")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	config, err := runtimeconfig.Load(*configFile, runtimeconfig.Default())
+	if err != nil {
+		return nil, err
+	}
+	given := make(map[string]string)
+	flags.Visit(func(f *flag.Flag) { given[f.Name] = f.Value.String() })
+	err = config.ApplyFlags(func(name string) (string, bool) {
+		value, ok := given[name]
+		return value, ok
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
 func replaceTags(a *app.App, ctx *middleware.Context, target cedar.EntityUID, values []string) error {
 	if len(values) == 0 {
 		return nil
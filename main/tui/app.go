@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 
+	cedar "github.com/cedar-policy/cedar-go"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -15,9 +16,12 @@ import (
 	inventoryui "github.com/TheFellow/go-modular-monolith/app/domains/inventory/surfaces/tui"
 	menusui "github.com/TheFellow/go-modular-monolith/app/domains/menus/surfaces/tui"
 	ordersui "github.com/TheFellow/go-modular-monolith/app/domains/orders/surfaces/tui"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/entity"
 	"github.com/TheFellow/go-modular-monolith/main/tui/routes"
 	tuiviews "github.com/TheFellow/go-modular-monolith/main/tui/views"
+	"github.com/TheFellow/go-modular-monolith/pkg/changes"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/runtimeconfig"
 	"github.com/TheFellow/go-modular-monolith/pkg/toolkits/tui"
 	"github.com/TheFellow/go-modular-monolith/pkg/toolkits/tui/keys"
 	"github.com/TheFellow/go-modular-monolith/pkg/toolkits/tui/styles"
@@ -52,9 +56,25 @@ type App struct {
 	height    int
 	showHelp  bool
 	lastError error
+	notice    string
+
+	// Backups taken with the backup key land here.
+	backups app.BackupOptions
 
 	// Child views (lazy initialized)
 	views map[routes.View]tui.ViewModel
+
+	// Views to refresh when next shown because data changed elsewhere
+	stale map[routes.View]bool
+}
+
+type changeMsg struct {
+	change changes.Change
+}
+
+type backupMsg struct {
+	result app.BackupResult
+	err    error
 }
 
 // NewApp creates a new App with the given application.
@@ -69,6 +89,19 @@ func NewApp(application *app.Session) *App {
 		keys:        keys.Standard,
 		help:        helpModel,
 		views:       make(map[routes.View]tui.ViewModel),
+		stale:       make(map[routes.View]bool),
+		backups: app.BackupOptions{
+			Dir:  runtimeconfig.BackupDir(application.Store.Path()),
+			Keep: runtimeconfig.DefaultBackupKeep,
+		},
+	}
+}
+
+// forwardChanges sends each change committed elsewhere to the program until
+// the subscription closes.
+func forwardChanges(program *tea.Program, subscription *changes.Subscription) {
+	for change := range subscription.Changes() {
+		program.Send(changeMsg{change: change})
 	}
 }
 
@@ -83,6 +116,13 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		vm := a.currentViewModel()
 		interaction := vm.Interaction()
+		a.notice = ""
+		// Text inputs use ctrl+b to move the cursor, so the backup key only
+		// applies while no field is capturing text.
+		if !interaction.CapturesText && key.Matches(msg, tuiviews.BackupKey) {
+			a.notice = "Backing up database..."
+			return a, a.backup()
+		}
 		if msg.Type != tea.KeyRunes || !interaction.CapturesText {
 			if key.Matches(msg, a.keys.Quit) {
 				return a, tea.Quit
@@ -131,6 +171,17 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.lastError = msg.Err
 		return a, nil
 
+	case changeMsg:
+		return a, a.applyChange(msg.change)
+
+	case backupMsg:
+		a.notice = ""
+		a.lastError = msg.err
+		if msg.err == nil {
+			a.notice = backupNotice(msg.result)
+		}
+		return a, nil
+
 	case viewSizeMsg:
 		vm, cmd := a.currentViewModel().Update(tea.WindowSizeMsg{
 			Width:  msg.width,
@@ -217,7 +268,7 @@ func (a *App) navigateTo(target routes.View) tea.Cmd {
 	}
 
 	if _, ok := a.views[target]; ok {
-		return a.syncWindowCmd()
+		return tea.Batch(a.syncWindowCmd(), a.refreshIfStale())
 	}
 
 	return a.initializeCurrentView()
@@ -241,7 +292,34 @@ func (a *App) navigateBack() tea.Cmd {
 		delete(a.views, routes.ViewDashboard)
 		return a.initializeCurrentView()
 	}
-	return a.syncWindowCmd()
+	return tea.Batch(a.syncWindowCmd(), a.refreshIfStale())
+}
+
+// applyChange refreshes the current view when the change concerns it and marks
+// other loaded views so they refresh when shown again.
+func (a *App) applyChange(change changes.Change) tea.Cmd {
+	var cmd tea.Cmd
+	for view, vm := range a.views {
+		if types := viewTypes(view); change.Missed == 0 && types != nil && !change.Touches(types...) {
+			continue
+		}
+		if view != a.currentView {
+			a.stale[view] = true
+			continue
+		}
+		a.views[view], cmd = vm.Update(tui.RefreshMsg{})
+	}
+	return cmd
+}
+
+func (a *App) refreshIfStale() tea.Cmd {
+	if !a.stale[a.currentView] {
+		return nil
+	}
+	delete(a.stale, a.currentView)
+	vm, cmd := a.currentViewModel().Update(tui.RefreshMsg{})
+	a.views[a.currentView] = vm
+	return cmd
 }
 
 // initializeCurrentView sizes a newly created child before its Init command can
@@ -292,6 +370,24 @@ func (a *App) helpHeight() int {
 	return lipgloss.Height(a.help.View(a.currentViewModel()))
 }
 
+// backup snapshots and verifies the database off the update loop. The store
+// keeps serving this session's reads and writes while the snapshot is taken.
+func (a *App) backup() tea.Cmd {
+	session, opts := a.app, a.backups
+	return func() tea.Msg {
+		result, err := session.Backup(session.Context(), opts)
+		return backupMsg{result: result, err: err}
+	}
+}
+
+func backupNotice(result app.BackupResult) string {
+	notice := "Backed up to " + result.Backup.Path
+	if n := len(result.Pruned); n > 0 {
+		notice += fmt.Sprintf(" (removed %d old)", n)
+	}
+	return notice
+}
+
 func (a *App) statusBarView() string {
 	var content string
 	if a.lastError != nil {
@@ -308,6 +404,8 @@ func (a *App) statusBarView() string {
 			style = a.styles.ErrorText
 		}
 		content = style.Render(tuiErr.Message)
+	} else if a.notice != "" {
+		content = a.styles.InfoText.Render(a.notice)
 	} else {
 		content = a.styles.HelpDesc.Render("View: " + viewTitle(a.currentView) + "  •  Press ? for help")
 	}
@@ -342,6 +440,24 @@ func isValidView(view routes.View) bool {
 	}
 }
 
+// viewTypes lists the entity types a view shows; nil means any change matters.
+func viewTypes(view routes.View) []cedar.EntityType {
+	switch view {
+	case routes.ViewDrinks:
+		return []cedar.EntityType{entity.TypeDrink, entity.TypeIngredient}
+	case routes.ViewIngredients:
+		return []cedar.EntityType{entity.TypeIngredient}
+	case routes.ViewInventory:
+		return []cedar.EntityType{entity.TypeInventory, entity.TypeIngredient}
+	case routes.ViewMenus:
+		return []cedar.EntityType{entity.TypeMenu, entity.TypeDrink, entity.TypeInventory}
+	case routes.ViewOrders:
+		return []cedar.EntityType{entity.TypeOrder, entity.TypeMenu}
+	default:
+		return nil
+	}
+}
+
 func viewTitle(view routes.View) string {
 	switch view {
 	case routes.ViewDashboard:
@@ -23,6 +23,8 @@ import (
 	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
 	"github.com/TheFellow/go-modular-monolith/main/tui/routes"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/runtimeconfig"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
 	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
 	"github.com/TheFellow/go-modular-monolith/pkg/testutil/tuitest"
 	"github.com/TheFellow/go-modular-monolith/pkg/toolkits/tui"
@@ -371,6 +373,21 @@ func TestStatusBarView_UsesErrorStyleForPermission(t *testing.T) {
 	testutil.Equals(t, app.statusBarView(), expected)
 }
 
+func TestE2E_BackupKeyReportsVerifiedSnapshot(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+
+	driver := tuitest.NewDriver(t, NewApp(f.App))
+	driver.Resize(120, 40)
+	driver.RequireText("Dashboard")
+	driver.Press("ctrl+b")
+	driver.RequireText("Backed up to")
+
+	backups, err := store.ListBackups(runtimeconfig.BackupDir(f.Store.Path()))
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(backups), 1)
+}
+
 func TestBackKey_CancelsDomainLocalStateBeforeNavigating(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+
+	ingredientsmodels "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/pkg/changes"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/tuitest"
+)
+
+func TestE2E_ChangesRefreshCurrentAndStaleViews(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	subscription := f.App.Subscribe(changes.Filter{})
+	t.Cleanup(subscription.Close)
+
+	driver := tuitest.NewDriver(t, NewApp(f.App))
+	driver.Resize(100, 40)
+	driver.Press("2")
+	driver.RequireText("Mixology > Ingredients")
+
+	tonic := testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{
+		Name: "Live Tonic", Category: ingredientsmodels.CategoryMixer, Unit: measurement.UnitMl,
+	})
+	driver.RequireNoText("Live Tonic")
+	driver.Send(changeMsg{change: <-subscription.Changes()})
+	driver.RequireText("Live Tonic")
+
+	driver.Press("esc")
+	driver.RequireText("Mixology > Dashboard")
+	testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{
+		Name: "Later Soda", Category: ingredientsmodels.CategoryMixer, Unit: measurement.UnitMl,
+	})
+	driver.Send(changeMsg{change: <-subscription.Changes()})
+	driver.Press("2")
+	driver.RequireText("Live Tonic", "Later Soda")
+
+	_, err := f.Ingredients.Delete(f.OwnerContext(), tonic.ID)
+	testutil.Ok(t, err)
+	driver.Send(changeMsg{change: <-subscription.Changes()})
+	driver.RequireNoText("Live Tonic")
+	driver.RequireText("Later Soda")
+}
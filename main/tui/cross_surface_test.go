@@ -45,7 +45,8 @@ func TestCLIAndComposedTUIShareTagContracts(t *testing.T) {
 	ctx = pkglog.ToContext(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)))
 	database, err := store.Open(ctx, filepath.Join(workingDirectory, defaultDatabasePath))
 	testutil.Ok(t, err)
-	application := app.New(ctx, app.Config{Store: database})
+	application, err := app.New(ctx, app.Config{Store: database})
+	testutil.Ok(t, err)
 	driver := tuitest.NewDriver(t, NewApp(app.NewSession(ctx, application)))
 	driver.Resize(100, 40)
 	driver.Press("7")
@@ -12,24 +12,18 @@ import (
 
 	"github.com/TheFellow/go-modular-monolith/app"
 	"github.com/TheFellow/go-modular-monolith/pkg/authn"
+	"github.com/TheFellow/go-modular-monolith/pkg/authz"
+	"github.com/TheFellow/go-modular-monolith/pkg/changes"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	pkglog "github.com/TheFellow/go-modular-monolith/pkg/log"
 	"github.com/TheFellow/go-modular-monolith/pkg/runtimeconfig"
+	"github.com/TheFellow/go-modular-monolith/pkg/seal"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
 )
 
 const defaultDatabasePath = runtimeconfig.DefaultDatabasePath
 
-type tuiConfig struct {
-	databasePath  string
-	actor         string
-	logLevel      string
-	logFormat     string
-	logFile       string
-	enableMetrics bool
-}
-
 func main() {
 	if err := newCommand().Run(context.Background(), os.Args); err != nil {
 		cli.HandleExitCoder(errors.ToCLIExit(err))
@@ -39,38 +33,53 @@ func main() {
 
 func newCommand() *cli.Command {
 	defaults := runtimeconfig.Default()
-	config := tuiConfig{
-		databasePath: defaults.DatabasePath,
-		actor:        defaults.Actor,
-		logLevel:     defaults.LogLevel,
-		logFormat:    defaults.LogFormat,
-		logFile:      defaultLogPath(defaults.DatabasePath),
-	}
 	return &cli.Command{
 		Name:  "mixology-tui",
 		Usage: "Interactive terminal client for Mixology",
 		Flags: []cli.Flag{
-			&cli.StringFlag{Name: "db", Value: config.databasePath, Usage: "Database path", Destination: &config.databasePath, Sources: cli.EnvVars(runtimeconfig.EnvDatabasePath)},
-			&cli.StringFlag{Name: "log-level", Value: config.logLevel, Usage: "Log level (debug, info, warn, error)", Destination: &config.logLevel, Sources: cli.EnvVars(runtimeconfig.EnvLogLevel)},
-			&cli.StringFlag{Name: "log-format", Value: config.logFormat, Usage: "Log format (text, json)", Destination: &config.logFormat, Sources: cli.EnvVars(runtimeconfig.EnvLogFormat)},
-			&cli.StringFlag{Name: "log-file", Value: config.logFile, Usage: "Write logs to file", Destination: &config.logFile, Sources: cli.EnvVars(runtimeconfig.EnvLogFile)},
-			&cli.StringFlag{Name: "actor", Aliases: []string{"as"}, Value: config.actor, Usage: "Actor to run as (owner|manager|sommelier|bartender|anonymous)", Destination: &config.actor, Sources: cli.EnvVars(runtimeconfig.EnvActor)},
-			&cli.BoolFlag{Name: "metrics", Usage: "Enable Prometheus metrics endpoint on :9090/metrics", Destination: &config.enableMetrics, Sources: cli.EnvVars(runtimeconfig.EnvMetrics)},
+			&cli.StringFlag{Name: "config", Usage: "Config file (default: ./" + runtimeconfig.ConfigFileName + ", then the user config directory)", Sources: cli.EnvVars(runtimeconfig.EnvConfigFile)},
+			&cli.StringFlag{Name: "db", Value: defaults.DatabasePath, Usage: "Database path", Sources: cli.EnvVars(runtimeconfig.EnvDatabasePath)},
+			&cli.StringFlag{Name: "log-level", Value: defaults.LogLevel, Usage: "Log level (debug, info, warn, error)", Sources: cli.EnvVars(runtimeconfig.EnvLogLevel)},
+			&cli.StringFlag{Name: "log-format", Value: defaults.LogFormat, Usage: "Log format (text, json)", Sources: cli.EnvVars(runtimeconfig.EnvLogFormat)},
+			&cli.StringFlag{Name: "log-file", Value: defaultLogPath(defaults.DatabasePath), Usage: "Write logs to file", Sources: cli.EnvVars(runtimeconfig.EnvLogFile)},
+			&cli.StringFlag{Name: "actor", Aliases: []string{"as"}, Value: defaults.Actor, Usage: "Actor to run as (owner|manager|sommelier|bartender|anonymous)", Sources: cli.EnvVars(runtimeconfig.EnvActor)},
+			&cli.BoolFlag{Name: "metrics", Usage: "Enable Prometheus metrics endpoint at /metrics on --metrics-addr", Sources: cli.EnvVars(runtimeconfig.EnvMetrics)},
+			&cli.StringFlag{Name: "metrics-addr", Value: defaults.MetricsAddr, Usage: "Address the metrics endpoint listens on", Sources: cli.EnvVars(runtimeconfig.EnvMetricsAddr)},
+			&cli.StringFlag{Name: "trace-file", Usage: "Append OTLP JSON traces to file", Sources: cli.EnvVars(runtimeconfig.EnvTraceFile)},
+			&cli.BoolFlag{Name: "read-only", Usage: "Open the database read-only beside a running writer; commands that change data fail", Sources: cli.EnvVars(runtimeconfig.EnvReadOnly)},
+			&cli.StringFlag{Name: "key-file", Usage: "File holding the passphrase or key that seals the database and its backups", Sources: cli.EnvVars(runtimeconfig.EnvKeyFile)},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
-			if !cmd.IsSet("log-file") {
-				config.logFile = defaultLogPath(config.databasePath)
+			config, err := runtimeconfig.Load(cmd.String("config"), defaults)
+			if err != nil {
+				return err
 			}
-			return run(ctx, config, config.databasePath)
+			err = config.ApplyFlags(func(name string) (string, bool) {
+				if !cmd.IsSet(name) {
+					return "", false
+				}
+				return fmt.Sprint(cmd.Value(name)), true
+			})
+			if err != nil {
+				return err
+			}
+			if config.LogFile == "" {
+				config.LogFile = defaultLogPath(config.DatabasePath)
+			}
+			return run(ctx, config)
 		},
 	}
 }
 
-func run(ctx context.Context, config tuiConfig, databasePath string) error {
-	if err := os.MkdirAll(filepath.Dir(config.logFile), 0o755); err != nil {
+func run(ctx context.Context, config runtimeconfig.Config) error {
+	defaults, err := app.DefaultsFrom(config)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(config.LogFile), 0o755); err != nil {
 		return fmt.Errorf("create log dir: %w", err)
 	}
-	logFile, err := os.OpenFile(config.logFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	logFile, err := os.OpenFile(config.LogFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
 	if err != nil {
 		return fmt.Errorf("open log file: %w", err)
 	}
@@ -79,7 +88,7 @@ func run(ctx context.Context, config tuiConfig, databasePath string) error {
 	var metrics = telemetry.Nop()
 	var metricsServer *http.Server
 	var shutdownMetrics func(context.Context) error
-	if config.enableMetrics {
+	if config.EnableMetrics {
 		prom, err := telemetry.NewPrometheus()
 		if err != nil {
 			return err
@@ -88,7 +97,7 @@ func run(ctx context.Context, config tuiConfig, databasePath string) error {
 		shutdownMetrics = prom.Shutdown
 		mux := http.NewServeMux()
 		mux.Handle("/metrics", prom.Handler)
-		metricsServer = &http.Server{Addr: runtimeconfig.DefaultMetricsAddr, Handler: mux}
+		metricsServer = &http.Server{Addr: config.MetricsAddr, Handler: mux}
 		go func() { _ = metricsServer.ListenAndServe() }()
 	}
 	if metricsServer != nil {
@@ -97,23 +106,55 @@ func run(ctx context.Context, config tuiConfig, databasePath string) error {
 	if shutdownMetrics != nil {
 		defer func() { _ = shutdownMetrics(ctx) }()
 	}
+	if config.TraceFile != "" {
+		traces, err := telemetry.NewTraceFile(config.TraceFile)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = traces.Shutdown(context.WithoutCancel(ctx)) }()
+		ctx = telemetry.WithTracing(ctx, traces.Provider)
+	}
 
-	principal, err := authn.ParseActor(config.actor)
+	principal, err := authn.ParseActor(config.Actor)
 	if err != nil {
 		return err
 	}
-	ctx = pkglog.ToContext(ctx, pkglog.Setup(config.logLevel, config.logFormat, logFile))
+	ctx = pkglog.ToContext(ctx, pkglog.Setup(config.LogLevel, config.LogFormat, logFile))
 	ctx = telemetry.WithMetrics(ctx, metrics)
 	ctx = authn.ToContext(ctx, principal)
+	ctx = authz.WithRequest(ctx, authz.Request{Surface: authz.SurfaceTUI, SessionID: authz.NewSessionID()})
 
-	database, err := store.Open(ctx, databasePath)
+	var opts []store.Option
+	if config.ReadOnly {
+		opts = append(opts, store.ReadOnly())
+	}
+	if config.KeyFile != "" {
+		key, err := seal.LoadKey(config.KeyFile)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, store.Sealed(key))
+	}
+	database, err := store.Open(ctx, config.DatabasePath, opts...)
 	if err != nil {
 		return err
 	}
-	application := app.New(ctx, app.Config{Store: database})
+	application, err := app.New(ctx, app.Config{Store: database, Defaults: defaults})
+	if err != nil {
+		_ = database.Close()
+		return err
+	}
 	defer func() { _ = application.Close() }()
+	if config.EnableMetrics {
+		application.StartMetricsCollector(ctx, runtimeconfig.DefaultMetricsInterval)
+	}
+
+	session := app.NewSession(ctx, application)
+	subscription := session.Subscribe(changes.Filter{})
+	defer subscription.Close()
 
-	program := tea.NewProgram(NewApp(app.NewSession(ctx, application)), tea.WithAltScreen())
+	program := tea.NewProgram(NewApp(session), tea.WithAltScreen())
+	go forwardChanges(program, subscription)
 	_, err = program.Run()
 	return err
 }
@@ -18,7 +18,7 @@ func TestTUIMutationWorkflowsUseAtomicTagComposition(t *testing.T) {
 		{"drinks", "edit_vm.go", "submit", "update", "Update"},
 		{"ingredients", "create_vm.go", "submit", "create", "Create"},
 		{"ingredients", "edit_vm.go", "submit", "update", "Update"},
-		{"inventory", "adjust_vm.go", "submit", "adjust", "Adjust"},
+		{"inventory", "adjust_vm.go", "adjustment", "adjust", "Adjust"},
 		{"inventory", "set_vm.go", "submit", "set", "Set"},
 		{"menus", "create_vm.go", "submit", "create", "Create"},
 		{"menus", "rename_vm.go", "submit", "update", "Update"},
@@ -62,6 +62,10 @@ func (d *Dashboard) Interaction() tui.Interaction { return tui.Interaction{} }
 // Update implements ViewModel.
 func (d *Dashboard) Update(msg tea.Msg) (tui.ViewModel, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tui.RefreshMsg:
+		d.loading = true
+		d.err = nil
+		return d, tea.Batch(d.spinner.Init(), d.loadData())
 	case tea.WindowSizeMsg:
 		d.width = msg.Width
 		d.height = msg.Height
@@ -155,7 +159,7 @@ func (d *Dashboard) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{d.keys.Nav1, d.keys.Nav2, d.keys.Nav3},
 		{d.keys.Nav4, d.keys.Nav5, d.keys.Nav6, d.keys.Nav7},
-		{d.keys.Refresh, d.keys.Help, d.keys.Quit},
+		{d.keys.Refresh, d.keys.Backup, d.keys.Help, d.keys.Quit},
 	}
 }
 
@@ -5,9 +5,13 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 )
 
+// BackupKey snapshots the database from any view. The root model handles it
+// because a backup belongs to no single screen.
+var BackupKey = keys.NewBinding("ctrl+b", "back up database", "ctrl+b")
+
 type dashboardKeys struct {
 	Nav1, Nav2, Nav3, Nav4, Nav5, Nav6, Nav7 key.Binding
-	Refresh, Help, Quit                      key.Binding
+	Refresh, Backup, Help, Quit              key.Binding
 }
 
 func newDashboardKeys() dashboardKeys {
@@ -20,6 +24,7 @@ func newDashboardKeys() dashboardKeys {
 		Nav6:    keys.NewBinding("6", "audit", "6"),
 		Nav7:    keys.NewBinding("7", "tags", "7"),
 		Refresh: keys.Standard.Refresh,
+		Backup:  BackupKey,
 		Help:    keys.Standard.Help,
 		Quit:    keys.Standard.Quit,
 	}
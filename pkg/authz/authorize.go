@@ -9,38 +9,54 @@ import (
 )
 
 var (
-	policiesOnce sync.Once
-	policiesSet  *cedar.PolicySet
-	policiesErr  error
+	evaluatorOnce sync.Once
+	evaluator     *Evaluator
+	evaluatorErr  error
 )
 
-func getPolicySet() (*cedar.PolicySet, error) {
-	policiesOnce.Do(func() {
-		policiesSet = cedar.NewPolicySet()
+func applicationEvaluator() (*Evaluator, error) {
+	evaluatorOnce.Do(func() {
+		evaluator, evaluatorErr = NewEvaluator(Documents()...)
+	})
+	return evaluator, evaluatorErr
+}
+
+// Documents returns the application's policy documents: the base policies
+// followed by every domain's policies.
+func Documents() []PolicyDocument {
+	return policyDocuments()
+}
 
-		docs := policyDocuments()
-		sort.Slice(docs, func(i, j int) bool { return docs[i].Name < docs[j].Name })
+// Evaluator evaluates requests against one assembled policy set. The package
+// functions use the application's documents; tests can assemble an evaluator
+// with additional documents to exercise a policy the application does not ship.
+type Evaluator struct {
+	policies *cedar.PolicySet
+}
 
-		for _, doc := range docs {
-			ps, err := cedar.NewPolicySetFromBytes(doc.Name, []byte(doc.Text))
-			if err != nil {
-				policiesErr = err
-				return
-			}
+// NewEvaluator parses docs into one policy set in deterministic document order.
+func NewEvaluator(docs ...PolicyDocument) (*Evaluator, error) {
+	docs = append([]PolicyDocument(nil), docs...)
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Name < docs[j].Name })
 
-			for id, p := range ps.All() {
-				_ = policiesSet.Add(cedar.PolicyID(doc.Name+":"+string(id)), p)
-			}
+	policies := cedar.NewPolicySet()
+	for _, doc := range docs {
+		ps, err := cedar.NewPolicySetFromBytes(doc.Name, []byte(doc.Text))
+		if err != nil {
+			return nil, err
 		}
-	})
-	return policiesSet, policiesErr
+		for id, p := range ps.All() {
+			_ = policies.Add(cedar.PolicyID(doc.Name+":"+string(id)), p)
+		}
+	}
+	return &Evaluator{policies: policies}, nil
 }
 
 // Authorize evaluates authorization for the given principal and action.
 // This is a pure function with no logging or telemetry side effects.
 // Observability should be handled by middleware wrapping this call.
 func Authorize(principal cedar.EntityUID, action cedar.EntityUID) error {
-	ps, err := getPolicySet()
+	e, err := applicationEvaluator()
 	if err != nil {
 		return err
 	}
@@ -68,26 +84,23 @@ func Authorize(principal cedar.EntityUID, action cedar.EntityUID) error {
 		Resource:  resource,
 		Context:   cedar.NewRecord(nil),
 	}
-
-	decision, diagnostic := cedar.Authorize(ps, entities, req)
-	if len(diagnostic.Errors) > 0 {
-		return errors.Internalf("authz evaluation error: %s", diagnostic.Errors[0].Message)
-	}
-	if decision == cedar.Deny {
-		return errors.Permissionf(
-			"authz denied principal=%s::%q action=%s::%q resource=%s::%q",
-			principal.Type, principal.ID,
-			action.Type, action.ID,
-			resource.Type, resource.ID,
-		)
-	}
-	return nil
+	return e.decide(entities, req)
 }
 
-// AuthorizeWithEntity evaluates authorization for the given principal, action, and resource.
+// AuthorizeWithEntity evaluates authorization for the given principal, action,
+// and resource under request, which becomes the Cedar context.
 // This is a pure function with no logging or telemetry side effects.
 // Observability should be handled by middleware wrapping this call.
-func AuthorizeWithEntity(principal cedar.EntityUID, action cedar.EntityUID, resource cedar.Entity) error {
+func AuthorizeWithEntity(principal cedar.EntityUID, action cedar.EntityUID, resource cedar.Entity, request Request) error {
+	e, err := applicationEvaluator()
+	if err != nil {
+		return err
+	}
+	return e.AuthorizeWithEntity(principal, action, resource, request)
+}
+
+// AuthorizeWithEntity is the evaluator-specific form of the package function.
+func (e *Evaluator) AuthorizeWithEntity(principal cedar.EntityUID, action cedar.EntityUID, resource cedar.Entity, request Request) error {
 	validator, ok := entityValidator(resource.UID.Type)
 	if !ok {
 		return errors.Internalf("authz resource type %q has no registered schema", resource.UID.Type)
@@ -97,11 +110,6 @@ func AuthorizeWithEntity(principal cedar.EntityUID, action cedar.EntityUID, reso
 			resource.UID.Type, resource.UID.ID, err)
 	}
 
-	ps, err := getPolicySet()
-	if err != nil {
-		return err
-	}
-
 	entities := cedar.EntityMap{
 		principal: {
 			UID:        principal,
@@ -116,19 +124,22 @@ func AuthorizeWithEntity(principal cedar.EntityUID, action cedar.EntityUID, reso
 		Principal: principal,
 		Action:    action,
 		Resource:  resource.UID,
-		Context:   cedar.NewRecord(nil),
+		Context:   request.Record(),
 	}
+	return e.decide(entities, req)
+}
 
-	decision, diagnostic := cedar.Authorize(ps, entities, req)
+func (e *Evaluator) decide(entities cedar.EntityMap, req cedar.Request) error {
+	decision, diagnostic := cedar.Authorize(e.policies, entities, req)
 	if len(diagnostic.Errors) > 0 {
 		return errors.Internalf("authz evaluation error: %s", diagnostic.Errors[0].Message)
 	}
 	if decision == cedar.Deny {
 		return errors.Permissionf(
 			"authz denied principal=%s::%q action=%s::%q resource=%s::%q",
-			principal.Type, principal.ID,
-			action.Type, action.ID,
-			resource.UID.Type, resource.UID.ID,
+			req.Principal.Type, req.Principal.ID,
+			req.Action.Type, req.Action.ID,
+			req.Resource.Type, req.Resource.ID,
 		)
 	}
 	return nil
@@ -14,7 +14,7 @@ func TestAuthorizeWithEntity_AllowsAnonymousList(t *testing.T) {
 	t.Parallel()
 
 	resource := validDrinkEntity()
-	err := authz.AuthorizeWithEntity(authn.Anonymous(), drinksauthz.ActionList, resource)
+	err := authz.AuthorizeWithEntity(authn.Anonymous(), drinksauthz.ActionList, resource, authz.Request{})
 	testutil.Ok(t, err)
 }
 
@@ -23,7 +23,7 @@ func TestAuthorizeWithEntity_DeniesAnonymousCreate(t *testing.T) {
 
 	resource := validDrinkEntity()
 
-	err := authz.AuthorizeWithEntity(authn.Anonymous(), drinksauthz.ActionCreate, resource)
+	err := authz.AuthorizeWithEntity(authn.Anonymous(), drinksauthz.ActionCreate, resource, authz.Request{})
 	testutil.ErrorIsPermission(t, err)
 }
 
@@ -32,7 +32,7 @@ func TestAuthorizeWithEntity_AllowsOwnerCreate(t *testing.T) {
 
 	resource := validDrinkEntity()
 
-	err := authz.AuthorizeWithEntity(authn.Owner(), drinksauthz.ActionCreate, resource)
+	err := authz.AuthorizeWithEntity(authn.Owner(), drinksauthz.ActionCreate, resource, authz.Request{})
 	testutil.Ok(t, err)
 }
 
@@ -44,7 +44,7 @@ func TestAuthorizeWithEntity_RejectsInvalidResource(t *testing.T) {
 		drinksauthz.DrinkCategoryAttr: cedar.Long(42),
 	})
 
-	err := authz.AuthorizeWithEntity(authn.Owner(), drinksauthz.ActionGet, resource)
+	err := authz.AuthorizeWithEntity(authn.Owner(), drinksauthz.ActionGet, resource, authz.Request{})
 	testutil.ErrorIsInternal(t, err)
 }
 
@@ -53,7 +53,7 @@ func TestAuthorizeWithEntity_RejectsUnknownResourceType(t *testing.T) {
 
 	err := authz.AuthorizeWithEntity(authn.Owner(), drinksauthz.ActionGet, cedar.Entity{
 		UID: cedar.NewEntityUID("Unknown::Resource", "test"),
-	})
+	}, authz.Request{})
 	testutil.ErrorIsInternal(t, err)
 }
 
@@ -240,14 +240,32 @@ func moduleActions(tree *ast.Schema) (types.Path, ast.Actions, error) {
 		if action.AppliesTo == nil {
 			continue
 		}
-		context, ok := action.AppliesTo.Context.(ast.RecordType)
-		if !ok || len(context) != 0 {
-			return "", nil, fmt.Errorf("action %s: non-empty action contexts are not supported", name)
+		if !supportedActionContext(tree, foundNS, action.AppliesTo.Context) {
+			return "", nil, fmt.Errorf("action %s: action contexts must be empty or the %s common type", name, requestContextType)
 		}
 	}
 	return foundNS, found, nil
 }
 
+// requestContextType names the common type that describes authz.Request.
+// Domains declare it beside their Actor enum; pkg/authz tests keep its shape
+// and the runtime record in agreement.
+const requestContextType = "RequestContext"
+
+func supportedActionContext(tree *ast.Schema, ns types.Path, context ast.IsType) bool {
+	switch context := context.(type) {
+	case ast.RecordType:
+		return len(context) == 0
+	case ast.TypeRef:
+		for _, path := range refCandidates(ns, string(context)) {
+			if _, _, ok := schemaCommonType(tree, path); ok {
+				return path == requestContextType || strings.HasSuffix(string(path), "::"+requestContextType)
+			}
+		}
+	}
+	return false
+}
+
 func moduleEntity(tree *ast.Schema, actionNS types.Path, actions ast.Actions) (types.Path, types.Ident, ast.Entity, error) {
 	var resource ast.EntityTypeRef
 	for _, action := range actions {
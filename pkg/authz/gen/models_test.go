@@ -1,6 +1,8 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -229,7 +231,7 @@ namespace Mixology::Drink {
 	testutil.ErrorIf(t, !strings.Contains(err.Error(), "parent types are not supported"), "unexpected error: %v", err)
 }
 
-func TestRenderModuleModelsRejectsActionContexts(t *testing.T) {
+func TestRenderModuleModelsRejectsAdHocActionContexts(t *testing.T) {
 	t.Parallel()
 
 	const src = `
@@ -252,7 +254,64 @@ namespace Mixology::Drink {
 
 	_, err = renderModuleModels(parsed.AST(), "drinks")
 	testutil.ErrorIf(t, err == nil, "expected unsupported action context error")
-	testutil.ErrorIf(t, !strings.Contains(err.Error(), "non-empty action contexts are not supported"), "unexpected error: %v", err)
+	testutil.ErrorIf(t, !strings.Contains(err.Error(), "action contexts must be empty or the RequestContext common type"), "unexpected error: %v", err)
+}
+
+const requestContextSchema = `
+namespace Mixology {
+    entity Actor enum ["manager", "bartender"];
+    type RequestContext = {
+        time: { unix: Long, weekday: Long, hour: Long, minute: Long },
+        surface: String,
+        client_address: String,
+        session_id: String
+    };
+    entity Order;
+}
+namespace Mixology::Order {
+    action place appliesTo {
+        principal: Mixology::Actor,
+        resource: Mixology::Order,
+        context: Mixology::RequestContext
+    };
+}`
+
+func TestRenderModuleModelsAcceptsRequestContext(t *testing.T) {
+	t.Parallel()
+
+	var parsed schema.Schema
+	testutil.Ok(t, parsed.UnmarshalCedar([]byte(requestContextSchema)))
+	_, err := parsed.Resolve()
+	testutil.Ok(t, err)
+
+	_, err = renderModuleModels(parsed.AST(), "orders")
+	testutil.Ok(t, err)
+}
+
+func TestValidatePoliciesChecksRequestContextAttributes(t *testing.T) {
+	t.Parallel()
+
+	var parsed schema.Schema
+	testutil.Ok(t, parsed.UnmarshalCedar([]byte(requestContextSchema)))
+	resolved, err := parsed.Resolve()
+	testutil.Ok(t, err)
+
+	dir := t.TempDir()
+	openHours := filepath.Join(dir, "open_hours.cedar")
+	testutil.Ok(t, os.WriteFile(openHours, []byte(`
+forbid(
+    principal == Mixology::Actor::"bartender",
+    action == Mixology::Order::Action::"place",
+    resource
+) unless {
+    context.time.hour >= 16 && context.time.hour < 23 && context.surface != "api"
+};`), 0o644))
+	testutil.Ok(t, validatePolicies(resolved, openHours))
+
+	unknown := filepath.Join(dir, "unknown.cedar")
+	testutil.Ok(t, os.WriteFile(unknown, []byte(`
+forbid(principal, action, resource) when { context.shift == "closed" };`), 0o644))
+	testutil.ErrorIf(t, validatePolicies(resolved, unknown) == nil, "expected unknown context attribute to fail validation")
 }
 
 func TestRenderModuleModelsRejectsReservedAttributeNames(t *testing.T) {
@@ -4,10 +4,12 @@ package authz
 import (
 	auditauthz "github.com/TheFellow/go-modular-monolith/app/domains/audit/authz"
 	drinksauthz "github.com/TheFellow/go-modular-monolith/app/domains/drinks/authz"
+	eventlogauthz "github.com/TheFellow/go-modular-monolith/app/domains/eventlog/authz"
 	ingredientsauthz "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/authz"
 	inventoryauthz "github.com/TheFellow/go-modular-monolith/app/domains/inventory/authz"
 	menusauthz "github.com/TheFellow/go-modular-monolith/app/domains/menus/authz"
 	ordersauthz "github.com/TheFellow/go-modular-monolith/app/domains/orders/authz"
+	outboxauthz "github.com/TheFellow/go-modular-monolith/app/domains/outbox/authz"
 	taggingauthz "github.com/TheFellow/go-modular-monolith/app/domains/tagging/authz"
 	cedar "github.com/cedar-policy/cedar-go"
 )
@@ -17,10 +19,12 @@ func policyDocuments() []PolicyDocument {
 		{Name: "pkg/authz/base.cedar", Text: Policies},
 		{Name: "app/domains/audit/authz/policies.cedar", Text: auditauthz.Policies},
 		{Name: "app/domains/drinks/authz/policies.cedar", Text: drinksauthz.Policies},
+		{Name: "app/domains/eventlog/authz/policies.cedar", Text: eventlogauthz.Policies},
 		{Name: "app/domains/ingredients/authz/policies.cedar", Text: ingredientsauthz.Policies},
 		{Name: "app/domains/inventory/authz/policies.cedar", Text: inventoryauthz.Policies},
 		{Name: "app/domains/menus/authz/policies.cedar", Text: menusauthz.Policies},
 		{Name: "app/domains/orders/authz/policies.cedar", Text: ordersauthz.Policies},
+		{Name: "app/domains/outbox/authz/policies.cedar", Text: outboxauthz.Policies},
 		{Name: "app/domains/tagging/authz/policies.cedar", Text: taggingauthz.Policies},
 	}
 }
@@ -31,6 +35,8 @@ func entityValidator(entityType cedar.EntityType) (func(cedar.Entity) error, boo
 		return auditauthz.ValidateEntity, true
 	case drinksauthz.ResourceType:
 		return drinksauthz.ValidateEntity, true
+	case eventlogauthz.ResourceType:
+		return eventlogauthz.ValidateEntity, true
 	case ingredientsauthz.ResourceType:
 		return ingredientsauthz.ValidateEntity, true
 	case inventoryauthz.ResourceType:
@@ -39,6 +45,8 @@ func entityValidator(entityType cedar.EntityType) (func(cedar.Entity) error, boo
 		return menusauthz.ValidateEntity, true
 	case ordersauthz.ResourceType:
 		return ordersauthz.ValidateEntity, true
+	case outboxauthz.ResourceType:
+		return outboxauthz.ValidateEntity, true
 	case taggingauthz.ResourceType:
 		return taggingauthz.ValidateEntity, true
 	default:
@@ -2,8 +2,13 @@ package authz
 
 import (
 	"context"
+	"strings"
+	"time"
 
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
 	cedar "github.com/cedar-policy/cedar-go"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // EntityAuthorizer evaluates whether principal may perform action on resource.
@@ -11,9 +16,55 @@ import (
 // may use the in-process policy set or adapt a remote policy service.
 type EntityAuthorizer func(context.Context, cedar.EntityUID, cedar.EntityUID, cedar.Entity) error
 
-// AuthorizeEntity evaluates the application's Cedar policies. The context is
-// accepted to give callers one reusable boundary for local and remote policy
-// evaluators; the in-process evaluator does not currently need it.
-func AuthorizeEntity(_ context.Context, principal, action cedar.EntityUID, resource cedar.Entity) error {
-	return AuthorizeWithEntity(principal, action, resource)
+// AuthorizeEntity evaluates the application's Cedar policies under the request
+// carried by ctx. Presentation contexts are not stamped by the operation
+// pipeline, so a request without a time is evaluated at the current time.
+// Each evaluation is traced as a child of the span carried by ctx and counted,
+// by action and decision, in the metrics carried by ctx.
+func AuthorizeEntity(ctx context.Context, principal, action cedar.EntityUID, resource cedar.Entity) error {
+	_, span := telemetry.StartSpan(ctx, telemetry.SpanAuthorize,
+		attribute.String(telemetry.AttrAction, action.String()),
+		attribute.String(telemetry.AttrPrincipalType, string(principal.Type)),
+		attribute.String(telemetry.AttrResourceType, string(resource.UID.Type)),
+	)
+	start := time.Now()
+	request := RequestFromContext(ctx)
+	if request.Time.IsZero() {
+		request.Time = start
+	}
+	err := AuthorizeWithEntity(principal, action, resource, request)
+	decision := "allow"
+	switch {
+	case errors.IsPermission(err):
+		decision = "deny"
+	case err != nil:
+		decision = "error"
+	}
+	recordDecision(ctx, action, decision, start)
+	span.SetAttributes(attribute.String(telemetry.AttrDecision, decision))
+	telemetry.EndSpan(span, err)
+	return err
+}
+
+func recordDecision(ctx context.Context, action cedar.EntityUID, decision string, start time.Time) {
+	metrics := telemetry.FromContext(ctx)
+	label := ActionLabel(action)
+	metrics.Histogram(telemetry.MetricAuthZLatency, telemetry.LabelAction).ObserveContext(ctx, time.Since(start).Seconds(), label)
+	metrics.Counter(telemetry.MetricAuthZTotal, telemetry.LabelAction, telemetry.LabelDecision).AddContext(ctx, 1, label, decision)
+	if decision == "deny" {
+		metrics.Counter(telemetry.MetricAuthZDenied, telemetry.LabelAction).AddContext(ctx, 1, label)
+	}
+}
+
+// ActionLabel shortens a Cedar action UID to a bounded metric label, such as
+// Mixology::Drink::Action::"create" to Drink.create.
+func ActionLabel(action cedar.EntityUID) string {
+	s := action.String()
+	parts := strings.Split(s, "::")
+	if len(parts) < 4 {
+		return s
+	}
+	domain := parts[1]
+	id := strings.Trim(parts[len(parts)-1], `"`)
+	return domain + "." + id
 }
@@ -0,0 +1,84 @@
+package authz
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// Surface names the entrypoint through which a request arrived.
+type Surface string
+
+const (
+	SurfaceCLI Surface = "cli"
+	SurfaceTUI Surface = "tui"
+	SurfaceGUI Surface = "gui"
+	SurfaceAPI Surface = "api"
+)
+
+// Request describes the circumstances of an authorization request rather than
+// its principal or resource. It becomes the Cedar context of every domain
+// action, whose schemas declare it as the RequestContext common type.
+type Request struct {
+	Time          time.Time
+	Surface       Surface
+	ClientAddress string
+	SessionID     string
+}
+
+// Cedar context attribute names.
+const (
+	ContextTimeAttr          = "time"
+	ContextSurfaceAttr       = "surface"
+	ContextClientAddressAttr = "client_address"
+	ContextSessionIDAttr     = "session_id"
+
+	TimeUnixAttr    = "unix"
+	TimeWeekdayAttr = "weekday"
+	TimeHourAttr    = "hour"
+	TimeMinuteAttr  = "minute"
+)
+
+// Record converts r to the RequestContext record. Clock fields are expressed in
+// the location of r.Time, so policies read opening hours as the bar does;
+// weekday counts from Sunday (0).
+func (r Request) Record() cedar.Record {
+	return cedar.NewRecord(cedar.RecordMap{
+		ContextTimeAttr: cedar.NewRecord(cedar.RecordMap{
+			TimeUnixAttr:    cedar.Long(r.Time.Unix()),
+			TimeWeekdayAttr: cedar.Long(r.Time.Weekday()),
+			TimeHourAttr:    cedar.Long(r.Time.Hour()),
+			TimeMinuteAttr:  cedar.Long(r.Time.Minute()),
+		}),
+		ContextSurfaceAttr:       cedar.String(r.Surface),
+		ContextClientAddressAttr: cedar.String(r.ClientAddress),
+		ContextSessionIDAttr:     cedar.String(r.SessionID),
+	})
+}
+
+type requestKey struct{}
+
+// WithRequest returns ctx carrying request. Entrypoints record the surface,
+// session, and client address once; middleware stamps the time of each
+// operation.
+func WithRequest(ctx context.Context, request Request) context.Context {
+	return context.WithValue(ctx, requestKey{}, request)
+}
+
+// RequestFromContext returns the request carried by ctx, or the zero Request
+// when no entrypoint recorded one.
+func RequestFromContext(ctx context.Context) Request {
+	request, _ := ctx.Value(requestKey{}).(Request)
+	return request
+}
+
+// NewSessionID returns a random identifier for one CLI invocation or one
+// interactive session.
+func NewSessionID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,94 @@
+package authz_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	drinksauthz "github.com/TheFellow/go-modular-monolith/app/domains/drinks/authz"
+	ordersauthz "github.com/TheFellow/go-modular-monolith/app/domains/orders/authz"
+	"github.com/TheFellow/go-modular-monolith/pkg/authn"
+	"github.com/TheFellow/go-modular-monolith/pkg/authz"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	cedar "github.com/cedar-policy/cedar-go"
+	"github.com/cedar-policy/cedar-go/x/exp/schema"
+	"github.com/cedar-policy/cedar-go/x/exp/schema/validate"
+)
+
+// openHours is the kind of policy request contexts make possible; the
+// application does not ship it.
+const openHours = `
+forbid(
+    principal == Mixology::Actor::"bartender",
+    action == Mixology::Order::Action::"place",
+    resource
+) unless {
+    context.time.hour >= 16 && context.time.hour < 23
+};`
+
+func TestRequestRecordConformsToDomainSchemas(t *testing.T) {
+	t.Parallel()
+
+	request := authz.Request{
+		Time:          time.Date(2026, time.March, 6, 18, 30, 0, 0, time.UTC),
+		Surface:       authz.SurfaceTUI,
+		ClientAddress: "127.0.0.1",
+		SessionID:     authz.NewSessionID(),
+	}
+	for name, doc := range map[string]string{"drinks": drinksauthz.Schema, "orders": ordersauthz.Schema} {
+		var parsed schema.Schema
+		testutil.Ok(t, parsed.UnmarshalCedar([]byte(doc)))
+		resolved, err := parsed.Resolve()
+		testutil.Ok(t, err)
+
+		action := drinksauthz.ActionGet
+		resource := validDrinkEntity()
+		if name == "orders" {
+			action, resource = ordersauthz.ActionPlace, validOrderEntity()
+		}
+		err = validate.New(resolved).Request(cedar.Request{
+			Principal: authn.Bartender(),
+			Action:    action,
+			Resource:  resource.UID,
+			Context:   request.Record(),
+		})
+		testutil.Ok(t, err)
+	}
+}
+
+func TestEvaluatorAppliesRequestTime(t *testing.T) {
+	t.Parallel()
+
+	evaluator, err := authz.NewEvaluator(append(authz.Documents(), authz.PolicyDocument{
+		Name: "test/open_hours.cedar",
+		Text: openHours,
+	})...)
+	testutil.Ok(t, err)
+
+	open := authz.Request{Time: time.Date(2026, time.March, 6, 18, 0, 0, 0, time.UTC), Surface: authz.SurfaceCLI}
+	closed := authz.Request{Time: time.Date(2026, time.March, 6, 9, 0, 0, 0, time.UTC), Surface: authz.SurfaceCLI}
+
+	testutil.Ok(t, evaluator.AuthorizeWithEntity(authn.Bartender(), ordersauthz.ActionPlace, validOrderEntity(), open))
+	testutil.ErrorIsPermission(t, evaluator.AuthorizeWithEntity(authn.Bartender(), ordersauthz.ActionPlace, validOrderEntity(), closed))
+	testutil.Ok(t, evaluator.AuthorizeWithEntity(authn.Manager(), ordersauthz.ActionPlace, validOrderEntity(), closed))
+	testutil.Ok(t, authz.AuthorizeWithEntity(authn.Bartender(), ordersauthz.ActionPlace, validOrderEntity(), closed))
+}
+
+func TestRequestFromContext(t *testing.T) {
+	t.Parallel()
+
+	testutil.Equals(t, authz.RequestFromContext(context.Background()), authz.Request{})
+
+	request := authz.Request{Surface: authz.SurfaceGUI, SessionID: "abc"}
+	testutil.Equals(t, authz.RequestFromContext(authz.WithRequest(context.Background(), request)), request)
+}
+
+func validOrderEntity() cedar.Entity {
+	return ordersauthz.Order{
+		UID:       cedar.NewEntityUID(ordersauthz.OrderType, "ord-test"),
+		MenuID:    cedar.NewEntityUID("Mixology::Menu", "mnu-test"),
+		Status:    "pending",
+		CreatedBy: authn.Bartender(),
+		Owner:     authn.Bartender(),
+	}.CedarEntity()
+}
@@ -0,0 +1,222 @@
+// Package changes is an in-process feed of committed commands for clients
+// that keep views open, such as the TUI, the GUI, and network transports.
+package changes
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/authn"
+	"github.com/TheFellow/go-modular-monolith/pkg/authz"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/log"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	middlewareevents "github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// subscriptionBuffer is how many changes a subscriber may fall behind before
+// further changes are counted in Change.Missed instead of queued.
+const subscriptionBuffer = 64
+
+// Resource is an entity's complete Cedar state and the action that reads it.
+type Resource struct {
+	Entity    cedar.Entity
+	GetAction cedar.EntityUID
+}
+
+// Resolver loads a touched entity in the committing transaction. A
+// soft-deleted entity resolves to its retained state, so its removal reaches
+// the subscribers that could read it; NotFound means the entity no longer
+// exists at all.
+type Resolver func(store.Context, cedar.EntityUID) (Resource, error)
+
+// Change is a committed command as one subscriber may see it.
+type Change struct {
+	Action cedar.EntityUID
+	At     time.Time
+	// Entities are the command's resource and touches that the subscriber
+	// may read, in touch order.
+	Entities []cedar.EntityUID
+	// Missed counts changes dropped before this one because the subscriber
+	// fell behind; a client seeing it should reload everything it shows.
+	Missed int
+}
+
+// Touches reports whether the change includes an entity of any of types.
+func (c Change) Touches(types ...cedar.EntityType) bool {
+	return slices.ContainsFunc(c.Entities, func(uid cedar.EntityUID) bool {
+		return slices.Contains(types, uid.Type)
+	})
+}
+
+// Filter selects the changes a subscriber receives.
+type Filter struct {
+	// Types limits changes to entities of these types; empty means all.
+	Types []cedar.EntityType
+}
+
+func (f Filter) allows(uid cedar.EntityUID) bool {
+	return len(f.Types) == 0 || slices.Contains(f.Types, uid.Type)
+}
+
+// Feed fans committed command activities out to subscribers. It implements
+// middleware.ChangeNotifier.
+type Feed struct {
+	resolve   Resolver
+	authorize authz.EntityAuthorizer
+
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+}
+
+func NewFeed(resolve Resolver) *Feed {
+	return &Feed{resolve: resolve, authorize: authz.AuthorizeEntity, subs: make(map[*Subscription]struct{})}
+}
+
+var _ middleware.ChangeNotifier = (*Feed)(nil)
+
+// staged is an entity captured as the command left it.
+type staged struct {
+	uid      cedar.EntityUID
+	resource Resource
+}
+
+// Stage captures the state of every entity the command touched, so that each
+// subscriber is authorized against what was committed rather than whatever
+// the entity looks like by the time the notification is delivered. Entities
+// that no longer resolve have no state to authorize against and are left out.
+func (f *Feed) Stage(ctx *middleware.Context, activity middlewareevents.Activity) func() {
+	if !f.hasSubscribers() {
+		return nil
+	}
+	uids := activity.Touches
+	if !activity.Resource.IsZero() && !slices.Contains(uids, activity.Resource) {
+		uids = append([]cedar.EntityUID{activity.Resource}, uids...)
+	}
+	entities := make([]staged, 0, len(uids))
+	for _, uid := range uids {
+		resource, err := f.resolve(ctx, uid)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			log.FromContext(ctx).Warn("resolve changed entity", "entity", uid.String(), log.Err(err))
+			continue
+		}
+		entities = append(entities, staged{uid: uid, resource: resource})
+	}
+	if len(entities) == 0 {
+		return nil
+	}
+	action, at := activity.Action, activity.CompletedAt
+	return func() { f.publish(action, at, entities) }
+}
+
+func (f *Feed) publish(action cedar.EntityUID, at time.Time, entities []staged) {
+	f.mu.Lock()
+	subs := make([]*Subscription, 0, len(f.subs))
+	for sub := range f.subs {
+		subs = append(subs, sub)
+	}
+	f.mu.Unlock()
+
+	for _, sub := range subs {
+		change := Change{Action: action, At: at}
+		for _, entity := range entities {
+			if !sub.filter.allows(entity.uid) {
+				continue
+			}
+			if err := f.authorize(sub.ctx, sub.principal, entity.resource.GetAction, entity.resource.Entity); err != nil {
+				continue
+			}
+			change.Entities = append(change.Entities, entity.uid)
+		}
+		if len(change.Entities) > 0 {
+			sub.deliver(change)
+		}
+	}
+}
+
+func (f *Feed) hasSubscribers() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.subs) > 0
+}
+
+// Subscribe starts delivering changes visible to the principal authenticated
+// on ctx. Each entity is authorized with that principal's read action under
+// ctx's request, so a subscriber only learns of entities it could load. The
+// subscription ends when ctx is done or Close is called.
+func (f *Feed) Subscribe(ctx context.Context, filter Filter) *Subscription {
+	sub := &Subscription{
+		feed:      f,
+		ctx:       ctx,
+		principal: authn.FromContext(ctx),
+		filter:    filter,
+		changes:   make(chan Change, subscriptionBuffer),
+	}
+	f.mu.Lock()
+	f.subs[sub] = struct{}{}
+	f.mu.Unlock()
+	sub.mu.Lock()
+	sub.stop = context.AfterFunc(ctx, sub.Close)
+	sub.mu.Unlock()
+	return sub
+}
+
+// Subscription receives changes until it is closed.
+type Subscription struct {
+	feed      *Feed
+	ctx       context.Context
+	principal cedar.EntityUID
+	filter    Filter
+	stop      func() bool
+
+	mu      sync.Mutex
+	changes chan Change
+	missed  int
+	closed  bool
+}
+
+// Changes is closed when the subscription ends.
+func (s *Subscription) Changes() <-chan Change {
+	return s.changes
+}
+
+func (s *Subscription) Close() {
+	s.feed.mu.Lock()
+	delete(s.feed.subs, s)
+	s.feed.mu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	if s.stop != nil {
+		s.stop()
+	}
+	close(s.changes)
+}
+
+// deliver never blocks the committing goroutine: a subscriber that is
+// behind loses the change and learns how many it lost with the next one.
+func (s *Subscription) deliver(change Change) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	change.Missed = s.missed
+	select {
+	case s.changes <- change:
+		s.missed = 0
+	default:
+		s.missed++
+	}
+}
@@ -0,0 +1,127 @@
+package changes
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/authn"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/log"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	middlewareevents "github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	testutil "github.com/TheFellow/go-modular-monolith/pkg/testutil/assert"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+var (
+	visible = cedar.NewEntityUID("Test::Thing", "visible")
+	hidden  = cedar.NewEntityUID("Test::Thing", "hidden")
+	retired = cedar.NewEntityUID("Test::Thing", "retired")
+	purged  = cedar.NewEntityUID("Test::Thing", "purged")
+)
+
+func testFeed() *Feed {
+	f := NewFeed(func(_ store.Context, uid cedar.EntityUID) (Resource, error) {
+		switch uid {
+		case purged:
+			return Resource{}, errors.NotFoundf("%s not found", uid)
+		case retired:
+			// A soft-deleted entity resolves to its retained state.
+			return Resource{Entity: cedar.Entity{UID: uid, Attributes: cedar.NewRecord(cedar.RecordMap{"deleted": cedar.True})}}, nil
+		}
+		return Resource{Entity: cedar.Entity{UID: uid}}, nil
+	})
+	f.authorize = func(_ context.Context, _, _ cedar.EntityUID, resource cedar.Entity) error {
+		if resource.UID == hidden {
+			return errors.Permissionf("denied")
+		}
+		return nil
+	}
+	return f
+}
+
+func commandContext() *middleware.Context {
+	ctx := log.ToContext(context.Background(), slog.New(slog.DiscardHandler))
+	return middleware.NewContext(authn.ToContext(ctx, authn.Anonymous()))
+}
+
+func commit(f *Feed, touches ...cedar.EntityUID) {
+	ctx := commandContext()
+	activity := middlewareevents.NewActivity(cedar.NewEntityUID("Test::Action", "update"), cedar.EntityUID{}, authn.Anonymous())
+	for _, uid := range touches {
+		activity.Touch(uid)
+	}
+	if notify := f.Stage(ctx, *activity); notify != nil {
+		notify()
+	}
+}
+
+func TestFeed_DeliversOnlyAuthorizedResolvableEntities(t *testing.T) {
+	t.Parallel()
+	f := testFeed()
+	sub := f.Subscribe(authn.ToContext(context.Background(), authn.Anonymous()), Filter{})
+	defer sub.Close()
+
+	commit(f, hidden, purged, visible)
+	commit(f, hidden)
+
+	change := <-sub.Changes()
+	testutil.ErrorIf(t, len(change.Entities) != 1 || change.Entities[0] != visible, "entities = %v, want only %v", change.Entities, visible)
+	select {
+	case change := <-sub.Changes():
+		testutil.ErrorIf(t, true, "unexpected change %v", change)
+	default:
+	}
+}
+
+func TestFeed_AnnouncesSoftDeletesAgainstRetainedState(t *testing.T) {
+	t.Parallel()
+	f := testFeed()
+	var authorized []cedar.Entity
+	f.authorize = func(_ context.Context, _, _ cedar.EntityUID, resource cedar.Entity) error {
+		authorized = append(authorized, resource)
+		if resource.UID == hidden {
+			return errors.Permissionf("denied")
+		}
+		return nil
+	}
+	sub := f.Subscribe(authn.ToContext(context.Background(), authn.Anonymous()), Filter{})
+	defer sub.Close()
+
+	commit(f, retired)
+
+	change := <-sub.Changes()
+	testutil.ErrorIf(t, len(change.Entities) != 1 || change.Entities[0] != retired, "entities = %v, want only %v", change.Entities, retired)
+	testutil.ErrorIf(t, len(authorized) != 1, "authorized %d entities, want 1", len(authorized))
+	_, ok := authorized[0].Attributes.Get("deleted")
+	testutil.ErrorIf(t, !ok, "%v", "authorized a state other than the retained one")
+}
+
+func TestFeed_SlowSubscriberLearnsHowManyChangesItMissed(t *testing.T) {
+	t.Parallel()
+	f := testFeed()
+	sub := f.Subscribe(authn.ToContext(context.Background(), authn.Anonymous()), Filter{})
+	defer sub.Close()
+
+	for range subscriptionBuffer + 3 {
+		commit(f, visible)
+	}
+	for range subscriptionBuffer {
+		<-sub.Changes()
+	}
+	commit(f, visible)
+
+	change := <-sub.Changes()
+	testutil.ErrorIf(t, change.Missed != 3, "missed = %d, want 3", change.Missed)
+}
+
+func TestFeed_StagesNothingWithoutSubscribers(t *testing.T) {
+	t.Parallel()
+	f := testFeed()
+	ctx := commandContext()
+	activity := middlewareevents.NewActivity(cedar.EntityUID{}, visible, authn.Anonymous())
+
+	testutil.ErrorIf(t, f.Stage(ctx, *activity) != nil, "%v", "staged a notification with no subscribers")
+}
@@ -4,23 +4,69 @@ package dispatcher
 
 import (
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/TheFellow/go-modular-monolith/app/kernel/tag"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/log"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	middlewareevents "github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
+	"github.com/TheFellow/go-modular-monolith/pkg/set"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type Dispatcher struct {
 	store *store.Store
 	tags  tag.Repository
+	only  set.Set[string]
 }
 
 func New(s *store.Store, tags tag.Repository) *Dispatcher {
 	return &Dispatcher{store: s, tags: tags}
 }
 
+// Only returns a dispatcher that runs just the handlers owned by the named
+// domains. Replay uses it to rebuild one domain's derived state without
+// re-running every other reaction.
+func (d *Dispatcher) Only(domains ...string) (*Dispatcher, error) {
+	only := set.New[string]()
+	for _, domain := range domains {
+		if !slices.Contains(handlerDomains, domain) {
+			return nil, errors.Invalidf("no %q event handlers (expected one of %s)", domain, strings.Join(handlerDomains, ", "))
+		}
+		only.Add(domain)
+	}
+	filtered := *d
+	filtered.only = only
+	return &filtered, nil
+}
+
+func (d *Dispatcher) handles(domain string) bool {
+	return d.only.Len() == 0 || d.only.Contains(domain)
+}
+
+// HandlerDomains returns the domains that own at least one event handler.
+func HandlerDomains() []string {
+	return slices.Clone(handlerDomains)
+}
+
+// DecodeEvent rebuilds a domain event recorded by middlewareevents.Encode
+// under its middlewareevents.Name.
+func DecodeEvent(name string, data []byte) (any, error) {
+	decode, ok := eventDecoders[name]
+	if !ok {
+		return nil, errors.Invalidf("unknown event type %q", name)
+	}
+	return decode(data)
+}
+
+func decode[T any](data []byte) (any, error) {
+	return middlewareevents.Decode[T](data)
+}
+
 // handlerError is called when a handler returns an error.
 // Return a non-nil error to stop dispatch immediately.
 func (d *Dispatcher) handlerError(_ctx *middleware.Context, _event any, err error) error {
@@ -40,6 +86,16 @@ func (d *Dispatcher) unhandledEvent(ctx *middleware.Context, event any) error {
 	return nil
 }
 
+// traceHandler starts the span for one handler call and returns the handler
+// context to run it under, with the function that ends the span.
+func traceHandler(hctx *middleware.HandlerContext, domain, handler, phase string, event any) (*middleware.HandlerContext, func(error)) {
+	spanCtx, span := telemetry.StartSpan(hctx, domain+"."+handler+"."+phase,
+		attribute.String(telemetry.AttrHandler, domain+"."+handler),
+		attribute.String(telemetry.AttrEventType, eventTypeLabel(event)),
+	)
+	return hctx.WithContext(spanCtx), func(err error) { telemetry.EndSpan(span, err) }
+}
+
 func eventTypeLabel(event any) string {
 	t := reflect.TypeOf(event)
 	if t == nil {
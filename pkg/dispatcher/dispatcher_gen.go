@@ -12,6 +12,7 @@ import (
 	menus_handlers "github.com/TheFellow/go-modular-monolith/app/domains/menus/handlers"
 	orders_events "github.com/TheFellow/go-modular-monolith/app/domains/orders/events"
 	orders_handlers "github.com/TheFellow/go-modular-monolith/app/domains/orders/handlers"
+	tagging_events "github.com/TheFellow/go-modular-monolith/app/domains/tagging/events"
 	middleware "github.com/TheFellow/go-modular-monolith/pkg/middleware"
 )
 
@@ -24,16 +25,26 @@ func (d *Dispatcher) Dispatch(ctx *middleware.Context, event any) error {
 	switch e := event.(type) {
 	case drinks_events.DrinkDeleted:
 		menusHandler := menus_handlers.NewDrinkDeleted(d.store, d.tags)
-		if err := menusHandler.Handle(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		if d.handles("menus") {
+			handlerCtx, done := traceHandler(hctx, "menus", "DrinkDeleted", "Handle", e)
+			err := menusHandler.Handle(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
 	case drinks_events.DrinkUpdated:
 		menusHandler := menus_handlers.NewDrinkUpdated(d.store, d.tags)
-		if err := menusHandler.Handle(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		if d.handles("menus") {
+			handlerCtx, done := traceHandler(hctx, "menus", "DrinkUpdated", "Handle", e)
+			err := menusHandler.Handle(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
 	case ingredients_events.IngredientDeleted:
@@ -41,106 +52,202 @@ func (d *Dispatcher) Dispatch(ctx *middleware.Context, event any) error {
 		inventoryHandler := inventory_handlers.NewIngredientDeleted(d.store, d.tags)
 		menusHandler := menus_handlers.NewIngredientDeleted(d.store, d.tags)
 		ordersHandler := orders_handlers.NewIngredientDeleted(d.store, d.tags)
-		if err := drinksHandler.Handling(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		if d.handles("drinks") {
+			handlerCtx, done := traceHandler(hctx, "drinks", "IngredientDeleted", "Handling", e)
+			err := drinksHandler.Handling(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
-		if err := menusHandler.Handling(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		if d.handles("menus") {
+			handlerCtx, done := traceHandler(hctx, "menus", "IngredientDeleted", "Handling", e)
+			err := menusHandler.Handling(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
-		if err := drinksHandler.Handle(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		if d.handles("drinks") {
+			handlerCtx, done := traceHandler(hctx, "drinks", "IngredientDeleted", "Handle", e)
+			err := drinksHandler.Handle(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
-		if err := inventoryHandler.Handle(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		if d.handles("inventory") {
+			handlerCtx, done := traceHandler(hctx, "inventory", "IngredientDeleted", "Handle", e)
+			err := inventoryHandler.Handle(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
-		if err := menusHandler.Handle(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		if d.handles("menus") {
+			handlerCtx, done := traceHandler(hctx, "menus", "IngredientDeleted", "Handle", e)
+			err := menusHandler.Handle(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
-		if err := ordersHandler.Handle(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		if d.handles("orders") {
+			handlerCtx, done := traceHandler(hctx, "orders", "IngredientDeleted", "Handle", e)
+			err := ordersHandler.Handle(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
 	case ingredients_events.IngredientUpdated:
 		drinksHandler := drinks_handlers.NewIngredientUpdated(d.store, d.tags)
 		menusHandler := menus_handlers.NewIngredientUpdated(d.store, d.tags)
-		if err := drinksHandler.Handle(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		if d.handles("drinks") {
+			handlerCtx, done := traceHandler(hctx, "drinks", "IngredientUpdated", "Handle", e)
+			err := drinksHandler.Handle(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
-		if err := menusHandler.Handle(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		if d.handles("menus") {
+			handlerCtx, done := traceHandler(hctx, "menus", "IngredientUpdated", "Handle", e)
+			err := menusHandler.Handle(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
 	case inventory_events.StockAdjusted:
 		menusHandler := menus_handlers.NewStockAdjusted(d.store, d.tags)
 		ordersHandler := orders_handlers.NewStockAdjusted(d.store, d.tags)
-		if err := menusHandler.Handle(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		if d.handles("menus") {
+			handlerCtx, done := traceHandler(hctx, "menus", "StockAdjusted", "Handle", e)
+			err := menusHandler.Handle(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
-		if err := ordersHandler.Handle(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		if d.handles("orders") {
+			handlerCtx, done := traceHandler(hctx, "orders", "StockAdjusted", "Handle", e)
+			err := ordersHandler.Handle(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
 	case menus_events.MenuPublished:
 		menusHandler := menus_handlers.NewMenuPublished(d.store, d.tags)
-		if err := menusHandler.Handle(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		if d.handles("menus") {
+			handlerCtx, done := traceHandler(hctx, "menus", "MenuPublished", "Handle", e)
+			err := menusHandler.Handle(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
 	case orders_events.OrderCancelled:
 		inventoryHandler := inventory_handlers.NewOrderCancelled(d.store, d.tags)
 		menusHandler := menus_handlers.NewOrderCancelled(d.store, d.tags)
-		if err := inventoryHandler.Handle(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		if d.handles("inventory") {
+			handlerCtx, done := traceHandler(hctx, "inventory", "OrderCancelled", "Handle", e)
+			err := inventoryHandler.Handle(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
-		if err := menusHandler.Handle(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		if d.handles("menus") {
+			handlerCtx, done := traceHandler(hctx, "menus", "OrderCancelled", "Handle", e)
+			err := menusHandler.Handle(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
 	case orders_events.OrderCompleted:
 		inventoryHandler := inventory_handlers.NewOrderCompleted(d.store, d.tags)
 		menusHandler := menus_handlers.NewOrderCompleted(d.store, d.tags)
-		if err := inventoryHandler.Handle(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		ordersHandler := orders_handlers.NewOrderCompleted(d.store, d.tags)
+		if d.handles("inventory") {
+			handlerCtx, done := traceHandler(hctx, "inventory", "OrderCompleted", "Handle", e)
+			err := inventoryHandler.Handle(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
-		if err := menusHandler.Handle(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		if d.handles("menus") {
+			handlerCtx, done := traceHandler(hctx, "menus", "OrderCompleted", "Handle", e)
+			err := menusHandler.Handle(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
+			}
+		}
+		if d.handles("orders") {
+			handlerCtx, done := traceHandler(hctx, "orders", "OrderCompleted", "Handle", e)
+			err := ordersHandler.Handle(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
 	case orders_events.OrderPlaced:
 		inventoryHandler := inventory_handlers.NewOrderPlaced(d.store, d.tags)
 		menusHandler := menus_handlers.NewOrderPlaced(d.store, d.tags)
-		if err := inventoryHandler.Handle(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		if d.handles("inventory") {
+			handlerCtx, done := traceHandler(hctx, "inventory", "OrderPlaced", "Handle", e)
+			err := inventoryHandler.Handle(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
-		if err := menusHandler.Handle(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		if d.handles("menus") {
+			handlerCtx, done := traceHandler(hctx, "menus", "OrderPlaced", "Handle", e)
+			err := menusHandler.Handle(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
 	default:
@@ -148,3 +255,37 @@ func (d *Dispatcher) Dispatch(ctx *middleware.Context, event any) error {
 	}
 	return nil
 }
+
+// handlerDomains lists, in order, every domain that owns an event handler.
+var handlerDomains = []string{
+	"drinks",
+	"inventory",
+	"menus",
+	"orders",
+}
+
+// eventDecoders rebuilds each domain event from its recorded encoding, keyed
+// by the event's middleware events name.
+var eventDecoders = map[string]func([]byte) (any, error){
+	"drinks.DrinkCreated":            decode[drinks_events.DrinkCreated],
+	"drinks.DrinkDeleted":            decode[drinks_events.DrinkDeleted],
+	"drinks.DrinkRestored":           decode[drinks_events.DrinkRestored],
+	"drinks.DrinkUpdated":            decode[drinks_events.DrinkUpdated],
+	"ingredients.IngredientCreated":  decode[ingredients_events.IngredientCreated],
+	"ingredients.IngredientDeleted":  decode[ingredients_events.IngredientDeleted],
+	"ingredients.IngredientRestored": decode[ingredients_events.IngredientRestored],
+	"ingredients.IngredientUpdated":  decode[ingredients_events.IngredientUpdated],
+	"inventory.StockAdjusted":        decode[inventory_events.StockAdjusted],
+	"menus.DrinkAddedToMenu":         decode[menus_events.DrinkAddedToMenu],
+	"menus.DrinkRemovedFromMenu":     decode[menus_events.DrinkRemovedFromMenu],
+	"menus.MenuCreated":              decode[menus_events.MenuCreated],
+	"menus.MenuDrafted":              decode[menus_events.MenuDrafted],
+	"menus.MenuPublished":            decode[menus_events.MenuPublished],
+	"menus.MenuRestored":             decode[menus_events.MenuRestored],
+	"orders.OrderCancelled":          decode[orders_events.OrderCancelled],
+	"orders.OrderCompleted":          decode[orders_events.OrderCompleted],
+	"orders.OrderDeleted":            decode[orders_events.OrderDeleted],
+	"orders.OrderPlaced":             decode[orders_events.OrderPlaced],
+	"orders.OrderRestored":           decode[orders_events.OrderRestored],
+	"tagging.TagsChanged":            decode[tagging_events.TagsChanged],
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/TheFellow/go-modular-monolith/pkg/dispatcher"
 	"github.com/TheFellow/go-modular-monolith/pkg/log"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	middlewareevents "github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
 	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
 	cedar "github.com/cedar-policy/cedar-go"
 )
@@ -43,3 +44,33 @@ func TestDispatcher_IgnoresUnknownEvents(t *testing.T) {
 	ctx := middleware.NewContext(base)
 	testutil.Ok(t, d.Dispatch(ctx, unknownEvent{}))
 }
+
+func TestDispatcher_DecodeEventRoundTripsEncodedEvents(t *testing.T) {
+	t.Parallel()
+
+	event := events.IngredientCreated{
+		Ingredient: models.Ingredient{
+			ID:   entity.IngredientID(cedar.NewEntityUID(entity.TypeIngredient, cedar.String("vodka"))),
+			Name: "Vodka",
+		},
+	}
+	data, err := middlewareevents.Encode(event)
+	testutil.Ok(t, err)
+	testutil.Equals(t, middlewareevents.Name(event), "ingredients.IngredientCreated")
+
+	decoded, err := dispatcher.DecodeEvent(middlewareevents.Name(event), data)
+	testutil.Ok(t, err)
+	testutil.Equals(t, decoded, any(event))
+
+	_, err = dispatcher.DecodeEvent("ingredients.Unknown", data)
+	testutil.ErrorIsInvalid(t, err)
+}
+
+func TestDispatcher_OnlyRejectsDomainsWithoutHandlers(t *testing.T) {
+	t.Parallel()
+
+	_, err := dispatcher.New(nil, nil).Only("menus", "orders")
+	testutil.Ok(t, err)
+	_, err = dispatcher.New(nil, nil).Only("audit")
+	testutil.ErrorIsInvalid(t, err)
+}
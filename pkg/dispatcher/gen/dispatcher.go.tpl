@@ -23,18 +23,28 @@ func (d *Dispatcher) Dispatch(ctx *{{ .MiddlewareAlias }}.Context, event any) er
 
 {{- range .Handlers }}
 {{- if .HasHandling }}
-		if err := {{ .VarName }}.Handling(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		if d.handles({{ printf "%q" .Domain }}) {
+			handlerCtx, done := traceHandler(hctx, {{ printf "%q" .Domain }}, {{ printf "%q" .Name }}, "Handling", e)
+			err := {{ .VarName }}.Handling(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
 {{- end }}
 {{- end }}
 
 {{- range .Handlers }}
-		if err := {{ .VarName }}.Handle(hctx, e); err != nil {
-			if herr := d.handlerError(ctx, e, err); herr != nil {
-				return herr
+		if d.handles({{ printf "%q" .Domain }}) {
+			handlerCtx, done := traceHandler(hctx, {{ printf "%q" .Domain }}, {{ printf "%q" .Name }}, "Handle", e)
+			err := {{ .VarName }}.Handle(handlerCtx, e)
+			done(err)
+			if err != nil {
+				if herr := d.handlerError(ctx, e, err); herr != nil {
+					return herr
+				}
 			}
 		}
 {{- end }}
@@ -44,3 +54,18 @@ func (d *Dispatcher) Dispatch(ctx *{{ .MiddlewareAlias }}.Context, event any) er
 	}
 	return nil
 }
+
+// handlerDomains lists, in order, every domain that owns an event handler.
+var handlerDomains = []string{
+{{- range .HandlerDomains }}
+	{{ printf "%q" . }},
+{{- end }}
+}
+
+// eventDecoders rebuilds each domain event from its recorded encoding, keyed
+// by the event's middleware events name.
+var eventDecoders = map[string]func([]byte) (any, error){
+{{- range .Events }}
+	{{ printf "%q" .Key }}: decode[{{ index $.ImportAlias .PkgPath }}.{{ .Name }}],
+{{- end }}
+}
@@ -26,6 +26,11 @@ type eventType struct {
 	Name    string
 }
 
+// Key is the event's middleware events name, "<domain>.<Type>".
+func (e eventType) Key() string {
+	return domainFromPkgPath(e.PkgPath) + "." + e.Name
+}
+
 type handlerType struct {
 	PkgPath      string
 	Name         string
@@ -33,6 +38,7 @@ type handlerType struct {
 	EventName    string
 	HasHandling  bool
 	VarName      string
+	Domain       string
 }
 
 type eventGroup struct {
@@ -107,6 +113,24 @@ func main() {
 
 	assignHandlerVarNames(groups)
 
+	var domains set.Set[string]
+	for _, g := range groups {
+		for _, h := range g.Handlers {
+			domains.Add(h.Domain)
+		}
+	}
+	handlerDomains := domains.Slice()
+	sort.Strings(handlerDomains)
+
+	// Every exported domain event can be recorded and replayed, including
+	// events that no handler consumes yet.
+	decodable := make([]eventType, 0, len(events))
+	for _, e := range events {
+		if ast.IsExported(e.Name) && strings.HasPrefix(e.PkgPath, modulePath+"/app/domains/") {
+			decodable = append(decodable, e)
+		}
+	}
+
 	type importSpec struct {
 		Alias string
 		Path  string
@@ -154,6 +178,9 @@ func main() {
 	middlewareAlias := addImport(middlewareImportPath)
 
 	// Add event + handler package imports.
+	for _, e := range decodable {
+		addImport(e.PkgPath)
+	}
 	for _, g := range groups {
 		addImport(g.Event.PkgPath)
 		for _, h := range g.Handlers {
@@ -180,6 +207,8 @@ func main() {
 		"Groups":          groups,
 		"ImportAlias":     importAlias,
 		"MiddlewareAlias": middlewareAlias,
+		"HandlerDomains":  handlerDomains,
+		"Events":          decodable,
 	}); err != nil {
 		fatalf("execute template: %v", err)
 	}
@@ -199,7 +228,8 @@ func assignHandlerVarNames(groups []*eventGroup) {
 	for _, g := range groups {
 		used := map[string]int{}
 		for i := range g.Handlers {
-			base := domainFromPkgPath(g.Handlers[i].PkgPath)
+			g.Handlers[i].Domain = domainFromPkgPath(g.Handlers[i].PkgPath)
+			base := g.Handlers[i].Domain
 			if base == "" {
 				base = "handler"
 			}
@@ -0,0 +1,127 @@
+// Package idempotency persists the results of commands run under
+// idempotency keys, so the command pipeline can replay them for retried
+// requests.
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/authz"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// TTL is how long a result is replayed. A key retried later runs its command
+// again; expired results are pruned whenever a new one is saved.
+const TTL = 24 * time.Hour
+
+type idempotentResultRow struct {
+	Key          string
+	ActionType   string
+	ActionID     string
+	Fingerprint  string
+	ResourceType string
+	ResourceID   string
+	// Entity is the result's Cedar state in Cedar's entities JSON format.
+	Entity    []byte
+	Result    []byte
+	CreatedAt time.Time
+}
+
+// Store keeps idempotent results in the application database. It implements
+// middleware.IdempotencyStore.
+type Store struct {
+	store *store.Store
+}
+
+// Register adds the result table to s.
+func Register(ctx context.Context, s *store.Store) {
+	s.Register(ctx, idempotentResultRow{})
+}
+
+func New(s *store.Store) *Store {
+	return &Store{store: s}
+}
+
+func (s *Store) FindIdempotent(ctx *middleware.Context, key string) (middleware.IdempotentResult, bool, error) {
+	var row idempotentResultRow
+	found := false
+	err := s.store.ReadContext(ctx, func(tx *store.Tx) error {
+		row = idempotentResultRow{Key: key}
+		err := tx.Get(&row)
+		if errors.Is(err, store.ErrAbsent) {
+			return nil
+		}
+		if err != nil {
+			return store.MapError(err, "get idempotent result")
+		}
+		found = !row.CreatedAt.Before(requestTime(ctx).Add(-TTL))
+		return nil
+	})
+	if err != nil || !found {
+		return middleware.IdempotentResult{}, false, err
+	}
+	entity, err := decodeEntity(row.Entity)
+	if err != nil {
+		return middleware.IdempotentResult{}, false, err
+	}
+	return middleware.IdempotentResult{
+		Key:         row.Key,
+		Action:      cedar.NewEntityUID(cedar.EntityType(row.ActionType), cedar.String(row.ActionID)),
+		Fingerprint: row.Fingerprint,
+		Resource:    cedar.NewEntityUID(cedar.EntityType(row.ResourceType), cedar.String(row.ResourceID)),
+		Entity:      entity,
+		Result:      row.Result,
+	}, true, nil
+}
+
+func (s *Store) SaveIdempotent(ctx *middleware.Context, result middleware.IdempotentResult) error {
+	createdAt := requestTime(ctx)
+	entity, err := json.Marshal(cedar.EntityMap{result.Entity.UID: result.Entity})
+	if err != nil {
+		return errors.Internalf("encode idempotent result entity: %w", err)
+	}
+	return store.Write(ctx, func(tx *store.Tx) error {
+		// Pruning first also clears an expired result stored under this key.
+		if _, err := store.QueryTx[idempotentResultRow](tx).FilterLess("CreatedAt", createdAt.Add(-TTL)).Delete(); err != nil {
+			return store.MapError(err, "prune idempotent results")
+		}
+		row := idempotentResultRow{
+			Key:          result.Key,
+			ActionType:   string(result.Action.Type),
+			ActionID:     string(result.Action.ID),
+			Fingerprint:  result.Fingerprint,
+			ResourceType: string(result.Resource.Type),
+			ResourceID:   string(result.Resource.ID),
+			Entity:       entity,
+			Result:       result.Result,
+			CreatedAt:    createdAt,
+		}
+		return store.MapError(tx.Insert(&row), "save idempotent result")
+	})
+}
+
+func requestTime(ctx context.Context) time.Time {
+	if at := authz.RequestFromContext(ctx).Time; !at.IsZero() {
+		return at
+	}
+	return time.Now()
+}
+
+func decodeEntity(data []byte) (cedar.Entity, error) {
+	if len(data) == 0 {
+		return cedar.Entity{}, nil
+	}
+	var entities cedar.EntityMap
+	if err := json.Unmarshal(data, &entities); err != nil {
+		return cedar.Entity{}, errors.Internalf("decode idempotent result entity: %w", err)
+	}
+	for _, entity := range entities {
+		return entity, nil
+	}
+	return cedar.Entity{}, nil
+}
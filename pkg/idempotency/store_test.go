@@ -0,0 +1,118 @@
+package idempotency_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/authn"
+	"github.com/TheFellow/go-modular-monolith/pkg/authz"
+	"github.com/TheFellow/go-modular-monolith/pkg/idempotency"
+	"github.com/TheFellow/go-modular-monolith/pkg/log"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/teststore"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+var start = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newStore(t *testing.T) (context.Context, *store.Store, *idempotency.Store) {
+	t.Helper()
+	ctx := authn.ToContext(context.Background(), authn.Owner())
+	ctx = log.ToContext(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	s, err := store.Open(ctx, teststore.Path(t, "idempotency.test"))
+	testutil.Ok(t, err)
+	idempotency.Register(ctx, s)
+	t.Cleanup(func() { testutil.Ok(t, s.Close()) })
+	return ctx, s, idempotency.New(s)
+}
+
+// at runs fn in a write transaction whose requests are stamped with now.
+func at(t *testing.T, ctx context.Context, s *store.Store, now time.Time, fn func(*middleware.Context) error) {
+	t.Helper()
+	ctx = authz.WithRequest(ctx, authz.Request{Time: now})
+	testutil.Ok(t, s.Write(ctx, func(tx *store.Tx) error {
+		return fn(middleware.NewContext(ctx).WithTransaction(tx))
+	}))
+}
+
+func find(t *testing.T, ctx context.Context, s *store.Store, results *idempotency.Store, now time.Time, key string) (middleware.IdempotentResult, bool) {
+	t.Helper()
+	var result middleware.IdempotentResult
+	var found bool
+	at(t, ctx, s, now, func(c *middleware.Context) error {
+		var err error
+		result, found, err = results.FindIdempotent(c, key)
+		return err
+	})
+	return result, found
+}
+
+func result(key string) middleware.IdempotentResult {
+	resource := cedar.NewEntityUID("Mixology::Drink", "drk-1")
+	return middleware.IdempotentResult{
+		Key:         key,
+		Action:      cedar.NewEntityUID("Mixology::Drink::Action", "create"),
+		Fingerprint: "fingerprint-" + key,
+		Resource:    resource,
+		Entity: cedar.Entity{
+			UID:     resource,
+			Parents: cedar.NewEntityUIDSet(),
+			Attributes: cedar.NewRecord(cedar.RecordMap{
+				"Category": cedar.String("wine"),
+				"Owner":    authn.Sommelier(),
+				"Servings": cedar.Long(2),
+				"Labels":   cedar.NewSet(cedar.String("red")),
+			}),
+			Tags: cedar.NewRecord(cedar.RecordMap{"region": cedar.String("west")}),
+		},
+		Result: []byte("encoded result"),
+	}
+}
+
+func TestStore_SavesAndFindsResultWithEntityState(t *testing.T) {
+	t.Parallel()
+	ctx, s, results := newStore(t)
+
+	_, found := find(t, ctx, s, results, start, "owner retry#1")
+	testutil.IsFalse(t, found)
+
+	want := result("owner retry#1")
+	at(t, ctx, s, start, func(c *middleware.Context) error { return results.SaveIdempotent(c, want) })
+
+	got, found := find(t, ctx, s, results, start.Add(time.Hour), want.Key)
+	testutil.IsTrue(t, found)
+	testutil.IsTrue(t, got.Entity.Equal(want.Entity))
+	got.Entity = want.Entity
+	testutil.Equals(t, got, want)
+}
+
+func TestStore_ExpiresResultsAfterTTLAndPrunesThem(t *testing.T) {
+	t.Parallel()
+	ctx, s, results := newStore(t)
+
+	first := result("owner retry#1")
+	other := result("owner other#1")
+	at(t, ctx, s, start, func(c *middleware.Context) error { return results.SaveIdempotent(c, first) })
+	at(t, ctx, s, start, func(c *middleware.Context) error { return results.SaveIdempotent(c, other) })
+
+	_, found := find(t, ctx, s, results, start.Add(idempotency.TTL), first.Key)
+	testutil.IsTrue(t, found)
+	expired := start.Add(idempotency.TTL + time.Second)
+	_, found = find(t, ctx, s, results, expired, first.Key)
+	testutil.IsFalse(t, found)
+
+	// The expired key can be used again; saving prunes every expired result.
+	again := first
+	again.Fingerprint = "fingerprint-again"
+	at(t, ctx, s, expired, func(c *middleware.Context) error { return results.SaveIdempotent(c, again) })
+	got, found := find(t, ctx, s, results, expired, first.Key)
+	testutil.IsTrue(t, found)
+	testutil.Equals(t, got.Fingerprint, "fingerprint-again")
+	_, found = find(t, ctx, s, results, start, other.Key)
+	testutil.IsFalse(t, found)
+}
@@ -22,6 +22,10 @@ func EventType(name string) slog.Attr {
 	return slog.String("event_type", name)
 }
 
+func CorrelationID(id string) slog.Attr {
+	return slog.String("correlation_id", id)
+}
+
 func Err(err error) slog.Attr {
 	if err == nil {
 		return slog.String("error", "")
@@ -18,7 +18,7 @@ func AuthorizeEntityQuery[Req any, Out CedarEntity](action cedar.EntityUID, next
 		if err != nil {
 			return zero, err
 		}
-		if err := authz.AuthorizeWithEntity(ctx.Principal(), action, out.CedarEntity()); err != nil {
+		if err := authz.AuthorizeEntity(ctx, ctx.Principal(), action, out.CedarEntity()); err != nil {
 			return zero, err
 		}
 		return out, nil
@@ -35,7 +35,7 @@ type CommandHandler[In CedarEntity, Out CedarEntity] func(*Context, In) (Out, er
 func AuthorizeCommand[In CedarEntity, Out CedarEntity](action cedar.EntityUID, next CommandHandler[In, Out]) CommandHandler[In, Out] {
 	return func(ctx *Context, in In) (Out, error) {
 		var zero Out
-		if err := authz.AuthorizeWithEntity(ctx.Principal(), action, in.CedarEntity()); err != nil {
+		if err := authz.AuthorizeEntity(ctx, ctx.Principal(), action, in.CedarEntity()); err != nil {
 			return zero, err
 		}
 
@@ -43,7 +43,7 @@ func AuthorizeCommand[In CedarEntity, Out CedarEntity](action cedar.EntityUID, n
 		if err != nil {
 			return zero, err
 		}
-		if err := authz.AuthorizeWithEntity(ctx.Principal(), action, out.CedarEntity()); err != nil {
+		if err := authz.AuthorizeEntity(ctx, ctx.Principal(), action, out.CedarEntity()); err != nil {
 			return zero, err
 		}
 		return out, nil
@@ -1,38 +1,69 @@
 package middleware
 
 import (
+	"time"
+
 	middlewareevents "github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
 )
 
 type PipelineConfig struct {
-	Store          *store.Store
-	Dispatcher     EventDispatcher
+	Store      *store.Store
+	Dispatcher EventDispatcher
+	// Publisher stores integration events for external delivery; nil
+	// publishes nothing.
+	Publisher EventPublisher
+	// Events persists every dispatched domain event; nil keeps none.
+	Events EventRecorder
+	// Changes is told about each command after it commits; nil tells no one.
+	Changes ChangeNotifier
+	// Idempotency stores the results of commands run under idempotency keys;
+	// nil rejects such commands.
+	Idempotency    IdempotencyStore
 	Metrics        telemetry.Metrics
 	RecordActivity func(*Context, middlewareevents.Activity) error
+	// ActivityID names each command's activity up front; nil leaves naming to
+	// RecordActivity.
+	ActivityID func() string
+	// Clock stamps each operation's authorization request; nil uses time.Now.
+	Clock func() time.Time
+	// Redact hides sensitive fields in the changes recorded with each command
+	// activity; nil records every value.
+	Redact middlewareevents.Redactor
 }
 
 type Pipeline struct {
 	query   *Chain
 	command *Chain
+	redact  middlewareevents.Redactor
 }
 
 func NewPipeline(config PipelineConfig) *Pipeline {
 	return &Pipeline{
 		query: NewChain(
+			Tracing(),
+			StampRequest(config.Clock),
 			SerializeTransaction(),
 			Logging(),
 			Metrics(config.Metrics),
 		),
 		command: NewChain(
+			Tracing(),
+			StampRequest(config.Clock),
 			SerializeTransaction(),
 			Logging(),
 			Metrics(config.Metrics),
-			TrackActivity(config.Store, config.RecordActivity),
+			TrackActivity(config.Store, config.RecordActivity, config.ActivityID),
 			UnitOfWork(config.Store),
-			recordSuccessfulActivity(config.RecordActivity),
+			CaptureDryRun(),
+			NotifyChanges(config.Changes),
+			recordSuccessfulActivity(config.Store, config.RecordActivity),
+			Idempotency(config.Idempotency),
+			RecordEvents(config.Events),
+			PublishEvents(config.Publisher),
 			DispatchEvents(config.Dispatcher),
 		),
+		redact: config.Redact,
 	}
 }
@@ -2,23 +2,26 @@ package middleware_test
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"log/slog"
 	"maps"
-	"path/filepath"
+	"os"
 	"testing"
 
 	drinksauthz "github.com/TheFellow/go-modular-monolith/app/domains/drinks/authz"
 	ingredientauthz "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/authz"
 	"github.com/TheFellow/go-modular-monolith/pkg/authn"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/idempotency"
 	"github.com/TheFellow/go-modular-monolith/pkg/log"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
 	middlewareevents "github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
+	"github.com/TheFellow/go-modular-monolith/pkg/seal"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/teststore"
 	cedar "github.com/cedar-policy/cedar-go"
-	"github.com/mjl-/bstore"
 )
 
 type testEntity struct {
@@ -43,6 +46,14 @@ func (e testEntity) CedarEntity() cedar.Entity {
 				attrs[name] = cedar.String("")
 			}
 		}
+		for _, name := range []cedar.String{
+			drinksauthz.DrinkCreatedByAttr,
+			drinksauthz.DrinkOwnerAttr,
+		} {
+			if _, ok := attrs[name]; !ok {
+				attrs[name] = authn.Owner()
+			}
+		}
 	}
 	return cedar.Entity{
 		UID:        e.ID,
@@ -62,7 +73,7 @@ func newTransactionTestStore(t *testing.T) (context.Context, *store.Store) {
 
 	ctx := authn.ToContext(context.Background(), authn.Owner())
 	ctx = log.ToContext(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)))
-	s, err := store.Open(ctx, filepath.Join(t.TempDir(), "middleware.test.db"))
+	s, err := store.Open(ctx, teststore.Path(t, "middleware.test"))
 	testutil.Ok(t, err)
 	s.Register(ctx, transactionProbe{})
 	t.Cleanup(func() { testutil.Ok(t, s.Close()) })
@@ -70,7 +81,7 @@ func newTransactionTestStore(t *testing.T) (context.Context, *store.Store) {
 }
 
 func insertTransactionProbe(ctx store.Context, kind string) error {
-	return store.Write(ctx, func(tx *bstore.Tx) error {
+	return store.Write(ctx, func(tx *store.Tx) error {
 		return tx.Insert(&transactionProbe{Kind: kind})
 	})
 }
@@ -79,8 +90,8 @@ func transactionProbeKinds(t *testing.T, ctx context.Context, s *store.Store) []
 	t.Helper()
 
 	var kinds []string
-	err := s.Read(ctx, func(tx *bstore.Tx) error {
-		rows, err := bstore.QueryTx[transactionProbe](tx).List()
+	err := s.Read(ctx, func(tx *store.Tx) error {
+		rows, err := store.QueryTx[transactionProbe](tx).List()
 		if err != nil {
 			return err
 		}
@@ -179,7 +190,7 @@ func TestRunCommand_UsesCallerTransactionForBusinessAndSuccessActivity(t *testin
 		}
 	})
 
-	var recorderTx *bstore.Tx
+	var recorderTx *store.Tx
 	pipeline := middleware.NewPipeline(middleware.PipelineConfig{
 		Store: s,
 		RecordActivity: func(ctx *middleware.Context, _ middlewareevents.Activity) error {
@@ -206,7 +217,7 @@ func TestRunCommand_UsesCallerTransactionForBusinessAndSuccessActivity(t *testin
 	)
 	testutil.Ok(t, err)
 	testutil.IsTrue(t, recorderTx == tx)
-	rows, err := bstore.QueryTx[transactionProbe](tx).List()
+	rows, err := store.QueryTx[transactionProbe](tx).List()
 	testutil.Ok(t, err)
 	testutil.Equals(t, len(rows), 2)
 	testutil.Equals(t, []string{rows[0].Kind, rows[1].Kind}, []string{"business-write", "success-audit"})
@@ -374,7 +385,7 @@ func TestRunCommand_LoaderRunsInTransaction(t *testing.T) {
 		RecordActivity: func(*middleware.Context, middlewareevents.Activity) error { return nil },
 	})
 
-	var gotTx *bstore.Tx
+	var gotTx *store.Tx
 	_, err := middleware.RunCommand(pipeline, ctx, middleware.CommandSpec[testEntity, testEntity]{
 		Action: drinksauthz.ActionCreate,
 		Load: func(ctx *middleware.Context) (testEntity, error) {
@@ -390,3 +401,275 @@ func TestRunCommand_LoaderRunsInTransaction(t *testing.T) {
 	testutil.Ok(t, err)
 	testutil.NotNil(t, gotTx)
 }
+
+func TestRunCommand_DryRunPreviewsThenRollsBack(t *testing.T) {
+	t.Parallel()
+
+	ctx, s := newTransactionTestStore(t)
+	recorded := 0
+	pipeline := middleware.NewPipeline(middleware.PipelineConfig{
+		Store: s,
+		RecordActivity: func(ctx *middleware.Context, _ middlewareevents.Activity) error {
+			recorded++
+			return insertTransactionProbe(ctx, "success-audit")
+		},
+	})
+	resource := testEntity{ID: cedar.NewEntityUID(drinksauthz.DrinkType, cedar.String("dry-run"))}
+	touched := cedar.NewEntityUID(ingredientauthz.IngredientType, cedar.String("touched"))
+
+	dryCtx, preview := middleware.NewContext(ctx).WithDryRun()
+	out, err := middleware.RunCommand(pipeline, dryCtx, middleware.CommandSpec[testEntity, testEntity]{
+		Action: drinksauthz.ActionCreate,
+		Load: func(*middleware.Context) (testEntity, error) {
+			return resource, nil
+		},
+		Handle: func(ctx *middleware.Context, in testEntity) (testEntity, error) {
+			ctx.AddEvent(testEvent{Name: "previewed"})
+			ctx.TouchEntity(touched)
+			return in, insertTransactionProbe(ctx, "business-write")
+		},
+	})
+	testutil.Ok(t, err)
+	testutil.Equals(t, out.ID, resource.ID)
+	testutil.Equals(t, recorded, 1)
+	testutil.Equals(t, transactionProbeKinds(t, ctx, s), []string(nil))
+
+	commands := preview.Commands()
+	testutil.Equals(t, len(commands), 1)
+	testutil.Equals(t, commands[0].Action, drinksauthz.ActionCreate)
+	testutil.Equals(t, commands[0].Resource, resource.ID)
+	testutil.Equals(t, commands[0].Touches, []cedar.EntityUID{touched})
+	testutil.Equals(t, commands[0].Events, []any{testEvent{Name: "previewed"}})
+}
+
+func TestRunCommand_DryRunRefusesCommittingCallerTransaction(t *testing.T) {
+	t.Parallel()
+
+	ctx, s := newTransactionTestStore(t)
+	tx, err := s.Begin(ctx, true)
+	testutil.Ok(t, err)
+	t.Cleanup(func() { testutil.Ok(t, s.Rollback(tx)) })
+	pipeline := middleware.NewPipeline(middleware.PipelineConfig{
+		Store:          s,
+		RecordActivity: func(*middleware.Context, middlewareevents.Activity) error { return nil },
+	})
+
+	dryCtx, preview := middleware.NewContext(ctx).WithTransaction(tx).WithDryRun()
+	_, err = middleware.RunCommand(pipeline, dryCtx, middleware.CommandSpec[testEntity, testEntity]{
+		Action: drinksauthz.ActionCreate,
+		Load: func(*middleware.Context) (testEntity, error) {
+			return testEntity{ID: cedar.NewEntityUID(drinksauthz.DrinkType, cedar.String("caller"))}, nil
+		},
+		Handle: func(ctx *middleware.Context, in testEntity) (testEntity, error) {
+			return in, insertTransactionProbe(ctx, "business-write")
+		},
+	})
+	testutil.ErrorIsFailedPrecondition(t, err)
+	testutil.Equals(t, len(preview.Commands()), 0)
+}
+
+func TestRunCommand_IdempotencyKeyReplaysStoredResult(t *testing.T) {
+	t.Parallel()
+
+	ctx, s := newTransactionTestStore(t)
+	idempotency.Register(ctx, s)
+	var recorded []middlewareevents.Activity
+	pipeline := middleware.NewPipeline(middleware.PipelineConfig{
+		Store:       s,
+		Idempotency: idempotency.New(s),
+		RecordActivity: func(_ *middleware.Context, activity middlewareevents.Activity) error {
+			recorded = append(recorded, activity)
+			return nil
+		},
+	})
+	handled := 0
+	place := func(ctx *middleware.Context, name string) (testEntity, error) {
+		return middleware.RunCommand(pipeline, ctx, middleware.CommandSpec[testEntity, testEntity]{
+			Action:  drinksauthz.ActionCreate,
+			Request: name,
+			Load: func(*middleware.Context) (testEntity, error) {
+				return testEntity{ID: cedar.NewEntityUID(drinksauthz.DrinkType, "")}, nil
+			},
+			Handle: func(ctx *middleware.Context, _ testEntity) (testEntity, error) {
+				handled++
+				id := cedar.NewEntityUID(drinksauthz.DrinkType, cedar.String(fmt.Sprintf("drink-%d", handled)))
+				return testEntity{ID: id}, insertTransactionProbe(ctx, name)
+			},
+		})
+	}
+
+	first, err := middleware.NewContext(ctx).WithIdempotencyKey("retry-1")
+	testutil.Ok(t, err)
+	created, err := place(first, "margarita")
+	testutil.Ok(t, err)
+
+	retry, err := middleware.NewContext(ctx).WithIdempotencyKey("retry-1")
+	testutil.Ok(t, err)
+	replayed, err := place(retry, "margarita")
+	testutil.Ok(t, err)
+	testutil.Equals(t, replayed.ID, created.ID)
+	testutil.Equals(t, handled, 1)
+	testutil.Equals(t, transactionProbeKinds(t, ctx, s), []string{"margarita"})
+	testutil.Equals(t, len(recorded), 2)
+	testutil.IsTrue(t, recorded[1].Success)
+	testutil.Equals(t, recorded[1].Resource, recorded[0].Resource)
+
+	mismatch, err := middleware.NewContext(ctx).WithIdempotencyKey("retry-1")
+	testutil.Ok(t, err)
+	_, err = place(mismatch, "daiquiri")
+	testutil.ErrorIsConflict(t, err)
+	testutil.Equals(t, handled, 1)
+
+	_, err = middleware.NewContext(ctx).WithIdempotencyKey(" ")
+	testutil.ErrorIsInvalid(t, err)
+}
+
+// categorizedDrink is a command result gob can encode for replay.
+type categorizedDrink struct {
+	ID       cedar.String
+	Category cedar.String
+}
+
+func (d categorizedDrink) CedarEntity() cedar.Entity {
+	return testEntity{
+		ID:         cedar.NewEntityUID(drinksauthz.DrinkType, d.ID),
+		Attributes: cedar.RecordMap{drinksauthz.DrinkCategoryAttr: d.Category},
+	}.CedarEntity()
+}
+
+// memoryIdempotency keeps results in a map so a test can alter what a replay
+// finds.
+type memoryIdempotency map[string]middleware.IdempotentResult
+
+func (m memoryIdempotency) FindIdempotent(_ *middleware.Context, key string) (middleware.IdempotentResult, bool, error) {
+	result, ok := m[key]
+	return result, ok, nil
+}
+
+func (m memoryIdempotency) SaveIdempotent(_ *middleware.Context, result middleware.IdempotentResult) error {
+	m[result.Key] = result
+	return nil
+}
+
+func TestRunCommand_IdempotentReplayIsAuthorizedAgainstStoredResult(t *testing.T) {
+	t.Parallel()
+
+	ctx, s := newTransactionTestStore(t)
+	ctx = authn.ToContext(ctx, authn.Sommelier())
+	results := memoryIdempotency{}
+	pipeline := middleware.NewPipeline(middleware.PipelineConfig{
+		Store:          s,
+		Idempotency:    results,
+		RecordActivity: func(*middleware.Context, middlewareevents.Activity) error { return nil },
+	})
+	handled := 0
+	create := func() (categorizedDrink, error) {
+		keyed, err := middleware.NewContext(ctx).WithIdempotencyKey("wine-1")
+		testutil.Ok(t, err)
+		return middleware.RunCommand(pipeline, keyed, middleware.CommandSpec[categorizedDrink, categorizedDrink]{
+			Action:  drinksauthz.ActionCreate,
+			Request: "claret",
+			Load: func(*middleware.Context) (categorizedDrink, error) {
+				return categorizedDrink{Category: "wine"}, nil
+			},
+			Handle: func(_ *middleware.Context, in categorizedDrink) (categorizedDrink, error) {
+				handled++
+				in.ID = "claret"
+				return in, nil
+			},
+		})
+	}
+
+	_, err := create()
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(results), 1)
+	for key, result := range results {
+		category, _ := result.Entity.Attributes.Get(drinksauthz.DrinkCategoryAttr)
+		testutil.Equals(t, category, cedar.Value(cedar.String("wine")))
+		_, err = create()
+		testutil.Ok(t, err)
+
+		// A result the sommelier may no longer act on is not replayed.
+		result.Entity = categorizedDrink{ID: result.Entity.UID.ID, Category: "cocktail"}.CedarEntity()
+		results[key] = result
+	}
+	_, err = create()
+	testutil.ErrorIsPermission(t, err)
+	testutil.Equals(t, handled, 1)
+}
+
+func TestRunCommand_IdempotencyKeyRequiresReplayableCommand(t *testing.T) {
+	t.Parallel()
+
+	ctx, s := newTransactionTestStore(t)
+	pipeline := middleware.NewPipeline(middleware.PipelineConfig{
+		Store:          s,
+		Idempotency:    idempotency.New(s),
+		RecordActivity: func(*middleware.Context, middlewareevents.Activity) error { return nil },
+	})
+	keyed, err := middleware.NewContext(ctx).WithIdempotencyKey("export")
+	testutil.Ok(t, err)
+
+	_, err = middleware.RunCommand(pipeline, keyed, middleware.CommandSpec[testEntity, testEntity]{
+		Action: drinksauthz.ActionCreate,
+		Load: func(*middleware.Context) (testEntity, error) {
+			return testEntity{ID: cedar.NewEntityUID(drinksauthz.DrinkType, "streamed")}, nil
+		},
+		Handle: func(ctx *middleware.Context, in testEntity) (testEntity, error) {
+			return in, insertTransactionProbe(ctx, "business-write")
+		},
+	})
+	testutil.ErrorIsFailedPrecondition(t, err)
+	testutil.Equals(t, transactionProbeKinds(t, ctx, s), []string(nil))
+}
+
+func TestRunCommand_WarnsWhenCommitsCannotBeSealed(t *testing.T) {
+	t.Parallel()
+
+	ctx := authn.ToContext(context.Background(), authn.Owner())
+	key, err := seal.NewKey("house key")
+	testutil.Ok(t, err)
+	path := teststore.Path(t, "middleware.sealed")
+	s, err := store.Open(ctx, path, store.Sealed(key))
+	testutil.Ok(t, err)
+	s.Register(ctx, transactionProbe{})
+
+	// A directory in the database's place makes every seal fail.
+	testutil.Ok(t, os.Rename(path, path+".aside"))
+	testutil.Ok(t, os.Mkdir(path, 0o700))
+	testutil.IsTrue(t, s.Flush(ctx) != nil)
+
+	logBuf := &testLogBuffer{}
+	logger := slog.New(slog.NewJSONHandler(logBuf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	pipeline := middleware.NewPipeline(middleware.PipelineConfig{
+		Store:          s,
+		RecordActivity: func(*middleware.Context, middlewareevents.Activity) error { return nil },
+	})
+	_, err = middleware.RunCommand(pipeline, middleware.NewContext(log.ToContext(ctx, logger)), middleware.CommandSpec[testEntity, testEntity]{
+		Action: drinksauthz.ActionCreate,
+		Load: func(*middleware.Context) (testEntity, error) {
+			return testEntity{ID: cedar.NewEntityUID(drinksauthz.DrinkType, cedar.String("unsealed"))}, nil
+		},
+		Handle: func(ctx *middleware.Context, in testEntity) (testEntity, error) {
+			return in, insertTransactionProbe(ctx, "business-write")
+		},
+	})
+	testutil.Ok(t, err)
+	_, fields := findTestLogEntry(t, logBuf, "committed changes are not yet sealed")
+	testutil.Equals(t, testutil.Cast[string](t, fields["path"]), path)
+
+	testutil.Ok(t, os.Remove(path))
+	testutil.Ok(t, os.Rename(path+".aside", path))
+	testutil.Ok(t, s.Close())
+	testutil.Equals(t, transactionProbeKinds(t, ctx, reopenSealed(t, ctx, path, key)), []string{"business-write"})
+}
+
+func reopenSealed(t *testing.T, ctx context.Context, path string, key *seal.Key) *store.Store {
+	t.Helper()
+
+	s, err := store.Open(ctx, path, store.Sealed(key))
+	testutil.Ok(t, err)
+	s.Register(ctx, transactionProbe{})
+	t.Cleanup(func() { testutil.Ok(t, s.Close()) })
+	return s
+}
@@ -2,40 +2,68 @@ package middleware
 
 import (
 	"context"
+	"log/slog"
 
 	"github.com/TheFellow/go-modular-monolith/pkg/authn"
 	"github.com/TheFellow/go-modular-monolith/pkg/log"
 	middlewareevents "github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
 	cedar "github.com/cedar-policy/cedar-go"
-	"github.com/mjl-/bstore"
 )
 
 type Context struct {
 	context.Context
 	events    []any
 	principal cedar.EntityUID
-	tx        *bstore.Tx
+	tx        *store.Tx
 	activity  *middlewareevents.Activity
+
+	correlationID string
+	// logger is the parent's logger before the context added its actor and
+	// correlation attributes, so WithNewCorrelationID can replace them.
+	logger *slog.Logger
 }
 
+// NewContext starts an operation context for the principal in parent. The
+// context's correlation ID comes from parent when it is a Context or carries
+// one from WithCorrelationID, and is generated otherwise. Every log record
+// written under the context, its audit entry, and its recorded events carry
+// the ID.
 func NewContext(parent context.Context) *Context {
-	var tx *bstore.Tx
+	var tx *store.Tx
+	logger := log.FromContext(parent)
 	if parentCtx, ok := parent.(*Context); ok {
-		tx = parentCtx.tx
+		tx, logger = parentCtx.tx, parentCtx.logger
 	}
 	principal := authn.FromContext(parent)
+	parent, correlationID := withCorrelation(parent)
 	parent = log.ToContext(parent, log.FromContext(parent).With(log.Actor(principal)))
 	c := &Context{
-		Context:   parent,
-		events:    make([]any, 0, 4),
-		principal: principal,
-		tx:        tx,
+		Context:       parent,
+		events:        make([]any, 0, 4),
+		principal:     principal,
+		tx:            tx,
+		correlationID: correlationID,
+		logger:        logger,
 	}
 
 	return c
 }
 
-func (c *Context) WithTransaction(tx *bstore.Tx) *Context {
+// WithNewCorrelationID derives a context whose operations share a fresh
+// correlation ID instead of c's, so work such as a batch can be traced as one
+// unit apart from whatever else the caller does under c.
+func (c *Context) WithNewCorrelationID() *Context {
+	id := NewCorrelationID()
+	derived := *c
+	parent := context.WithValue(c.Context, correlationKey{}, correlation{id: id, logged: true})
+	derived.Context = log.ToContext(parent, c.logger.With(log.CorrelationID(id), log.Actor(c.principal)))
+	derived.events = make([]any, 0, 4)
+	derived.correlationID = id
+	return &derived
+}
+
+func (c *Context) WithTransaction(tx *store.Tx) *Context {
 	derived := *c
 	derived.Context = c.Context
 	derived.events = make([]any, 0, 4)
@@ -71,7 +99,16 @@ func (c *Context) Principal() cedar.EntityUID {
 	return authn.Anonymous()
 }
 
-func (c *Context) Transaction() (*bstore.Tx, bool) {
+// CorrelationID identifies the operation across logs, audit entries, and
+// recorded events.
+func (c *Context) CorrelationID() string {
+	if c == nil {
+		return ""
+	}
+	return c.correlationID
+}
+
+func (c *Context) Transaction() (*store.Tx, bool) {
 	if c == nil || c.tx == nil {
 		return nil, false
 	}
@@ -98,7 +135,13 @@ func NewHandlerContext(ctx *Context) *HandlerContext {
 	return &HandlerContext{Context: ctx.Context, ctx: ctx}
 }
 
-func (h *HandlerContext) Transaction() (*bstore.Tx, bool) {
+// WithContext returns a handler context that runs under ctx, typically one
+// carrying the handler's span, while sharing h's operation state.
+func (h *HandlerContext) WithContext(ctx context.Context) *HandlerContext {
+	return &HandlerContext{Context: ctx, ctx: h.ctx}
+}
+
+func (h *HandlerContext) Transaction() (*store.Tx, bool) {
 	return h.ctx.Transaction()
 }
 
@@ -106,6 +149,10 @@ func (h *HandlerContext) TouchEntity(uid cedar.EntityUID) {
 	h.ctx.TouchEntity(uid)
 }
 
+func (h *HandlerContext) CorrelationID() string {
+	return h.ctx.CorrelationID()
+}
+
 func (h *HandlerContext) Principal() cedar.EntityUID {
 	return h.ctx.Principal()
 }
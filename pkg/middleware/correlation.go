@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/log"
+)
+
+type correlationKey struct{}
+
+// correlation is the context value behind WithCorrelationID. logged records
+// that the context's logger already carries the ID, so nested contexts do not
+// repeat the attribute.
+type correlation struct {
+	id     string
+	logged bool
+}
+
+// WithCorrelationID asks the next NewContext built from ctx to adopt id rather
+// than generate one. Callers use it to carry an ID from outside the process,
+// such as a script tying several CLI invocations together. An empty id is
+// ignored.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, correlationKey{}, correlation{id: id})
+}
+
+// NewCorrelationID returns a random 128-bit ID in lowercase hex.
+func NewCorrelationID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// withCorrelation returns parent carrying its correlation ID, generating one
+// when parent has none, with the ID attached to parent's logger.
+func withCorrelation(parent context.Context) (context.Context, string) {
+	c, _ := parent.Value(correlationKey{}).(correlation)
+	if c.id == "" {
+		c = correlation{id: NewCorrelationID()}
+	}
+	if c.logged {
+		return parent, c.id
+	}
+	parent = log.ToContext(parent, log.FromContext(parent).With(log.CorrelationID(c.id)))
+	return context.WithValue(parent, correlationKey{}, correlation{id: c.id, logged: true}), c.id
+}
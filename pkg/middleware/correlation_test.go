@@ -0,0 +1,38 @@
+package middleware_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/log"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestNewContext_GeneratesCorrelationIDAndNestedContextsKeepIt(t *testing.T) {
+	t.Parallel()
+
+	logBuf := &testLogBuffer{}
+	first := newTestContext(logBuf, telemetry.Memory())
+	second := newTestContext(&testLogBuffer{}, telemetry.Memory())
+	testutil.Equals(t, len(first.CorrelationID()), 32)
+	testutil.IsTrue(t, first.CorrelationID() != second.CorrelationID())
+
+	nested := middleware.NewContext(first)
+	testutil.Equals(t, nested.CorrelationID(), first.CorrelationID())
+	log.FromContext(nested).Info("nested")
+	testutil.Equals(t, strings.Count(logBuf.String(), `"correlation_id":"`+first.CorrelationID()+`"`), 1)
+}
+
+func TestNewContext_AdoptsCallerCorrelationID(t *testing.T) {
+	t.Parallel()
+
+	logBuf := &testLogBuffer{}
+	base := newTestContext(logBuf, telemetry.Memory())
+	ctx := middleware.NewContext(middleware.WithCorrelationID(base.Context, "caller-supplied"))
+	testutil.Equals(t, ctx.CorrelationID(), "caller-supplied")
+
+	log.FromContext(ctx).Info("adopted")
+	testutil.StringContains(t, logBuf.String(), `"correlation_id":"caller-supplied"`)
+}
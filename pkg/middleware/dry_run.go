@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	cedar "github.com/cedar-policy/cedar-go"
+
+	middlewareevents "github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
+)
+
+// Preview collects what the commands run under a dry-run context would have
+// done.
+type Preview struct {
+	mu       sync.Mutex
+	commands []PreviewedCommand
+}
+
+// PreviewedCommand is one successful command as it stood just before its
+// transaction rolled back.
+type PreviewedCommand struct {
+	Action   cedar.EntityUID
+	Resource cedar.EntityUID
+	Changes  []middlewareevents.Change
+	Touches  []cedar.EntityUID
+	Events   []any
+}
+
+// Commands returns the previewed commands in the order they ran.
+func (p *Preview) Commands() []PreviewedCommand {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.commands)
+}
+
+func (p *Preview) add(command PreviewedCommand) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.commands = append(p.commands, command)
+}
+
+type previewKey struct{}
+
+// WithDryRun derives a context whose commands run completely, including
+// event dispatch and result authorization, and then roll back. Each
+// successful command is added to the returned preview. Transactions the
+// caller begins for the derived context must use it so they roll back too.
+func (c *Context) WithDryRun() (*Context, *Preview) {
+	preview := &Preview{}
+	derived := *c
+	derived.Context = context.WithValue(store.RollbackOnly(c.Context), previewKey{}, preview)
+	return &derived, preview
+}
+
+// PreviewFromContext returns the preview of a dry-run context.
+func PreviewFromContext(ctx context.Context) (*Preview, bool) {
+	preview, ok := ctx.Value(previewKey{}).(*Preview)
+	return preview, ok
+}
+
+// CaptureDryRun adds each successful command run under WithDryRun to its
+// preview. It runs inside the unit of work, where the command's events and
+// completed activity are still at hand, and refuses a caller-owned
+// transaction that would commit.
+func CaptureDryRun() Middleware {
+	return func(ctx *Context, op Operation, next Next) error {
+		if op.Kind != OperationKindCommand {
+			return next(ctx)
+		}
+		preview, ok := PreviewFromContext(ctx)
+		if !ok {
+			return next(ctx)
+		}
+		if tx, ok := ctx.Transaction(); !ok || tx == nil || !store.RollsBack(tx) {
+			return errors.FailedPreconditionf("dry run requires a transaction opened with its context")
+		}
+
+		if err := next(ctx); err != nil {
+			return err
+		}
+
+		command := PreviewedCommand{Action: op.Action, Events: slices.Clone(ctx.Events())}
+		if activity, ok := ctx.Activity(); ok {
+			command.Resource = activity.Resource
+			command.Changes = slices.Clone(activity.Changes)
+			command.Touches = slices.Clone(activity.Touches)
+		}
+		preview.add(command)
+		return nil
+	}
+}
@@ -8,14 +8,24 @@ import (
 )
 
 type Activity struct {
+	// ID names the activity before it is recorded, so records written during
+	// the command, such as its events, can refer to it. Empty lets the
+	// recorder choose.
+	ID string
+
 	Action    cedar.EntityUID
 	Resource  cedar.EntityUID
 	Principal cedar.EntityUID
 
+	// CorrelationID ties the activity to the log records and events of the
+	// operation that ran it.
+	CorrelationID string
+
 	StartedAt   time.Time
 	CompletedAt time.Time
 
 	Touches []cedar.EntityUID
+	Changes []Change
 
 	Success bool
 	Error   string
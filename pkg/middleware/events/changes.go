@@ -0,0 +1,159 @@
+package events
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Redacted replaces the values of fields a Redactor hides.
+const Redacted = "[redacted]"
+
+// Change is one field whose value differs between a command's loaded input and
+// its result. Paths use Go field names, indexes, and map keys, for example
+// Recipe.Ingredients[0].Amount. An empty Before marks a value the result added;
+// an empty After marks one it removed or cleared.
+type Change struct {
+	Path   string
+	Before string
+	After  string
+}
+
+// Redactor reports whether the value at path should be hidden from audit
+// history. The change is still recorded so the history shows that the field
+// changed, but both values become Redacted.
+type Redactor func(path string) bool
+
+// Snapshot flattens v into rendered leaf values keyed by path. Values that
+// implement fmt.Stringer, such as entity IDs and measurements, are leaves;
+// optional values are unwrapped and absent ones omitted. Fields tagged
+// `changes:"-"` describe the operation rather than the entity and are skipped.
+func Snapshot(v any) map[string]string {
+	out := map[string]string{}
+	flatten(out, "", reflect.ValueOf(v), 0)
+	return out
+}
+
+// Diff compares two snapshots and returns their changes ordered by path.
+func Diff(before, after map[string]string, redact Redactor) []Change {
+	paths := make([]string, 0, len(before)+len(after))
+	for path, value := range before {
+		if after[path] != value {
+			paths = append(paths, path)
+		}
+	}
+	for path, value := range after {
+		if _, ok := before[path]; !ok && value != "" {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+
+	changes := make([]Change, 0, len(paths))
+	for _, path := range paths {
+		change := Change{Path: path, Before: before[path], After: after[path]}
+		if redact != nil && redact(path) {
+			change.Before, change.After = redactValue(change.Before), redactValue(change.After)
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func redactValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	return Redacted
+}
+
+// maxSnapshotDepth bounds recursion through self-referential values.
+const maxSnapshotDepth = 12
+
+var (
+	stringerType = reflect.TypeFor[fmt.Stringer]()
+	timeType     = reflect.TypeFor[time.Time]()
+)
+
+func flatten(out map[string]string, path string, v reflect.Value, depth int) {
+	if !v.IsValid() || depth > maxSnapshotDepth {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+	}
+	if v.Type() == timeType {
+		if t := v.Interface().(time.Time); !t.IsZero() {
+			out[path] = t.UTC().Format(time.RFC3339Nano)
+		}
+		return
+	}
+	if inner, ok := unwrapOptional(v); ok {
+		flatten(out, path, inner, depth+1)
+		return
+	}
+	if v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface && v.Type().Implements(stringerType) {
+		if s := v.Interface().(fmt.Stringer).String(); s != "" {
+			out[path] = s
+		}
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		flatten(out, path, v.Elem(), depth+1)
+	case reflect.Struct:
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+			if field.IsExported() && field.Tag.Get("changes") != "-" {
+				flatten(out, join(path, field.Name), v.Field(i), depth+1)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			flatten(out, path+"["+strconv.Itoa(i)+"]", v.Index(i), depth+1)
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return cmp.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+		})
+		for _, key := range keys {
+			flatten(out, path+"["+fmt.Sprint(key.Interface())+"]", v.MapIndex(key), depth+1)
+		}
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+	default:
+		out[path] = fmt.Sprint(v.Interface())
+	}
+}
+
+// unwrapOptional recognizes optional.Value-like types by their
+// Unwrap() (T, bool) method without importing them.
+func unwrapOptional(v reflect.Value) (reflect.Value, bool) {
+	method := v.MethodByName("Unwrap")
+	if !method.IsValid() {
+		return reflect.Value{}, false
+	}
+	typ := method.Type()
+	if typ.NumIn() != 0 || typ.NumOut() != 2 || typ.Out(1).Kind() != reflect.Bool {
+		return reflect.Value{}, false
+	}
+	results := method.Call(nil)
+	if !results[1].Bool() {
+		return reflect.Value{}, true
+	}
+	return results[0], true
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,80 @@
+package events_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
+	"github.com/TheFellow/go-modular-monolith/pkg/optional"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+type label string
+
+func (l label) String() string { return strings.ToUpper(string(l)) }
+
+type line struct {
+	Name   label
+	Amount float64
+}
+
+type record struct {
+	Title    string
+	Secret   string
+	Lines    []line
+	Tags     map[string]bool
+	Note     optional.Value[string]
+	Archived time.Time
+	hidden   string
+}
+
+func TestSnapshot_FlattensFieldsIndexesAndKeys(t *testing.T) {
+	t.Parallel()
+	at := time.Date(2026, 10, 18, 21, 30, 0, 0, time.FixedZone("EDT", -4*60*60))
+
+	got := events.Snapshot(&record{
+		Title:    "Martini",
+		Lines:    []line{{Name: "gin", Amount: 2}},
+		Tags:     map[string]bool{"dry": true},
+		Note:     optional.Some("stirred"),
+		Archived: at,
+		hidden:   "ignored",
+	})
+
+	testutil.Equals(t, got, map[string]string{
+		"Title":           "Martini",
+		"Secret":          "",
+		"Lines[0].Name":   "GIN",
+		"Lines[0].Amount": "2",
+		"Tags[dry]":       "true",
+		"Note":            "stirred",
+		"Archived":        "2026-10-19T01:30:00Z",
+	})
+}
+
+func TestDiff_ReportsChangedAddedAndRemovedPaths(t *testing.T) {
+	t.Parallel()
+	before := events.Snapshot(record{Title: "Martini", Lines: []line{{Name: "gin", Amount: 2}, {Name: "vermouth", Amount: 1}}})
+	after := events.Snapshot(record{Title: "Martini", Lines: []line{{Name: "gin", Amount: 2.5}}, Note: optional.Some("dry")})
+
+	testutil.Equals(t, events.Diff(before, after, nil), []events.Change{
+		{Path: "Lines[0].Amount", Before: "2", After: "2.5"},
+		{Path: "Lines[1].Amount", Before: "1"},
+		{Path: "Lines[1].Name", Before: "VERMOUTH"},
+		{Path: "Note", After: "dry"},
+	})
+}
+
+func TestDiff_RedactsMatchingPaths(t *testing.T) {
+	t.Parallel()
+	before := events.Snapshot(record{Title: "Martini", Secret: "old"})
+	after := events.Snapshot(record{Title: "Dry Martini", Secret: "new"})
+
+	got := events.Diff(before, after, func(path string) bool { return path == "Secret" })
+
+	testutil.Equals(t, got, []events.Change{
+		{Path: "Secret", Before: events.Redacted, After: events.Redacted},
+		{Path: "Title", Before: "Martini", After: "Dry Martini"},
+	})
+}
@@ -0,0 +1,51 @@
+package events
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"strings"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+)
+
+// Name identifies an event's type as "<domain>.<Type>", for example
+// "orders.OrderPlaced". Events declared outside an events package are named
+// by their own package.
+func Name(event any) string {
+	t := reflect.TypeOf(event)
+	if t == nil {
+		return ""
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	parts := strings.Split(t.PkgPath(), "/")
+	pkg := parts[len(parts)-1]
+	if pkg == "events" && len(parts) > 1 {
+		pkg = parts[len(parts)-2]
+	}
+	if pkg == "" {
+		return t.Name()
+	}
+	return pkg + "." + t.Name()
+}
+
+// Encode serializes event with encoding/gob, which keeps every exported field
+// and the concrete types behind interface fields. Decode with the event's
+// own type.
+func Encode(event any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(event); err != nil {
+		return nil, errors.Internalf("encode event %s: %w", Name(event), err)
+	}
+	return buf.Bytes(), nil
+}
+
+func Decode[T any](data []byte) (T, error) {
+	var event T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&event); err != nil {
+		return event, errors.Internalf("decode event %s: %w", Name(event), err)
+	}
+	return event, nil
+}
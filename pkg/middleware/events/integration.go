@@ -0,0 +1,10 @@
+package events
+
+// Integration is implemented by domain events that are also published to
+// systems outside the process. Topic names the message stream and Payload
+// returns the event's JSON contract, which is kept independent of the domain
+// model's Go shape so models can change without breaking subscribers.
+type Integration interface {
+	Topic() string
+	Payload() any
+}
@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/authz"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// MaxIdempotencyKeyLength bounds the keys callers may attach to commands.
+const MaxIdempotencyKeyLength = 128
+
+// IdempotentResult is the stored outcome of a command run under an
+// idempotency key. Key is already scoped to the principal and to the
+// command's position under the caller's key. Entity is the result's Cedar
+// state, which a replay is authorized against.
+type IdempotentResult struct {
+	Key         string
+	Action      cedar.EntityUID
+	Fingerprint string
+	Resource    cedar.EntityUID
+	Entity      cedar.Entity
+	Result      []byte
+}
+
+// IdempotencyStore keeps the results of commands run under idempotency keys.
+// Both calls run inside the command's transaction. A store may forget results
+// after a while; a key retried after that runs the command again.
+type IdempotencyStore interface {
+	FindIdempotent(ctx *Context, key string) (IdempotentResult, bool, error)
+	SaveIdempotent(ctx *Context, result IdempotentResult) error
+}
+
+type idempotencyKey struct{}
+
+// idempotency numbers the commands run under one key, so an operation that
+// runs several commands, such as a batch or a tagged create, replays each of
+// them when it is retried in the same order.
+type idempotency struct {
+	key  string
+	mu   sync.Mutex
+	next int
+}
+
+func (i *idempotency) take(principal cedar.EntityUID) string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.next++
+	return fmt.Sprintf("%s %s#%d", principal, i.key, i.next)
+}
+
+// WithIdempotencyKey derives a context whose commands are remembered under
+// key. A command repeated under the same key and principal with identical
+// input returns the stored result without running again; the same key with
+// different input is a Conflict.
+func (c *Context) WithIdempotencyKey(key string) (*Context, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, errors.Invalidf("idempotency key is required")
+	}
+	if len(key) > MaxIdempotencyKeyLength {
+		return nil, errors.Invalidf("idempotency key must be at most %d bytes", MaxIdempotencyKeyLength)
+	}
+	if strings.IndexFunc(key, unicode.IsControl) >= 0 {
+		return nil, errors.Invalidf("idempotency key must not contain control characters")
+	}
+	derived := *c
+	derived.Context = context.WithValue(c.Context, idempotencyKey{}, &idempotency{key: key})
+	return &derived, nil
+}
+
+// IdempotencyKeyFromContext returns the key attached by WithIdempotencyKey.
+func IdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	state, ok := ctx.Value(idempotencyKey{}).(*idempotency)
+	if !ok {
+		return "", false
+	}
+	return state.key, true
+}
+
+// Idempotency replays the stored result of a command repeated under its
+// idempotency key. It runs inside the unit of work after the activity is
+// recorded, so a replay is audited as a success without changes and skips
+// event recording, publishing, and dispatch. A replay skips Load and Handle,
+// so the command's action is authorized again against the stored result's
+// state: a principal who has since lost the permission is refused rather than
+// handed the result. A first run saves its result in the command's own
+// transaction, so the key is remembered exactly when the command commits.
+func Idempotency(s IdempotencyStore) Middleware {
+	return func(ctx *Context, op Operation, next Next) error {
+		if op.Kind != OperationKindCommand {
+			return next(ctx)
+		}
+		state, ok := ctx.Value(idempotencyKey{}).(*idempotency)
+		if !ok {
+			return next(ctx)
+		}
+		if s == nil {
+			return errors.FailedPreconditionf("idempotency keys are not supported by this pipeline")
+		}
+		if op.Request == nil || op.Result == nil {
+			return errors.FailedPreconditionf("%s does not accept an idempotency key", op.Action)
+		}
+
+		key := state.take(ctx.Principal())
+		fingerprint, err := idempotencyFingerprint(op.Action, op.Request)
+		if err != nil {
+			return err
+		}
+		stored, found, err := s.FindIdempotent(ctx, key)
+		if err != nil {
+			return err
+		}
+		if found {
+			if stored.Fingerprint != fingerprint {
+				return errors.Conflictf("idempotency key %q was already used for a different request", state.key)
+			}
+			if err := authz.AuthorizeEntity(ctx, ctx.Principal(), op.Action, stored.Entity); err != nil {
+				return err
+			}
+			if err := gob.NewDecoder(bytes.NewReader(stored.Result)).Decode(op.Result); err != nil {
+				return errors.Internalf("decode stored result of %s: %w", op.Action, err)
+			}
+			if activity, ok := ctx.Activity(); ok {
+				activity.Resource = stored.Resource
+			}
+			return nil
+		}
+
+		if err := next(ctx); err != nil {
+			return err
+		}
+
+		var result bytes.Buffer
+		if err := gob.NewEncoder(&result).Encode(op.Result); err != nil {
+			return errors.Internalf("encode result of %s: %w", op.Action, err)
+		}
+		record := IdempotentResult{
+			Key: key, Action: op.Action, Fingerprint: fingerprint, Entity: resultEntity(op.Result), Result: result.Bytes(),
+		}
+		if activity, ok := ctx.Activity(); ok {
+			record.Resource = activity.Resource
+		}
+		return s.SaveIdempotent(ctx, record)
+	}
+}
+
+// resultEntity returns the Cedar state of the command result op.Result
+// points at.
+func resultEntity(result any) cedar.Entity {
+	v := reflect.ValueOf(result)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return cedar.Entity{}
+	}
+	v = v.Elem()
+	if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		return cedar.Entity{}
+	}
+	if entity, ok := v.Interface().(CedarEntity); ok {
+		return entity.CedarEntity()
+	}
+	return cedar.Entity{}
+}
+
+// idempotencyFingerprint identifies a command's input. Gob keeps optional
+// values and interface amounts that JSON would drop, and encodes the same
+// value to the same bytes.
+func idempotencyFingerprint(action cedar.EntityUID, request any) (string, error) {
+	var buf bytes.Buffer
+	buf.WriteString(action.String())
+	if err := gob.NewEncoder(&buf).Encode(request); err != nil {
+		return "", errors.Internalf("encode request of %s: %w", action, err)
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -1,11 +1,10 @@
 package middleware
 
 import (
-	"strings"
 	"time"
 
+	"github.com/TheFellow/go-modular-monolith/pkg/authz"
 	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
-	"github.com/cedar-policy/cedar-go"
 )
 
 type metricsCollector struct {
@@ -36,41 +35,29 @@ func newMetricsCollector(m telemetry.Metrics) *metricsCollector {
 func Metrics(metrics telemetry.Metrics) Middleware {
 	mc := newMetricsCollector(metrics)
 	return func(ctx *Context, op Operation, next Next) error {
-		actionLabel := actionLabel(op.Action)
+		actionLabel := authz.ActionLabel(op.Action)
 		start := time.Now()
 
 		err := next(ctx)
 
 		switch op.Kind {
 		case OperationKindCommand:
-			mc.commandDuration.ObserveDuration(start, actionLabel)
+			mc.commandDuration.ObserveContext(ctx, time.Since(start).Seconds(), actionLabel)
 			if err != nil {
-				mc.commandTotal.Inc(actionLabel, "error")
-				mc.commandErrors.Inc(actionLabel)
+				mc.commandTotal.AddContext(ctx, 1, actionLabel, "error")
+				mc.commandErrors.AddContext(ctx, 1, actionLabel)
 			} else {
-				mc.commandTotal.Inc(actionLabel, "success")
+				mc.commandTotal.AddContext(ctx, 1, actionLabel, "success")
 			}
 		case OperationKindQuery:
-			mc.queryDuration.ObserveDuration(start, actionLabel)
+			mc.queryDuration.ObserveContext(ctx, time.Since(start).Seconds(), actionLabel)
 			if err != nil {
-				mc.queryTotal.Inc(actionLabel, "error")
-				mc.queryErrors.Inc(actionLabel)
+				mc.queryTotal.AddContext(ctx, 1, actionLabel, "error")
+				mc.queryErrors.AddContext(ctx, 1, actionLabel)
 			} else {
-				mc.queryTotal.Inc(actionLabel, "success")
+				mc.queryTotal.AddContext(ctx, 1, actionLabel, "success")
 			}
 		}
 		return err
 	}
 }
-
-func actionLabel(action cedar.EntityUID) string {
-	// Mixology::Drink::Action::"create" -> Drink.create
-	s := action.String()
-	parts := strings.Split(s, "::")
-	if len(parts) < 4 {
-		return s
-	}
-	domain := parts[1]
-	id := strings.Trim(parts[len(parts)-1], `"`)
-	return domain + "." + id
-}
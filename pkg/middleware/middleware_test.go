@@ -12,6 +12,7 @@ import (
 
 	drinksauthz "github.com/TheFellow/go-modular-monolith/app/domains/drinks/authz"
 	"github.com/TheFellow/go-modular-monolith/pkg/authn"
+	"github.com/TheFellow/go-modular-monolith/pkg/authz"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/log"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
@@ -60,6 +61,30 @@ func TestPageQuery_FillsPagePastDeniedEntities(t *testing.T) {
 	testutil.Equals(t, page.Next, paging.Cursor("wine-2"))
 }
 
+func TestCollectQuery_FailsOnDeniedEntity(t *testing.T) {
+	t.Parallel()
+
+	fix := testutil.NewFixture(t)
+	pipeline := middleware.NewPipeline(middleware.PipelineConfig{Store: fix.Store})
+	items := []testEntity{testDrink("wine-1", "wine"), testDrink("beer-1", "beer")}
+	execute := func(store.Context, struct{}) iter.Seq2[testEntity, error] {
+		return func(yield func(testEntity, error) bool) {
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+
+	all, err := middleware.RunCollectQuery(pipeline, fix.OwnerContext(), drinksauthz.ActionList, execute, struct{}{})
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(all), 2)
+
+	_, err = middleware.RunCollectQuery(pipeline, fix.ActorContext("sommelier"), drinksauthz.ActionList, execute, struct{}{})
+	testutil.ErrorIsPermission(t, err)
+}
+
 func testDrink(id, category string) testEntity {
 	return testEntity{
 		ID: cedar.NewEntityUID(drinksauthz.DrinkType, cedar.String(id)),
@@ -68,6 +93,8 @@ func testDrink(id, category string) testEntity {
 			drinksauthz.DrinkDescriptionAttr: cedar.String(""),
 			drinksauthz.DrinkGlassAttr:       cedar.String(""),
 			drinksauthz.DrinkNameAttr:        cedar.String(id),
+			drinksauthz.DrinkCreatedByAttr:   authn.Owner(),
+			drinksauthz.DrinkOwnerAttr:       authn.Owner(),
 		},
 	}
 }
@@ -350,6 +377,28 @@ func TestEntityQuery_ReturnsNotFoundWithoutAuthorization(t *testing.T) {
 	testutil.ErrorIsNotFound(t, err)
 }
 
+func TestStampRequest_UsesPipelineClockAndKeepsEntrypointRequest(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.March, 6, 18, 30, 0, 0, time.UTC)
+	pipeline := middleware.NewPipeline(middleware.PipelineConfig{
+		Metrics: telemetry.Memory(),
+		Clock:   func() time.Time { return now },
+	})
+	ctx := context.Background()
+	ctx = log.ToContext(ctx, slog.New(slog.NewTextHandler(&testLogBuffer{}, nil)))
+	ctx = authn.ToContext(ctx, authn.Owner())
+	ctx = authz.WithRequest(ctx, authz.Request{Surface: authz.SurfaceTUI, SessionID: "session-1"})
+
+	var seen authz.Request
+	_, err := middleware.RunEntityQuery(pipeline, middleware.NewContext(ctx), drinksauthz.ActionGet, func(c store.Context, _ struct{}) (testEntity, error) {
+		seen = authz.RequestFromContext(c)
+		return testDrink("drk-stamped", "wine"), nil
+	}, struct{}{})
+	testutil.Ok(t, err)
+	testutil.Equals(t, seen, authz.Request{Time: now, Surface: authz.SurfaceTUI, SessionID: "session-1"})
+}
+
 func TestTrackActivity_MissingCallbackFailsBeforeCommand(t *testing.T) {
 	t.Parallel()
 
@@ -358,7 +407,7 @@ func TestTrackActivity_MissingCallbackFailsBeforeCommand(t *testing.T) {
 	mctx := newTestContext(logBuf, mem)
 
 	chain := middleware.NewChain(
-		middleware.TrackActivity(nil, nil),
+		middleware.TrackActivity(nil, nil, nil),
 	)
 
 	called := false
@@ -455,3 +504,99 @@ func TestDispatchEvents_DoesNotCascadeNewEvents(t *testing.T) {
 	testutil.Ok(t, err)
 	testutil.Equals(t, len(dispatcher.dispatched), 1)
 }
+
+type integrationEvent struct{ ID string }
+
+func (e integrationEvent) Topic() string { return "tests.integration" }
+func (e integrationEvent) Payload() any  { return map[string]string{"id": e.ID} }
+
+type recordingPublisher struct {
+	published []middlewareevents.Integration
+	err       error
+}
+
+func (p *recordingPublisher) Publish(_ *middleware.Context, event middlewareevents.Integration) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, event)
+	return nil
+}
+
+func TestPublishEvents_PublishesOnlyIntegrationEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx := log.ToContext(context.Background(), slog.New(slog.NewJSONHandler(&testLogBuffer{}, nil)))
+	mctx := middleware.NewContext(authn.ToContext(ctx, authn.Anonymous()))
+	publisher := &recordingPublisher{}
+	chain := middleware.NewChain(middleware.PublishEvents(publisher))
+
+	err := chain.Execute(mctx, middleware.CommandOperation(drinksauthz.ActionCreate), func(ctx *middleware.Context) error {
+		ctx.AddEvent(testEvent{Name: "internal"})
+		ctx.AddEvent(integrationEvent{ID: "1"})
+		return nil
+	})
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(publisher.published), 1)
+	testutil.Equals(t, publisher.published[0].Topic(), "tests.integration")
+}
+
+func TestPublishEvents_PublisherFailureFailsCommand(t *testing.T) {
+	t.Parallel()
+
+	ctx := log.ToContext(context.Background(), slog.New(slog.NewJSONHandler(&testLogBuffer{}, nil)))
+	mctx := middleware.NewContext(authn.ToContext(ctx, authn.Anonymous()))
+	chain := middleware.NewChain(middleware.PublishEvents(&recordingPublisher{err: errors.Internalf("disk full")}))
+
+	err := chain.Execute(mctx, middleware.CommandOperation(drinksauthz.ActionCreate), func(ctx *middleware.Context) error {
+		ctx.AddEvent(integrationEvent{ID: "1"})
+		return nil
+	})
+	testutil.ErrorIsInternal(t, err)
+	testutil.ErrorContains(t, err, "disk full")
+}
+
+type recordedEvent struct {
+	activityID string
+	event      any
+}
+
+type recordingRecorder struct {
+	recorded []recordedEvent
+}
+
+func (r *recordingRecorder) RecordEvent(_ *middleware.Context, activityID string, event any) error {
+	r.recorded = append(r.recorded, recordedEvent{activityID: activityID, event: event})
+	return nil
+}
+
+func TestRecordEvents_RecordsEventsWithActivityID(t *testing.T) {
+	t.Parallel()
+
+	ctx := log.ToContext(context.Background(), slog.New(slog.NewJSONHandler(&testLogBuffer{}, nil)))
+	mctx := middleware.NewContext(authn.ToContext(ctx, authn.Anonymous()))
+	recorder := &recordingRecorder{}
+	recordActivity := func(*middleware.Context, middlewareevents.Activity) error { return nil }
+	chain := middleware.NewChain(
+		middleware.TrackActivity(nil, recordActivity, func() string { return "activity-1" }),
+		middleware.RecordEvents(recorder),
+	)
+
+	err := chain.Execute(mctx, middleware.CommandOperation(drinksauthz.ActionCreate), func(ctx *middleware.Context) error {
+		ctx.AddEvent(testEvent{Name: "first"})
+		ctx.AddEvent(integrationEvent{ID: "2"})
+		return nil
+	})
+	testutil.Ok(t, err)
+	testutil.Equals(t, len(recorder.recorded), 2)
+	testutil.Equals(t, recorder.recorded[0].activityID, "activity-1")
+	testutil.Equals(t, recorder.recorded[0].event, any(testEvent{Name: "first"}))
+	testutil.Equals(t, recorder.recorded[1].event, any(integrationEvent{ID: "2"}))
+
+	err = chain.Execute(mctx, middleware.CommandOperation(drinksauthz.ActionCreate), func(ctx *middleware.Context) error {
+		ctx.AddEvent(testEvent{Name: "rolled back"})
+		return errors.Invalidf("nope")
+	})
+	testutil.ErrorIsInvalid(t, err)
+	testutil.Equals(t, len(recorder.recorded), 2)
+}
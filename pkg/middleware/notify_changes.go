@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+
+	middlewareevents "github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
+)
+
+// ChangeNotifier tells observers about commands once they commit.
+type ChangeNotifier interface {
+	// Stage runs in the command's transaction after the command succeeds, so
+	// it sees the state the command is about to commit. It returns the
+	// notification to deliver after the commit, or nil for none.
+	Stage(ctx *Context, activity middlewareevents.Activity) func()
+}
+
+// NotifyChanges stages a notification for every successful command and
+// delivers it only when the transaction holding the command commits, whether
+// the unit of work or a caller owns that transaction.
+func NotifyChanges(n ChangeNotifier) Middleware {
+	return func(ctx *Context, op Operation, next Next) error {
+		if op.Kind != OperationKindCommand {
+			return next(ctx)
+		}
+
+		if err := next(ctx); err != nil || n == nil {
+			return err
+		}
+
+		activity, ok := ctx.Activity()
+		if !ok {
+			return nil
+		}
+		tx, ok := ctx.Transaction()
+		if !ok || tx == nil {
+			return nil
+		}
+		if notify := n.Stage(ctx, *activity); notify != nil {
+			store.AfterCommit(tx, notify)
+		}
+		return nil
+	}
+}
@@ -16,6 +16,14 @@ const (
 type Operation struct {
 	Kind   OperationKind
 	Action cedar.EntityUID
+	// Request is a command's input as the caller gave it, and Result points
+	// at the command's result. Idempotency compares the one and stores the
+	// other; both are nil when the command cannot be replayed.
+	Request any
+	Result  any
+	// ReadsOnly marks a command whose only write is its own audit entry. On
+	// a read-only store it runs unaudited instead of being refused.
+	ReadsOnly bool
 }
 
 func QueryOperation(action cedar.EntityUID) Operation {
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"slices"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	middlewareevents "github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
+)
+
+// EventPublisher stores integration events for delivery outside the process.
+type EventPublisher interface {
+	Publish(ctx *Context, event middlewareevents.Integration) error
+}
+
+// PublishEvents hands the command's integration events to p after they have
+// been dispatched in-process. It runs inside the unit of work, so an event is
+// stored for delivery exactly when the command that raised it commits.
+func PublishEvents(p EventPublisher) Middleware {
+	return func(ctx *Context, op Operation, next Next) error {
+		if op.Kind != OperationKindCommand {
+			return next(ctx)
+		}
+
+		if err := next(ctx); err != nil {
+			return err
+		}
+
+		if p == nil {
+			return nil
+		}
+
+		for _, event := range slices.Clone(ctx.Events()) {
+			integration, ok := event.(middlewareevents.Integration)
+			if !ok {
+				continue
+			}
+			if err := p.Publish(ctx, integration); err != nil {
+				return errors.Internalf("publish event %T: %w", event, err)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"slices"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+)
+
+// EventRecorder persists domain events with the activity that raised them.
+type EventRecorder interface {
+	RecordEvent(ctx *Context, activityID string, event any) error
+}
+
+// RecordEvents persists every event the command dispatched. It runs inside
+// the unit of work, so the event log holds exactly the events of committed
+// commands, in dispatch order.
+func RecordEvents(r EventRecorder) Middleware {
+	return func(ctx *Context, op Operation, next Next) error {
+		if op.Kind != OperationKindCommand {
+			return next(ctx)
+		}
+
+		if err := next(ctx); err != nil {
+			return err
+		}
+
+		if r == nil {
+			return nil
+		}
+
+		var activityID string
+		if activity, ok := ctx.Activity(); ok {
+			activityID = activity.ID
+		}
+		for _, event := range slices.Clone(ctx.Events()) {
+			if err := r.RecordEvent(ctx, activityID, event); err != nil {
+				return errors.Internalf("record event %T: %w", event, err)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
+	cedar "github.com/cedar-policy/cedar-go"
+
+	middlewareevents "github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
+)
+
+// ReplayEvent dispatches an already-recorded event to d in its own write
+// transaction on s and returns the entities the handlers touched. Nothing is
+// audited, recorded, or published: replay rebuilds handler-derived state and
+// must not look like new activity. For the same reason it records no metrics;
+// handlers and the store see a no-op implementation.
+func ReplayEvent(ctx *Context, s *store.Store, d EventDispatcher, event any) ([]cedar.EntityUID, error) {
+	if d == nil {
+		return nil, errors.Internalf("replay %T: no dispatcher", event)
+	}
+	quiet := *ctx
+	quiet.Context = telemetry.WithMetrics(ctx.Context, telemetry.Nop())
+	ctx = &quiet
+	activity := middlewareevents.NewActivity(cedar.EntityUID{}, cedar.EntityUID{}, ctx.Principal())
+	activity.CorrelationID = ctx.CorrelationID()
+	err := s.Write(ctx, func(tx *store.Tx) error {
+		txCtx := ctx.WithTransaction(tx)
+		txCtx.activity = activity
+		return d.Dispatch(txCtx, event)
+	})
+	if err != nil {
+		return nil, errors.Internalf("replay %T: %w", event, err)
+	}
+	return activity.Touches, nil
+}
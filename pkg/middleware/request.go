@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/authz"
+)
+
+// StampRequest records when an operation began in its authorization request.
+// The entrypoint's surface, session, and client address are preserved, so
+// every Cedar decision in the operation sees one consistent request context.
+// A nil clock uses time.Now.
+func StampRequest(clock func() time.Time) Middleware {
+	if clock == nil {
+		clock = time.Now
+	}
+	return func(ctx *Context, _ Operation, next Next) error {
+		request := authz.RequestFromContext(ctx)
+		request.Time = clock()
+		ctx.Context = authz.WithRequest(ctx.Context, request)
+		return next(ctx)
+	}
+}
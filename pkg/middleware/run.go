@@ -2,9 +2,11 @@ package middleware
 
 import (
 	"iter"
+	"reflect"
 
 	"github.com/TheFellow/go-modular-monolith/pkg/authz"
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	middlewareevents "github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
 	"github.com/TheFellow/go-modular-monolith/pkg/paging"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 	cedar "github.com/cedar-policy/cedar-go"
@@ -33,7 +35,7 @@ func RunPageQuery[Req any, Item CedarEntity](
 				return err
 			}
 
-			err = authz.AuthorizeWithEntity(c.Principal(), action, item.CedarEntity())
+			err = authz.AuthorizeEntity(c, c.Principal(), action, item.CedarEntity())
 			switch {
 			case err == nil:
 				if len(page.Items) == pageRequest.Limit {
@@ -52,6 +54,35 @@ func RunPageQuery[Req any, Item CedarEntity](
 	return page, err
 }
 
+// RunCollectQuery gathers every entity a sequence yields. Unlike RunPageQuery
+// it fails on the first entity the caller may not see, so callers that need
+// the complete set, such as exports, never receive a silently partial one.
+func RunCollectQuery[Req any, Item CedarEntity](
+	pipeline *Pipeline,
+	ctx *Context,
+	action cedar.EntityUID,
+	execute func(store.Context, Req) iter.Seq2[Item, error],
+	req Req,
+) ([]Item, error) {
+	items := []Item{}
+	err := pipeline.query.Execute(ctx, QueryOperation(action), func(c *Context) error {
+		for item, err := range execute(c, req) {
+			if err != nil {
+				return err
+			}
+			if err := authz.AuthorizeEntity(c, c.Principal(), action, item.CedarEntity()); err != nil {
+				return err
+			}
+			items = append(items, item)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 // RunEntityQuery loads one entity and authorizes that entity before returning
 // it to the caller.
 func RunEntityQuery[Req any, Res CedarEntity](
@@ -83,19 +114,40 @@ type CommandSpec[In CedarEntity, Out CedarEntity] struct {
 	// AuthorizationActions optionally derives the complete set of actions that
 	// must authorize the loaded and resulting states. Nil uses Action.
 	AuthorizationActions func(In) []cedar.EntityUID
-	Load                 func(*Context) (In, error)
-	Handle               CommandHandler[In, Out]
+	// Request is the caller's input before Load reads any state. A command
+	// retried under the same idempotency key must repeat it exactly; nil
+	// refuses idempotency keys.
+	Request any
+	// ReadsOnly declares that Handle changes nothing, so the command may run
+	// against a read-only store, unaudited.
+	ReadsOnly bool
+	Load      func(*Context) (In, error)
+	// Before optionally loads the existing entity a command changes when its
+	// input is not that entity, such as a patch. It runs after Load, and
+	// NotFound means there is no prior state. Nil records the loaded input as
+	// the prior state only when it is of the result's type.
+	Before func(*Context, In) (Out, error)
+	Handle CommandHandler[In, Out]
 }
 
 func RunCommand[In CedarEntity, Out CedarEntity](pipeline *Pipeline, ctx *Context, spec CommandSpec[In, Out]) (Out, error) {
 	var out Out
 
-	err := pipeline.command.Execute(ctx, CommandOperation(spec.Action), func(c *Context) error {
+	op := CommandOperation(spec.Action)
+	op.ReadsOnly = spec.ReadsOnly
+	if spec.Request != nil {
+		op.Request, op.Result = spec.Request, &out
+	}
+	err := pipeline.command.Execute(ctx, op, func(c *Context) error {
 		input, err := spec.Load(c)
 		if err != nil {
 			return err
 		}
 		inputEntity := input.CedarEntity()
+		before, err := snapshotBefore(c, spec, input, inputEntity)
+		if err != nil {
+			return err
+		}
 
 		if activity, ok := c.Activity(); ok && activity.Resource.IsZero() {
 			activity.Resource = inputEntity.UID
@@ -114,8 +166,11 @@ func RunCommand[In CedarEntity, Out CedarEntity](pipeline *Pipeline, ctx *Contex
 			return err
 		}
 
-		if activity, ok := c.Activity(); ok && activity.Resource.IsZero() {
-			activity.Resource = res.CedarEntity().UID
+		if activity, ok := c.Activity(); ok {
+			if activity.Resource.IsZero() {
+				activity.Resource = res.CedarEntity().UID
+			}
+			activity.Changes = middlewareevents.Diff(before, middlewareevents.Snapshot(res), pipeline.redact)
 		}
 
 		out = res
@@ -124,6 +179,27 @@ func RunCommand[In CedarEntity, Out CedarEntity](pipeline *Pipeline, ctx *Contex
 	return out, err
 }
 
+// snapshotBefore captures the prior state before Handle can mutate it. A
+// spec's Before supplies it; otherwise only an existing entity of the result's
+// kind has one, since a create's proposed input or a patch describes the
+// request instead, so its result is recorded in full.
+func snapshotBefore[In CedarEntity, Out CedarEntity](c *Context, spec CommandSpec[In, Out], input In, entity cedar.Entity) (map[string]string, error) {
+	if spec.Before != nil {
+		existing, err := spec.Before(c, input)
+		switch {
+		case errors.IsNotFound(err):
+			return nil, nil
+		case err != nil:
+			return nil, err
+		}
+		return middlewareevents.Snapshot(existing), nil
+	}
+	if entity.UID.ID == "" || reflect.TypeFor[In]() != reflect.TypeFor[Out]() {
+		return nil, nil
+	}
+	return middlewareevents.Snapshot(input), nil
+}
+
 func authorizeCommandActions[In CedarEntity, Out CedarEntity](
 	actions []cedar.EntityUID,
 	next CommandHandler[In, Out],
@@ -131,7 +207,7 @@ func authorizeCommandActions[In CedarEntity, Out CedarEntity](
 	return func(ctx *Context, in In) (Out, error) {
 		var zero Out
 		for _, action := range actions {
-			if err := authz.AuthorizeWithEntity(ctx.Principal(), action, in.CedarEntity()); err != nil {
+			if err := authz.AuthorizeEntity(ctx, ctx.Principal(), action, in.CedarEntity()); err != nil {
 				return zero, err
 			}
 		}
@@ -141,7 +217,7 @@ func authorizeCommandActions[In CedarEntity, Out CedarEntity](
 			return zero, err
 		}
 		for _, action := range actions {
-			if err := authz.AuthorizeWithEntity(ctx.Principal(), action, out.CedarEntity()); err != nil {
+			if err := authz.AuthorizeEntity(ctx, ctx.Principal(), action, out.CedarEntity()); err != nil {
 				return zero, err
 			}
 		}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"github.com/TheFellow/go-modular-monolith/pkg/authz"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Tracing opens a span around each operation, named for its kind and action
+// label and tagged with the Cedar action, the principal's entity type, and the
+// outcome. Authorization, store access, and event handlers run under the
+// operation's context, so their spans nest beneath it, and measurements
+// recorded with that context can carry exemplars pointing at the trace.
+func Tracing() Middleware {
+	return func(ctx *Context, op Operation, next Next) error {
+		spanCtx, span := telemetry.StartSpan(ctx.Context, string(op.Kind)+" "+authz.ActionLabel(op.Action),
+			attribute.String(telemetry.AttrOperation, string(op.Kind)),
+			attribute.String(telemetry.AttrAction, op.Action.String()),
+			attribute.String(telemetry.AttrPrincipalType, string(ctx.Principal().Type)),
+			attribute.String(telemetry.AttrCorrelationID, ctx.CorrelationID()),
+		)
+		ctx.Context = spanCtx
+
+		err := next(ctx)
+
+		span.SetAttributes(attribute.String(telemetry.AttrOutcome, outcome(err)))
+		telemetry.EndSpan(span, err)
+		return err
+	}
+}
+
+func outcome(err error) string {
+	switch {
+	case err == nil:
+		return "success"
+	case errors.IsPermission(err):
+		return "denied"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,119 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/hex"
+	"log/slog"
+	"testing"
+
+	drinksauthz "github.com/TheFellow/go-modular-monolith/app/domains/drinks/authz"
+	"github.com/TheFellow/go-modular-monolith/pkg/authn"
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/log"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing_NestsAuthorizationUnderOperationAndLinksMetrics(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	metrics := telemetry.OTEL(meter.Meter("test"))
+
+	ctx := context.Background()
+	ctx = log.ToContext(ctx, slog.New(slog.NewTextHandler(&testLogBuffer{}, nil)))
+	ctx = authn.ToContext(ctx, authn.Owner())
+	ctx = telemetry.WithTracing(ctx, tracer)
+	pipeline := middleware.NewPipeline(middleware.PipelineConfig{Metrics: metrics})
+
+	_, err := middleware.RunEntityQuery(pipeline, middleware.NewContext(ctx), drinksauthz.ActionGet, func(_ store.Context, _ struct{}) (testEntity, error) {
+		return testDrink("drk-traced", "wine"), nil
+	}, struct{}{})
+	testutil.Ok(t, err)
+
+	spans := endedSpans(recorder)
+	operation, ok := spans["query Drink.get"]
+	testutil.IsTrue(t, ok)
+	attrs := spanAttributes(operation)
+	testutil.Equals(t, attrs[telemetry.AttrAction], drinksauthz.ActionGet.String())
+	testutil.Equals(t, attrs[telemetry.AttrPrincipalType], string(authn.Owner().Type))
+	testutil.Equals(t, attrs[telemetry.AttrOutcome], "success")
+
+	authorize, ok := spans[telemetry.SpanAuthorize]
+	testutil.IsTrue(t, ok)
+	testutil.Equals(t, authorize.Parent().SpanID(), operation.SpanContext().SpanID())
+	testutil.Equals(t, spanAttributes(authorize)[telemetry.AttrDecision], "allow")
+
+	var collected metricdata.ResourceMetrics
+	testutil.Ok(t, reader.Collect(context.Background(), &collected))
+	testutil.Equals(t, exemplarTraceIDs(collected, telemetry.MetricQueryDuration), []string{operation.SpanContext().TraceID().String()})
+}
+
+func TestTracing_MarksDeniedOperations(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	mctx := newTestContext(&testLogBuffer{}, telemetry.Memory())
+	mctx.Context = telemetry.WithTracing(mctx.Context, tracer)
+
+	chain := middleware.NewChain(middleware.Tracing())
+	err := chain.Execute(mctx, middleware.CommandOperation(drinksauthz.ActionCreate), func(_ *middleware.Context) error {
+		return errors.Permissionf("access denied")
+	})
+	testutil.ErrorIsPermission(t, err)
+
+	span, ok := endedSpans(recorder)["command Drink.create"]
+	testutil.IsTrue(t, ok)
+	testutil.Equals(t, spanAttributes(span)[telemetry.AttrOutcome], "denied")
+	testutil.Equals(t, span.Status().Code, codes.Error)
+}
+
+func endedSpans(recorder *tracetest.SpanRecorder) map[string]sdktrace.ReadOnlySpan {
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	return spans
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[string]string {
+	attrs := make(map[string]string)
+	for _, kv := range span.Attributes() {
+		if kv.Value.Type() == attribute.STRING {
+			attrs[string(kv.Key)] = kv.Value.AsString()
+		}
+	}
+	return attrs
+}
+
+// exemplarTraceIDs returns the hex trace IDs of the exemplars attached to the
+// named histogram.
+func exemplarTraceIDs(rm metricdata.ResourceMetrics, name string) []string {
+	var ids []string
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			histogram, ok := m.Data.(metricdata.Histogram[float64])
+			if m.Name != name || !ok {
+				continue
+			}
+			for _, point := range histogram.DataPoints {
+				for _, exemplar := range point.Exemplars {
+					ids = append(ids, hex.EncodeToString(exemplar.TraceID))
+				}
+			}
+		}
+	}
+	return ids
+}
@@ -1,26 +1,41 @@
 package middleware
 
 import (
+	"log/slog"
+
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 	"github.com/TheFellow/go-modular-monolith/pkg/log"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 	cedar "github.com/cedar-policy/cedar-go"
-	"github.com/mjl-/bstore"
 
 	middlewareevents "github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
 )
 
-func TrackActivity(s *store.Store, recordActivity func(*Context, middlewareevents.Activity) error) Middleware {
+func TrackActivity(s *store.Store, recordActivity func(*Context, middlewareevents.Activity) error, activityID func() string) Middleware {
 	return func(ctx *Context, op Operation, next Next) error {
 		if op.Kind != OperationKindCommand {
 			return next(ctx)
 		}
+		// A read-only store cannot record activity. UnitOfWork refuses the
+		// commands that would change anything; the rest, such as exports, run
+		// unaudited and leave a warning in the log in place of their entry.
+		if s != nil && s.ReadOnly() {
+			err := next(ctx)
+			if op.ReadsOnly {
+				logUnaudited(ctx, err)
+			}
+			return err
+		}
 
 		if recordActivity == nil {
 			return errors.Internalf("record activity callback missing from pipeline")
 		}
 
 		activity := middlewareevents.NewActivity(op.Action, cedar.EntityUID{}, ctx.Principal())
+		activity.CorrelationID = ctx.CorrelationID()
+		if activityID != nil {
+			activity.ID = activityID()
+		}
 		ctx.activity = activity
 
 		err := next(ctx)
@@ -45,7 +60,7 @@ func TrackActivity(s *store.Store, recordActivity func(*Context, middlewareevent
 				return rerr
 			}
 		} else if s != nil {
-			if rerr := s.Write(ctx, func(tx *bstore.Tx) error {
+			if rerr := s.Write(ctx, func(tx *store.Tx) error {
 				txCtx := ctx.WithTransaction(tx)
 				return record(txCtx)
 			}); rerr != nil {
@@ -63,9 +78,9 @@ func TrackActivity(s *store.Store, recordActivity func(*Context, middlewareevent
 // of work returns makes the command, its event handlers, and its success audit
 // entry one atomic write. Failed commands bypass this middleware's recording;
 // TrackActivity records their failure only after UnitOfWork has rolled back.
-func recordSuccessfulActivity(recordActivity func(*Context, middlewareevents.Activity) error) Middleware {
+func recordSuccessfulActivity(s *store.Store, recordActivity func(*Context, middlewareevents.Activity) error) Middleware {
 	return func(ctx *Context, op Operation, next Next) error {
-		if op.Kind != OperationKindCommand {
+		if op.Kind != OperationKindCommand || s != nil && s.ReadOnly() {
 			return next(ctx)
 		}
 
@@ -103,3 +118,11 @@ func recordCompletedActivity(
 	}
 	return nil
 }
+
+func logUnaudited(ctx *Context, err error) {
+	attrs := []any{slog.Bool("success", err == nil)}
+	if err != nil {
+		attrs = append(attrs, log.Err(err))
+	}
+	log.FromContext(ctx).Warn("command not audited: the database is open read-only", attrs...)
+}
@@ -1,8 +1,10 @@
 package middleware
 
 import (
+	"log/slog"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/log"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
-	"github.com/mjl-/bstore"
 
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
 )
@@ -16,13 +18,33 @@ func UnitOfWork(s *store.Store) Middleware {
 		if s == nil {
 			return errors.Internalf("store missing from context")
 		}
+		if s.ReadOnly() && !op.ReadsOnly {
+			return errors.FailedPreconditionf("%s changes data, but the database is open read-only", op.Action)
+		}
 		if tx, ok := ctx.Transaction(); ok && tx != nil {
 			return next(ctx)
 		}
 
-		return s.Write(ctx, func(tx *bstore.Tx) error {
+		run := s.Write
+		if s.ReadOnly() {
+			run = s.Read
+		}
+		err := run(ctx, func(tx *store.Tx) error {
 			txCtx := ctx.WithTransaction(tx)
 			return next(txCtx)
 		})
+		if err == nil {
+			warnUnsealed(ctx, s)
+		}
+		return err
+	}
+}
+
+// warnUnsealed reports when a sealed database has committed changes it could
+// not seal over its file. The command succeeded and its changes are safe in
+// the working copy, so this warns rather than failing it.
+func warnUnsealed(ctx *Context, s *store.Store) {
+	if err := s.SealError(); err != nil {
+		log.FromContext(ctx).Warn("committed changes are not yet sealed", slog.String("path", s.Path()), log.Err(err))
 	}
 }
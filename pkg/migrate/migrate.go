@@ -0,0 +1,206 @@
+// Package migrate runs the ordered data migrations each domain declares for
+// its stored rows and records which have been applied. The store evolves row
+// schemas on its own; migrations cover the data transformations it cannot,
+// such as backfilling a new field.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+// Migration is one step in a domain's data history. Up runs inside the write
+// transaction that records it, so a failed step leaves no trace. Steps must be
+// idempotent: a database restored from an older backup replays them.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, tx *store.Tx) error
+}
+
+// Domain is the migrations one domain owns, numbered from 1 without gaps.
+type Domain struct {
+	Name       string
+	Migrations []Migration
+}
+
+// Status describes one migration known to the binary. AppliedAt is zero
+// while the migration is pending.
+type Status struct {
+	Domain    string
+	Version   int
+	Name      string
+	AppliedAt time.Time
+}
+
+func (s Status) Pending() bool {
+	return s.AppliedAt.IsZero()
+}
+
+type migrationRow struct {
+	ID        string
+	Domain    string `bstore:"index"`
+	Version   int
+	Name      string
+	AppliedAt time.Time
+}
+
+func migrationID(domain string, version int) string {
+	return fmt.Sprintf("%s/%d", domain, version)
+}
+
+// Register adds the applied-migration table to s.
+func Register(ctx context.Context, s *store.Store) {
+	s.Register(ctx, migrationRow{})
+}
+
+// Migrator applies a fixed set of domain migrations to one store.
+type Migrator struct {
+	store   *store.Store
+	domains []Domain
+}
+
+// New validates the domains' numbering. Domains run in the order given.
+func New(s *store.Store, domains ...Domain) (*Migrator, error) {
+	seen := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		if seen[domain.Name] {
+			return nil, errors.Internalf("domain %s declares migrations twice", domain.Name)
+		}
+		seen[domain.Name] = true
+		for i, migration := range domain.Migrations {
+			if migration.Version != i+1 {
+				return nil, errors.Internalf("%s migration %q has version %d, want %d", domain.Name, migration.Name, migration.Version, i+1)
+			}
+			if migration.Up == nil {
+				return nil, errors.Internalf("%s migration %d has no Up", domain.Name, migration.Version)
+			}
+		}
+	}
+	return &Migrator{store: s, domains: domains}, nil
+}
+
+// Status lists every migration the binary knows, in the order Up applies them.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.store.Read(ctx, func(tx *store.Tx) error {
+		applied, err := m.applied(tx)
+		if err != nil {
+			return err
+		}
+		statuses = m.statuses(applied)
+		return nil
+	})
+	return statuses, err
+}
+
+// Check refuses a database that a newer binary has migrated: one recording a
+// migration this binary does not know.
+func (m *Migrator) Check(ctx context.Context) error {
+	return m.store.Read(ctx, func(tx *store.Tx) error {
+		applied, err := m.applied(tx)
+		if err != nil {
+			return err
+		}
+		return m.checkKnown(applied)
+	})
+}
+
+// Up applies every pending migration in one write transaction and returns the
+// ones applied. Under store.RollbackOnly the migrations run and are reported
+// but nothing is kept.
+func (m *Migrator) Up(ctx context.Context) ([]Status, error) {
+	var ran []Status
+	err := m.store.Write(ctx, func(tx *store.Tx) error {
+		applied, err := m.applied(tx)
+		if err != nil {
+			return err
+		}
+		if err := m.checkKnown(applied); err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		for _, status := range m.statuses(applied) {
+			if !status.Pending() {
+				continue
+			}
+			migration := m.migration(status.Domain, status.Version)
+			if err := migration.Up(ctx, tx); err != nil {
+				return errors.Internalf("%s migration %d (%s): %w", status.Domain, status.Version, status.Name, err)
+			}
+			status.AppliedAt = now
+			row := migrationRow{
+				ID:        migrationID(status.Domain, status.Version),
+				Domain:    status.Domain,
+				Version:   status.Version,
+				Name:      status.Name,
+				AppliedAt: now,
+			}
+			if err := tx.Insert(&row); err != nil {
+				return store.MapError(err, "record %s migration %d", status.Domain, status.Version)
+			}
+			ran = append(ran, status)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ran, nil
+}
+
+func (m *Migrator) applied(tx *store.Tx) (map[string]migrationRow, error) {
+	rows, err := store.QueryTx[migrationRow](tx).List()
+	if err != nil {
+		return nil, store.MapError(err, "list applied migrations")
+	}
+	applied := make(map[string]migrationRow, len(rows))
+	for _, row := range rows {
+		applied[row.ID] = row
+	}
+	return applied, nil
+}
+
+func (m *Migrator) statuses(applied map[string]migrationRow) []Status {
+	var statuses []Status
+	for _, domain := range m.domains {
+		for _, migration := range domain.Migrations {
+			status := Status{Domain: domain.Name, Version: migration.Version, Name: migration.Name}
+			if row, ok := applied[migrationID(domain.Name, migration.Version)]; ok {
+				status.AppliedAt = row.AppliedAt
+			}
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+func (m *Migrator) checkKnown(applied map[string]migrationRow) error {
+	ids := make([]string, 0, len(applied))
+	for id := range applied {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		row := applied[id]
+		if m.migration(row.Domain, row.Version) == nil {
+			return errors.FailedPreconditionf("database has %s migration %d (%s), which this binary does not know; use a newer binary",
+				row.Domain, row.Version, row.Name)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) migration(domain string, version int) *Migration {
+	for _, d := range m.domains {
+		if d.Name == domain && version >= 1 && version <= len(d.Migrations) {
+			return &d.Migrations[version-1]
+		}
+	}
+	return nil
+}
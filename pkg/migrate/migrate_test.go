@@ -0,0 +1,143 @@
+package migrate_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/migrate"
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+	testutil "github.com/TheFellow/go-modular-monolith/pkg/testutil/assert"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/teststore"
+)
+
+type lotRow struct {
+	ID       int
+	Quantity float64
+	Lots     int
+}
+
+func openStore(t *testing.T, path string) *store.Store {
+	t.Helper()
+	ctx := context.Background()
+	s, err := store.Open(ctx, path)
+	testutil.ErrorIf(t, err != nil, "open store: %v", err)
+	t.Cleanup(func() { _ = s.Close() })
+	s.Register(ctx, lotRow{})
+	migrate.Register(ctx, s)
+	return s
+}
+
+// splitLots sets Lots on rows that predate it, recording the order it ran in.
+func splitLots(order *[]string, name string) migrate.Migration {
+	return migrate.Migration{
+		Version: len(*order) + 1,
+		Name:    name,
+		Up: func(_ context.Context, tx *store.Tx) error {
+			*order = append(*order, name)
+			_, err := store.QueryTx[lotRow](tx).FilterEqual("Lots", 0).UpdateField("Lots", 1)
+			return err
+		},
+	}
+}
+
+func TestUpAppliesPendingMigrationsOnceInOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := openStore(t, teststore.Path(t, "store"))
+	err := s.Write(ctx, func(tx *store.Tx) error { return tx.Insert(&lotRow{Quantity: 3}) })
+	testutil.ErrorIf(t, err != nil, "insert: %v", err)
+
+	var order []string
+	first := splitLots(&order, "split lots")
+	stock := migrate.Domain{Name: "stock", Migrations: []migrate.Migration{first}}
+	m, err := migrate.New(s, stock)
+	testutil.ErrorIf(t, err != nil, "new: %v", err)
+
+	ran, err := m.Up(ctx)
+	testutil.ErrorIf(t, err != nil, "up: %v", err)
+	testutil.ErrorIf(t, len(ran) != 1 || ran[0].Pending(), "ran = %#v, want one applied migration", ran)
+
+	ran, err = m.Up(ctx)
+	testutil.ErrorIf(t, err != nil || len(ran) != 0, "second up = %#v, %v; want nothing pending", ran, err)
+	testutil.ErrorIf(t, len(order) != 1, "migration ran %d times, want once", len(order))
+
+	second := migrate.Migration{Version: 2, Name: "no-op", Up: func(context.Context, *store.Tx) error {
+		order = append(order, "no-op")
+		return nil
+	}}
+	stock.Migrations = append(stock.Migrations, second)
+	m, err = migrate.New(s, migrate.Domain{Name: "audit"}, stock)
+	testutil.ErrorIf(t, err != nil, "new: %v", err)
+	statuses, err := m.Status(ctx)
+	testutil.ErrorIf(t, err != nil, "status: %v", err)
+	testutil.ErrorIf(t, len(statuses) != 2 || statuses[0].Pending() || !statuses[1].Pending(), "statuses = %#v", statuses)
+
+	_, err = m.Up(ctx)
+	testutil.ErrorIf(t, err != nil, "up: %v", err)
+	testutil.ErrorIf(t, len(order) != 2 || order[1] != "no-op", "order = %v", order)
+	err = s.Read(ctx, func(tx *store.Tx) error {
+		row, err := store.QueryTx[lotRow](tx).Get()
+		testutil.ErrorIf(t, row.Lots != 1, "lots = %d, want backfilled 1", row.Lots)
+		return err
+	})
+	testutil.ErrorIf(t, err != nil, "read: %v", err)
+}
+
+func TestUpUnderRollbackOnlyReportsWithoutApplying(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := openStore(t, teststore.Path(t, "store"))
+	var order []string
+	m, err := migrate.New(s, migrate.Domain{Name: "stock", Migrations: []migrate.Migration{splitLots(&order, "split lots")}})
+	testutil.ErrorIf(t, err != nil, "new: %v", err)
+
+	ran, err := m.Up(store.RollbackOnly(ctx))
+	testutil.ErrorIf(t, err != nil || len(ran) != 1, "dry run = %#v, %v; want one migration reported", ran, err)
+	statuses, err := m.Status(ctx)
+	testutil.ErrorIf(t, err != nil, "status: %v", err)
+	testutil.ErrorIf(t, !statuses[0].Pending(), "dry run recorded %#v", statuses[0])
+}
+
+func TestCheckRefusesDatabaseFromNewerBinary(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := openStore(t, teststore.Path(t, "store"))
+	noop := func(context.Context, *store.Tx) error { return nil }
+	first := migrate.Migration{Version: 1, Name: "split lots", Up: noop}
+	second := migrate.Migration{Version: 2, Name: "merge lots", Up: noop}
+	newer, err := migrate.New(s, migrate.Domain{Name: "stock", Migrations: []migrate.Migration{first, second}})
+	testutil.ErrorIf(t, err != nil, "new: %v", err)
+	_, err = newer.Up(ctx)
+	testutil.ErrorIf(t, err != nil, "up: %v", err)
+
+	older, err := migrate.New(s, migrate.Domain{Name: "stock", Migrations: []migrate.Migration{first}})
+	testutil.ErrorIf(t, err != nil, "new: %v", err)
+	{
+		err := older.Check(ctx)
+		testutil.ErrorIf(t, !errors.IsFailedPrecondition(err), "check error = %v, want failed precondition", err)
+	}
+	{
+		_, err := older.Up(ctx)
+		testutil.ErrorIf(t, !errors.IsFailedPrecondition(err), "up error = %v, want failed precondition", err)
+	}
+}
+
+func TestNewRejectsMisnumberedMigrations(t *testing.T) {
+	t.Parallel()
+
+	s := openStore(t, teststore.Path(t, "store"))
+	noop := func(context.Context, *store.Tx) error { return nil }
+	cases := map[string][]migrate.Domain{
+		"gap":       {{Name: "stock", Migrations: []migrate.Migration{{Version: 2, Name: "late", Up: noop}}}},
+		"no up":     {{Name: "stock", Migrations: []migrate.Migration{{Version: 1, Name: "empty"}}}},
+		"duplicate": {{Name: "stock"}, {Name: "stock"}},
+	}
+	for name, domains := range cases {
+		_, err := migrate.New(s, domains...)
+		testutil.ErrorIf(t, err == nil, "%s: New accepted %#v", name, domains)
+	}
+}
@@ -0,0 +1,30 @@
+package optional
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+type gobValue[T any] struct {
+	Valid bool
+	Value T
+}
+
+// GobEncode keeps presence when a Value is persisted with encoding/gob, as
+// recorded domain events are.
+func (v Value[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(gobValue[T]{Valid: v.valid, Value: v.value}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (v *Value[T]) GobDecode(data []byte) error {
+	var decoded gobValue[T]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&decoded); err != nil {
+		return err
+	}
+	v.value, v.valid = decoded.Value, decoded.Valid
+	return nil
+}
@@ -1,6 +1,8 @@
 package optional_test
 
 import (
+	"bytes"
+	"encoding/gob"
 	"testing"
 
 	"github.com/TheFellow/go-modular-monolith/pkg/optional"
@@ -49,3 +51,24 @@ func TestZeroValueIsNone(t *testing.T) {
 	testutil.IsFalse(t, ok)
 	testutil.Equals(t, got, 0)
 }
+
+func TestGobRoundTripKeepsPresence(t *testing.T) {
+	t.Parallel()
+
+	type record struct {
+		Name  optional.Value[string]
+		Count optional.Value[int]
+		Note  optional.Value[string]
+	}
+	want := record{Name: optional.Some("Negroni"), Count: optional.Some(0), Note: optional.None[string]()}
+	var buf bytes.Buffer
+	testutil.Ok(t, gob.NewEncoder(&buf).Encode(want))
+
+	var got record
+	testutil.Ok(t, gob.NewDecoder(&buf).Decode(&got))
+	testutil.Equals(t, got, want)
+	count, ok := got.Count.Unwrap()
+	testutil.IsTrue(t, ok)
+	testutil.Equals(t, count, 0)
+	testutil.IsTrue(t, got.Note.IsNone())
+}
@@ -0,0 +1,40 @@
+// Package preview describes a dry run's would-be effects in plain lines that
+// every surface can show.
+package preview
+
+import (
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	middlewareevents "github.com/TheFellow/go-modular-monolith/pkg/middleware/events"
+)
+
+// Lines describes each previewed command: its action and resource, then the
+// fields it changes, the entities it touches, and the events it raises.
+func Lines(p *middleware.Preview) []string {
+	commands := p.Commands()
+	if len(commands) == 0 {
+		return []string{"No changes"}
+	}
+	var lines []string
+	for _, command := range commands {
+		lines = append(lines, command.Action.String()+" "+command.Resource.String())
+		for _, change := range command.Changes {
+			lines = append(lines, "  "+change.Path+": "+value(change.Before)+" -> "+value(change.After))
+		}
+		for _, touched := range command.Touches {
+			if touched != command.Resource {
+				lines = append(lines, "  touches "+touched.String())
+			}
+		}
+		for _, event := range command.Events {
+			lines = append(lines, "  raises "+middlewareevents.Name(event))
+		}
+	}
+	return lines
+}
+
+func value(v string) string {
+	if v == "" {
+		return "(none)"
+	}
+	return v
+}
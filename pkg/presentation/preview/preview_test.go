@@ -0,0 +1,36 @@
+package preview_test
+
+import (
+	"testing"
+
+	ingredientsauthz "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/authz"
+	ingredientsmodels "github.com/TheFellow/go-modular-monolith/app/domains/ingredients/models"
+	"github.com/TheFellow/go-modular-monolith/app/kernel/measurement"
+	"github.com/TheFellow/go-modular-monolith/pkg/presentation/preview"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestLinesDescribeRolledBackCommands(t *testing.T) {
+	t.Parallel()
+	f := testutil.NewFixture(t)
+	gin := testutil.CreateIngredient(t, f, ingredientsmodels.Ingredient{
+		Name: "Gin", Category: ingredientsmodels.CategorySpirit, Unit: measurement.UnitOz,
+	})
+
+	dry, p := f.App.DryRun()
+	testutil.Equals(t, preview.Lines(p), []string{"No changes"})
+	renamed := *gin
+	renamed.Name = "Old Tom Gin"
+	_, err := dry.Ingredients.Update(dry.Context(), &renamed)
+	testutil.Ok(t, err)
+
+	testutil.Equals(t, preview.Lines(p), []string{
+		ingredientsauthz.ActionUpdate.String() + " " + gin.ID.EntityUID().String(),
+		"  Name: Gin -> Old Tom Gin",
+		"  Version: 1 -> 2",
+		"  raises ingredients.IngredientUpdated",
+	})
+	current, err := f.Ingredients.Get(f.OwnerContext(), gin.ID)
+	testutil.Ok(t, err)
+	testutil.Equals(t, current.Name, "Gin")
+}
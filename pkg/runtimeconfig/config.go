@@ -3,12 +3,29 @@
 // executable edge; their names, environment variables, and defaults do not.
 package runtimeconfig
 
+import (
+	"path/filepath"
+	"time"
+)
+
 const (
 	DefaultDatabasePath = "data/mixology.db"
 	DefaultActor        = "owner"
 	DefaultLogLevel     = "info"
 	DefaultLogFormat    = "text"
 	DefaultMetricsAddr  = ":9090"
+	// DefaultMetricsInterval is how often the domain gauges behind the
+	// metrics endpoint are recomputed from stored state.
+	DefaultMetricsInterval = 15 * time.Second
+	// DefaultBackupKeep is how many verified backups survive retention;
+	// older snapshots are deleted after each successful backup.
+	DefaultBackupKeep = 10
+	// DefaultLowStockThreshold matches inventory.DefaultLowStockThreshold, the
+	// quantity at or below which stock is reported as low.
+	DefaultLowStockThreshold = 10.0
+	DefaultTargetMargin      = 0.7
+	DefaultCurrency          = "USD"
+	DefaultServerAddr        = ":8080"
 
 	EnvDatabasePath = "MIXOLOGY_DB"
 	EnvActor        = "MIXOLOGY_ACTOR"
@@ -17,6 +34,22 @@ const (
 	EnvLogFormat    = "MIXOLOGY_LOG_FORMAT"
 	EnvLogFile      = "MIXOLOGY_LOG_FILE"
 	EnvMetrics      = "MIXOLOGY_METRICS"
+	EnvTraceFile    = "MIXOLOGY_TRACE_FILE"
+	// EnvCorrelationID supplies the CLI's correlation ID, letting a script
+	// group several invocations under one ID.
+	EnvCorrelationID = "MIXOLOGY_CORRELATION_ID"
+	EnvBackupDir     = "MIXOLOGY_BACKUP_DIR"
+	EnvReadOnly      = "MIXOLOGY_READ_ONLY"
+	// EnvKeyFile names the file holding the passphrase or key that seals the
+	// database, its backups, and exported files.
+	EnvKeyFile           = "MIXOLOGY_KEY_FILE"
+	EnvMetricsAddr       = "MIXOLOGY_METRICS_ADDR"
+	EnvLowStockThreshold = "MIXOLOGY_LOW_STOCK_THRESHOLD"
+	EnvTargetMargin      = "MIXOLOGY_TARGET_MARGIN"
+	EnvCurrency          = "MIXOLOGY_CURRENCY"
+	EnvServerAddr        = "MIXOLOGY_SERVER_ADDR"
+	// EnvConfigFile names the config file when --config is not given.
+	EnvConfigFile = "MIXOLOGY_CONFIG"
 )
 
 // Config is the common runtime contract. An executable may choose not to
@@ -29,6 +62,28 @@ type Config struct {
 	LogFile       string
 	EnableMetrics bool
 	MetricsAddr   string
+	// TraceFile receives OTLP JSON traces when set; empty disables tracing.
+	TraceFile string
+	// ReadOnly opens the database for reading beside a writer in another
+	// process; commands that change data are refused.
+	ReadOnly bool
+	// KeyFile holds the key for sealed databases; empty leaves new databases,
+	// backups, and exports plain.
+	KeyFile string
+	// LowStockThreshold, TargetMargin, and Currency are the starting values
+	// surfaces offer for a low-stock filter, a menu analysis, and a price
+	// entered without a currency.
+	LowStockThreshold float64
+	TargetMargin      float64
+	Currency          string
+	// ServerAddr is reserved for the HTTP server; no entry point listens on
+	// it yet.
+	ServerAddr string
+
+	// ConfigFile is the file Load read settings from; empty when there was
+	// none.
+	ConfigFile string
+	sources    map[string]Source
 }
 
 func Default() Config {
@@ -38,5 +93,16 @@ func Default() Config {
 		LogLevel:     DefaultLogLevel,
 		LogFormat:    DefaultLogFormat,
 		MetricsAddr:  DefaultMetricsAddr,
+
+		LowStockThreshold: DefaultLowStockThreshold,
+		TargetMargin:      DefaultTargetMargin,
+		Currency:          DefaultCurrency,
+		ServerAddr:        DefaultServerAddr,
 	}
 }
+
+// BackupDir is the default backup directory for a database: a backups
+// directory beside the database file.
+func BackupDir(databasePath string) string {
+	return filepath.Join(filepath.Dir(databasePath), "backups")
+}
@@ -0,0 +1,277 @@
+package runtimeconfig
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+)
+
+// ConfigFileName is the config file looked for in the working directory.
+const ConfigFileName = "mixology.toml"
+
+// Source records which layer supplied a setting. Later layers win: flags over
+// the environment, the environment over the config file, and the file over
+// built-in defaults.
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// Setting is one resolved value, as printed by config show.
+type Setting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source Source `json:"source"`
+	Env    string `json:"env"`
+}
+
+// setting binds a config file key to its environment variable, the global
+// flag every entry point spells it with (if any), and the Config field it
+// fills. Adding a setting here is all the file, the environment, and config
+// show need.
+type setting struct {
+	key   string
+	env   string
+	flag  string
+	field func(*Config) any
+	check func(float64) bool
+	rule  string
+}
+
+var settings = []setting{
+	{key: "database.path", env: EnvDatabasePath, flag: "db", field: func(c *Config) any { return &c.DatabasePath }},
+	{key: "database.read_only", env: EnvReadOnly, flag: "read-only", field: func(c *Config) any { return &c.ReadOnly }},
+	{key: "database.key_file", env: EnvKeyFile, flag: "key-file", field: func(c *Config) any { return &c.KeyFile }},
+	{key: "actor", env: EnvActor, flag: "actor", field: func(c *Config) any { return &c.Actor }},
+	{key: "log.level", env: EnvLogLevel, flag: "log-level", field: func(c *Config) any { return &c.LogLevel }},
+	{key: "log.format", env: EnvLogFormat, flag: "log-format", field: func(c *Config) any { return &c.LogFormat }},
+	{key: "log.file", env: EnvLogFile, flag: "log-file", field: func(c *Config) any { return &c.LogFile }},
+	{key: "metrics.enabled", env: EnvMetrics, flag: "metrics", field: func(c *Config) any { return &c.EnableMetrics }},
+	{key: "metrics.addr", env: EnvMetricsAddr, flag: "metrics-addr", field: func(c *Config) any { return &c.MetricsAddr }},
+	{key: "trace.file", env: EnvTraceFile, flag: "trace-file", field: func(c *Config) any { return &c.TraceFile }},
+	{
+		key: "inventory.low_stock_threshold", env: EnvLowStockThreshold,
+		field: func(c *Config) any { return &c.LowStockThreshold },
+		check: func(v float64) bool { return v >= 0 }, rule: "a number of at least 0",
+	},
+	{
+		key: "menus.target_margin", env: EnvTargetMargin,
+		field: func(c *Config) any { return &c.TargetMargin },
+		check: func(v float64) bool { return v > 0 && v < 1 }, rule: "a number between 0 and 1",
+	},
+	{key: "money.currency", env: EnvCurrency, field: func(c *Config) any { return &c.Currency }},
+	{key: "server.addr", env: EnvServerAddr, field: func(c *Config) any { return &c.ServerAddr }},
+}
+
+// SearchPaths lists where Load looks for a config file when none is named:
+// the working directory, then the user's configuration directory.
+func SearchPaths() []string {
+	paths := []string{ConfigFileName}
+	if dir, err := os.UserConfigDir(); err == nil {
+		paths = append(paths, filepath.Join(dir, "mixology", "config.toml"))
+	}
+	return paths
+}
+
+// Load layers the config file and then MIXOLOGY_* environment variables over
+// base, which holds the entry point's defaults. path names the config file
+// and must exist; when it is empty, MIXOLOGY_CONFIG or the first of
+// SearchPaths that exists is read, and finding none is not an error. Flags
+// are applied afterwards with ApplyFlags.
+func Load(path string, base Config) (Config, error) {
+	c := base
+	c.sources = make(map[string]Source, len(settings))
+	if path == "" {
+		path = os.Getenv(EnvConfigFile)
+	}
+	if path == "" {
+		for _, candidate := range SearchPaths() {
+			if _, err := os.Stat(candidate); err == nil {
+				path = candidate
+				break
+			}
+		}
+	} else if _, err := os.Stat(path); err != nil {
+		return Config{}, errors.NotFoundf("config file %s not found", path)
+	}
+	if path != "" {
+		if err := c.applyFile(path); err != nil {
+			return Config{}, err
+		}
+		c.ConfigFile = path
+	}
+	for _, s := range settings {
+		raw := os.Getenv(s.env)
+		if raw == "" {
+			continue
+		}
+		if err := c.set(s, raw, SourceEnv); err != nil {
+			return Config{}, errors.Invalidf("%s: %v", s.env, err)
+		}
+	}
+	return c, nil
+}
+
+func (c *Config) applyFile(path string) error {
+	var raw map[string]any
+	if _, err := toml.DecodeFile(path, &raw); err != nil {
+		return errors.Invalidf("read config file %s: %v", path, err)
+	}
+	values := make(map[string]any)
+	flatten("", raw, values)
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		s, ok := lookup(key)
+		if !ok {
+			return errors.Invalidf("config file %s: unknown setting %q", path, key)
+		}
+		text, err := fileValue(s, values[key])
+		if err == nil {
+			err = c.set(s, text, SourceFile)
+		}
+		if err != nil {
+			return errors.Invalidf("config file %s: %s: %v", path, key, err)
+		}
+	}
+	return nil
+}
+
+// ApplyFlags overrides settings with the flags lookup reports as given.
+// Flag libraries that also read MIXOLOGY_* variables report those values as
+// set too, so a flag value equal to the environment's keeps the env source.
+func (c *Config) ApplyFlags(lookup func(flag string) (string, bool)) error {
+	if c.sources == nil {
+		c.sources = make(map[string]Source, len(settings))
+	}
+	for _, s := range settings {
+		if s.flag == "" {
+			continue
+		}
+		raw, ok := lookup(s.flag)
+		if !ok {
+			continue
+		}
+		previous, source := s.format(c), c.Source(s.key)
+		if err := c.set(s, raw, SourceFlag); err != nil {
+			return errors.Invalidf("--%s: %v", s.flag, err)
+		}
+		if source == SourceEnv && s.format(c) == previous {
+			c.sources[s.key] = SourceEnv
+		}
+	}
+	return nil
+}
+
+// Source reports which layer supplied the setting named by its config file
+// key.
+func (c Config) Source(key string) Source {
+	if source, ok := c.sources[key]; ok {
+		return source
+	}
+	return SourceDefault
+}
+
+// Settings lists every setting in config file order with its effective value
+// and source.
+func (c Config) Settings() []Setting {
+	out := make([]Setting, 0, len(settings))
+	for _, s := range settings {
+		out = append(out, Setting{Key: s.key, Value: s.format(&c), Source: c.Source(s.key), Env: s.env})
+	}
+	return out
+}
+
+func (c *Config) set(s setting, raw string, source Source) error {
+	switch field := s.field(c).(type) {
+	case *string:
+		*field = raw
+	case *bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return errors.Invalidf("%q is not true or false", raw)
+		}
+		*field = value
+	case *float64:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) || (s.check != nil && !s.check(value)) {
+			return errors.Invalidf("%q is not %s", raw, s.rule)
+		}
+		*field = value
+	}
+	c.sources[s.key] = source
+	return nil
+}
+
+func (s setting) format(c *Config) string {
+	switch field := s.field(c).(type) {
+	case *string:
+		return *field
+	case *bool:
+		return strconv.FormatBool(*field)
+	case *float64:
+		return strconv.FormatFloat(*field, 'f', -1, 64)
+	}
+	return ""
+}
+
+// fileValue renders a decoded TOML value as the text set parses, refusing a
+// value whose TOML type does not match the setting.
+func fileValue(s setting, value any) (string, error) {
+	switch s.field(&Config{}).(type) {
+	case *string:
+		if text, ok := value.(string); ok {
+			return text, nil
+		}
+		return "", errors.Invalidf("expected a string")
+	case *bool:
+		if flag, ok := value.(bool); ok {
+			return strconv.FormatBool(flag), nil
+		}
+		return "", errors.Invalidf("expected true or false")
+	case *float64:
+		switch number := value.(type) {
+		case int64:
+			return strconv.FormatInt(number, 10), nil
+		case float64:
+			return strconv.FormatFloat(number, 'f', -1, 64), nil
+		}
+		return "", errors.Invalidf("expected a number")
+	}
+	return "", errors.Invalidf("unsupported setting")
+}
+
+func flatten(prefix string, table map[string]any, out map[string]any) {
+	for key, value := range table {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]any); ok {
+			flatten(key, nested, out)
+			continue
+		}
+		out[key] = value
+	}
+}
+
+func lookup(key string) (setting, bool) {
+	for _, s := range settings {
+		if s.key == key {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
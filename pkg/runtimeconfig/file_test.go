@@ -0,0 +1,120 @@
+//nolint:paralleltest // Load reads MIXOLOGY_* variables from the process environment.
+package runtimeconfig_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/runtimeconfig"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func writeConfig(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "mixology.toml")
+	testutil.Ok(t, os.WriteFile(path, []byte(body), 0o600))
+	return path
+}
+
+func flags(values map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := values[name]
+		return value, ok
+	}
+}
+
+func TestLoadLayersFlagsOverEnvOverFileOverDefaults(t *testing.T) {
+	path := writeConfig(t, `
+actor = "manager"
+
+[database]
+path = "bar.db"
+
+[log]
+level = "debug"
+format = "json"
+
+[metrics]
+addr = ":9191"
+
+[inventory]
+low_stock_threshold = 4
+
+[menus]
+target_margin = 0.65
+
+[money]
+currency = "EUR"
+`)
+	t.Setenv(runtimeconfig.EnvLogLevel, "warn")
+	t.Setenv(runtimeconfig.EnvActor, "bartender")
+
+	got, err := runtimeconfig.Load(path, runtimeconfig.Default())
+	testutil.Ok(t, err)
+	testutil.Ok(t, got.ApplyFlags(flags(map[string]string{"actor": "sommelier", "log-level": "warn"})))
+
+	testutil.Equals(t, got.ConfigFile, path)
+	testutil.Equals(t, got.DatabasePath, "bar.db")
+	testutil.Equals(t, got.LogFormat, "json")
+	testutil.Equals(t, got.MetricsAddr, ":9191")
+	testutil.Equals(t, got.LowStockThreshold, 4.0)
+	testutil.Equals(t, got.TargetMargin, 0.65)
+	testutil.Equals(t, got.Currency, "EUR")
+	testutil.Equals(t, got.LogLevel, "warn")
+	testutil.Equals(t, got.Actor, "sommelier")
+	testutil.Equals(t, got.LogFile, "")
+
+	testutil.Equals(t, got.Source("database.path"), runtimeconfig.SourceFile)
+	testutil.Equals(t, got.Source("log.level"), runtimeconfig.SourceEnv)
+	testutil.Equals(t, got.Source("actor"), runtimeconfig.SourceFlag)
+	testutil.Equals(t, got.Source("log.file"), runtimeconfig.SourceDefault)
+
+	settings := got.Settings()
+	testutil.Equals(t, settings[0], runtimeconfig.Setting{
+		Key: "database.path", Value: "bar.db", Source: runtimeconfig.SourceFile, Env: runtimeconfig.EnvDatabasePath,
+	})
+}
+
+func TestLoadWithoutFileKeepsDefaults(t *testing.T) {
+	t.Setenv(runtimeconfig.EnvConfigFile, "")
+	t.Chdir(t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	got, err := runtimeconfig.Load("", runtimeconfig.Default())
+	testutil.Ok(t, err)
+	testutil.Equals(t, got.ConfigFile, "")
+	testutil.Equals(t, got.TargetMargin, runtimeconfig.DefaultTargetMargin)
+	for _, setting := range got.Settings() {
+		testutil.Equals(t, setting.Source, runtimeconfig.SourceDefault)
+	}
+
+	testutil.Ok(t, os.WriteFile(runtimeconfig.ConfigFileName, []byte("actor = \"manager\"\n"), 0o600))
+	found, err := runtimeconfig.Load("", runtimeconfig.Default())
+	testutil.Ok(t, err)
+	testutil.Equals(t, found.ConfigFile, runtimeconfig.ConfigFileName)
+	testutil.Equals(t, found.Actor, "manager")
+}
+
+func TestLoadRejectsBadConfig(t *testing.T) {
+	_, err := runtimeconfig.Load(filepath.Join(t.TempDir(), "missing.toml"), runtimeconfig.Default())
+	testutil.ErrorIsNotFound(t, err)
+
+	for body, want := range map[string]string{
+		"[database]\npaht = \"x\"\n":            `unknown setting "database.paht"`,
+		"[metrics]\nenabled = \"yes\"\n":        "expected true or false",
+		"[menus]\ntarget_margin = 1.5\n":        "between 0 and 1",
+		"[inventory]\nlow_stock_threshold = -1": "at least 0",
+		"actor = ":                              "read config file",
+	} {
+		_, err := runtimeconfig.Load(writeConfig(t, body), runtimeconfig.Default())
+		testutil.ErrorIsInvalid(t, err)
+		testutil.ErrorContains(t, err, want)
+	}
+
+	t.Setenv(runtimeconfig.EnvMetrics, "sometimes")
+	_, err = runtimeconfig.Load(writeConfig(t, ""), runtimeconfig.Default())
+	testutil.ErrorIsInvalid(t, err)
+	testutil.ErrorContains(t, err, runtimeconfig.EnvMetrics)
+}
@@ -0,0 +1,358 @@
+// Package seal encrypts files at rest with a key derived from a passphrase or
+// key file. A sealed file is a short header followed by AES-256-GCM chunks, so
+// files of any size stream through a fixed buffer and a truncated or altered
+// file fails to open instead of yielding partial data.
+package seal
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+)
+
+const (
+	version    = 1
+	saltSize   = 16
+	checkSize  = sha256.Size
+	fileIDSize = 16
+	headerSize = len(magic) + 1 + saltSize + checkSize + fileIDSize
+	chunkSize  = 64 << 10
+	// iterations makes each guess at a passphrase cost as much as one
+	// PBKDF2-HMAC-SHA256 derivation at the OWASP recommended work factor.
+	iterations = 600_000
+
+	magic = "MIXSEAL\x00"
+)
+
+// Key is the secret that seals and unseals files. Deriving the encryption
+// key from the secret is deliberately slow, so a Key remembers what it has
+// derived and new files reuse the salt of the first derivation; each file
+// still gets its own cipher key from a random file ID.
+type Key struct {
+	secret string
+
+	mu      sync.Mutex
+	salt    []byte
+	masters map[string][]byte
+}
+
+// NewKey returns a key for secret, which may be a passphrase or random key
+// material.
+func NewKey(secret string) (*Key, error) {
+	if secret == "" {
+		return nil, errors.Invalidf("key must not be empty")
+	}
+	return &Key{secret: secret, masters: map[string][]byte{}}, nil
+}
+
+// LoadKey reads the key file at path. A trailing line break is not part of
+// the key, so a passphrase saved by an editor or echo works unchanged.
+func LoadKey(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("key file %s not found", path)
+	}
+	if err != nil {
+		return nil, errors.Internalf("read key file %s: %w", path, err)
+	}
+	secret := strings.TrimRight(string(data), "\r\n")
+	if secret == "" {
+		return nil, errors.Invalidf("key file %s is empty", path)
+	}
+	return NewKey(secret)
+}
+
+// master returns the key derived from the secret with salt.
+func (k *Key) master(salt []byte) []byte {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if master, ok := k.masters[string(salt)]; ok {
+		return master
+	}
+	master, err := pbkdf2.Key(sha256.New, k.secret, salt, iterations, 32)
+	if err != nil {
+		// Only an out-of-range key length fails, and 32 is in range.
+		panic(err)
+	}
+	k.masters[string(salt)] = master
+	if k.salt == nil {
+		k.salt = bytes.Clone(salt)
+	}
+	return master
+}
+
+// sealingSalt is the salt new files are sealed with.
+func (k *Key) sealingSalt() []byte {
+	k.mu.Lock()
+	salt := k.salt
+	k.mu.Unlock()
+	if salt == nil {
+		salt = make([]byte, saltSize)
+		_, _ = rand.Read(salt)
+	}
+	return salt
+}
+
+// check proves a master key without revealing it.
+func check(master []byte) []byte {
+	mac := hmac.New(sha256.New, master)
+	mac.Write([]byte("mixology seal check"))
+	return mac.Sum(nil)
+}
+
+func chunkCipher(master, fileID []byte) cipher.AEAD {
+	key, err := hkdf.Key(sha256.New, master, fileID, "mixology seal chunks", 32)
+	if err != nil {
+		panic(err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		panic(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		panic(err)
+	}
+	return aead
+}
+
+// chunkData authenticates a chunk together with the header and whether it is
+// the last chunk, so chunks cannot be moved between files or dropped from the
+// end.
+func chunkData(header []byte, final bool) []byte {
+	ad := append(bytes.Clone(header), 0)
+	if final {
+		ad[len(ad)-1] = 1
+	}
+	return ad
+}
+
+func chunkNonce(aead cipher.AEAD, seq uint64) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], seq)
+	return nonce
+}
+
+// IsSealed reports whether the file at path was written by a Writer.
+func IsSealed(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	header := make([]byte, len(magic))
+	n, _ := io.ReadFull(f, header)
+	return string(header[:n]) == magic, nil
+}
+
+// Writer seals everything written to it. Close writes the final chunk; a
+// file whose Writer was not closed does not open.
+type Writer struct {
+	w      io.Writer
+	aead   cipher.AEAD
+	header []byte
+	buf    []byte
+	seq    uint64
+}
+
+// NewWriter writes the header of a file sealed with key to w and returns a
+// Writer for its contents.
+func NewWriter(w io.Writer, key *Key) (*Writer, error) {
+	salt := key.sealingSalt()
+	master := key.master(salt)
+	fileID := make([]byte, fileIDSize)
+	_, _ = rand.Read(fileID)
+
+	header := make([]byte, 0, headerSize)
+	header = append(header, magic...)
+	header = append(header, version)
+	header = append(header, salt...)
+	header = append(header, check(master)...)
+	header = append(header, fileID...)
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+	return &Writer{
+		w:      w,
+		aead:   chunkCipher(master, fileID),
+		header: header,
+		buf:    make([]byte, 0, chunkSize),
+	}, nil
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	n := 0
+	for len(p) > 0 {
+		// A full chunk is written only once more data follows it, so the
+		// final chunk is always the one Close writes.
+		if len(w.buf) == chunkSize {
+			if err := w.flush(false); err != nil {
+				return n, err
+			}
+		}
+		m := min(chunkSize-len(w.buf), len(p))
+		w.buf = append(w.buf, p[:m]...)
+		p = p[m:]
+		n += m
+	}
+	return n, nil
+}
+
+// Close writes the final chunk. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	return w.flush(true)
+}
+
+func (w *Writer) flush(final bool) error {
+	sealed := w.aead.Seal(nil, chunkNonce(w.aead, w.seq), w.buf, chunkData(w.header, final))
+	w.seq++
+	w.buf = w.buf[:0]
+	_, err := w.w.Write(sealed)
+	return err
+}
+
+// Reader unseals a file written by a Writer.
+type Reader struct {
+	r      *bufio.Reader
+	name   string
+	aead   cipher.AEAD
+	header []byte
+	buf    []byte
+	plain  []byte
+	seq    uint64
+	done   bool
+}
+
+// NewReader reads the header of the sealed file r and checks that key
+// sealed it. name identifies the file in errors. A different key fails with
+// a Permission error before any contents are read.
+func NewReader(r io.Reader, key *Key, name string) (*Reader, error) {
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(r, header); err != nil || !bytes.HasPrefix(header, []byte(magic)) {
+		return nil, errors.Invalidf("%s is not sealed", name)
+	}
+	rest := header[len(magic):]
+	if rest[0] != version {
+		return nil, errors.Invalidf("%s is sealed with unsupported format version %d", name, rest[0])
+	}
+	salt := rest[1 : 1+saltSize]
+	want := rest[1+saltSize : 1+saltSize+checkSize]
+	fileID := rest[1+saltSize+checkSize:]
+	master := key.master(salt)
+	if !hmac.Equal(check(master), want) {
+		return nil, errors.Permissionf("%s is sealed with a different key or passphrase", name)
+	}
+	aead := chunkCipher(master, fileID)
+	return &Reader{
+		r:      bufio.NewReader(r),
+		name:   name,
+		aead:   aead,
+		header: header,
+		buf:    make([]byte, chunkSize+aead.Overhead()),
+	}, nil
+}
+
+func (r *Reader) Read(p []byte) (int, error) {
+	for len(r.plain) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.plain)
+	r.plain = r.plain[n:]
+	return n, nil
+}
+
+func (r *Reader) next() error {
+	n, err := io.ReadFull(r.r, r.buf)
+	final := false
+	switch err {
+	case nil:
+		if _, err := r.r.Peek(1); err == io.EOF {
+			final = true
+		} else if err != nil {
+			return errors.Internalf("read %s: %w", r.name, err)
+		}
+	case io.EOF, io.ErrUnexpectedEOF:
+		final = true
+	default:
+		return errors.Internalf("read %s: %w", r.name, err)
+	}
+	plain, err := r.aead.Open(nil, chunkNonce(r.aead, r.seq), r.buf[:n], chunkData(r.header, final))
+	if err != nil {
+		return errors.Invalidf("%s is corrupt or truncated", r.name)
+	}
+	r.seq++
+	r.plain = plain
+	r.done = final
+	return nil
+}
+
+// RekeyFile reseals the file at path, sealed with from, with to. The new file
+// is written beside path and renamed into place, so path is never partial.
+func RekeyFile(path string, from, to *Key) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return errors.Internalf("open %s: %w", path, err)
+	}
+	defer src.Close()
+	r, err := NewReader(src, from, path)
+	if err != nil {
+		return err
+	}
+	return WriteFile(path, to, func(w io.Writer) error {
+		_, err := io.Copy(w, r)
+		return err
+	})
+}
+
+// WriteFile seals what write produces with key into path. The file is
+// written beside path and renamed into place once sealed and synced.
+func WriteFile(path string, key *Key, write func(io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.seal")
+	if err != nil {
+		return errors.Internalf("create %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+	w, err := NewWriter(tmp, key)
+	if err == nil {
+		err = write(w)
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		var appErr *errors.Error
+		if errors.As(err, &appErr) {
+			return err
+		}
+		return errors.Internalf("seal %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return errors.Internalf("install %s: %w", path, err)
+	}
+	return nil
+}
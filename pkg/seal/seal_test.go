@@ -0,0 +1,128 @@
+package seal_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/seal"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func newKey(t *testing.T, secret string) *seal.Key {
+	t.Helper()
+	key, err := seal.NewKey(secret)
+	testutil.Ok(t, err)
+	return key
+}
+
+func sealBytes(t *testing.T, key *seal.Key, plain []byte) []byte {
+	t.Helper()
+	var sealed bytes.Buffer
+	w, err := seal.NewWriter(&sealed, key)
+	testutil.Ok(t, err)
+	_, err = w.Write(plain)
+	testutil.Ok(t, err)
+	testutil.Ok(t, w.Close())
+	return sealed.Bytes()
+}
+
+func unsealBytes(key *seal.Key, sealed []byte) ([]byte, error) {
+	r, err := seal.NewReader(bytes.NewReader(sealed), key, "test")
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func TestSealRoundTripsAcrossChunkBoundaries(t *testing.T) {
+	t.Parallel()
+
+	key := newKey(t, "correct horse battery staple")
+	const chunk = 64 << 10
+	for _, size := range []int{0, 1, chunk - 1, chunk, chunk + 1, 3 * chunk} {
+		plain := make([]byte, size)
+		_, _ = rand.Read(plain)
+		sealed := sealBytes(t, key, plain)
+		testutil.IsFalse(t, size > 16 && bytes.Contains(sealed, plain[:16]))
+
+		// A different Key with the same secret reads it, as another
+		// process would.
+		got, err := unsealBytes(newKey(t, "correct horse battery staple"), sealed)
+		testutil.Ok(t, err)
+		testutil.IsTrue(t, bytes.Equal(got, plain))
+	}
+}
+
+func TestSealRejectsWrongKeyAndDamage(t *testing.T) {
+	t.Parallel()
+
+	key := newKey(t, "right")
+	plain := make([]byte, 2*64<<10+100)
+	sealed := sealBytes(t, key, plain)
+
+	_, err := unsealBytes(newKey(t, "wrong"), sealed)
+	testutil.ErrorIsPermission(t, err)
+	testutil.ErrorContains(t, err, "different key or passphrase")
+
+	_, err = unsealBytes(key, sealed[:len(sealed)-1])
+	testutil.ErrorIsInvalid(t, err)
+	// Dropping the whole final chunk leaves only complete chunks, none of
+	// which was sealed as the last.
+	_, err = unsealBytes(key, sealed[:len(sealed)-(100+16)])
+	testutil.ErrorIsInvalid(t, err)
+
+	flipped := bytes.Clone(sealed)
+	flipped[len(flipped)/2] ^= 1
+	_, err = unsealBytes(key, flipped)
+	testutil.ErrorIsInvalid(t, err)
+
+	_, err = unsealBytes(key, []byte(`{"version":1}`))
+	testutil.ErrorIsInvalid(t, err)
+}
+
+func TestLoadKey(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	passphrase := filepath.Join(dir, "passphrase")
+	testutil.Ok(t, os.WriteFile(passphrase, []byte("shaken not stirred\n"), 0o600))
+	fromFile, err := seal.LoadKey(passphrase)
+	testutil.Ok(t, err)
+	got, err := unsealBytes(fromFile, sealBytes(t, newKey(t, "shaken not stirred"), []byte("martini")))
+	testutil.Ok(t, err)
+	testutil.Equals(t, string(got), "martini")
+
+	_, err = seal.LoadKey(filepath.Join(dir, "missing"))
+	testutil.ErrorIsNotFound(t, err)
+	empty := filepath.Join(dir, "empty")
+	testutil.Ok(t, os.WriteFile(empty, []byte("\n"), 0o600))
+	_, err = seal.LoadKey(empty)
+	testutil.ErrorIsInvalid(t, err)
+}
+
+func TestRekeyFile(t *testing.T) {
+	t.Parallel()
+
+	old, next := newKey(t, "old"), newKey(t, "new")
+	path := filepath.Join(t.TempDir(), "export.json")
+	testutil.Ok(t, os.WriteFile(path, sealBytes(t, old, []byte("menu")), 0o600))
+	sealed, err := seal.IsSealed(path)
+	testutil.Ok(t, err)
+	testutil.IsTrue(t, sealed)
+
+	testutil.Ok(t, seal.RekeyFile(path, old, next))
+	data, err := os.ReadFile(path)
+	testutil.Ok(t, err)
+	got, err := unsealBytes(next, data)
+	testutil.Ok(t, err)
+	testutil.Equals(t, string(got), "menu")
+	_, err = unsealBytes(old, data)
+	testutil.ErrorIsPermission(t, err)
+
+	// A file sealed with neither key is left alone.
+	testutil.ErrorIsPermission(t, seal.RekeyFile(path, old, next))
+}
@@ -4,30 +4,69 @@ import (
 	"sync"
 
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
-	"github.com/mjl-/bstore"
+	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
 )
 
 var transactionLocks sync.Map
 
-func registerTransaction(tx *bstore.Tx) {
-	transactionLocks.LoadOrStore(tx, &sync.Mutex{})
+// transactionState is the process-local bookkeeping for one open transaction.
+type transactionState struct {
+	mu sync.Mutex
+
+	callbacks   sync.Mutex
+	afterCommit []func()
+
+	rollbackOnly bool
+}
+
+func registerTransaction(tx *Tx) *transactionState {
+	value, _ := transactionLocks.LoadOrStore(tx, &transactionState{})
+	return value.(*transactionState)
 }
 
-func unregisterTransaction(tx *bstore.Tx) {
+func unregisterTransaction(tx *Tx) {
 	transactionLocks.Delete(tx)
 }
 
-func LockTransaction(tx *bstore.Tx) func() {
-	value, _ := transactionLocks.LoadOrStore(tx, &sync.Mutex{})
-	mu := value.(*sync.Mutex)
-	mu.Lock()
-	return mu.Unlock
+func LockTransaction(tx *Tx) func() {
+	state := registerTransaction(tx)
+	state.mu.Lock()
+	return state.mu.Unlock
+}
+
+// AfterCommit runs fn once tx commits through this package, in registration
+// order and after the commit has returned. A transaction that rolls back
+// discards fn. Callbacks run on the committing goroutine and should hand
+// slow work to another one.
+func AfterCommit(tx *Tx, fn func()) {
+	state := registerTransaction(tx)
+	state.callbacks.Lock()
+	defer state.callbacks.Unlock()
+	state.afterCommit = append(state.afterCommit, fn)
+}
+
+// RollsBack reports whether tx was opened under RollbackOnly and so will
+// roll back where it would have committed.
+func RollsBack(tx *Tx) bool {
+	return registerTransaction(tx).rollbackOnly
+}
+
+func (s *transactionState) committed() {
+	s.callbacks.Lock()
+	callbacks := s.afterCommit
+	s.afterCommit = nil
+	s.callbacks.Unlock()
+	for _, fn := range callbacks {
+		fn()
+	}
 }
 
 // Read executes f within a read transaction.
 // If a transaction exists in context, uses it. Otherwise creates a new read tx.
-func (s *Store) ReadContext(ctx Context, f func(*bstore.Tx) error) error {
+func (s *Store) ReadContext(ctx Context, f func(*Tx) error) (err error) {
 	if tx, ok := ctx.Transaction(); ok && tx != nil {
+		_, span := telemetry.StartSpan(ctx, telemetry.SpanStoreRead, transactionAttr("existing"))
+		defer func() { telemetry.EndSpan(span, err) }()
 		return f(tx)
 	}
 	return s.Read(ctx, f)
@@ -35,10 +74,12 @@ func (s *Store) ReadContext(ctx Context, f func(*bstore.Tx) error) error {
 
 // Write executes f within the existing write transaction.
 // Requires a transaction in context (set by UnitOfWork middleware).
-func Write(ctx Context, f func(*bstore.Tx) error) error {
+func Write(ctx Context, f func(*Tx) error) (err error) {
 	tx, ok := ctx.Transaction()
 	if !ok || tx == nil {
 		return errors.Internalf("missing transaction")
 	}
+	_, span := telemetry.StartSpan(ctx, telemetry.SpanStoreWrite, transactionAttr("existing"))
+	defer func() { telemetry.EndSpan(span, err) }()
 	return f(tx)
 }
@@ -0,0 +1,90 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+)
+
+// Backend names the storage engine behind a Store.
+type Backend string
+
+const (
+	// BackendBstore is the embedded bstore/bbolt database and the default.
+	BackendBstore Backend = "bstore"
+	// BackendSQLite is a pure-Go SQLite database with one table per row type,
+	// readable by ordinary SQL tools.
+	BackendSQLite Backend = "sqlite"
+)
+
+// sqliteMagic starts every SQLite database file.
+var sqliteMagic = []byte("SQLite format 3\x00")
+
+// BackendFor reports which backend Open uses for path. An existing database
+// is recognized by its contents, so copies keep their engine whatever they are
+// named. A new database is SQLite when path ends in .sqlite or .sqlite3 and
+// bstore otherwise. A sealed database hides its engine until it is unsealed.
+func BackendFor(path string) (Backend, error) {
+	if sealed, err := isSealed(path); err != nil {
+		return "", err
+	} else if sealed {
+		return "", errors.FailedPreconditionf("database %s is sealed", path)
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return backendForName(path), nil
+	}
+	if err != nil {
+		return "", errors.Internalf("open database %s: %w", path, err)
+	}
+	defer f.Close()
+	header := make([]byte, len(sqliteMagic))
+	n, _ := io.ReadFull(f, header)
+	switch {
+	case bytes.Equal(header[:n], sqliteMagic):
+		return BackendSQLite, nil
+	case n == 0:
+		return backendForName(path), nil
+	}
+	return BackendBstore, nil
+}
+
+func backendForName(path string) Backend {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".sqlite", ".sqlite3":
+		return BackendSQLite
+	}
+	return BackendBstore
+}
+
+// backend is one storage engine. Store owns transaction bookkeeping, managed
+// transactions and telemetry; a backend only stores rows.
+type backend interface {
+	register(ctx context.Context, models ...any) error
+	begin(ctx context.Context, writable bool) (txBackend, error)
+	close() error
+	// snapshot writes a consistent copy of the database into the empty file
+	// at path while other transactions continue.
+	snapshot(ctx context.Context, path string) error
+	// checkRecords decodes every stored record and counts them per type.
+	checkRecords(ctx context.Context) (map[string]int, error)
+	// install replaces the database file with the one at from and closes the
+	// backend.
+	install(from, path string) error
+}
+
+// txBackend is one backend transaction. Each method takes a pointer to a
+// registered row; its first field is the primary key.
+type txBackend interface {
+	get(value any) error
+	insert(value any) error
+	update(value any) error
+	delete(value any) error
+	commit() error
+	rollback() error
+}
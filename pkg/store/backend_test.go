@@ -0,0 +1,218 @@
+package store
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	testutil "github.com/TheFellow/go-modular-monolith/pkg/testutil/assert"
+)
+
+type contractRecord struct {
+	ID        int64
+	Name      string `bstore:"unique"`
+	Group     string `bstore:"index"`
+	Score     int
+	Removed   *time.Time
+	Labels    []string
+	CreatedAt time.Time `bstore:"index"`
+}
+
+type contractKeyed struct {
+	Key   string
+	Value string
+}
+
+func openContractStore(t *testing.T, file string) *Store {
+	t.Helper()
+	ctx := context.Background()
+	s, err := Open(ctx, filepath.Join(t.TempDir(), file))
+	testutil.ErrorIf(t, err != nil, "open store: %v", err)
+	t.Cleanup(func() { _ = s.Close() })
+	s.Register(ctx, contractRecord{}, contractKeyed{})
+	return s
+}
+
+func TestBackendForSniffsContentsBeforeName(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for name, want := range map[string]Backend{
+		"new.db":      BackendBstore,
+		"new.sqlite":  BackendSQLite,
+		"new.SQLite3": BackendSQLite,
+	} {
+		got, err := BackendFor(filepath.Join(dir, name))
+		testutil.ErrorIf(t, err != nil || got != want, "BackendFor(%s) = %s, %v; want %s", name, got, err, want)
+	}
+
+	ctx := context.Background()
+	s, err := Open(ctx, filepath.Join(dir, "data.sqlite"))
+	testutil.ErrorIf(t, err != nil, "open sqlite: %v", err)
+	s.Register(ctx, contractKeyed{})
+	copied := filepath.Join(dir, "copy.db")
+	testutil.ErrorIf(t, s.CopyTo(ctx, copied) != nil, "%v", "copy sqlite store")
+	testutil.ErrorIf(t, s.Close() != nil, "%v", "close sqlite store")
+
+	got, err := BackendFor(copied)
+	testutil.ErrorIf(t, err != nil || got != BackendSQLite, "BackendFor(copy.db) = %s, %v; want sqlite", got, err)
+	testutil.ErrorIf(t, os.WriteFile(filepath.Join(dir, "empty.sqlite"), nil, 0o644) != nil, "%v", "write empty file")
+	got, err = BackendFor(filepath.Join(dir, "empty.sqlite"))
+	testutil.ErrorIf(t, err != nil || got != BackendSQLite, "BackendFor(empty.sqlite) = %s, %v; want sqlite", got, err)
+}
+
+func TestBackendsReportTheSameStorageErrors(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, file string) {
+		ctx := context.Background()
+		s := openContractStore(t, file)
+
+		var first contractRecord
+		err := s.Write(ctx, func(tx *Tx) error {
+			first = contractRecord{Name: "gin", Group: "spirit"}
+			if err := tx.Insert(&first); err != nil {
+				return err
+			}
+			return tx.Insert(&contractRecord{Name: "rum", Group: "spirit"})
+		})
+		testutil.ErrorIf(t, err != nil, "insert: %v", err)
+		testutil.ErrorIf(t, first.ID != 1, "assigned ID = %d, want 1", first.ID)
+
+		err = s.Write(ctx, func(tx *Tx) error {
+			return tx.Insert(&contractRecord{Name: "gin"})
+		})
+		testutil.ErrorIf(t, !errors.Is(err, ErrUnique), "duplicate unique index error = %v", err)
+		testutil.ErrorIf(t, !errors.IsConflict(MapError(err, "record")), "mapped duplicate = %v", MapError(err, "record"))
+
+		err = s.Write(ctx, func(tx *Tx) error {
+			return tx.Insert(&contractRecord{ID: first.ID, Name: "vodka"})
+		})
+		testutil.ErrorIf(t, !errors.Is(err, ErrUnique), "duplicate primary key error = %v", err)
+
+		err = s.Write(ctx, func(tx *Tx) error {
+			return tx.Insert(&contractKeyed{})
+		})
+		testutil.ErrorIf(t, !errors.Is(err, ErrZero), "zero string key error = %v", err)
+
+		err = s.Read(ctx, func(tx *Tx) error {
+			return tx.Get(&contractRecord{ID: 99})
+		})
+		testutil.ErrorIf(t, !errors.Is(err, ErrAbsent), "missing get error = %v", err)
+		testutil.ErrorIf(t, !errors.IsNotFound(MapError(err, "record")), "mapped missing = %v", MapError(err, "record"))
+
+		err = s.Write(ctx, func(tx *Tx) error {
+			return tx.Update(&contractRecord{ID: 99, Name: "missing"})
+		})
+		testutil.ErrorIf(t, !errors.Is(err, ErrAbsent), "missing update error = %v", err)
+		err = s.Write(ctx, func(tx *Tx) error {
+			return tx.Delete(&contractRecord{ID: 99})
+		})
+		testutil.ErrorIf(t, !errors.Is(err, ErrAbsent), "missing delete error = %v", err)
+
+		err = s.Read(ctx, func(tx *Tx) error {
+			_, err := QueryTx[contractRecord](tx).FilterEqual("Group", "spirit").Get()
+			return err
+		})
+		testutil.ErrorIf(t, !errors.Is(err, ErrMultiple), "multiple get error = %v", err)
+		err = s.Read(ctx, func(tx *Tx) error {
+			_, err := QueryTx[contractRecord](tx).FilterEqual("Group", "mixer").Get()
+			return err
+		})
+		testutil.ErrorIf(t, !errors.Is(err, ErrAbsent), "empty get error = %v", err)
+	})
+}
+
+func TestBackendsAgreeOnQueries(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, file string) {
+		ctx := context.Background()
+		s := openContractStore(t, file)
+		removed := time.Date(2026, 5, 1, 9, 30, 0, 0, time.UTC)
+		base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		err := s.Write(ctx, func(tx *Tx) error {
+			for i, r := range []contractRecord{
+				{Name: "gin", Group: "spirit", Score: 3, Labels: []string{"dry"}},
+				{Name: "rum", Group: "spirit", Score: 1, Removed: &removed},
+				{Name: "cola", Group: "mixer", Score: 2},
+				{Name: "lime", Group: "garnish", Score: 2},
+			} {
+				r.CreatedAt = base.Add(time.Duration(i) * time.Hour)
+				if err := tx.Insert(&r); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		testutil.ErrorIf(t, err != nil, "insert: %v", err)
+
+		names := func(rows []contractRecord) []string {
+			out := make([]string, len(rows))
+			for i, r := range rows {
+				out[i] = r.Name
+			}
+			return out
+		}
+		err = s.Read(ctx, func(tx *Tx) error {
+			rows, err := QueryTx[contractRecord](tx).FilterNotEqual("Group", "garnish").SortDesc("Score").List()
+			testutil.ErrorIf(t, err != nil, "list: %v", err)
+			testutil.ErrorIf(t, !slices.Equal(names(rows), []string{"gin", "cola", "rum"}), "got %v", names(rows))
+
+			rows, err = QueryTx[contractRecord](tx).FilterGreaterEqual("CreatedAt", base.Add(time.Hour)).
+				FilterLess("Score", 3).SortAsc("Score", "Name").Limit(2).List()
+			testutil.ErrorIf(t, err != nil, "list compared: %v", err)
+			testutil.ErrorIf(t, !slices.Equal(names(rows), []string{"rum", "cola"}), "got %v", names(rows))
+
+			rows, err = QueryTx[contractRecord](tx).FilterIDs([]int64{1, 3}).
+				FilterFn(func(r contractRecord) bool { return r.Group == "mixer" }).List()
+			testutil.ErrorIf(t, err != nil, "list ids: %v", err)
+			testutil.ErrorIf(t, !slices.Equal(names(rows), []string{"cola"}), "got %v", names(rows))
+
+			rum, err := QueryTx[contractRecord](tx).FilterEqual("Name", "rum").Get()
+			testutil.ErrorIf(t, err != nil, "get rum: %v", err)
+			testutil.ErrorIf(t, rum.Removed == nil || !rum.Removed.Equal(removed), "removed = %v, want %v", rum.Removed, removed)
+			gin := contractRecord{ID: 1}
+			testutil.ErrorIf(t, tx.Get(&gin) != nil, "%v", "get gin")
+			testutil.ErrorIf(t, !slices.Equal(gin.Labels, []string{"dry"}), "got %v", gin.Labels)
+			testutil.ErrorIf(t, gin.Removed != nil, "gin removed = %v, want nil", gin.Removed)
+			return nil
+		})
+		testutil.ErrorIf(t, err != nil, "read: %v", err)
+
+		err = s.Write(ctx, func(tx *Tx) error {
+			n, err := QueryTx[contractRecord](tx).FilterEqual("Score", 2).UpdateField("Group", "other")
+			testutil.ErrorIf(t, err != nil || n != 2, "update field = %d, %v", n, err)
+			n, err = QueryTx[contractRecord](tx).FilterEqual("Group", "spirit").Delete()
+			testutil.ErrorIf(t, err != nil || n != 2, "delete = %d, %v", n, err)
+			return nil
+		})
+		testutil.ErrorIf(t, err != nil, "write: %v", err)
+		err = s.Read(ctx, func(tx *Tx) error {
+			n, err := QueryTx[contractRecord](tx).FilterEqual("Group", "other").Count()
+			testutil.ErrorIf(t, err != nil || n != 2, "count = %d, %v", n, err)
+			n, err = QueryTx[contractRecord](tx).Count()
+			testutil.ErrorIf(t, err != nil || n != 2, "total = %d, %v", n, err)
+			return nil
+		})
+		testutil.ErrorIf(t, err != nil, "read after write: %v", err)
+	})
+}
+
+func TestRestoreRefusesSnapshotOfOtherBackend(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := openContractStore(t, "store.sqlite")
+	other, err := Open(ctx, filepath.Join(dir, "other.db"))
+	testutil.ErrorIf(t, err != nil, "open bstore: %v", err)
+	testutil.ErrorIf(t, other.Close() != nil, "%v", "close bstore")
+
+	err = s.Restore(filepath.Join(dir, "other.db"))
+	testutil.ErrorIf(t, !errors.IsInvalid(err), "restore error = %v, want invalid", err)
+}
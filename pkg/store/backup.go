@@ -0,0 +1,221 @@
+package store
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+)
+
+const (
+	backupPrefix = "mixology-"
+	backupSuffix = ".db"
+	// backupTimeLayout sorts lexically in time order and is safe in file
+	// names on every platform.
+	backupTimeLayout = "20060102T150405.000Z"
+)
+
+// Backup is one snapshot file in a backup directory. Its name records when
+// it was taken, so a directory listing needs no separate catalog.
+type Backup struct {
+	Name      string
+	Path      string
+	CreatedAt time.Time
+	Size      int64
+}
+
+// Backup writes a consistent snapshot of the database into dir, named for
+// now, while other transactions continue. An existing snapshot with the same
+// name is never overwritten.
+func (s *Store) Backup(ctx context.Context, dir string, now time.Time) (Backup, error) {
+	now = now.UTC()
+	name := backupPrefix + now.Format(backupTimeLayout) + backupSuffix
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); err == nil {
+		return Backup{}, errors.Conflictf("backup %s already exists", name)
+	}
+	if err := s.CopyTo(ctx, path); err != nil {
+		return Backup{}, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return Backup{}, errors.Internalf("stat backup %s: %w", name, err)
+	}
+	return Backup{Name: name, Path: path, CreatedAt: now, Size: info.Size()}, nil
+}
+
+// ListBackups returns the snapshots in dir, newest first. A missing directory
+// holds no backups. Files whose names Backup did not produce are ignored.
+func ListBackups(dir string) ([]Backup, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Internalf("read backup directory: %w", err)
+	}
+	var backups []Backup
+	for _, entry := range entries {
+		createdAt, ok := parseBackupName(entry.Name())
+		if !ok || !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, errors.Internalf("stat backup %s: %w", entry.Name(), err)
+		}
+		backups = append(backups, Backup{
+			Name:      entry.Name(),
+			Path:      filepath.Join(dir, entry.Name()),
+			CreatedAt: createdAt,
+			Size:      info.Size(),
+		})
+	}
+	slices.SortFunc(backups, func(a, b Backup) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	return backups, nil
+}
+
+// FindBackup returns the snapshot in dir with the given name.
+func FindBackup(dir, name string) (Backup, error) {
+	backups, err := ListBackups(dir)
+	if err != nil {
+		return Backup{}, err
+	}
+	for _, backup := range backups {
+		if backup.Name == name {
+			return backup, nil
+		}
+	}
+	return Backup{}, errors.NotFoundf("backup %s not found in %s", name, dir)
+}
+
+// PruneBackups deletes all but the newest keep snapshots in dir and returns
+// the ones it removed.
+func PruneBackups(dir string, keep int) ([]Backup, error) {
+	if keep < 1 {
+		return nil, errors.Invalidf("keep must be at least 1")
+	}
+	backups, err := ListBackups(dir)
+	if err != nil || len(backups) <= keep {
+		return nil, err
+	}
+	removed := backups[keep:]
+	for _, backup := range removed {
+		if err := os.Remove(backup.Path); err != nil {
+			return nil, errors.Internalf("remove backup %s: %w", backup.Name, err)
+		}
+	}
+	return removed, nil
+}
+
+func parseBackupName(name string) (time.Time, bool) {
+	stamp, ok := strings.CutPrefix(name, backupPrefix)
+	if !ok {
+		return time.Time{}, false
+	}
+	stamp, ok = strings.CutSuffix(stamp, backupSuffix)
+	if !ok {
+		return time.Time{}, false
+	}
+	createdAt, err := time.Parse(backupTimeLayout, stamp)
+	return createdAt, err == nil
+}
+
+// Restore replaces the database s has open with the snapshot at from and
+// closes s. The snapshot is copied beside the database and renamed into
+// place, so a failed restore leaves the original intact. A sealed snapshot
+// is unsealed with the store's key, and a sealed database is sealed again.
+func (s *Store) Restore(from string) error {
+	if s.readOnly {
+		return errors.FailedPreconditionf("database %s is open read-only", s.path)
+	}
+	if _, err := os.Stat(from); os.IsNotExist(err) {
+		return errors.NotFoundf("backup %s not found", from)
+	}
+	name := from
+	sealed, err := isSealed(from)
+	if err != nil {
+		return err
+	}
+	if sealed {
+		if err := requireKey(from, s.key); err != nil {
+			return err
+		}
+		dir, claim, err := workingDir(context.Background(), s.path)
+		if err != nil {
+			return err
+		}
+		defer releaseWorking(dir, claim)
+		plain := filepath.Join(dir, filepath.Base(s.path))
+		if err := unsealFile(from, plain, s.key); err != nil {
+			return err
+		}
+		from = plain
+	}
+	if kind, err := BackendFor(from); err != nil {
+		return err
+	} else if kind != s.kind {
+		return errors.Invalidf("backup %s is a %s database; %s is %s", name, kind, s.path, s.kind)
+	}
+	if s.working != "" {
+		return s.restoreSealed(from)
+	}
+
+	src, err := os.Open(from)
+	if err != nil {
+		return errors.Internalf("open backup: %w", err)
+	}
+	defer src.Close()
+	dir := filepath.Dir(s.path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".*.restore")
+	if err != nil {
+		return errors.Internalf("create restored database: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = io.Copy(tmp, src)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return errors.Internalf("copy backup: %w", err)
+	}
+	if err := s.backend.install(tmp.Name(), s.path); err != nil {
+		return errors.Internalf("install restored database: %w", err)
+	}
+	return nil
+}
+
+// restoreSealed seals the unsealed snapshot at from over the database and
+// drops the working copy without sealing it back.
+func (s *Store) restoreSealed(from string) error {
+	s.resealMu.Lock()
+	defer s.resealMu.Unlock()
+	if err := sealFile(from, s.path, s.key); err != nil {
+		return err
+	}
+	err := s.backend.close()
+	s.dropWorking(true)
+	if err != nil {
+		return errors.Internalf("close restored database: %w", err)
+	}
+	return nil
+}
+
+// CheckRecords decodes every record of every type stored in the database and
+// returns the number of records per type. A record that cannot be decoded
+// fails the check.
+func (s *Store) CheckRecords(ctx context.Context) (map[string]int, error) {
+	counts, err := s.backend.checkRecords(ctx)
+	if err != nil {
+		return nil, errors.Internalf("check records: %w", err)
+	}
+	return counts, nil
+}
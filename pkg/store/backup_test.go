@@ -0,0 +1,108 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	testutil "github.com/TheFellow/go-modular-monolith/pkg/testutil/assert"
+)
+
+type backupRecord struct {
+	ID   int
+	Name string
+}
+
+func openBackupStore(t *testing.T, path string) *Store {
+	t.Helper()
+	ctx := context.Background()
+	s, err := Open(ctx, path)
+	testutil.ErrorIf(t, err != nil, "open store: %v", err)
+	s.Register(ctx, backupRecord{})
+	return s
+}
+
+func insertBackupRecord(t *testing.T, s *Store, name string) {
+	t.Helper()
+	err := s.Write(context.Background(), func(tx *Tx) error {
+		return tx.Insert(&backupRecord{Name: name})
+	})
+	testutil.ErrorIf(t, err != nil, "insert %s: %v", name, err)
+}
+
+func TestBackupListsNewestFirstAndPrunesOldest(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, file string) {
+		ctx := context.Background()
+		root := t.TempDir()
+		dir := filepath.Join(root, "backups")
+		s := openBackupStore(t, filepath.Join(root, file))
+		t.Cleanup(func() { _ = s.Close() })
+		insertBackupRecord(t, s, "first")
+
+		start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+		for i := range 3 {
+			_, err := s.Backup(ctx, dir, start.Add(time.Duration(i)*time.Minute))
+			testutil.ErrorIf(t, err != nil, "backup %d: %v", i, err)
+		}
+		{
+			_, err := s.Backup(ctx, dir, start)
+			testutil.ErrorIf(t, !errors.IsConflict(err), "duplicate backup error = %v", err)
+		}
+
+		backups, err := ListBackups(dir)
+		testutil.ErrorIf(t, err != nil, "list backups: %v", err)
+		testutil.ErrorIf(t, len(backups) != 3, "backups = %d, want 3", len(backups))
+		testutil.ErrorIf(t, !backups[0].CreatedAt.Equal(start.Add(2*time.Minute)), "newest backup = %v", backups[0].CreatedAt)
+
+		removed, err := PruneBackups(dir, 2)
+		testutil.ErrorIf(t, err != nil, "prune backups: %v", err)
+		testutil.ErrorIf(t, len(removed) != 1 || !removed[0].CreatedAt.Equal(start), "removed = %#v, want the oldest backup", removed)
+		{
+			_, err := FindBackup(dir, removed[0].Name)
+			testutil.ErrorIf(t, !errors.IsNotFound(err), "pruned backup lookup error = %v", err)
+		}
+		{
+			_, err := PruneBackups(dir, 0)
+			testutil.ErrorIf(t, !errors.IsInvalid(err), "keep 0 error = %v", err)
+		}
+		{
+			missing, err := ListBackups(filepath.Join(root, "missing"))
+			testutil.ErrorIf(t, err != nil || len(missing) != 0, "missing directory = %#v, %v", missing, err)
+		}
+	})
+}
+
+func TestRestoreReplacesDatabaseWithSnapshot(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, file string) {
+		ctx := context.Background()
+		root := t.TempDir()
+		path := filepath.Join(root, file)
+		s := openBackupStore(t, path)
+		insertBackupRecord(t, s, "kept")
+		backup, err := s.Backup(ctx, filepath.Join(root, "backups"), time.Now())
+		testutil.ErrorIf(t, err != nil, "backup: %v", err)
+		insertBackupRecord(t, s, "discarded")
+
+		{
+			counts, err := s.CheckRecords(ctx)
+			testutil.ErrorIf(t, err != nil, "check records: %v", err)
+			testutil.ErrorIf(t, counts["backupRecord"] != 2, "live counts = %v", counts)
+		}
+		{
+			err := s.Restore(backup.Path)
+			testutil.ErrorIf(t, err != nil, "restore: %v", err)
+		}
+
+		restored := openBackupStore(t, path)
+		t.Cleanup(func() { _ = restored.Close() })
+		counts, err := restored.CheckRecords(ctx)
+		testutil.ErrorIf(t, err != nil, "check restored records: %v", err)
+		testutil.ErrorIf(t, counts["backupRecord"] != 1, "restored counts = %v, want only the snapshot's record", counts)
+	})
+}
@@ -0,0 +1,195 @@
+package store
+
+import (
+	"context"
+	"iter"
+	"os"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/mjl-/bstore"
+)
+
+// bstoreBackend keeps rows in an embedded bstore database, which holds an
+// exclusive lock on its file while open.
+type bstoreBackend struct {
+	db *bstore.DB
+}
+
+func openBstore(ctx context.Context, path string) (*bstoreBackend, error) {
+	db, err := bstore.Open(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &bstoreBackend{db: db}, nil
+}
+
+func (b *bstoreBackend) register(ctx context.Context, models ...any) error {
+	return b.db.Register(ctx, models...)
+}
+
+func (b *bstoreBackend) begin(ctx context.Context, writable bool) (txBackend, error) {
+	tx, err := b.db.Begin(ctx, writable)
+	if err != nil {
+		return nil, bstoreError(err)
+	}
+	return &bstoreTx{tx: tx}, nil
+}
+
+func (b *bstoreBackend) close() error {
+	return b.db.Close()
+}
+
+func (b *bstoreBackend) snapshot(ctx context.Context, path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		return err
+	}
+	err = b.db.Read(ctx, func(tx *bstore.Tx) error {
+		_, err := tx.WriteTo(f)
+		return err
+	})
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func (b *bstoreBackend) checkRecords(ctx context.Context) (map[string]int, error) {
+	counts := make(map[string]int)
+	err := b.db.Read(ctx, func(tx *bstore.Tx) error {
+		types, err := tx.Types()
+		if err != nil {
+			return err
+		}
+		for _, name := range types {
+			var fields []string
+			err := tx.Records(name, &fields, func(map[string]any) error {
+				counts[name]++
+				return nil
+			})
+			if err != nil {
+				return errors.Internalf("read %s records: %w", name, err)
+			}
+		}
+		return nil
+	})
+	return counts, err
+}
+
+// install renames the new file into place before closing, so the lock this
+// backend holds keeps other processes out until the swap is done.
+func (b *bstoreBackend) install(from, path string) error {
+	if err := os.Rename(from, path); err != nil {
+		return err
+	}
+	return b.close()
+}
+
+type bstoreTx struct {
+	tx *bstore.Tx
+}
+
+func (t *bstoreTx) get(value any) error    { return bstoreError(t.tx.Get(value)) }
+func (t *bstoreTx) insert(value any) error { return bstoreError(t.tx.Insert(value)) }
+func (t *bstoreTx) update(value any) error { return bstoreError(t.tx.Update(value)) }
+func (t *bstoreTx) delete(value any) error { return bstoreError(t.tx.Delete(value)) }
+func (t *bstoreTx) commit() error          { return bstoreError(t.tx.Commit()) }
+func (t *bstoreTx) rollback() error        { return bstoreError(t.tx.Rollback()) }
+
+// bstoreError matches bstore's failures to the package sentinels.
+func bstoreError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, bstore.ErrAbsent):
+		return classify(ErrAbsent, err)
+	case errors.Is(err, bstore.ErrUnique):
+		return classify(ErrUnique, err)
+	case errors.Is(err, bstore.ErrZero):
+		return classify(ErrZero, err)
+	case errors.Is(err, bstore.ErrMultiple):
+		return classify(ErrMultiple, err)
+	}
+	return err
+}
+
+// bstoreQuery forwards to bstore's own query, which plans filters and sorts
+// against its indexes.
+type bstoreQuery[T any] struct {
+	q *bstore.Query[T]
+}
+
+func newBstoreQuery[T any](tx *bstoreTx) *bstoreQuery[T] {
+	return &bstoreQuery[T]{q: bstore.QueryTx[T](tx.tx)}
+}
+
+func (q *bstoreQuery[T]) filterEqual(field string, values []any, not bool) {
+	if not {
+		q.q.FilterNotEqual(field, values...)
+		return
+	}
+	q.q.FilterEqual(field, values...)
+}
+
+func (q *bstoreQuery[T]) filterCompare(field string, op compareOp, value any) {
+	switch op {
+	case opGreater:
+		q.q.FilterGreater(field, value)
+	case opGreaterEqual:
+		q.q.FilterGreaterEqual(field, value)
+	case opLess:
+		q.q.FilterLess(field, value)
+	case opLessEqual:
+		q.q.FilterLessEqual(field, value)
+	}
+}
+
+func (q *bstoreQuery[T]) filterID(id any)          { q.q.FilterID(id) }
+func (q *bstoreQuery[T]) filterIDs(ids any)        { q.q.FilterIDs(ids) }
+func (q *bstoreQuery[T]) filterFn(fn func(T) bool) { q.q.FilterFn(fn) }
+func (q *bstoreQuery[T]) limit(n int)              { q.q.Limit(n) }
+func (q *bstoreQuery[T]) sort(fields []string, asc bool) {
+	if asc {
+		q.q.SortAsc(fields...)
+		return
+	}
+	q.q.SortDesc(fields...)
+}
+
+func (q *bstoreQuery[T]) list() ([]T, error) {
+	rows, err := q.q.List()
+	return rows, bstoreError(err)
+}
+
+func (q *bstoreQuery[T]) get() (T, error) {
+	row, err := q.q.Get()
+	return row, bstoreError(err)
+}
+
+func (q *bstoreQuery[T]) count() (int, error) {
+	n, err := q.q.Count()
+	return n, bstoreError(err)
+}
+
+func (q *bstoreQuery[T]) delete() (int, error) {
+	n, err := q.q.Delete()
+	return n, bstoreError(err)
+}
+
+func (q *bstoreQuery[T]) updateField(field string, value any) (int, error) {
+	n, err := q.q.UpdateField(field, value)
+	return n, bstoreError(err)
+}
+
+func (q *bstoreQuery[T]) all() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for row, err := range q.q.All() {
+			if !yield(row, bstoreError(err)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package store
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+)
+
+// CopyTo writes a consistent snapshot of the database to path while other
+// transactions continue. The copy is written beside path and renamed into
+// place, so path never holds a partial database. A store opened with a key
+// writes a sealed copy.
+func (s *Store) CopyTo(ctx context.Context, path string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Internalf("mkdir %s: %w", dir, err)
+	}
+	if s.key != nil {
+		return s.sealCopy(ctx, path, s.key)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return errors.Internalf("create database copy: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Close(); err != nil {
+		return errors.Internalf("create database copy: %w", err)
+	}
+
+	if err := s.backend.snapshot(ctx, tmp.Name()); err != nil {
+		return errors.Internalf("copy database: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return errors.Internalf("install database copy: %w", err)
+	}
+	return nil
+}
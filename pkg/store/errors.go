@@ -2,22 +2,49 @@ package store
 
 import (
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
-	"github.com/mjl-/bstore"
 )
 
-// MapError converts bstore errors to domain errors.
+// Storage failures every backend reports in the same way. DAOs test for them
+// with errors.Is and translate them at their boundary with MapError.
+var (
+	// ErrAbsent is returned when a record or query selection does not exist.
+	ErrAbsent = errors.New("record absent")
+	// ErrUnique is returned when a write would duplicate a primary key or a
+	// unique index.
+	ErrUnique = errors.New("record already exists")
+	// ErrZero is returned when a record needs a key it does not have.
+	ErrZero = errors.New("zero primary key")
+	// ErrMultiple is returned by Query.Get when more than one record matches.
+	ErrMultiple = errors.New("multiple records selected")
+)
+
+// backendError keeps a backend's own message while matching the shared
+// sentinel it corresponds to.
+type backendError struct {
+	kind error
+	err  error
+}
+
+func (e *backendError) Error() string   { return e.err.Error() }
+func (e *backendError) Unwrap() []error { return []error{e.kind, e.err} }
+
+func classify(kind, err error) error {
+	return &backendError{kind: kind, err: err}
+}
+
+// MapError converts storage errors to domain errors.
 // Use in DAO methods to ensure consistent error handling.
 func MapError(err error, format string, args ...any) error {
 	if err == nil {
 		return nil
 	}
-	if errors.Is(err, bstore.ErrAbsent) {
+	if errors.Is(err, ErrAbsent) {
 		return errors.NotFoundf(format, args...)
 	}
-	if errors.Is(err, bstore.ErrUnique) {
+	if errors.Is(err, ErrUnique) {
 		return errors.Conflictf(format, args...)
 	}
-	if errors.Is(err, bstore.ErrZero) {
+	if errors.Is(err, ErrZero) {
 		return errors.Invalidf(format, args...)
 	}
 	return errors.Internalf(format+": %w", append(args, err)...)
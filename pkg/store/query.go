@@ -0,0 +1,193 @@
+package store
+
+import (
+	"iter"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+)
+
+// Query selects rows of type T inside a transaction. Filters narrow the
+// selection and are pushed into the backend where it can evaluate them;
+// FilterFn always runs in Go. Like bstore's query, each method changes the
+// query in place and returns it for chaining, and the first invalid call is
+// reported by the terminal method.
+type Query[T any] struct {
+	impl queryBackend[T]
+}
+
+// queryBackend is a backend's implementation of one query.
+type queryBackend[T any] interface {
+	filterEqual(field string, values []any, not bool)
+	filterCompare(field string, op compareOp, value any)
+	filterID(id any)
+	filterIDs(ids any)
+	filterFn(fn func(T) bool)
+	sort(fields []string, asc bool)
+	limit(n int)
+
+	list() ([]T, error)
+	get() (T, error)
+	count() (int, error)
+	all() iter.Seq2[T, error]
+	delete() (int, error)
+	updateField(field string, value any) (int, error)
+}
+
+type compareOp string
+
+const (
+	opGreater      compareOp = ">"
+	opGreaterEqual compareOp = ">="
+	opLess         compareOp = "<"
+	opLessEqual    compareOp = "<="
+)
+
+// QueryTx starts a query for rows of type T in tx.
+func QueryTx[T any](tx *Tx) *Query[T] {
+	switch b := tx.backend.(type) {
+	case *bstoreTx:
+		return &Query[T]{impl: newBstoreQuery[T](b)}
+	case *sqliteTx:
+		return &Query[T]{impl: newSQLiteQuery[T](b)}
+	}
+	return &Query[T]{impl: failedQuery[T]{err: errors.Internalf("store: unsupported transaction %T", tx.backend)}}
+}
+
+// FilterEqual selects rows whose field equals one of values.
+func (q *Query[T]) FilterEqual(field string, values ...any) *Query[T] {
+	q.impl.filterEqual(field, values, false)
+	return q
+}
+
+// FilterNotEqual selects rows whose field equals none of values.
+func (q *Query[T]) FilterNotEqual(field string, values ...any) *Query[T] {
+	q.impl.filterEqual(field, values, true)
+	return q
+}
+
+// FilterGreater selects rows whose field is greater than value.
+func (q *Query[T]) FilterGreater(field string, value any) *Query[T] {
+	q.impl.filterCompare(field, opGreater, value)
+	return q
+}
+
+// FilterGreaterEqual selects rows whose field is at least value.
+func (q *Query[T]) FilterGreaterEqual(field string, value any) *Query[T] {
+	q.impl.filterCompare(field, opGreaterEqual, value)
+	return q
+}
+
+// FilterLess selects rows whose field is less than value.
+func (q *Query[T]) FilterLess(field string, value any) *Query[T] {
+	q.impl.filterCompare(field, opLess, value)
+	return q
+}
+
+// FilterLessEqual selects rows whose field is at most value.
+func (q *Query[T]) FilterLessEqual(field string, value any) *Query[T] {
+	q.impl.filterCompare(field, opLessEqual, value)
+	return q
+}
+
+// FilterID selects the row whose primary key is id.
+func (q *Query[T]) FilterID(id any) *Query[T] {
+	q.impl.filterID(id)
+	return q
+}
+
+// FilterIDs selects rows whose primary key is in ids, a slice of the primary
+// key's type.
+func (q *Query[T]) FilterIDs(ids any) *Query[T] {
+	q.impl.filterIDs(ids)
+	return q
+}
+
+// FilterFn selects rows for which fn returns true. It runs after every
+// backend filter.
+func (q *Query[T]) FilterFn(fn func(T) bool) *Query[T] {
+	q.impl.filterFn(fn)
+	return q
+}
+
+// SortAsc orders rows by fields, ascending. Later calls add further orderings.
+// Rows are in primary key order otherwise.
+func (q *Query[T]) SortAsc(fields ...string) *Query[T] {
+	q.impl.sort(fields, true)
+	return q
+}
+
+// SortDesc orders rows by fields, descending.
+func (q *Query[T]) SortDesc(fields ...string) *Query[T] {
+	q.impl.sort(fields, false)
+	return q
+}
+
+// Limit returns at most n rows. n must be at least 1.
+func (q *Query[T]) Limit(n int) *Query[T] {
+	q.impl.limit(n)
+	return q
+}
+
+// List returns the selected rows.
+func (q *Query[T]) List() ([]T, error) {
+	return q.impl.list()
+}
+
+// Get returns the single selected row.
+//
+// ErrAbsent is returned if no row was selected and ErrMultiple if more than
+// one was.
+func (q *Query[T]) Get() (T, error) {
+	return q.impl.get()
+}
+
+// Count returns the number of selected rows.
+func (q *Query[T]) Count() (int, error) {
+	return q.impl.count()
+}
+
+// All iterates the selected rows. Changes made while iterating are not
+// necessarily reflected in the rows yielded.
+func (q *Query[T]) All() iter.Seq2[T, error] {
+	return q.impl.all()
+}
+
+// Delete removes the selected rows and returns how many there were.
+func (q *Query[T]) Delete() (int, error) {
+	return q.impl.delete()
+}
+
+// UpdateField sets field to value on every selected row and returns how many
+// rows it changed.
+func (q *Query[T]) UpdateField(field string, value any) (int, error) {
+	return q.impl.updateField(field, value)
+}
+
+// failedQuery reports an error from every terminal method.
+type failedQuery[T any] struct {
+	err error
+}
+
+func (q failedQuery[T]) filterEqual(string, []any, bool)      {}
+func (q failedQuery[T]) filterCompare(string, compareOp, any) {}
+func (q failedQuery[T]) filterID(any)                         {}
+func (q failedQuery[T]) filterIDs(any)                        {}
+func (q failedQuery[T]) filterFn(func(T) bool)                {}
+func (q failedQuery[T]) sort([]string, bool)                  {}
+func (q failedQuery[T]) limit(int)                            {}
+func (q failedQuery[T]) list() ([]T, error)                   { return nil, q.err }
+func (q failedQuery[T]) count() (int, error)                  { return 0, q.err }
+func (q failedQuery[T]) delete() (int, error)                 { return 0, q.err }
+func (q failedQuery[T]) updateField(string, any) (int, error) { return 0, q.err }
+
+func (q failedQuery[T]) get() (T, error) {
+	var zero T
+	return zero, q.err
+}
+
+func (q failedQuery[T]) all() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		yield(zero, q.err)
+	}
+}
@@ -0,0 +1,166 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/seal"
+)
+
+const (
+	// snapshotAttempts bounds how often a copy is retried while a writer
+	// keeps committing during it.
+	snapshotAttempts = 10
+	snapshotRetry    = 50 * time.Millisecond
+
+	// bboltMagic and bboltPageSizeOffset locate the page size in the meta
+	// page at the start of every bbolt file, after the 16-byte page header.
+	bboltMagic          = 0xED0CDAED
+	bboltMagicOffset    = 16
+	bboltPageSizeOffset = 24
+)
+
+func openReadOnly(ctx context.Context, path string, key *seal.Key) (*Store, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, errors.NotFoundf("database %s not found", path)
+	} else if err != nil {
+		return nil, errors.Internalf("stat database %s: %w", path, err)
+	}
+	if sealed, err := isSealed(path); err != nil {
+		return nil, err
+	} else if sealed {
+		return openSealedReadOnly(ctx, path, key)
+	}
+	kind, err := BackendFor(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &Store{kind: kind, path: path, readOnly: true, key: key}
+	if kind == BackendSQLite {
+		s.backend, err = openSQLite(ctx, path, true)
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+
+	s.snapshot, err = os.MkdirTemp("", "mixology-read-only-*")
+	if err != nil {
+		return nil, errors.Internalf("create snapshot directory: %w", err)
+	}
+	copied := filepath.Join(s.snapshot, filepath.Base(path))
+	if err := copyBstore(path, copied); err != nil {
+		_ = os.RemoveAll(s.snapshot)
+		return nil, err
+	}
+	s.backend, err = openBstore(ctx, copied)
+	if err != nil {
+		_ = os.RemoveAll(s.snapshot)
+		return nil, errors.Internalf("open snapshot of %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// openSealedReadOnly unseals the database into a private snapshot, a working
+// directory like a writer's. A writer replaces the sealed file whole on every
+// seal, so it is always consistent and needs no lock; the snapshot holds the
+// commits sealed by then.
+func openSealedReadOnly(ctx context.Context, path string, key *seal.Key) (*Store, error) {
+	if err := requireKey(path, key); err != nil {
+		return nil, err
+	}
+	if err := removeStaleWorking(ctx, path, key, false); err != nil {
+		return nil, err
+	}
+	s := &Store{path: path, readOnly: true, sealed: true, key: key}
+	var err error
+	if s.snapshot, s.claim, err = workingDir(ctx, path); err != nil {
+		return nil, err
+	}
+	copied := filepath.Join(s.snapshot, filepath.Base(path))
+	if err := unsealFile(path, copied, key); err != nil {
+		releaseWorking(s.snapshot, s.claim)
+		return nil, err
+	}
+	if s.kind, err = BackendFor(copied); err == nil {
+		s.backend, err = openBackend(ctx, s.kind, copied, true)
+	}
+	if err != nil {
+		releaseWorking(s.snapshot, s.claim)
+		return nil, err
+	}
+	return s, nil
+}
+
+// copyBstore copies the bbolt file at path to dst without taking the lock a
+// writer may hold. bbolt commits by writing a meta page after the pages it
+// references, and never overwrites pages the newest meta reaches, so a copy
+// is consistent when both meta pages are unchanged across it.
+func copyBstore(path, dst string) error {
+	for range snapshotAttempts {
+		before, err := bboltMeta(path)
+		if err != nil {
+			return err
+		}
+		if err := copyFile(path, dst); err != nil {
+			return errors.Internalf("copy database %s: %w", path, err)
+		}
+		after, err := bboltMeta(path)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(before, after) {
+			return nil
+		}
+		time.Sleep(snapshotRetry)
+	}
+	return errors.FailedPreconditionf("database %s changed during every attempt to copy it", path)
+}
+
+// bboltMeta returns the two meta pages at the start of the bbolt file at path.
+func bboltMeta(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Internalf("open database %s: %w", path, err)
+	}
+	defer f.Close()
+	header := make([]byte, bboltPageSizeOffset+4)
+	if _, err := io.ReadFull(f, header); err != nil {
+		return nil, errors.Invalidf("database %s is not a bstore database", path)
+	}
+	if binary.NativeEndian.Uint32(header[bboltMagicOffset:]) != bboltMagic {
+		return nil, errors.Invalidf("database %s is not a bstore database", path)
+	}
+	pageSize := int64(binary.NativeEndian.Uint32(header[bboltPageSizeOffset:]))
+	if pageSize < 512 || pageSize > 1<<20 {
+		return nil, errors.Invalidf("database %s has page size %d", path, pageSize)
+	}
+	meta := make([]byte, 2*pageSize)
+	if _, err := f.ReadAt(meta, 0); err != nil {
+		return nil, errors.Internalf("read database %s: %w", path, err)
+	}
+	return meta, nil
+}
+
+func copyFile(from, to string) error {
+	src, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(to, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, src)
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
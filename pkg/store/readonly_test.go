@@ -0,0 +1,144 @@
+package store
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	testutil "github.com/TheFellow/go-modular-monolith/pkg/testutil/assert"
+)
+
+func TestReadOnlyReadsAlongsideWriter(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, file string) {
+		ctx := context.Background()
+		path := filepath.Join(t.TempDir(), file)
+		writer, err := Open(ctx, path)
+		testutil.ErrorIf(t, err != nil, "open writer: %v", err)
+		t.Cleanup(func() { _ = writer.Close() })
+		writer.Register(ctx, contractKeyed{})
+		err = writer.Write(ctx, func(tx *Tx) error {
+			return tx.Insert(&contractKeyed{Key: "before", Value: "1"})
+		})
+		testutil.ErrorIf(t, err != nil, "insert before: %v", err)
+
+		reader, err := Open(ctx, path, ReadOnly())
+		testutil.ErrorIf(t, err != nil, "open read-only: %v", err)
+		reader.Register(ctx, contractKeyed{})
+		testutil.ErrorIf(t, !reader.ReadOnly(), "%v", "store is not read-only")
+		testutil.ErrorIf(t, reader.Snapshot() != (reader.Backend() == BackendBstore), "snapshot = %v for %s", reader.Snapshot(), reader.Backend())
+
+		// The writer keeps committing while the reader is open.
+		err = writer.Write(ctx, func(tx *Tx) error {
+			return tx.Insert(&contractKeyed{Key: "after", Value: "2"})
+		})
+		testutil.ErrorIf(t, err != nil, "insert after: %v", err)
+
+		err = reader.Read(ctx, func(tx *Tx) error {
+			return tx.Get(&contractKeyed{Key: "before"})
+		})
+		testutil.ErrorIf(t, err != nil, "read before: %v", err)
+		err = reader.Read(ctx, func(tx *Tx) error {
+			return tx.Get(&contractKeyed{Key: "after"})
+		})
+		testutil.ErrorIf(t, reader.Snapshot() == (err == nil), "read after from snapshot=%v: %v", reader.Snapshot(), err)
+
+		err = reader.Write(ctx, func(tx *Tx) error {
+			return tx.Insert(&contractKeyed{Key: "refused"})
+		})
+		testutil.ErrorIf(t, !errors.IsFailedPrecondition(err), "write error = %v, want failed precondition", err)
+		err = reader.CopyTo(ctx, filepath.Join(t.TempDir(), "copy"+filepath.Ext(file)))
+		testutil.ErrorIf(t, err != nil, "copy read-only store: %v", err)
+		err = reader.Restore(path)
+		testutil.ErrorIf(t, !errors.IsFailedPrecondition(err), "restore error = %v, want failed precondition", err)
+
+		snapshot := reader.snapshot
+		testutil.ErrorIf(t, reader.Close() != nil, "%v", "close read-only store")
+		if snapshot != "" {
+			_, err := os.Stat(snapshot)
+			testutil.ErrorIf(t, !os.IsNotExist(err), "snapshot %s remains after close: %v", snapshot, err)
+		}
+	})
+}
+
+func TestReadOnlyRefusesMissingDatabase(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, file string) {
+		path := filepath.Join(t.TempDir(), file)
+		_, err := Open(context.Background(), path, ReadOnly())
+		testutil.ErrorIf(t, !errors.IsNotFound(err), "open error = %v, want not found", err)
+		_, err = os.Stat(path)
+		testutil.ErrorIf(t, !os.IsNotExist(err), "read-only open created %s: %v", path, err)
+	})
+}
+
+func TestReadOnlySnapshotIsConsistentDuringWrites(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "busy.db")
+	writer, err := Open(ctx, path)
+	testutil.ErrorIf(t, err != nil, "open writer: %v", err)
+	t.Cleanup(func() { _ = writer.Close() })
+	writer.Register(ctx, contractRecord{})
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			_ = writer.Write(ctx, func(tx *Tx) error {
+				return tx.Insert(&contractRecord{Labels: []string{"busy"}})
+			})
+		}
+	})
+	defer func() {
+		close(stop)
+		wg.Wait()
+	}()
+
+	for range 5 {
+		reader, err := Open(ctx, path, ReadOnly())
+		testutil.ErrorIf(t, err != nil, "open read-only: %v", err)
+		reader.Register(ctx, contractRecord{})
+		_, err = reader.CheckRecords(ctx)
+		testutil.ErrorIf(t, err != nil, "check snapshot: %v", err)
+		testutil.ErrorIf(t, reader.Close() != nil, "%v", "close read-only store")
+	}
+}
+
+func TestReadOnlySQLiteReportsTablesItCannotRead(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "old.sqlite")
+	writer, err := Open(ctx, path)
+	testutil.ErrorIf(t, err != nil, "open writer: %v", err)
+	writer.Register(ctx, contractKeyed{})
+	testutil.ErrorIf(t, writer.Close() != nil, "%v", "close writer")
+
+	reader, err := Open(ctx, path, ReadOnly())
+	testutil.ErrorIf(t, err != nil, "open read-only: %v", err)
+	t.Cleanup(func() { _ = reader.Close() })
+	reader.Register(ctx, contractKeyed{}, contractRecord{})
+
+	err = reader.Read(ctx, func(tx *Tx) error {
+		_, err := QueryTx[contractKeyed](tx).Count()
+		return err
+	})
+	testutil.ErrorIf(t, err != nil, "read existing table: %v", err)
+	err = reader.Read(ctx, func(tx *Tx) error {
+		_, err := QueryTx[contractRecord](tx).Count()
+		return err
+	})
+	testutil.ErrorIf(t, !errors.IsFailedPrecondition(err), "read missing table error = %v, want failed precondition", err)
+}
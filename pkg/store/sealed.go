@@ -0,0 +1,534 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/seal"
+	"github.com/mjl-/bstore"
+)
+
+// Sealed supplies the key for sealed databases. With a key, a new database is
+// created sealed, an existing sealed database opens, and CopyTo and Backup
+// write sealed copies. An existing database that is not sealed stays as it is
+// until Seal converts it. A nil key is ignored.
+func Sealed(key *seal.Key) Option {
+	return func(o *options) { o.key = key }
+}
+
+// Key is the key the store was opened with, or nil.
+func (s *Store) Key() *seal.Key {
+	return s.key
+}
+
+// IsSealed reports whether the database file is sealed. The store itself works
+// on an unsealed copy in a private directory, kept in memory where the host
+// has a memory-backed filesystem, and seals the whole copy back over the file
+// once per batch of commits rather than after each one.
+func (s *Store) IsSealed() bool {
+	return s.working != "" || (s.readOnly && s.sealed)
+}
+
+func isSealed(path string) (bool, error) {
+	sealed, err := seal.IsSealed(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Internalf("open database %s: %w", path, err)
+	}
+	return sealed, nil
+}
+
+func requireKey(path string, key *seal.Key) error {
+	if key == nil {
+		return errors.FailedPreconditionf("database %s is sealed; open it with its key", path)
+	}
+	return nil
+}
+
+// openSealed opens the sealed database at path, or creates one when create is
+// set. The file is replaced on every seal, so its lock lives in a sibling
+// file that stays put, held the same way bstore holds its own.
+func openSealed(ctx context.Context, path string, key *seal.Key, create bool) (*Store, error) {
+	if err := requireKey(path, key); err != nil {
+		return nil, err
+	}
+	lock, err := bstore.Open(ctx, path+".lock", nil)
+	if err != nil {
+		return nil, errors.Internalf("lock database %s: %w", path, err)
+	}
+	s := &Store{path: path, key: key, lock: lock}
+	// Only a key that opens the file may seal a stale copy over it.
+	if err := removeStaleWorking(ctx, path, key, create || opens(path, key)); err != nil {
+		_ = lock.Close()
+		return nil, err
+	}
+	if err := s.openWorking(ctx, create); err != nil {
+		_ = lock.Close()
+		releaseWorking(s.working, s.claim)
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Store) openWorking(ctx context.Context, create bool) error {
+	var err error
+	if s.working, s.claim, err = workingDir(ctx, s.path); err != nil {
+		return err
+	}
+	plain := filepath.Join(s.working, filepath.Base(s.path))
+	if !create {
+		if err := unsealFile(s.path, plain, s.key); err != nil {
+			return err
+		}
+	}
+	if s.kind, err = BackendFor(plain); err != nil {
+		return err
+	}
+	if s.backend, err = openBackend(ctx, s.kind, plain, false); err != nil {
+		return err
+	}
+	if create {
+		s.markDirty()
+		return s.reseal(ctx)
+	}
+	return nil
+}
+
+const (
+	// sealDelay is how long a commit waits before the working copy is sealed
+	// over the database file, so a burst of commits is sealed once.
+	sealDelay = time.Second
+
+	// memoryRoot is the memory-backed filesystem preferred for working
+	// copies, where the host has one.
+	memoryRoot = "/dev/shm"
+
+	// claimFile is held open by the store using a working directory, and
+	// dirtyFile marks a working copy holding commits not yet sealed.
+	claimFile = "claim"
+	dirtyFile = "dirty"
+
+	// claimGrace is how long a working directory may go unclaimed before it
+	// counts as stale; a directory is unclaimed while it is being created.
+	claimGrace = time.Minute
+)
+
+// markDirty records that the working copy holds changes the database file
+// does not, and schedules a seal unless one is already pending.
+func (s *Store) markDirty() {
+	s.sealMu.Lock()
+	defer s.sealMu.Unlock()
+	s.commits++
+	if !s.dirty {
+		s.dirty = true
+		_ = os.WriteFile(filepath.Join(s.working, dirtyFile), nil, 0o600)
+	}
+	if s.pending == nil {
+		s.pending = time.AfterFunc(sealDelay, func() { _ = s.reseal(context.Background()) })
+	}
+}
+
+// workingDir creates and claims a private directory for a plaintext copy of
+// the sealed database at path. It goes on the memory-backed filesystem when
+// the host has one, so the plaintext never reaches persistent disk, and
+// beside the database otherwise; either way only the owner can read it.
+func workingDir(ctx context.Context, path string) (string, *bstore.DB, error) {
+	var dir string
+	var err error
+	for _, root := range workingRoots(path) {
+		if dir, err = os.MkdirTemp(root, workingPrefix(path)+"*"); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return "", nil, errors.Internalf("create working directory: %w", err)
+	}
+	if err := os.Chmod(dir, 0o700); err != nil {
+		_ = os.RemoveAll(dir)
+		return "", nil, errors.Internalf("create working directory: %w", err)
+	}
+	claim, err := bstore.Open(ctx, filepath.Join(dir, claimFile), nil)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return "", nil, errors.Internalf("claim working directory: %w", err)
+	}
+	return dir, claim, nil
+}
+
+// releaseWorking gives up the claim on a working directory and removes it.
+func releaseWorking(dir string, claim *bstore.DB) {
+	if claim != nil {
+		_ = claim.Close()
+	}
+	if dir != "" {
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func workingRoots(path string) []string {
+	roots := []string{filepath.Dir(path)}
+	if info, err := os.Stat(memoryRoot); err == nil && info.IsDir() {
+		roots = append([]string{memoryRoot}, roots...)
+	}
+	return roots
+}
+
+// workingPrefix names the working directories of the database at path. The
+// memory-backed filesystem is shared by every database on the host, so the
+// name carries a digest of the database's absolute path.
+func workingPrefix(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	sum := sha256.Sum256([]byte(path))
+	return "." + filepath.Base(path) + "." + hex.EncodeToString(sum[:6]) + ".work-"
+}
+
+// removeStaleWorking deletes the working directories that processes which
+// stopped without closing the database left behind, plaintext that would
+// otherwise stay on the host. A directory some store still claims is live and
+// stays. A stale copy holding commits that were never sealed is sealed over
+// the database first when recover is set, which only a caller holding the
+// database lock may do; otherwise it is left for that writer.
+func removeStaleWorking(ctx context.Context, path string, key *seal.Key, recover bool) error {
+	for _, root := range workingRoots(path) {
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			return errors.Internalf("open database %s: %w", path, err)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() || !strings.HasPrefix(entry.Name(), workingPrefix(path)) {
+				continue
+			}
+			dir := filepath.Join(root, entry.Name())
+			if !staleWorking(ctx, dir) {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(dir, dirtyFile)); err == nil {
+				if !recover {
+					continue
+				}
+				if err := recoverWorking(ctx, dir, path, key); err != nil {
+					return err
+				}
+			}
+			if err := os.RemoveAll(dir); err != nil {
+				return errors.Internalf("remove stale working copy: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// staleWorking reports whether no store claims the working directory.
+func staleWorking(ctx context.Context, dir string) bool {
+	claim, err := bstore.Open(ctx, filepath.Join(dir, claimFile), &bstore.Options{Timeout: time.Millisecond, MustExist: true})
+	if err == nil {
+		_ = claim.Close()
+		return true
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return false
+	}
+	info, err := os.Stat(dir)
+	return err == nil && time.Since(info.ModTime()) > claimGrace
+}
+
+// recoverWorking seals the commits a stopped process left unsealed in dir
+// over the database at path.
+func recoverWorking(ctx context.Context, dir, path string, key *seal.Key) error {
+	plain := filepath.Join(dir, filepath.Base(path))
+	kind, err := BackendFor(plain)
+	if err != nil {
+		return err
+	}
+	b, err := openBackend(ctx, kind, plain, false)
+	if err != nil {
+		return err
+	}
+	defer b.close()
+	orphan := &Store{backend: b, working: dir}
+	if err := orphan.sealCopy(ctx, path, key); err != nil {
+		return errors.Internalf("recover unsealed changes in %s: %w", dir, err)
+	}
+	return nil
+}
+
+// reseal seals the working copy over the database file. Commits made while it
+// runs stay dirty for the next seal, and the copy stays dirty until a seal
+// succeeds.
+func (s *Store) reseal(ctx context.Context) error {
+	s.resealMu.Lock()
+	defer s.resealMu.Unlock()
+	s.sealMu.Lock()
+	if s.pending != nil {
+		s.pending.Stop()
+		s.pending = nil
+	}
+	working, commits := s.working, s.commits
+	s.sealMu.Unlock()
+	if working == "" {
+		return nil
+	}
+	err := s.sealCopy(ctx, s.path, s.key)
+	s.sealMu.Lock()
+	defer s.sealMu.Unlock()
+	if err != nil {
+		s.sealErr = err
+		return err
+	}
+	s.sealErr = nil
+	if s.commits == commits {
+		s.dirty = false
+		_ = os.Remove(filepath.Join(working, dirtyFile))
+	}
+	return nil
+}
+
+// Flush seals commits still waiting for their batch over the database file
+// now. It does nothing for a database that is not sealed.
+func (s *Store) Flush(ctx context.Context) error {
+	s.sealMu.Lock()
+	dirty := s.dirty
+	s.sealMu.Unlock()
+	if !dirty {
+		return nil
+	}
+	return s.reseal(ctx)
+}
+
+// SealError reports why committed changes of a sealed database could not be
+// sealed over its file, or nil when the last attempt succeeded. Those changes
+// are safe in the working copy until the next batch, Flush or Close seals
+// them.
+func (s *Store) SealError() error {
+	s.sealMu.Lock()
+	defer s.sealMu.Unlock()
+	if !s.dirty {
+		return nil
+	}
+	return s.sealErr
+}
+
+// sealCopy writes a consistent snapshot of the database to path, sealed with
+// key. The unsealed snapshot goes to the working directory, never beside path.
+func (s *Store) sealCopy(ctx context.Context, path string, key *seal.Key) error {
+	plain, err := os.CreateTemp(s.working, "snapshot-*")
+	if err != nil {
+		return errors.Internalf("create database copy: %w", err)
+	}
+	defer os.Remove(plain.Name())
+	if err := plain.Close(); err != nil {
+		return errors.Internalf("create database copy: %w", err)
+	}
+	if err := s.backend.snapshot(ctx, plain.Name()); err != nil {
+		return errors.Internalf("copy database: %w", err)
+	}
+	return sealFile(plain.Name(), path, key)
+}
+
+func sealFile(from, to string, key *seal.Key) error {
+	src, err := os.Open(from)
+	if err != nil {
+		return errors.Internalf("open %s: %w", from, err)
+	}
+	defer src.Close()
+	return seal.WriteFile(to, key, func(w io.Writer) error {
+		_, err := io.Copy(w, src)
+		return err
+	})
+}
+
+func unsealFile(from, to string, key *seal.Key) error {
+	src, err := os.Open(from)
+	if err != nil {
+		return errors.Internalf("open %s: %w", from, err)
+	}
+	defer src.Close()
+	r, err := seal.NewReader(src, key, from)
+	if err != nil {
+		return err
+	}
+	dst, err := os.OpenFile(to, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return errors.Internalf("create %s: %w", to, err)
+	}
+	_, err = io.Copy(dst, r)
+	if cerr := dst.Close(); err == nil && cerr != nil {
+		err = errors.Internalf("write %s: %w", to, cerr)
+	}
+	return err
+}
+
+// Seal converts the open database to a sealed one under the store's key.
+// Other processes must not have the database open while it runs.
+func (s *Store) Seal(ctx context.Context) error {
+	switch {
+	case s.readOnly:
+		return errors.FailedPreconditionf("database %s is open read-only", s.path)
+	case s.key == nil:
+		return errors.FailedPreconditionf("a key is required to seal database %s", s.path)
+	case s.working != "":
+		return errors.FailedPreconditionf("database %s is already sealed", s.path)
+	}
+	s.resealMu.Lock()
+	defer s.resealMu.Unlock()
+
+	lock, err := bstore.Open(ctx, s.path+".lock", nil)
+	if err != nil {
+		return errors.Internalf("lock database %s: %w", s.path, err)
+	}
+	if err := removeStaleWorking(ctx, s.path, s.key, false); err != nil {
+		_ = lock.Close()
+		return err
+	}
+	working, claim, err := workingDir(ctx, s.path)
+	if err != nil {
+		_ = lock.Close()
+		return err
+	}
+	plain := filepath.Join(working, filepath.Base(s.path))
+	next, err := func() (backend, error) {
+		if err := os.WriteFile(plain, nil, 0o600); err != nil {
+			return nil, errors.Internalf("create working copy: %w", err)
+		}
+		if err := s.backend.snapshot(ctx, plain); err != nil {
+			return nil, errors.Internalf("copy database: %w", err)
+		}
+		if err := sealFile(plain, s.path, s.key); err != nil {
+			return nil, err
+		}
+		next, err := openBackend(ctx, s.kind, plain, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, models := range s.models {
+			if err := next.register(ctx, models...); err != nil {
+				_ = next.close()
+				return nil, err
+			}
+		}
+		return next, nil
+	}()
+	if err != nil {
+		releaseWorking(working, claim)
+		_ = lock.Close()
+		return err
+	}
+	// The sealed file has replaced the plain one; closing the old backend
+	// only releases it.
+	_ = s.backend.close()
+	s.sealMu.Lock()
+	s.backend, s.working, s.claim, s.lock = next, working, claim, lock
+	s.sealMu.Unlock()
+	return nil
+}
+
+// Rekey seals the database with key from now on and reseals it at once.
+func (s *Store) Rekey(ctx context.Context, key *seal.Key) error {
+	if s.working == "" {
+		return errors.FailedPreconditionf("database %s is not sealed", s.path)
+	}
+	s.resealMu.Lock()
+	defer s.resealMu.Unlock()
+	s.sealMu.Lock()
+	commits := s.commits
+	s.sealMu.Unlock()
+	if err := s.sealCopy(ctx, s.path, key); err != nil {
+		return err
+	}
+	s.key = key
+	s.sealMu.Lock()
+	defer s.sealMu.Unlock()
+	s.sealErr = nil
+	if s.commits == commits {
+		s.dirty = false
+		_ = os.Remove(filepath.Join(s.working, dirtyFile))
+	}
+	return nil
+}
+
+// RekeyBackups reseals the sealed backups in dir with to and returns the ones
+// it changed. Backups that are not sealed, or are already sealed with to, are
+// left alone, so an interrupted rotation can be rerun.
+func RekeyBackups(dir string, from, to *seal.Key) ([]Backup, error) {
+	backups, err := ListBackups(dir)
+	if err != nil {
+		return nil, err
+	}
+	var rekeyed []Backup
+	for _, backup := range backups {
+		sealed, err := isSealed(backup.Path)
+		if err != nil {
+			return rekeyed, err
+		}
+		if !sealed || opens(backup.Path, to) {
+			continue
+		}
+		if err := seal.RekeyFile(backup.Path, from, to); err != nil {
+			return rekeyed, err
+		}
+		rekeyed = append(rekeyed, backup)
+	}
+	return rekeyed, nil
+}
+
+// opens reports whether key unseals the sealed file at path.
+func opens(path string, key *seal.Key) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	_, err = seal.NewReader(f, key, path)
+	return err == nil
+}
+
+// closeSealed seals unsaved changes back over the database and removes the
+// working copy. When they cannot be sealed, the copy stays behind, marked
+// dirty, for the next writer to seal.
+func (s *Store) closeSealed() error {
+	err := s.Flush(context.Background())
+	s.resealMu.Lock()
+	defer s.resealMu.Unlock()
+	cerr := s.backend.close()
+	if err != nil {
+		working := s.working
+		s.dropWorking(false)
+		return errors.Internalf("seal database %s: %w; its unsealed changes stay in %s until it is next opened with its key", s.path, err, working)
+	}
+	s.dropWorking(true)
+	return cerr
+}
+
+// dropWorking releases the working copy, removing it when remove is set, and
+// releases the lock. The caller has closed the backend and holds resealMu.
+func (s *Store) dropWorking(remove bool) {
+	s.sealMu.Lock()
+	if s.pending != nil {
+		s.pending.Stop()
+		s.pending = nil
+	}
+	working, claim := s.working, s.claim
+	s.working, s.claim, s.dirty = "", nil, false
+	s.sealMu.Unlock()
+	if remove {
+		releaseWorking(working, claim)
+	} else if claim != nil {
+		_ = claim.Close()
+	}
+	if s.lock != nil {
+		_ = s.lock.Close()
+		s.lock = nil
+	}
+}
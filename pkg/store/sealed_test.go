@@ -0,0 +1,287 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/seal"
+	testutil "github.com/TheFellow/go-modular-monolith/pkg/testutil/assert"
+	"github.com/mjl-/bstore"
+)
+
+func newSealKey(t *testing.T, secret string) *seal.Key {
+	t.Helper()
+	key, err := seal.NewKey(secret)
+	testutil.ErrorIf(t, err != nil, "new key: %v", err)
+	return key
+}
+
+func openSealedStore(t *testing.T, path string, opts ...Option) *Store {
+	t.Helper()
+	ctx := context.Background()
+	s, err := Open(ctx, path, opts...)
+	testutil.ErrorIf(t, err != nil, "open %s: %v", path, err)
+	s.Register(ctx, contractKeyed{})
+	return s
+}
+
+func readKeyed(s *Store, key string) (string, error) {
+	row := contractKeyed{Key: key}
+	err := s.Read(context.Background(), func(tx *Tx) error {
+		return tx.Get(&row)
+	})
+	return row.Value, err
+}
+
+func assertSealedFile(t *testing.T, path, secret string) {
+	t.Helper()
+	sealed, err := seal.IsSealed(path)
+	testutil.ErrorIf(t, err != nil || !sealed, "%s sealed = %v, %v", path, sealed, err)
+	data, err := os.ReadFile(path)
+	testutil.ErrorIf(t, err != nil, "read %s: %v", path, err)
+	testutil.ErrorIf(t, bytes.Contains(data, []byte(secret)), "%s holds %q in plain form", path, secret)
+}
+
+func TestSealedDatabaseNeverWritesPlainData(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, file string) {
+		ctx := context.Background()
+		path := filepath.Join(t.TempDir(), file)
+		key := newSealKey(t, "house key")
+		s := openSealedStore(t, path, Sealed(key))
+		testutil.ErrorIf(t, !s.IsSealed(), "%v", "new database with a key is not sealed")
+		err := s.Write(ctx, func(tx *Tx) error {
+			return tx.Insert(&contractKeyed{Key: "cost", Value: "gin-cost-price-1234"})
+		})
+		testutil.ErrorIf(t, err != nil, "insert: %v", err)
+		// Commits are sealed in batches, not only at Close.
+		assertSealedFile(t, path, "gin-cost-price-1234")
+		testutil.ErrorIf(t, s.pending == nil, "%v", "commit did not schedule a seal")
+		testutil.ErrorIf(t, s.Flush(ctx) != nil || s.SealError() != nil, "%v", "flush pending commits")
+		assertSealedFile(t, path, "gin-cost-price-1234")
+
+		reader := openSealedStore(t, path, ReadOnly(), Sealed(newSealKey(t, "house key")))
+		testutil.ErrorIf(t, !strings.HasPrefix(filepath.Base(reader.snapshot), workingPrefix(path)), "snapshot %s is not a working directory", reader.snapshot)
+		got, err := readKeyed(reader, "cost")
+		testutil.ErrorIf(t, err != nil || got != "gin-cost-price-1234", "read-only read = %q, %v", got, err)
+		testutil.ErrorIf(t, reader.Close() != nil, "%v", "close reader")
+
+		working := s.working
+		testutil.ErrorIf(t, s.Close() != nil, "%v", "close sealed store")
+		_, err = os.Stat(working)
+		testutil.ErrorIf(t, !os.IsNotExist(err), "working copy %s remains after close: %v", working, err)
+
+		_, err = Open(ctx, path)
+		testutil.ErrorIf(t, !errors.IsFailedPrecondition(err), "open without key error = %v", err)
+		_, err = Open(ctx, path, ReadOnly())
+		testutil.ErrorIf(t, !errors.IsFailedPrecondition(err), "read-only open without key error = %v", err)
+		_, err = Open(ctx, path, Sealed(newSealKey(t, "wrong key")))
+		testutil.ErrorIf(t, !errors.IsPermission(err), "open with wrong key error = %v", err)
+		_, err = BackendFor(path)
+		testutil.ErrorIf(t, !errors.IsFailedPrecondition(err), "backend of sealed file error = %v", err)
+
+		reopened := openSealedStore(t, path, Sealed(key))
+		defer reopened.Close()
+		testutil.ErrorIf(t, reopened.Backend() != backendForName(file), "backend = %s", reopened.Backend())
+		got, err = readKeyed(reopened, "cost")
+		testutil.ErrorIf(t, err != nil || got != "gin-cost-price-1234", "reopened read = %q, %v", got, err)
+	})
+}
+
+func TestSealedBackupsRestore(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, file string) {
+		ctx := context.Background()
+		root := t.TempDir()
+		key := newSealKey(t, "backup key")
+		s := openSealedStore(t, filepath.Join(root, file), Sealed(key))
+		defer s.Close()
+		err := s.Write(ctx, func(tx *Tx) error {
+			return tx.Insert(&contractKeyed{Key: "staff", Value: "bartender-shift-notes"})
+		})
+		testutil.ErrorIf(t, err != nil, "insert: %v", err)
+		backup, err := s.Backup(ctx, filepath.Join(root, "backups"), time.Now())
+		testutil.ErrorIf(t, err != nil, "backup: %v", err)
+		assertSealedFile(t, backup.Path, "bartender-shift-notes")
+
+		// A plain database opened with a key stays plain but copies sealed,
+		// and restores a sealed backup as a plain database.
+		plainPath := filepath.Join(root, "plain"+filepath.Ext(file))
+		plain := openSealedStore(t, plainPath)
+		testutil.ErrorIf(t, plain.Close() != nil, "%v", "close plain store")
+		plain = openSealedStore(t, plainPath, Sealed(key))
+		testutil.ErrorIf(t, plain.IsSealed(), "%v", "existing plain database was sealed on open")
+		copied := filepath.Join(root, "copy"+filepath.Ext(file))
+		testutil.ErrorIf(t, plain.CopyTo(ctx, copied) != nil, "%v", "copy plain store")
+		assertSealedFile(t, copied, "never written")
+		testutil.ErrorIf(t, plain.Restore(backup.Path) != nil, "%v", "restore into plain store")
+		_ = plain.Close()
+		sealed, err := seal.IsSealed(plainPath)
+		testutil.ErrorIf(t, err != nil || sealed, "restored plain database sealed = %v, %v", sealed, err)
+		plain = openSealedStore(t, plainPath)
+		got, err := readKeyed(plain, "staff")
+		testutil.ErrorIf(t, err != nil || got != "bartender-shift-notes", "restored plain read = %q, %v", got, err)
+		_ = plain.Close()
+
+		// A sealed database restores a sealed backup and stays sealed.
+		err = s.Write(ctx, func(tx *Tx) error {
+			return tx.Delete(&contractKeyed{Key: "staff"})
+		})
+		testutil.ErrorIf(t, err != nil, "delete: %v", err)
+		testutil.ErrorIf(t, s.Restore(backup.Path) != nil, "%v", "restore into sealed store")
+		testutil.ErrorIf(t, s.Close() != nil, "%v", "close restored store")
+		assertSealedFile(t, s.Path(), "bartender-shift-notes")
+		restored := openSealedStore(t, s.Path(), Sealed(key))
+		defer restored.Close()
+		got, err = readKeyed(restored, "staff")
+		testutil.ErrorIf(t, err != nil || got != "bartender-shift-notes", "restored sealed read = %q, %v", got, err)
+
+		other := openSealedStore(t, filepath.Join(root, "other"+filepath.Ext(file)), Sealed(newSealKey(t, "other key")))
+		defer other.Close()
+		err = other.Restore(backup.Path)
+		testutil.ErrorIf(t, !errors.IsPermission(err), "restore with wrong key error = %v", err)
+	})
+}
+
+func TestSealConvertsAndRekeyRotates(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, file string) {
+		ctx := context.Background()
+		root := t.TempDir()
+		path := filepath.Join(root, file)
+		old, next := newSealKey(t, "old key"), newSealKey(t, "new key")
+
+		s := openSealedStore(t, path)
+		err := s.Write(ctx, func(tx *Tx) error {
+			return tx.Insert(&contractKeyed{Key: "menu", Value: "house-margarita-margin"})
+		})
+		testutil.ErrorIf(t, err != nil, "insert: %v", err)
+		err = s.Seal(ctx)
+		testutil.ErrorIf(t, !errors.IsFailedPrecondition(err), "seal without key error = %v", err)
+		testutil.ErrorIf(t, s.Close() != nil, "%v", "close plain store")
+
+		s = openSealedStore(t, path, Sealed(old))
+		testutil.ErrorIf(t, s.Seal(ctx) != nil, "%v", "seal plain database")
+		testutil.ErrorIf(t, !s.IsSealed(), "%v", "store is not sealed after Seal")
+		assertSealedFile(t, path, "house-margarita-margin")
+		err = s.Write(ctx, func(tx *Tx) error {
+			return tx.Insert(&contractKeyed{Key: "after", Value: "sealed"})
+		})
+		testutil.ErrorIf(t, err != nil, "insert after sealing: %v", err)
+		dir := filepath.Join(root, "backups")
+		_, err = s.Backup(ctx, dir, time.Now())
+		testutil.ErrorIf(t, err != nil, "backup: %v", err)
+
+		testutil.ErrorIf(t, s.Rekey(ctx, next) != nil, "%v", "rekey database")
+		rekeyed, err := RekeyBackups(dir, old, next)
+		testutil.ErrorIf(t, err != nil || len(rekeyed) != 1, "rekey backups = %d, %v", len(rekeyed), err)
+		rekeyed, err = RekeyBackups(dir, old, next)
+		testutil.ErrorIf(t, err != nil || len(rekeyed) != 0, "rerun rekey backups = %d, %v", len(rekeyed), err)
+		testutil.ErrorIf(t, s.Close() != nil, "%v", "close rekeyed store")
+
+		_, err = Open(ctx, path, Sealed(old))
+		testutil.ErrorIf(t, !errors.IsPermission(err), "open with retired key error = %v", err)
+		s = openSealedStore(t, path, Sealed(next))
+		defer s.Close()
+		for _, key := range []string{"menu", "after"} {
+			_, err := readKeyed(s, key)
+			testutil.ErrorIf(t, err != nil, "read %s after rotation: %v", key, err)
+		}
+		backups, err := ListBackups(dir)
+		testutil.ErrorIf(t, err != nil, "list backups: %v", err)
+		testutil.ErrorIf(t, !opens(backups[0].Path, next), "%v", "backup does not open with the new key")
+	})
+}
+
+func TestSealedCommitSurvivesResealFailure(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, file string) {
+		ctx := context.Background()
+		path := filepath.Join(t.TempDir(), file)
+		key := newSealKey(t, "house key")
+		s := openSealedStore(t, path, Sealed(key))
+
+		// A directory in the database's place makes every reseal fail to
+		// rename the sealed copy over it.
+		testutil.ErrorIf(t, os.Rename(path, path+".aside") != nil, "%v", "move database aside")
+		testutil.ErrorIf(t, os.MkdirAll(filepath.Join(path, "blocked"), 0o700) != nil, "%v", "block database path")
+		err := s.Write(ctx, func(tx *Tx) error {
+			return tx.Insert(&contractKeyed{Key: "cost", Value: "committed-while-blocked"})
+		})
+		testutil.ErrorIf(t, err != nil, "committed write reported as failed: %v", err)
+		testutil.ErrorIf(t, s.Flush(ctx) == nil, "%v", "flush did not fail")
+		testutil.ErrorIf(t, s.SealError() == nil, "%v", "reseal failure is not reported")
+		got, err := readKeyed(s, "cost")
+		testutil.ErrorIf(t, err != nil || got != "committed-while-blocked", "read after failed reseal = %q, %v", got, err)
+
+		// Close reports the failure and keeps the unsealed copy.
+		working := s.working
+		testutil.ErrorIf(t, s.Close() == nil, "%v", "close did not report the failed reseal")
+		_, err = os.Stat(filepath.Join(working, dirtyFile))
+		testutil.ErrorIf(t, err != nil, "unsealed working copy was not kept: %v", err)
+
+		// The next writer seals the kept copy over the database.
+		testutil.ErrorIf(t, os.RemoveAll(path) != nil, "%v", "unblock database path")
+		testutil.ErrorIf(t, os.Rename(path+".aside", path) != nil, "%v", "restore database")
+		recovered := openSealedStore(t, path, Sealed(key))
+		_, err = os.Stat(working)
+		testutil.ErrorIf(t, !os.IsNotExist(err), "recovered working copy remains: %v", err)
+		testutil.ErrorIf(t, recovered.Close() != nil, "%v", "close recovered store")
+		assertSealedFile(t, path, "committed-while-blocked")
+
+		reopened := openSealedStore(t, path, Sealed(key))
+		defer reopened.Close()
+		got, err = readKeyed(reopened, "cost")
+		testutil.ErrorIf(t, err != nil || got != "committed-while-blocked", "reopened read = %q, %v", got, err)
+	})
+}
+
+func TestSealedWorkingCopyStaysInMemory(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, file string) {
+		ctx := context.Background()
+		path := filepath.Join(t.TempDir(), file)
+		key := newSealKey(t, "house key")
+		testutil.ErrorIf(t, openSealedStore(t, path, Sealed(key)).Close() != nil, "%v", "create sealed database")
+
+		// A process that stopped without closing leaves its plain copy
+		// behind, with no one holding its claim.
+		root := workingRoots(path)[0]
+		stale := filepath.Join(root, workingPrefix(path)+"crashed")
+		testutil.ErrorIf(t, os.MkdirAll(stale, 0o700) != nil, "%v", "create stale working directory")
+		t.Cleanup(func() { _ = os.RemoveAll(stale) })
+		testutil.ErrorIf(t, os.WriteFile(filepath.Join(stale, file), []byte("plain"), 0o600) != nil, "%v", "write stale copy")
+		claim, err := bstore.Open(ctx, filepath.Join(stale, claimFile), nil)
+		testutil.ErrorIf(t, err != nil, "create stale claim: %v", err)
+		testutil.ErrorIf(t, claim.Close() != nil, "%v", "release stale claim")
+
+		// A copy some other store still claims is live.
+		live, liveClaim, err := workingDir(ctx, path)
+		testutil.ErrorIf(t, err != nil, "create live working directory: %v", err)
+		defer releaseWorking(live, liveClaim)
+
+		s := openSealedStore(t, path, Sealed(key))
+		defer s.Close()
+		_, err = os.Stat(stale)
+		testutil.ErrorIf(t, !os.IsNotExist(err), "stale working copy remains after open: %v", err)
+		_, err = os.Stat(live)
+		testutil.ErrorIf(t, err != nil, "live working copy was removed: %v", err)
+		if info, err := os.Stat(memoryRoot); err == nil && info.IsDir() {
+			testutil.ErrorIf(t, filepath.Dir(s.working) != memoryRoot, "working copy %s is not in %s", s.working, memoryRoot)
+		}
+		info, err := os.Stat(s.working)
+		testutil.ErrorIf(t, err != nil, "stat working directory: %v", err)
+		testutil.ErrorIf(t, info.Mode().Perm() != 0o700, "working directory mode = %v", info.Mode().Perm())
+	})
+}
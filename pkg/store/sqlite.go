@@ -0,0 +1,563 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// sqliteBackend keeps rows in a SQLite database with one table per row type
+// and one column per top-level field, so reports can query the file with any
+// SQL tool. Composite fields are stored as JSON text.
+type sqliteBackend struct {
+	db       *sql.DB
+	readOnly bool
+	// writer admits one write transaction at a time within this process, as
+	// bstore does; BEGIN IMMEDIATE and the busy timeout cover other processes.
+	writer sync.Mutex
+
+	mu     sync.RWMutex
+	tables map[reflect.Type]*sqliteTable
+}
+
+// openSQLite opens the database at path. A read-only backend sets query_only,
+// so SQLite itself refuses every change, and leaves the schema as it is.
+func openSQLite(ctx context.Context, path string, readOnly bool) (*sqliteBackend, error) {
+	dsn := path + "?_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)"
+	if readOnly {
+		dsn = path + "?_pragma=busy_timeout(10000)&_pragma=query_only(1)"
+	}
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+	// Reading the schema fails early when path is not a SQLite database.
+	if _, err := db.ExecContext(ctx, "SELECT count(*) FROM sqlite_schema"); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("sqlite: open %s: %w", path, err)
+	}
+	return &sqliteBackend{db: db, readOnly: readOnly, tables: make(map[reflect.Type]*sqliteTable)}, nil
+}
+
+// register creates each row type's table and indexes, adds columns for new
+// fields and drops indexes no longer declared, mirroring how bstore evolves
+// stored types.
+func (b *sqliteBackend) register(ctx context.Context, models ...any) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, model := range models {
+		typ := reflect.TypeOf(model)
+		if typ != nil && typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		if typ == nil {
+			return fmt.Errorf("sqlite: register nil model")
+		}
+		if _, ok := b.tables[typ]; ok {
+			continue
+		}
+		table, err := newSQLiteTable(typ)
+		if err != nil {
+			return err
+		}
+		for other := range b.tables {
+			if other.Name() == table.name {
+				return fmt.Errorf("sqlite: register %s: another row type uses table %s", typ, table.name)
+			}
+		}
+		if b.readOnly {
+			err = b.inspect(ctx, table)
+		} else {
+			err = b.migrate(ctx, table)
+		}
+		if err != nil {
+			return fmt.Errorf("sqlite: register %s: %w", typ.Name(), err)
+		}
+		b.tables[typ] = table
+	}
+	return nil
+}
+
+func (b *sqliteBackend) migrate(ctx context.Context, table *sqliteTable) error {
+	tx, err := b.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, table.createSQL()); err != nil {
+		return err
+	}
+	existing, err := tableColumns(ctx, tx, table.name)
+	if err != nil {
+		return err
+	}
+	for _, c := range table.columns {
+		if existing[c.name] {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, "ALTER TABLE "+quoteIdent(table.name)+" ADD COLUMN "+c.definition()); err != nil {
+			return err
+		}
+	}
+
+	declared := make(map[string]bool)
+	for _, index := range table.indexes {
+		declared[index.name] = true
+	}
+	var stale []string
+	rows, err := tx.QueryContext(ctx, "SELECT name FROM sqlite_schema WHERE type = 'index' AND tbl_name = ? AND sql IS NOT NULL", table.name)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		if !declared[name] {
+			stale = append(stale, name)
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	for _, name := range stale {
+		if _, err := tx.ExecContext(ctx, "DROP INDEX "+quoteIdent(name)); err != nil {
+			return err
+		}
+	}
+	for _, index := range table.indexes {
+		if _, err := tx.ExecContext(ctx, index.createSQL(table.name)); err != nil {
+			return sqliteError(err)
+		}
+	}
+	return tx.Commit()
+}
+
+// inspect records whether a read-only backend can read table as it is
+// stored. A missing table or column is reported when the table is used, not
+// here, so an outdated database still serves the tables it has.
+func (b *sqliteBackend) inspect(ctx context.Context, table *sqliteTable) error {
+	existing, err := tableColumns(ctx, b.db, table.name)
+	if err != nil {
+		return err
+	}
+	if len(existing) == 0 {
+		table.outdated = "has no table " + table.name
+		return nil
+	}
+	for _, c := range table.columns {
+		if !existing[c.name] {
+			table.outdated = "has no column " + table.name + "." + c.name
+			return nil
+		}
+	}
+	return nil
+}
+
+type sqliteQueryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// tableColumns returns the names of the columns table has, none when it does
+// not exist.
+func tableColumns(ctx context.Context, q sqliteQueryer, table string) (map[string]bool, error) {
+	rows, err := q.QueryContext(ctx, "SELECT name FROM pragma_table_info(?)", table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		existing[name] = true
+	}
+	return existing, rows.Err()
+}
+
+func (b *sqliteBackend) table(typ reflect.Type) (*sqliteTable, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	table, ok := b.tables[typ]
+	if !ok {
+		return nil, fmt.Errorf("sqlite: type %s not registered", typ)
+	}
+	if table.outdated != "" {
+		return nil, errors.FailedPreconditionf("database %s; open it writable once to upgrade it", table.outdated)
+	}
+	return table, nil
+}
+
+// begin takes a dedicated connection for the transaction. Statements run
+// without ctx's cancellation so a transaction is only ever ended by commit or
+// rollback, as with bstore.
+func (b *sqliteBackend) begin(ctx context.Context, writable bool) (txBackend, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	ctx = context.WithoutCancel(ctx)
+	if writable {
+		b.writer.Lock()
+	}
+	tx, err := b.beginConn(ctx, writable)
+	if err != nil && writable {
+		b.writer.Unlock()
+	}
+	return tx, err
+}
+
+func (b *sqliteBackend) beginConn(ctx context.Context, writable bool) (*sqliteTx, error) {
+	conn, err := b.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stmt := "BEGIN"
+	if writable {
+		stmt = "BEGIN IMMEDIATE"
+	}
+	if _, err := conn.ExecContext(ctx, stmt); err != nil {
+		_ = conn.Close()
+		return nil, sqliteError(err)
+	}
+	return &sqliteTx{backend: b, ctx: ctx, conn: conn, writable: writable}, nil
+}
+
+func (b *sqliteBackend) close() error {
+	return b.db.Close()
+}
+
+func (b *sqliteBackend) snapshot(ctx context.Context, path string) error {
+	if err := b.vacuumInto(ctx, path); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	err = f.Sync()
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// vacuumInto writes the database to path. VACUUM INTO leaves the source
+// untouched but counts as a write under query_only, so a read-only backend
+// lifts it on the one connection that runs the copy.
+func (b *sqliteBackend) vacuumInto(ctx context.Context, path string) error {
+	if !b.readOnly {
+		_, err := b.db.ExecContext(ctx, "VACUUM INTO ?", path)
+		return err
+	}
+	conn, err := b.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, "PRAGMA query_only = 0"); err != nil {
+		return err
+	}
+	_, err = conn.ExecContext(ctx, "VACUUM INTO ?", path)
+	if _, rerr := conn.ExecContext(context.WithoutCancel(ctx), "PRAGMA query_only = 1"); rerr != nil {
+		// Never return a writable connection to the pool.
+		_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		if err == nil {
+			err = rerr
+		}
+	}
+	return err
+}
+
+func (b *sqliteBackend) checkRecords(ctx context.Context) (map[string]int, error) {
+	var result string
+	if err := b.db.QueryRowContext(ctx, "PRAGMA integrity_check(1)").Scan(&result); err != nil {
+		return nil, err
+	}
+	if result != "ok" {
+		return nil, fmt.Errorf("sqlite integrity check: %s", result)
+	}
+
+	b.mu.RLock()
+	registered := make(map[string]*sqliteTable, len(b.tables))
+	for _, table := range b.tables {
+		registered[table.name] = table
+	}
+	b.mu.RUnlock()
+
+	rows, err := b.db.QueryContext(ctx, "SELECT name FROM sqlite_schema WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(names))
+	for _, name := range names {
+		// Tables of types this process has not registered can only be
+		// counted; registered ones are decoded row by row.
+		var n int
+		if table, ok := registered[name]; ok {
+			n, err = b.decodeAll(ctx, table)
+		} else {
+			err = b.db.QueryRowContext(ctx, "SELECT count(*) FROM "+quoteIdent(name)).Scan(&n)
+		}
+		if err != nil {
+			return nil, errors.Internalf("read %s records: %w", name, err)
+		}
+		counts[name] = n
+	}
+	return counts, nil
+}
+
+// decodeAll loads every row of a registered table and returns how many there
+// were.
+func (b *sqliteBackend) decodeAll(ctx context.Context, table *sqliteTable) (int, error) {
+	if table.outdated != "" {
+		return 0, fmt.Errorf("database %s", table.outdated)
+	}
+	rows, err := b.db.QueryContext(ctx, table.selectSQL)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	n := 0
+	for rows.Next() {
+		if err := table.scan(rows, reflect.New(table.typ).Elem()); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, rows.Err()
+}
+
+// install closes the database first, which checkpoints the write-ahead log
+// into the old file, then swaps the new file in and removes any leftover log.
+func (b *sqliteBackend) install(from, path string) error {
+	if err := b.close(); err != nil {
+		return err
+	}
+	if err := os.Rename(from, path); err != nil {
+		return err
+	}
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(path + suffix); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// sqliteTx is one SQLite transaction on its own connection.
+type sqliteTx struct {
+	backend  *sqliteBackend
+	ctx      context.Context
+	conn     *sql.Conn
+	writable bool
+	done     bool
+}
+
+// row resolves value, a pointer to a registered row, to its table.
+func (t *sqliteTx) row(value any) (*sqliteTable, reflect.Value, error) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil, reflect.Value{}, fmt.Errorf("sqlite: want pointer to a row struct, got %T", value)
+	}
+	v = v.Elem()
+	table, err := t.backend.table(v.Type())
+	if err != nil {
+		return nil, reflect.Value{}, err
+	}
+	return table, v, nil
+}
+
+func (t *sqliteTx) check(write bool) error {
+	switch {
+	case t.done:
+		return fmt.Errorf("sqlite: transaction already finished")
+	case write && !t.writable:
+		return fmt.Errorf("sqlite: write in a read-only transaction")
+	}
+	return nil
+}
+
+func (t *sqliteTx) key(table *sqliteTable, v reflect.Value) (any, error) {
+	pk := v.Field(table.pk().index)
+	if pk.IsZero() {
+		return nil, fmt.Errorf("sqlite: %s: primary key must not be zero", table.name)
+	}
+	return table.pk().encode(pk)
+}
+
+func (t *sqliteTx) get(value any) error {
+	if err := t.check(false); err != nil {
+		return err
+	}
+	table, v, err := t.row(value)
+	if err != nil {
+		return err
+	}
+	key, err := t.key(table, v)
+	if err != nil {
+		return err
+	}
+	err = table.scan(t.conn.QueryRowContext(t.ctx, table.getSQL, key), v)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %s %v", ErrAbsent, table.name, key)
+	}
+	return sqliteError(err)
+}
+
+func (t *sqliteTx) insert(value any) error {
+	if err := t.check(true); err != nil {
+		return err
+	}
+	table, v, err := t.row(value)
+	if err != nil {
+		return err
+	}
+	values, err := table.values(v)
+	if err != nil {
+		return err
+	}
+	pk := v.Field(table.pk().index)
+	if !pk.IsZero() {
+		_, err := t.conn.ExecContext(t.ctx, table.insertSQL, values...)
+		return sqliteError(err)
+	}
+	if !table.autoKey {
+		return fmt.Errorf("%w: %s", ErrZero, table.name)
+	}
+	result, err := t.conn.ExecContext(t.ctx, table.insertAutoSQL, values[1:]...)
+	if err != nil {
+		return sqliteError(err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	if pk.Kind() >= reflect.Uint && pk.Kind() <= reflect.Uint64 {
+		pk.SetUint(uint64(id))
+	} else {
+		pk.SetInt(id)
+	}
+	return nil
+}
+
+func (t *sqliteTx) update(value any) error {
+	if err := t.check(true); err != nil {
+		return err
+	}
+	table, v, err := t.row(value)
+	if err != nil {
+		return err
+	}
+	key, err := t.key(table, v)
+	if err != nil {
+		return err
+	}
+	values, err := table.values(v)
+	if err != nil {
+		return err
+	}
+	if len(values) == 1 {
+		values = append(values, key)
+	} else {
+		values = append(values[1:], key)
+	}
+	result, err := t.conn.ExecContext(t.ctx, table.updateSQL, values...)
+	return t.affected(table, key, result, err)
+}
+
+func (t *sqliteTx) delete(value any) error {
+	if err := t.check(true); err != nil {
+		return err
+	}
+	table, v, err := t.row(value)
+	if err != nil {
+		return err
+	}
+	key, err := t.key(table, v)
+	if err != nil {
+		return err
+	}
+	result, err := t.conn.ExecContext(t.ctx, table.deleteSQL, key)
+	return t.affected(table, key, result, err)
+}
+
+// affected reports ErrAbsent when a statement by primary key changed no row.
+func (t *sqliteTx) affected(table *sqliteTable, key any, result sql.Result, err error) error {
+	if err != nil {
+		return sqliteError(err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: %s %v", ErrAbsent, table.name, key)
+	}
+	return nil
+}
+
+func (t *sqliteTx) commit() error {
+	return t.finish("COMMIT")
+}
+
+func (t *sqliteTx) rollback() error {
+	return t.finish("ROLLBACK")
+}
+
+func (t *sqliteTx) finish(stmt string) error {
+	if t.done {
+		return fmt.Errorf("sqlite: transaction already finished")
+	}
+	t.done = true
+	_, err := t.conn.ExecContext(t.ctx, stmt)
+	if err != nil && stmt == "COMMIT" {
+		// A failed commit leaves the transaction open; end it before the
+		// connection goes back to the pool.
+		_, _ = t.conn.ExecContext(t.ctx, "ROLLBACK")
+	}
+	if cerr := t.conn.Close(); err == nil {
+		err = cerr
+	}
+	if t.writable {
+		t.backend.writer.Unlock()
+	}
+	return sqliteError(err)
+}
+
+// sqliteError matches SQLite constraint failures to the package sentinels.
+func sqliteError(err error) error {
+	var serr *sqlite.Error
+	if errors.As(err, &serr) {
+		switch serr.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+			return classify(ErrUnique, err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,321 @@
+package store
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// sqliteQuery builds one SELECT from the filters and sorts on plain columns.
+// FilterFn runs in Go on the rows SQLite returns, so a limit is applied in Go
+// whenever one is present.
+type sqliteQuery[T any] struct {
+	tx    *sqliteTx
+	table *sqliteTable
+	err   error
+
+	where []string
+	args  []any
+	order []string
+	fns   []func(T) bool
+	max   int
+}
+
+func newSQLiteQuery[T any](tx *sqliteTx) *sqliteQuery[T] {
+	q := &sqliteQuery[T]{tx: tx}
+	q.table, q.err = tx.backend.table(reflect.TypeFor[T]())
+	return q
+}
+
+func (q *sqliteQuery[T]) fail(format string, args ...any) {
+	if q.err == nil {
+		q.err = fmt.Errorf("sqlite: query %s: "+format, append([]any{q.table.name}, args...)...)
+	}
+}
+
+// column resolves a filtered or sorted field. JSON columns hold documents
+// rather than comparable values, so they are refused.
+func (q *sqliteQuery[T]) column(field string) (sqliteColumn, bool) {
+	if q.err != nil {
+		return sqliteColumn{}, false
+	}
+	c, ok := q.table.column(field)
+	if !ok {
+		q.fail("unknown field %s", field)
+		return sqliteColumn{}, false
+	}
+	if c.kind == kindJSON {
+		q.fail("field %s cannot be filtered or sorted", field)
+		return sqliteColumn{}, false
+	}
+	return c, true
+}
+
+// param encodes a filter value for column c. The value must be of the field's
+// type, or its element type for a pointer field.
+func (q *sqliteQuery[T]) param(c sqliteColumn, value any) (any, bool) {
+	target := c.typ
+	if c.nullable {
+		target = target.Elem()
+	}
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		q.fail("nil value for %s", c.name)
+		return nil, false
+	}
+	if v.Type() != target {
+		if !v.Type().ConvertibleTo(target) || columnKindOf(v.Type()) != c.kind {
+			q.fail("value of type %T for %s of type %s", value, c.name, target)
+			return nil, false
+		}
+		v = v.Convert(target)
+	}
+	scalar := c
+	scalar.nullable = false
+	encoded, err := scalar.encode(v)
+	if err != nil {
+		q.fail("%v", err)
+		return nil, false
+	}
+	return encoded, true
+}
+
+func (q *sqliteQuery[T]) filterEqual(field string, values []any, not bool) {
+	c, ok := q.column(field)
+	if !ok {
+		return
+	}
+	if len(values) == 0 {
+		q.fail("no values for %s", field)
+		return
+	}
+	params := make([]string, len(values))
+	for i, value := range values {
+		encoded, ok := q.param(c, value)
+		if !ok {
+			return
+		}
+		params[i] = "?"
+		q.args = append(q.args, encoded)
+	}
+	in := quoteIdent(c.name) + " IN (" + strings.Join(params, ", ") + ")"
+	switch {
+	case !not:
+		q.where = append(q.where, in)
+	case c.nullable:
+		q.where = append(q.where, "("+quoteIdent(c.name)+" IS NULL OR NOT "+in+")")
+	default:
+		q.where = append(q.where, "NOT "+in)
+	}
+}
+
+func (q *sqliteQuery[T]) filterCompare(field string, op compareOp, value any) {
+	c, ok := q.column(field)
+	if !ok {
+		return
+	}
+	encoded, ok := q.param(c, value)
+	if !ok {
+		return
+	}
+	q.where = append(q.where, quoteIdent(c.name)+" "+string(op)+" ?")
+	q.args = append(q.args, encoded)
+}
+
+func (q *sqliteQuery[T]) filterID(id any) {
+	if q.err != nil {
+		return
+	}
+	q.filterEqual(q.table.pk().name, []any{id}, false)
+}
+
+func (q *sqliteQuery[T]) filterIDs(ids any) {
+	if q.err != nil {
+		return
+	}
+	v := reflect.ValueOf(ids)
+	if v.Kind() != reflect.Slice {
+		q.fail("ids must be a slice, got %T", ids)
+		return
+	}
+	if v.Len() == 0 {
+		q.where = append(q.where, "0")
+		return
+	}
+	values := make([]any, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Interface()
+	}
+	q.filterEqual(q.table.pk().name, values, false)
+}
+
+func (q *sqliteQuery[T]) filterFn(fn func(T) bool) {
+	q.fns = append(q.fns, fn)
+}
+
+func (q *sqliteQuery[T]) sort(fields []string, asc bool) {
+	direction := " DESC"
+	if asc {
+		direction = " ASC"
+	}
+	for _, field := range fields {
+		c, ok := q.column(field)
+		if !ok {
+			return
+		}
+		q.order = append(q.order, quoteIdent(c.name)+direction)
+	}
+}
+
+func (q *sqliteQuery[T]) limit(n int) {
+	if n < 1 {
+		q.fail("limit must be at least 1")
+		return
+	}
+	q.max = n
+}
+
+// load runs the query and returns every selected row. Rows are read in full
+// before callers issue further statements on the transaction.
+func (q *sqliteQuery[T]) load() ([]T, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	if err := q.tx.check(false); err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	b.WriteString(q.table.selectSQL)
+	if len(q.where) > 0 {
+		b.WriteString(" WHERE " + strings.Join(q.where, " AND "))
+	}
+	b.WriteString(" ORDER BY " + strings.Join(append(q.order[:len(q.order):len(q.order)], quoteIdent(q.table.pk().name)+" ASC"), ", "))
+	if q.max > 0 && len(q.fns) == 0 {
+		b.WriteString(" LIMIT " + strconv.Itoa(q.max))
+	}
+	rows, err := q.tx.conn.QueryContext(q.tx.ctx, b.String(), q.args...)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	defer rows.Close()
+	var out []T
+	for rows.Next() {
+		var row T
+		if err := q.table.scan(rows, reflect.ValueOf(&row).Elem()); err != nil {
+			return nil, err
+		}
+		if !q.match(row) {
+			continue
+		}
+		out = append(out, row)
+		if q.max > 0 && len(out) == q.max {
+			break
+		}
+	}
+	return out, sqliteError(rows.Err())
+}
+
+func (q *sqliteQuery[T]) match(row T) bool {
+	for _, fn := range q.fns {
+		if !fn(row) {
+			return false
+		}
+	}
+	return true
+}
+
+func (q *sqliteQuery[T]) list() ([]T, error) {
+	return q.load()
+}
+
+func (q *sqliteQuery[T]) get() (T, error) {
+	var zero T
+	rows, err := q.load()
+	switch {
+	case err != nil:
+		return zero, err
+	case len(rows) == 0:
+		return zero, fmt.Errorf("%w: %s", ErrAbsent, q.table.name)
+	case len(rows) > 1:
+		return zero, fmt.Errorf("%w: %s", ErrMultiple, q.table.name)
+	}
+	return rows[0], nil
+}
+
+func (q *sqliteQuery[T]) count() (int, error) {
+	rows, err := q.load()
+	return len(rows), err
+}
+
+func (q *sqliteQuery[T]) all() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		rows, err := q.load()
+		if err != nil {
+			var zero T
+			yield(zero, err)
+			return
+		}
+		for _, row := range rows {
+			if !yield(row, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (q *sqliteQuery[T]) delete() (int, error) {
+	if err := q.tx.check(true); err != nil {
+		return 0, err
+	}
+	rows, err := q.load()
+	if err != nil {
+		return 0, err
+	}
+	for i := range rows {
+		if err := q.tx.delete(&rows[i]); err != nil {
+			return i, err
+		}
+	}
+	return len(rows), nil
+}
+
+func (q *sqliteQuery[T]) updateField(field string, value any) (int, error) {
+	if err := q.tx.check(true); err != nil {
+		return 0, err
+	}
+	if q.err != nil {
+		return 0, q.err
+	}
+	c, ok := q.table.column(field)
+	if !ok {
+		q.fail("unknown field %s", field)
+		return 0, q.err
+	}
+	if c.index == q.table.pk().index {
+		q.fail("cannot update primary key %s", field)
+		return 0, q.err
+	}
+	v := reflect.ValueOf(value)
+	switch {
+	case !v.IsValid():
+		v = reflect.Zero(c.typ)
+	case v.Type() != c.typ && v.Type().ConvertibleTo(c.typ):
+		v = v.Convert(c.typ)
+	case v.Type() != c.typ:
+		q.fail("value of type %T for %s of type %s", value, field, c.typ)
+		return 0, q.err
+	}
+	rows, err := q.load()
+	if err != nil {
+		return 0, err
+	}
+	for i := range rows {
+		reflect.ValueOf(&rows[i]).Elem().Field(c.index).Set(v)
+		if err := q.tx.update(&rows[i]); err != nil {
+			return i, err
+		}
+	}
+	return len(rows), nil
+}
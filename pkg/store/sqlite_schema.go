@@ -0,0 +1,437 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// sqliteTimeLayout is fixed width and always UTC, so stored times compare and
+// sort as text in time order.
+const sqliteTimeLayout = "2006-01-02T15:04:05.000000000Z"
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// columnKind is how one field is stored in its SQLite column.
+type columnKind int
+
+const (
+	kindText columnKind = iota
+	kindInteger
+	kindUnsigned
+	kindBool
+	kindReal
+	kindBlob
+	kindTime
+	// kindJSON holds structs, slices, maps and any other composite value as a
+	// JSON document. It can be stored and loaded but not filtered or sorted.
+	kindJSON
+)
+
+func columnKindOf(t reflect.Type) columnKind {
+	if t == timeType {
+		return kindTime
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return kindText
+	case reflect.Bool:
+		return kindBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return kindInteger
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return kindUnsigned
+	case reflect.Float32, reflect.Float64:
+		return kindReal
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return kindBlob
+		}
+	}
+	return kindJSON
+}
+
+// sqliteColumn is one exported field of a row type.
+type sqliteColumn struct {
+	name  string
+	index int
+	typ   reflect.Type
+	kind  columnKind
+	// nullable columns hold a pointer to a scalar; nil is stored as NULL.
+	nullable bool
+	nonzero  bool
+}
+
+func (c sqliteColumn) definition() string {
+	var sqlType, zero string
+	switch c.kind {
+	case kindText:
+		sqlType, zero = "TEXT", "''"
+	case kindInteger, kindUnsigned, kindBool:
+		sqlType, zero = "INTEGER", "0"
+	case kindReal:
+		sqlType, zero = "REAL", "0"
+	case kindBlob:
+		sqlType, zero = "BLOB", "x''"
+	case kindTime:
+		sqlType, zero = "TEXT", "'"+time.Time{}.Format(sqliteTimeLayout)+"'"
+	case kindJSON:
+		sqlType, zero = "TEXT", "'null'"
+	}
+	if c.nullable {
+		return quoteIdent(c.name) + " " + sqlType
+	}
+	return quoteIdent(c.name) + " " + sqlType + " NOT NULL DEFAULT " + zero
+}
+
+// encode converts a field value to what its column stores.
+func (c sqliteColumn) encode(v reflect.Value) (any, error) {
+	if c.nullable {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	switch c.kind {
+	case kindText:
+		return v.String(), nil
+	case kindInteger:
+		return v.Int(), nil
+	case kindUnsigned:
+		return int64(v.Uint()), nil
+	case kindBool:
+		if v.Bool() {
+			return int64(1), nil
+		}
+		return int64(0), nil
+	case kindReal:
+		return v.Float(), nil
+	case kindBlob:
+		if v.Len() == 0 {
+			return []byte{}, nil
+		}
+		return v.Bytes(), nil
+	case kindTime:
+		return v.Interface().(time.Time).UTC().Format(sqliteTimeLayout), nil
+	}
+	buf, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("encode %s: %w", c.name, err)
+	}
+	return string(buf), nil
+}
+
+// decode sets dst from a value read from the column.
+func (c sqliteColumn) decode(src any, dst reflect.Value) error {
+	if c.nullable {
+		if src == nil {
+			dst.SetZero()
+			return nil
+		}
+		ptr := reflect.New(c.typ.Elem())
+		if err := c.decodeValue(src, ptr.Elem()); err != nil {
+			return err
+		}
+		dst.Set(ptr)
+		return nil
+	}
+	return c.decodeValue(src, dst)
+}
+
+func (c sqliteColumn) decodeValue(src any, dst reflect.Value) error {
+	switch c.kind {
+	case kindText:
+		s, err := textOf(src)
+		if err != nil {
+			return c.decodeError(err)
+		}
+		dst.SetString(s)
+	case kindInteger, kindUnsigned, kindBool:
+		n, ok := src.(int64)
+		if !ok {
+			return c.decodeError(fmt.Errorf("got %T, want integer", src))
+		}
+		switch c.kind {
+		case kindInteger:
+			dst.SetInt(n)
+		case kindUnsigned:
+			dst.SetUint(uint64(n))
+		default:
+			dst.SetBool(n != 0)
+		}
+	case kindReal:
+		switch f := src.(type) {
+		case float64:
+			dst.SetFloat(f)
+		case int64:
+			dst.SetFloat(float64(f))
+		default:
+			return c.decodeError(fmt.Errorf("got %T, want real", src))
+		}
+	case kindBlob:
+		b, ok := src.([]byte)
+		if !ok {
+			return c.decodeError(fmt.Errorf("got %T, want blob", src))
+		}
+		if len(b) == 0 {
+			dst.SetZero()
+		} else {
+			dst.SetBytes(bytes.Clone(b))
+		}
+	case kindTime:
+		s, err := textOf(src)
+		if err != nil {
+			return c.decodeError(err)
+		}
+		t, err := time.Parse(sqliteTimeLayout, s)
+		if err != nil {
+			return c.decodeError(err)
+		}
+		dst.Set(reflect.ValueOf(t))
+	case kindJSON:
+		s, err := textOf(src)
+		if err != nil {
+			return c.decodeError(err)
+		}
+		dst.SetZero()
+		if err := json.Unmarshal([]byte(s), dst.Addr().Interface()); err != nil {
+			return c.decodeError(err)
+		}
+	}
+	return nil
+}
+
+func (c sqliteColumn) decodeError(err error) error {
+	return fmt.Errorf("decode %s: %w", c.name, err)
+}
+
+func textOf(src any) (string, error) {
+	switch s := src.(type) {
+	case string:
+		return s, nil
+	case []byte:
+		return string(s), nil
+	}
+	return "", fmt.Errorf("got %T, want text", src)
+}
+
+// sqliteIndex is an index declared by a bstore:"index" or bstore:"unique"
+// field tag.
+type sqliteIndex struct {
+	name    string
+	unique  bool
+	columns []string
+}
+
+// sqliteTable maps one registered row type to its table. The first column is
+// the primary key; an integer key is assigned by SQLite when left zero.
+type sqliteTable struct {
+	name    string
+	typ     reflect.Type
+	columns []sqliteColumn
+	indexes []sqliteIndex
+	autoKey bool
+	// outdated says why a read-only backend cannot read the table: the
+	// database predates the row type and was never upgraded.
+	outdated string
+
+	selectSQL string
+	insertSQL string
+	// insertAutoSQL leaves the primary key out so SQLite assigns it.
+	insertAutoSQL string
+	updateSQL     string
+	getSQL        string
+	deleteSQL     string
+}
+
+func (t *sqliteTable) pk() sqliteColumn { return t.columns[0] }
+
+func (t *sqliteTable) column(name string) (sqliteColumn, bool) {
+	for _, c := range t.columns {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return sqliteColumn{}, false
+}
+
+// newSQLiteTable derives a table from a row type. Rows keep declaring their
+// indexes with bstore struct tags, so both backends read one schema.
+func newSQLiteTable(typ reflect.Type) (*sqliteTable, error) {
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("sqlite: register %s: row type must be a struct", typ)
+	}
+	t := &sqliteTable{name: typ.Name(), typ: typ}
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		tag := f.Tag.Get("bstore")
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+		c := sqliteColumn{name: f.Name, index: i, typ: f.Type, kind: columnKindOf(f.Type)}
+		if f.Type.Kind() == reflect.Pointer {
+			if elem := columnKindOf(f.Type.Elem()); elem != kindJSON {
+				c.kind, c.nullable = elem, true
+			}
+		}
+		if err := t.parseTag(&c, tag); err != nil {
+			return nil, fmt.Errorf("sqlite: register %s: field %s: %w", typ.Name(), f.Name, err)
+		}
+		t.columns = append(t.columns, c)
+	}
+	if len(t.columns) == 0 {
+		return nil, fmt.Errorf("sqlite: register %s: no exported fields", typ.Name())
+	}
+	switch pk := t.pk(); {
+	case pk.nullable:
+		return nil, fmt.Errorf("sqlite: register %s: primary key %s cannot be a pointer", typ.Name(), pk.name)
+	case pk.kind == kindInteger || pk.kind == kindUnsigned:
+		t.autoKey = true
+	case pk.kind != kindText:
+		return nil, fmt.Errorf("sqlite: register %s: primary key %s must be a string or an integer", typ.Name(), pk.name)
+	}
+	for _, index := range t.indexes {
+		for _, name := range index.columns {
+			c, ok := t.column(name)
+			if !ok {
+				return nil, fmt.Errorf("sqlite: register %s: index %s names unknown field %s", typ.Name(), index.name, name)
+			}
+			if c.kind == kindJSON {
+				return nil, fmt.Errorf("sqlite: register %s: index %s cannot include %s", typ.Name(), index.name, name)
+			}
+		}
+	}
+	t.prepareSQL()
+	return t, nil
+}
+
+// parseTag reads the bstore tag words this backend understands: index and
+// unique, optionally over several "+"-joined fields and with a name, and
+// nonzero.
+func (t *sqliteTable) parseTag(c *sqliteColumn, tag string) error {
+	if tag == "" {
+		return nil
+	}
+	for word := range strings.SplitSeq(tag, ",") {
+		parts := strings.Fields(word)
+		if len(parts) == 0 {
+			continue
+		}
+		switch parts[0] {
+		case "nonzero":
+			c.nonzero = true
+		case "index", "unique":
+			columns := []string{c.name}
+			if len(parts) > 1 {
+				columns = strings.Split(parts[1], "+")
+			}
+			name := t.name + "_" + strings.Join(columns, "_")
+			if len(parts) > 2 {
+				name = t.name + "_" + parts[2]
+			}
+			if len(parts) > 3 {
+				return fmt.Errorf("malformed tag %q", word)
+			}
+			t.indexes = append(t.indexes, sqliteIndex{name: name, unique: parts[0] == "unique", columns: columns})
+		default:
+			return fmt.Errorf("unsupported tag %q", word)
+		}
+	}
+	return nil
+}
+
+func (t *sqliteTable) prepareSQL() {
+	names := make([]string, len(t.columns))
+	params := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		names[i] = quoteIdent(c.name)
+		params[i] = "?"
+	}
+	table, pk := quoteIdent(t.name), quoteIdent(t.pk().name)
+	t.selectSQL = "SELECT " + strings.Join(names, ", ") + " FROM " + table
+	t.insertSQL = "INSERT INTO " + table + " (" + strings.Join(names, ", ") + ") VALUES (" + strings.Join(params, ", ") + ")"
+	if len(names) == 1 {
+		t.insertAutoSQL = "INSERT INTO " + table + " DEFAULT VALUES"
+	} else {
+		t.insertAutoSQL = "INSERT INTO " + table + " (" + strings.Join(names[1:], ", ") + ") VALUES (" + strings.Join(params[1:], ", ") + ")"
+	}
+	sets := make([]string, 0, len(names)-1)
+	for _, name := range names[1:] {
+		sets = append(sets, name+" = ?")
+	}
+	if len(sets) == 0 {
+		sets = append(sets, pk+" = "+pk)
+	}
+	t.updateSQL = "UPDATE " + table + " SET " + strings.Join(sets, ", ") + " WHERE " + pk + " = ?"
+	t.getSQL = t.selectSQL + " WHERE " + pk + " = ?"
+	t.deleteSQL = "DELETE FROM " + table + " WHERE " + pk + " = ?"
+}
+
+// createSQL creates the table when it is missing.
+func (t *sqliteTable) createSQL() string {
+	defs := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		defs[i] = c.definition()
+	}
+	pk := quoteIdent(t.pk().name)
+	if t.autoKey {
+		defs[0] = pk + " INTEGER PRIMARY KEY AUTOINCREMENT"
+	} else {
+		defs[0] = pk + " TEXT NOT NULL PRIMARY KEY"
+	}
+	return "CREATE TABLE IF NOT EXISTS " + quoteIdent(t.name) + " (\n\t" + strings.Join(defs, ",\n\t") + "\n)"
+}
+
+func (i sqliteIndex) createSQL(table string) string {
+	columns := make([]string, len(i.columns))
+	for n, c := range i.columns {
+		columns[n] = quoteIdent(c)
+	}
+	kind := "INDEX"
+	if i.unique {
+		kind = "UNIQUE INDEX"
+	}
+	return "CREATE " + kind + " IF NOT EXISTS " + quoteIdent(i.name) + " ON " + quoteIdent(table) + " (" + strings.Join(columns, ", ") + ")"
+}
+
+// values encodes every column of the row v points at, in column order.
+func (t *sqliteTable) values(v reflect.Value) ([]any, error) {
+	out := make([]any, len(t.columns))
+	for i, c := range t.columns {
+		field := v.Field(c.index)
+		if c.nonzero && field.IsZero() {
+			return nil, fmt.Errorf("%w: %s.%s", ErrZero, t.name, c.name)
+		}
+		value, err := c.encode(field)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: %s: %w", t.name, err)
+		}
+		out[i] = value
+	}
+	return out, nil
+}
+
+// scan decodes one result row selected with selectSQL into dst.
+func (t *sqliteTable) scan(scanner interface{ Scan(...any) error }, dst reflect.Value) error {
+	raw := make([]any, len(t.columns))
+	ptrs := make([]any, len(t.columns))
+	for i := range raw {
+		ptrs[i] = &raw[i]
+	}
+	if err := scanner.Scan(ptrs...); err != nil {
+		return err
+	}
+	for i, c := range t.columns {
+		if err := c.decode(raw[i], dst.Field(c.index)); err != nil {
+			return fmt.Errorf("sqlite: %s: %w", t.name, err)
+		}
+	}
+	return nil
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -4,18 +4,77 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/seal"
 	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
 	"github.com/mjl-/bstore"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type Store struct {
-	db *bstore.DB
+	backend  backend
+	kind     Backend
+	path     string
+	readOnly bool
+	// snapshot is the directory holding the private copy a read-only bstore
+	// or sealed store reads; Close removes it.
+	snapshot string
+	// sealed marks a read-only store over a sealed database.
+	sealed bool
+	models [][]any
+
+	// key seals the database and its copies; nil leaves them plain.
+	key *seal.Key
+	// working is the private directory holding the unsealed copy of a sealed
+	// database, and lock keeps other processes from opening it meanwhile.
+	// claim marks working, or the snapshot of a sealed read-only store, as in
+	// use so stale cleanup passes it by.
+	working string
+	lock    *bstore.DB
+	claim   *bstore.DB
+	// resealMu serializes sealing the working copy. sealMu guards the rest:
+	// dirty marks commits not yet sealed, commits counts them, pending is the
+	// timer sealing the current batch, and sealErr is why the last attempt to
+	// seal failed.
+	resealMu sync.Mutex
+	sealMu   sync.Mutex
+	dirty    bool
+	commits  uint64
+	pending  *time.Timer
+	sealErr  error
+}
+
+// Option changes how Open opens a database.
+type Option func(*options)
+
+type options struct {
+	readOnly bool
+	key      *seal.Key
 }
 
-func Open(ctx context.Context, path string) (*Store, error) {
+// ReadOnly opens an existing database for reading alongside a writer in
+// another process. Write transactions fail with a FailedPrecondition error.
+// A bstore file admits one process at a time, so a read-only bstore store
+// reads a snapshot copy taken when it opens; a SQLite store reads the live
+// database.
+func ReadOnly() Option {
+	return func(o *options) { o.readOnly = true }
+}
+
+// Open opens the database at path with the backend BackendFor chooses,
+// creating a missing database and its parent directory.
+func Open(ctx context.Context, path string, opts ...Option) (*Store, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.readOnly {
+		return openReadOnly(ctx, path, o.key)
+	}
+
 	dir := filepath.Dir(path)
 	if dir != "" && dir != "." {
 		if err := os.MkdirAll(dir, 0o755); err != nil {
@@ -23,62 +82,195 @@ func Open(ctx context.Context, path string) (*Store, error) {
 		}
 	}
 
-	db, err := bstore.Open(ctx, path, nil)
+	sealed, err := isSealed(path)
 	if err != nil {
 		return nil, err
 	}
+	if sealed {
+		return openSealed(ctx, path, o.key, false)
+	}
+	if o.key != nil {
+		if info, err := os.Stat(path); os.IsNotExist(err) || (err == nil && info.Size() == 0) {
+			return openSealed(ctx, path, o.key, true)
+		}
+	}
+
+	kind, err := BackendFor(path)
+	if err != nil {
+		return nil, err
+	}
+	b, err := openBackend(ctx, kind, path, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{backend: b, kind: kind, path: path, key: o.key}, nil
+}
+
+func openBackend(ctx context.Context, kind Backend, path string, readOnly bool) (backend, error) {
+	if kind == BackendSQLite {
+		return openSQLite(ctx, path, readOnly)
+	}
+	return openBstore(ctx, path)
+}
+
+// Path is the database file the store has open.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Backend names the storage engine holding the database.
+func (s *Store) Backend() Backend {
+	return s.kind
+}
+
+// ReadOnly reports whether the store was opened with ReadOnly.
+func (s *Store) ReadOnly() bool {
+	return s.readOnly
+}
 
-	return &Store{db: db}, nil
+// Snapshot reports whether the store reads a copy of its database taken at
+// open rather than the database itself, so it does not see later writes.
+func (s *Store) Snapshot() bool {
+	return s.snapshot != ""
 }
 
 // Register adds domain-owned persistence models to this store. Domain module
 // bootstrap calls it before the application begins serving operations.
 func (s *Store) Register(ctx context.Context, models ...any) {
-	if err := s.db.Register(ctx, models...); err != nil {
+	if err := s.backend.register(ctx, models...); err != nil {
 		panic(err)
 	}
+	s.sealMu.Lock()
+	s.models = append(s.models, models)
+	s.sealMu.Unlock()
+	// Registering may upgrade the schema of the working copy.
+	if s.working != "" {
+		s.markDirty()
+	}
 }
 
 func (s *Store) Close() error {
-	return s.db.Close()
+	if s.working != "" {
+		return s.closeSealed()
+	}
+	err := s.backend.close()
+	if s.claim != nil {
+		_ = s.claim.Close()
+	}
+	if s.snapshot != "" {
+		if rerr := os.RemoveAll(s.snapshot); err == nil {
+			err = rerr
+		}
+	}
+	return err
 }
 
-func (s *Store) Begin(ctx context.Context, writable bool) (*bstore.Tx, error) {
-	tx, err := s.db.Begin(ctx, writable)
-	if err == nil {
-		registerTransaction(tx)
+func (s *Store) Begin(ctx context.Context, writable bool) (*Tx, error) {
+	if writable && s.readOnly {
+		return nil, errors.FailedPreconditionf("database %s is open read-only", s.path)
 	}
-	return tx, err
+	b, err := s.backend.begin(ctx, writable)
+	if err != nil {
+		return nil, err
+	}
+	tx := &Tx{backend: b}
+	registerTransaction(tx).rollbackOnly = IsRollbackOnly(ctx)
+	return tx, nil
 }
 
 // Commit finalizes a transaction created by Begin and releases its
-// serialization state. Callers must use this method instead of Tx.Commit.
-func (s *Store) Commit(tx *bstore.Tx) error {
+// serialization state. Callers must use this method instead of committing
+// through the backend. A transaction begun under RollbackOnly rolls back
+// instead. On a sealed database the commit joins the batch sealed back over
+// the file shortly after, or at Flush or Close. A failure to seal does not
+// fail the committed transaction: the change stays in the working copy,
+// SealError reports why, and the next batch, Flush or Close seals it.
+func (s *Store) Commit(tx *Tx) error {
+	state := registerTransaction(tx)
 	defer unregisterTransaction(tx)
-	return tx.Commit()
+	if state.rollbackOnly {
+		return tx.backend.rollback()
+	}
+	if err := tx.backend.commit(); err != nil {
+		return err
+	}
+	if s.working != "" {
+		s.markDirty()
+	}
+	state.committed()
+	return nil
 }
 
 // Rollback finalizes a transaction created by Begin and releases its
-// serialization state. Callers must use this method instead of Tx.Rollback.
-func (s *Store) Rollback(tx *bstore.Tx) error {
+// serialization state.
+func (s *Store) Rollback(tx *Tx) error {
 	defer unregisterTransaction(tx)
-	return tx.Rollback()
+	return tx.backend.rollback()
 }
 
-func (s *Store) Read(ctx context.Context, fn func(*bstore.Tx) error) error {
+func (s *Store) Read(ctx context.Context, fn func(*Tx) error) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, telemetry.SpanStoreRead, transactionAttr("new"))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	start := time.Now()
-	err := s.db.Read(ctx, fn)
-	telemetry.FromContext(ctx).Histogram(telemetry.MetricStoreReadDuration).ObserveDuration(start)
+	err = s.run(ctx, false, fn)
+	telemetry.FromContext(ctx).Histogram(telemetry.MetricStoreReadDuration).ObserveContext(ctx, time.Since(start).Seconds())
 	return err
 }
 
-func (s *Store) Write(ctx context.Context, fn func(*bstore.Tx) error) error {
+func (s *Store) Write(ctx context.Context, fn func(*Tx) error) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, telemetry.SpanStoreWrite, transactionAttr("new"))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	start := time.Now()
-	err := s.db.Write(ctx, func(tx *bstore.Tx) error {
-		registerTransaction(tx)
-		defer unregisterTransaction(tx)
-		return fn(tx)
-	})
-	telemetry.FromContext(ctx).Histogram(telemetry.MetricStoreWriteDuration).ObserveDuration(start)
+	err = s.run(ctx, true, fn)
+	telemetry.FromContext(ctx).Histogram(telemetry.MetricStoreWriteDuration).ObserveContext(ctx, time.Since(start).Seconds())
 	return err
 }
+
+// run executes fn in a managed transaction. A read transaction always rolls
+// back; a write commits when fn succeeds unless ctx is RollbackOnly. A panic
+// in fn rolls back before it propagates.
+func (s *Store) run(ctx context.Context, writable bool, fn func(*Tx) error) error {
+	tx, err := s.Begin(ctx, writable)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			_ = s.Rollback(tx)
+			panic(r)
+		}
+	}()
+	if err := fn(tx); err != nil {
+		_ = s.Rollback(tx)
+		return err
+	}
+	if !writable {
+		return s.Rollback(tx)
+	}
+	return s.Commit(tx)
+}
+
+// transactionAttr tags a store span with whether it opened its own
+// transaction or joined the caller's.
+func transactionAttr(kind string) attribute.KeyValue {
+	return attribute.String(telemetry.AttrTransaction, kind)
+}
+
+type rollbackOnlyKey struct{}
+
+// RollbackOnly marks ctx so that transactions this package commits under it
+// roll back instead, whether through Write or through Begin and Commit.
+// After-commit callbacks never run for them. Dry runs use it to execute real
+// writes without keeping them.
+func RollbackOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, rollbackOnlyKey{}, true)
+}
+
+// IsRollbackOnly reports whether ctx was marked by RollbackOnly.
+func IsRollbackOnly(ctx context.Context) bool {
+	rollbackOnly, _ := ctx.Value(rollbackOnlyKey{}).(bool)
+	return rollbackOnly
+}
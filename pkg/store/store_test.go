@@ -6,7 +6,6 @@ import (
 	"testing"
 
 	testutil "github.com/TheFellow/go-modular-monolith/pkg/testutil/assert"
-	"github.com/mjl-/bstore"
 )
 
 type transactionLifecycleRecord struct {
@@ -17,44 +16,180 @@ type transactionLifecycleRecord struct {
 func TestCommitPersistsAndUnregistersCallerTransaction(t *testing.T) {
 	t.Parallel()
 
-	ctx := context.Background()
-	s, err := Open(ctx, filepath.Join(t.TempDir(), "store.db"))
-	testutil.ErrorIf(t, err != nil, "open store: %v", err)
-	t.Cleanup(func() {
+	forEachBackend(t, func(t *testing.T, file string) {
+		ctx := context.Background()
+		s, err := Open(ctx, filepath.Join(t.TempDir(), file))
+		testutil.ErrorIf(t, err != nil, "open store: %v", err)
+		t.Cleanup(func() {
+			{
+				err := s.Close()
+				testutil.ErrorIf(t, err != nil, "close store: %v", err)
+			}
+		})
+		s.Register(ctx, transactionLifecycleRecord{})
+		tx, err := s.Begin(ctx, true)
+		testutil.ErrorIf(t, err != nil, "begin transaction: %v", err)
+		{
+			_, ok := transactionLocks.Load(tx)
+			testutil.ErrorIf(t, !ok, "%v", "transaction lock was not registered")
+		}
 		{
-			err := s.Close()
-			testutil.ErrorIf(t, err != nil, "close store: %v", err)
+			err := tx.Insert(&transactionLifecycleRecord{Name: "committed"})
+			testutil.ErrorIf(t, err != nil, "insert record: %v", err)
 		}
+
+		{
+			err := s.Commit(tx)
+			testutil.ErrorIf(t, err != nil, "commit transaction: %v", err)
+		}
+		{
+			_, ok := transactionLocks.Load(tx)
+			testutil.ErrorIf(t, ok, "%v", "transaction lock remained registered after commit")
+		}
+
+		var records []transactionLifecycleRecord
+		{
+			err := s.Read(ctx, func(tx *Tx) error {
+				var err error
+				records, err = QueryTx[transactionLifecycleRecord](tx).List()
+				return err
+			})
+			testutil.ErrorIf(t, err != nil, "read records: %v", err)
+		}
+		testutil.ErrorIf(t, len(records) != 1 || records[0].Name != "committed", "records = %#v, want committed record", records)
 	})
-	s.Register(ctx, transactionLifecycleRecord{})
-	tx, err := s.Begin(ctx, true)
-	testutil.ErrorIf(t, err != nil, "begin transaction: %v", err)
-	{
-		_, ok := transactionLocks.Load(tx)
-		testutil.ErrorIf(t, !ok, "%v", "transaction lock was not registered")
-	}
-	{
-		err := tx.Insert(&transactionLifecycleRecord{Name: "committed"})
-		testutil.ErrorIf(t, err != nil, "insert record: %v", err)
-	}
+}
 
-	{
-		err := s.Commit(tx)
-		testutil.ErrorIf(t, err != nil, "commit transaction: %v", err)
-	}
-	{
-		_, ok := transactionLocks.Load(tx)
-		testutil.ErrorIf(t, ok, "%v", "transaction lock remained registered after commit")
-	}
+func TestCopyToWritesOpenableSnapshot(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, file string) {
+		ctx := context.Background()
+		dir := t.TempDir()
+		s, err := Open(ctx, filepath.Join(dir, file))
+		testutil.ErrorIf(t, err != nil, "open store: %v", err)
+		t.Cleanup(func() { _ = s.Close() })
+		s.Register(ctx, transactionLifecycleRecord{})
+		{
+			err := s.Write(ctx, func(tx *Tx) error {
+				return tx.Insert(&transactionLifecycleRecord{Name: "copied"})
+			})
+			testutil.ErrorIf(t, err != nil, "insert record: %v", err)
+		}
+
+		path := filepath.Join(dir, "copies", "copy"+filepath.Ext(file))
+		{
+			err := s.CopyTo(ctx, path)
+			testutil.ErrorIf(t, err != nil, "copy store: %v", err)
+		}
+
+		copied, err := Open(ctx, path)
+		testutil.ErrorIf(t, err != nil, "open copy: %v", err)
+		t.Cleanup(func() { _ = copied.Close() })
+		copied.Register(ctx, transactionLifecycleRecord{})
+		var records []transactionLifecycleRecord
+		{
+			err := copied.Read(ctx, func(tx *Tx) error {
+				var err error
+				records, err = QueryTx[transactionLifecycleRecord](tx).List()
+				return err
+			})
+			testutil.ErrorIf(t, err != nil, "read copy: %v", err)
+		}
+		testutil.ErrorIf(t, len(records) != 1 || records[0].Name != "copied", "records = %#v, want copied record", records)
+	})
+}
+
+func TestAfterCommitRunsOnlyForCommittedTransactions(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, file string) {
+		ctx := context.Background()
+		s, err := Open(ctx, filepath.Join(t.TempDir(), file))
+		testutil.ErrorIf(t, err != nil, "open store: %v", err)
+		t.Cleanup(func() { _ = s.Close() })
+		s.Register(ctx, transactionLifecycleRecord{})
+
+		var ran []string
+		{
+			err := s.Write(ctx, func(tx *Tx) error {
+				AfterCommit(tx, func() { ran = append(ran, "managed") })
+				testutil.ErrorIf(t, len(ran) != 0, "%v", "callback ran before commit")
+				return tx.Insert(&transactionLifecycleRecord{Name: "managed"})
+			})
+			testutil.ErrorIf(t, err != nil, "write: %v", err)
+		}
+		{
+			err := s.Write(ctx, func(tx *Tx) error {
+				AfterCommit(tx, func() { ran = append(ran, "failed") })
+				return ErrAbsent
+			})
+			testutil.ErrorIf(t, err == nil, "%v", "expected write to fail")
+		}
+		tx, err := s.Begin(ctx, true)
+		testutil.ErrorIf(t, err != nil, "begin: %v", err)
+		AfterCommit(tx, func() { ran = append(ran, "rolled back") })
+		testutil.ErrorIf(t, s.Rollback(tx) != nil, "%v", "rollback failed")
+		tx, err = s.Begin(ctx, true)
+		testutil.ErrorIf(t, err != nil, "begin: %v", err)
+		AfterCommit(tx, func() { ran = append(ran, "caller") })
+		testutil.ErrorIf(t, s.Commit(tx) != nil, "%v", "commit failed")
+
+		testutil.ErrorIf(t, len(ran) != 2 || ran[0] != "managed" || ran[1] != "caller", "ran = %v, want managed then caller", ran)
+	})
+}
+
+func TestRollbackOnlyDiscardsWrites(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, file string) {
+		ctx := context.Background()
+		s, err := Open(ctx, filepath.Join(t.TempDir(), file))
+		testutil.ErrorIf(t, err != nil, "open store: %v", err)
+		t.Cleanup(func() { _ = s.Close() })
+		s.Register(ctx, transactionLifecycleRecord{})
+
+		dryCtx := RollbackOnly(ctx)
+		ran := false
+		{
+			err := s.Write(dryCtx, func(tx *Tx) error {
+				AfterCommit(tx, func() { ran = true })
+				return tx.Insert(&transactionLifecycleRecord{Name: "managed"})
+			})
+			testutil.ErrorIf(t, err != nil, "write: %v", err)
+		}
+		tx, err := s.Begin(dryCtx, true)
+		testutil.ErrorIf(t, err != nil, "begin: %v", err)
+		{
+			err := tx.Insert(&transactionLifecycleRecord{Name: "caller"})
+			testutil.ErrorIf(t, err != nil, "insert record: %v", err)
+		}
+		AfterCommit(tx, func() { ran = true })
+		testutil.ErrorIf(t, s.Commit(tx) != nil, "%v", "commit failed")
+		_, registered := transactionLocks.Load(tx)
+		testutil.ErrorIf(t, registered, "%v", "transaction lock was not released")
+
+		var count int
+		{
+			err := s.Read(ctx, func(tx *Tx) error {
+				count, err = QueryTx[transactionLifecycleRecord](tx).Count()
+				return err
+			})
+			testutil.ErrorIf(t, err != nil, "read: %v", err)
+		}
+		testutil.ErrorIf(t, count != 0, "records = %d, want none kept", count)
+		testutil.ErrorIf(t, ran, "%v", "after-commit callback ran for a rolled back transaction")
+	})
+}
 
-	var records []transactionLifecycleRecord
-	{
-		err := s.Read(ctx, func(tx *bstore.Tx) error {
-			var err error
-			records, err = bstore.QueryTx[transactionLifecycleRecord](tx).List()
-			return err
+// forEachBackend runs fn against a database of each backend, named by the
+// file extension that selects it.
+func forEachBackend(t *testing.T, fn func(t *testing.T, file string)) {
+	t.Helper()
+	for _, file := range []string{"store.db", "store.sqlite"} {
+		t.Run(string(backendForName(file)), func(t *testing.T) {
+			t.Parallel()
+			fn(t, file)
 		})
-		testutil.ErrorIf(t, err != nil, "read records: %v", err)
 	}
-	testutil.ErrorIf(t, len(records) != 1 || records[0].Name != "committed", "records = %#v, want committed record", records)
 }
@@ -0,0 +1,59 @@
+package store
+
+// Tx is one transaction on a Store, whichever backend holds the data. Rows
+// are the DAO-owned structs registered with Store.Register; each method takes
+// a pointer to one and treats its first field as the primary key.
+type Tx struct {
+	backend txBackend
+}
+
+// Get loads each value by the primary key it holds.
+//
+// ErrAbsent is returned if a record does not exist.
+func (tx *Tx) Get(values ...any) error {
+	for _, value := range values {
+		if err := tx.backend.get(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Insert adds each value as a new record. A zero integer primary key is
+// assigned the next value of the type's sequence and written back.
+//
+// ErrUnique is returned if the primary key or a unique index is taken.
+// ErrZero is returned for a zero primary key that cannot be assigned.
+func (tx *Tx) Insert(values ...any) error {
+	for _, value := range values {
+		if err := tx.backend.insert(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Update replaces each value's existing record.
+//
+// ErrAbsent is returned if a record does not exist.
+// ErrUnique is returned if the new values collide with a unique index.
+func (tx *Tx) Update(values ...any) error {
+	for _, value := range values {
+		if err := tx.backend.update(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete removes each value's record by primary key.
+//
+// ErrAbsent is returned if a record does not exist.
+func (tx *Tx) Delete(values ...any) error {
+	for _, value := range values {
+		if err := tx.backend.delete(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package store
+
+import "github.com/TheFellow/go-modular-monolith/pkg/errors"
+
+// CheckVersion guards an optimistic write. Rows carry a version that every
+// write advances; a write prepared from version expected may replace the row
+// only while it is still at that version. format names the entity in the
+// Conflict returned otherwise.
+func CheckVersion(current, expected int64, format string, args ...any) error {
+	if current == expected {
+		return nil
+	}
+	return errors.Conflictf(format+" changed since version %d (now version %d); reload and retry", append(args, expected, current)...)
+}
@@ -1,6 +1,7 @@
 package telemetry
 
 import (
+	"context"
 	"strings"
 	"sync"
 	"time"
@@ -83,6 +84,16 @@ func (m *MemoryMetrics) HistogramCount(name string, labelValues ...string) int {
 	return h.count(labelValues...)
 }
 
+func (m *MemoryMetrics) GaugeValue(name string, labelValues ...string) float64 {
+	m.mu.RLock()
+	g := m.gauges[name]
+	m.mu.RUnlock()
+	if g == nil {
+		return 0
+	}
+	return g.get(labelValues...)
+}
+
 type memoryCounter struct {
 	mu     sync.RWMutex
 	values map[string]float64
@@ -96,6 +107,10 @@ func (c *memoryCounter) Add(value float64, labelValues ...string) {
 	c.values[labelKey(labelValues)] += value
 }
 
+func (c *memoryCounter) AddContext(_ context.Context, value float64, labelValues ...string) {
+	c.Add(value, labelValues...)
+}
+
 func (c *memoryCounter) get(labelValues ...string) float64 {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -118,6 +133,10 @@ func (h *memoryHistogram) ObserveDuration(start time.Time, labelValues ...string
 	h.Observe(time.Since(start).Seconds(), labelValues...)
 }
 
+func (h *memoryHistogram) ObserveContext(_ context.Context, value float64, labelValues ...string) {
+	h.Observe(value, labelValues...)
+}
+
 func (h *memoryHistogram) count(labelValues ...string) int {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
@@ -147,6 +166,12 @@ func (g *memoryGauge) Dec(labelValues ...string) {
 	g.values[labelKey(labelValues)]--
 }
 
+func (g *memoryGauge) get(labelValues ...string) float64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.values[labelKey(labelValues)]
+}
+
 func labelKey(labelValues []string) string {
 	return strings.Join(labelValues, "|")
 }
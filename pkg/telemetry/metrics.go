@@ -1,6 +1,9 @@
 package telemetry
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 type Metrics interface {
 	Counter(name string, labels ...string) Counter
@@ -8,14 +11,19 @@ type Metrics interface {
 	Gauge(name string, labels ...string) Gauge
 }
 
+// Counter and Histogram take an optional caller context through their
+// *Context methods. Backends that support exemplars use the span carried by
+// that context to link the measurement to its trace.
 type Counter interface {
 	Inc(labelValues ...string)
 	Add(value float64, labelValues ...string)
+	AddContext(ctx context.Context, value float64, labelValues ...string)
 }
 
 type Histogram interface {
 	Observe(value float64, labelValues ...string)
 	ObserveDuration(start time.Time, labelValues ...string)
+	ObserveContext(ctx context.Context, value float64, labelValues ...string)
 }
 
 type Gauge interface {
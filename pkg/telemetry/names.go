@@ -19,6 +19,15 @@ const (
 
 	MetricStoreReadDuration  = "mixology_store_read_duration_seconds"
 	MetricStoreWriteDuration = "mixology_store_write_duration_seconds"
+
+	MetricInventoryOnHand    = "mixology_inventory_on_hand"
+	MetricInventoryReserved  = "mixology_inventory_reserved"
+	MetricInventoryAvailable = "mixology_inventory_available"
+
+	MetricOrdersOpen               = "mixology_orders_open"
+	MetricOrderFulfillmentDuration = "mixology_order_fulfillment_duration_seconds"
+
+	MetricMenusWithFindings = "mixology_menus_with_findings"
 )
 
 const (
@@ -27,4 +36,68 @@ const (
 	LabelEventType = "event_type"
 	LabelResult    = "result"
 	LabelDecision  = "decision"
+	LabelCategory  = "category"
+	LabelUnit      = "unit"
+	LabelStatus    = "status"
+	LabelSeverity  = "severity"
+)
+
+// descriptions document each application-owned instrument. Backends that
+// publish help text, such as the Prometheus endpoint, read them through
+// Description.
+var descriptions = map[string]string{
+	MetricCommandTotal:    "Commands executed, by action and result.",
+	MetricCommandDuration: "Command latency in seconds, by action.",
+	MetricCommandErrors:   "Commands that failed, by action.",
+
+	MetricQueryTotal:    "Queries executed, by action and result.",
+	MetricQueryDuration: "Query latency in seconds, by action.",
+	MetricQueryErrors:   "Queries that failed, by action.",
+
+	MetricAuthZTotal:   "Cedar authorization decisions, by action and decision.",
+	MetricAuthZDenied:  "Cedar authorization denials, by action.",
+	MetricAuthZLatency: "Cedar authorization latency in seconds, by action.",
+
+	MetricEventsDispatched: "Domain events dispatched to handlers, by event type.",
+	MetricEventsDuration:   "Event dispatch latency in seconds, by event type.",
+	MetricEventsErrors:     "Event dispatches that failed, by event type.",
+
+	MetricStoreReadDuration:  "Duration in seconds of managed store read transactions.",
+	MetricStoreWriteDuration: "Duration in seconds of managed store write transactions.",
+
+	MetricInventoryOnHand:    "Stock on hand, summed by ingredient category and unit.",
+	MetricInventoryReserved:  "Stock reserved by open orders, summed by ingredient category and unit.",
+	MetricInventoryAvailable: "Stock on hand less reservations, summed by ingredient category and unit.",
+
+	MetricOrdersOpen:               "Orders not yet completed or cancelled, by status (pending or blocked).",
+	MetricOrderFulfillmentDuration: "Seconds from order placement to completion.",
+
+	MetricMenusWithFindings: "Draft and published menus with at least one readiness finding, by severity.",
+}
+
+// Description returns the help text for an application-owned instrument, or
+// an empty string for a name this package does not declare.
+func Description(name string) string {
+	return descriptions[name]
+}
+
+const (
+	SpanAuthorize  = "authz.authorize"
+	SpanStoreRead  = "store.read"
+	SpanStoreWrite = "store.write"
+
+	SpanMetricsCollect = "metrics.collect"
+)
+
+const (
+	AttrOperation     = "mixology.operation"
+	AttrAction        = "mixology.action"
+	AttrPrincipalType = "mixology.principal.type"
+	AttrOutcome       = "mixology.outcome"
+	AttrResourceType  = "mixology.resource.type"
+	AttrDecision      = "mixology.decision"
+	AttrHandler       = "mixology.handler"
+	AttrEventType     = "mixology.event.type"
+	AttrTransaction   = "mixology.store.transaction"
+	AttrCorrelationID = "mixology.correlation_id"
 )
@@ -1,6 +1,9 @@
 package telemetry
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 func Nop() Metrics {
 	return nopMetrics{}
@@ -14,14 +17,16 @@ func (nopMetrics) Gauge(string, ...string) Gauge         { return nopGauge{} }
 
 type nopCounter struct{}
 
-func (nopCounter) Inc(...string)          {}
-func (nopCounter) Add(float64, ...string) {}
+func (nopCounter) Inc(...string)                                  {}
+func (nopCounter) Add(float64, ...string)                         {}
+func (nopCounter) AddContext(context.Context, float64, ...string) {}
 
 type nopHistogram struct{}
 
 func (nopHistogram) Observe(float64, ...string) {}
 func (nopHistogram) ObserveDuration(time.Time, ...string) {
 }
+func (nopHistogram) ObserveContext(context.Context, float64, ...string) {}
 
 type nopGauge struct{}
 
@@ -44,7 +44,7 @@ func (m *otelMetrics) Counter(name string, labels ...string) Counter {
 		return c
 	}
 
-	inst, err := m.meter.Float64Counter(name)
+	inst, err := m.meter.Float64Counter(name, metric.WithDescription(Description(name)))
 	if err != nil {
 		return nopCounter{}
 	}
@@ -69,7 +69,7 @@ func (m *otelMetrics) Histogram(name string, labels ...string) Histogram {
 		return h
 	}
 
-	inst, err := m.meter.Float64Histogram(name)
+	inst, err := m.meter.Float64Histogram(name, metric.WithDescription(Description(name)))
 	if err != nil {
 		return nopHistogram{}
 	}
@@ -94,7 +94,7 @@ func (m *otelMetrics) Gauge(name string, labels ...string) Gauge {
 		return g
 	}
 
-	inst, err := m.meter.Float64UpDownCounter(name)
+	inst, err := m.meter.Float64UpDownCounter(name, metric.WithDescription(Description(name)))
 	if err != nil {
 		return nopGauge{}
 	}
@@ -117,7 +117,11 @@ func (c *otelCounter) Inc(labelValues ...string) {
 }
 
 func (c *otelCounter) Add(value float64, labelValues ...string) {
-	c.c.Add(context.Background(), value, metric.WithAttributes(toAttrs(c.labelKeys, labelValues)...))
+	c.AddContext(context.Background(), value, labelValues...)
+}
+
+func (c *otelCounter) AddContext(ctx context.Context, value float64, labelValues ...string) {
+	c.c.Add(ctx, value, metric.WithAttributes(toAttrs(c.labelKeys, labelValues)...))
 }
 
 type otelHistogram struct {
@@ -126,7 +130,11 @@ type otelHistogram struct {
 }
 
 func (h *otelHistogram) Observe(value float64, labelValues ...string) {
-	h.h.Record(context.Background(), value, metric.WithAttributes(toAttrs(h.labelKeys, labelValues)...))
+	h.ObserveContext(context.Background(), value, labelValues...)
+}
+
+func (h *otelHistogram) ObserveContext(ctx context.Context, value float64, labelValues ...string) {
+	h.h.Record(ctx, value, metric.WithAttributes(toAttrs(h.labelKeys, labelValues)...))
 }
 
 func (h *otelHistogram) ObserveDuration(start time.Time, labelValues ...string) {
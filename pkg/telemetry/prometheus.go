@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	promclient "github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/prometheus"
@@ -32,8 +33,13 @@ func NewPrometheus() (*PrometheusServer, error) {
 	otel.SetMeterProvider(provider)
 
 	return &PrometheusServer{
-		Metrics:  OTEL(provider.Meter("mixology")),
-		Handler:  promhttp.Handler(),
+		Metrics: OTEL(provider.Meter("mixology")),
+		Handler: promhttp.InstrumentMetricHandler(promclient.DefaultRegisterer,
+			promhttp.HandlerFor(promclient.DefaultGatherer, promhttp.HandlerOpts{
+				// Exemplars, which link histogram buckets and counters to
+				// traces, are only exposed in the OpenMetrics format.
+				EnableOpenMetrics: true,
+			})),
 		Shutdown: provider.Shutdown,
 	}, nil
 }
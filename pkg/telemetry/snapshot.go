@@ -0,0 +1,68 @@
+package telemetry
+
+import "sync"
+
+// GaugeSnapshot publishes complete readings of a labeled gauge whose label
+// sets come and go, such as stock summed per category. Add accumulates the
+// values of one reading; Flush sets every accumulated label set and resets to
+// zero any label set the previous reading reported but this one did not, so a
+// series never keeps a stale value.
+type GaugeSnapshot struct {
+	gauge Gauge
+
+	mu       sync.Mutex
+	pending  map[string]snapshotSample
+	reported map[string][]string
+}
+
+type snapshotSample struct {
+	labelValues []string
+	value       float64
+}
+
+func NewGaugeSnapshot(gauge Gauge) *GaugeSnapshot {
+	return &GaugeSnapshot{
+		gauge:    gauge,
+		pending:  make(map[string]snapshotSample),
+		reported: make(map[string][]string),
+	}
+}
+
+// Add adds value to the label set's total in the reading being built.
+func (s *GaugeSnapshot) Add(value float64, labelValues ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := labelKey(labelValues)
+	sample, ok := s.pending[key]
+	if !ok {
+		sample.labelValues = append([]string(nil), labelValues...)
+	}
+	sample.value += value
+	s.pending[key] = sample
+}
+
+// Flush publishes the reading built since the last Flush and starts a new one.
+func (s *GaugeSnapshot) Flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, labelValues := range s.reported {
+		if _, ok := s.pending[key]; !ok {
+			s.gauge.Set(0, labelValues...)
+		}
+	}
+	reported := make(map[string][]string, len(s.pending))
+	for key, sample := range s.pending {
+		s.gauge.Set(sample.value, sample.labelValues...)
+		reported[key] = sample.labelValues
+	}
+	s.reported = reported
+	s.pending = make(map[string]snapshotSample)
+}
+
+// Discard drops the reading being built, leaving the published values as
+// they were. Collectors call it when a reading fails partway.
+func (s *GaugeSnapshot) Discard() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending = make(map[string]snapshotSample)
+}
@@ -0,0 +1,29 @@
+package telemetry_test
+
+import (
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+)
+
+func TestGaugeSnapshot_FlushZeroesLabelSetsMissingFromTheReading(t *testing.T) {
+	t.Parallel()
+
+	metrics := telemetry.Memory()
+	snapshot := telemetry.NewGaugeSnapshot(metrics.Gauge("stock", "category"))
+
+	snapshot.Add(2, "spirit")
+	snapshot.Add(3, "spirit")
+	snapshot.Add(1, "mixer")
+	snapshot.Flush()
+	testutil.Equals(t, metrics.GaugeValue("stock", "spirit"), 5.0)
+	testutil.Equals(t, metrics.GaugeValue("stock", "mixer"), 1.0)
+
+	snapshot.Add(4, "spirit")
+	snapshot.Discard()
+	snapshot.Add(7, "spirit")
+	snapshot.Flush()
+	testutil.Equals(t, metrics.GaugeValue("stock", "spirit"), 7.0)
+	testutil.Equals(t, metrics.GaugeValue("stock", "mixer"), 0.0)
+}
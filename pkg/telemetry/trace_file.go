@@ -0,0 +1,284 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceFile is a tracer provider that appends its finished spans to a local
+// file, one OTLP JSON export request per line. The OpenTelemetry Collector's
+// otlpjsonfile receiver and most trace viewers read the format directly.
+type TraceFile struct {
+	Provider trace.TracerProvider
+	Shutdown func(context.Context) error
+}
+
+// NewTraceFile opens path for appending and returns a provider exporting to
+// it. Shutdown flushes buffered spans and closes the file.
+func NewTraceFile(path string) (*TraceFile, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, errors.Internalf("open trace file: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(&otlpFileExporter{file: f}),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "mixology"))),
+	)
+	return &TraceFile{Provider: provider, Shutdown: provider.Shutdown}, nil
+}
+
+type otlpFileExporter struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func (e *otlpFileExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	line, err := json.Marshal(otlpTraces(spans))
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.file == nil {
+		return errors.FailedPreconditionf("trace file is closed")
+	}
+	_, err = e.file.Write(append(line, '\n'))
+	return err
+}
+
+func (e *otlpFileExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.file == nil {
+		return nil
+	}
+	err := e.file.Close()
+	e.file = nil
+	return err
+}
+
+// The types below are the OTLP/JSON encoding of an ExportTraceServiceRequest:
+// IDs are lowercase hex, 64-bit integers are decimal strings, and enums are
+// numbers.
+type otlpTraceRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	SchemaURL  string           `json:"schemaUrl,omitempty"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpScopeSpans struct {
+	Scope     otlpScope  `json:"scope"`
+	Spans     []otlpSpan `json:"spans"`
+	SchemaURL string     `json:"schemaUrl,omitempty"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID                string         `json:"traceId"`
+	SpanID                 string         `json:"spanId"`
+	TraceState             string         `json:"traceState,omitempty"`
+	ParentSpanID           string         `json:"parentSpanId,omitempty"`
+	Name                   string         `json:"name"`
+	Kind                   int            `json:"kind"`
+	StartTimeUnixNano      string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano        string         `json:"endTimeUnixNano"`
+	Attributes             []otlpKeyValue `json:"attributes,omitempty"`
+	DroppedAttributesCount int            `json:"droppedAttributesCount,omitempty"`
+	Events                 []otlpEvent    `json:"events,omitempty"`
+	DroppedEventsCount     int            `json:"droppedEventsCount,omitempty"`
+	Links                  []otlpLink     `json:"links,omitempty"`
+	DroppedLinksCount      int            `json:"droppedLinksCount,omitempty"`
+	Status                 otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpLink struct {
+	TraceID    string         `json:"traceId"`
+	SpanID     string         `json:"spanId"`
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string         `json:"stringValue,omitempty"`
+	BoolValue   *bool           `json:"boolValue,omitempty"`
+	IntValue    *string         `json:"intValue,omitempty"`
+	DoubleValue *float64        `json:"doubleValue,omitempty"`
+	ArrayValue  *otlpArrayValue `json:"arrayValue,omitempty"`
+}
+
+type otlpArrayValue struct {
+	Values []otlpValue `json:"values"`
+}
+
+// otlpTraces groups spans by resource and instrumentation scope, keeping the
+// order in which each group first appears.
+func otlpTraces(spans []sdktrace.ReadOnlySpan) otlpTraceRequest {
+	var req otlpTraceRequest
+	resources := make(map[attribute.Distinct]int)
+	scopes := make(map[attribute.Distinct]map[instrumentation.Scope]int)
+	for _, span := range spans {
+		res := span.Resource()
+		key := res.Equivalent()
+		ri, ok := resources[key]
+		if !ok {
+			ri = len(req.ResourceSpans)
+			resources[key] = ri
+			scopes[key] = make(map[instrumentation.Scope]int)
+			req.ResourceSpans = append(req.ResourceSpans, otlpResourceSpans{
+				Resource:  otlpResource{Attributes: otlpAttributes(res.Attributes())},
+				SchemaURL: res.SchemaURL(),
+			})
+		}
+		rs := &req.ResourceSpans[ri]
+		scope := span.InstrumentationScope()
+		si, ok := scopes[key][scope]
+		if !ok {
+			si = len(rs.ScopeSpans)
+			scopes[key][scope] = si
+			rs.ScopeSpans = append(rs.ScopeSpans, otlpScopeSpans{
+				Scope:     otlpScope{Name: scope.Name, Version: scope.Version},
+				SchemaURL: scope.SchemaURL,
+			})
+		}
+		rs.ScopeSpans[si].Spans = append(rs.ScopeSpans[si].Spans, otlpSpanFrom(span))
+	}
+	return req
+}
+
+func otlpSpanFrom(span sdktrace.ReadOnlySpan) otlpSpan {
+	sc := span.SpanContext()
+	out := otlpSpan{
+		TraceID:                sc.TraceID().String(),
+		SpanID:                 sc.SpanID().String(),
+		TraceState:             sc.TraceState().String(),
+		Name:                   span.Name(),
+		Kind:                   int(span.SpanKind()),
+		StartTimeUnixNano:      unixNano(span.StartTime().UnixNano()),
+		EndTimeUnixNano:        unixNano(span.EndTime().UnixNano()),
+		Attributes:             otlpAttributes(span.Attributes()),
+		DroppedAttributesCount: span.DroppedAttributes(),
+		DroppedEventsCount:     span.DroppedEvents(),
+		DroppedLinksCount:      span.DroppedLinks(),
+		Status:                 otlpStatusFrom(span.Status()),
+	}
+	if parent := span.Parent(); parent.HasSpanID() {
+		out.ParentSpanID = parent.SpanID().String()
+	}
+	for _, event := range span.Events() {
+		out.Events = append(out.Events, otlpEvent{
+			TimeUnixNano: unixNano(event.Time.UnixNano()),
+			Name:         event.Name,
+			Attributes:   otlpAttributes(event.Attributes),
+		})
+	}
+	for _, link := range span.Links() {
+		out.Links = append(out.Links, otlpLink{
+			TraceID:    link.SpanContext.TraceID().String(),
+			SpanID:     link.SpanContext.SpanID().String(),
+			Attributes: otlpAttributes(link.Attributes),
+		})
+	}
+	return out
+}
+
+// otlpStatusFrom maps the API status codes, which order Error before Ok, onto
+// OTLP's STATUS_CODE_OK (1) and STATUS_CODE_ERROR (2).
+func otlpStatusFrom(status sdktrace.Status) otlpStatus {
+	switch status.Code {
+	case codes.Ok:
+		return otlpStatus{Code: 1}
+	case codes.Error:
+		return otlpStatus{Code: 2, Message: status.Description}
+	default:
+		return otlpStatus{}
+	}
+}
+
+func otlpAttributes(attrs []attribute.KeyValue) []otlpKeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	out := make([]otlpKeyValue, 0, len(attrs))
+	for _, kv := range attrs {
+		out = append(out, otlpKeyValue{Key: string(kv.Key), Value: otlpValueFrom(kv.Value)})
+	}
+	return out
+}
+
+func otlpValueFrom(v attribute.Value) otlpValue {
+	switch v.Type() {
+	case attribute.BOOL:
+		b := v.AsBool()
+		return otlpValue{BoolValue: &b}
+	case attribute.INT64:
+		i := strconv.FormatInt(v.AsInt64(), 10)
+		return otlpValue{IntValue: &i}
+	case attribute.FLOAT64:
+		f := v.AsFloat64()
+		return otlpValue{DoubleValue: &f}
+	case attribute.BOOLSLICE:
+		return otlpArray(v.AsBoolSlice(), attribute.BoolValue)
+	case attribute.INT64SLICE:
+		return otlpArray(v.AsInt64Slice(), attribute.Int64Value)
+	case attribute.FLOAT64SLICE:
+		return otlpArray(v.AsFloat64Slice(), attribute.Float64Value)
+	case attribute.STRINGSLICE:
+		return otlpArray(v.AsStringSlice(), attribute.StringValue)
+	default:
+		s := v.Emit()
+		return otlpValue{StringValue: &s}
+	}
+}
+
+func otlpArray[T any](items []T, value func(T) attribute.Value) otlpValue {
+	values := make([]otlpValue, 0, len(items))
+	for _, item := range items {
+		values = append(values, otlpValueFrom(value(item)))
+	}
+	return otlpValue{ArrayValue: &otlpArrayValue{Values: values}}
+}
+
+func unixNano(ns int64) string {
+	return strconv.FormatInt(ns, 10)
+}
@@ -0,0 +1,81 @@
+package telemetry_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestTraceFile_WritesOTLPJSONLines(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	traces, err := telemetry.NewTraceFile(path)
+	testutil.Ok(t, err)
+
+	ctx := telemetry.WithTracing(context.Background(), traces.Provider)
+	ctx, parent := telemetry.StartSpan(ctx, "command Drink.create", attribute.String(telemetry.AttrOutcome, "error"))
+	_, child := telemetry.StartSpan(ctx, telemetry.SpanStoreWrite, attribute.Int("rows", 2))
+	telemetry.EndSpan(child, errors.Conflictf("drink changed"))
+	telemetry.EndSpan(parent, nil)
+	testutil.Ok(t, traces.Shutdown(context.Background()))
+
+	data, err := os.ReadFile(path)
+	testutil.Ok(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	testutil.Equals(t, len(lines), 1)
+
+	var request struct {
+		ResourceSpans []struct {
+			Resource struct {
+				Attributes []otlpKeyValue `json:"attributes"`
+			} `json:"resource"`
+			ScopeSpans []struct {
+				Spans []struct {
+					TraceID      string         `json:"traceId"`
+					SpanID       string         `json:"spanId"`
+					ParentSpanID string         `json:"parentSpanId"`
+					Name         string         `json:"name"`
+					StartTime    string         `json:"startTimeUnixNano"`
+					Attributes   []otlpKeyValue `json:"attributes"`
+					Status       struct {
+						Code    int    `json:"code"`
+						Message string `json:"message"`
+					} `json:"status"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	testutil.Ok(t, json.Unmarshal([]byte(lines[0]), &request))
+	testutil.Equals(t, len(request.ResourceSpans), 1)
+	testutil.Equals(t, request.ResourceSpans[0].Resource.Attributes, []otlpKeyValue{
+		{Key: "service.name", Value: map[string]any{"stringValue": "mixology"}},
+	})
+
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	testutil.Equals(t, len(spans), 2)
+	store, command := spans[0], spans[1]
+	testutil.Equals(t, store.Name, telemetry.SpanStoreWrite)
+	testutil.Equals(t, store.TraceID, command.TraceID)
+	testutil.Equals(t, store.ParentSpanID, command.SpanID)
+	testutil.Equals(t, command.ParentSpanID, "")
+	testutil.Equals(t, len(store.TraceID), 32)
+	testutil.Equals(t, store.Status.Code, 2)
+	testutil.StringContains(t, store.Status.Message, "drink changed")
+	testutil.Equals(t, store.Attributes, []otlpKeyValue{{Key: "rows", Value: map[string]any{"intValue": "2"}}})
+	testutil.Equals(t, command.Status.Code, 0)
+	testutil.IsTrue(t, command.StartTime != "")
+}
+
+type otlpKeyValue struct {
+	Key   string         `json:"key"`
+	Value map[string]any `json:"value"`
+}
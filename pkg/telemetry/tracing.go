@@ -0,0 +1,59 @@
+package telemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// instrumentationName identifies the spans this application creates.
+const instrumentationName = "github.com/TheFellow/go-modular-monolith"
+
+type tracingKey struct{}
+
+// WithTracing returns ctx carrying provider. Spans started from the returned
+// context, and from contexts derived from it, are created by provider; a nil
+// provider disables tracing.
+func WithTracing(ctx context.Context, provider trace.TracerProvider) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if provider == nil {
+		provider = noop.NewTracerProvider()
+	}
+	return context.WithValue(ctx, tracingKey{}, provider)
+}
+
+// TracerProviderFromContext returns the provider installed by WithTracing, or
+// a no-op provider.
+func TracerProviderFromContext(ctx context.Context) trace.TracerProvider {
+	if ctx != nil {
+		if provider, ok := ctx.Value(tracingKey{}).(trace.TracerProvider); ok && provider != nil {
+			return provider
+		}
+	}
+	return noop.NewTracerProvider()
+}
+
+// StartSpan starts a span named name as a child of the span in ctx. Without a
+// provider in ctx it returns ctx unchanged and a span that records nothing, so
+// libraries can trace unconditionally.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	provider, ok := ctx.Value(tracingKey{}).(trace.TracerProvider)
+	if !ok || provider == nil {
+		return ctx, noop.Span{}
+	}
+	return provider.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan records err, if any, as the span's error status and ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
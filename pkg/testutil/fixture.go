@@ -4,21 +4,23 @@ import (
 	"context"
 	"io"
 	"log/slog"
-	"path/filepath"
 	"testing"
 
 	"github.com/TheFellow/go-modular-monolith/app"
 	"github.com/TheFellow/go-modular-monolith/app/domains/audit"
 	"github.com/TheFellow/go-modular-monolith/app/domains/drinks"
+	"github.com/TheFellow/go-modular-monolith/app/domains/eventlog"
 	"github.com/TheFellow/go-modular-monolith/app/domains/ingredients"
 	"github.com/TheFellow/go-modular-monolith/app/domains/inventory"
 	"github.com/TheFellow/go-modular-monolith/app/domains/menus"
 	"github.com/TheFellow/go-modular-monolith/app/domains/orders"
+	"github.com/TheFellow/go-modular-monolith/app/domains/outbox"
 	"github.com/TheFellow/go-modular-monolith/pkg/authn"
 	"github.com/TheFellow/go-modular-monolith/pkg/log"
 	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
 	"github.com/TheFellow/go-modular-monolith/pkg/store"
 	"github.com/TheFellow/go-modular-monolith/pkg/telemetry"
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil/teststore"
 )
 
 type Fixture struct {
@@ -29,10 +31,12 @@ type Fixture struct {
 
 	Audit       *audit.Module
 	Drinks      *drinks.Module
+	EventLog    *eventlog.Module
 	Ingredients *ingredients.Module
 	Inventory   *inventory.Module
 	Menus       *menus.Module
 	Orders      *orders.Module
+	Outbox      *outbox.Module
 
 	ownerCtx *middleware.Context
 	ctx      context.Context
@@ -42,7 +46,7 @@ type Fixture struct {
 func NewFixture(t testing.TB) *Fixture {
 	t.Helper()
 
-	path := filepath.Join(t.TempDir(), "mixology.test.db")
+	path := teststore.Path(t, "mixology.test")
 	metrics := telemetry.Memory()
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	ctx := log.ToContext(context.Background(), logger)
@@ -52,7 +56,8 @@ func NewFixture(t testing.TB) *Fixture {
 	ctx = authn.ToContext(ctx, p)
 	s, err := store.Open(ctx, path)
 	Ok(t, err)
-	application := app.New(ctx, app.Config{Store: s})
+	application, err := app.New(ctx, app.Config{Store: s})
+	Ok(t, err)
 	ownerCtx := middleware.NewContext(ctx)
 	a := app.NewSession(ownerCtx, application)
 
@@ -64,10 +69,12 @@ func NewFixture(t testing.TB) *Fixture {
 
 		Audit:       a.Audit,
 		Drinks:      a.Drinks,
+		EventLog:    a.EventLog,
 		Ingredients: a.Ingredients,
 		Inventory:   a.Inventory,
 		Menus:       a.Menus,
 		Orders:      a.Orders,
+		Outbox:      a.Outbox,
 
 		ownerCtx: ownerCtx,
 		ctx:      ctx,
@@ -0,0 +1,38 @@
+// Package teststore picks the storage backend for test databases, so the same
+// suites run against bstore and SQLite. It imports nothing from the
+// application and is safe to use from DAO package tests.
+package teststore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/store"
+)
+
+// Env names the environment variable that selects the backend. Set it to
+// "sqlite" to run tests against SQLite; bstore is used otherwise.
+const Env = "MIXOLOGY_TEST_STORE"
+
+// Backend is the storage backend Env selects.
+func Backend() store.Backend {
+	if os.Getenv(Env) == string(store.BackendSQLite) {
+		return store.BackendSQLite
+	}
+	return store.BackendBstore
+}
+
+// File names a database file called name for the selected backend.
+func File(name string) string {
+	if Backend() == store.BackendSQLite {
+		return name + ".sqlite"
+	}
+	return name + ".db"
+}
+
+// Path is File(name) in a new temporary directory of t.
+func Path(t testing.TB, name string) string {
+	t.Helper()
+	return filepath.Join(t.TempDir(), File(name))
+}
@@ -0,0 +1,21 @@
+package gui
+
+import "reflect"
+
+// MergeForm rebases an edited form onto latest after a version conflict. T
+// must be a struct. Each field still equal to base, the value the form was
+// opened with, takes the latest value; edited fields keep the user's input.
+func MergeForm[T any](base, edited, latest T) T {
+	merged := edited
+	b, e, l := reflect.ValueOf(base), reflect.ValueOf(edited), reflect.ValueOf(latest)
+	out := reflect.ValueOf(&merged).Elem()
+	for i := range out.NumField() {
+		if !out.Field(i).CanSet() {
+			continue
+		}
+		if reflect.DeepEqual(e.Field(i).Interface(), b.Field(i).Interface()) {
+			out.Field(i).Set(l.Field(i))
+		}
+	}
+	return merged
+}
@@ -0,0 +1,20 @@
+package gui_test
+
+import (
+	"testing"
+
+	"github.com/TheFellow/go-modular-monolith/pkg/testutil"
+	gui "github.com/TheFellow/go-modular-monolith/pkg/toolkits/gui"
+)
+
+func TestMergeFormKeepsEditsAndTakesLatestForUntouchedFields(t *testing.T) {
+	t.Parallel()
+	type form struct {
+		Name, Description string
+		Featured          bool
+	}
+	base := form{Name: "Gin", Description: "Dry"}
+	edited := form{Name: "London Gin", Description: "Dry"}
+	latest := form{Name: "Old Tom", Description: "Juniper forward", Featured: true}
+	testutil.Equals(t, gui.MergeForm(base, edited, latest), form{Name: "London Gin", Description: "Juniper forward", Featured: true})
+}
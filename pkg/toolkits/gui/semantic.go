@@ -33,3 +33,18 @@ func NewEntry(id string) *SemanticEntry {
 }
 
 func (e *SemanticEntry) SemanticID() string { return e.id }
+
+type SemanticCheck struct {
+	widget.Check
+	id string
+}
+
+func NewCheck(id, label string, changed func(bool)) *SemanticCheck {
+	check := &SemanticCheck{id: id}
+	check.Text = label
+	check.OnChanged = changed
+	check.ExtendBaseWidget(check)
+	return check
+}
+
+func (c *SemanticCheck) SemanticID() string { return c.id }
@@ -18,11 +18,14 @@ func TestSemanticDriverInteractsWithActualWidgets(t *testing.T) {
 	entry := gui.NewEntry("drink-name")
 	tapped := false
 	button := gui.NewButton("save-drink", "Save", func() { tapped = true })
-	driver := fynetest.NewDriver(t, container.NewVBox(entry, button))
+	checked := false
+	check := gui.NewCheck("show-deleted", "Show deleted", func(on bool) { checked = on })
+	driver := fynetest.NewDriver(t, container.NewVBox(entry, button, check))
 
 	driver.Type("drink-name", "Gimlet")
 	driver.Tap("save-drink")
-	testutil.ErrorIf(t, entry.Text != "Gimlet" || !tapped, "entry=%q tapped=%v", entry.Text, tapped)
+	driver.Tap("show-deleted")
+	testutil.ErrorIf(t, entry.Text != "Gimlet" || !tapped || !checked, "entry=%q tapped=%v checked=%v", entry.Text, tapped, checked)
 }
 
 func TestListDetailPreservesSuppliedObjectsAndRatio(t *testing.T) {
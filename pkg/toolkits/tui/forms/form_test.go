@@ -87,3 +87,32 @@ func TestFormUsesBrowseThenEditInteraction(t *testing.T) {
 	testutil.Equals(t, form.IsEditing(), false)
 	testutil.Equals(t, name.Value(), "Original accepted")
 }
+
+func TestRebaseKeepsEditsAndTakesUnchangedValues(t *testing.T) {
+	t.Parallel()
+
+	untouched := forms.NewTextField("Name", forms.WithInitialValue("Gin"))
+	kept, err := forms.Rebase(untouched, "Gin", "London Gin")
+	testutil.Ok(t, err)
+	testutil.IsFalse(t, kept)
+	testutil.Equals(t, untouched.Value(), "London Gin")
+
+	edited := forms.NewTextField("Name", forms.WithInitialValue("Gin"))
+	testutil.Ok(t, edited.SetValue("Old Tom Gin"))
+	kept, err = forms.Rebase(edited, "Gin", "London Gin")
+	testutil.Ok(t, err)
+	testutil.IsTrue(t, kept)
+	testutil.Equals(t, edited.Value(), "Old Tom Gin")
+
+	kept, err = forms.Rebase(edited, "Gin", "Gin")
+	testutil.Ok(t, err)
+	testutil.IsFalse(t, kept)
+	testutil.Equals(t, edited.Value(), "Old Tom Gin")
+
+	options := []forms.SelectOption{{Label: "a", Value: "a"}, {Label: "b", Value: "b"}}
+	same := forms.NewSelectField("Kind", options, forms.WithInitialValue("a"))
+	testutil.Ok(t, same.SetValue("b"))
+	kept, err = forms.Rebase(same, "a", "b")
+	testutil.Ok(t, err)
+	testutil.IsFalse(t, kept)
+}
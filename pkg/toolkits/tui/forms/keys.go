@@ -9,5 +9,6 @@ type FormKeys struct {
 	Edit      key.Binding
 	Accept    key.Binding
 	Submit    key.Binding
+	Preview   key.Binding
 	Cancel    key.Binding
 }
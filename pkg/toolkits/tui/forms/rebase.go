@@ -0,0 +1,15 @@
+package forms
+
+import "reflect"
+
+// Rebase moves field onto latest when its value still equals base, the value
+// the form was opened with, and otherwise keeps the user's edit. It reports
+// whether the kept edit overrides a different change made since base, so
+// callers can tell the user which of their edits now compete with another.
+func Rebase(field Field, base, latest any) (bool, error) {
+	current := field.Value()
+	if reflect.DeepEqual(current, base) {
+		return false, field.SetValue(latest)
+	}
+	return !reflect.DeepEqual(base, latest) && !reflect.DeepEqual(current, latest), nil
+}
@@ -13,6 +13,7 @@ const (
 	Right      = "right"
 	Edit       = "e"
 	Submit     = "ctrl+s"
+	Preview    = "ctrl+p"
 	InsertLine = "ctrl+j"
 	Clear      = "ctrl+u"
 	End        = "end"
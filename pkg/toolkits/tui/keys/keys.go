@@ -27,6 +27,7 @@ type KeyMap struct {
 	NextField key.Binding
 	PrevField key.Binding
 	Submit    key.Binding
+	Preview   key.Binding
 
 	// Dialog keys
 	Confirm   key.Binding
@@ -113,6 +114,10 @@ func newKeyMap() KeyMap {
 			key.WithKeys(keyname.Submit),
 			key.WithHelp(keyname.Submit, "submit"),
 		),
+		Preview: key.NewBinding(
+			key.WithKeys(keyname.Preview),
+			key.WithHelp(keyname.Preview, "preview"),
+		),
 		Confirm: key.NewBinding(
 			key.WithKeys(keyname.Enter),
 			key.WithHelp(keyname.Enter, "confirm"),
@@ -163,6 +168,7 @@ func formKeysFrom(k KeyMap) forms.FormKeys {
 		Edit:      k.Edit,
 		Accept:    k.Enter,
 		Submit:    k.Submit,
+		Preview:   k.Preview,
 		Cancel:    k.Back,
 	}
 }
@@ -30,3 +30,7 @@ type Interaction struct {
 	CapturesText bool
 	HandlesBack  bool
 }
+
+// RefreshMsg asks a view to reload its data because it may have changed
+// elsewhere. Views ignore it while the user is editing.
+type RefreshMsg struct{}
@@ -0,0 +1,82 @@
+// Package undo maps command actions to the compensating commands that reverse
+// them. Domains declare compensations for their own actions; the audit log
+// anchors which command is reversed, and the events that command recorded
+// describe what it did.
+package undo
+
+import (
+	"github.com/TheFellow/go-modular-monolith/pkg/errors"
+	"github.com/TheFellow/go-modular-monolith/pkg/middleware"
+	cedar "github.com/cedar-policy/cedar-go"
+)
+
+// Compensate reverses one command from the events it recorded and returns the
+// entities it changed. It runs the owning domain's ordinary, authorized
+// commands inside the undo's transaction, so the reversal is audited like any
+// other change.
+type Compensate func(ctx *middleware.Context, events []any) ([]cedar.EntityUID, error)
+
+// Compensation is the reversal of every command recorded under Action.
+type Compensation struct {
+	Action     cedar.EntityUID
+	Compensate Compensate
+}
+
+// Registry holds the compensations of every domain, keyed by the action
+// string audit entries record.
+type Registry struct {
+	compensations map[string]Compensation
+}
+
+// NewRegistry combines the compensations each domain declares. Duplicate and
+// incomplete compensations are programmer errors and panic during application
+// assembly.
+func NewRegistry(domains ...[]Compensation) *Registry {
+	r := &Registry{compensations: make(map[string]Compensation)}
+	for _, compensations := range domains {
+		for _, compensation := range compensations {
+			if compensation.Action.IsZero() || compensation.Compensate == nil {
+				panic("undo: incomplete compensation")
+			}
+			action := compensation.Action.String()
+			if _, ok := r.compensations[action]; ok {
+				panic("undo: duplicate compensation for " + action)
+			}
+			r.compensations[action] = compensation
+		}
+	}
+	return r
+}
+
+// Lookup returns the compensation for an audited action.
+func (r *Registry) Lookup(action string) (Compensation, error) {
+	if r != nil {
+		if compensation, ok := r.compensations[action]; ok {
+			return compensation, nil
+		}
+	}
+	return Compensation{}, errors.FailedPreconditionf("%s commands cannot be undone", action)
+}
+
+// Find returns the single recorded event of type T. Compensations use it to
+// read the facts their command recorded; a command recorded before those facts
+// existed, or one that changed nothing, cannot be reversed.
+func Find[T any](events []any) (T, error) {
+	var (
+		found T
+		count int
+	)
+	for _, event := range events {
+		if e, ok := event.(T); ok {
+			found = e
+			count++
+		}
+	}
+	switch count {
+	case 1:
+		return found, nil
+	case 0:
+		return found, errors.FailedPreconditionf("the command recorded no %T event to reverse", found)
+	}
+	return found, errors.FailedPreconditionf("the command recorded %d %T events; only one can be reversed", count, found)
+}